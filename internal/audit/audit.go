@@ -5,11 +5,13 @@ package audit
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"sync"
 	"time"
 
 	"go.uber.org/zap"
 
+	"github.com/tareqmamari/cloud-logs-mcp/internal/security"
 	"github.com/tareqmamari/cloud-logs-mcp/internal/tracing"
 )
 
@@ -19,6 +21,7 @@ type Entry struct {
 	TraceID     string                 `json:"trace_id"`
 	SpanID      string                 `json:"span_id,omitempty"`
 	Tool        string                 `json:"tool"`
+	Instance    string                 `json:"instance,omitempty"`
 	Operation   string                 `json:"operation"` // create, read, update, delete, query, etc.
 	Resource    string                 `json:"resource,omitempty"`
 	ResourceID  string                 `json:"resource_id,omitempty"`
@@ -36,6 +39,11 @@ type Logger struct {
 	enabled bool
 	logger  *zap.Logger
 
+	// Durable destination for entries, separate from the application log
+	sinkMu   sync.Mutex
+	sink     Sink
+	redactor *security.Redactor
+
 	// In-memory buffer for recent entries (for the audit tool)
 	mu         sync.RWMutex
 	entries    []Entry
@@ -47,6 +55,7 @@ func NewLogger(logger *zap.Logger, enabled bool) *Logger {
 	return &Logger{
 		enabled:    enabled,
 		logger:     logger.Named("audit"),
+		redactor:   security.NewRedactor(),
 		entries:    make([]Entry, 0, 1000),
 		maxEntries: 1000, // Keep last 1000 entries in memory
 	}
@@ -72,6 +81,19 @@ func (l *Logger) Log(ctx context.Context, entry Entry) {
 		entry.Timestamp = time.Now().UTC()
 	}
 
+	// Secrets never reach an audit record, whatever the response redaction setting
+	entry.ErrorMsg = l.redactor.Redact(entry.ErrorMsg)
+	if len(entry.Metadata) > 0 {
+		metadata := make(map[string]interface{}, len(entry.Metadata))
+		for k, v := range entry.Metadata {
+			if str, ok := v.(string); ok {
+				v = l.redactor.Redact(str)
+			}
+			metadata[k] = v
+		}
+		entry.Metadata = metadata
+	}
+
 	// Log to structured logger
 	fields := []zap.Field{
 		zap.Time("timestamp", entry.Timestamp),
@@ -85,6 +107,9 @@ func (l *Logger) Log(ctx context.Context, entry Entry) {
 	if entry.SpanID != "" {
 		fields = append(fields, zap.String("span_id", entry.SpanID))
 	}
+	if entry.Instance != "" {
+		fields = append(fields, zap.String("instance", entry.Instance))
+	}
 	if entry.Resource != "" {
 		fields = append(fields, zap.String("resource", entry.Resource))
 	}
@@ -103,6 +128,14 @@ func (l *Logger) Log(ctx context.Context, entry Entry) {
 
 	l.logger.Info("audit", fields...)
 
+	l.sinkMu.Lock()
+	if l.sink != nil {
+		if err := l.sink.Write(ctx, entry); err != nil {
+			l.logger.Error("Failed to write audit entry", zap.String("tool", entry.Tool), zap.Error(err))
+		}
+	}
+	l.sinkMu.Unlock()
+
 	// Store in memory buffer
 	l.mu.Lock()
 	defer l.mu.Unlock()
@@ -114,6 +147,24 @@ func (l *Logger) Log(ctx context.Context, entry Entry) {
 	l.entries = append(l.entries, entry)
 }
 
+// SetSink sets the destination audit entries are written to in addition to the
+// application log and the in-memory buffer; nil removes it
+func (l *Logger) SetSink(sink Sink) {
+	l.sinkMu.Lock()
+	defer l.sinkMu.Unlock()
+	l.sink = sink
+}
+
+// Close closes the sink if it holds resources such as an open file
+func (l *Logger) Close() error {
+	l.sinkMu.Lock()
+	defer l.sinkMu.Unlock()
+	if closer, ok := l.sink.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
 // LogToolExecution is a convenience method for logging tool executions
 func (l *Logger) LogToolExecution(ctx context.Context, toolName string, operation string, resource string, resourceID string, success bool, duration time.Duration, err error) {
 	entry := Entry{
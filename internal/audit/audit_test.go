@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -500,3 +503,92 @@ func TestConcurrentLogAndRead(t *testing.T) {
 		t.Errorf("expected %d entries, got %d", writers*entriesPerWriter, len(total))
 	}
 }
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit", "trail.jsonl")
+	sink, err := NewFileSink(path)
+	if err != nil {
+		t.Fatalf("NewFileSink failed: %v", err)
+	}
+	l := newTestLogger(true)
+	l.SetSink(sink)
+
+	l.Log(context.Background(), Entry{Tool: "create_alert", Operation: "create", ResourceID: "a1", Success: true})
+	l.Log(context.Background(), Entry{Tool: "update_alert", Operation: "update", ErrorMsg: `rejected "api_key": "abcdef123456"`})
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 JSON lines, got %d: %s", len(lines), data)
+	}
+	var first Entry
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("line is not JSON: %v", err)
+	}
+	if first.Tool != "create_alert" || first.ResourceID != "a1" || first.Timestamp.IsZero() {
+		t.Errorf("unexpected entry: %+v", first)
+	}
+	if strings.Contains(lines[1], "abcdef123456") {
+		t.Errorf("secret was not redacted: %s", lines[1])
+	}
+}
+
+type failingSink struct{}
+
+func (failingSink) Write(context.Context, Entry) error { return errors.New("disk full") }
+
+func TestLogSinkErrorKeepsEntry(t *testing.T) {
+	l := newTestLogger(true)
+	l.SetSink(failingSink{})
+	l.Log(context.Background(), Entry{Tool: "delete_alert"})
+	if got := l.GetRecentEntries(0); len(got) != 1 {
+		t.Errorf("expected the entry to stay in memory when the sink fails, got %d", len(got))
+	}
+}
+
+// slowSink blocks each write until release is closed
+type slowSink struct {
+	release chan struct{}
+	mu      sync.Mutex
+	written []Entry
+}
+
+func (s *slowSink) Write(_ context.Context, entry Entry) error {
+	<-s.release
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.written = append(s.written, entry)
+	return nil
+}
+
+func TestAsyncSink(t *testing.T) {
+	inner := &slowSink{release: make(chan struct{})}
+	sink := NewAsyncSink(inner, 1, zap.NewNop())
+	l := newTestLogger(true)
+	l.SetSink(sink)
+
+	done := make(chan struct{})
+	go func() {
+		l.Log(context.Background(), Entry{Tool: "delete_alert"})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Log blocked on a slow sink")
+	}
+
+	close(inner.release)
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if len(inner.written) != 1 || inner.written[0].Tool != "delete_alert" {
+		t.Errorf("expected the queued entry to be written on close, got %+v", inner.written)
+	}
+}
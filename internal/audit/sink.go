@@ -0,0 +1,117 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Sink is a durable destination for audit entries, kept apart from the application log
+type Sink interface {
+	Write(ctx context.Context, entry Entry) error
+}
+
+// FileSink appends audit entries to a file as JSON lines. The file is opened append-only,
+// so existing records are never rewritten.
+type FileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileSink opens (or creates) the audit file at path, creating its directory if needed
+func NewFileSink(path string) (*FileSink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600) // #nosec G304 -- path comes from operator configuration
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log file: %w", err)
+	}
+	return &FileSink{file: file}, nil
+}
+
+// Write appends one entry as a JSON line
+func (s *FileSink) Write(_ context.Context, entry Entry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.file.Write(append(line, '\n'))
+	return err
+}
+
+// Close closes the audit file
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// AsyncSinkDrainTimeout bounds how long closing an AsyncSink waits for queued entries
+const AsyncSinkDrainTimeout = 30 * time.Second
+
+// AsyncSink writes entries to another sink from a background goroutine, so slow destinations
+// (such as ingestion over the network) never delay the tool call being audited. Entries are
+// dropped, and reported as an error, when the queue is full.
+type AsyncSink struct {
+	sink      Sink
+	logger    *zap.Logger
+	queue     chan Entry
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewAsyncSink starts writing queued entries to sink; write failures are logged to logger
+func NewAsyncSink(sink Sink, queueSize int, logger *zap.Logger) *AsyncSink {
+	s := &AsyncSink{
+		sink:   sink,
+		logger: logger,
+		queue:  make(chan Entry, queueSize),
+		done:   make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+func (s *AsyncSink) run() {
+	defer close(s.done)
+	for entry := range s.queue {
+		if err := s.sink.Write(context.Background(), entry); err != nil {
+			s.logger.Error("Failed to write audit entry", zap.String("tool", entry.Tool), zap.Error(err))
+		}
+	}
+}
+
+// Write queues one entry without waiting for it to be written
+func (s *AsyncSink) Write(_ context.Context, entry Entry) error {
+	select {
+	case s.queue <- entry:
+		return nil
+	default:
+		return fmt.Errorf("audit queue is full (%d entries), entry dropped", cap(s.queue))
+	}
+}
+
+// Close writes the queued entries, waiting at most AsyncSinkDrainTimeout, then closes the
+// wrapped sink
+func (s *AsyncSink) Close() error {
+	s.closeOnce.Do(func() { close(s.queue) })
+	select {
+	case <-s.done:
+	case <-time.After(AsyncSinkDrainTimeout):
+		return fmt.Errorf("timed out writing %d queued audit entries", len(s.queue))
+	}
+	if closer, ok := s.sink.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
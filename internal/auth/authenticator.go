@@ -31,23 +31,47 @@ type Authenticator struct {
 	logger        *zap.Logger
 }
 
+// Options configures an Authenticator. At least one of APIKey and IAMToken is required.
+type Options struct {
+	APIKey   string // IBM Cloud API key, exchanged for IAM tokens automatically
+	IAMToken string // Pre-issued IAM access token; refreshed with APIKey near expiry when both are set
+	IAMURL   string // Optional custom IAM endpoint
+
+	// HTTPClient is used for IAM token requests (proxy and CA settings); nil uses the SDK default
+	HTTPClient *http.Client
+}
+
 // New creates a new authenticator using IBM SDK
 func New(apiKey string, iamURL string, logger *zap.Logger) (*Authenticator, error) {
-	if apiKey == "" {
-		return nil, fmt.Errorf("API key is required")
+	return NewWithOptions(Options{APIKey: apiKey, IAMURL: iamURL}, logger)
+}
+
+// NewWithOptions creates an authenticator using an API key, an IAM access token, or both
+func NewWithOptions(opts Options, logger *zap.Logger) (*Authenticator, error) {
+	if opts.APIKey == "" && opts.IAMToken == "" {
+		return nil, fmt.Errorf("API key or IAM token is required")
 	}
 
-	// Create IBM Cloud IAM authenticator
-	authenticator := &core.IamAuthenticator{
-		ApiKey: apiKey, // pragma: allowlist secret
+	var iamAuth *core.IamAuthenticator
+	if opts.APIKey != "" {
+		// Create IBM Cloud IAM authenticator
+		iamAuth = &core.IamAuthenticator{
+			ApiKey: opts.APIKey, // pragma: allowlist secret
+			Client: opts.HTTPClient,
+		}
+
+		// Set custom IAM URL if provided (for staging/dev environments)
+		// Production uses default: https://iam.cloud.ibm.com
+		// Staging uses: https://iam.test.cloud.ibm.com
+		if opts.IAMURL != "" {
+			iamAuth.URL = opts.IAMURL
+			logger.Info("Using custom IAM endpoint", zap.String("iam_url", opts.IAMURL))
+		}
 	}
 
-	// Set custom IAM URL if provided (for staging/dev environments)
-	// Production uses default: https://iam.cloud.ibm.com
-	// Staging uses: https://iam.test.cloud.ibm.com
-	if iamURL != "" {
-		authenticator.URL = iamURL
-		logger.Info("Using custom IAM endpoint", zap.String("iam_url", iamURL))
+	var authenticator core.Authenticator = iamAuth
+	if opts.IAMToken != "" {
+		authenticator = newTokenAuthenticator(opts.IAMToken, iamAuth, logger)
 	}
 
 	// Validate the authenticator
@@ -55,7 +79,10 @@ func New(apiKey string, iamURL string, logger *zap.Logger) (*Authenticator, erro
 		return nil, fmt.Errorf("failed to validate authenticator: %w", err)
 	}
 
-	logger.Info("IBM Cloud IAM authenticator initialized successfully")
+	logger.Info("IBM Cloud IAM authenticator initialized successfully",
+		zap.String("auth_mode", authenticator.AuthenticationType()),
+		zap.Bool("token_refresh", iamAuth != nil),
+	)
 
 	return &Authenticator{
 		authenticator: authenticator,
@@ -95,6 +122,13 @@ func (a *Authenticator) Authenticate(req *http.Request) error {
 // GetToken retrieves the current bearer token (for debugging/monitoring)
 func (a *Authenticator) GetToken() (string, error) {
 	// This is useful for health checks and monitoring
+	if tokenAuth, ok := a.authenticator.(*tokenAuthenticator); ok {
+		token, err := tokenAuth.currentToken()
+		if err != nil {
+			return "", fmt.Errorf("failed to get token: %w", err)
+		}
+		return token, nil
+	}
 	if iamAuth, ok := a.authenticator.(*core.IamAuthenticator); ok {
 		token, err := iamAuth.RequestToken()
 		if err != nil {
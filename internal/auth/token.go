@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"go.uber.org/zap"
+)
+
+// TokenRefreshWindow is how long before expiry a supplied IAM token is replaced
+// by one obtained with the API key
+const TokenRefreshWindow = 5 * time.Minute
+
+// tokenAuthenticator authenticates with a caller-supplied IAM access token. When an API key
+// is also configured, it switches to API key based token refresh once the supplied token is
+// within TokenRefreshWindow of expiry; otherwise an expired token is reported as an error.
+type tokenAuthenticator struct {
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time // zero when the token carries no exp claim
+	refresher *core.IamAuthenticator
+	logger    *zap.Logger
+	now       func() time.Time
+}
+
+// newTokenAuthenticator creates a token authenticator; refresher may be nil
+func newTokenAuthenticator(token string, refresher *core.IamAuthenticator, logger *zap.Logger) *tokenAuthenticator {
+	token = strings.TrimSpace(token)
+	if len(token) > len("bearer ") && strings.EqualFold(token[:len("bearer ")], "bearer ") {
+		token = strings.TrimSpace(token[len("bearer "):])
+	}
+
+	t := &tokenAuthenticator{
+		token:     token,
+		refresher: refresher,
+		logger:    logger,
+		now:       time.Now,
+	}
+	// Opaque tokens have no readable expiry and are used as-is until the API rejects them
+	if claims, err := parseJWTClaims(token); err == nil && claims.ExpiresAt > 0 {
+		t.expiresAt = time.Unix(claims.ExpiresAt, 0)
+	}
+	return t
+}
+
+// AuthenticationType implements core.Authenticator
+func (t *tokenAuthenticator) AuthenticationType() string {
+	return core.AUTHTYPE_BEARER_TOKEN
+}
+
+// Validate implements core.Authenticator
+func (t *tokenAuthenticator) Validate() error {
+	if t.token == "" {
+		return fmt.Errorf("IAM token is empty")
+	}
+	if t.refresher != nil {
+		return t.refresher.Validate()
+	}
+	return nil
+}
+
+// Authenticate implements core.Authenticator by setting the Authorization header
+func (t *tokenAuthenticator) Authenticate(req *http.Request) error {
+	token, err := t.currentToken()
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// currentToken returns the supplied token while it is fresh, or a refreshed one
+func (t *tokenAuthenticator) currentToken() (string, error) {
+	t.mu.Lock()
+	fresh := t.token != "" && (t.expiresAt.IsZero() || t.now().Add(TokenRefreshWindow).Before(t.expiresAt))
+	if fresh {
+		token := t.token
+		t.mu.Unlock()
+		return token, nil
+	}
+	if t.refresher == nil {
+		t.mu.Unlock()
+		return "", fmt.Errorf("IAM token expired at %s; supply a new LOGS_IAM_TOKEN or set LOGS_API_KEY for automatic refresh",
+			t.expiresAt.UTC().Format(time.RFC3339))
+	}
+	if t.token != "" {
+		// Drop the supplied token so later calls go straight to the refresher
+		t.token = ""
+		t.logger.Info("Supplied IAM token is near expiry, refreshing with API key",
+			zap.Time("expires_at", t.expiresAt))
+	}
+	t.mu.Unlock()
+
+	// The IAM authenticator caches its token and refreshes it before expiry
+	return t.refresher.GetToken()
+}
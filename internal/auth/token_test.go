@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"go.uber.org/zap"
+)
+
+// testJWT builds an unsigned JWT with the given subject and expiry
+func testJWT(subject string, exp time.Time) string {
+	payload := fmt.Sprintf(`{"sub":%q,"exp":%d}`, subject, exp.Unix())
+	return "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".sig"
+}
+
+func TestNewWithOptions_TokenOnly(t *testing.T) {
+	token := testJWT("iam-ServiceId-token", time.Now().Add(time.Hour))
+	a, err := NewWithOptions(Options{IAMToken: "Bearer " + token}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewWithOptions() failed: %v", err)
+	}
+
+	req, _ := http.NewRequest("GET", "https://example.com", nil)
+	if err := a.Authenticate(req); err != nil {
+		t.Fatalf("Authenticate() failed: %v", err)
+	}
+	if got := req.Header.Get("Authorization"); got != "Bearer "+token {
+		t.Errorf("Authorization = %q, want bearer token without duplicated prefix", got)
+	}
+
+	subject, err := a.GetUserIdentity()
+	if err != nil || subject != "iam-ServiceId-token" {
+		t.Errorf("GetUserIdentity() = %q, %v", subject, err)
+	}
+}
+
+func TestNewWithOptions_RequiresCredential(t *testing.T) {
+	if _, err := NewWithOptions(Options{}, zap.NewNop()); err == nil {
+		t.Error("Expected error when neither API key nor IAM token is set")
+	}
+}
+
+func TestTokenAuthenticator_ExpiredWithoutAPIKey(t *testing.T) {
+	ta := newTokenAuthenticator(testJWT("sub", time.Now().Add(2*time.Minute)), nil, zap.NewNop())
+
+	req, _ := http.NewRequest("GET", "https://example.com", nil)
+	err := ta.Authenticate(req)
+	if err == nil || !strings.Contains(err.Error(), "LOGS_API_KEY") {
+		t.Errorf("Expected expiry error suggesting LOGS_API_KEY, got %v", err)
+	}
+	if req.Header.Get("Authorization") != "" {
+		t.Error("Expired token must not be sent")
+	}
+}
+
+func TestTokenAuthenticator_RefreshesWithAPIKey(t *testing.T) {
+	refreshed := testJWT("sub", time.Now().Add(time.Hour))
+	iamServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":%q,"refresh_token":"r","token_type":"Bearer","expires_in":3600,"expiration":%d}`,
+			refreshed, time.Now().Add(time.Hour).Unix())
+	}))
+	defer iamServer.Close()
+
+	supplied := testJWT("sub", time.Now().Add(10*time.Minute))
+	refresher := &core.IamAuthenticator{ApiKey: "test-api-key", URL: iamServer.URL} //nolint:gosec // test value
+	ta := newTokenAuthenticator(supplied, refresher, zap.NewNop())
+
+	token, err := ta.currentToken()
+	if err != nil || token != supplied {
+		t.Fatalf("Expected supplied token while fresh, got %q, %v", token, err)
+	}
+
+	// Move the clock into the refresh window
+	ta.now = func() time.Time { return time.Now().Add(6 * time.Minute) }
+	token, err = ta.currentToken()
+	if err != nil {
+		t.Fatalf("currentToken() after expiry failed: %v", err)
+	}
+	if token != refreshed {
+		t.Errorf("Expected refreshed token from IAM, got %q", token)
+	}
+}
+
+func TestTokenAuthenticator_OpaqueTokenUsedAsIs(t *testing.T) {
+	ta := newTokenAuthenticator("opaque-token-value", nil, zap.NewNop())
+	token, err := ta.currentToken()
+	if err != nil || token != "opaque-token-value" {
+		t.Errorf("currentToken() = %q, %v", token, err)
+	}
+}
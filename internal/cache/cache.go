@@ -305,14 +305,26 @@ func (m *Manager) Set(userID, instanceID, toolName string, cacheKey string, valu
 	fullKey := toolName + ":" + cacheKey
 
 	// Get TTL for this tool
+	m.mu.RLock()
 	ttl := m.config.DefaultTTL
 	if toolTTL, ok := m.config.TTLByTool[toolName]; ok {
 		ttl = toolTTL
 	}
+	m.mu.RUnlock()
 
 	cache.Set(fullKey, value, ttl)
 }
 
+// SetToolTTL overrides the time-to-live of new cache entries for a tool
+func (m *Manager) SetToolTTL(toolName string, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.config.TTLByTool == nil {
+		m.config.TTLByTool = make(map[string]time.Duration)
+	}
+	m.config.TTLByTool[toolName] = ttl
+}
+
 // InvalidateTool removes all cache entries for a specific tool
 func (m *Manager) InvalidateTool(userID, instanceID, toolName string) int {
 	cache := m.GetUserCache(userID, instanceID)
@@ -324,9 +336,11 @@ func (m *Manager) InvalidateRelated(userID, instanceID, mutationTool string) {
 	// Define what tools should be invalidated for each mutation
 	invalidationMap := map[string][]string{
 		// Alert mutations invalidate alert-related caches
-		"create_alert": {"list_alerts", "get_alert", "suggest_alert"},
-		"update_alert": {"list_alerts", "get_alert", "suggest_alert"},
-		"delete_alert": {"list_alerts", "get_alert", "suggest_alert"},
+		"create_alert":       {"list_alerts", "get_alert", "suggest_alert"},
+		"bulk_create_alerts": {"list_alerts", "get_alert", "suggest_alert"},
+		"restore_alert":      {"list_alerts", "get_alert", "suggest_alert"},
+		"update_alert":       {"list_alerts", "get_alert", "suggest_alert"},
+		"delete_alert":       {"list_alerts", "get_alert", "suggest_alert"},
 
 		// Dashboard mutations
 		"create_dashboard": {"list_dashboards", "get_dashboard"},
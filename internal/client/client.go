@@ -3,6 +3,7 @@ package client
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"crypto/rand"
 	"encoding/json"
@@ -21,6 +22,7 @@ import (
 
 	"github.com/tareqmamari/cloud-logs-mcp/internal/auth"
 	"github.com/tareqmamari/cloud-logs-mcp/internal/config"
+	"github.com/tareqmamari/cloud-logs-mcp/internal/security"
 	"github.com/tareqmamari/cloud-logs-mcp/internal/tracing"
 )
 
@@ -50,8 +52,17 @@ type Client struct {
 	authenticator Authenticator
 	version       string
 	enableTracing bool
+	debugTracer   *DebugTracer // nil unless debug tracing is enabled
 }
 
+// RateLimitReporter is implemented by clients that throttle their requests
+type RateLimitReporter interface {
+	GetRateLimitInfo() RateLimitInfo
+}
+
+// Verify Client implements RateLimitReporter at compile time.
+var _ RateLimitReporter = (*Client)(nil)
+
 // RateLimitInfo contains information about the current rate limit state
 type RateLimitInfo struct {
 	Limit     int     `json:"limit"`     // Requests per second limit
@@ -62,17 +73,9 @@ type RateLimitInfo struct {
 
 // New creates a new API client
 func New(cfg *config.Config, logger *zap.Logger, version string) (*Client, error) {
-	// Create IBM Cloud authenticator
-	authenticator, err := auth.New(cfg.APIKey, cfg.IAMURL, logger)
+	transport, err := NewTransport(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create authenticator: %w", err)
-	}
-
-	transport := &http.Transport{
-		MaxIdleConns:        cfg.MaxIdleConns,
-		IdleConnTimeout:     cfg.IdleConnTimeout,
-		TLSHandshakeTimeout: 10 * time.Second,
-		TLSClientConfig:     newTLSConfig(),
+		return nil, fmt.Errorf("failed to configure HTTP transport: %w", err)
 	}
 
 	httpClient := &http.Client{
@@ -80,9 +83,24 @@ func New(cfg *config.Config, logger *zap.Logger, version string) (*Client, error
 		Timeout:   cfg.Timeout,
 	}
 
+	// Create IBM Cloud authenticator; IAM token requests use the same proxy and CA settings
+	authenticator, err := auth.NewWithOptions(auth.Options{
+		APIKey:     cfg.APIKey,
+		IAMToken:   cfg.IAMToken,
+		IAMURL:     cfg.IAMURL,
+		HTTPClient: httpClient,
+	}, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authenticator: %w", err)
+	}
+
+	if cfg.ProxyURL != "" {
+		logger.Info("Using HTTP proxy", zap.String("proxy", redactProxyURL(cfg.ProxyURL)))
+	}
+
 	var rateLimiter *rate.Limiter
 	if cfg.EnableRateLimit {
-		rateLimiter = rate.NewLimiter(rate.Limit(cfg.RateLimit), cfg.RateLimitBurst)
+		rateLimiter = newRateLimiter(cfg.RateLimit, cfg.RateLimitBurst)
 	}
 
 	// Use provided version or default to "dev"
@@ -90,6 +108,20 @@ func New(cfg *config.Config, logger *zap.Logger, version string) (*Client, error
 		version = "dev"
 	}
 
+	var debugTracer *DebugTracer
+	if cfg.DebugTrace {
+		path := cfg.DebugTraceFile
+		if path == "" {
+			path = DefaultDebugTracePath()
+		}
+		debugTracer, err = acquireDebugTracer(path, int64(cfg.DebugTraceMaxBytes))
+		if err != nil {
+			return nil, err
+		}
+		logger.Warn("HTTP debug tracing enabled; raw requests and responses are written to the trace file",
+			zap.String("path", path))
+	}
+
 	return &Client{
 		httpClient:    httpClient,
 		config:        cfg,
@@ -98,9 +130,19 @@ func New(cfg *config.Config, logger *zap.Logger, version string) (*Client, error
 		authenticator: authenticator,
 		version:       version,
 		enableTracing: cfg.EnableTracing,
+		debugTracer:   debugTracer,
 	}, nil
 }
 
+// newRateLimiter creates the token bucket for requestsPerSecond. A burst of 0 allows one
+// second's worth of requests, since a zero-capacity bucket would never admit a request.
+func newRateLimiter(requestsPerSecond, burst int) *rate.Limiter {
+	if burst <= 0 {
+		burst = max(requestsPerSecond, 1)
+	}
+	return rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+}
+
 // GetRateLimitInfo returns information about the current rate limit state
 func (c *Client) GetRateLimitInfo() RateLimitInfo {
 	info := RateLimitInfo{
@@ -110,7 +152,9 @@ func (c *Client) GetRateLimitInfo() RateLimitInfo {
 	}
 
 	if c.rateLimiter != nil {
-		info.Available = float64(c.rateLimiter.Tokens())
+		info.Burst = c.rateLimiter.Burst()
+		// Tokens goes negative while callers are queued in Wait; nothing is available then
+		info.Available = max(c.rateLimiter.Tokens(), 0)
 	}
 
 	return info
@@ -149,6 +193,7 @@ type Request struct {
 	RequestID      string        // Optional client-provided request ID for idempotency
 	UseIngressHost bool          // Use ingress endpoint instead of API endpoint for log ingestion
 	AcceptSSE      bool          // Use text/event-stream Accept header for streaming responses (e.g., sync queries)
+	Compressible   bool          // Body may be gzip-compressed according to the configured ingest compression mode
 	Timeout        time.Duration // Optional per-request timeout (overrides client default)
 }
 
@@ -309,7 +354,7 @@ func (c *Client) doRequest(ctx context.Context, req *Request) (*Response, error)
 
 	requestURL := c.buildRequestURL(req)
 
-	bodyReader, err := c.prepareBody(req)
+	bodyReader, gzipped, err := c.prepareBody(req)
 	if err != nil {
 		return nil, err
 	}
@@ -320,6 +365,9 @@ func (c *Client) doRequest(ctx context.Context, req *Request) (*Response, error)
 	}
 
 	c.setHeaders(ctx, httpReq, req)
+	if gzipped {
+		httpReq.Header.Set("Content-Encoding", "gzip")
+	}
 
 	if err := c.authenticator.Authenticate(httpReq); err != nil {
 		return nil, fmt.Errorf("authentication failed: %w", err)
@@ -329,14 +377,57 @@ func (c *Client) doRequest(ctx context.Context, req *Request) (*Response, error)
 		httpReq.Header.Set(k, v)
 	}
 
-	return c.executeRequest(httpReq, req, requestURL)
+	if c.debugTracer == nil {
+		return c.executeRequest(httpReq, req, requestURL)
+	}
+	start := time.Now()
+	resp, err := c.executeRequest(httpReq, req, requestURL)
+	c.recordDebugTrace(ctx, httpReq, req, resp, err, time.Since(start))
+	return resp, err
+}
+
+// recordDebugTrace writes the exchange to the debug trace file. Header values are masked by
+// name and bodies are redacted by the tracer; failures are logged and never fail the request.
+func (c *Client) recordDebugTrace(ctx context.Context, httpReq *http.Request, req *Request, resp *Response, reqErr error, duration time.Duration) {
+	entry := DebugTraceEntry{
+		Time:           time.Now().UTC(),
+		CallID:         CallIDFromContext(ctx),
+		Method:         req.Method,
+		URL:            httpReq.URL.String(),
+		RequestHeaders: security.MaskSensitiveHeaders(httpReq.Header),
+		DurationMs:     duration.Milliseconds(),
+	}
+	if req.Body != nil {
+		if body, err := json.Marshal(req.Body); err == nil {
+			entry.RequestBody = string(body)
+		}
+	}
+	if resp != nil {
+		entry.Status = resp.StatusCode
+		entry.ResponseHeaders = security.MaskSensitiveHeaders(resp.Headers)
+		entry.ResponseBody = string(resp.Body)
+	}
+	if reqErr != nil {
+		entry.Error = reqErr.Error()
+	}
+	if err := c.debugTracer.Record(entry); err != nil {
+		c.logger.Warn("Failed to write debug trace entry", zap.Error(err))
+	}
 }
 
+// applyRateLimit blocks until the token bucket has a token for this request, so bursts
+// are spread out client-side instead of tripping upstream 429s. It fails only when ctx is
+// cancelled or its deadline would pass before a token is available.
 func (c *Client) applyRateLimit(ctx context.Context) error {
-	if c.rateLimiter != nil {
-		if err := c.rateLimiter.Wait(ctx); err != nil {
-			return fmt.Errorf("rate limit wait failed: %w", err)
-		}
+	if c.rateLimiter == nil {
+		return nil
+	}
+	start := time.Now()
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return fmt.Errorf("rate limit wait failed: %w", err)
+	}
+	if waited := time.Since(start); waited >= 10*time.Millisecond {
+		c.logger.Debug("Request throttled by client rate limit", zap.Duration("waited", waited))
 	}
 	return nil
 }
@@ -358,15 +449,48 @@ func (c *Client) buildRequestURL(req *Request) string {
 	return requestURL
 }
 
-func (c *Client) prepareBody(req *Request) (io.Reader, error) {
+// GzipThreshold is the body size above which compressible requests are gzipped in "auto" mode
+const GzipThreshold = 32 * 1024
+
+// prepareBody marshals the request body, gzipping it when the request is compressible and the
+// configured ingest compression mode calls for it. It reports whether the body was compressed.
+func (c *Client) prepareBody(req *Request) (io.Reader, bool, error) {
 	if req.Body == nil {
-		return nil, nil
+		return nil, false, nil
 	}
 	bodyBytes, err := json.Marshal(req.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		return nil, false, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+	if !req.Compressible || !c.shouldGzip(len(bodyBytes)) {
+		return bytes.NewReader(bodyBytes), false, nil
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(bodyBytes); err != nil {
+		return nil, false, fmt.Errorf("failed to compress request body: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, false, fmt.Errorf("failed to compress request body: %w", err)
+	}
+	c.logger.Debug("Compressed request body",
+		zap.Int("original_size", len(bodyBytes)),
+		zap.Int("compressed_size", buf.Len()),
+	)
+	return &buf, true, nil
+}
+
+// shouldGzip reports whether a compressible body of the given size should be gzipped
+func (c *Client) shouldGzip(size int) bool {
+	switch c.config.IngestCompression {
+	case config.IngestCompressionAlways:
+		return true
+	case config.IngestCompressionOff:
+		return false
+	default: // auto
+		return size > GzipThreshold
 	}
-	return bytes.NewReader(bodyBytes), nil
 }
 
 func (c *Client) setHeaders(ctx context.Context, httpReq *http.Request, req *Request) {
@@ -537,6 +661,7 @@ type InstanceInfo struct {
 	ServiceURL   string `json:"service_url"`
 	Region       string `json:"region"`
 	InstanceName string `json:"instance_name,omitempty"`
+	InstanceID   string `json:"instance_id,omitempty"`
 }
 
 // GetInstanceInfo returns information about the IBM Cloud Logs service instance
@@ -545,11 +670,15 @@ func (c *Client) GetInstanceInfo() InstanceInfo {
 		ServiceURL:   c.config.ServiceURL,
 		Region:       c.config.Region,
 		InstanceName: c.config.InstanceName,
+		InstanceID:   c.config.InstanceID,
 	}
 }
 
 // Close closes the client and releases resources
 func (c *Client) Close() error {
 	c.httpClient.CloseIdleConnections()
+	if c.debugTracer != nil {
+		return c.debugTracer.release()
+	}
 	return nil
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"golang.org/x/time/rate"
 
 	"github.com/tareqmamari/cloud-logs-mcp/internal/config"
 )
@@ -820,3 +821,55 @@ func TestRetryWith429AndRetryAfter(t *testing.T) {
 	// Should have waited at least 1 second (the Retry-After value)
 	assert.GreaterOrEqual(t, elapsed, 1*time.Second, "Should respect Retry-After header")
 }
+
+func TestApplyRateLimit_BlocksInsteadOfFailing(t *testing.T) {
+	c := newTestClient("http://localhost", "test")
+	c.config.EnableRateLimit = true
+	c.config.RateLimit = 20
+	c.config.RateLimitBurst = 1
+	c.rateLimiter = rate.NewLimiter(rate.Limit(c.config.RateLimit), c.config.RateLimitBurst)
+
+	require.NoError(t, c.applyRateLimit(context.Background()))
+	assert.Less(t, c.GetRateLimitInfo().Available, 0.5, "the only token was just spent")
+
+	start := time.Now()
+	require.NoError(t, c.applyRateLimit(context.Background()), "an empty bucket should wait, not fail")
+	assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+}
+
+func TestApplyRateLimit_ContextCancellation(t *testing.T) {
+	c := newTestClient("http://localhost", "test")
+	c.rateLimiter = rate.NewLimiter(rate.Limit(1), 1)
+	require.NoError(t, c.applyRateLimit(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := c.applyRateLimit(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rate limit wait failed")
+}
+
+func TestGetRateLimitInfo(t *testing.T) {
+	c := newTestClient("http://localhost", "test")
+	c.config.EnableRateLimit = true
+	c.config.RateLimit = 5
+	c.config.RateLimitBurst = 3
+	c.rateLimiter = rate.NewLimiter(rate.Limit(5), 3)
+
+	info := c.GetRateLimitInfo()
+	assert.True(t, info.Enabled)
+	assert.Equal(t, 5, info.Limit)
+	assert.Equal(t, 3, info.Burst)
+	assert.InDelta(t, 3, info.Available, 0.1)
+
+	// Queued reservations drive the limiter's tokens negative; report none available
+	c.rateLimiter.ReserveN(time.Now(), 3)
+	c.rateLimiter.ReserveN(time.Now(), 2)
+	assert.Zero(t, c.GetRateLimitInfo().Available)
+}
+
+func TestNewRateLimiter_ZeroBurst(t *testing.T) {
+	assert.Equal(t, 20, newRateLimiter(20, 0).Burst())
+	assert.Equal(t, 1, newRateLimiter(0, 0).Burst())
+	assert.Equal(t, 5, newRateLimiter(20, 5).Burst())
+}
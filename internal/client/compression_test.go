@@ -0,0 +1,138 @@
+package client
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/config"
+)
+
+func TestPrepareBody_Compression(t *testing.T) {
+	small := map[string]string{"text": "hello"}
+	large := map[string]string{"text": strings.Repeat("x", GzipThreshold+1)}
+
+	tests := []struct {
+		name         string
+		mode         string
+		body         interface{}
+		compressible bool
+		wantGzip     bool
+	}{
+		{"auto below threshold", config.IngestCompressionAuto, small, true, false},
+		{"auto above threshold", config.IngestCompressionAuto, large, true, true},
+		{"unset mode behaves as auto", "", large, true, true},
+		{"always", config.IngestCompressionAlways, small, true, true},
+		{"off", config.IngestCompressionOff, large, true, false},
+		{"not compressible", config.IngestCompressionAlways, large, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient("http://example.com", "test")
+			c.config.IngestCompression = tt.mode
+
+			reader, gzipped, err := c.prepareBody(&Request{Body: tt.body, Compressible: tt.compressible})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantGzip, gzipped)
+
+			if gzipped {
+				gz, err := gzip.NewReader(reader)
+				require.NoError(t, err)
+				reader = gz
+			}
+			raw, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			want, _ := json.Marshal(tt.body)
+			assert.Equal(t, string(want), string(raw))
+		})
+	}
+}
+
+// TestIngestCompressionRoundTrip ingests a gzipped batch through the .ingress. host and
+// queries it back through the .api. host of the same fake service.
+func TestIngestCompressionRoundTrip(t *testing.T) {
+	var mu sync.Mutex
+	var stored []map[string]interface{}
+	var ingestHost, ingestEncoding string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/logs/v1/singles":
+			ingestHost = r.Host
+			ingestEncoding = r.Header.Get("Content-Encoding")
+			var body io.Reader = r.Body
+			if ingestEncoding == "gzip" {
+				gz, err := gzip.NewReader(r.Body)
+				if err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				body = gz
+			}
+			if err := json.NewDecoder(body).Decode(&stored); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{}`))
+		case "/v1/query":
+			if !strings.Contains(r.Host, ".api.") {
+				w.WriteHeader(http.StatusMisdirectedRequest)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"events": stored})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// Route every host to the test server so the .api./.ingress. rewrite is exercised
+	serverAddr := server.Listener.Addr().String()
+	c := newTestClient("http://inst.api.us-south.logs.test", "test")
+	c.config.IngestCompression = config.IngestCompressionAlways
+	c.httpClient.Transport = &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, serverAddr)
+		},
+	}
+
+	logs := []map[string]interface{}{
+		{"applicationName": "api", "subsystemName": "auth", "severity": 5, "text": "login failed"},
+		{"applicationName": "api", "subsystemName": "auth", "severity": 3, "text": "login ok"},
+	}
+	resp, err := c.Do(context.Background(), &Request{
+		Method:         "POST",
+		Path:           "/logs/v1/singles",
+		Body:           logs,
+		UseIngressHost: true,
+		Compressible:   true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "gzip", ingestEncoding)
+	assert.Equal(t, "inst.ingress.us-south.logs.test", ingestHost)
+
+	resp, err = c.Do(context.Background(), &Request{Method: "POST", Path: "/v1/query", Body: map[string]string{"query": "source logs"}})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Events []map[string]interface{} `json:"events"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Body, &result))
+	require.Len(t, result.Events, 2)
+	assert.Equal(t, "login failed", result.Events[0]["text"])
+}
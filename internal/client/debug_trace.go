@@ -0,0 +1,204 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/security"
+)
+
+// DefaultDebugTraceMaxBytes is the size at which the debug trace file is rotated
+const DefaultDebugTraceMaxBytes = 10 * 1024 * 1024
+
+// DefaultDebugTracePath returns the trace file used when debug tracing is enabled without a path
+func DefaultDebugTracePath() string {
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(homeDir, ".logs-mcp", "debug", "http-trace.log")
+	}
+	return filepath.Join(os.TempDir(), "logs-mcp-http-trace.log")
+}
+
+// callIDKey is the context key for the tool call ID that correlates debug trace entries
+type callIDKey struct{}
+
+// NewCallID returns a random ID identifying one tool call in the debug trace
+func NewCallID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%016x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// WithCallID returns a context whose API requests are traced under the given call ID
+func WithCallID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, callIDKey{}, id)
+}
+
+// CallIDFromContext returns the call ID set by WithCallID, or ""
+func CallIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(callIDKey{}).(string)
+	return id
+}
+
+// DebugTraceEntry is one request/response exchange written to the debug trace file.
+// Auth headers are masked and bodies pass through the secret redactor.
+type DebugTraceEntry struct {
+	Time            time.Time         `json:"time"`
+	CallID          string            `json:"call_id,omitempty"`
+	Method          string            `json:"method"`
+	URL             string            `json:"url"`
+	RequestHeaders  map[string]string `json:"request_headers,omitempty"`
+	RequestBody     string            `json:"request_body,omitempty"`
+	Status          int               `json:"status,omitempty"`
+	ResponseHeaders map[string]string `json:"response_headers,omitempty"`
+	ResponseBody    string            `json:"response_body,omitempty"`
+	DurationMs      int64             `json:"duration_ms"`
+	Error           string            `json:"error,omitempty"`
+}
+
+// DebugTracer appends request/response exchanges as JSON lines to a file. When the file
+// would grow past maxBytes it is renamed to "<path>.1" (replacing the previous backup) and
+// a new file is started.
+type DebugTracer struct {
+	mu       sync.Mutex
+	path     string
+	maxBytes int64
+	file     *os.File
+	size     int64
+	redactor *security.Redactor
+	refs     int
+}
+
+var (
+	debugTracersMu sync.Mutex
+	debugTracers   = map[string]*DebugTracer{}
+)
+
+// acquireDebugTracer returns the tracer for path, opening it on first use. Clients for
+// several instances share one tracer so rotation is coordinated.
+func acquireDebugTracer(path string, maxBytes int64) (*DebugTracer, error) {
+	debugTracersMu.Lock()
+	defer debugTracersMu.Unlock()
+
+	if t, ok := debugTracers[path]; ok {
+		t.refs++
+		return t, nil
+	}
+	t, err := NewDebugTracer(path, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	t.refs = 1
+	debugTracers[path] = t
+	return t, nil
+}
+
+// release drops a reference taken by acquireDebugTracer and closes the file with the last one
+func (t *DebugTracer) release() error {
+	debugTracersMu.Lock()
+	defer debugTracersMu.Unlock()
+
+	t.refs--
+	if t.refs > 0 {
+		return nil
+	}
+	delete(debugTracers, t.path)
+	return t.Close()
+}
+
+// NewDebugTracer opens (or creates) the trace file at path with owner-only permissions
+func NewDebugTracer(path string, maxBytes int64) (*DebugTracer, error) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultDebugTraceMaxBytes
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create debug trace directory: %w", err)
+	}
+	t := &DebugTracer{path: path, maxBytes: maxBytes, redactor: security.NewRedactor()}
+	if err := t.open(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// open opens the trace file for appending and records its current size
+func (t *DebugTracer) open() error {
+	f, err := os.OpenFile(t.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open debug trace file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to stat debug trace file: %w", err)
+	}
+	t.file = f
+	t.size = info.Size()
+	return nil
+}
+
+// Record redacts the entry and appends it to the trace file, rotating first if needed
+func (t *DebugTracer) Record(entry DebugTraceEntry) error {
+	entry.URL = security.MaskURL(entry.URL)
+	entry.RequestBody = t.redactor.Redact(entry.RequestBody)
+	entry.ResponseBody = t.redactor.Redact(entry.ResponseBody)
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode debug trace entry: %w", err)
+	}
+	line = append(line, '\n')
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.file == nil {
+		return fmt.Errorf("debug trace file is closed")
+	}
+	if t.size > 0 && t.size+int64(len(line)) > t.maxBytes {
+		if err := t.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := t.file.Write(line)
+	t.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write debug trace entry: %w", err)
+	}
+	return nil
+}
+
+// rotate moves the current file to the ".1" backup and starts a new one
+func (t *DebugTracer) rotate() error {
+	if err := t.file.Close(); err != nil {
+		return fmt.Errorf("failed to close debug trace file: %w", err)
+	}
+	t.file = nil
+	renameErr := os.Rename(t.path, t.path+".1")
+	if err := t.open(); err != nil {
+		return err
+	}
+	if renameErr != nil {
+		return fmt.Errorf("failed to rotate debug trace file: %w", renameErr)
+	}
+	return nil
+}
+
+// Close closes the trace file
+func (t *DebugTracer) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.file == nil {
+		return nil
+	}
+	err := t.file.Close()
+	t.file = nil
+	return err
+}
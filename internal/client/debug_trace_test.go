@@ -0,0 +1,112 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readTraceEntries parses the JSON lines of a debug trace file
+func readTraceEntries(t *testing.T, path string) []DebugTraceEntry {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	var entries []DebugTraceEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e DebugTraceEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+func TestDebugTrace_RecordsRedactedExchange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Set-Cookie", "session=abc")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"bad filter","api_key":"leakedsecret123"}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "trace", "http.log")
+	tracer, err := NewDebugTracer(path, 0)
+	require.NoError(t, err)
+
+	c := newTestClient(server.URL, "test")
+	c.debugTracer = tracer
+
+	ctx := WithCallID(context.Background(), "call-123")
+	resp, err := c.doRequest(ctx, &Request{
+		Method: "POST",
+		Path:   "/v1/query",
+		Body:   map[string]interface{}{"query": "source logs", "password": "hunter2hunter2"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	require.NoError(t, tracer.Close())
+
+	entries := readTraceEntries(t, path)
+	require.Len(t, entries, 1)
+	e := entries[0]
+	assert.Equal(t, "call-123", e.CallID)
+	assert.Equal(t, "POST", e.Method)
+	assert.Equal(t, http.StatusBadRequest, e.Status)
+	assert.Contains(t, e.RequestBody, "source logs")
+	assert.Contains(t, e.ResponseBody, "bad filter")
+	assert.Equal(t, "***REDACTED***", e.RequestHeaders["Authorization"])
+	assert.Equal(t, "***REDACTED***", e.ResponseHeaders["Set-Cookie"])
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	for _, secret := range []string{"test-token", "hunter2hunter2", "leakedsecret123", "session=abc"} {
+		assert.NotContains(t, string(raw), secret)
+	}
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestDebugTrace_Rotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "http.log")
+	tracer, err := NewDebugTracer(path, 300)
+	require.NoError(t, err)
+	defer func() { _ = tracer.Close() }()
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, tracer.Record(DebugTraceEntry{
+			Method:       "GET",
+			URL:          "https://example.test/v1/alerts",
+			ResponseBody: strings.Repeat("x", 150),
+		}))
+	}
+
+	assert.Len(t, readTraceEntries(t, path), 1)
+	assert.Len(t, readTraceEntries(t, path+".1"), 1)
+}
+
+func TestAcquireDebugTracer_Shared(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "http.log")
+	first, err := acquireDebugTracer(path, 0)
+	require.NoError(t, err)
+	second, err := acquireDebugTracer(path, 0)
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+
+	require.NoError(t, first.release())
+	require.NoError(t, second.Record(DebugTraceEntry{Method: "GET"}), "tracer should stay open while referenced")
+	require.NoError(t, second.release())
+	assert.Error(t, second.Record(DebugTraceEntry{Method: "GET"}), "tracer should be closed after the last release")
+}
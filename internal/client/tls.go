@@ -2,11 +2,57 @@ package client
 
 import (
 	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/config"
 )
 
 // newTLSConfig returns a secure TLS configuration with TLS 1.2 minimum.
-func newTLSConfig() *tls.Config {
-	return &tls.Config{
+// When cfg.CABundle is set, the PEM certificates it contains are trusted in addition
+// to the system roots (for proxies or gateways with private CAs). When a client
+// certificate is configured it is presented for mutual TLS.
+func newTLSConfig(cfg *config.Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
 		MinVersion: tls.VersionTLS12,
 	}
+
+	if cfg.CABundle != "" {
+		pem, err := os.ReadFile(cfg.CABundle) // #nosec G304 -- path comes from operator configuration
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA bundle %s contains no PEM certificates", cfg.CABundle)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.ClientCert != "" || cfg.ClientKey != "" {
+		cert, err := LoadClientCertificate(cfg.ClientCert, cfg.ClientKey)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// LoadClientCertificate loads a PEM client certificate and private key for mutual TLS,
+// reporting a missing file, unparseable PEM, or a key that doesn't match the certificate
+func LoadClientCertificate(certFile, keyFile string) (tls.Certificate, error) {
+	if certFile == "" || keyFile == "" {
+		return tls.Certificate{}, fmt.Errorf("mutual TLS requires both a client certificate and a client key")
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to load client certificate %s with key %s: %w", certFile, keyFile, err)
+	}
+	return cert, nil
 }
@@ -2,6 +2,7 @@
 package config
 
 import (
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -18,11 +19,20 @@ type Config struct {
 	// IBM Cloud Logs Service Configuration
 	ServiceURL   string `json:"service_url"`
 	APIKey       string `json:"api_key,omitempty"` //nolint:gosec // Not stored in files, from env only
+	IAMToken     string `json:"-"`                 // Pre-issued IAM access token (alternative to, or used with, api_key)
 	Region       string `json:"region"`
 	InstanceID   string `json:"instance_id,omitempty"`   // Service instance ID (alternative to service_url)
 	InstanceName string `json:"instance_name,omitempty"` // Optional friendly name for this instance
 	IAMURL       string `json:"iam_url,omitempty"`       // Optional IAM endpoint (default: production, or iam.test.cloud.ibm.com for staging)
 
+	// Instances are additional named instances served by the same process, selected per call
+	// with the instance tool parameter. They are only read from the config file.
+	Instances []InstanceConfig `json:"instances,omitempty"`
+
+	// SkipEndpointValidation accepts a service URL that does not match the IBM Cloud Logs
+	// endpoint shape (local mocks, proxies)
+	SkipEndpointValidation bool `json:"skip_endpoint_validation,omitempty"`
+
 	// HTTP Client Configuration
 	Timeout         time.Duration `json:"timeout"`
 	MaxRetries      int           `json:"max_retries"`
@@ -31,14 +41,39 @@ type Config struct {
 	MaxIdleConns    int           `json:"max_idle_conns"`
 	IdleConnTimeout time.Duration `json:"idle_conn_timeout"`
 
+	// Network
+	ProxyURL string `json:"proxy_url,omitempty"` // Explicit HTTP(S) proxy, may include user:password; overrides HTTPS_PROXY/HTTP_PROXY
+	CABundle string `json:"ca_bundle,omitempty"` // PEM file of extra trusted CAs (TLS-intercepting proxies, mTLS gateways)
+
+	// Mutual TLS client certificate presented to gateways; independent of API key auth
+	ClientCert string `json:"client_cert,omitempty"` // PEM client certificate path
+	ClientKey  string `json:"client_key,omitempty"`  // PEM client private key path
+
 	// Operation-Specific Timeouts
 	QueryTimeout          time.Duration `json:"query_timeout"`           // Timeout for synchronous queries (default: 60s)
 	BackgroundPollTimeout time.Duration `json:"background_poll_timeout"` // Timeout for background query status checks (default: 10s)
 	BulkOperationTimeout  time.Duration `json:"bulk_operation_timeout"`  // Timeout for bulk operations (default: 120s)
+	QueryCacheTTL         time.Duration `json:"query_cache_ttl"`         // How long identical query_logs results are reused (default: 30s, 0 disables)
+
+	// ToolTimeouts overrides the execution timeout of individual tools (tool name -> timeout)
+	ToolTimeouts map[string]time.Duration `json:"tool_timeouts,omitempty"`
+
+	// Tool access: which tools the server advertises and runs
+	ReadOnlyMode  bool     `json:"read_only_mode"`           // Expose only tools that do not create, update, or delete anything (default: false)
+	EnabledTools  []string `json:"enabled_tools,omitempty"`  // When set, only these tools are exposed
+	DisabledTools []string `json:"disabled_tools,omitempty"` // Tools never exposed; applied after enabled_tools and read_only_mode
+
+	// RequireConfirmation makes destructive tools return a short-lived confirmation token
+	// instead of deleting, and delete only when called again with that token (default: false)
+	RequireConfirmation bool `json:"require_confirmation"`
+
+	// Application scope: which applications' logs queries may read
+	AllowedApplications []string `json:"allowed_applications,omitempty"` // When set, queries only see logs from these applications
+	DeniedApplications  []string `json:"denied_applications,omitempty"`  // Applications whose logs are never returned; queries naming them are rejected
 
 	// Rate Limiting
 	RateLimit       int  `json:"rate_limit"`       // requests per second
-	RateLimitBurst  int  `json:"rate_limit_burst"` // burst size
+	RateLimitBurst  int  `json:"rate_limit_burst"` // burst size (0 allows one second's worth of requests)
 	EnableRateLimit bool `json:"enable_rate_limit"`
 
 	// Observability
@@ -46,16 +81,111 @@ type Config struct {
 	EnableAuditLog  bool `json:"enable_audit_log"` // Enable audit logging (default: true)
 	MetricsEndpoint bool `json:"metrics_endpoint"` // Enable Prometheus metrics endpoint (default: true)
 
+	// AuditLogDestination is where the audit trail of create/update/delete calls is written,
+	// separate from the application log: "file:<path>" (JSON lines) or "ingest" (this instance)
+	AuditLogDestination string `json:"audit_log_destination,omitempty"`
+
 	// Health & Metrics HTTP Server
-	HealthPort      int           `json:"health_port"`      // Port for health/metrics HTTP server (default: 8080, 0 to disable)
+	HealthPort      int           `json:"health_port"`      // Port for health/metrics HTTP server (default: 0, disabled)
 	HealthBindAddr  string        `json:"health_bind_addr"` // Bind address for health server (default: 127.0.0.1 for security)
+	MetricsPort     int           `json:"metrics_port"`     // Separate port for /metrics (default: 0, served on the health port)
 	ShutdownTimeout time.Duration `json:"shutdown_timeout"` // Timeout for graceful shutdown (default: 30s)
 
-	// Logging
-	LogLevel  string `json:"log_level"`
+	// Response Size Limits
+	MaxResultSize      int `json:"max_result_size"`      // Maximum tool result size in bytes before truncation (default: 100KB)
+	FinalResponseLimit int `json:"final_response_limit"` // Absolute maximum response text size in bytes (default: 150KB)
+
+	// Query event limits
+	MaxQueryEvents      int `json:"max_query_events"`       // Log entries kept from a query response by default (default: 2000)
+	MaxQueryEventsLimit int `json:"max_query_events_limit"` // Upper bound for the per-call max_events argument (default: 20000)
+
+	// Session Persistence
+	SessionPersistence bool   `json:"session_persistence"` // Persist session context to disk across restarts (default: false)
+	SessionDir         string `json:"session_dir"`         // Directory for session files (default: ~/.logs-mcp/sessions)
+	Stateless          bool   `json:"stateless"`           // Run without session state: no remembered context between requests, nothing persisted (default: false)
+
+	// Learned tool chains
+	ChainDecayHalfLife   time.Duration `json:"chain_decay_half_life"`  // Time for an unused learned chain to lose half its weight (default: 168h, negative disables decay)
+	ChainMinObservations int           `json:"chain_min_observations"` // Uses of a tool sequence before it is suggested as a chain (default: 2)
+
+	// Query behavior
+	AutoCorrectQueries bool `json:"auto_correct_queries"` // Rewrite common DataPrime mistakes instead of rejecting the query (default: true)
+
+	// Secret redaction
+	RedactSecrets     bool     `json:"redact_secrets"`               // Mask tokens, webhook keys, and credentials in tool responses (default: true)
+	RedactionPatterns []string `json:"redaction_patterns,omitempty"` // Extra regular expressions to redact in addition to the defaults
+
+	// Webhook tests and scheduled reports
+	WebhookAllowHTTP bool `json:"webhook_allow_http"` // Allow plain http webhook URLs; only https is contacted by default (default: false)
+
+	// PII masking for query_logs redact_pii, independent of secret redaction
+	PIIPatterns []string `json:"pii_patterns,omitempty"` // Extra regular expressions to mask in addition to emails, IPs, card and phone numbers
+
+	// Root-cause knowledge base used to label log patterns
+	RootCauseRulesFile string `json:"root_cause_rules_file,omitempty"` // JSON file of pattern -> root cause/remediation rules checked before the built-in set
+
+	// Ingestion
+	IngestCompression string `json:"ingest_compression"` // Gzip ingest_logs payloads: auto (above 32KB), always, or off (default: auto)
+	IngestBatchSize   int    `json:"ingest_batch_size"`  // Maximum log entries per ingestion request; larger calls are chunked (default: 1000)
+	IngestBatchBytes  int    `json:"ingest_batch_bytes"` // Maximum serialized bytes per ingestion request (default: 2MB)
+
+	// Timestamp display in query output; raw ISO values are shown alongside when these change them
+	DisplayTimezone   string `json:"display_timezone"`    // IANA timezone such as "Europe/Berlin" (default: UTC)
+	DisplayTimeFormat string `json:"display_time_format"` // rfc3339, datetime, time, or a Go layout (default: rfc3339)
+
+	// Exports
+	ExportDir string `json:"export_dir"` // Directory for file-mode query result exports (default: ~/.logs-mcp/exports)
+
+	// Scheduled queries
+	ScheduleFile string `json:"schedule_file"` // File scheduled queries are persisted to, independent of session persistence (default: ~/.logs-mcp/schedules.json)
+
+	// HTTP debug tracing: raw requests and responses (auth headers and secrets redacted)
+	DebugTrace         bool   `json:"debug_trace"`           // Write each API request and response to DebugTraceFile (default: false)
+	DebugTraceFile     string `json:"debug_trace_file"`      // Trace file path (default: ~/.logs-mcp/debug/http-trace.log)
+	DebugTraceMaxBytes int    `json:"debug_trace_max_bytes"` // Size at which the trace file is rotated to "<file>.1" (default: 10MB)
+
+	// Logging of the server itself; empty values keep the ENVIRONMENT preset
+	// (info/json in production, debug/console otherwise)
+	LogLevel  string `json:"log_level"`  // debug, info, warn, or error
 	LogFormat string `json:"log_format"` // json or console
 }
 
+// InstanceConfig names an additional IBM Cloud Logs instance. Unset fields inherit the
+// primary instance's values, so instances in the same account can share an API key.
+type InstanceConfig struct {
+	Name       string `json:"name"`
+	ServiceURL string `json:"service_url,omitempty"`
+	APIKey     string `json:"api_key,omitempty"` //nolint:gosec // Read from the config file only
+	IAMToken   string `json:"iam_token,omitempty"`
+	Region     string `json:"region,omitempty"`
+	InstanceID string `json:"instance_id,omitempty"`
+	IAMURL     string `json:"iam_url,omitempty"`
+}
+
+// DefaultPrimaryInstanceName names the primary instance when LOGS_INSTANCE_NAME is not set
+const DefaultPrimaryInstanceName = "primary"
+
+// Ingest compression modes
+const (
+	IngestCompressionAuto   = "auto"
+	IngestCompressionAlways = "always"
+	IngestCompressionOff    = "off"
+)
+
+// Response size limit bounds
+const (
+	// DefaultMaxResultSize is the default maximum tool result size (100KB for Claude Desktop compatibility)
+	DefaultMaxResultSize = 100 * 1024
+	// DefaultFinalResponseLimit is the default absolute maximum response text size
+	DefaultFinalResponseLimit = 150 * 1024
+	// MinResultSize is the smallest accepted result size; smaller limits truncate nearly every response
+	MinResultSize = 10 * 1024
+	// MaxAllowedResultSize is the hard upper bound (MCP's 1MB message limit)
+	MaxAllowedResultSize = 1024 * 1024
+	// LargeResultSizeWarning is the size above which clients may fail to compact context
+	LargeResultSizeWarning = 512 * 1024
+)
+
 // Load configuration from environment variables and config file
 func Load() (*Config, error) {
 	cfg := &Config{
@@ -69,20 +199,27 @@ func Load() (*Config, error) {
 		RateLimit:       100,
 		RateLimitBurst:  20,
 		EnableRateLimit: true,
-		LogLevel:        "info",
-		LogFormat:       "json",
 		// Operation-specific timeouts
 		QueryTimeout:          60 * time.Second,
 		BackgroundPollTimeout: 10 * time.Second,
 		BulkOperationTimeout:  120 * time.Second,
+		QueryCacheTTL:         30 * time.Second,
 		// Observability defaults
 		EnableTracing:   true,
 		EnableAuditLog:  true,
 		MetricsEndpoint: true, // Enabled by default for operational visibility
 		// Health & shutdown defaults
-		HealthPort:      8080,
+		HealthPort:      0,           // Off by default; stdio-only usage needs no listener
 		HealthBindAddr:  "127.0.0.1", // Bind to localhost by default for security
 		ShutdownTimeout: 30 * time.Second,
+		// Response size defaults
+		MaxResultSize:      DefaultMaxResultSize,
+		FinalResponseLimit: DefaultFinalResponseLimit,
+		// Query behavior defaults
+		AutoCorrectQueries: true,
+		IngestCompression:  IngestCompressionAuto,
+		// Security defaults
+		RedactSecrets: true,
 	}
 
 	// Try to load from config file if specified
@@ -95,22 +232,54 @@ func Load() (*Config, error) {
 	// Override with environment variables (these take precedence)
 	loadFromEnv(cfg)
 
+	cfg.resolveEndpoint()
+
+	return cfg, nil
+}
+
+// resolveEndpoint fills in the region and instance ID from the service URL, or builds the
+// service URL from the region and instance ID
+func (c *Config) resolveEndpoint() {
 	// If ServiceURL is provided, extract region and instance ID from it
-	if cfg.ServiceURL != "" {
-		if cfg.Region == "" {
-			cfg.Region = ExtractRegionFromURL(cfg.ServiceURL)
+	if c.ServiceURL != "" {
+		if c.Region == "" {
+			c.Region = ExtractRegionFromURL(c.ServiceURL)
 		}
-		if cfg.InstanceID == "" {
-			cfg.InstanceID = ExtractInstanceIDFromURL(cfg.ServiceURL)
+		if c.InstanceID == "" {
+			c.InstanceID = ExtractInstanceIDFromURL(c.ServiceURL)
 		}
 	}
 
 	// If ServiceURL is not provided but Region and InstanceID are, construct the URL
-	if cfg.ServiceURL == "" && cfg.Region != "" && cfg.InstanceID != "" {
-		cfg.ServiceURL = BuildServiceURL(cfg.InstanceID, cfg.Region)
+	if c.ServiceURL == "" && c.Region != "" && c.InstanceID != "" {
+		c.ServiceURL = BuildServiceURL(c.InstanceID, c.Region)
 	}
+}
 
-	return cfg, nil
+// PrimaryInstanceName returns the name tools use for the primary instance
+func (c *Config) PrimaryInstanceName() string {
+	if c.InstanceName != "" {
+		return c.InstanceName
+	}
+	return DefaultPrimaryInstanceName
+}
+
+// ForInstance returns the configuration for an additional instance: a copy of this config
+// with the instance's endpoint and credentials applied. Settings such as timeouts, proxy,
+// and rate limits are shared with the primary instance.
+func (c *Config) ForInstance(ic InstanceConfig) *Config {
+	cfg := *c
+	cfg.Instances = nil
+	cfg.InstanceName = ic.Name
+	cfg.ServiceURL, cfg.Region, cfg.InstanceID = ic.ServiceURL, ic.Region, ic.InstanceID
+	if ic.APIKey != "" || ic.IAMToken != "" {
+		cfg.APIKey, cfg.IAMToken = ic.APIKey, ic.IAMToken
+	}
+	if ic.IAMURL != "" {
+		cfg.IAMURL = ic.IAMURL
+	}
+	cfg.resolveEndpoint()
+	return &cfg
 }
 
 func loadFromFile(cfg *Config, path string) error {
@@ -147,6 +316,9 @@ func loadStringEnvs(cfg *Config) {
 	if v := os.Getenv("LOGS_API_KEY"); v != "" {
 		cfg.APIKey = v
 	}
+	if v := os.Getenv("LOGS_IAM_TOKEN"); v != "" {
+		cfg.IAMToken = v
+	}
 	if v := os.Getenv("LOGS_REGION"); v != "" {
 		cfg.Region = v
 	}
@@ -159,12 +331,54 @@ func loadStringEnvs(cfg *Config) {
 	if v := os.Getenv("LOGS_IAM_URL"); v != "" {
 		cfg.IAMURL = v
 	}
+	if v := os.Getenv("LOGS_PROXY_URL"); v != "" {
+		cfg.ProxyURL = v
+	}
+	if v := os.Getenv("LOGS_CA_BUNDLE"); v != "" {
+		cfg.CABundle = v
+	}
+	if v := os.Getenv("LOGS_CLIENT_CERT"); v != "" {
+		cfg.ClientCert = v
+	}
+	if v := os.Getenv("LOGS_CLIENT_KEY"); v != "" {
+		cfg.ClientKey = v
+	}
 	if v := os.Getenv("LOG_LEVEL"); v != "" {
 		cfg.LogLevel = v
 	}
 	if v := os.Getenv("LOG_FORMAT"); v != "" {
 		cfg.LogFormat = v
 	}
+	if v := os.Getenv("LOGS_SESSION_DIR"); v != "" {
+		cfg.SessionDir = v
+	}
+	if v := os.Getenv("LOGS_INGEST_COMPRESSION"); v != "" {
+		cfg.IngestCompression = strings.ToLower(v)
+	}
+	if v := os.Getenv("LOGS_DISPLAY_TIMEZONE"); v != "" {
+		cfg.DisplayTimezone = v
+	}
+	if v := os.Getenv("LOGS_DISPLAY_TIME_FORMAT"); v != "" {
+		cfg.DisplayTimeFormat = v
+	}
+	if v := os.Getenv("LOGS_EXPORT_DIR"); v != "" {
+		cfg.ExportDir = v
+	}
+	if v := os.Getenv("LOGS_SCHEDULE_FILE"); v != "" {
+		cfg.ScheduleFile = v
+	}
+	if v := os.Getenv("LOGS_DEBUG_TRACE_FILE"); v != "" {
+		cfg.DebugTraceFile = v
+	}
+	if v := os.Getenv("LOGS_REDACTION_PATTERNS"); v != "" {
+		cfg.RedactionPatterns = ParseRedactionPatterns(v)
+	}
+	if v := os.Getenv("LOGS_PII_PATTERNS"); v != "" {
+		cfg.PIIPatterns = ParseRedactionPatterns(v)
+	}
+	if v := os.Getenv("LOGS_ROOT_CAUSE_RULES_FILE"); v != "" {
+		cfg.RootCauseRulesFile = v
+	}
 	if v := os.Getenv("LOGS_HEALTH_BIND_ADDR"); v != "" {
 		cfg.HealthBindAddr = v
 	}
@@ -191,6 +405,37 @@ func loadDurationEnvs(cfg *Config) {
 			cfg.BulkOperationTimeout = d
 		}
 	}
+	if v := os.Getenv("LOGS_QUERY_CACHE_TTL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.QueryCacheTTL = d
+		}
+	}
+	if v := os.Getenv("LOGS_TOOL_TIMEOUTS"); v != "" {
+		cfg.ToolTimeouts = ParseToolTimeouts(v)
+	}
+	if v := os.Getenv("LOGS_READ_ONLY_MODE"); v != "" {
+		cfg.ReadOnlyMode = v == "true" || v == "1"
+	}
+	if v := os.Getenv("LOGS_ENABLED_TOOLS"); v != "" {
+		cfg.EnabledTools = ParseNameList(v)
+	}
+	if v := os.Getenv("LOGS_DISABLED_TOOLS"); v != "" {
+		cfg.DisabledTools = ParseNameList(v)
+	}
+	if v := os.Getenv("LOGS_REQUIRE_CONFIRMATION"); v != "" {
+		cfg.RequireConfirmation = v == "true" || v == "1"
+	}
+	if v := os.Getenv("LOGS_ALLOWED_APPLICATIONS"); v != "" {
+		cfg.AllowedApplications = ParseNameList(v)
+	}
+	if v := os.Getenv("LOGS_DENIED_APPLICATIONS"); v != "" {
+		cfg.DeniedApplications = ParseNameList(v)
+	}
+	if v := os.Getenv("LOGS_CHAIN_DECAY_HALF_LIFE"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.ChainDecayHalfLife = d
+		}
+	}
 	if v := os.Getenv("LOGS_SHUTDOWN_TIMEOUT"); v != "" {
 		if d, err := time.ParseDuration(v); err == nil {
 			cfg.ShutdownTimeout = d
@@ -223,6 +468,60 @@ func loadIntEnvs(cfg *Config) {
 			cfg.HealthPort = port
 		}
 	}
+	if v := os.Getenv("LOGS_METRICS_PORT"); v != "" {
+		var port int
+		if _, err := fmt.Sscanf(v, "%d", &port); err == nil {
+			cfg.MetricsPort = port
+		}
+	}
+	if v := os.Getenv("LOGS_MAX_RESULT_SIZE"); v != "" {
+		var size int
+		if _, err := fmt.Sscanf(v, "%d", &size); err == nil {
+			cfg.MaxResultSize = size
+		}
+	}
+	if v := os.Getenv("LOGS_FINAL_RESPONSE_LIMIT"); v != "" {
+		var size int
+		if _, err := fmt.Sscanf(v, "%d", &size); err == nil {
+			cfg.FinalResponseLimit = size
+		}
+	}
+	if v := os.Getenv("LOGS_MAX_QUERY_EVENTS"); v != "" {
+		var n int
+		if _, err := fmt.Sscanf(v, "%d", &n); err == nil {
+			cfg.MaxQueryEvents = n
+		}
+	}
+	if v := os.Getenv("LOGS_MAX_QUERY_EVENTS_LIMIT"); v != "" {
+		var n int
+		if _, err := fmt.Sscanf(v, "%d", &n); err == nil {
+			cfg.MaxQueryEventsLimit = n
+		}
+	}
+	if v := os.Getenv("LOGS_INGEST_BATCH_SIZE"); v != "" {
+		var size int
+		if _, err := fmt.Sscanf(v, "%d", &size); err == nil {
+			cfg.IngestBatchSize = size
+		}
+	}
+	if v := os.Getenv("LOGS_INGEST_BATCH_BYTES"); v != "" {
+		var size int
+		if _, err := fmt.Sscanf(v, "%d", &size); err == nil {
+			cfg.IngestBatchBytes = size
+		}
+	}
+	if v := os.Getenv("LOGS_CHAIN_MIN_OBSERVATIONS"); v != "" {
+		var n int
+		if _, err := fmt.Sscanf(v, "%d", &n); err == nil {
+			cfg.ChainMinObservations = n
+		}
+	}
+	if v := os.Getenv("LOGS_DEBUG_TRACE_MAX_BYTES"); v != "" {
+		var size int
+		if _, err := fmt.Sscanf(v, "%d", &size); err == nil {
+			cfg.DebugTraceMaxBytes = size
+		}
+	}
 }
 
 func loadBoolEnvs(cfg *Config) {
@@ -235,9 +534,78 @@ func loadBoolEnvs(cfg *Config) {
 	if v := os.Getenv("LOGS_ENABLE_AUDIT_LOG"); v != "" {
 		cfg.EnableAuditLog = v == "true" || v == "1"
 	}
+	if v := os.Getenv("LOGS_AUDIT_LOG_DESTINATION"); v != "" {
+		cfg.AuditLogDestination = v
+	}
 	if v := os.Getenv("LOGS_METRICS_ENDPOINT"); v != "" {
 		cfg.MetricsEndpoint = v == "true" || v == "1"
 	}
+	if v := os.Getenv("LOGS_SESSION_PERSISTENCE"); v != "" {
+		cfg.SessionPersistence = v == "true" || v == "1"
+	}
+	if v := os.Getenv("LOGS_STATELESS"); v != "" {
+		cfg.Stateless = v == "true" || v == "1"
+	}
+	if v := os.Getenv("LOGS_AUTO_CORRECT_QUERIES"); v != "" {
+		cfg.AutoCorrectQueries = v == "true" || v == "1"
+	}
+	if v := os.Getenv("LOGS_REDACT_SECRETS"); v != "" {
+		cfg.RedactSecrets = v == "true" || v == "1"
+	}
+	if v := os.Getenv("LOGS_WEBHOOK_ALLOW_HTTP"); v != "" {
+		cfg.WebhookAllowHTTP = v == "true" || v == "1"
+	}
+	if v := os.Getenv("LOGS_SKIP_ENDPOINT_VALIDATION"); v != "" {
+		cfg.SkipEndpointValidation = v == "true" || v == "1"
+	}
+	if v := os.Getenv("LOGS_DEBUG_TRACE"); v != "" {
+		cfg.DebugTrace = v == "true" || v == "1"
+	}
+}
+
+// ParseRedactionPatterns splits a ";"-separated list of regular expressions.
+// Semicolons are used because commas are common in regex quantifiers.
+func ParseRedactionPatterns(v string) []string {
+	var patterns []string
+	for _, p := range strings.Split(v, ";") {
+		if p = strings.TrimSpace(p); p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
+// ParseNameList splits a comma-separated list of names, such as tool or application names
+func ParseNameList(v string) []string {
+	var names []string
+	for _, name := range strings.Split(v, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ParseToolTimeouts parses per-tool timeouts in the form "query_logs=120s,list_alerts=10s".
+// Malformed entries are skipped.
+func ParseToolTimeouts(v string) map[string]time.Duration {
+	timeouts := make(map[string]time.Duration)
+	for _, entry := range strings.Split(v, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || name == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(strings.TrimSpace(value)); err == nil && d > 0 {
+			timeouts[strings.TrimSpace(name)] = d
+		}
+	}
+	return timeouts
+}
+
+// SeparateMetricsPort reports whether /metrics needs its own listener because it is
+// enabled on a port other than the health port
+func (c *Config) SeparateMetricsPort() bool {
+	return c.MetricsEndpoint && c.MetricsPort > 0 && c.MetricsPort != c.HealthPort
 }
 
 // Validate checks if the configuration is valid
@@ -245,8 +613,13 @@ func (c *Config) Validate() error {
 	if c.ServiceURL == "" {
 		return errors.New("LOGS_SERVICE_URL is required")
 	}
-	if c.APIKey == "" {
-		return errors.New("LOGS_API_KEY is required")
+	if c.APIKey == "" && c.IAMToken == "" {
+		return errors.New("LOGS_API_KEY or LOGS_IAM_TOKEN is required")
+	}
+	if !c.SkipEndpointValidation {
+		if err := ValidateServiceURL(c.ServiceURL, c.Region); err != nil {
+			return err
+		}
 	}
 	if c.Timeout <= 0 {
 		return errors.New("timeout must be positive")
@@ -257,17 +630,170 @@ func (c *Config) Validate() error {
 	if c.RateLimit <= 0 && c.EnableRateLimit {
 		return errors.New("rate_limit must be positive when rate limiting is enabled")
 	}
+	if c.RateLimitBurst < 0 {
+		return errors.New("rate_limit_burst must be non-negative")
+	}
+
+	// Zero values mean "use the default"
+	maxResult, finalLimit := c.ResponseLimits()
+	if maxResult < MinResultSize || maxResult > MaxAllowedResultSize {
+		return fmt.Errorf("max_result_size must be between %d and %d bytes, got %d", MinResultSize, MaxAllowedResultSize, maxResult)
+	}
+	if finalLimit < maxResult || finalLimit > MaxAllowedResultSize {
+		return fmt.Errorf("final_response_limit must be between max_result_size (%d) and %d bytes, got %d", maxResult, MaxAllowedResultSize, finalLimit)
+	}
+
+	if c.ProxyURL != "" {
+		u, err := url.Parse(c.ProxyURL)
+		if err != nil || u.Host == "" {
+			return fmt.Errorf("invalid proxy URL: must be like http://[user:password@]host:port")
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return fmt.Errorf("invalid proxy URL scheme %q (valid: http, https, socks5)", u.Scheme)
+		}
+	}
+	if c.CABundle != "" {
+		if _, err := os.Stat(c.CABundle); err != nil {
+			return fmt.Errorf("CA bundle not readable: %w", err)
+		}
+	}
+	if c.ClientCert != "" || c.ClientKey != "" {
+		if c.ClientCert == "" || c.ClientKey == "" {
+			return errors.New("LOGS_CLIENT_CERT and LOGS_CLIENT_KEY must be set together")
+		}
+		if _, err := tls.LoadX509KeyPair(c.ClientCert, c.ClientKey); err != nil {
+			return fmt.Errorf("invalid mutual TLS client certificate: %w", err)
+		}
+	}
+
+	if path, ok := strings.CutPrefix(c.AuditLogDestination, "file:"); ok {
+		if path == "" {
+			return errors.New("audit_log_destination file: needs a path, like file:/var/log/logs-mcp/audit.jsonl")
+		}
+	} else if c.AuditLogDestination != "" && c.AuditLogDestination != "ingest" {
+		return fmt.Errorf("invalid audit_log_destination %q (valid: file:<path>, ingest)", c.AuditLogDestination)
+	}
+
+	if c.DebugTraceMaxBytes < 0 {
+		return fmt.Errorf("debug_trace_max_bytes must not be negative")
+	}
+
+	if c.DisplayTimezone != "" {
+		if _, err := time.LoadLocation(c.DisplayTimezone); err != nil {
+			return fmt.Errorf("invalid display timezone %q: %w", c.DisplayTimezone, err)
+		}
+	}
+
+	if c.HealthPort < 0 || c.HealthPort > 65535 {
+		return fmt.Errorf("health_port must be between 0 and 65535, got %d", c.HealthPort)
+	}
+	if c.MetricsPort < 0 || c.MetricsPort > 65535 {
+		return fmt.Errorf("metrics_port must be between 0 and 65535, got %d", c.MetricsPort)
+	}
+
+	if c.QueryCacheTTL < 0 {
+		return errors.New("query_cache_ttl must be non-negative")
+	}
+
+	if c.ChainMinObservations < 0 {
+		return errors.New("chain_min_observations must be non-negative")
+	}
+
+	if c.MaxQueryEvents < 0 || c.MaxQueryEventsLimit < 0 {
+		return errors.New("max_query_events and max_query_events_limit must be non-negative")
+	}
+	if c.MaxQueryEvents > 0 && c.MaxQueryEventsLimit > 0 && c.MaxQueryEvents > c.MaxQueryEventsLimit {
+		return fmt.Errorf("max_query_events (%d) must not exceed max_query_events_limit (%d)", c.MaxQueryEvents, c.MaxQueryEventsLimit)
+	}
+
+	if c.IngestBatchSize < 0 || c.IngestBatchBytes < 0 {
+		return errors.New("ingest_batch_size and ingest_batch_bytes must be non-negative")
+	}
+
+	switch c.IngestCompression {
+	case "", IngestCompressionAuto, IngestCompressionAlways, IngestCompressionOff:
+	default:
+		return fmt.Errorf("invalid ingest compression: %s (valid: auto, always, off)", c.IngestCompression)
+	}
+
+	for _, p := range c.RedactionPatterns {
+		if _, err := regexp.Compile(p); err != nil {
+			return fmt.Errorf("invalid redaction pattern %q: %w", p, err)
+		}
+	}
+	for _, p := range c.PIIPatterns {
+		if _, err := regexp.Compile(p); err != nil {
+			return fmt.Errorf("invalid PII pattern %q: %w", p, err)
+		}
+	}
+	if c.RootCauseRulesFile != "" {
+		if _, err := os.Stat(c.RootCauseRulesFile); err != nil {
+			return fmt.Errorf("root cause rules file not readable: %w", err)
+		}
+	}
+
+	if err := c.validateInstances(); err != nil {
+		return err
+	}
 
 	validLogLevels := map[string]bool{
-		"debug": true, "info": true, "warn": true, "error": true,
+		"": true, "debug": true, "info": true, "warn": true, "error": true,
 	}
 	if !validLogLevels[strings.ToLower(c.LogLevel)] {
 		return fmt.Errorf("invalid log level: %s", c.LogLevel)
 	}
+	switch strings.ToLower(c.LogFormat) {
+	case "", "json", "console":
+	default:
+		return fmt.Errorf("invalid log format: %s (must be json or console)", c.LogFormat)
+	}
+
+	return nil
+}
+
+// validateInstances checks that additional instances have unique names and valid endpoints
+func (c *Config) validateInstances() error {
+	seen := map[string]bool{c.PrimaryInstanceName(): true}
+	for i, ic := range c.Instances {
+		if ic.Name == "" {
+			return fmt.Errorf("instances[%d]: name is required", i)
+		}
+		if seen[ic.Name] {
+			return fmt.Errorf("instances[%d]: duplicate instance name %q", i, ic.Name)
+		}
+		seen[ic.Name] = true
 
+		instanceCfg := c.ForInstance(ic)
+		if instanceCfg.ServiceURL == "" {
+			return fmt.Errorf("instance %q: service_url, or region and instance_id, is required", ic.Name)
+		}
+		if !c.SkipEndpointValidation {
+			if err := ValidateServiceURL(instanceCfg.ServiceURL, instanceCfg.Region); err != nil {
+				return fmt.Errorf("instance %q: %w", ic.Name, err)
+			}
+		}
+	}
 	return nil
 }
 
+// ResponseLimits returns the effective result size and final response limits,
+// substituting defaults for unset (zero) values
+func (c *Config) ResponseLimits() (maxResultSize, finalResponseLimit int) {
+	maxResultSize, finalResponseLimit = c.MaxResultSize, c.FinalResponseLimit
+	if maxResultSize == 0 {
+		maxResultSize = DefaultMaxResultSize
+	}
+	if finalResponseLimit == 0 {
+		finalResponseLimit = DefaultFinalResponseLimit
+		if maxResultSize > finalResponseLimit {
+			finalResponseLimit = maxResultSize
+		}
+	}
+	return maxResultSize, finalResponseLimit
+}
+
 // Redact returns a copy of the config with sensitive data removed
 func (c *Config) Redact() *Config {
 	redacted := *c
@@ -279,6 +805,23 @@ func (c *Config) Redact() *Config {
 			redacted.APIKey = "***REDACTED***"
 		}
 	}
+	if u, err := url.Parse(redacted.ProxyURL); err == nil && u.User != nil {
+		u.User = nil
+		redacted.ProxyURL = strings.Replace(u.String(), "://", "://***@", 1)
+	}
+	if redacted.IAMToken != "" {
+		redacted.IAMToken = "***REDACTED***"
+	}
+	if len(redacted.Instances) > 0 {
+		redacted.Instances = make([]InstanceConfig, len(c.Instances))
+		for i, ic := range c.Instances {
+			ic.APIKey = MaskAPIKey(ic.APIKey)
+			if ic.IAMToken != "" {
+				ic.IAMToken = "***REDACTED***"
+			}
+			redacted.Instances[i] = ic
+		}
+	}
 	return &redacted
 }
 
@@ -336,6 +879,60 @@ func ExtractRegionFromURL(serviceURL string) string {
 	return ""
 }
 
+// ValidRegions lists the IBM Cloud regions where IBM Cloud Logs is available
+var ValidRegions = []string{
+	"au-syd", "br-sao", "ca-tor", "eu-de", "eu-es", "eu-gb",
+	"in-che", "jp-osa", "jp-tok", "us-east", "us-south",
+}
+
+// IsValidRegion reports whether region is a known IBM Cloud Logs region
+func IsValidRegion(region string) bool {
+	for _, r := range ValidRegions {
+		if r == region {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateServiceURL checks that serviceURL has the IBM Cloud Logs endpoint shape
+// (https://<instance-id>.api.<region>.logs.cloud.ibm.com, optionally private, dev, or stage),
+// that its region is a known one, and that region, when set, matches the URL.
+func ValidateServiceURL(serviceURL, region string) error {
+	const shape = "https://<instance-id>.api.<region>.logs.cloud.ibm.com"
+
+	parsed, err := url.Parse(serviceURL)
+	if err != nil {
+		return fmt.Errorf("invalid LOGS_SERVICE_URL %q: %w (expected %s)", serviceURL, err, shape)
+	}
+	if parsed.Scheme != "https" {
+		return fmt.Errorf("invalid LOGS_SERVICE_URL %q: scheme must be https (expected %s)", serviceURL, shape)
+	}
+	if ExtractInstanceIDFromURL(serviceURL) == "" {
+		return fmt.Errorf("invalid LOGS_SERVICE_URL %q: missing instance ID (expected %s)", serviceURL, shape)
+	}
+
+	urlRegion := ExtractRegionFromURL(serviceURL)
+	if urlRegion == "" {
+		return fmt.Errorf("invalid LOGS_SERVICE_URL %q: host does not match %s", serviceURL, shape)
+	}
+
+	// Dev regions are "<env-name>.<region>"; only the region part is checked
+	baseRegion := urlRegion
+	if i := strings.LastIndex(urlRegion, "."); i >= 0 {
+		baseRegion = urlRegion[i+1:]
+	}
+	if !IsValidRegion(baseRegion) {
+		return fmt.Errorf("invalid LOGS_SERVICE_URL %q: unknown region %q (valid regions: %s)",
+			serviceURL, baseRegion, strings.Join(ValidRegions, ", "))
+	}
+
+	if region != "" && region != urlRegion {
+		return fmt.Errorf("LOGS_REGION %q does not match region %q in LOGS_SERVICE_URL %q", region, urlRegion, serviceURL)
+	}
+	return nil
+}
+
 // BuildServiceURL constructs an IBM Cloud Logs service URL from instance ID and region.
 // Returns the production API endpoint URL.
 func BuildServiceURL(instanceID, region string) string {
@@ -54,6 +54,45 @@ const (
 	CodeNetworkError ErrorCode = "NETWORK_ERROR"
 )
 
+// Tool error taxonomy. Every tool error result carries one of these codes so clients can
+// decide whether to retry, re-authenticate, or fix their input.
+const (
+	// CodeNotFound indicates the requested resource does not exist
+	CodeNotFound ErrorCode = "NOT_FOUND"
+	// CodeRateLimited indicates the request was throttled; retry after a delay
+	CodeRateLimited ErrorCode = "RATE_LIMITED"
+	// CodeUpstreamError indicates the IBM Cloud Logs API failed or was unreachable
+	CodeUpstreamError ErrorCode = "UPSTREAM_ERROR"
+)
+
+// CodeForHTTPStatus maps an HTTP status code to the tool error taxonomy
+// (NOT_FOUND, UNAUTHORIZED, RATE_LIMITED, INVALID_INPUT, UPSTREAM_ERROR, TIMEOUT)
+func CodeForHTTPStatus(statusCode int) ErrorCode {
+	switch {
+	case statusCode == 401 || statusCode == 403:
+		return CodeUnauthorized
+	case statusCode == 404:
+		return CodeNotFound
+	case statusCode == 408 || statusCode == 504:
+		return CodeTimeout
+	case statusCode == 429:
+		return CodeRateLimited
+	case statusCode >= 400 && statusCode < 500:
+		return CodeInvalidInput
+	default:
+		return CodeUpstreamError
+	}
+}
+
+// IsRetryable reports whether an operation that failed with code may succeed if retried unchanged
+func IsRetryable(code ErrorCode) bool {
+	switch code {
+	case CodeRateLimited, CodeTimeout, CodeUpstreamError:
+		return true
+	}
+	return false
+}
+
 // StructuredError represents a detailed error with category, code, and recovery suggestion
 type StructuredError struct {
 	Code       ErrorCode     `json:"code"`
@@ -270,3 +270,37 @@ func containsMiddle(s, substr string) bool {
 	}
 	return false
 }
+
+func TestCodeForHTTPStatus(t *testing.T) {
+	tests := map[int]ErrorCode{
+		400: CodeInvalidInput,
+		401: CodeUnauthorized,
+		403: CodeUnauthorized,
+		404: CodeNotFound,
+		408: CodeTimeout,
+		409: CodeInvalidInput,
+		422: CodeInvalidInput,
+		429: CodeRateLimited,
+		500: CodeUpstreamError,
+		502: CodeUpstreamError,
+		504: CodeTimeout,
+	}
+	for status, want := range tests {
+		if got := CodeForHTTPStatus(status); got != want {
+			t.Errorf("CodeForHTTPStatus(%d) = %s, want %s", status, got, want)
+		}
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	for _, code := range []ErrorCode{CodeRateLimited, CodeTimeout, CodeUpstreamError} {
+		if !IsRetryable(code) {
+			t.Errorf("%s should be retryable", code)
+		}
+	}
+	for _, code := range []ErrorCode{CodeNotFound, CodeUnauthorized, CodeInvalidInput} {
+		if IsRetryable(code) {
+			t.Errorf("%s should not be retryable", code)
+		}
+	}
+}
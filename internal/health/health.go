@@ -4,6 +4,7 @@ package health
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
@@ -43,6 +44,9 @@ type Checker struct {
 	client        client.Doer
 	authenticator TokenValidator
 	logger        *zap.Logger
+
+	// lastPing is the UnixNano time of the last successful authenticated API call
+	lastPing atomic.Int64
 }
 
 // New creates a new health checker
@@ -71,10 +75,45 @@ func (c *Checker) CheckAll(ctx context.Context) (Status, []Check) {
 			overallStatus = StatusDegraded
 		}
 	}
+	if overallStatus == StatusHealthy {
+		c.lastPing.Store(time.Now().UnixNano())
+	}
 
 	return overallStatus, checks
 }
 
+// Ping validates the token and makes a cheap authenticated API call, recording the
+// time on success so readiness probes can reuse it
+func (c *Checker) Ping(ctx context.Context) error {
+	if err := c.authenticator.ValidateToken(); err != nil {
+		return fmt.Errorf("authentication failed: %w", err)
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if _, err := c.client.Do(pingCtx, pingRequest()); err != nil {
+		return fmt.Errorf("API unreachable: %w", err)
+	}
+	c.lastPing.Store(time.Now().UnixNano())
+	return nil
+}
+
+// PingedWithin reports whether an authenticated API call succeeded within the last d
+func (c *Checker) PingedWithin(d time.Duration) bool {
+	last := c.lastPing.Load()
+	return last != 0 && time.Since(time.Unix(0, last)) <= d
+}
+
+// pingRequest is the cheapest authenticated call: list alerts with limit 1
+func pingRequest() *client.Request {
+	return &client.Request{
+		Method: "GET",
+		Path:   "/v1/alerts",
+		Query:  map[string]string{"limit": "1"},
+	}
+}
+
 // checkAuthentication verifies authentication is working
 func (c *Checker) checkAuthentication() Check {
 	start := time.Now()
@@ -112,18 +151,11 @@ func (c *Checker) checkAPIConnectivity(ctx context.Context) Check {
 		Timestamp: start,
 	}
 
-	// Try a simple API call (list alerts with limit 1)
-	req := &client.Request{
-		Method: "GET",
-		Path:   "/v1/alerts",
-		Query:  map[string]string{"limit": "1"},
-	}
-
 	// Use a short timeout for health checks
 	checkCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	_, err := c.client.Do(checkCtx, req)
+	_, err := c.client.Do(checkCtx, pingRequest())
 	check.Duration = time.Since(start)
 
 	if err != nil {
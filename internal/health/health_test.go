@@ -488,3 +488,85 @@ func TestHealthHandler_WithChecker_Unhealthy(t *testing.T) {
 	}
 	assertJSONContains(t, w.Body.Bytes(), "unhealthy")
 }
+
+func TestHealthzHandler_Alive(t *testing.T) {
+	srv := newTestServer()
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	w := httptest.NewRecorder()
+	srv.httpServer.Handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("/healthz should return 200, got %d", w.Code)
+	}
+	assertJSONContains(t, w.Body.Bytes(), "alive")
+}
+
+func TestReadyzHandler_RequiresSuccessfulPing(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.DefaultResponse = &client.Response{StatusCode: 200, Body: []byte(`{}`)}
+	checker := New(mock, &mockTokenValidator{}, zap.NewNop())
+	srv := NewServer(checker, zap.NewNop(), 0, "127.0.0.1", false)
+
+	serve := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		srv.httpServer.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		return w
+	}
+
+	if w := serve(); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("/readyz before SetReady should return 503, got %d", w.Code)
+	}
+
+	srv.SetReady(true)
+	if w := serve(); w.Code != http.StatusOK {
+		t.Errorf("/readyz with a reachable API should return 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if mock.RequestCount() != 1 {
+		t.Fatalf("expected one ping, got %d", mock.RequestCount())
+	}
+
+	// A recent success is reused instead of pinging on every probe
+	serve()
+	if mock.RequestCount() != 1 {
+		t.Errorf("expected the recent ping to be reused, got %d requests", mock.RequestCount())
+	}
+}
+
+func TestReadyzHandler_PingFailure(t *testing.T) {
+	mock := client.NewMockClient()
+	checker := New(mock, &mockTokenValidator{err: errors.New("token expired")}, zap.NewNop())
+	srv := NewServer(checker, zap.NewNop(), 0, "127.0.0.1", false)
+	srv.SetReady(true)
+
+	w := httptest.NewRecorder()
+	srv.httpServer.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("/readyz with failing auth should return 503, got %d", w.Code)
+	}
+	assertJSONContains(t, w.Body.Bytes(), "token expired")
+	if checker.PingedWithin(time.Minute) {
+		t.Error("a failed ping must not count as recent success")
+	}
+}
+
+func TestNewMetricsServer_OnlyServesMetrics(t *testing.T) {
+	srv := NewMetricsServer(zap.NewNop(), 9100, "")
+
+	if srv.httpServer.Addr != "127.0.0.1:9100" {
+		t.Errorf("metrics server address = %s, want 127.0.0.1:9100", srv.httpServer.Addr)
+	}
+
+	w := httptest.NewRecorder()
+	srv.httpServer.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("/metrics should return 200, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	srv.httpServer.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("/readyz should not be served by the metrics server, got %d", w.Code)
+	}
+}
@@ -13,11 +13,15 @@ import (
 	"go.uber.org/zap"
 )
 
+// readyzPingTTL is how long a successful API ping keeps /readyz ready before it pings again
+const readyzPingTTL = 30 * time.Second
+
 // Server provides HTTP endpoints for health checks and metrics.
 // It exposes:
-//   - /health - Full health check with component status
-//   - /ready  - Readiness probe for K8s (checks if server can handle requests)
-//   - /live   - Liveness probe for K8s (checks if server is running)
+//   - /health  - Full health check with component status
+//   - /ready   - Readiness probe for K8s (checks if server can handle requests)
+//   - /readyz  - Readiness probe that also requires a recent authenticated API ping
+//   - /live, /healthz - Liveness probe for K8s (checks if server is running)
 //   - /metrics - Prometheus metrics (if enabled)
 type Server struct {
 	checker        *Checker
@@ -51,21 +55,46 @@ func NewServer(checker *Checker, logger *zap.Logger, port int, bindAddr string,
 	mux.HandleFunc("/health", s.healthHandler)
 	mux.HandleFunc("/ready", s.readyHandler)
 	mux.HandleFunc("/live", s.liveHandler)
+	mux.HandleFunc("/healthz", s.liveHandler)
+	mux.HandleFunc("/readyz", s.readyzHandler)
 
 	if metricsEnabled {
 		mux.Handle("/metrics", promhttp.Handler())
 	}
 
-	s.httpServer = &http.Server{
+	s.httpServer = newHTTPServer(bindAddr, port, mux)
+
+	return s
+}
+
+// NewMetricsServer creates an HTTP server that only exposes /metrics, for when
+// metrics are served on a different port than the health endpoints.
+func NewMetricsServer(logger *zap.Logger, port int, bindAddr string) *Server {
+	if bindAddr == "" {
+		bindAddr = "127.0.0.1"
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return &Server{
+		logger:         logger,
+		port:           port,
+		metricsEnabled: true,
+		httpServer:     newHTTPServer(bindAddr, port, mux),
+	}
+}
+
+// newHTTPServer builds the listener shared by the health and metrics servers
+func newHTTPServer(bindAddr string, port int, handler http.Handler) *http.Server {
+	return &http.Server{
 		Addr:              fmt.Sprintf("%s:%d", bindAddr, port),
-		Handler:           mux,
+		Handler:           handler,
 		ReadTimeout:       5 * time.Second,
 		WriteTimeout:      10 * time.Second,
 		IdleTimeout:       60 * time.Second,
 		ReadHeaderTimeout: 2 * time.Second,
 	}
-
-	return s
 }
 
 // SetReady marks the server as ready to handle requests.
@@ -155,6 +184,36 @@ func (s *Server) readyHandler(w http.ResponseWriter, r *http.Request) {
 	_, _ = w.Write([]byte(`{"status":"ready"}`))
 }
 
+// readyzHandler handles the /readyz endpoint.
+// Returns 200 once the server is started (its config validated) and an authenticated
+// API call succeeded within readyzPingTTL, pinging the API when the last success is stale.
+func (s *Server) readyzHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if !s.ready.Load() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"status":"not_ready"}`))
+		return
+	}
+
+	if s.checker != nil && !s.checker.PingedWithin(readyzPingTTL) {
+		if err := s.checker.Ping(r.Context()); err != nil {
+			s.logger.Warn("Readiness ping failed", zap.Error(err))
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "not_ready", "reason": err.Error()})
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(`{"status":"ready"}`))
+}
+
 // liveHandler handles the /live endpoint (K8s liveness probe).
 // Returns 200 if the server process is running.
 // This is a simple check - if we can respond, we're alive.
@@ -0,0 +1,100 @@
+// Package logging builds the server's own zap logger.
+package logging
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/security"
+)
+
+// New builds a logger from the environment preset ("production" or anything else for
+// development), then applies level and format when set. An empty level or format keeps the
+// preset's value: info/json in production, debug/console in development.
+// Every entry passes through a redacting core so secrets are never written, even at debug level.
+func New(environment, level, format string) (*zap.Logger, error) {
+	var cfg zap.Config
+	if environment == "production" {
+		cfg = zap.NewProductionConfig()
+	} else {
+		cfg = zap.NewDevelopmentConfig()
+	}
+
+	if level != "" {
+		lvl, err := zapcore.ParseLevel(strings.ToLower(level))
+		if err != nil {
+			return nil, fmt.Errorf("invalid log level %q: %w", level, err)
+		}
+		cfg.Level = zap.NewAtomicLevelAt(lvl)
+	}
+
+	switch strings.ToLower(format) {
+	case "":
+	case "json", "console":
+		cfg.Encoding = strings.ToLower(format)
+	default:
+		return nil, fmt.Errorf("invalid log format %q: must be json or console", format)
+	}
+
+	return cfg.Build(zap.WrapCore(NewRedactingCore))
+}
+
+// redactingCore masks secrets in log messages and fields before they reach the wrapped core
+type redactingCore struct {
+	zapcore.Core
+	redactor *security.Redactor
+}
+
+// NewRedactingCore wraps core so that messages, string fields, and errors are passed through
+// the default secret patterns, and string fields with sensitive names (api_key, token,
+// password, ...) are masked entirely.
+func NewRedactingCore(core zapcore.Core) zapcore.Core {
+	return &redactingCore{Core: core, redactor: security.NewRedactor()}
+}
+
+// With redacts the fields added to the child logger
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{Core: c.Core.With(c.redactFields(fields)), redactor: c.redactor}
+}
+
+// Check adds this core (rather than the wrapped one) so Write sees every entry
+func (c *redactingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write redacts the entry message and fields before writing them
+func (c *redactingCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ent.Message = c.redactor.Redact(ent.Message)
+	return c.Core.Write(ent, c.redactFields(fields))
+}
+
+// redactFields returns a copy of fields with secret values masked
+func (c *redactingCore) redactFields(fields []zapcore.Field) []zapcore.Field {
+	out := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		switch f.Type {
+		case zapcore.StringType:
+			if security.IsSensitiveField(f.Key) && f.String != "" {
+				f.String = security.RedactedPlaceholder
+			} else {
+				f.String = c.redactor.Redact(f.String)
+			}
+		case zapcore.ErrorType:
+			if err, ok := f.Interface.(error); ok && err != nil {
+				f = zap.String(f.Key, c.redactor.Redact(err.Error()))
+			}
+		case zapcore.StringerType:
+			if s, ok := f.Interface.(fmt.Stringer); ok && s != nil {
+				f = zap.String(f.Key, c.redactor.Redact(s.String()))
+			}
+		}
+		out[i] = f
+	}
+	return out
+}
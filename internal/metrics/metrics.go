@@ -14,9 +14,11 @@ import (
 
 // Prometheus metric labels
 const (
-	labelTool   = "tool"
-	labelStatus = "status"
-	labelType   = "type"
+	labelTool      = "tool"
+	labelStatus    = "status"
+	labelType      = "type"
+	labelErrorCode = "error_code"
+	labelResult    = "result"
 )
 
 // Metrics tracks operational metrics with both internal counters and Prometheus metrics
@@ -46,6 +48,10 @@ type Metrics struct {
 	toolErrors  map[string]uint64
 	toolLatency map[string]int64 // microseconds
 
+	// Result cache lookups
+	cacheHits   atomic.Uint64
+	cacheMisses atomic.Uint64
+
 	logger *zap.Logger
 
 	// Prometheus metrics
@@ -59,6 +65,9 @@ type Metrics struct {
 	promToolCalls          *prometheus.CounterVec
 	promToolErrors         *prometheus.CounterVec
 	promToolLatency        *prometheus.HistogramVec
+	promToolErrorsByCode   *prometheus.CounterVec
+	promAPIResponses       *prometheus.CounterVec
+	promCacheLookups       *prometheus.CounterVec
 }
 
 // New creates a new metrics tracker with Prometheus integration using the default registry.
@@ -133,6 +142,21 @@ func NewWithRegistry(logger *zap.Logger, reg prometheus.Registerer) *Metrics {
 			Help:      "Tool execution latency in seconds, labeled by tool name",
 			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 15), // 1ms to ~16s
 		}, []string{labelTool}),
+		promToolErrorsByCode: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "logs_mcp",
+			Name:      "tool_errors_by_code_total",
+			Help:      "Tool errors labeled by tool name and error code (e.g., NOT_FOUND, TIMEOUT)",
+		}, []string{labelTool, labelErrorCode}),
+		promAPIResponses: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "logs_mcp",
+			Name:      "api_responses_total",
+			Help:      "IBM Cloud Logs API responses by HTTP status code (\"error\" when no response was received)",
+		}, []string{labelStatus}),
+		promCacheLookups: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "logs_mcp",
+			Name:      "cache_lookups_total",
+			Help:      "Result cache lookups labeled by tool name and result (hit or miss)",
+		}, []string{labelTool, labelResult}),
 	}
 
 	// Initialize min latency to max value
@@ -149,6 +173,7 @@ func (m *Metrics) RecordRequest(success bool, latency time.Duration, statusCode
 	// Update Prometheus counters
 	m.promRequestsTotal.Inc()
 	m.promRequestLatency.Observe(latency.Seconds())
+	m.promAPIResponses.WithLabelValues(statusLabel(statusCode)).Inc()
 
 	if success {
 		m.successfulRequests.Add(1)
@@ -205,6 +230,34 @@ func (m *Metrics) RecordToolExecution(toolName string, success bool, latency tim
 	}
 }
 
+// RecordToolError records the error code of a failed tool call
+func (m *Metrics) RecordToolError(toolName, code string) {
+	if code == "" {
+		code = "UNKNOWN"
+	}
+	m.promToolErrorsByCode.WithLabelValues(toolName, code).Inc()
+}
+
+// RecordCacheLookup records a result cache hit or miss for a tool
+func (m *Metrics) RecordCacheLookup(toolName string, hit bool) {
+	result := "miss"
+	if hit {
+		m.cacheHits.Add(1)
+		result = "hit"
+	} else {
+		m.cacheMisses.Add(1)
+	}
+	m.promCacheLookups.WithLabelValues(toolName, result).Inc()
+}
+
+// statusLabel is the api_responses_total label for a status code; 0 means no response
+func statusLabel(statusCode int) string {
+	if statusCode == 0 {
+		return "error"
+	}
+	return fmt.Sprintf("%d", statusCode)
+}
+
 func (m *Metrics) recordLatency(latency time.Duration) {
 	latencyUs := latency.Microseconds()
 
@@ -274,6 +327,12 @@ func (m *Metrics) GetStats() Stats {
 	totalReq := m.totalRequests.Load()
 	latencyCount := m.latencyCount.Load()
 
+	var cacheHitRatio float64
+	cacheHits, cacheMisses := m.cacheHits.Load(), m.cacheMisses.Load()
+	if lookups := cacheHits + cacheMisses; lookups > 0 {
+		cacheHitRatio = float64(cacheHits) / float64(lookups)
+	}
+
 	var avgLatency time.Duration
 	if latencyCount > 0 {
 		// Use float64 division to avoid integer overflow issues
@@ -294,6 +353,9 @@ func (m *Metrics) GetStats() Stats {
 		ToolUsage:          toolUsage,
 		ToolErrors:         toolErrors,
 		ToolLatency:        toolLatency,
+		CacheHits:          cacheHits,
+		CacheMisses:        cacheMisses,
+		CacheHitRatio:      cacheHitRatio,
 	}
 }
 
@@ -318,6 +380,7 @@ func (m *Metrics) LogStats() {
 		zap.Duration("min_latency", stats.MinLatency),
 		zap.Any("errors_by_status", stats.ErrorsByStatus),
 		zap.Any("tool_usage", stats.ToolUsage),
+		zap.Float64("cache_hit_ratio", stats.CacheHitRatio),
 	)
 }
 
@@ -335,6 +398,9 @@ type Stats struct {
 	ToolUsage          map[string]uint64
 	ToolErrors         map[string]uint64
 	ToolLatency        map[string]time.Duration
+	CacheHits          uint64
+	CacheMisses        uint64
+	CacheHitRatio      float64 // hits / (hits + misses), 0 before any lookup
 }
 
 // GetPrometheusRegistry returns the default Prometheus registry
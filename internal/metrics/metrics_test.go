@@ -16,6 +16,30 @@ func newTestMetrics(t *testing.T) *Metrics {
 	return NewWithRegistry(zap.NewNop(), reg)
 }
 
+// counterValue returns the value of the counter in reg with the given name and labels.
+func counterValue(t *testing.T, reg *prometheus.Registry, name string, labels map[string]string) float64 {
+	t.Helper()
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather() failed: %v", err)
+	}
+	for _, mf := range families {
+		if mf.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, metric := range mf.GetMetric() {
+			for _, lp := range metric.GetLabel() {
+				if labels[lp.GetName()] != lp.GetValue() {
+					continue metrics
+				}
+			}
+			return metric.GetCounter().GetValue()
+		}
+	}
+	return 0
+}
+
 func TestRecordRequest_Success(t *testing.T) {
 	m := newTestMetrics(t)
 
@@ -256,3 +280,59 @@ func TestGetPrometheusRegistry(t *testing.T) {
 		t.Fatalf("GetPrometheusRegistry() returned %T, want *prometheus.Registry", reg)
 	}
 }
+
+func TestRecordRequest_CountsEveryStatus(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewWithRegistry(zap.NewNop(), reg)
+
+	m.RecordRequest(true, time.Millisecond, 200)
+	m.RecordRequest(true, time.Millisecond, 200)
+	m.RecordRequest(false, time.Millisecond, 404)
+	m.RecordRequest(false, time.Millisecond, 0)
+
+	for status, want := range map[string]float64{"200": 2, "404": 1, "error": 1} {
+		if got := counterValue(t, reg, "logs_mcp_api_responses_total", map[string]string{"status": status}); got != want {
+			t.Errorf("api_responses_total{status=%q} = %v, want %v", status, got, want)
+		}
+	}
+}
+
+func TestRecordToolError(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewWithRegistry(zap.NewNop(), reg)
+
+	m.RecordToolError("get_alert", "NOT_FOUND")
+	m.RecordToolError("get_alert", "NOT_FOUND")
+	m.RecordToolError("query_logs", "")
+
+	if got := counterValue(t, reg, "logs_mcp_tool_errors_by_code_total", map[string]string{"tool": "get_alert", "error_code": "NOT_FOUND"}); got != 2 {
+		t.Errorf("tool_errors_by_code_total{get_alert,NOT_FOUND} = %v, want 2", got)
+	}
+	if got := counterValue(t, reg, "logs_mcp_tool_errors_by_code_total", map[string]string{"tool": "query_logs", "error_code": "UNKNOWN"}); got != 1 {
+		t.Errorf("tool_errors_by_code_total{query_logs,UNKNOWN} = %v, want 1", got)
+	}
+}
+
+func TestRecordCacheLookup_HitRatio(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewWithRegistry(zap.NewNop(), reg)
+	if m.GetStats().CacheHitRatio != 0 {
+		t.Error("CacheHitRatio should be 0 before any lookup")
+	}
+
+	m.RecordCacheLookup("list_alerts", true)
+	m.RecordCacheLookup("list_alerts", true)
+	m.RecordCacheLookup("list_alerts", true)
+	m.RecordCacheLookup("list_streams", false)
+
+	stats := m.GetStats()
+	if stats.CacheHits != 3 || stats.CacheMisses != 1 {
+		t.Errorf("cache hits/misses = %d/%d, want 3/1", stats.CacheHits, stats.CacheMisses)
+	}
+	if stats.CacheHitRatio != 0.75 {
+		t.Errorf("CacheHitRatio = %v, want 0.75", stats.CacheHitRatio)
+	}
+	if got := counterValue(t, reg, "logs_mcp_cache_lookups_total", map[string]string{"tool": "list_streams", "result": "miss"}); got != 1 {
+		t.Errorf("cache_lookups_total{list_streams,miss} = %v, want 1", got)
+	}
+}
@@ -7,6 +7,7 @@ package prompts
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
 	"time"
 
@@ -75,8 +76,12 @@ func NewRegistry(logger *zap.Logger) *Registry {
 	return r
 }
 
-// SetContextProvider sets the session context provider for context-aware prompts
+// SetContextProvider sets the session context provider for context-aware prompts.
+// A nil provider, including a typed nil pointer, disables session context.
 func (r *Registry) SetContextProvider(provider SessionContextProvider) {
+	if v := reflect.ValueOf(provider); v.Kind() == reflect.Ptr && v.IsNil() {
+		provider = nil
+	}
 	r.contextProvider = provider
 }
 
@@ -127,6 +132,32 @@ func getStringArg(args map[string]string, key, defaultVal string) string {
 	return defaultVal
 }
 
+// Verbosity levels accepted by the workflow prompts' "verbosity" argument
+const (
+	verbosityConcise  = "concise"
+	verbosityStandard = "standard"
+	verbosityDetailed = "detailed"
+)
+
+// verbosityArgument returns the optional argument that controls how much prose a workflow prompt returns
+func verbosityArgument() *mcp.PromptArgument {
+	return &mcp.PromptArgument{
+		Name:        "verbosity",
+		Description: "Output detail: 'concise' (ordered tool calls only), 'standard' (default), or 'detailed' (adds rationale and tips)",
+		Required:    false,
+	}
+}
+
+// getVerbosity returns the requested verbosity level, falling back to standard for unknown values
+func getVerbosity(args map[string]string) string {
+	switch v := strings.ToLower(getStringArg(args, "verbosity", verbosityStandard)); v {
+	case verbosityConcise, verbosityDetailed:
+		return v
+	default:
+		return verbosityStandard
+	}
+}
+
 // investigateErrorsPrompt creates the "investigate_errors" prompt definition
 func (r *Registry) investigateErrorsPrompt() *PromptDefinition {
 	return &PromptDefinition{
@@ -140,10 +171,21 @@ func (r *Registry) investigateErrorsPrompt() *PromptDefinition {
 					Description: "Time range to investigate (e.g., '1h', '24h', '7d')",
 					Required:    false,
 				},
+				verbosityArgument(),
 			},
 		},
 		Handler: func(_ context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
 			timeRange := getStringArg(req.Params.Arguments, "time_range", "1h")
+			verbosity := getVerbosity(req.Params.Arguments)
+
+			if verbosity == verbosityConcise {
+				content := fmt.Sprintf(`Investigate errors (last %s):
+1. query_logs query="level:error" time_range="%s"
+2. list_alerts
+3. get_alert_definition alert_definition_id=<from step 2>
+4. list_policies`, timeRange, timeRange)
+				return createPromptResult("Investigate error spikes workflow", content), nil
+			}
 
 			content := fmt.Sprintf(`Let's investigate recent error spikes in your IBM Cloud Logs. I'll help you:
 
@@ -161,6 +203,20 @@ To get started, please use these tools in sequence:
 
 I'll help you correlate the errors with alerts and policies to identify the root cause.`, timeRange, timeRange)
 
+			if verbosity == verbosityDetailed {
+				content += `
+
+**Why each step matters:**
+- The error query establishes when the spike started and which applications are affected. Group the results by application and subsystem to separate one noisy service from a platform-wide problem.
+- Triggered alerts confirm whether monitoring caught the spike. If nothing fired, the thresholds may be too loose for this failure mode.
+- Alert definitions show the exact condition and threshold, so you can judge whether the alert was tuned for this kind of spike.
+- Policies can drop or downgrade logs before they are stored. A policy that blocks an application's logs can hide errors or make a spike look smaller than it is.
+
+**Tips:**
+- Widen the time range to include the period before the spike, so there is a baseline to compare against.
+- Record findings as you go so the investigation can be resumed with continue_investigation.`
+			}
+
 			return createPromptResult("Investigate error spikes workflow", content), nil
 		},
 	}
@@ -179,10 +235,22 @@ func (r *Registry) setupMonitoringPrompt() *PromptDefinition {
 					Description: "Name of the service to monitor",
 					Required:    false,
 				},
+				verbosityArgument(),
 			},
 		},
 		Handler: func(_ context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
 			serviceName := getStringArg(req.Params.Arguments, "service_name", "your-service")
+			verbosity := getVerbosity(req.Params.Arguments)
+
+			if verbosity == verbosityConcise {
+				content := fmt.Sprintf(`Set up monitoring for %s:
+1. create_alert_def name="%s High Error Rate" severity="high"
+2. create_outgoing_webhook name="%s Alerts" url=<notification endpoint>
+3. create_alert alert_definition_id=<from step 1> webhook_id=<from step 2>
+4. (optional) create_policy name="%s Logs" priority="high" application_name="%s"`,
+					serviceName, serviceName, serviceName, serviceName, serviceName)
+				return createPromptResult("Setup monitoring workflow", content), nil
+			}
 
 			content := fmt.Sprintf(`I'll help you set up comprehensive monitoring for %s. Here's what we'll create:
 
@@ -219,6 +287,20 @@ Set up log retention and routing:
 
 Would you like to proceed with these steps? I'll guide you through each one.`, serviceName, serviceName, serviceName, serviceName, serviceName)
 
+			if verbosity == verbosityDetailed {
+				content += `
+
+**Why each step matters:**
+- The alert definition decides what counts as a problem. Base the threshold on the service's normal error rate rather than a fixed number, or the alert will be either noisy or silent.
+- The webhook is created separately so several alerts can share one notification channel.
+- Linking the alert to the webhook is what actually delivers notifications. An alert without a webhook only shows up in the UI.
+- A high-priority policy keeps the service's logs in frequent search, so the logs an alert points to can be queried quickly.
+
+**Tips:**
+- Run query_logs for the service first to check that its logs arrive under the expected application name.
+- Start with a single alert on error rate and add latency or volume alerts once it has proven useful.`
+			}
+
 			return createPromptResult("Setup monitoring workflow", content), nil
 		},
 	}
@@ -288,10 +370,24 @@ func (r *Registry) debuggingWorkflowPrompt() *PromptDefinition {
 					Description: "Error message or pattern to search for",
 					Required:    false,
 				},
+				verbosityArgument(),
 			},
 		},
 		Handler: func(_ context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
 			errorMessage := getStringArg(req.Params.Arguments, "error_message", "your error message")
+			verbosity := getVerbosity(req.Params.Arguments)
+
+			if verbosity == verbosityConcise {
+				content := fmt.Sprintf(`Debug "%s":
+1. query_logs query="%s" time_range="1h" (widen if no results)
+2. list_enrichments
+3. list_policies
+4. list_data_access_rules
+5. list_alerts
+6. list_e2m
+7. list_views`, errorMessage, errorMessage)
+				return createPromptResult("Debugging workflow", content), nil
+			}
 
 			content := fmt.Sprintf(`Let's debug this issue systematically. I'll guide you through a structured debugging workflow:
 
@@ -324,6 +420,20 @@ Based on the findings, I'll help you:
 
 Let's start with searching for the error in recent logs.`, errorMessage)
 
+			if verbosity == verbosityDetailed {
+				content += `
+
+**Why each step matters:**
+- Searching a short window first keeps results fast and focused. Widen it only to find when the error first appeared.
+- Timestamps and affected components show whether the error is constant, periodic, or tied to a deployment or traffic peak.
+- Enrichments, policies, and data access rules change what is stored and who can see it. A missing or reshaped log is often a configuration issue rather than an application bug.
+- Alerts, E2M metrics, and views that already cover the error show what the team knew and when.
+
+**Tips:**
+- Copy a distinctive fragment of the message, such as an error code, rather than the whole line. Variable parts like IDs and timestamps stop exact matches.
+- Once the cause is known, create an alert on the same query so a recurrence is caught early.`
+			}
+
 			return createPromptResult("Debugging workflow", content), nil
 		},
 	}
@@ -567,15 +567,53 @@ func TestCreatePromptResult(t *testing.T) {
 	}
 }
 
+func TestWorkflowPromptVerbosity(t *testing.T) {
+	registry := NewRegistry(zap.NewNop())
+	prompts := make(map[string]*PromptDefinition)
+	for _, p := range registry.GetPrompts() {
+		prompts[p.Prompt.Name] = p
+	}
+
+	for _, name := range []string{"investigate_errors", "setup_monitoring", "debugging_workflow"} {
+		t.Run(name, func(t *testing.T) {
+			texts := make(map[string]string)
+			for _, verbosity := range []string{"", "concise", "standard", "detailed", "unknown"} {
+				req := &mcp.GetPromptRequest{Params: &mcp.GetPromptParams{
+					Arguments: map[string]string{"verbosity": verbosity},
+				}}
+				result, err := prompts[name].Handler(context.Background(), req)
+				if err != nil {
+					t.Fatalf("Handler(%q) returned error: %v", verbosity, err)
+				}
+				texts[verbosity] = result.Messages[0].Content.(*mcp.TextContent).Text
+			}
+
+			if texts[""] != texts["standard"] || texts["unknown"] != texts["standard"] {
+				t.Error("missing or unknown verbosity should default to standard")
+			}
+			if len(texts["concise"]) >= len(texts["standard"]) {
+				t.Errorf("concise output (%d chars) should be shorter than standard (%d chars)",
+					len(texts["concise"]), len(texts["standard"]))
+			}
+			if !containsString(texts["concise"], "1. ") {
+				t.Error("concise output should list the ordered tool calls")
+			}
+			if !containsString(texts["detailed"], texts["standard"]) || !containsString(texts["detailed"], "Why each step matters") {
+				t.Error("detailed output should extend the standard explanation")
+			}
+		})
+	}
+}
+
 func TestPromptArgumentsDefinition(t *testing.T) {
 	logger := zap.NewNop()
 	registry := NewRegistry(logger)
 
 	expectedArgs := map[string][]string{
-		"investigate_errors":        {"time_range"},
-		"setup_monitoring":          {"service_name"},
+		"investigate_errors":        {"time_range", "verbosity"},
+		"setup_monitoring":          {"service_name", "verbosity"},
 		"compare_environments":      {"time_range"},
-		"debugging_workflow":        {"error_message"},
+		"debugging_workflow":        {"error_message", "verbosity"},
 		"optimize_retention":        {},
 		"test_log_ingestion":        {"application_name"},
 		"create_dashboard_workflow": {"dashboard_name"},
@@ -994,3 +1032,32 @@ func TestContainsAny(t *testing.T) {
 		}
 	}
 }
+
+func TestContextAwarePromptWithNilSessionProvider(t *testing.T) {
+	registry := NewRegistry(zap.NewNop())
+	var provider *MockContextProvider
+	registry.SetContextProvider(provider)
+
+	var prompt *PromptDefinition
+	for _, p := range registry.GetPrompts() {
+		if p.Prompt.Name == "context_aware_assist" {
+			prompt = p
+			break
+		}
+	}
+	if prompt == nil {
+		t.Fatal("context_aware_assist prompt not found")
+	}
+
+	result, err := prompt.Handler(context.Background(), &mcp.GetPromptRequest{Params: &mcp.GetPromptParams{}})
+	if err != nil {
+		t.Fatalf("Handler returned error: %v", err)
+	}
+	content, ok := result.Messages[0].Content.(*mcp.TextContent)
+	if !ok {
+		t.Fatal("Message content is not TextContent")
+	}
+	if !containsString(content.Text, "Session context not available") {
+		t.Errorf("Expected no-context guidance for a nil provider, got %q", content.Text)
+	}
+}
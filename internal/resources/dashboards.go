@@ -0,0 +1,82 @@
+package resources
+
+import (
+	"context"
+	"sort"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+	"github.com/tareqmamari/cloud-logs-mcp/internal/tools"
+)
+
+// DashboardURIPrefix is the URI scheme of dashboard resources (dashboard://<id>)
+const DashboardURIPrefix = "dashboard://"
+
+// NewDashboardResources exposes dashboards as dashboard://<id> resources whose read returns a
+// markdown description of the dashboard's sections, widgets and widget queries
+func NewDashboardResources(apiClient client.Doer, logger *zap.Logger) *ListedResources {
+	return &ListedResources{
+		prefix: DashboardURIPrefix,
+		template: mcp.ResourceTemplate{
+			URITemplate: DashboardURIPrefix + "{id}",
+			Name:        "dashboard",
+			Title:       "Dashboard",
+			Description: "Dashboard sections, widgets and widget queries by dashboard ID",
+			MIMEType:    "text/markdown",
+		},
+		list: func(ctx context.Context) ([]*mcp.Resource, error) {
+			res, err := tools.NewListDashboardsTool(apiClient, logger).ListDashboards(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return dashboardResourceList(res), nil
+		},
+		read: func(ctx context.Context, id string) (*mcp.ResourceContents, error) {
+			dashboard, err := tools.NewGetDashboardTool(apiClient, logger).GetDashboard(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			return &mcp.ResourceContents{MIMEType: "text/markdown", Text: tools.DescribeDashboard(dashboard)}, nil
+		},
+		logger:     logger,
+		registered: make(map[string]string),
+	}
+}
+
+// dashboardResourceList converts a list_dashboards response into resource definitions, sorted by URI
+func dashboardResourceList(res map[string]interface{}) []*mcp.Resource {
+	items, ok := res["items"].([]interface{})
+	if !ok {
+		items, _ = res["dashboards"].([]interface{})
+	}
+	resources := make([]*mcp.Resource, 0, len(items))
+	for _, item := range items {
+		dashboard, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id := resourceID(dashboard["id"])
+		if id == "" {
+			continue
+		}
+		name, _ := dashboard["name"].(string)
+		if name == "" {
+			name = "Dashboard " + id
+		}
+		description, _ := dashboard["description"].(string)
+		if description == "" {
+			description = "Dashboard"
+		}
+		resources = append(resources, &mcp.Resource{
+			URI:         DashboardURIPrefix + id,
+			Name:        name,
+			Title:       name,
+			Description: description,
+			MIMEType:    "text/markdown",
+		})
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].URI < resources[j].URI })
+	return resources
+}
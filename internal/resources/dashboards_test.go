@@ -0,0 +1,71 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestDashboardResources_ListAndRead(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.RespondWith(http.StatusOK, map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"id": "dash-b", "name": "Latency", "description": "p99 latency"},
+			map[string]interface{}{"id": "dash-a", "name": "Errors"},
+		},
+	})
+	mock.RespondWith(http.StatusOK, map[string]interface{}{
+		"id":   "dash-a",
+		"name": "Errors",
+		"layout": map[string]interface{}{"sections": []interface{}{
+			map[string]interface{}{"rows": []interface{}{
+				map[string]interface{}{"widgets": []interface{}{
+					map[string]interface{}{
+						"title": "Error count",
+						"definition": map[string]interface{}{"data_table": map[string]interface{}{
+							"query": map[string]interface{}{"logs": map[string]interface{}{
+								"lucene_query": map[string]interface{}{"value": "severity:error"},
+							}},
+						}},
+					},
+				}},
+			}},
+		}},
+	})
+
+	session := connectListedServer(t, NewDashboardResources(mock, zap.NewNop()))
+	ctx := context.Background()
+
+	list, err := session.ListResources(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListResources failed: %v", err)
+	}
+	if len(list.Resources) != 2 {
+		t.Fatalf("expected 2 dashboard resources, got %d", len(list.Resources))
+	}
+	r := list.Resources[0]
+	if r.URI != "dashboard://dash-a" || r.Name != "Errors" || r.MIMEType != "text/markdown" {
+		t.Errorf("unexpected resource %s (%s, %s)", r.URI, r.Name, r.MIMEType)
+	}
+	if list.Resources[1].Description != "p99 latency" {
+		t.Errorf("Description = %q", list.Resources[1].Description)
+	}
+
+	read, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: r.URI})
+	if err != nil {
+		t.Fatalf("ReadResource failed: %v", err)
+	}
+	text := read.Contents[0].Text
+	if !strings.Contains(text, "**Error count** (data table)") || !strings.Contains(text, "`severity:error`") {
+		t.Errorf("unexpected dashboard description: %s", text)
+	}
+	if req := mock.LastRequest(); req.Path != "/v1/dashboards/dash-a" {
+		t.Errorf("read should fetch the dashboard by ID, got %s", req.Path)
+	}
+}
@@ -0,0 +1,130 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/tools"
+)
+
+// listedRefreshInterval bounds how often resources/list re-fetches a resource kind
+const listedRefreshInterval = 30 * time.Second
+
+// ListedResources exposes API objects of one kind (views, dashboards) as MCP resources with
+// URIs of the form <prefix><id>. The objects are listed from the API when clients list
+// resources, and any object can be read by ID through the resource template.
+type ListedResources struct {
+	prefix   string
+	template mcp.ResourceTemplate
+	list     func(ctx context.Context) ([]*mcp.Resource, error)
+	read     func(ctx context.Context, id string) (*mcp.ResourceContents, error)
+	logger   *zap.Logger
+
+	mu          sync.Mutex
+	lastRefresh time.Time
+	registered  map[string]string // URI -> name, as last added to the server
+}
+
+// Template returns the <prefix>{id} resource template
+func (l *ListedResources) Template() mcp.ResourceTemplate {
+	return l.template
+}
+
+// Handler reads a <prefix><id> resource
+func (l *ListedResources) Handler() mcp.ResourceHandler {
+	return func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		uri := req.Params.URI
+		id := strings.TrimPrefix(uri, l.prefix)
+		if id == "" || id == uri || strings.Contains(id, "/") {
+			return nil, mcp.ResourceNotFoundError(uri)
+		}
+
+		contents, err := l.read(ctx, id)
+		if err != nil {
+			var apiErr *tools.APIError
+			if errors.As(err, &apiErr) && apiErr.IsNotFound() {
+				return nil, mcp.ResourceNotFoundError(uri)
+			}
+			return nil, fmt.Errorf("failed to read %s: %w", uri, err)
+		}
+		contents.URI = uri
+		return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{contents}}, nil
+	}
+}
+
+// Middleware refreshes the resources registered on server before resources/list is
+// answered. Refreshes are rate limited and failures keep the previous list.
+func (l *ListedResources) Middleware(server *mcp.Server) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if method == "resources/list" {
+				if err := l.Refresh(ctx, server); err != nil {
+					l.logger.Warn("Failed to refresh listed resources", zap.String("uri_template", l.template.URITemplate), zap.Error(err))
+				}
+			}
+			return next(ctx, method, req)
+		}
+	}
+}
+
+// Refresh lists the objects and adds, updates, or removes their resources on server.
+// The server is only changed when the set changed, so clients aren't sent needless
+// list-changed notifications.
+func (l *ListedResources) Refresh(ctx context.Context, server *mcp.Server) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if time.Since(l.lastRefresh) < listedRefreshInterval {
+		return nil
+	}
+
+	current, err := l.list(ctx)
+	if err != nil {
+		return err
+	}
+	l.lastRefresh = time.Now()
+
+	seen := make(map[string]bool, len(current))
+	handler := l.Handler()
+	for _, r := range current {
+		seen[r.URI] = true
+		if name, ok := l.registered[r.URI]; ok && name == r.Name {
+			continue
+		}
+		server.AddResource(r, handler)
+		l.registered[r.URI] = r.Name
+	}
+
+	var stale []string
+	for uri := range l.registered {
+		if !seen[uri] {
+			stale = append(stale, uri)
+			delete(l.registered, uri)
+		}
+	}
+	if len(stale) > 0 {
+		sort.Strings(stale)
+		server.RemoveResources(stale...)
+	}
+	return nil
+}
+
+// resourceID renders an object ID, which the API may return as a number
+func resourceID(raw interface{}) string {
+	switch id := raw.(type) {
+	case string:
+		return id
+	case float64:
+		return strconv.FormatFloat(id, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
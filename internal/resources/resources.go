@@ -143,6 +143,9 @@ func (r *Registry) configResource() RegisteredResource {
 				"log_format":             r.config.LogFormat,
 				"server_version":         r.version,
 				"api_key_configured":     r.config.APIKey != "",
+				"iam_token_configured":   r.config.IAMToken != "",
+				"proxy_configured":       r.config.ProxyURL != "",
+				"ca_bundle":              r.config.CABundle,
 			}
 
 			content, err := json.MarshalIndent(safeConfig, "", "  ")
@@ -0,0 +1,109 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+	"github.com/tareqmamari/cloud-logs-mcp/internal/tools"
+)
+
+// ViewURIPrefix is the URI scheme of saved view resources (view://<id>)
+const ViewURIPrefix = "view://"
+
+// NewViewResources exposes saved views as view://<id> resources whose read returns the view's
+// query and filter configuration, fetched the same way as the get_view tool
+func NewViewResources(apiClient client.Doer, logger *zap.Logger) *ListedResources {
+	return &ListedResources{
+		prefix: ViewURIPrefix,
+		template: mcp.ResourceTemplate{
+			URITemplate: ViewURIPrefix + "{id}",
+			Name:        "view",
+			Title:       "Saved View",
+			Description: "Saved view query and filter configuration by view ID",
+			MIMEType:    "application/json",
+		},
+		list: func(ctx context.Context) ([]*mcp.Resource, error) {
+			res, err := tools.NewListViewsTool(apiClient, logger).ListViews(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return viewResourceList(res), nil
+		},
+		read: func(ctx context.Context, id string) (*mcp.ResourceContents, error) {
+			view, err := tools.NewGetViewTool(apiClient, logger).GetView(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			content, err := json.MarshalIndent(view, "", "  ")
+			if err != nil {
+				return nil, err
+			}
+			return &mcp.ResourceContents{MIMEType: "application/json", Text: string(content)}, nil
+		},
+		logger:     logger,
+		registered: make(map[string]string),
+	}
+}
+
+// viewResourceList converts a list_views response into resource definitions, sorted by URI
+func viewResourceList(res map[string]interface{}) []*mcp.Resource {
+	views, _ := res["views"].([]interface{})
+	resources := make([]*mcp.Resource, 0, len(views))
+	for _, item := range views {
+		view, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id := resourceID(view["id"])
+		if id == "" {
+			continue
+		}
+		name, _ := view["name"].(string)
+		if name == "" {
+			name = "View " + id
+		}
+		resources = append(resources, &mcp.Resource{
+			URI:         ViewURIPrefix + id,
+			Name:        name,
+			Title:       name,
+			Description: viewDescription(view),
+			MIMEType:    "application/json",
+		})
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].URI < resources[j].URI })
+	return resources
+}
+
+// viewDescription summarizes a view's query and filters
+func viewDescription(view map[string]interface{}) string {
+	var parts []string
+	if sq, ok := view["search_query"].(map[string]interface{}); ok {
+		if q, _ := sq["query"].(string); q != "" {
+			parts = append(parts, "Query: "+q)
+		}
+	}
+	if f, ok := view["filters"].(map[string]interface{}); ok {
+		filters, _ := f["filters"].([]interface{})
+		var names []string
+		for _, item := range filters {
+			if fm, ok := item.(map[string]interface{}); ok {
+				if n, _ := fm["name"].(string); n != "" {
+					names = append(names, n)
+				}
+			}
+		}
+		if len(names) > 0 {
+			parts = append(parts, "Filters: "+strings.Join(names, ", "))
+		}
+	}
+	if len(parts) == 0 {
+		return "Saved view"
+	}
+	return strings.Join(parts, "; ")
+}
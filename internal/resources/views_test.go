@@ -0,0 +1,127 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// connectListedServer serves the listed resources over an in-memory transport and returns the client session
+func connectListedServer(t *testing.T, views *ListedResources) *mcp.ClientSession {
+	t.Helper()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "0.0.1"}, &mcp.ServerOptions{HasResources: true})
+	tmpl := views.Template()
+	server.AddResourceTemplate(&tmpl, views.Handler())
+	server.AddReceivingMiddleware(views.Middleware(server))
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if _, err := server.Connect(ctx, serverTransport, nil); err != nil {
+		t.Fatalf("server Connect failed: %v", err)
+	}
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "0.0.1"}, nil).Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client Connect failed: %v", err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+func TestViewResources_ListAndRead(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.RespondWith(http.StatusOK, map[string]interface{}{
+		"views": []interface{}{
+			map[string]interface{}{
+				"id":           1234567890,
+				"name":         "Checkout errors",
+				"search_query": map[string]interface{}{"query": "level:error"},
+				"filters": map[string]interface{}{"filters": []interface{}{
+					map[string]interface{}{"name": "applicationName"},
+				}},
+			},
+		},
+	})
+	mock.RespondWith(http.StatusOK, map[string]interface{}{"id": 1234567890, "name": "Checkout errors"})
+
+	session := connectListedServer(t, NewViewResources(mock, zap.NewNop()))
+	ctx := context.Background()
+
+	list, err := session.ListResources(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListResources failed: %v", err)
+	}
+	if len(list.Resources) != 1 {
+		t.Fatalf("expected 1 view resource, got %d", len(list.Resources))
+	}
+	r := list.Resources[0]
+	if r.URI != "view://1234567890" || r.Name != "Checkout errors" {
+		t.Errorf("unexpected resource %s (%s)", r.URI, r.Name)
+	}
+	if r.Description != "Query: level:error; Filters: applicationName" {
+		t.Errorf("Description = %q", r.Description)
+	}
+
+	read, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: r.URI})
+	if err != nil {
+		t.Fatalf("ReadResource failed: %v", err)
+	}
+	if !strings.Contains(read.Contents[0].Text, "Checkout errors") {
+		t.Errorf("unexpected view content: %s", read.Contents[0].Text)
+	}
+	if req := mock.LastRequest(); req.Path != "/v1/views/1234567890" {
+		t.Errorf("read should fetch the view by ID, got %s", req.Path)
+	}
+}
+
+func TestViewResources_ReadNotFound(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.RespondWith(http.StatusNotFound, map[string]interface{}{"message": "not found"})
+
+	handler := NewViewResources(mock, zap.NewNop()).Handler()
+	_, err := handler(context.Background(), &mcp.ReadResourceRequest{Params: &mcp.ReadResourceParams{URI: "view://42"}})
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected resource not found error, got %v", err)
+	}
+}
+
+func TestViewResources_RefreshRemovesStale(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.RespondWith(http.StatusOK, map[string]interface{}{"views": []interface{}{
+		map[string]interface{}{"id": 1, "name": "A"},
+		map[string]interface{}{"id": 2, "name": "B"},
+	}})
+	mock.RespondWith(http.StatusOK, map[string]interface{}{"views": []interface{}{
+		map[string]interface{}{"id": 2, "name": "B renamed"},
+	}})
+
+	views := NewViewResources(mock, zap.NewNop())
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "0.0.1"}, nil)
+	ctx := context.Background()
+
+	if err := views.Refresh(ctx, server); err != nil {
+		t.Fatal(err)
+	}
+	if len(views.registered) != 2 {
+		t.Fatalf("registered = %v", views.registered)
+	}
+
+	// A second refresh inside the interval is skipped
+	if err := views.Refresh(ctx, server); err != nil || mock.RequestCount() != 1 {
+		t.Fatalf("expected rate-limited refresh, requests=%d err=%v", mock.RequestCount(), err)
+	}
+
+	views.lastRefresh = views.lastRefresh.Add(-listedRefreshInterval)
+	if err := views.Refresh(ctx, server); err != nil {
+		t.Fatal(err)
+	}
+	if len(views.registered) != 1 || views.registered["view://2"] != "B renamed" {
+		t.Errorf("registered = %v, want only the renamed view 2", views.registered)
+	}
+}
@@ -0,0 +1,98 @@
+package security
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// PIIPattern is a named detector for personal data. Matches are replaced with Placeholder.
+// When Validate is set, a match is only masked if Validate accepts it.
+type PIIPattern struct {
+	Name        string
+	Pattern     *regexp.Regexp
+	Placeholder string
+	Validate    func(match string) bool
+}
+
+// DefaultPIIPatterns are the detectors applied by query_logs with redact_pii. Card numbers
+// are matched before phone numbers so grouped card digits are not taken for a phone number.
+var DefaultPIIPatterns = []PIIPattern{
+	{Name: "email", Pattern: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`), Placeholder: "[email]"},
+	{Name: "credit_card", Pattern: regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`), Placeholder: "[card]", Validate: luhnValid},
+	{Name: "ipv4", Pattern: regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\.){3}(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\b`), Placeholder: "[ip]"},
+	{Name: "ipv6", Pattern: regexp.MustCompile(`(?i)\b(?:[0-9a-f]{1,4}:){7}[0-9a-f]{1,4}\b|\b(?:[0-9a-f]{1,4}:){1,6}:[0-9a-f]{1,4}(?::[0-9a-f]{1,4}){0,5}\b`), Placeholder: "[ip]"},
+	{Name: "phone", Pattern: regexp.MustCompile(`(?:\+\d{1,3}[ .-]?)?(?:\(\d{3}\) ?|\b\d{3}[ .-])\d{3}[ .-]\d{4}\b`), Placeholder: "[phone]"},
+}
+
+// PIIRedactor masks personal data in log content using a configurable pattern set.
+// It is separate from Redactor, which masks credentials in every response.
+type PIIRedactor struct {
+	mu       sync.RWMutex
+	patterns []PIIPattern
+}
+
+// NewPIIRedactor creates a redactor using DefaultPIIPatterns
+func NewPIIRedactor() *PIIRedactor {
+	return &PIIRedactor{patterns: append([]PIIPattern(nil), DefaultPIIPatterns...)}
+}
+
+// Configure replaces the pattern set with the defaults plus the given extra regular
+// expressions, whose matches are replaced with "[pii]"
+func (r *PIIRedactor) Configure(extra []string) error {
+	patterns := append([]PIIPattern(nil), DefaultPIIPatterns...)
+	for i, expr := range extra {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return fmt.Errorf("invalid PII pattern %q: %w", expr, err)
+		}
+		patterns = append(patterns, PIIPattern{Name: fmt.Sprintf("custom_%d", i+1), Pattern: re, Placeholder: "[pii]"})
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.patterns = patterns
+	return nil
+}
+
+// Redact masks every match of the pattern set and returns the text with the number of
+// values masked
+func (r *PIIRedactor) Redact(text string) (string, int) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, p := range r.patterns {
+		text = p.Pattern.ReplaceAllStringFunc(text, func(match string) string {
+			if p.Validate != nil && !p.Validate(match) {
+				return match
+			}
+			count++
+			return p.Placeholder
+		})
+	}
+	return text, count
+}
+
+// luhnValid reports whether the digits of s pass the Luhn checksum used by card numbers
+func luhnValid(s string) bool {
+	sum, n := 0, 0
+	double := false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+		n++
+	}
+	return n >= 13 && sum%10 == 0
+}
@@ -0,0 +1,90 @@
+package security
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPIIRedactorDefaultPatterns(t *testing.T) {
+	r := NewPIIRedactor()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+		count int
+	}{
+		{
+			name:  "email in auth failure",
+			input: "Authentication failed for user john.doe+ops@example.co.uk from 10.42.0.17",
+			want:  "Authentication failed for user [email] from [ip]",
+			count: 2,
+		},
+		{
+			name:  "card number in payment error",
+			input: "payment declined card=4111 1111 1111 1111 amount=42.00",
+			want:  "payment declined card=[card] amount=42.00",
+			count: 1,
+		},
+		{
+			name:  "dashed card number",
+			input: "tokenizing 5500-0000-0000-0004",
+			want:  "tokenizing [card]",
+			count: 1,
+		},
+		{
+			name:  "phone numbers",
+			input: "SMS to +1 415-555-0132 failed, fallback (212) 555-0199",
+			want:  "SMS to [phone] failed, fallback [phone]",
+			count: 2,
+		},
+		{
+			name:  "ipv6 client",
+			input: "client 2001:db8:85a3::8a2e:370:7334 disconnected",
+			want:  "client [ip] disconnected",
+			count: 1,
+		},
+		{
+			name:  "access log line",
+			input: `192.168.1.20 - - [01/May/2024:20:47:12 +0000] "GET /users/jane@corp.io HTTP/1.1" 500 512`,
+			want:  `[ip] - - [01/May/2024:20:47:12 +0000] "GET /users/[email] HTTP/1.1" 500 512`,
+			count: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, count := r.Redact(tt.input)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.count, count)
+		})
+	}
+}
+
+func TestPIIRedactorLeavesOrdinaryLogLinesAlone(t *testing.T) {
+	r := NewPIIRedactor()
+	lines := []string{
+		"2024-05-01T20:47:12.123Z request completed in 1234ms",
+		"order 1234567890123 shipped", // 13 digits that fail the Luhn check
+		"upstream timeout after 30s (attempt 3/5)",
+		"version 1.27.3 deployed to us-south",
+	}
+	for _, line := range lines {
+		got, count := r.Redact(line)
+		assert.Equal(t, line, got)
+		assert.Zero(t, count)
+	}
+}
+
+func TestPIIRedactorConfigure(t *testing.T) {
+	r := NewPIIRedactor()
+	require.NoError(t, r.Configure([]string{`EMP-\d{6}`}))
+
+	got, count := r.Redact("employee EMP-123456 logged in from 10.0.0.1")
+	assert.Equal(t, "employee [pii] logged in from [ip]", got)
+	assert.Equal(t, 2, count)
+
+	assert.Error(t, r.Configure([]string{"("}))
+}
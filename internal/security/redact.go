@@ -0,0 +1,84 @@
+package security
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// RedactedPlaceholder replaces secret values in redacted text
+const RedactedPlaceholder = "***redacted***"
+
+// SecretPattern is a named pattern for secrets that must not appear in tool responses.
+// Matches are replaced with Replacement (regexp expansion syntax); when empty, the first
+// capture group is kept and the rest replaced, so surrounding context stays readable.
+type SecretPattern struct {
+	Name        string
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+// defaultReplacement keeps the first capture group and redacts the remainder of the match
+const defaultReplacement = "${1}" + RedactedPlaceholder
+
+// DefaultSecretPatterns are the secret patterns redacted from every tool response
+var DefaultSecretPatterns = []SecretPattern{
+	{Name: "bearer_token", Pattern: regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9\-._~+/]{16,}=*`)},
+	{Name: "slack_webhook", Pattern: regexp.MustCompile(`(hooks\.slack\.com/(?:services|workflows|triggers)/)[A-Za-z0-9_/-]+`)},
+	{Name: "slack_token", Pattern: regexp.MustCompile(`xox[abposr]-[A-Za-z0-9-]{10,}`)},
+	{Name: "pagerduty_key", Pattern: regexp.MustCompile(`(?i)("(?:routing_key|service_key|integration_key)"\s*:\s*")[^"]+`)},
+	{Name: "api_key", Pattern: regexp.MustCompile(`(?i)((?:api[_-]?key|access[_-]?token|auth[_-]?token|client[_-]?secret|password|passwd)"?\s*[:=]\s*"?)[^"'\s,&}]{6,}`)},
+	{Name: "url_basic_auth", Pattern: regexp.MustCompile(`([a-zA-Z][a-zA-Z0-9+.-]*://)[^/\s:@"]+:[^/\s@"]+@`), Replacement: "${1}" + RedactedPlaceholder + "@"},
+}
+
+// Redactor replaces secrets in text using a configurable pattern set
+type Redactor struct {
+	mu       sync.RWMutex
+	enabled  bool
+	patterns []SecretPattern
+}
+
+// NewRedactor creates an enabled redactor using DefaultSecretPatterns
+func NewRedactor() *Redactor {
+	return &Redactor{
+		enabled:  true,
+		patterns: append([]SecretPattern(nil), DefaultSecretPatterns...),
+	}
+}
+
+// Configure enables or disables redaction and replaces the pattern set with the
+// defaults plus the given extra regular expressions
+func (r *Redactor) Configure(enabled bool, extra []string) error {
+	patterns := append([]SecretPattern(nil), DefaultSecretPatterns...)
+	for i, expr := range extra {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return fmt.Errorf("invalid redaction pattern %q: %w", expr, err)
+		}
+		patterns = append(patterns, SecretPattern{Name: fmt.Sprintf("custom_%d", i+1), Pattern: re})
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.enabled = enabled
+	r.patterns = patterns
+	return nil
+}
+
+// Redact replaces every secret matched by the pattern set with RedactedPlaceholder
+func (r *Redactor) Redact(text string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if !r.enabled {
+		return text
+	}
+
+	for _, p := range r.patterns {
+		replacement := p.Replacement
+		if replacement == "" {
+			replacement = defaultReplacement
+		}
+		text = p.Pattern.ReplaceAllString(text, replacement)
+	}
+	return text
+}
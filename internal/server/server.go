@@ -5,11 +5,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"go.uber.org/zap"
 
+	"github.com/tareqmamari/cloud-logs-mcp/internal/audit"
 	"github.com/tareqmamari/cloud-logs-mcp/internal/auth"
 	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
 	"github.com/tareqmamari/cloud-logs-mcp/internal/config"
@@ -27,16 +30,22 @@ type Authenticator interface {
 	GetUserIdentity() (string, error)
 }
 
+// auditQueueSize bounds the audit entries waiting to be ingested into the instance
+const auditQueueSize = 1000
+
 // Server represents the MCP server
 type Server struct {
 	mcpServer     *mcp.Server
 	apiClient     client.Doer
+	clients       map[string]client.Doer // All instance clients by name, including apiClient
 	config        *config.Config
 	logger        *zap.Logger
 	metrics       *metrics.Metrics
 	version       string
 	healthServer  *health.Server
+	metricsServer *health.Server
 	authenticator Authenticator
+	audit         *audit.Logger
 }
 
 // New creates a new MCP server instance using real IBM Cloud credentials.
@@ -48,17 +57,48 @@ func New(cfg *config.Config, logger *zap.Logger, version string) (*Server, error
 	}
 
 	// Create authenticator for health checks
-	authenticator, err := auth.New(cfg.APIKey, cfg.IAMURL, logger)
+	transport, err := client.NewTransport(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure HTTP transport: %w", err)
+	}
+	authenticator, err := auth.NewWithOptions(auth.Options{
+		APIKey:     cfg.APIKey,
+		IAMToken:   cfg.IAMToken,
+		IAMURL:     cfg.IAMURL,
+		HTTPClient: &http.Client{Transport: transport, Timeout: cfg.Timeout},
+	}, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create authenticator: %w", err)
 	}
 
-	return NewWithDeps(cfg, apiClient, authenticator, logger, version)
+	// Additional instances share the primary's settings but get their own client
+	clients := map[string]client.Doer{cfg.PrimaryInstanceName(): apiClient}
+	for _, ic := range cfg.Instances {
+		instanceClient, err := client.New(cfg.ForInstance(ic), logger, version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create API client for instance %q: %w", ic.Name, err)
+		}
+		clients[ic.Name] = instanceClient
+	}
+
+	return NewWithInstances(cfg, clients, authenticator, logger, version)
 }
 
 // NewWithDeps creates a new MCP server instance with injectable dependencies.
 // This constructor enables testing with mock clients and authenticators.
 func NewWithDeps(cfg *config.Config, apiClient client.Doer, authenticator Authenticator, logger *zap.Logger, version string) (*Server, error) {
+	return NewWithInstances(cfg, map[string]client.Doer{cfg.PrimaryInstanceName(): apiClient}, authenticator, logger, version)
+}
+
+// NewWithInstances creates a new MCP server instance that routes tool calls to the named
+// instance clients. clients must include the primary instance (cfg.PrimaryInstanceName()),
+// which handles calls without an instance argument.
+func NewWithInstances(cfg *config.Config, clients map[string]client.Doer, authenticator Authenticator, logger *zap.Logger, version string) (*Server, error) {
+	apiClient, ok := clients[cfg.PrimaryInstanceName()]
+	if !ok {
+		return nil, fmt.Errorf("no API client for primary instance %q", cfg.PrimaryInstanceName())
+	}
+
 	// Create MCP server with tools, prompts, and resources capabilities
 	mcpServer := mcp.NewServer(&mcp.Implementation{
 		Name:    "IBM Cloud Logs MCP Server",
@@ -70,6 +110,48 @@ func NewWithDeps(cfg *config.Config, apiClient client.Doer, authenticator Authen
 	})
 
 	metricsTracker := metrics.New(logger)
+	tools.SetMetrics(metricsTracker)
+
+	// Stateless mode keeps no session at all, so it overrides session persistence
+	tools.SetStatelessMode(cfg.Stateless)
+	if cfg.Stateless {
+		if cfg.SessionPersistence {
+			logger.Warn("Session persistence is ignored in stateless mode")
+		}
+		logger.Info("Running stateless: session context is not kept between requests")
+	}
+
+	// Session persistence is opt-in so ephemeral deployments don't write to disk
+	if cfg.SessionPersistence && !cfg.Stateless {
+		tools.EnableSessionPersistence(cfg.SessionDir, tools.DefaultSessionSaveDebounce, logger)
+		logger.Info("Session persistence enabled", zap.String("session_dir", cfg.SessionDir))
+	}
+
+	// Scheduled queries keep their own file so they survive restarts without session persistence
+	if !cfg.Stateless {
+		tools.EnableScheduledQueryStore(cfg.ScheduleFile, logger)
+	}
+
+	tools.SetExportDir(cfg.ExportDir)
+	tools.SetInstanceClients(cfg.PrimaryInstanceName(), clients)
+	tools.SetAutoCorrectQueries(cfg.AutoCorrectQueries)
+	if err := tools.SetSecretRedaction(cfg.RedactSecrets, cfg.RedactionPatterns); err != nil {
+		return nil, fmt.Errorf("invalid secret redaction config: %w", err)
+	}
+	tools.SetWebhookAllowHTTP(cfg.WebhookAllowHTTP)
+	if err := tools.SetPIIPatterns(cfg.PIIPatterns); err != nil {
+		return nil, fmt.Errorf("invalid PII pattern config: %w", err)
+	}
+	if cfg.RootCauseRulesFile != "" {
+		n, err := tools.LoadRootCauseRules(cfg.RootCauseRulesFile)
+		if err != nil {
+			return nil, fmt.Errorf("invalid root cause rules: %w", err)
+		}
+		logger.Info("Loaded custom root cause rules", zap.Int("rules", n), zap.String("file", cfg.RootCauseRulesFile))
+	}
+	if err := tools.SetTimeDisplay(cfg.DisplayTimezone, cfg.DisplayTimeFormat); err != nil {
+		return nil, fmt.Errorf("invalid timestamp display config: %w", err)
+	}
 
 	// Initialize user-specific session using JWT subject from IAM token
 	// The subject uniquely identifies the user/service across sessions
@@ -79,7 +161,11 @@ func NewWithDeps(cfg *config.Config, apiClient client.Doer, authenticator Authen
 		logger.Warn("Could not get user identity from token, using API key hash",
 			zap.Error(err),
 		)
-		tools.SetCurrentUser(cfg.APIKey, cfg.InstanceID)
+		credential := cfg.APIKey
+		if credential == "" {
+			credential = cfg.IAMToken
+		}
+		tools.SetCurrentUser(credential, cfg.InstanceID)
 	} else {
 		tools.SetCurrentUserFromJWT(userID, cfg.InstanceID)
 		logger.Debug("Initialized user session from JWT",
@@ -88,20 +174,93 @@ func NewWithDeps(cfg *config.Config, apiClient client.Doer, authenticator Authen
 		)
 	}
 
+	// Apply configured response size limits
+	maxResultSize, finalResponseLimit := cfg.ResponseLimits()
+	if maxResultSize > config.LargeResultSizeWarning {
+		logger.Warn("Max result size is set very high; large tool results may break client context compaction",
+			zap.Int("max_result_size", maxResultSize),
+			zap.Int("recommended_max", config.DefaultMaxResultSize),
+		)
+	}
+	tools.SetResponseLimits(maxResultSize, finalResponseLimit)
+	tools.SetToolTimeoutOverrides(cfg.ToolTimeouts)
+	toolAccess, err := tools.NewToolAccessPolicy(cfg.ReadOnlyMode, cfg.EnabledTools, cfg.DisabledTools)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tool access config: %w", err)
+	}
+	tools.SetToolAccessPolicy(toolAccess)
+	if cfg.ReadOnlyMode {
+		logger.Info("Read-only mode enabled: tools that create, update, or delete are not exposed")
+	}
+	tools.SetRequireConfirmation(cfg.RequireConfirmation)
+	if cfg.RequireConfirmation {
+		logger.Info("Destructive tools require a confirmation token before deleting")
+	}
+	tools.SetApplicationScope(cfg.AllowedApplications, cfg.DeniedApplications)
+	if len(cfg.AllowedApplications) > 0 || len(cfg.DeniedApplications) > 0 {
+		logger.Info("Log queries are restricted by application",
+			zap.Strings("allowed_applications", cfg.AllowedApplications),
+			zap.Strings("denied_applications", cfg.DeniedApplications))
+	}
+	tools.SetQueryEventLimits(cfg.MaxQueryEvents, cfg.MaxQueryEventsLimit)
+	tools.SetQueryCacheTTL(cfg.QueryCacheTTL)
+	tools.SetIngestBatchLimits(cfg.IngestBatchSize, cfg.IngestBatchBytes)
+	tools.SetChainLearning(cfg.ChainDecayHalfLife, cfg.ChainMinObservations)
+	tools.SetServerInfo(tools.ServerInfo{
+		Version: version,
+		Features: map[string]bool{
+			"rate_limiting":        cfg.EnableRateLimit,
+			"tracing":              cfg.EnableTracing,
+			"audit_log":            cfg.EnableAuditLog,
+			"metrics_endpoint":     cfg.MetricsEndpoint,
+			"secret_redaction":     cfg.RedactSecrets,
+			"session_persistence":  cfg.SessionPersistence && !cfg.Stateless,
+			"stateless":            cfg.Stateless,
+			"read_only_mode":       cfg.ReadOnlyMode,
+			"require_confirmation": cfg.RequireConfirmation,
+			"application_scope":    len(cfg.AllowedApplications) > 0 || len(cfg.DeniedApplications) > 0,
+		},
+	})
+
+	auditLogger := audit.NewLogger(logger, cfg.EnableAuditLog)
+	if cfg.AuditLogDestination != "" {
+		if !cfg.EnableAuditLog {
+			logger.Warn("audit_log_destination is ignored because audit logging is disabled")
+		} else if path, ok := strings.CutPrefix(cfg.AuditLogDestination, "file:"); ok {
+			sink, err := audit.NewFileSink(path)
+			if err != nil {
+				return nil, err
+			}
+			auditLogger.SetSink(sink)
+			logger.Info("Writing audit trail to file", zap.String("path", path))
+		} else {
+			auditLogger.SetSink(audit.NewAsyncSink(tools.NewIngestAuditSink(apiClient), auditQueueSize, logger))
+			logger.Info("Ingesting audit trail into the Cloud Logs instance",
+				zap.String("application", "logs-mcp"), zap.String("subsystem", "audit"))
+		}
+	}
+
 	s := &Server{
 		mcpServer:     mcpServer,
 		apiClient:     apiClient,
+		clients:       clients,
 		config:        cfg,
 		logger:        logger,
 		metrics:       metricsTracker,
 		version:       version,
 		authenticator: authenticator,
+		audit:         auditLogger,
 	}
 
-	// Create health server if port is configured (port > 0)
+	// Create health server if port is configured (port > 0). Metrics share its
+	// listener unless they are given a port of their own.
 	if cfg.HealthPort > 0 {
 		healthChecker := health.New(apiClient, authenticator, logger)
-		s.healthServer = health.NewServer(healthChecker, logger, cfg.HealthPort, cfg.HealthBindAddr, cfg.MetricsEndpoint)
+		s.healthServer = health.NewServer(healthChecker, logger, cfg.HealthPort, cfg.HealthBindAddr,
+			cfg.MetricsEndpoint && !cfg.SeparateMetricsPort())
+	}
+	if cfg.SeparateMetricsPort() {
+		s.metricsServer = health.NewMetricsServer(logger, cfg.MetricsPort, cfg.HealthBindAddr)
 	}
 
 	// Fetch and cache TCO policies for tier selection
@@ -128,10 +287,17 @@ func NewWithDeps(cfg *config.Config, apiClient client.Doer, authenticator Authen
 func (s *Server) registerTools() error {
 	// Alert tools
 	s.registerTool(tools.NewGetAlertTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewWatchAlertTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewListAlertsTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewCreateAlertTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewBulkCreateAlertsTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewUpdateAlertTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewDeleteAlertTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewCreateSLOBurnAlertTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewCompareAlertsTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewExportAlertTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewRestoreAlertTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewValidateAlertConditionTool(s.apiClient, s.logger))
 
 	// Alert Definition tools
 	s.registerTool(tools.NewGetAlertDefinitionTool(s.apiClient, s.logger))
@@ -139,6 +305,7 @@ func (s *Server) registerTools() error {
 	s.registerTool(tools.NewCreateAlertDefinitionTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewUpdateAlertDefinitionTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewDeleteAlertDefinitionTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewCreateAlertFromQueryTool(s.apiClient, s.logger))
 
 	// Rule Group tools
 	s.registerTool(tools.NewGetRuleGroupTool(s.apiClient, s.logger))
@@ -146,6 +313,7 @@ func (s *Server) registerTools() error {
 	s.registerTool(tools.NewCreateRuleGroupTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewUpdateRuleGroupTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewDeleteRuleGroupTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewTestRuleGroupTool(s.apiClient, s.logger))
 
 	// Outgoing Webhook tools
 	s.registerTool(tools.NewGetOutgoingWebhookTool(s.apiClient, s.logger))
@@ -153,6 +321,7 @@ func (s *Server) registerTools() error {
 	s.registerTool(tools.NewCreateOutgoingWebhookTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewUpdateOutgoingWebhookTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewDeleteOutgoingWebhookTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewTestOutgoingWebhookTool(s.apiClient, s.logger))
 
 	// Policy tools
 	s.registerTool(tools.NewGetPolicyTool(s.apiClient, s.logger))
@@ -160,6 +329,7 @@ func (s *Server) registerTools() error {
 	s.registerTool(tools.NewCreatePolicyTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewUpdatePolicyTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewDeletePolicyTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewDiffPoliciesTool(s.apiClient, s.logger))
 
 	// Events to Metrics (E2M) tools
 	s.registerTool(tools.NewGetE2MTool(s.apiClient, s.logger))
@@ -170,12 +340,29 @@ func (s *Server) registerTools() error {
 
 	// Query tools
 	s.registerTool(tools.NewQueryTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewReplayQueryTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewTraceLogsTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewClusterLogsTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewCompareTimeWindowsTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewSaveQueryTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewListSavedQueriesTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewGetSavedQueryTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewDeleteSavedQueryTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewScheduleQueryTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewListScheduledQueriesTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewDeleteScheduledQueryTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewBuildQueryTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewBuildAggregationQueryTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewDataPrimeReferenceTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewSubmitBackgroundQueryTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewGetBackgroundQueryStatusTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewGetBackgroundQueryDataTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewCancelBackgroundQueryTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewListBackgroundQueriesTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewCancelAllBackgroundQueriesTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewListApplicationsTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewListSubsystemsTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewDiscoverFieldsTool(s.apiClient, s.logger))
 
 	// Log Ingestion tools
 	s.registerTool(tools.NewIngestLogsTool(s.apiClient, s.logger))
@@ -194,6 +381,12 @@ func (s *Server) registerTools() error {
 	s.registerTool(tools.NewUpdateEnrichmentTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewDeleteEnrichmentTool(s.apiClient, s.logger))
 
+	// Data set tools (lookup tables for custom enrichments)
+	s.registerTool(tools.NewListDataSetsTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewGetDataSetTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewCreateDataSetTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewDeleteDataSetTool(s.apiClient, s.logger))
+
 	// View tools
 	s.registerTool(tools.NewListViewsTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewCreateViewTool(s.apiClient, s.logger))
@@ -207,6 +400,7 @@ func (s *Server) registerTools() error {
 	s.registerTool(tools.NewGetViewFolderTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewReplaceViewFolderTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewDeleteViewFolderTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewMoveViewToFolderTool(s.apiClient, s.logger))
 
 	// Data Usage tools
 	s.registerTool(tools.NewExportDataUsageTool(s.apiClient, s.logger))
@@ -222,6 +416,7 @@ func (s *Server) registerTools() error {
 	s.registerTool(tools.NewListDashboardsTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewGetDashboardTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewCreateDashboardTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewCreateDashboardFromTemplateTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewUpdateDashboardTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewDeleteDashboardTool(s.apiClient, s.logger))
 
@@ -242,24 +437,40 @@ func (s *Server) registerTools() error {
 	s.registerTool(tools.NewCreateStreamTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewUpdateStreamTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewDeleteStreamTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewTestStreamTool(s.apiClient, s.logger))
 
 	// AI Helper tools
 	s.registerTool(tools.NewExplainQueryTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewParseErrorTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewAdvancedSuggestAlertTool(s.apiClient, s.logger)) // SRE-grade alert recommendations
 	s.registerTool(tools.NewGetAuditLogTool(s.apiClient, s.logger))
 
+	// Infrastructure as Code tools
+	s.registerTool(tools.NewExportTerraformTool(s.apiClient, s.logger))
+
 	// Query Intelligence tools
 	s.registerTool(tools.NewQueryTemplatesTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewValidateQueryTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewNormalizeQueryTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewConvertLuceneToDataPrimeTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewConvertDataPrimeToLuceneTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewQueryCostEstimateTool(s.apiClient, s.logger))
 
 	// Workflow Automation tools
 	s.registerTool(tools.NewInvestigateIncidentTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewHealthCheckTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewCheckReferencesTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewBackupInstanceTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewRestoreInstanceTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewDiffBackupTool(s.apiClient, s.logger))
 
 	// Meta tools (discovery and session management)
 	s.registerTool(tools.NewDiscoverToolsTool(s.apiClient, s.logger))
 	s.registerTool(tools.NewSessionContextTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewRecordFeedbackTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewWhoAmITool(s.apiClient, s.logger))
+	s.registerTool(tools.NewSearchAllResourcesTool(s.apiClient, s.logger))
+	s.registerTool(tools.NewListInstancesTool(s.apiClient, s.logger))
 
 	// Dynamic toolset meta-tools (token-efficient discovery pattern)
 	// These enable: search_tools → describe_tools → execute workflow
@@ -276,6 +487,12 @@ func (s *Server) registerTools() error {
 func (s *Server) registerTool(t tools.Tool) {
 	toolName := t.Name()
 
+	// Tools disabled by configuration are not advertised at all
+	if err := tools.CheckToolAccess(t); err != nil {
+		s.logger.Debug("Tool not registered", zap.String("tool", toolName), zap.Error(err))
+		return
+	}
+
 	// Register in dynamic registry for search_tools/describe_tools pattern
 	tools.RegisterToolForDynamic(t)
 
@@ -283,7 +500,7 @@ func (s *Server) registerTool(t tools.Tool) {
 	mcpTool := &mcp.Tool{
 		Name:        toolName,
 		Description: t.Description(),
-		InputSchema: t.InputSchema(),
+		InputSchema: tools.WithInstanceParam(tools.WithTimeoutParam(tools.WithConfirmationParam(t, t.InputSchema()))),
 		Annotations: t.Annotations(),
 	}
 
@@ -291,10 +508,6 @@ func (s *Server) registerTool(t tools.Tool) {
 	handler := func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		start := time.Now()
 
-		// Add client to context for tool execution
-		// This enables per-request client injection for future HTTP transport
-		ctx = tools.WithClient(ctx, s.apiClient)
-
 		// Add session to context for tool execution
 		// This enables per-request session injection for better testability
 		ctx = tools.WithSession(ctx, tools.GetSession())
@@ -308,12 +521,35 @@ func (s *Server) registerTool(t tools.Tool) {
 			}
 		}
 
+		// Add the selected instance's client to context for tool execution
+		apiClient, instanceName, err := tools.ResolveInstanceClient(args, s.apiClient)
+		if err != nil {
+			s.metrics.RecordToolExecution(toolName, false, time.Since(start))
+			return tools.NewToolResultErrorFromErr(err), nil
+		}
+		ctx = tools.WithInstanceName(tools.WithClient(ctx, apiClient), instanceName)
+
+		// Correlate this call's API requests in the debug trace file
+		var callID string
+		if s.config.DebugTrace {
+			callID = client.NewCallID()
+			ctx = client.WithCallID(ctx, callID)
+		}
+
 		// Estimate input tokens from arguments
 		inputTokens := tools.EstimateJSONTokens(args)
 
-		result, err := t.Execute(ctx, args)
+		// Run under the tool's timeout (overridable per call via timeout_seconds)
+		result, err := tools.ExecuteWithTimeout(ctx, t, args)
 		success := err == nil && (result == nil || !result.IsError)
+		if entry, ok := tools.BuildAuditEntry(ctx, t, args, result, err, time.Since(start)); ok {
+			s.audit.Log(ctx, entry)
+		}
 		s.metrics.RecordToolExecution(toolName, success, time.Since(start))
+		if result != nil && result.IsError {
+			code, _ := result.Meta["error_code"].(string)
+			s.metrics.RecordToolError(toolName, code)
+		}
 
 		// Estimate output tokens from result and record budget usage
 		outputTokens := 0
@@ -326,6 +562,13 @@ func (s *Server) registerTool(t tools.Tool) {
 		}
 		tools.GetBudgetContext().RecordToolExecution(inputTokens, outputTokens)
 
+		if callID != "" && result != nil {
+			if result.Meta == nil {
+				result.Meta = mcp.Meta{}
+			}
+			result.Meta["call_id"] = callID
+		}
+
 		return result, err
 	}
 
@@ -364,6 +607,18 @@ func (s *Server) registerResources() {
 		s.logger.Debug("Registered resource template", zap.String("uri_template", t.URITemplate))
 	}
 
+	// Saved views are listed from the API on resources/list and readable as view://<id>
+	views := resources.NewViewResources(s.apiClient, s.logger)
+	viewTemplate := views.Template()
+	s.mcpServer.AddResourceTemplate(&viewTemplate, views.Handler())
+	s.mcpServer.AddReceivingMiddleware(views.Middleware(s.mcpServer))
+
+	// Dashboards are listed the same way and read as a markdown description of their widgets
+	dashboards := resources.NewDashboardResources(s.apiClient, s.logger)
+	dashboardTemplate := dashboards.Template()
+	s.mcpServer.AddResourceTemplate(&dashboardTemplate, dashboards.Handler())
+	s.mcpServer.AddReceivingMiddleware(dashboards.Middleware(s.mcpServer))
+
 	s.logger.Info("Registered all MCP resources",
 		zap.Int("static_count", len(registry.GetResources())),
 		zap.Int("template_count", len(registry.GetResourceTemplates())),
@@ -384,11 +639,25 @@ func (s *Server) Start(ctx context.Context) error {
 		// Mark as ready once server is starting
 		s.healthServer.SetReady(true)
 	}
+	if s.metricsServer != nil {
+		go func() {
+			if err := s.metricsServer.Start(); err != nil {
+				s.logger.Error("Metrics server error", zap.Error(err))
+			}
+		}()
+	}
+
+	// Run scheduled queries while the server is up
+	go tools.NewQueryScheduler(s.apiClient, s.logger).Run(ctx)
 
 	defer func() {
 		// Log final metrics on shutdown
 		s.metrics.LogStats()
 
+		if err := s.audit.Close(); err != nil {
+			s.logger.Error("Failed to close audit log", zap.Error(err))
+		}
+
 		// Save user session for persistence (learned patterns, preferences)
 		if err := tools.SaveCurrentSession(); err != nil {
 			s.logger.Error("Failed to save user session", zap.Error(err))
@@ -405,9 +674,18 @@ func (s *Server) Start(ctx context.Context) error {
 				s.logger.Error("Failed to shutdown health server", zap.Error(err))
 			}
 		}
+		if s.metricsServer != nil {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := s.metricsServer.Shutdown(shutdownCtx); err != nil {
+				s.logger.Error("Failed to shutdown metrics server", zap.Error(err))
+			}
+		}
 
-		if err := s.apiClient.Close(); err != nil {
-			s.logger.Error("Failed to close API client", zap.Error(err))
+		for name, c := range s.clients {
+			if err := c.Close(); err != nil {
+				s.logger.Error("Failed to close API client", zap.String("instance", name), zap.Error(err))
+			}
 		}
 	}()
 
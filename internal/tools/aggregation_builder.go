@@ -0,0 +1,270 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// aggregationMetrics maps supported metric names to their DataPrime aggregation templates.
+// %s is replaced with the (numeric-cast) field expression.
+var aggregationMetrics = map[string]string{
+	"count": "count()",
+	"sum":   "sum(%s)",
+	"avg":   "avg(%s)",
+	"min":   "min(%s)",
+	"max":   "max(%s)",
+	"p50":   "percentile(%s, 0.5)",
+	"p90":   "percentile(%s, 0.9)",
+	"p95":   "percentile(%s, 0.95)",
+	"p99":   "percentile(%s, 0.99)",
+}
+
+// aggregationMetricNames is the ordered list of supported metrics for schemas and errors
+var aggregationMetricNames = []string{"count", "sum", "avg", "min", "max", "p50", "p90", "p95", "p99"}
+
+// timeBucketPattern matches DataPrime interval literals such as 30s, 5m, 1h, 1d
+var timeBucketPattern = regexp.MustCompile(`^[1-9][0-9]*(s|m|h|d)$`)
+
+// BuildAggregationQueryTool deterministically assembles DataPrime aggregation pipelines
+type BuildAggregationQueryTool struct{ *BaseTool }
+
+// NewBuildAggregationQueryTool creates a new tool instance
+func NewBuildAggregationQueryTool(c client.Doer, l *zap.Logger) *BuildAggregationQueryTool {
+	return &BuildAggregationQueryTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *BuildAggregationQueryTool) Name() string { return "build_aggregation_query" }
+
+// Annotations returns tool hints for LLMs
+func (t *BuildAggregationQueryTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Build Aggregation Query")
+}
+
+// Description returns the tool description
+func (t *BuildAggregationQueryTool) Description() string {
+	return `Build a DataPrime aggregation query from structured parameters.
+
+Assembles filter → groupby → aggregate → sort stages deterministically, so aggregation syntax
+doesn't have to be written by hand. Returns the query plus an explanation of each stage.
+
+**Metrics:** count, sum, avg, min, max, p50, p90, p95, p99 (all but count need a numeric field)
+
+**Examples:**
+- Error count per app every 5m: metric=count, group_by=["applicationname"], filter="$m.severity >= ERROR", time_bucket="5m"
+- p95 latency per endpoint: metric=p95, field="duration_ms", group_by=["endpoint"]
+
+**Related tools:** build_query, query_logs, validate_query`
+}
+
+// InputSchema returns the input schema
+func (t *BuildAggregationQueryTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"metric": map[string]interface{}{
+				"type":        "string",
+				"description": "Aggregation to compute",
+				"enum":        aggregationMetricNames,
+			},
+			"field": map[string]interface{}{
+				"type":        "string",
+				"description": "Numeric field to aggregate (required for all metrics except count), e.g. 'duration_ms' or '$d.response.time'",
+			},
+			"group_by": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Fields to group by, e.g. ['applicationname', 'endpoint']",
+			},
+			"filter": map[string]interface{}{
+				"type":        "string",
+				"description": "Optional DataPrime filter expression applied before aggregating, e.g. \"$m.severity >= ERROR\"",
+			},
+			"time_bucket": map[string]interface{}{
+				"type":        "string",
+				"description": "Optional time bucket interval (e.g. 1m, 5m, 1h) for a time series",
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"description": "Maximum number of result rows (default: 100)",
+				"default":     100,
+			},
+		},
+		"required": []string{"metric"},
+	}
+}
+
+// AggregationSpec describes an aggregation query to build
+type AggregationSpec struct {
+	Metric     string
+	Field      string
+	GroupBy    []string
+	Filter     string
+	TimeBucket string
+	Limit      int
+}
+
+// BuildAggregationQuery assembles the DataPrime pipeline for spec and returns the query
+// along with a stage-by-stage explanation
+func BuildAggregationQuery(spec AggregationSpec) (string, []string, error) {
+	tmpl, ok := aggregationMetrics[spec.Metric]
+	if !ok {
+		return "", nil, fmt.Errorf("invalid metric '%s' (valid: %s)", spec.Metric, strings.Join(aggregationMetricNames, ", "))
+	}
+
+	var aggExpr string
+	if spec.Metric == "count" {
+		aggExpr = tmpl
+	} else {
+		if spec.Field == "" {
+			return "", nil, fmt.Errorf("metric '%s' requires a numeric field", spec.Metric)
+		}
+		fieldExpr := toDataPrimeField(spec.Field)
+		if strings.HasPrefix(fieldExpr, "$l.") {
+			return "", nil, fmt.Errorf("metric '%s' requires a numeric field, but '%s' is a string label", spec.Metric, spec.Field)
+		}
+		if strings.HasPrefix(fieldExpr, "$d.") && !strings.Contains(fieldExpr, ":") {
+			// $d fields are mixed-type; cast so the aggregation operates on numbers
+			fieldExpr += ":number"
+		}
+		aggExpr = fmt.Sprintf(tmpl, fieldExpr)
+	}
+
+	alias := spec.Metric
+	if spec.Field != "" && spec.Metric != "count" {
+		alias = spec.Metric + "_" + aggregationAlias(spec.Field)
+	}
+
+	var explanation []string
+	stages := []string{"source logs"}
+
+	if filter := strings.TrimSpace(spec.Filter); filter != "" {
+		filter = strings.TrimPrefix(filter, "filter ")
+		stages = append(stages, "filter "+filter)
+		explanation = append(explanation, fmt.Sprintf("`filter %s` keeps only matching events before aggregating", filter))
+	}
+
+	var groupKeys []string
+	if spec.TimeBucket != "" {
+		if !timeBucketPattern.MatchString(spec.TimeBucket) {
+			return "", nil, fmt.Errorf("invalid time_bucket '%s': use an interval such as 1m, 5m, or 1h", spec.TimeBucket)
+		}
+		groupKeys = append(groupKeys, fmt.Sprintf("roundTime($m.timestamp, %s) as time_bucket", spec.TimeBucket))
+		explanation = append(explanation, fmt.Sprintf("`roundTime($m.timestamp, %s)` buckets events into %s intervals", spec.TimeBucket, spec.TimeBucket))
+	}
+	for _, g := range spec.GroupBy {
+		if g = strings.TrimSpace(g); g != "" {
+			groupKeys = append(groupKeys, toDataPrimeField(g))
+		}
+	}
+
+	// Grouping and aggregating must be one stage: a separate groupby would reduce the events to
+	// distinct keys before the aggregate runs
+	aggStage := fmt.Sprintf("aggregate %s as %s", aggExpr, alias)
+	if len(groupKeys) > 0 {
+		stages = append(stages, "groupby "+strings.Join(groupKeys, ", ")+" "+aggStage)
+		explanation = append(explanation, fmt.Sprintf("`groupby ... %s` computes the %s for each combination of: %s",
+			aggStage, describeMetric(spec.Metric), strings.Join(groupKeys, ", ")))
+	} else {
+		stages = append(stages, aggStage)
+		explanation = append(explanation, fmt.Sprintf("`%s` computes the %s over all matching events", aggStage, describeMetric(spec.Metric)))
+	}
+
+	if spec.TimeBucket != "" {
+		stages = append(stages, "sortby time_bucket")
+		explanation = append(explanation, "`sortby time_bucket` orders the series chronologically")
+	} else if len(groupKeys) > 0 {
+		stages = append(stages, "sortby -"+alias)
+		explanation = append(explanation, fmt.Sprintf("`sortby -%s` puts the largest values first", alias))
+	}
+
+	limit := spec.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	if len(groupKeys) > 0 {
+		stages = append(stages, fmt.Sprintf("limit %d", limit))
+		explanation = append(explanation, fmt.Sprintf("`limit %d` caps the number of result rows", limit))
+	}
+
+	return strings.Join(stages, " | "), explanation, nil
+}
+
+// aggregationAlias derives a column alias from a field name
+func aggregationAlias(field string) string {
+	field = strings.TrimPrefix(strings.TrimPrefix(strings.TrimPrefix(field, "$d."), "$m."), "json.")
+	if i := strings.Index(field, ":"); i >= 0 {
+		field = field[:i]
+	}
+	return strings.NewReplacer(".", "_", "-", "_").Replace(field)
+}
+
+// describeMetric returns a human-readable description of a metric
+func describeMetric(metric string) string {
+	switch metric {
+	case "count":
+		return "number of events"
+	case "sum":
+		return "sum of the field"
+	case "avg":
+		return "average of the field"
+	case "min":
+		return "minimum of the field"
+	case "max":
+		return "maximum of the field"
+	default:
+		return strings.TrimPrefix(metric, "p") + "th percentile of the field"
+	}
+}
+
+// Execute builds the aggregation query
+func (t *BuildAggregationQueryTool) Execute(_ context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	metric, err := GetStringParam(args, "metric", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	field, _ := GetStringParam(args, "field", false)
+	filter, _ := GetStringParam(args, "filter", false)
+	timeBucket, _ := GetStringParam(args, "time_bucket", false)
+	limit, _ := GetIntParam(args, "limit", false)
+
+	query, explanation, err := BuildAggregationQuery(AggregationSpec{
+		Metric:     metric,
+		Field:      field,
+		GroupBy:    getStringArray(args, "group_by"),
+		Filter:     filter,
+		TimeBucket: timeBucket,
+		Limit:      limit,
+	})
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	if err := ValidateDataPrimeQuery(query); err != nil {
+		return NewToolResultError(fmt.Sprintf("Generated query failed validation: %v", err)), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Aggregation Query\n\n")
+	sb.WriteString("```\n")
+	sb.WriteString(query)
+	sb.WriteString("\n```\n\n")
+	sb.WriteString("### Explanation\n\n")
+	for _, line := range explanation {
+		fmt.Fprintf(&sb, "- %s\n", line)
+	}
+	sb.WriteString("\n**Usage with query_logs:**\n")
+	sb.WriteString("```json\n")
+	fmt.Fprintf(&sb, `{"query": "%s", "syntax": "dataprime"}`, escapeJSON(query))
+	sb.WriteString("\n```\n")
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}},
+	}, nil
+}
@@ -0,0 +1,79 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestBuildAggregationQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		spec AggregationSpec
+		want string
+	}{
+		{
+			name: "count without grouping",
+			spec: AggregationSpec{Metric: "count"},
+			want: "source logs | aggregate count() as count",
+		},
+		{
+			name: "count per app bucketed",
+			spec: AggregationSpec{Metric: "count", GroupBy: []string{"applicationname"}, Filter: "$m.severity >= ERROR", TimeBucket: "5m"},
+			want: "source logs | filter $m.severity >= ERROR | groupby roundTime($m.timestamp, 5m) as time_bucket, $l.applicationname aggregate count() as count | sortby time_bucket | limit 100",
+		},
+		{
+			name: "p95 latency per endpoint",
+			spec: AggregationSpec{Metric: "p95", Field: "duration_ms", GroupBy: []string{"endpoint"}, Limit: 20},
+			want: "source logs | groupby $d.endpoint aggregate percentile($d.duration_ms:number, 0.95) as p95_duration_ms | sortby -p95_duration_ms | limit 20",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, explanation, err := BuildAggregationQuery(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, query)
+			assert.NotEmpty(t, explanation)
+			assert.Nil(t, ValidateDataPrimeQuery(query))
+		})
+	}
+}
+
+func TestBuildAggregationQuery_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    AggregationSpec
+		wantErr string
+	}{
+		{"unknown metric", AggregationSpec{Metric: "median"}, "invalid metric"},
+		{"percentile without field", AggregationSpec{Metric: "p99"}, "requires a numeric field"},
+		{"percentile on label", AggregationSpec{Metric: "p99", Field: "applicationname"}, "string label"},
+		{"bad bucket", AggregationSpec{Metric: "count", TimeBucket: "five minutes"}, "invalid time_bucket"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := BuildAggregationQuery(tt.spec)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestBuildAggregationQueryTool_Execute(t *testing.T) {
+	tool := NewBuildAggregationQueryTool(nil, zap.NewNop())
+	result, err := tool.Execute(t.Context(), map[string]interface{}{
+		"metric":   "avg",
+		"field":    "duration_ms",
+		"group_by": []interface{}{"applicationname"},
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	text := result.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, "avg($d.duration_ms:number) as avg_duration_ms")
+	assert.Contains(t, text, "### Explanation")
+}
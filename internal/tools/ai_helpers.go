@@ -98,7 +98,7 @@ type QueryExample struct {
 func (t *ExplainQueryTool) Execute(_ context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	query, err := GetStringParam(args, "query", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	syntax, _ := GetStringParam(args, "syntax", false)
@@ -0,0 +1,409 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements export_alert and restore_alert, a backup and restore primitive for alerts.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+	mcperrors "github.com/tareqmamari/cloud-logs-mcp/internal/errors"
+)
+
+// alertExportFormat identifies export_alert output so restore_alert can recognize it
+const alertExportFormat = "ibm-cloud-logs-alert/v1"
+
+// webhookRefKeys are the alert fields that reference an outgoing webhook
+var webhookRefKeys = []string{"webhook_id", "integration_id", "outgoing_webhook_id"}
+
+// WebhookReference records a webhook an exported alert notifies through, so the reference can
+// be re-resolved by name when the webhook's ID no longer exists
+type WebhookReference struct {
+	Field string `json:"field"`
+	ID    string `json:"id"`
+	Name  string `json:"name,omitempty"`
+}
+
+// AlertExport is the self-contained alert definition produced by export_alert
+type AlertExport struct {
+	Format     string                 `json:"format"`
+	ExportedAt string                 `json:"exported_at"`
+	Alert      map[string]interface{} `json:"alert"`
+	Webhooks   []WebhookReference     `json:"webhooks,omitempty"`
+}
+
+// ExportAlertTool exports an alert as a portable definition
+type ExportAlertTool struct{ *BaseTool }
+
+// NewExportAlertTool creates a new tool instance
+func NewExportAlertTool(c client.Doer, l *zap.Logger) *ExportAlertTool {
+	return &ExportAlertTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *ExportAlertTool) Name() string { return "export_alert" }
+
+// Annotations returns tool hints for LLMs
+func (t *ExportAlertTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Export Alert")
+}
+
+// DefaultTimeout returns the timeout (the alert and the webhooks are fetched)
+func (t *ExportAlertTool) DefaultTimeout() time.Duration {
+	return 2 * DefaultGetTimeout
+}
+
+// Description returns the tool description
+func (t *ExportAlertTool) Description() string {
+	return `Export an alert as a self-contained JSON definition for backup or copying to another instance.
+
+Server-managed fields (id, timestamps) are removed. Each outgoing webhook the alert notifies
+through is recorded with its name, so restore_alert can re-resolve the reference if the webhook
+is later recreated with a different ID. Keep the whole output: pass it unchanged to restore_alert.
+
+**When to use:**
+- Before deleting or heavily editing an alert
+- Copying an alert between environments
+
+**Related tools:** restore_alert, get_alert, delete_alert`
+}
+
+// InputSchema returns the input schema
+func (t *ExportAlertTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"id": map[string]interface{}{
+				"type":        "string",
+				"description": "ID of the alert to export",
+			},
+		},
+		"required": []string{"id"},
+	}
+}
+
+// Execute executes the tool
+func (t *ExportAlertTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	id, err := GetStringParam(args, "id", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	alert, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/alerts/" + id})
+	if err != nil {
+		return HandleGetError(err, "Alert", id, "list_alerts"), nil
+	}
+
+	export := AlertExport{
+		Format:     alertExportFormat,
+		ExportedAt: time.Now().UTC().Format(time.RFC3339),
+		Alert:      stripServerManaged(alert),
+	}
+	var refs []fieldReference
+	collectReferences(export.Alert, webhookRefKeys, "", &refs)
+	response := map[string]interface{}{}
+	if len(refs) > 0 {
+		webhooks, err := t.listWebhooks(ctx)
+		if err != nil {
+			response["warning"] = fmt.Sprintf("Webhook names could not be recorded (%v); restore_alert can only reuse the original webhook IDs", err)
+		}
+		for _, ref := range refs {
+			name := ""
+			if wh := findWebhookByRef(webhooks, ref.Value); wh != nil {
+				name, _ = wh["name"].(string)
+			}
+			export.Webhooks = append(export.Webhooks, WebhookReference{Field: ref.Field, ID: ref.Value, Name: name})
+		}
+	}
+
+	response["export"] = export
+	return t.FormatResponse(response)
+}
+
+// listWebhooks returns the instance's outgoing webhooks
+func (t *BaseTool) listWebhooks(ctx context.Context) ([]map[string]interface{}, error) {
+	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/outgoing_webhooks"})
+	if err != nil {
+		return nil, err
+	}
+	var webhooks []map[string]interface{}
+	for _, item := range listItems(res, []string{"outgoing_webhooks", "webhooks"}) {
+		if m, ok := item.(map[string]interface{}); ok {
+			webhooks = append(webhooks, m)
+		}
+	}
+	return webhooks, nil
+}
+
+// findWebhookByRef returns the webhook whose id or numeric external_id is ref
+func findWebhookByRef(webhooks []map[string]interface{}, ref string) map[string]interface{} {
+	for _, wh := range webhooks {
+		for _, key := range []string{"id", "external_id"} {
+			if v, ok := wh[key]; ok && v != nil && fmt.Sprint(v) == ref {
+				return wh
+			}
+		}
+	}
+	return nil
+}
+
+// RestoreAlertTool recreates an alert from an export_alert definition
+type RestoreAlertTool struct{ *BaseTool }
+
+// NewRestoreAlertTool creates a new tool instance
+func NewRestoreAlertTool(c client.Doer, l *zap.Logger) *RestoreAlertTool {
+	return &RestoreAlertTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *RestoreAlertTool) Name() string { return "restore_alert" }
+
+// Annotations returns tool hints for LLMs
+func (t *RestoreAlertTool) Annotations() *mcp.ToolAnnotations {
+	return CreateAnnotations("Restore Alert")
+}
+
+// DefaultTimeout returns the timeout (webhooks and alerts are listed before creating)
+func (t *RestoreAlertTool) DefaultTimeout() time.Duration {
+	return DefaultListTimeout
+}
+
+// Description returns the tool description
+func (t *RestoreAlertTool) Description() string {
+	return `Recreate an alert from the definition returned by export_alert, e.g. after it was deleted by mistake.
+
+Webhook references whose ID no longer exists are re-resolved by the webhook name recorded in the
+export; the restore fails if no webhook with that name exists. If an alert with the same name
+already exists, " (restored)" is appended to the name, or the restore fails when on_name_conflict
+is "error". Pass name to restore under a different name.
+
+**Related tools:** export_alert, list_outgoing_webhooks, create_outgoing_webhook, get_alert`
+}
+
+// InputSchema returns the input schema
+func (t *RestoreAlertTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"definition": map[string]interface{}{
+				"type":        "object",
+				"description": "The export object returned by export_alert (format, alert, webhooks)",
+			},
+			"name": map[string]interface{}{
+				"type":        "string",
+				"description": "Name for the restored alert (default: the exported name). Fails if an alert already has this name.",
+			},
+			"on_name_conflict": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"suffix", "error"},
+				"description": "When an alert with the exported name exists: append \" (restored)\" (default) or fail",
+				"default":     "suffix",
+			},
+			"dry_run": map[string]interface{}{
+				"type":        "boolean",
+				"description": "If true, resolve webhooks and the name and show the alert that would be created without creating it",
+				"default":     false,
+			},
+		},
+		"required": []string{"definition"},
+	}
+}
+
+// Execute executes the tool
+func (t *RestoreAlertTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	definition, err := GetObjectParam(args, "definition", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	newName, _ := GetStringParam(args, "name", false)
+	onConflict, _ := GetStringParam(args, "on_name_conflict", false)
+	if onConflict == "" {
+		onConflict = "suffix"
+	}
+	if onConflict != "suffix" && onConflict != "error" {
+		return NewToolResultError(fmt.Sprintf("Invalid on_name_conflict %q (valid: suffix, error)", onConflict)), nil
+	}
+	dryRun, _ := GetBoolParam(args, "dry_run", false)
+
+	export, err := parseAlertExport(definition)
+	if err != nil {
+		return NewToolResultErrorWithSuggestion(err.Error(), "Pass the export object returned by export_alert unchanged."), nil
+	}
+	alert := export.Alert
+
+	var remapped []map[string]interface{}
+	if len(export.Webhooks) > 0 {
+		webhooks, err := t.listWebhooks(ctx)
+		if err != nil {
+			return NewToolResultErrorFromErr(err), nil
+		}
+		var unresolved []string
+		remapped, unresolved = remapWebhookReferences(alert, export.Webhooks, webhooks)
+		if len(unresolved) > 0 {
+			return newToolError(mcperrors.CodeNotFound,
+				"Cannot restore alert: these webhooks no longer exist and none has the same name: "+strings.Join(unresolved, ", "),
+				"Recreate the webhooks with create_outgoing_webhook using the same names, then retry."), nil
+		}
+	}
+
+	existing, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/alerts"})
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	names := make(map[string]bool)
+	for _, item := range listItems(existing, []string{"alerts"}) {
+		if m, ok := item.(map[string]interface{}); ok {
+			if name, ok := m["name"].(string); ok {
+				names[strings.ToLower(name)] = true
+			}
+		}
+	}
+	originalName, _ := alert["name"].(string)
+	name, conflict := resolveRestoredAlertName(originalName, newName, onConflict, names)
+	if conflict != nil {
+		return conflict, nil
+	}
+	alert["name"] = name
+
+	summary := map[string]interface{}{"name": name}
+	if name != originalName {
+		summary["renamed_from"] = originalName
+	}
+	if len(remapped) > 0 {
+		summary["webhooks_remapped"] = remapped
+	}
+	if dryRun {
+		summary["dry_run"] = true
+		summary["alert"] = alert
+		summary["validation"] = checkAlertSpec(alert)
+		return MarkDryRun(t.FormatResponse(summary))
+	}
+
+	created, err := t.ExecuteRequest(ctx, &client.Request{Method: "POST", Path: "/v1/alerts", Body: alert})
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	GetCacheHelperFromContext(ctx).InvalidateRelated(t.Name())
+
+	summary["id"] = created["id"]
+	summary["alert"] = created
+	return t.FormatResponseWithSuggestions(summary, "restore_alert")
+}
+
+// parseAlertExport reads an export_alert definition. A bare alert object is accepted too, in
+// which case webhook references cannot be re-resolved. The alert is deep-copied with
+// server-managed fields removed.
+func parseAlertExport(definition map[string]interface{}) (*AlertExport, error) {
+	data, err := json.Marshal(definition)
+	if err != nil {
+		return nil, fmt.Errorf("invalid definition: %w", err)
+	}
+	var export AlertExport
+	if format, _ := definition["format"].(string); format != "" || definition["alert"] != nil {
+		if format != alertExportFormat {
+			return nil, fmt.Errorf("unsupported definition format %q (expected %s)", format, alertExportFormat)
+		}
+		if err := json.Unmarshal(data, &export); err != nil {
+			return nil, fmt.Errorf("invalid definition: %w", err)
+		}
+	} else if err := json.Unmarshal(data, &export.Alert); err != nil {
+		return nil, fmt.Errorf("invalid definition: %w", err)
+	}
+	if name, _ := export.Alert["name"].(string); name == "" {
+		return nil, fmt.Errorf("the definition has no alert name")
+	}
+	export.Alert = stripServerManaged(export.Alert)
+	return &export, nil
+}
+
+// remapWebhookReferences points the alert's webhook references that no longer exist at the
+// current webhook with the recorded name. It returns the remapped references and the names
+// (or IDs) of references that could not be resolved.
+func remapWebhookReferences(alert map[string]interface{}, refs []WebhookReference, webhooks []map[string]interface{}) ([]map[string]interface{}, []string) {
+	replacements := make(map[string]interface{})
+	var remapped []map[string]interface{}
+	var unresolved []string
+	for _, ref := range refs {
+		if _, done := replacements[ref.ID]; done || findWebhookByRef(webhooks, ref.ID) != nil {
+			continue
+		}
+		var match map[string]interface{}
+		for _, wh := range webhooks {
+			if name, _ := wh["name"].(string); ref.Name != "" && name == ref.Name {
+				match = wh
+				break
+			}
+		}
+		if match == nil {
+			label := ref.ID
+			if ref.Name != "" {
+				label = fmt.Sprintf("%q (ID %s)", ref.Name, ref.ID)
+			}
+			unresolved = append(unresolved, label)
+			continue
+		}
+		// Numeric references such as integration_id hold the webhook's external_id
+		newID := match["id"]
+		if _, err := strconv.ParseInt(ref.ID, 10, 64); err == nil && match["external_id"] != nil {
+			newID = match["external_id"]
+		}
+		replacements[ref.ID] = newID
+		remapped = append(remapped, map[string]interface{}{"webhook": ref.Name, "old_id": ref.ID, "new_id": newID})
+	}
+	replaceReferences(alert, webhookRefKeys, replacements)
+	sort.Strings(unresolved)
+	return remapped, unresolved
+}
+
+// replaceReferences rewrites every refKeys value found at any depth of value that has a replacement
+func replaceReferences(value interface{}, refKeys []string, replacements map[string]interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if isRefKey(key, refKeys) {
+				if newValue, ok := replacements[referenceValue(child)]; ok {
+					v[key] = newValue
+				}
+				continue
+			}
+			replaceReferences(child, refKeys, replacements)
+		}
+	case []interface{}:
+		for _, child := range v {
+			replaceReferences(child, refKeys, replacements)
+		}
+	}
+}
+
+// resolveRestoredAlertName picks the restored alert's name. An explicit name must be free; the
+// exported name gets a " (restored)" suffix on collision unless onConflict is "error".
+func resolveRestoredAlertName(original, requested, onConflict string, taken map[string]bool) (string, *mcp.CallToolResult) {
+	if requested != "" {
+		if taken[strings.ToLower(requested)] {
+			return "", newToolError(mcperrors.CodeConflict,
+				fmt.Sprintf("An alert named %q already exists", requested),
+				"Choose a different name.")
+		}
+		return requested, nil
+	}
+	if !taken[strings.ToLower(original)] {
+		return original, nil
+	}
+	if onConflict == "error" {
+		return "", newToolError(mcperrors.CodeConflict,
+			fmt.Sprintf("An alert named %q already exists", original),
+			"Pass name to restore under a new name, or on_name_conflict: \"suffix\".")
+	}
+	name := original + " (restored)"
+	for i := 2; taken[strings.ToLower(name)]; i++ {
+		name = fmt.Sprintf("%s (restored %d)", original, i)
+	}
+	return name, nil
+}
@@ -0,0 +1,124 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// alertBackupMock serves GETs from bodies by path and records created alerts
+func alertBackupMock(t *testing.T, bodies map[string]interface{}, created *[]map[string]interface{}) *client.MockClient {
+	mock := client.NewMockClient()
+	mock.DoFunc = func(_ context.Context, req *client.Request) (*client.Response, error) {
+		if req.Method == "POST" {
+			alert := req.Body.(map[string]interface{})
+			*created = append(*created, alert)
+			data, _ := json.Marshal(map[string]interface{}{"id": "new-alert", "name": alert["name"]})
+			return &client.Response{StatusCode: 200, Body: data}, nil
+		}
+		body, ok := bodies[req.Path]
+		if !ok {
+			return &client.Response{StatusCode: 404, Body: []byte(`{"message":"not found"}`)}, nil
+		}
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		return &client.Response{StatusCode: 200, Body: data}, nil
+	}
+	return mock
+}
+
+func decodeFirstJSON(t *testing.T, result *mcp.CallToolResult) map[string]interface{} {
+	t.Helper()
+	require.False(t, result.IsError, "%+v", result.Content)
+	var out map[string]interface{}
+	require.NoError(t, json.NewDecoder(strings.NewReader(result.Content[0].(*mcp.TextContent).Text)).Decode(&out))
+	return out
+}
+
+func TestExportRestoreAlert(t *testing.T) {
+	alert := map[string]interface{}{
+		"id": "alert-1", "name": "High errors", "severity": "error", "created_at": "2024-01-01T00:00:00Z",
+		"condition": map[string]interface{}{"more_than": map[string]interface{}{"threshold": 10.0}},
+		"notification_groups": []interface{}{map[string]interface{}{
+			"notifications": []interface{}{map[string]interface{}{"integration_id": 7.0}},
+		}},
+	}
+	var created []map[string]interface{}
+	mock := alertBackupMock(t, map[string]interface{}{
+		"/v1/alerts/alert-1":    alert,
+		"/v1/outgoing_webhooks": map[string]interface{}{"outgoing_webhooks": []interface{}{map[string]interface{}{"id": "wh-old", "external_id": 7.0, "name": "pagerduty"}}},
+	}, &created)
+
+	exported := decodeFirstJSON(t, mustExecute(t, NewExportAlertTool(mock, zap.NewNop()), map[string]interface{}{"id": "alert-1"}))
+	export := exported["export"].(map[string]interface{})
+	assert.Equal(t, alertExportFormat, export["format"])
+	assert.NotContains(t, export["alert"], "id")
+	assert.NotContains(t, export["alert"], "created_at")
+	require.Len(t, export["webhooks"], 1)
+	assert.Equal(t, map[string]interface{}{"field": "notification_groups[0].notifications[0].integration_id", "id": "7", "name": "pagerduty"}, export["webhooks"].([]interface{})[0])
+
+	// The alert and its webhook were deleted; the webhook was recreated under the same name
+	mock = alertBackupMock(t, map[string]interface{}{
+		"/v1/alerts":            map[string]interface{}{"alerts": []interface{}{map[string]interface{}{"id": "other", "name": "High errors"}}},
+		"/v1/outgoing_webhooks": map[string]interface{}{"outgoing_webhooks": []interface{}{map[string]interface{}{"id": "wh-new", "external_id": 42.0, "name": "pagerduty"}}},
+	}, &created)
+	restored := decodeFirstJSON(t, mustExecute(t, NewRestoreAlertTool(mock, zap.NewNop()), map[string]interface{}{"definition": export}))
+	assert.Equal(t, "new-alert", restored["id"])
+	assert.Equal(t, "High errors (restored)", restored["name"])
+	assert.Equal(t, "High errors", restored["renamed_from"])
+	require.Len(t, created, 1)
+	notification := created[0]["notification_groups"].([]interface{})[0].(map[string]interface{})["notifications"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, 42.0, notification["integration_id"])
+
+	result := mustExecute(t, NewRestoreAlertTool(mock, zap.NewNop()), map[string]interface{}{"definition": export, "on_name_conflict": "error"})
+	require.True(t, result.IsError)
+	assert.Equal(t, "CONFLICT", result.Meta["error_code"])
+	assert.Len(t, created, 1)
+}
+
+func TestRestoreAlert_UnresolvedWebhook(t *testing.T) {
+	var created []map[string]interface{}
+	mock := alertBackupMock(t, map[string]interface{}{
+		"/v1/alerts":            map[string]interface{}{"alerts": []interface{}{}},
+		"/v1/outgoing_webhooks": map[string]interface{}{"outgoing_webhooks": []interface{}{}},
+	}, &created)
+	definition := map[string]interface{}{
+		"format":   alertExportFormat,
+		"alert":    map[string]interface{}{"name": "Latency", "webhook_id": "wh-gone"},
+		"webhooks": []interface{}{map[string]interface{}{"field": "webhook_id", "id": "wh-gone", "name": "slack"}},
+	}
+	result := mustExecute(t, NewRestoreAlertTool(mock, zap.NewNop()), map[string]interface{}{"definition": definition})
+	require.True(t, result.IsError)
+	assert.Equal(t, "NOT_FOUND", result.Meta["error_code"])
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, `"slack" (ID wh-gone)`)
+	assert.Empty(t, created)
+}
+
+func TestResolveRestoredAlertName(t *testing.T) {
+	taken := map[string]bool{"errors": true, "errors (restored)": true}
+	name, res := resolveRestoredAlertName("Errors", "", "suffix", taken)
+	assert.Nil(t, res)
+	assert.Equal(t, "Errors (restored 2)", name)
+
+	name, _ = resolveRestoredAlertName("Latency", "", "error", taken)
+	assert.Equal(t, "Latency", name)
+
+	_, res = resolveRestoredAlertName("Latency", "errors", "suffix", taken)
+	require.NotNil(t, res)
+	assert.Equal(t, "CONFLICT", res.Meta["error_code"])
+}
+
+func mustExecute(t *testing.T, tool Tool, args map[string]interface{}) *mcp.CallToolResult {
+	t.Helper()
+	result, err := tool.Execute(context.Background(), args)
+	require.NoError(t, err)
+	return result
+}
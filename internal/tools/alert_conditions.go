@@ -0,0 +1,287 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// ConditionProblem is one problem found in an alert condition, located by its path
+type ConditionProblem struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// conditionShape lists the parameters a kind of alert condition requires and how each is checked
+type conditionShape struct {
+	required []string
+	fields   map[string]FieldValidator
+	// singleKey lists array parameters that must hold exactly one entry
+	singleKey []string
+}
+
+var (
+	thresholdField         = FieldValidator{Type: "int"}
+	timeframeField         = FieldValidator{Type: "string", Pattern: "^timeframe_"}
+	relativeTimeframeField = FieldValidator{Type: "string", AllowedValues: []string{"hour_or_unspecified", "day", "week", "month"}}
+	arrayField             = FieldValidator{Type: "array"}
+	boolField              = FieldValidator{Type: "bool"}
+)
+
+// conditionShapes maps each condition kind to its parameter shape
+var conditionShapes = map[string]conditionShape{
+	"threshold": {
+		required: []string{"threshold", "timeframe"},
+		fields:   map[string]FieldValidator{"threshold": thresholdField, "timeframe": timeframeField, "group_by": arrayField},
+	},
+	"ratio": {
+		required: []string{"threshold", "timeframe"},
+		fields:   map[string]FieldValidator{"threshold": thresholdField, "timeframe": timeframeField, "group_by": arrayField, "ignore_infinity": boolField},
+	},
+	"time_relative": {
+		required: []string{"threshold", "relative_timeframe"},
+		fields:   map[string]FieldValidator{"threshold": thresholdField, "relative_timeframe": relativeTimeframeField, "group_by": arrayField, "ignore_infinity": boolField},
+	},
+	"anomaly": {
+		required: []string{"threshold", "timeframe"},
+		fields:   map[string]FieldValidator{"threshold": thresholdField, "timeframe": timeframeField, "group_by": arrayField},
+	},
+	"new_value": {
+		required:  []string{"timeframe", "group_by"},
+		fields:    map[string]FieldValidator{"timeframe": timeframeField, "group_by": arrayField},
+		singleKey: []string{"group_by"},
+	},
+	"unique_count": {
+		required:  []string{"threshold", "timeframe", "cardinality_fields"},
+		fields:    map[string]FieldValidator{"threshold": thresholdField, "timeframe": timeframeField, "cardinality_fields": arrayField, "group_by": arrayField},
+		singleKey: []string{"cardinality_fields"},
+	},
+}
+
+// conditionKinds maps the condition keys accepted by create_alert to their default kind
+var conditionKinds = map[string]string{
+	"immediate":       "immediate",
+	"more_than":       "threshold",
+	"less_than":       "threshold",
+	"more_than_usual": "anomaly",
+	"less_than_usual": "anomaly",
+	"new_value":       "new_value",
+	"unique_count":    "unique_count",
+	"flow":            "flow",
+}
+
+// conditionExamples shows a valid condition for each kind
+var conditionExamples = map[string]map[string]interface{}{
+	"immediate": {"immediate": map[string]interface{}{}},
+	"threshold": {"more_than": map[string]interface{}{"parameters": map[string]interface{}{
+		"threshold": 100, "timeframe": "timeframe_10_min", "group_by": []string{"json.service"}}}},
+	"ratio": {"more_than": map[string]interface{}{"parameters": map[string]interface{}{
+		"threshold": 0.05, "timeframe": "timeframe_1_h", "ignore_infinity": true}}},
+	"time_relative": {"more_than": map[string]interface{}{"parameters": map[string]interface{}{
+		"threshold": 2, "relative_timeframe": "day", "ignore_infinity": true}}},
+	"anomaly": {"more_than_usual": map[string]interface{}{"parameters": map[string]interface{}{
+		"threshold": 10, "timeframe": "timeframe_1_h"}}},
+	"new_value": {"new_value": map[string]interface{}{"parameters": map[string]interface{}{
+		"timeframe": "timeframe_24_h", "group_by": []string{"json.error_code"}}}},
+	"unique_count": {"unique_count": map[string]interface{}{"parameters": map[string]interface{}{
+		"threshold": 50, "timeframe": "timeframe_10_min", "cardinality_fields": []string{"json.user_id"}}}},
+	"flow": {"flow": map[string]interface{}{"stages": []interface{}{map[string]interface{}{"groups": []interface{}{}, "timeframe": map[string]interface{}{"ms": 60000}}}}},
+}
+
+// conditionKind resolves the kind of a condition key. more_than/less_than are ratio or
+// time-relative conditions when the alert's filter_type says so or relative_timeframe is set.
+func conditionKind(key, filterType string, params map[string]interface{}) string {
+	kind := conditionKinds[key]
+	if kind != "threshold" {
+		return kind
+	}
+	if _, ok := params["relative_timeframe"]; ok || filterType == "time_relative" {
+		return "time_relative"
+	}
+	if filterType == "ratio" {
+		return "ratio"
+	}
+	return kind
+}
+
+// ValidateAlertCondition checks a create_alert condition object against the known shapes.
+// It returns the condition key, the resolved kind and every problem found, each with its path.
+func ValidateAlertCondition(condition map[string]interface{}, filterType string) (string, string, []ConditionProblem) {
+	validKeys := make([]string, 0, len(conditionKinds))
+	for key := range conditionKinds {
+		validKeys = append(validKeys, key)
+	}
+	sort.Strings(validKeys)
+
+	if len(condition) == 0 {
+		return "", "", []ConditionProblem{{Path: "condition", Message: "condition is empty; set exactly one of: " + strings.Join(validKeys, ", ")}}
+	}
+	if len(condition) > 1 {
+		keys := make([]string, 0, len(condition))
+		for key := range condition {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return "", "", []ConditionProblem{{Path: "condition", Message: fmt.Sprintf("condition has %d types (%s); use exactly one", len(keys), strings.Join(keys, ", "))}}
+	}
+
+	var key string
+	var value interface{}
+	for k, v := range condition {
+		key, value = k, v
+	}
+	path := "condition." + key
+	if _, ok := conditionKinds[key]; !ok {
+		msg := fmt.Sprintf("unknown condition type '%s'; valid types: %s", key, strings.Join(validKeys, ", "))
+		if key == "threshold" {
+			msg += ". condition.threshold is the alert definition shape (create_alert_definition); create_alert uses more_than or less_than"
+		}
+		return key, "", []ConditionProblem{{Path: path, Message: msg}}
+	}
+	body, ok := value.(map[string]interface{})
+	if !ok {
+		return key, conditionKinds[key], []ConditionProblem{{Path: path, Message: "must be an object"}}
+	}
+
+	switch key {
+	case "immediate":
+		return key, "immediate", nil
+	case "flow":
+		if stages, ok := body["stages"].([]interface{}); !ok || len(stages) == 0 {
+			return key, "flow", []ConditionProblem{{Path: path + ".stages", Message: "flow conditions need a non-empty stages array"}}
+		}
+		return key, "flow", nil
+	}
+
+	params, ok := body["parameters"].(map[string]interface{})
+	if !ok {
+		return key, conditionKind(key, filterType, nil), []ConditionProblem{{Path: path + ".parameters", Message: "required: an object with the condition parameters"}}
+	}
+	kind := conditionKind(key, filterType, params)
+	shape := conditionShapes[kind]
+	paramsPath := path + ".parameters"
+
+	var problems []ConditionProblem
+	for _, field := range shape.required {
+		if _, ok := params[field]; !ok {
+			problems = append(problems, ConditionProblem{Path: paramsPath + "." + field, Message: fmt.Sprintf("required for %s conditions", strings.ReplaceAll(kind, "_", "-"))})
+		}
+	}
+	fields := make([]string, 0, len(shape.fields))
+	for field := range shape.fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	validator := &ResourceValidator{}
+	for _, field := range fields {
+		val, exists := params[field]
+		if !exists {
+			continue
+		}
+		if msg := validator.validateField(field, val, shape.fields[field]); msg != "" {
+			if field == "timeframe" {
+				msg = fmt.Sprintf("Field 'timeframe' must be a timeframe value such as timeframe_10_min or timeframe_1_h (got %v)", val)
+			}
+			problems = append(problems, ConditionProblem{Path: paramsPath + "." + field, Message: msg})
+		}
+	}
+	if threshold, ok := params["threshold"].(float64); ok && threshold < 0 {
+		problems = append(problems, ConditionProblem{Path: paramsPath + ".threshold", Message: "must not be negative"})
+	}
+	for _, field := range shape.singleKey {
+		if values, ok := params[field].([]interface{}); ok && len(values) != 1 {
+			problems = append(problems, ConditionProblem{Path: paramsPath + "." + field, Message: fmt.Sprintf("must contain exactly one field for %s conditions (got %d)", strings.ReplaceAll(kind, "_", "-"), len(values))})
+		}
+	}
+	if _, ok := params["relative_timeframe"]; ok && kind != "time_relative" {
+		problems = append(problems, ConditionProblem{Path: paramsPath + ".relative_timeframe", Message: "only applies to time-relative conditions"})
+	}
+	return key, kind, problems
+}
+
+// ValidateAlertConditionTool checks an alert condition object before create_alert
+type ValidateAlertConditionTool struct{ *BaseTool }
+
+// NewValidateAlertConditionTool creates a new tool instance
+func NewValidateAlertConditionTool(c client.Doer, l *zap.Logger) *ValidateAlertConditionTool {
+	return &ValidateAlertConditionTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *ValidateAlertConditionTool) Name() string { return "validate_alert_condition" }
+
+// Annotations returns tool hints for LLMs
+func (t *ValidateAlertConditionTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Validate Alert Condition")
+}
+
+// Description returns the tool description
+func (t *ValidateAlertConditionTool) Description() string {
+	return `Check an alert condition object before calling create_alert. Runs locally; nothing is sent to IBM Cloud Logs.
+
+Reports which kind of condition it is (threshold, ratio, time-relative, anomaly, new-value,
+unique-count, immediate or flow) and every missing or invalid sub-field with its path,
+e.g. condition.unique_count.parameters.cardinality_fields. Invalid conditions come with an example
+of the detected kind.
+
+Ratio and time-relative alerts use more_than/less_than conditions; pass the alert's
+filters.filter_type so they are checked as such.
+
+**Related tools:** create_alert, update_alert, create_alert_from_query`
+}
+
+// InputSchema returns the input schema
+func (t *ValidateAlertConditionTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"condition": map[string]interface{}{
+				"type":        "object",
+				"description": "The alert's condition object, with exactly one condition type key",
+			},
+			"filter_type": map[string]interface{}{
+				"type":        "string",
+				"description": "The alert's filters.filter_type; ratio and time_relative change how more_than/less_than are checked",
+				"enum":        []string{"text_or_unspecified", "template", "ratio", "unique_count", "time_relative", "metric", "flow"},
+			},
+		},
+		"required": []string{"condition"},
+		"examples": []interface{}{
+			map[string]interface{}{"condition": conditionExamples["threshold"]},
+			map[string]interface{}{"condition": conditionExamples["ratio"], "filter_type": "ratio"},
+		},
+	}
+}
+
+// Execute executes the tool
+func (t *ValidateAlertConditionTool) Execute(_ context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	condition, err := GetObjectParam(args, "condition", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	filterType, _ := GetStringParam(args, "filter_type", false)
+
+	key, kind, problems := ValidateAlertCondition(condition, filterType)
+	result := map[string]interface{}{
+		"valid": len(problems) == 0,
+	}
+	if key != "" {
+		result["condition_type"] = key
+	}
+	if kind != "" {
+		result["kind"] = kind
+	}
+	if len(problems) > 0 {
+		result["errors"] = problems
+		if example, ok := conditionExamples[kind]; ok {
+			result["example"] = example
+		}
+	}
+	return t.FormatResponse(result)
+}
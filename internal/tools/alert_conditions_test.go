@@ -0,0 +1,127 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestValidateAlertCondition(t *testing.T) {
+	tests := []struct {
+		name       string
+		condition  map[string]interface{}
+		filterType string
+		wantKind   string
+		wantPaths  []string
+	}{
+		{
+			name: "valid threshold",
+			condition: map[string]interface{}{"more_than": map[string]interface{}{"parameters": map[string]interface{}{
+				"threshold": 100.0, "timeframe": "timeframe_10_min", "group_by": []interface{}{"json.service"}}}},
+			wantKind: "threshold",
+		},
+		{
+			name: "unique count missing cardinality fields",
+			condition: map[string]interface{}{"unique_count": map[string]interface{}{"parameters": map[string]interface{}{
+				"threshold": 5.0, "timeframe": "timeframe_1_h"}}},
+			wantKind:  "unique_count",
+			wantPaths: []string{"condition.unique_count.parameters.cardinality_fields"},
+		},
+		{
+			name: "ratio from filter type",
+			condition: map[string]interface{}{"more_than": map[string]interface{}{"parameters": map[string]interface{}{
+				"threshold": 0.05, "timeframe": "timeframe_1_h", "ignore_infinity": "yes"}}},
+			filterType: "ratio",
+			wantKind:   "ratio",
+			wantPaths:  []string{"condition.more_than.parameters.ignore_infinity"},
+		},
+		{
+			name: "time relative needs relative timeframe",
+			condition: map[string]interface{}{"less_than": map[string]interface{}{"parameters": map[string]interface{}{
+				"threshold": 2.0}}},
+			filterType: "time_relative",
+			wantKind:   "time_relative",
+			wantPaths:  []string{"condition.less_than.parameters.relative_timeframe"},
+		},
+		{
+			name: "new value with two group by fields and bad timeframe",
+			condition: map[string]interface{}{"new_value": map[string]interface{}{"parameters": map[string]interface{}{
+				"timeframe": "24h", "group_by": []interface{}{"a", "b"}}}},
+			wantKind:  "new_value",
+			wantPaths: []string{"condition.new_value.parameters.group_by", "condition.new_value.parameters.timeframe"},
+		},
+		{
+			name:      "missing parameters",
+			condition: map[string]interface{}{"more_than": map[string]interface{}{}},
+			wantKind:  "threshold",
+			wantPaths: []string{"condition.more_than.parameters"},
+		},
+		{
+			name:      "immediate",
+			condition: map[string]interface{}{"immediate": map[string]interface{}{}},
+			wantKind:  "immediate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, kind, problems := ValidateAlertCondition(tt.condition, tt.filterType)
+			if kind != tt.wantKind {
+				t.Errorf("kind = %q, want %q", kind, tt.wantKind)
+			}
+			paths := make([]string, 0, len(problems))
+			for _, p := range problems {
+				paths = append(paths, p.Path)
+			}
+			for _, want := range tt.wantPaths {
+				found := false
+				for _, p := range paths {
+					found = found || p == want
+				}
+				if !found {
+					t.Errorf("expected a problem at %s, got %+v", want, problems)
+				}
+			}
+			if len(tt.wantPaths) == 0 && len(problems) > 0 {
+				t.Errorf("expected no problems, got %+v", problems)
+			}
+		})
+	}
+}
+
+func TestValidateAlertCondition_ShapeErrors(t *testing.T) {
+	_, _, problems := ValidateAlertCondition(map[string]interface{}{
+		"more_than": map[string]interface{}{}, "less_than": map[string]interface{}{},
+	}, "")
+	if len(problems) != 1 || !strings.Contains(problems[0].Message, "exactly one") {
+		t.Errorf("multiple condition types should be rejected, got %+v", problems)
+	}
+
+	_, _, problems = ValidateAlertCondition(map[string]interface{}{"threshold": map[string]interface{}{}}, "")
+	if len(problems) != 1 || !strings.Contains(problems[0].Message, "more_than or less_than") {
+		t.Errorf("the alert definition shape should get a hint, got %+v", problems)
+	}
+}
+
+func TestValidateAlertConditionTool_Execute(t *testing.T) {
+	mock := client.NewMockClient()
+	result, err := NewValidateAlertConditionTool(mock, zap.NewNop()).Execute(testCtx(mock), map[string]interface{}{
+		"condition": map[string]interface{}{"unique_count": map[string]interface{}{"parameters": map[string]interface{}{
+			"threshold": 5.0, "timeframe": "timeframe_1_h"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(text, `"valid": false`) || !strings.Contains(text, `"kind": "unique_count"`) ||
+		!strings.Contains(text, "condition.unique_count.parameters.cardinality_fields") || !strings.Contains(text, `"example"`) {
+		t.Errorf("unexpected result: %s", text)
+	}
+	if mock.RequestCount() != 0 {
+		t.Errorf("validation should not call the API")
+	}
+}
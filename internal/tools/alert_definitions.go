@@ -45,7 +45,7 @@ func (t *GetAlertDefinitionTool) InputSchema() interface{} {
 func (t *GetAlertDefinitionTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	id, err := GetStringParam(arguments, "id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	result, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/alert_definitions/" + id})
 	if err != nil {
@@ -76,17 +76,17 @@ func (t *ListAlertDefinitionsTool) Description() string {
 func (t *ListAlertDefinitionsTool) InputSchema() interface{} {
 	return map[string]interface{}{
 		"type":       "object",
-		"properties": map[string]interface{}{},
+		"properties": withSummaryOnly(map[string]interface{}{}),
 	}
 }
 
 // Execute executes the tool
-func (t *ListAlertDefinitionsTool) Execute(ctx context.Context, _ map[string]interface{}) (*mcp.CallToolResult, error) {
+func (t *ListAlertDefinitionsTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	result, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/alert_definitions"})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
-	return t.FormatResponseWithSuggestions(result, "list_alert_definitions")
+	return t.FormatListResponse(result, args, "list_alert_definitions")
 }
 
 // CreateAlertDefinitionTool creates a new alert definition
@@ -173,7 +173,7 @@ func (t *CreateAlertDefinitionTool) InputSchema() interface{} {
 func (t *CreateAlertDefinitionTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	def, err := GetObjectParam(arguments, "definition", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	// Check for dry-run mode
@@ -184,7 +184,7 @@ func (t *CreateAlertDefinitionTool) Execute(ctx context.Context, arguments map[s
 
 	result, err := t.ExecuteRequest(ctx, &client.Request{Method: "POST", Path: "/v1/alert_definitions", Body: def})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	return t.FormatResponseWithSuggestions(result, "create_alert_definition")
 }
@@ -270,6 +270,12 @@ func (t *UpdateAlertDefinitionTool) InputSchema() interface{} {
 			"definition": map[string]interface{}{"type": "object", "description": "Updated definition"},
 		},
 		"required": []string{"id", "definition"},
+		"examples": []interface{}{
+			map[string]interface{}{
+				"id":         "alert-def-uuid",
+				"definition": AlertDefinitionExample,
+			},
+		},
 	}
 }
 
@@ -277,15 +283,15 @@ func (t *UpdateAlertDefinitionTool) InputSchema() interface{} {
 func (t *UpdateAlertDefinitionTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	id, err := GetStringParam(arguments, "id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	def, err := GetObjectParam(arguments, "definition", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	result, err := t.ExecuteRequest(ctx, &client.Request{Method: "PUT", Path: "/v1/alert_definitions/" + id, Body: def})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	return t.FormatResponseWithSuggestions(result, "update_alert_definition")
 }
@@ -328,11 +334,11 @@ func (t *DeleteAlertDefinitionTool) InputSchema() interface{} {
 func (t *DeleteAlertDefinitionTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	id, err := GetStringParam(arguments, "id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	result, err := t.ExecuteRequest(ctx, &client.Request{Method: "DELETE", Path: "/v1/alert_definitions/" + id})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	return t.FormatResponseWithSuggestions(result, "delete_alert_definition")
 }
@@ -4,6 +4,7 @@ package tools
 import (
 	"fmt"
 	"math"
+	"slices"
 	"strings"
 	"time"
 )
@@ -137,6 +138,9 @@ type AdvancedAlertSuggestion struct {
 	// Infrastructure as Code
 	TerraformConfig string `json:"terraform_config,omitempty"`
 
+	// Evidence holds the observed numbers a threshold was derived from (use_live_data)
+	Evidence []string `json:"evidence,omitempty"`
+
 	// Explanation
 	Explanation   string   `json:"explanation"`
 	BestPractices []string `json:"best_practices"`
@@ -861,6 +865,94 @@ func DetectComponentType(query string, useCase string) ComponentType {
 	return ComponentCustom
 }
 
+// InferComponentFromName attempts to infer component type from an application
+// or service name (e.g., "orders-db", "session-cache", "edge-gateway").
+// Names are split into tokens so short markers like "db" only match whole words.
+func InferComponentFromName(name string) ComponentType {
+	tokens := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return r == '-' || r == '_' || r == '.' || r == ' ' || r == '/'
+	})
+	if len(tokens) == 0 {
+		return ComponentCustom
+	}
+
+	// Order matters! Storage-like markers are checked before generic service markers
+	patterns := []struct {
+		compType ComponentType
+		keywords []string
+	}{
+		{ComponentCache, []string{"cache", "redis", "memcached", "memcache"}},
+		{ComponentDatabase, []string{"db", "database", "postgres", "postgresql", "mysql", "mongo", "mongodb", "sql", "cassandra", "elasticsearch"}},
+		{ComponentMessageQueue, []string{"queue", "mq", "kafka", "rabbitmq", "rabbit", "sqs", "broker", "eventstreams"}},
+		{ComponentAPIGateway, []string{"gateway", "gw", "proxy", "ingress", "edge"}},
+		{ComponentLoadBalancer, []string{"lb", "loadbalancer", "haproxy", "nginx"}},
+		{ComponentWorker, []string{"worker", "job", "jobs", "cron", "scheduler", "consumer", "processor"}},
+		{ComponentStorage, []string{"storage", "s3", "cos", "blob", "volume"}},
+		{ComponentServerless, []string{"lambda", "function", "fn", "serverless"}},
+		{ComponentWebService, []string{"api", "web", "svc", "service", "frontend", "backend", "app", "server"}},
+	}
+
+	for _, p := range patterns {
+		for _, keyword := range p.keywords {
+			for _, token := range tokens {
+				if token == keyword {
+					return p.compType
+				}
+			}
+		}
+	}
+
+	return ComponentCustom
+}
+
+// MethodologySignals returns the signals covered by an alerting methodology
+func MethodologySignals(methodology AlertingMethodology) []string {
+	switch methodology {
+	case MethodologyRED:
+		return []string{"rate", "errors", "duration"}
+	case MethodologyUSE:
+		return []string{"utilization", "saturation", "errors"}
+	case MethodologyGoldenSignals:
+		return []string{"duration", "rate", "errors", "saturation"}
+	default:
+		return nil
+	}
+}
+
+// FilterMetricsByMethodology keeps only the metrics whose signal belongs to the
+// given methodology. Returns the input unchanged for unknown methodologies.
+func FilterMetricsByMethodology(metrics []MetricRecommendation, methodology AlertingMethodology) []MetricRecommendation {
+	signals := MethodologySignals(methodology)
+	if signals == nil {
+		return metrics
+	}
+
+	filtered := make([]MetricRecommendation, 0, len(metrics))
+	for _, m := range metrics {
+		if slices.Contains(signals, m.Signal) {
+			filtered = append(filtered, m)
+		}
+	}
+	return filtered
+}
+
+// FilterSuggestionsByMethodology keeps only the suggestions whose signal belongs to the
+// given methodology. Returns the input unchanged for unknown methodologies.
+func FilterSuggestionsByMethodology(suggestions []AdvancedAlertSuggestion, methodology AlertingMethodology) []AdvancedAlertSuggestion {
+	signals := MethodologySignals(methodology)
+	if signals == nil {
+		return suggestions
+	}
+
+	filtered := make([]AdvancedAlertSuggestion, 0, len(suggestions))
+	for _, s := range suggestions {
+		if slices.Contains(signals, s.Signal) {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
+
 // ValidateActionability ensures an alert has required actionability fields
 func ValidateActionability(suggestion *AdvancedAlertSuggestion) []string {
 	var errors []string
@@ -579,3 +579,108 @@ func TestGenerateDynamicBaselineQuery(t *testing.T) {
 func alertingTestContains(s, substr string) bool {
 	return strings.Contains(s, substr)
 }
+
+func TestInferComponentFromName(t *testing.T) {
+	tests := []struct {
+		name          string
+		wantComponent ComponentType
+	}{
+		{"orders-db", ComponentDatabase},
+		{"session_cache", ComponentCache},
+		{"edge-gateway", ComponentAPIGateway},
+		{"payments-api", ComponentWebService},
+		{"billing.worker", ComponentWorker},
+		{"kafka-events", ComponentMessageQueue},
+		{"dashboard", ComponentCustom}, // "db" must match whole tokens only
+		{"", ComponentCustom},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InferComponentFromName(tt.name); got != tt.wantComponent {
+				t.Errorf("InferComponentFromName(%q) = %v, want %v", tt.name, got, tt.wantComponent)
+			}
+		})
+	}
+}
+
+func TestFilterMetricsByMethodology(t *testing.T) {
+	metrics := []MetricRecommendation{
+		{Name: "request_rate", Signal: "rate"},
+		{Name: "error_rate", Signal: "errors"},
+		{Name: "cpu", Signal: "utilization"},
+		{Name: "pool_wait", Signal: "saturation"},
+	}
+
+	red := FilterMetricsByMethodology(metrics, MethodologyRED)
+	if len(red) != 2 {
+		t.Errorf("RED filter returned %d metrics, want 2", len(red))
+	}
+
+	use := FilterMetricsByMethodology(metrics, MethodologyUSE)
+	if len(use) != 3 {
+		t.Errorf("USE filter returned %d metrics, want 3", len(use))
+	}
+
+	unknown := FilterMetricsByMethodology(metrics, AlertingMethodology("OTHER"))
+	if len(unknown) != len(metrics) {
+		t.Errorf("Unknown methodology should not filter, got %d metrics", len(unknown))
+	}
+}
+
+func TestFilterSuggestionsByMethodology(t *testing.T) {
+	suggestions := []AdvancedAlertSuggestion{
+		{Name: "errors", Signal: "errors"},
+		{Name: "latency", Signal: "duration"},
+		{Name: "pool", Signal: "saturation"},
+	}
+
+	red := FilterSuggestionsByMethodology(suggestions, MethodologyRED)
+	if len(red) != 2 {
+		t.Errorf("RED filter returned %d suggestions, want 2", len(red))
+	}
+	for _, s := range red {
+		if s.Signal == "saturation" {
+			t.Error("RED filter should drop saturation suggestions")
+		}
+	}
+
+	use := FilterSuggestionsByMethodology(suggestions, MethodologyUSE)
+	if len(use) != 2 {
+		t.Errorf("USE filter returned %d suggestions, want 2", len(use))
+	}
+
+	unknown := FilterSuggestionsByMethodology(suggestions, AlertingMethodology("OTHER"))
+	if len(unknown) != len(suggestions) {
+		t.Errorf("Unknown methodology should not filter, got %d suggestions", len(unknown))
+	}
+}
+
+func TestResolveMethodology(t *testing.T) {
+	t.Run("infers USE from database name", func(t *testing.T) {
+		input := &SuggestAlertInput{ServiceName: "orders-db", UseCase: "slow responses"}
+		reason := resolveMethodology(input)
+
+		if input.ServiceType != ComponentDatabase {
+			t.Errorf("ServiceType = %v, want %v", input.ServiceType, ComponentDatabase)
+		}
+		if input.Methodology != MethodologyUSE {
+			t.Errorf("Methodology = %v, want %v", input.Methodology, MethodologyUSE)
+		}
+		if !strings.Contains(reason, "orders-db") {
+			t.Errorf("Reason should mention the service name, got %q", reason)
+		}
+	})
+
+	t.Run("explicit methodology wins", func(t *testing.T) {
+		input := &SuggestAlertInput{ServiceType: ComponentDatabase, Methodology: MethodologyRED}
+		reason := resolveMethodology(input)
+
+		if input.Methodology != MethodologyRED {
+			t.Errorf("Methodology = %v, want %v", input.Methodology, MethodologyRED)
+		}
+		if !strings.Contains(reason, "explicitly requested") {
+			t.Errorf("Reason should mention explicit request, got %q", reason)
+		}
+	})
+}
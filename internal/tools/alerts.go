@@ -1,12 +1,20 @@
 package tools
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"go.uber.org/zap"
 
 	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+	mcperrors "github.com/tareqmamari/cloud-logs-mcp/internal/errors"
 )
 
 // GetAlertTool retrieves a specific alert by ID
@@ -79,7 +87,7 @@ func (t *GetAlertTool) Execute(ctx context.Context, arguments map[string]interfa
 	id, err := GetStringParam(arguments, "id", true)
 	if err != nil {
 		session.RecordToolUse(t.Name(), false, arguments)
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	req := &client.Request{
@@ -132,6 +140,9 @@ func (t *ListAlertsTool) Description() string {
 - To find a specific alert's ID for updates or deletion
 - After creating an alert (to verify it was created)
 
+**Filtering:** Narrow long lists with severity, state and name_contains, and order them with
+sort_by/order. Filters are applied to the fetched page; the output reports filtered_count vs total_count.
+
 **Related tools:** get_alert, create_alert, update_alert, delete_alert, list_alert_definitions, list_outgoing_webhooks`
 }
 
@@ -139,9 +150,33 @@ func (t *ListAlertsTool) Description() string {
 func (t *ListAlertsTool) InputSchema() interface{} {
 	// Use standardized pagination schema for consistency
 	props := StandardPaginationSchema()
+	props["severity"] = map[string]interface{}{
+		"type":        "string",
+		"description": "Only return alerts with this severity",
+		"enum":        alertSeverities,
+	}
+	props["state"] = map[string]interface{}{
+		"type":        "string",
+		"description": "Only return alerts in this state: active (enabled), inactive (disabled), or triggered (has fired)",
+		"enum":        []string{"active", "inactive", "triggered"},
+	}
+	props["name_contains"] = map[string]interface{}{
+		"type":        "string",
+		"description": "Only return alerts whose name contains this text (case-insensitive)",
+	}
+	props["sort_by"] = map[string]interface{}{
+		"type":        "string",
+		"description": "Sort alerts by name or severity",
+		"enum":        []string{"name", "severity"},
+	}
+	props["order"] = map[string]interface{}{
+		"type":        "string",
+		"description": "Sort order (default: asc for name, desc for severity)",
+		"enum":        []string{"asc", "desc"},
+	}
 	return map[string]interface{}{
 		"type":       "object",
-		"properties": props,
+		"properties": withSummaryOnly(props),
 	}
 }
 
@@ -176,7 +211,14 @@ func (t *ListAlertsTool) Execute(ctx context.Context, arguments map[string]inter
 	pagination, err := GetPaginationParams(arguments)
 	if err != nil {
 		session.RecordToolUse(t.Name(), false, arguments)
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
+	}
+
+	// Filters are applied client-side, so they don't affect the cache key
+	filter, err := parseAlertListFilter(arguments)
+	if err != nil {
+		session.RecordToolUse(t.Name(), false, arguments)
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	// Generate cache key based on pagination
@@ -190,7 +232,7 @@ func (t *ListAlertsTool) Execute(ctx context.Context, arguments map[string]inter
 		if cachedResult, ok := cached.(map[string]interface{}); ok {
 			session.RecordToolUse(t.Name(), true, arguments)
 			cachedResult["_cached"] = true
-			return t.FormatResponseWithSuggestions(cachedResult, "list_alerts")
+			return t.FormatListResponse(FilterAndSortAlerts(cachedResult, filter), arguments, "list_alerts")
 		}
 	}
 
@@ -207,7 +249,7 @@ func (t *ListAlertsTool) Execute(ctx context.Context, arguments map[string]inter
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
 		session.RecordToolUse(t.Name(), false, arguments)
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	// Cache the result
@@ -217,7 +259,128 @@ func (t *ListAlertsTool) Execute(ctx context.Context, arguments map[string]inter
 	session.RecordToolUse(t.Name(), true, arguments)
 	session.CacheResult(t.Name(), result)
 
-	return t.FormatResponseWithSuggestions(result, "list_alerts")
+	return t.FormatListResponse(FilterAndSortAlerts(result, filter), arguments, "list_alerts")
+}
+
+// AlertListFilter holds the client-side filter and sort options for list_alerts
+type AlertListFilter struct {
+	Severity     string
+	State        string
+	NameContains string
+	SortBy       string
+	Order        string
+}
+
+// IsZero reports whether no filtering or sorting was requested
+func (f AlertListFilter) IsZero() bool {
+	return f == AlertListFilter{}
+}
+
+// parseAlertListFilter reads and validates the list_alerts filter parameters
+func parseAlertListFilter(args map[string]interface{}) (AlertListFilter, error) {
+	var f AlertListFilter
+	f.Severity, _ = GetStringParam(args, "severity", false)
+	f.State, _ = GetStringParam(args, "state", false)
+	f.NameContains, _ = GetStringParam(args, "name_contains", false)
+	f.SortBy, _ = GetStringParam(args, "sort_by", false)
+	f.Order, _ = GetStringParam(args, "order", false)
+
+	enums := []struct {
+		field, value string
+		allowed      []string
+	}{
+		{"severity", f.Severity, alertSeverities},
+		{"state", f.State, []string{"active", "inactive", "triggered"}},
+		{"sort_by", f.SortBy, []string{"name", "severity"}},
+		{"order", f.Order, []string{"asc", "desc"}},
+	}
+	for _, e := range enums {
+		if e.value != "" && !slices.Contains(e.allowed, e.value) {
+			return f, fmt.Errorf("invalid %s '%s'. Valid values: %s", e.field, e.value, strings.Join(e.allowed, ", "))
+		}
+	}
+	return f, nil
+}
+
+// FilterAndSortAlerts applies client-side filters and sorting to a list_alerts
+// response. The input is not modified; a shallow copy with the filtered alerts,
+// filtered_count and total_count is returned when any option is set.
+func FilterAndSortAlerts(result map[string]interface{}, f AlertListFilter) map[string]interface{} {
+	if f.IsZero() {
+		return result
+	}
+
+	alerts, _ := result["alerts"].([]interface{})
+	filtered := make([]interface{}, 0, len(alerts))
+	for _, item := range alerts {
+		alert, ok := item.(map[string]interface{})
+		if !ok || !alertMatchesFilter(alert, f) {
+			continue
+		}
+		filtered = append(filtered, alert)
+	}
+
+	if f.SortBy != "" {
+		desc := f.Order == "desc" || (f.Order == "" && f.SortBy == "severity")
+		sort.SliceStable(filtered, func(i, j int) bool {
+			a, b := filtered[i].(map[string]interface{}), filtered[j].(map[string]interface{})
+			var cmp int
+			if f.SortBy == "severity" {
+				cmp = alertSeverityRank(a) - alertSeverityRank(b)
+			} else {
+				an, _ := a["name"].(string)
+				bn, _ := b["name"].(string)
+				cmp = strings.Compare(strings.ToLower(an), strings.ToLower(bn))
+			}
+			if desc {
+				return cmp > 0
+			}
+			return cmp < 0
+		})
+	}
+
+	out := make(map[string]interface{}, len(result)+2)
+	for k, v := range result {
+		out[k] = v
+	}
+	out["alerts"] = filtered
+	out["filtered_count"] = len(filtered)
+	out["total_count"] = len(alerts)
+	return out
+}
+
+// alertMatchesFilter reports whether an alert satisfies every set filter
+func alertMatchesFilter(alert map[string]interface{}, f AlertListFilter) bool {
+	if f.Severity != "" {
+		if severity, _ := alert["severity"].(string); severity != f.Severity {
+			return false
+		}
+	}
+	if f.NameContains != "" {
+		name, _ := alert["name"].(string)
+		if !strings.Contains(strings.ToLower(name), strings.ToLower(f.NameContains)) {
+			return false
+		}
+	}
+	switch f.State {
+	case "active":
+		return alert["is_active"] == true
+	case "inactive":
+		return alert["is_active"] == false
+	case "triggered":
+		if state, _ := alert["state"].(string); strings.EqualFold(state, "triggered") {
+			return true
+		}
+		lastTriggered, _ := alert["last_triggered_at"].(string)
+		return lastTriggered != ""
+	}
+	return true
+}
+
+// alertSeverityRank orders severities from least (0) to most severe
+func alertSeverityRank(alert map[string]interface{}) int {
+	severity, _ := alert["severity"].(string)
+	return slices.Index(alertSeverities, severity)
 }
 
 // CreateAlertTool creates a new alert
@@ -251,7 +414,12 @@ func (t *CreateAlertTool) Description() string {
 **Prerequisites:**
 1. Create an alert definition (create_alert_def) to define the trigger condition
 2. Create an outgoing webhook (create_outgoing_webhook) for notifications
-3. Use this tool to link them together`
+3. Use this tool to link them together
+
+**Duplicate detection:** Existing alerts are checked first. If one has a nearly identical name
+(a few characters apart) or an identical condition (query and threshold), creation is refused
+with the existing alert's ID. Only the first page of the alert list is checked. Set force: true
+to create it anyway.`
 }
 
 // InputSchema returns the input schema
@@ -265,6 +433,7 @@ func (t *CreateAlertTool) InputSchema() interface{} {
 				"example": map[string]interface{}{
 					"name":                  "Production Error Alert",
 					"is_active":             true,
+					"severity":              "error",
 					"alert_definition_id":   "alert-def-uuid-here",
 					"notification_group_id": "notification-group-uuid-here",
 					"filters": map[string]interface{}{
@@ -280,6 +449,11 @@ func (t *CreateAlertTool) InputSchema() interface{} {
 				"description": "If true, validates the alert configuration without creating it. Use this to preview what will be created and check for errors.",
 				"default":     false,
 			},
+			"force": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Create the alert even if a similar existing alert is found",
+				"default":     false,
+			},
 		},
 		"required": []string{"alert"},
 	}
@@ -312,7 +486,7 @@ func (t *CreateAlertTool) Execute(ctx context.Context, arguments map[string]inte
 	alert, err := GetObjectParam(arguments, "alert", true)
 	if err != nil {
 		session.RecordToolUse(t.Name(), false, arguments)
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	// Check for dry-run mode
@@ -321,6 +495,14 @@ func (t *CreateAlertTool) Execute(ctx context.Context, arguments map[string]inte
 		return t.validateAlert(alert)
 	}
 
+	force, _ := GetBoolParam(arguments, "force", false)
+	if !force {
+		if dup := t.checkDuplicateAlert(ctx, alert); dup != nil {
+			session.RecordToolUse(t.Name(), false, arguments)
+			return dup, nil
+		}
+	}
+
 	req := &client.Request{
 		Method: "POST",
 		Path:   "/v1/alerts",
@@ -330,7 +512,7 @@ func (t *CreateAlertTool) Execute(ctx context.Context, arguments map[string]inte
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
 		session.RecordToolUse(t.Name(), false, arguments)
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	// Invalidate related caches
@@ -344,32 +526,108 @@ func (t *CreateAlertTool) Execute(ctx context.Context, arguments map[string]inte
 	return t.FormatResponseWithSuggestions(result, "create_alert")
 }
 
+// duplicateAlertNameThreshold is the name similarity at or above which an alert counts as a duplicate
+const duplicateAlertNameThreshold = 0.85
+
+// checkDuplicateAlert looks for an existing alert similar to the one being created and returns
+// a CONFLICT result naming it, or nil when none is found. Failing to list alerts does not block
+// creation. Only the alerts returned by one GET /v1/alerts call are checked, so on instances
+// whose alert list is paged the check is best effort.
+func (t *CreateAlertTool) checkDuplicateAlert(ctx context.Context, alert map[string]interface{}) *mcp.CallToolResult {
+	existing, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/alerts"})
+	if err != nil {
+		t.logger.Warn("Skipping duplicate alert check, failed to list alerts", zap.Error(err))
+		return nil
+	}
+	alerts, _ := existing["alerts"].([]interface{})
+
+	match, reason := findDuplicateAlert(alert, alerts)
+	if match == nil {
+		return nil
+	}
+	id, _ := match["id"].(string)
+	name, _ := match["name"].(string)
+	return newToolError(mcperrors.CodeConflict,
+		fmt.Sprintf("A similar alert already exists: %s (ID: %s) - %s", name, id, reason),
+		"Update the existing alert with update_alert, or set force: true to create this alert anyway.")
+}
+
+// findDuplicateAlert returns the first existing alert whose name is fuzzily similar to the new
+// alert's, or whose condition (query and threshold) is identical, along with the reason
+func findDuplicateAlert(alert map[string]interface{}, existing []interface{}) (map[string]interface{}, string) {
+	name, _ := alert["name"].(string)
+	name = strings.ToLower(strings.TrimSpace(name))
+	condition, _ := alert["condition"].(map[string]interface{})
+
+	for _, item := range existing {
+		other, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if otherName, ok := other["name"].(string); ok && name != "" {
+			otherName = strings.ToLower(strings.TrimSpace(otherName))
+			if otherName != "" && nameSimilarity(name, otherName) >= duplicateAlertNameThreshold {
+				return other, "very similar name"
+			}
+		}
+		if len(condition) > 0 && sameJSON(condition, other["condition"]) {
+			return other, "identical query and threshold"
+		}
+	}
+	return nil, ""
+}
+
+// duplicateAlertNameLengthRatio is the minimum length ratio of two alert names compared for
+// duplicates, so fuzzyMatch's substring scores only flag names of nearly the same length
+const duplicateAlertNameLengthRatio = 0.85
+
+// nameSimilarity scores two alert names with fuzzyMatch, the matcher used for tool discovery,
+// guarded by a length ratio: "high errors" matches "high error", but "errors" does not match
+// every name containing it such as "checkout errors"
+func nameSimilarity(a, b string) float64 {
+	la, lb := utf8.RuneCountInString(a), utf8.RuneCountInString(b)
+	shorter, longer := min(la, lb), max(la, lb)
+	if longer == 0 {
+		return 1
+	}
+	if float64(shorter)/float64(longer) < duplicateAlertNameLengthRatio {
+		return 0
+	}
+	return fuzzyMatch(a, b)
+}
+
+// sameJSON reports whether two values encode to the same JSON, so numbers compare equal
+// regardless of their Go type
+func sameJSON(a, b interface{}) bool {
+	aj, errA := json.Marshal(a)
+	bj, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(aj, bj)
+}
+
 // validateAlert performs dry-run validation for alert creation
 func (t *CreateAlertTool) validateAlert(alert map[string]interface{}) (*mcp.CallToolResult, error) {
+	return FormatDryRunResult(checkAlertSpec(alert), "Alert", alert), nil
+}
+
+// checkAlertSpec validates an alert configuration for creation
+func checkAlertSpec(alert map[string]interface{}) *ValidationResult {
 	result := &ValidationResult{
 		Valid:   true,
 		Summary: make(map[string]interface{}),
 	}
 
 	// Validate required fields
-	requiredFields := []string{"name"}
-	for _, field := range requiredFields {
-		if _, ok := alert[field]; !ok {
-			result.Errors = append(result.Errors, "Missing required field: "+field)
-			result.Valid = false
-		}
+	if requiredErrors := ValidateRequiredFields(alert, []string{"name"}); len(requiredErrors) > 0 {
+		result.Valid = false
+		result.Errors = append(result.Errors, requiredErrors...)
 	}
 
 	// Validate name length
+	if errMsg := ValidateStringLength(alert, "name", 1, 4096); errMsg != "" {
+		result.Valid = false
+		result.Errors = append(result.Errors, errMsg)
+	}
 	if name, ok := alert["name"].(string); ok {
-		if len(name) < 1 {
-			result.Errors = append(result.Errors, "Alert name must not be empty")
-			result.Valid = false
-		}
-		if len(name) > 4096 {
-			result.Errors = append(result.Errors, "Alert name must be at most 4096 characters")
-			result.Valid = false
-		}
 		result.Summary["name"] = name
 	}
 
@@ -380,18 +638,34 @@ func (t *CreateAlertTool) validateAlert(alert map[string]interface{}) (*mcp.Call
 		result.Summary["is_active"] = true // default
 	}
 
-	// Validate alert_definition_id (recommended)
-	if alertDefID, ok := alert["alert_definition_id"].(string); ok && alertDefID != "" {
+	// Validate severity
+	if errMsg := ValidateEnumField(alert, "severity", alertSeverities); errMsg != "" {
+		result.Valid = false
+		result.Errors = append(result.Errors, errMsg)
+	} else if severity, ok := alert["severity"].(string); ok {
+		result.Summary["severity"] = severity
+	}
+
+	// A condition is required unless it comes from a linked alert definition
+	alertDefID, _ := alert["alert_definition_id"].(string)
+	if condition, ok := alert["condition"].(map[string]interface{}); ok && len(condition) > 0 {
+		for conditionType := range condition {
+			result.Summary["condition_type"] = conditionType
+		}
+	} else if alertDefID != "" {
 		result.Summary["alert_definition_id"] = alertDefID
 	} else {
-		result.Warnings = append(result.Warnings, "No alert_definition_id provided - consider using list_alert_definitions to find an existing definition or create_alert_def to create one")
+		result.Valid = false
+		result.Errors = append(result.Errors, "Missing required field: condition (or alert_definition_id referencing an alert definition that provides it)")
 	}
 
-	// Validate notification_group_id (recommended)
+	// Validate notification target (recommended)
 	if notifGroupID, ok := alert["notification_group_id"].(string); ok && notifGroupID != "" {
 		result.Summary["notification_group_id"] = notifGroupID
+	} else if groups, ok := alert["notification_groups"].([]interface{}); ok && len(groups) > 0 {
+		result.Summary["notification_groups"] = len(groups)
 	} else {
-		result.Warnings = append(result.Warnings, "No notification_group_id provided - without this, alert triggers won't send notifications")
+		result.Warnings = append(result.Warnings, "No notification webhook attached (notification_group_id or notification_groups) - without this, alert triggers won't send notifications")
 	}
 
 	// Validate filters if provided
@@ -417,6 +691,9 @@ func (t *CreateAlertTool) validateAlert(alert map[string]interface{}) (*mcp.Call
 	}
 
 	// Add suggestions
+	if !hasRunbook(alert) {
+		result.Suggestions = append(result.Suggestions, "Add a runbook URL (e.g., a meta_labels entry with key 'runbook_url') so responders know how to act on this alert")
+	}
 	if result.Valid {
 		result.Suggestions = append(result.Suggestions, "Alert configuration is valid")
 		result.Suggestions = append(result.Suggestions, "Remove dry_run parameter to create the alert")
@@ -431,7 +708,27 @@ func (t *CreateAlertTool) validateAlert(alert map[string]interface{}) (*mcp.Call
 		RiskLevel: "low",
 	}
 
-	return FormatDryRunResult(result, "Alert", alert), nil
+	return result
+}
+
+// alertSeverities are the severity values accepted by the alerts API
+var alertSeverities = []string{"info_or_unspecified", "warning", "error", "critical"}
+
+// hasRunbook reports whether the alert references a runbook in its labels or description
+func hasRunbook(alert map[string]interface{}) bool {
+	if labels, ok := alert["meta_labels"].([]interface{}); ok {
+		for _, l := range labels {
+			if label, ok := l.(map[string]interface{}); ok {
+				if key, _ := label["key"].(string); strings.Contains(strings.ToLower(key), "runbook") {
+					return true
+				}
+			}
+		}
+	}
+	if desc, ok := alert["description"].(string); ok && strings.Contains(strings.ToLower(desc), "runbook") {
+		return true
+	}
+	return false
 }
 
 // UpdateAlertTool updates an existing alert
@@ -472,10 +769,25 @@ func (t *UpdateAlertTool) InputSchema() interface{} {
 			},
 			"alert": map[string]interface{}{
 				"type":        "object",
-				"description": "The updated alert configuration",
+				"description": "The updated alert configuration (same structure as create_alert)",
 			},
 		},
 		"required": []string{"id", "alert"},
+		"examples": []interface{}{
+			map[string]interface{}{
+				"id": "alert-uuid",
+				"alert": map[string]interface{}{
+					"name":                  "Production Error Alert",
+					"is_active":             false,
+					"severity":              "critical",
+					"alert_definition_id":   "alert-def-uuid-here",
+					"notification_group_id": "notification-group-uuid-here",
+					"filters": map[string]interface{}{
+						"severities": []string{"critical"},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -504,12 +816,12 @@ func (t *UpdateAlertTool) Execute(ctx context.Context, arguments map[string]inte
 
 	id, err := GetStringParam(arguments, "id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	alert, err := GetObjectParam(arguments, "alert", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	req := &client.Request{
@@ -520,7 +832,7 @@ func (t *UpdateAlertTool) Execute(ctx context.Context, arguments map[string]inte
 
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	// Invalidate related caches
@@ -594,7 +906,7 @@ func (t *DeleteAlertTool) Execute(ctx context.Context, arguments map[string]inte
 
 	id, err := GetStringParam(arguments, "id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	req := &client.Request{
@@ -604,7 +916,7 @@ func (t *DeleteAlertTool) Execute(ctx context.Context, arguments map[string]inte
 
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	// Invalidate related caches
@@ -1,9 +1,14 @@
 package tools
 
 import (
+	"context"
 	"testing"
 
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
 )
 
 func TestGetAlertTool_InputSchema(t *testing.T) {
@@ -55,3 +60,180 @@ func TestDeleteAlertTool_InputSchema(t *testing.T) {
 	idProp := props["id"].(map[string]interface{})
 	assert.Equal(t, "string", idProp["type"])
 }
+
+func TestCreateAlertTool_DryRun(t *testing.T) {
+	tool := NewCreateAlertTool(nil, nil)
+
+	dryRun := func(alert map[string]interface{}) string {
+		result, err := tool.Execute(context.Background(), map[string]interface{}{
+			"alert":   alert,
+			"dry_run": true,
+		})
+		assert.NoError(t, err)
+		return result.Content[0].(*mcp.TextContent).Text
+	}
+
+	t.Run("missing name and condition", func(t *testing.T) {
+		text := dryRun(map[string]interface{}{"is_active": true})
+		assert.Contains(t, text, "Missing required field: name")
+		assert.Contains(t, text, "Missing required field: condition")
+	})
+
+	t.Run("invalid severity", func(t *testing.T) {
+		text := dryRun(map[string]interface{}{
+			"name":                "Errors",
+			"severity":            "sev1",
+			"alert_definition_id": "def-1",
+		})
+		assert.Contains(t, text, "Invalid value for 'severity'")
+	})
+
+	t.Run("warns about webhook and suggests runbook", func(t *testing.T) {
+		text := dryRun(map[string]interface{}{
+			"name":      "Errors",
+			"severity":  "critical",
+			"condition": map[string]interface{}{"immediate": map[string]interface{}{}},
+		})
+		assert.Contains(t, text, "No notification webhook attached")
+		assert.Contains(t, text, "runbook")
+	})
+
+	t.Run("runbook label suppresses suggestion", func(t *testing.T) {
+		alert := map[string]interface{}{
+			"name":                  "Errors",
+			"condition":             map[string]interface{}{"immediate": map[string]interface{}{}},
+			"notification_group_id": "ng-1",
+			"meta_labels": []interface{}{
+				map[string]interface{}{"key": "runbook_url", "value": "https://runbooks/errors"},
+			},
+		}
+		assert.True(t, hasRunbook(alert))
+		text := dryRun(alert)
+		assert.NotContains(t, text, "No notification webhook attached")
+	})
+}
+
+func TestCreateAlertTool_DuplicateDetection(t *testing.T) {
+	existing := map[string]interface{}{
+		"alerts": []map[string]interface{}{
+			{
+				"id":   "alert-1",
+				"name": "Production Error Alert",
+				"condition": map[string]interface{}{
+					"more_than": map[string]interface{}{"query": "level:error", "threshold": 10},
+				},
+			},
+		},
+	}
+
+	create := func(t *testing.T, args map[string]interface{}) (*client.MockClient, *mcp.CallToolResult) {
+		mock := client.NewMockClient()
+		mock.RespondWith(200, existing)
+		mock.RespondWith(201, map[string]interface{}{"id": "new-alert"})
+		result, err := NewCreateAlertTool(mock, zap.NewNop()).Execute(testCtx(mock), args)
+		assert.NoError(t, err)
+		return mock, result
+	}
+
+	t.Run("similar name is refused", func(t *testing.T) {
+		mock, result := create(t, map[string]interface{}{
+			"alert": map[string]interface{}{"name": "production error alert"},
+		})
+		assert.True(t, result.IsError)
+		text := result.Content[0].(*mcp.TextContent).Text
+		assert.Contains(t, text, "[CONFLICT]")
+		assert.Contains(t, text, "alert-1")
+		assert.Contains(t, text, "force: true")
+		assert.Equal(t, 1, mock.RequestCount(), "should not POST the duplicate")
+	})
+
+	t.Run("identical condition is refused", func(t *testing.T) {
+		_, result := create(t, map[string]interface{}{
+			"alert": map[string]interface{}{
+				"name": "Checkout failures",
+				"condition": map[string]interface{}{
+					"more_than": map[string]interface{}{"query": "level:error", "threshold": 10},
+				},
+			},
+		})
+		assert.True(t, result.IsError)
+		assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "identical query and threshold")
+	})
+
+	t.Run("force creates anyway", func(t *testing.T) {
+		mock, result := create(t, map[string]interface{}{
+			"alert": map[string]interface{}{"name": "Production Error Alert"},
+			"force": true,
+		})
+		assert.False(t, result.IsError)
+		assert.Equal(t, "POST", mock.LastRequest().Method)
+		assert.Equal(t, 1, mock.RequestCount(), "force should skip the duplicate lookup")
+	})
+
+	t.Run("distinct alert is created", func(t *testing.T) {
+		mock, result := create(t, map[string]interface{}{
+			"alert": map[string]interface{}{"name": "Staging Latency Alert"},
+		})
+		assert.False(t, result.IsError)
+		assert.Equal(t, "POST", mock.LastRequest().Method)
+	})
+
+	t.Run("name containing an existing name is created", func(t *testing.T) {
+		mock, result := create(t, map[string]interface{}{
+			"alert": map[string]interface{}{"name": "Production Error Alert - payments"},
+		})
+		assert.False(t, result.IsError)
+		assert.Equal(t, "POST", mock.LastRequest().Method)
+	})
+}
+
+func TestNameSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, nameSimilarity("high errors", "high errors"))
+	assert.GreaterOrEqual(t, nameSimilarity("high errors", "high error"), duplicateAlertNameThreshold)
+	assert.Less(t, nameSimilarity("errors", "checkout errors"), duplicateAlertNameThreshold)
+	assert.Less(t, nameSimilarity("api latency", "db latency"), duplicateAlertNameThreshold)
+	// Same score as fuzzyMatch once the lengths are close
+	assert.Equal(t, fuzzyMatch("production error alert", "production error alerts"), nameSimilarity("production error alert", "production error alerts"))
+	assert.Equal(t, 0.0, nameSimilarity("abc", ""))
+}
+
+func TestFilterAndSortAlerts(t *testing.T) {
+	result := map[string]interface{}{
+		"alerts": []interface{}{
+			map[string]interface{}{"name": "API errors", "severity": "error", "is_active": true},
+			map[string]interface{}{"name": "api latency", "severity": "warning", "is_active": false},
+			map[string]interface{}{"name": "DB down", "severity": "critical", "is_active": true, "last_triggered_at": "2024-01-01T00:00:00Z"},
+		},
+	}
+
+	out := FilterAndSortAlerts(result, AlertListFilter{NameContains: "API", SortBy: "name"})
+	alerts := out["alerts"].([]interface{})
+	assert.Len(t, alerts, 2)
+	assert.Equal(t, "API errors", alerts[0].(map[string]interface{})["name"])
+	assert.Equal(t, 2, out["filtered_count"])
+	assert.Equal(t, 3, out["total_count"])
+
+	out = FilterAndSortAlerts(result, AlertListFilter{State: "active", SortBy: "severity"})
+	alerts = out["alerts"].([]interface{})
+	assert.Len(t, alerts, 2)
+	assert.Equal(t, "DB down", alerts[0].(map[string]interface{})["name"], "severity sorts most severe first by default")
+
+	out = FilterAndSortAlerts(result, AlertListFilter{State: "triggered"})
+	assert.Equal(t, 1, out["filtered_count"])
+
+	out = FilterAndSortAlerts(result, AlertListFilter{Severity: "warning", State: "inactive"})
+	assert.Equal(t, 1, out["filtered_count"])
+
+	// No options leaves the response untouched
+	assert.Equal(t, result, FilterAndSortAlerts(result, AlertListFilter{}))
+	assert.Len(t, result["alerts"], 3)
+}
+
+func TestParseAlertListFilter_Invalid(t *testing.T) {
+	_, err := parseAlertListFilter(map[string]interface{}{"sort_by": "created"})
+	assert.Error(t, err)
+
+	f, err := parseAlertListFilter(map[string]interface{}{"severity": "critical", "order": "asc"})
+	assert.NoError(t, err)
+	assert.Equal(t, "critical", f.Severity)
+}
@@ -2,6 +2,8 @@ package tools
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"go.uber.org/zap"
@@ -34,7 +36,7 @@ func (t *GetOutgoingWebhookTool) InputSchema() interface{} {
 func (t *GetOutgoingWebhookTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	id, err := GetStringParam(args, "id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/outgoing_webhooks/" + id})
 	if err != nil {
@@ -63,16 +65,20 @@ func (t *ListOutgoingWebhooksTool) Description() string {
 
 // InputSchema returns the input schema
 func (t *ListOutgoingWebhooksTool) InputSchema() interface{} {
-	return map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+	return map[string]interface{}{"type": "object", "properties": withSummaryOnly(OffsetPaginationSchema())}
 }
 
 // Execute executes the tool
-func (t *ListOutgoingWebhooksTool) Execute(ctx context.Context, _ map[string]interface{}) (*mcp.CallToolResult, error) {
+func (t *ListOutgoingWebhooksTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/outgoing_webhooks"})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
+	}
+	paged, err := ApplyOffsetPagination(res, args, "outgoing_webhooks")
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
 	}
-	return t.FormatResponseWithSuggestions(res, "list_outgoing_webhooks")
+	return t.FormatListResponse(paged, args, "list_outgoing_webhooks")
 }
 
 // CreateOutgoingWebhookTool creates a new outgoing webhook.
@@ -96,7 +102,13 @@ func (t *CreateOutgoingWebhookTool) Description() string {
 - generic: Custom HTTP webhook to any endpoint
 - slack: Slack incoming webhook integration
 - pagerduty: PagerDuty integration for incident management
-- ibm_event_notifications: IBM Cloud Event Notifications service`
+- ibm_event_notifications: IBM Cloud Event Notifications service. A url alone is rejected; set
+  the ibm_event_notifications object with the instance GUID (or CRN) and region
+
+**Custom messages:** payload_template sets the notification body for slack and generic webhooks
+using alert placeholders ($ALERT_NAME, $ALERT_SEVERITY, $APPLICATION_NAME, $LOG_URL, ...). The
+native slack integration has a fixed format, so a templated slack webhook is created as a generic
+webhook posting {"text": <template>} to the Slack URL.`
 }
 
 // InputSchema returns the input schema
@@ -119,10 +131,12 @@ func (t *CreateOutgoingWebhookTool) InputSchema() interface{} {
 					},
 					"url": map[string]interface{}{
 						"type":        "string",
-						"description": "Target URL for the webhook",
+						"description": "Target URL for the webhook (optional for ibm_event_notifications)",
 					},
+					"ibm_event_notifications": eventNotificationsSchema,
 				},
 			},
+			"payload_template": payloadTemplateSchema,
 			"dry_run": map[string]interface{}{
 				"type":        "boolean",
 				"description": "If true, validates the webhook configuration without creating it. Use this to preview and check for errors.",
@@ -138,6 +152,14 @@ func (t *CreateOutgoingWebhookTool) InputSchema() interface{} {
 					"url":  "https://hooks.slack.com/services/XXX/YYY/ZZZ",
 				},
 			},
+			map[string]interface{}{
+				"webhook": map[string]interface{}{
+					"name": "Slack Alerts (custom)",
+					"type": "slack",
+					"url":  "https://hooks.slack.com/services/XXX/YYY/ZZZ",
+				},
+				"payload_template": ":rotating_light: *$ALERT_NAME* ($ALERT_SEVERITY) on $APPLICATION_NAME/$SUBSYSTEM_NAME\n$HIT_COUNT matching logs: $LOG_URL",
+			},
 			map[string]interface{}{
 				"webhook": map[string]interface{}{
 					"name": "PagerDuty Critical",
@@ -145,6 +167,17 @@ func (t *CreateOutgoingWebhookTool) InputSchema() interface{} {
 					"url":  "https://events.pagerduty.com/v2/enqueue",
 				},
 			},
+			map[string]interface{}{
+				"webhook": map[string]interface{}{
+					"name": "Event Notifications",
+					"type": "ibm_event_notifications",
+					"ibm_event_notifications": map[string]interface{}{
+						"event_notifications_instance_id": "6964e1e9-74a2-4c6c-980b-d806ff75175d",
+						"region_id":                       "us-south",
+						"source_name":                     "Cloud Logs production",
+					},
+				},
+			},
 		},
 	}
 }
@@ -153,7 +186,12 @@ func (t *CreateOutgoingWebhookTool) InputSchema() interface{} {
 func (t *CreateOutgoingWebhookTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	wh, err := GetObjectParam(args, "webhook", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
+	}
+	if template, _ := GetStringParam(args, "payload_template", false); template != "" {
+		if err := applyPayloadTemplate(wh, template); err != nil {
+			return NewToolResultErrorFromErr(err), nil
+		}
 	}
 
 	// Check for dry-run mode
@@ -161,69 +199,39 @@ func (t *CreateOutgoingWebhookTool) Execute(ctx context.Context, args map[string
 	if dryRun {
 		return t.validateWebhook(wh)
 	}
+	if errs := validateWebhookTypeFields(wh); len(errs) > 0 {
+		return NewToolResultErrorWithSuggestion("Invalid webhook: "+strings.Join(errs, "; "),
+			"Use dry_run=true to validate the webhook configuration."), nil
+	}
 
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "POST", Path: "/v1/outgoing_webhooks", Body: wh})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	return t.FormatResponseWithSuggestions(res, "create_outgoing_webhook")
 }
 
+// webhookDryRunSpec defines dry-run validation for outgoing webhooks
+var webhookDryRunSpec = DryRunSpec{
+	ResourceType:   "Webhook",
+	RequiredFields: []string{"name", "type"},
+	EnumFields: map[string][]string{
+		"type": {"generic", "slack", "pagerduty", "ibm_event_notifications"},
+	},
+	SummaryFields: []string{"name", "type", "url", "generic_webhook", "ibm_event_notifications"},
+}
+
 // validateWebhook performs dry-run validation for webhook creation
 func (t *CreateOutgoingWebhookTool) validateWebhook(wh map[string]interface{}) (*mcp.CallToolResult, error) {
-	result := &ValidationResult{
-		Valid:   true,
-		Summary: make(map[string]interface{}),
-	}
+	result := t.ValidateDryRun(wh, webhookDryRunSpec)
 
-	// Validate required fields
-	requiredFields := []string{"name", "type", "url"}
-	for _, field := range requiredFields {
-		if _, ok := wh[field]; !ok {
-			result.Errors = append(result.Errors, "Missing required field: "+field)
-			result.Valid = false
-		}
-	}
-
-	// Validate name
-	if name, ok := wh["name"].(string); ok {
-		result.Summary["name"] = name
-	}
-
-	// Validate type
-	validTypes := map[string]bool{
-		"generic":                 true,
-		"slack":                   true,
-		"pagerduty":               true,
-		"ibm_event_notifications": true,
-	}
-	if whType, ok := wh["type"].(string); ok {
-		if !validTypes[whType] {
-			result.Errors = append(result.Errors, "Invalid webhook type: "+whType+". Valid types: generic, slack, pagerduty, ibm_event_notifications")
-			result.Valid = false
-		}
-		result.Summary["type"] = whType
-	}
-
-	// Validate URL format
-	if urlStr, ok := wh["url"].(string); ok {
-		result.Summary["url"] = urlStr
-		if urlStr == "" {
-			result.Errors = append(result.Errors, "URL cannot be empty")
-			result.Valid = false
-		}
-	}
-
-	// Add suggestions
-	if result.Valid {
-		result.Suggestions = append(result.Suggestions, "Webhook configuration is valid")
-		result.Suggestions = append(result.Suggestions, "Remove dry_run parameter to create the webhook")
-	} else {
-		result.Suggestions = append(result.Suggestions, "Fix the errors above before creating")
+	// url or the ibm_event_notifications target, depending on the type
+	if errs := validateWebhookTypeFields(wh); len(errs) > 0 {
+		result.Errors = append(result.Errors, errs...)
+		result.Valid = false
 	}
 
-	result.EstimatedImpact = &ImpactEstimate{RiskLevel: "low"}
-	return FormatDryRunResult(result, "Outgoing Webhook", wh), nil
+	return t.FormatDryRun(result, webhookDryRunSpec, wh), nil
 }
 
 // UpdateOutgoingWebhookTool updates an existing outgoing webhook.
@@ -242,22 +250,50 @@ func (t *UpdateOutgoingWebhookTool) Description() string { return "Update an out
 
 // InputSchema returns the input schema
 func (t *UpdateOutgoingWebhookTool) InputSchema() interface{} {
-	return map[string]interface{}{"type": "object", "properties": map[string]interface{}{"id": map[string]interface{}{"type": "string"}, "webhook": map[string]interface{}{"type": "object"}}, "required": []string{"id", "webhook"}}
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"id":               map[string]interface{}{"type": "string", "description": "The unique identifier of the webhook"},
+			"webhook":          map[string]interface{}{"type": "object", "description": "Updated webhook configuration (same structure as create_outgoing_webhook)"},
+			"payload_template": payloadTemplateSchema,
+		},
+		"required": []string{"id", "webhook"},
+		"examples": []interface{}{
+			map[string]interface{}{
+				"id": "webhook-uuid",
+				"webhook": map[string]interface{}{
+					"name": "Slack Alerts",
+					"type": "slack",
+					"url":  "https://hooks.slack.com/services/XXX/YYY/ZZZ",
+				},
+			},
+		},
+	}
 }
 
 // Execute executes the tool
 func (t *UpdateOutgoingWebhookTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	id, err := GetStringParam(args, "id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	wh, err := GetObjectParam(args, "webhook", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
+	}
+	if template, _ := GetStringParam(args, "payload_template", false); template != "" {
+		if err := applyPayloadTemplate(wh, template); err != nil {
+			return NewToolResultErrorFromErr(err), nil
+		}
+	}
+	if _, ok := wh["type"]; ok {
+		if errs := validateWebhookTypeFields(wh); len(errs) > 0 {
+			return NewToolResultError("Invalid webhook: " + strings.Join(errs, "; ")), nil
+		}
 	}
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "PUT", Path: "/v1/outgoing_webhooks/" + id, Body: wh})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	return t.FormatResponseWithSuggestions(res, "update_outgoing_webhook")
 }
@@ -279,24 +315,22 @@ func (t *DeleteOutgoingWebhookTool) Annotations() *mcp.ToolAnnotations {
 }
 
 // Description returns the tool description
-func (t *DeleteOutgoingWebhookTool) Description() string { return "Delete an outgoing webhook" }
+func (t *DeleteOutgoingWebhookTool) Description() string {
+	return `Delete an outgoing webhook.
+
+The delete is refused while alerts or alert definitions notify through the webhook, since they
+would silently stop sending notifications. Use dry_run=true to list those dependents without
+deleting, and force=true to delete regardless.`
+}
 
 // InputSchema returns the input schema
 func (t *DeleteOutgoingWebhookTool) InputSchema() interface{} {
-	return map[string]interface{}{"type": "object", "properties": map[string]interface{}{"id": map[string]interface{}{"type": "string"}}, "required": []string{"id"}}
+	return deleteDependentsSchema(webhookDeleteGuard)
 }
 
 // Execute executes the tool
 func (t *DeleteOutgoingWebhookTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
-	id, err := GetStringParam(args, "id", true)
-	if err != nil {
-		return NewToolResultError(err.Error()), nil
-	}
-	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "DELETE", Path: "/v1/outgoing_webhooks/" + id})
-	if err != nil {
-		return NewToolResultError(err.Error()), nil
-	}
-	return t.FormatResponseWithSuggestions(res, "delete_outgoing_webhook")
+	return t.guardedDelete(ctx, args, webhookDeleteGuard, "delete_outgoing_webhook")
 }
 
 // GetPolicyTool retrieves a specific policy by ID.
@@ -322,7 +356,7 @@ func (t *GetPolicyTool) InputSchema() interface{} {
 func (t *GetPolicyTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	id, err := GetStringParam(args, "id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/policies/" + id})
 	if err != nil {
@@ -351,16 +385,20 @@ func (t *ListPoliciesTool) Description() string {
 
 // InputSchema returns the input schema
 func (t *ListPoliciesTool) InputSchema() interface{} {
-	return map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+	return map[string]interface{}{"type": "object", "properties": withSummaryOnly(OffsetPaginationSchema())}
 }
 
 // Execute executes the tool
-func (t *ListPoliciesTool) Execute(ctx context.Context, _ map[string]interface{}) (*mcp.CallToolResult, error) {
+func (t *ListPoliciesTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/policies"})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
-	return t.FormatResponseWithSuggestions(res, "list_policies")
+	paged, err := ApplyOffsetPagination(res, args, "policies")
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	return t.FormatListResponse(paged, args, "list_policies")
 }
 
 // CreatePolicyTool creates a new policy.
@@ -481,7 +519,7 @@ func (t *CreatePolicyTool) InputSchema() interface{} {
 func (t *CreatePolicyTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	pol, err := GetObjectParam(args, "policy", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	// Check for dry-run mode
@@ -492,41 +530,24 @@ func (t *CreatePolicyTool) Execute(ctx context.Context, args map[string]interfac
 
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "POST", Path: "/v1/policies", Body: pol})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	return t.FormatResponseWithSuggestions(res, "create_policy")
 }
 
+// policyDryRunSpec defines dry-run validation for policies
+var policyDryRunSpec = DryRunSpec{
+	ResourceType:   "Policy",
+	RequiredFields: []string{"name"},
+	EnumFields: map[string][]string{
+		"priority": {"type_low", "type_medium", "type_high", "type_unspecified"},
+	},
+	SummaryFields: []string{"name", "description", "priority"},
+}
+
 // validatePolicy performs dry-run validation for policy creation
 func (t *CreatePolicyTool) validatePolicy(policy map[string]interface{}) (*mcp.CallToolResult, error) {
-	result := &ValidationResult{
-		Valid:   true,
-		Summary: make(map[string]interface{}),
-	}
-
-	// Check required fields
-	requiredErrors := ValidateRequiredFields(policy, []string{"name"})
-	if len(requiredErrors) > 0 {
-		result.Valid = false
-		result.Errors = append(result.Errors, requiredErrors...)
-	}
-
-	// Validate priority field if present
-	if errMsg := ValidateEnumField(policy, "priority", []string{"type_low", "type_medium", "type_high", "type_unspecified"}); errMsg != "" {
-		result.Valid = false
-		result.Errors = append(result.Errors, errMsg)
-	}
-
-	// Build summary
-	if name, ok := policy["name"].(string); ok {
-		result.Summary["name"] = name
-	}
-	if desc, ok := policy["description"].(string); ok {
-		result.Summary["description"] = desc
-	}
-	if priority, ok := policy["priority"].(string); ok {
-		result.Summary["priority"] = priority
-	}
+	result := t.ValidateDryRun(policy, policyDryRunSpec)
 
 	// Add warnings and suggestions
 	if _, hasAppRule := policy["application_rule"]; !hasAppRule {
@@ -537,7 +558,7 @@ func (t *CreatePolicyTool) validatePolicy(policy map[string]interface{}) (*mcp.C
 
 	result.Suggestions = append(result.Suggestions, "Consider setting explicit retention policies to optimize costs")
 
-	return FormatDryRunResult(result, "Policy", policy), nil
+	return t.FormatDryRun(result, policyDryRunSpec, policy), nil
 }
 
 // UpdatePolicyTool updates an existing policy.
@@ -556,22 +577,43 @@ func (t *UpdatePolicyTool) Description() string { return "Update a policy" }
 
 // InputSchema returns the input schema
 func (t *UpdatePolicyTool) InputSchema() interface{} {
-	return map[string]interface{}{"type": "object", "properties": map[string]interface{}{"id": map[string]interface{}{"type": "string"}, "policy": map[string]interface{}{"type": "object"}}, "required": []string{"id", "policy"}}
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"id":     map[string]interface{}{"type": "string", "description": "The unique identifier of the policy"},
+			"policy": map[string]interface{}{"type": "object", "description": "Updated policy configuration (same structure as create_policy)"},
+		},
+		"required": []string{"id", "policy"},
+		"examples": []interface{}{
+			map[string]interface{}{
+				"id": "policy-uuid",
+				"policy": map[string]interface{}{
+					"name":        "Debug Logs Low Priority",
+					"description": "Route debug logs to low priority",
+					"priority":    "type_low",
+					"application_rule": map[string]interface{}{
+						"name":         "api-gateway",
+						"rule_type_id": "starts_with",
+					},
+				},
+			},
+		},
+	}
 }
 
 // Execute executes the tool
 func (t *UpdatePolicyTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	id, err := GetStringParam(args, "id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	pol, err := GetObjectParam(args, "policy", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "PUT", Path: "/v1/policies/" + id, Body: pol})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	return t.FormatResponseWithSuggestions(res, "update_policy")
 }
@@ -593,24 +635,21 @@ func (t *DeletePolicyTool) Annotations() *mcp.ToolAnnotations {
 }
 
 // Description returns the tool description
-func (t *DeletePolicyTool) Description() string { return "Delete a policy" }
+func (t *DeletePolicyTool) Description() string {
+	return `Delete a policy.
+
+Nothing references policies, so there is no dependents check. Use dry_run=true to confirm
+the policy exists and review the effect of deleting it without deleting.`
+}
 
 // InputSchema returns the input schema
 func (t *DeletePolicyTool) InputSchema() interface{} {
-	return map[string]interface{}{"type": "object", "properties": map[string]interface{}{"id": map[string]interface{}{"type": "string"}}, "required": []string{"id"}}
+	return deleteDependentsSchema(policyDeleteGuard)
 }
 
 // Execute executes the tool
 func (t *DeletePolicyTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
-	id, err := GetStringParam(args, "id", true)
-	if err != nil {
-		return NewToolResultError(err.Error()), nil
-	}
-	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "DELETE", Path: "/v1/policies/" + id})
-	if err != nil {
-		return NewToolResultError(err.Error()), nil
-	}
-	return t.FormatResponseWithSuggestions(res, "delete_policy")
+	return t.guardedDelete(ctx, args, policyDeleteGuard, "delete_policy")
 }
 
 // GetE2MTool retrieves a specific events-to-metrics configuration by ID.
@@ -636,7 +675,7 @@ func (t *GetE2MTool) InputSchema() interface{} {
 func (t *GetE2MTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	id, err := GetStringParam(args, "id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/events2metrics/" + id})
 	if err != nil {
@@ -665,16 +704,20 @@ func (t *ListE2MTool) Description() string {
 
 // InputSchema returns the input schema
 func (t *ListE2MTool) InputSchema() interface{} {
-	return map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+	return map[string]interface{}{"type": "object", "properties": withSummaryOnly(OffsetPaginationSchema())}
 }
 
 // Execute executes the tool
-func (t *ListE2MTool) Execute(ctx context.Context, _ map[string]interface{}) (*mcp.CallToolResult, error) {
+func (t *ListE2MTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/events2metrics"})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
+	}
+	paged, err := ApplyOffsetPagination(res, args, "events2metrics")
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
 	}
-	return t.FormatResponseWithSuggestions(res, "list_e2m")
+	return t.FormatListResponse(paged, args, "list_e2m")
 }
 
 // CreateE2MTool creates a new events-to-metrics configuration.
@@ -808,7 +851,7 @@ func (t *CreateE2MTool) InputSchema() interface{} {
 func (t *CreateE2MTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	e2m, err := GetObjectParam(args, "e2m", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	// Check for dry-run mode
@@ -819,44 +862,24 @@ func (t *CreateE2MTool) Execute(ctx context.Context, args map[string]interface{}
 
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "POST", Path: "/v1/events2metrics", Body: e2m})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	return t.FormatResponseWithSuggestions(res, "create_e2m")
 }
 
+// e2mDryRunSpec defines dry-run validation for events-to-metrics configurations
+var e2mDryRunSpec = DryRunSpec{
+	ResourceType:   "Events-to-Metrics",
+	RequiredFields: []string{"name", "type"},
+	EnumFields: map[string][]string{
+		"type": {"logs2metrics", "spans2metrics"},
+	},
+	SummaryFields: []string{"name", "type"},
+}
+
 // validateE2M performs dry-run validation for E2M creation
 func (t *CreateE2MTool) validateE2M(e2m map[string]interface{}) (*mcp.CallToolResult, error) {
-	result := &ValidationResult{
-		Valid:   true,
-		Summary: make(map[string]interface{}),
-	}
-
-	// Validate required fields
-	requiredFields := []string{"name", "type"}
-	for _, field := range requiredFields {
-		if _, ok := e2m[field]; !ok {
-			result.Errors = append(result.Errors, "Missing required field: "+field)
-			result.Valid = false
-		}
-	}
-
-	// Validate name
-	if name, ok := e2m["name"].(string); ok {
-		result.Summary["name"] = name
-	}
-
-	// Validate type
-	validTypes := map[string]bool{
-		"logs2metrics":  true,
-		"spans2metrics": true,
-	}
-	if e2mType, ok := e2m["type"].(string); ok {
-		if !validTypes[e2mType] {
-			result.Errors = append(result.Errors, "Invalid E2M type: "+e2mType+". Valid types: logs2metrics, spans2metrics")
-			result.Valid = false
-		}
-		result.Summary["type"] = e2mType
-	}
+	result := t.ValidateDryRun(e2m, e2mDryRunSpec)
 
 	// Check for logs_query (required for logs2metrics)
 	if e2mType, _ := e2m["type"].(string); e2mType == "logs2metrics" {
@@ -865,16 +888,7 @@ func (t *CreateE2MTool) validateE2M(e2m map[string]interface{}) (*mcp.CallToolRe
 		}
 	}
 
-	// Add suggestions
-	if result.Valid {
-		result.Suggestions = append(result.Suggestions, "E2M configuration is valid")
-		result.Suggestions = append(result.Suggestions, "Remove dry_run parameter to create the E2M configuration")
-	} else {
-		result.Suggestions = append(result.Suggestions, "Fix the errors above before creating")
-	}
-
-	result.EstimatedImpact = &ImpactEstimate{RiskLevel: "low"}
-	return FormatDryRunResult(result, "Events-to-Metrics", e2m), nil
+	return t.FormatDryRun(result, e2mDryRunSpec, e2m), nil
 }
 
 // ReplaceE2MTool replaces an events-to-metrics configuration.
@@ -893,22 +907,46 @@ func (t *ReplaceE2MTool) Description() string { return "Replace an events-to-met
 
 // InputSchema returns the input schema
 func (t *ReplaceE2MTool) InputSchema() interface{} {
-	return map[string]interface{}{"type": "object", "properties": map[string]interface{}{"id": map[string]interface{}{"type": "string"}, "e2m": map[string]interface{}{"type": "object"}}, "required": []string{"id", "e2m"}}
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"id":  map[string]interface{}{"type": "string", "description": "The unique identifier of the E2M"},
+			"e2m": map[string]interface{}{"type": "object", "description": "Full replacement E2M configuration (same structure as create_e2m)"},
+		},
+		"required": []string{"id", "e2m"},
+		"examples": []interface{}{
+			map[string]interface{}{
+				"id": "e2m-uuid",
+				"e2m": map[string]interface{}{
+					"name":        "Error Count Metric",
+					"description": "Count of error logs per application",
+					"type":        "logs2metrics",
+					"logs_query": map[string]interface{}{
+						"lucene":           "level:error",
+						"severity_filters": []string{"error", "critical"},
+					},
+					"metric_labels": []interface{}{
+						map[string]interface{}{"target_label": "app", "source_field": "applicationName"},
+					},
+				},
+			},
+		},
+	}
 }
 
 // Execute executes the tool
 func (t *ReplaceE2MTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	id, err := GetStringParam(args, "id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	e2m, err := GetObjectParam(args, "e2m", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "PUT", Path: "/v1/events2metrics/" + id, Body: e2m})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	return t.FormatResponseWithSuggestions(res, "replace_e2m")
 }
@@ -941,11 +979,11 @@ func (t *DeleteE2MTool) InputSchema() interface{} {
 func (t *DeleteE2MTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	id, err := GetStringParam(args, "id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "DELETE", Path: "/v1/events2metrics/" + id})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	return t.FormatResponseWithSuggestions(res, "delete_e2m")
 }
@@ -970,16 +1008,20 @@ func (t *ListDataAccessRulesTool) Description() string {
 
 // InputSchema returns the input schema
 func (t *ListDataAccessRulesTool) InputSchema() interface{} {
-	return map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+	return map[string]interface{}{"type": "object", "properties": withSummaryOnly(OffsetPaginationSchema())}
 }
 
 // Execute executes the tool
-func (t *ListDataAccessRulesTool) Execute(ctx context.Context, _ map[string]interface{}) (*mcp.CallToolResult, error) {
+func (t *ListDataAccessRulesTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/data_access_rules"})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
+	}
+	paged, err := ApplyOffsetPagination(res, args, "data_access_rules")
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
 	}
-	return t.FormatResponseWithSuggestions(res, "list_data_access_rules")
+	return t.FormatListResponse(paged, args, "list_data_access_rules")
 }
 
 // GetDataAccessRuleTool retrieves a specific data access rule by ID.
@@ -1005,7 +1047,7 @@ func (t *GetDataAccessRuleTool) InputSchema() interface{} {
 func (t *GetDataAccessRuleTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	id, err := GetStringParam(args, "id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/data_access_rules/" + id})
 	if err != nil {
@@ -1100,7 +1142,7 @@ func (t *CreateDataAccessRuleTool) InputSchema() interface{} {
 func (t *CreateDataAccessRuleTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	rule, err := GetObjectParam(args, "rule", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	// Check for dry-run mode
@@ -1111,48 +1153,31 @@ func (t *CreateDataAccessRuleTool) Execute(ctx context.Context, args map[string]
 
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "POST", Path: "/v1/data_access_rules", Body: rule})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	return t.FormatResponseWithSuggestions(res, "create_data_access_rule")
 }
 
+// dataAccessRuleDryRunSpec defines dry-run validation for data access rules
+var dataAccessRuleDryRunSpec = DryRunSpec{
+	ResourceType:   "Data Access Rule",
+	RequiredFields: []string{"display_name"},
+	SummaryFields:  []string{"display_name", "description", "default_expression"},
+	RiskLevel:      "medium",
+}
+
 // validateDataAccessRule performs dry-run validation for data access rule creation
 func (t *CreateDataAccessRuleTool) validateDataAccessRule(rule map[string]interface{}) (*mcp.CallToolResult, error) {
-	result := &ValidationResult{
-		Valid:   true,
-		Summary: make(map[string]interface{}),
-	}
-
-	// Validate required fields
-	if _, ok := rule["display_name"]; !ok {
-		result.Errors = append(result.Errors, "Missing required field: display_name")
-		result.Valid = false
-	} else if name, ok := rule["display_name"].(string); ok {
-		result.Summary["display_name"] = name
-	}
+	result := t.ValidateDryRun(rule, dataAccessRuleDryRunSpec)
 
 	// Check for filters or default_expression
-	hasFilters := false
-	if _, ok := rule["filters"]; ok {
-		hasFilters = true
-	}
-	if _, ok := rule["default_expression"]; ok {
-		hasFilters = true
-	}
-	if !hasFilters {
+	_, hasFilters := rule["filters"]
+	_, hasDefault := rule["default_expression"]
+	if !hasFilters && !hasDefault {
 		result.Warnings = append(result.Warnings, "No filters or default_expression specified - rule may not restrict any data")
 	}
 
-	// Add suggestions
-	if result.Valid {
-		result.Suggestions = append(result.Suggestions, "Data access rule configuration is valid")
-		result.Suggestions = append(result.Suggestions, "Remove dry_run parameter to create the rule")
-	} else {
-		result.Suggestions = append(result.Suggestions, "Fix the errors above before creating")
-	}
-
-	result.EstimatedImpact = &ImpactEstimate{RiskLevel: "medium"}
-	return FormatDryRunResult(result, "Data Access Rule", rule), nil
+	return t.FormatDryRun(result, dataAccessRuleDryRunSpec, rule), nil
 }
 
 // UpdateDataAccessRuleTool updates an existing data access rule.
@@ -1171,22 +1196,44 @@ func (t *UpdateDataAccessRuleTool) Description() string { return "Update a data
 
 // InputSchema returns the input schema
 func (t *UpdateDataAccessRuleTool) InputSchema() interface{} {
-	return map[string]interface{}{"type": "object", "properties": map[string]interface{}{"id": map[string]interface{}{"type": "string"}, "rule": map[string]interface{}{"type": "object"}}, "required": []string{"id", "rule"}}
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"id":   map[string]interface{}{"type": "string", "description": "The unique identifier of the data access rule"},
+			"rule": map[string]interface{}{"type": "object", "description": "Updated rule configuration (same structure as create_data_access_rule)"},
+		},
+		"required": []string{"id", "rule"},
+		"examples": []interface{}{
+			map[string]interface{}{
+				"id": "data-access-rule-uuid",
+				"rule": map[string]interface{}{
+					"display_name": "Production Logs Only",
+					"description":  "Restrict access to production application logs",
+					"filters": []interface{}{
+						map[string]interface{}{
+							"entity_type": "logs",
+							"expression":  "applicationName.startsWith('production')",
+						},
+					},
+				},
+			},
+		},
+	}
 }
 
 // Execute executes the tool
 func (t *UpdateDataAccessRuleTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	id, err := GetStringParam(args, "id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	rule, err := GetObjectParam(args, "rule", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "PUT", Path: "/v1/data_access_rules/" + id, Body: rule})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	return t.FormatResponseWithSuggestions(res, "update_data_access_rule")
 }
@@ -1219,11 +1266,11 @@ func (t *DeleteDataAccessRuleTool) InputSchema() interface{} {
 func (t *DeleteDataAccessRuleTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	id, err := GetStringParam(args, "id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "DELETE", Path: "/v1/data_access_rules/" + id})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	return t.FormatResponseWithSuggestions(res, "delete_data_access_rule")
 }
@@ -1248,16 +1295,20 @@ func (t *ListEnrichmentsTool) Description() string {
 
 // InputSchema returns the input schema
 func (t *ListEnrichmentsTool) InputSchema() interface{} {
-	return map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+	return map[string]interface{}{"type": "object", "properties": withSummaryOnly(OffsetPaginationSchema())}
 }
 
 // Execute executes the tool
-func (t *ListEnrichmentsTool) Execute(ctx context.Context, _ map[string]interface{}) (*mcp.CallToolResult, error) {
+func (t *ListEnrichmentsTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/enrichments"})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
-	return t.FormatResponseWithSuggestions(res, "list_enrichments")
+	paged, err := ApplyOffsetPagination(res, args, "enrichments")
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	return t.FormatListResponse(paged, args, "list_enrichments")
 }
 
 // CreateEnrichmentTool creates a new enrichment.
@@ -1278,8 +1329,8 @@ func (t *CreateEnrichmentTool) Description() string {
 **Related tools:** list_enrichments, get_enrichments, update_enrichment, delete_enrichment
 
 **Enrichment Types:**
-- geo_ip: Add geographic information based on IP addresses
-- custom_enrichment: Add custom fields from lookup tables
+- geo_ip: Add geographic information based on IP addresses (requires field_name; no custom_enrichment_config)
+- custom_enrichment: Add custom fields from lookup tables (requires custom_enrichment_config.lookup_table_id, a data set id from list_data_sets or create_data_set)
 
 **Use Cases:**
 - Add geographic location from IP addresses
@@ -1321,9 +1372,20 @@ func (t *CreateEnrichmentTool) InputSchema() interface{} {
 					"custom_enrichment_config": map[string]interface{}{
 						"type":        "object",
 						"description": "Configuration for custom_enrichment type",
+						"properties": map[string]interface{}{
+							"lookup_table_id": map[string]interface{}{
+								"type":        "string",
+								"description": "ID of the custom enrichment lookup table (required for custom_enrichment)",
+							},
+						},
 					},
 				},
 			},
+			"dry_run": map[string]interface{}{
+				"type":        "boolean",
+				"description": "If true, validates the enrichment configuration without creating it. Use this to preview what will be created and check for errors.",
+				"default":     false,
+			},
 		},
 		"required": []string{"enrichment"},
 		"examples": []interface{}{
@@ -1354,15 +1416,77 @@ func (t *CreateEnrichmentTool) InputSchema() interface{} {
 func (t *CreateEnrichmentTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	enr, err := GetObjectParam(args, "enrichment", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
+
+	// Check for dry-run mode
+	dryRun, _ := GetBoolParam(args, "dry_run", false)
+	if dryRun {
+		return t.validateEnrichment(enr)
+	}
+
+	if errs := enrichmentConfigErrors(enr); len(errs) > 0 {
+		return NewToolResultErrorWithSuggestion("Invalid enrichment configuration:\n- "+strings.Join(errs, "\n- "),
+			"Use dry_run=true to validate the enrichment before creating it."), nil
+	}
+
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "POST", Path: "/v1/enrichments", Body: enr})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	return t.FormatResponseWithSuggestions(res, "create_enrichment")
 }
 
+// enrichmentDryRunSpec defines dry-run validation for enrichments
+var enrichmentDryRunSpec = DryRunSpec{
+	ResourceType:   "Enrichment",
+	RequiredFields: []string{"field_name", "enrichment_type"},
+	EnumFields: map[string][]string{
+		"enrichment_type": {"geo_ip", "custom_enrichment"},
+	},
+	SummaryFields: []string{"name", "field_name", "enrichment_type"},
+}
+
+// enrichmentConfigErrors checks that the config block matches enrichment_type, so an
+// enrichment is never created with a block the API ignores. Each error names its field.
+func enrichmentConfigErrors(enr map[string]interface{}) []string {
+	var errs []string
+	switch enr["enrichment_type"] {
+	case "geo_ip":
+		fieldName, _ := enr["field_name"].(string)
+		if strings.TrimSpace(fieldName) == "" {
+			errs = append(errs, "field_name: required for geo_ip (the field holding the IP address, e.g. json.client_ip)")
+		} else if strings.ContainsAny(fieldName, " \t") {
+			errs = append(errs, fmt.Sprintf("field_name: '%s' must be a field path without spaces", fieldName))
+		}
+		if _, ok := enr["custom_enrichment_config"]; ok {
+			errs = append(errs, "custom_enrichment_config: not allowed for geo_ip; remove it or set enrichment_type to custom_enrichment")
+		}
+	case "custom_enrichment":
+		if cfg, ok := enr["custom_enrichment_config"].(map[string]interface{}); !ok {
+			errs = append(errs, "custom_enrichment_config: required for custom_enrichment (an object with lookup_table_id)")
+		} else if id, ok := cfg["lookup_table_id"]; !ok || id == nil || id == "" {
+			errs = append(errs, "custom_enrichment_config.lookup_table_id: required for custom_enrichment")
+		}
+		if _, ok := enr["geo_ip_config"]; ok {
+			errs = append(errs, "geo_ip_config: not allowed for custom_enrichment; remove it or set enrichment_type to geo_ip")
+		}
+	}
+	return errs
+}
+
+// validateEnrichment performs dry-run validation for enrichment creation
+func (t *CreateEnrichmentTool) validateEnrichment(enr map[string]interface{}) (*mcp.CallToolResult, error) {
+	result := t.ValidateDryRun(enr, enrichmentDryRunSpec)
+
+	if errs := enrichmentConfigErrors(enr); len(errs) > 0 {
+		result.Valid = false
+		result.Errors = append(result.Errors, errs...)
+	}
+
+	return t.FormatDryRun(result, enrichmentDryRunSpec, enr), nil
+}
+
 // UpdateEnrichmentTool updates an existing enrichment.
 type UpdateEnrichmentTool struct{ *BaseTool }
 
@@ -1379,22 +1503,40 @@ func (t *UpdateEnrichmentTool) Description() string { return "Update an existing
 
 // InputSchema returns the input schema
 func (t *UpdateEnrichmentTool) InputSchema() interface{} {
-	return map[string]interface{}{"type": "object", "properties": map[string]interface{}{"id": map[string]interface{}{"type": "string", "description": "The unique identifier of the enrichment"}, "enrichment": map[string]interface{}{"type": "object"}}, "required": []string{"id", "enrichment"}}
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"id":         map[string]interface{}{"type": "string", "description": "The unique identifier of the enrichment"},
+			"enrichment": map[string]interface{}{"type": "object", "description": "Updated enrichment configuration (same structure as create_enrichment)"},
+		},
+		"required": []string{"id", "enrichment"},
+		"examples": []interface{}{
+			map[string]interface{}{
+				"id": "enrichment-uuid",
+				"enrichment": map[string]interface{}{
+					"name":            "Client GeoIP",
+					"description":     "Add geo location for client IP addresses",
+					"field_name":      "json.client_ip",
+					"enrichment_type": "geo_ip",
+				},
+			},
+		},
+	}
 }
 
 // Execute executes the tool
 func (t *UpdateEnrichmentTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	id, err := GetStringParam(args, "id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	enr, err := GetObjectParam(args, "enrichment", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "PUT", Path: "/v1/enrichments/" + id, Body: enr})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	return t.FormatResponseWithSuggestions(res, "update_enrichment")
 }
@@ -1427,11 +1569,11 @@ func (t *DeleteEnrichmentTool) InputSchema() interface{} {
 func (t *DeleteEnrichmentTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	id, err := GetStringParam(args, "id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "DELETE", Path: "/v1/enrichments/" + id})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	return t.FormatResponseWithSuggestions(res, "delete_enrichment")
 }
@@ -1461,7 +1603,7 @@ func (t *GetEnrichmentsTool) InputSchema() interface{} {
 func (t *GetEnrichmentsTool) Execute(ctx context.Context, _ map[string]interface{}) (*mcp.CallToolResult, error) {
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/enrichments"})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	return t.FormatResponseWithSuggestions(res, "get_enrichments")
 }
@@ -1486,16 +1628,25 @@ func (t *ListViewsTool) Description() string {
 
 // InputSchema returns the input schema
 func (t *ListViewsTool) InputSchema() interface{} {
-	return map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+	return map[string]interface{}{"type": "object", "properties": withSummaryOnly(OffsetPaginationSchema())}
 }
 
 // Execute executes the tool
-func (t *ListViewsTool) Execute(ctx context.Context, _ map[string]interface{}) (*mcp.CallToolResult, error) {
-	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/views"})
+func (t *ListViewsTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	res, err := t.ListViews(ctx)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	paged, err := ApplyOffsetPagination(res, args, "views")
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
-	return t.FormatResponseWithSuggestions(res, "list_views")
+	return t.FormatListResponse(paged, args, "list_views")
+}
+
+// ListViews fetches all saved views. It is shared with the view:// MCP resources.
+func (t *ListViewsTool) ListViews(ctx context.Context) (map[string]interface{}, error) {
+	return t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/views"})
 }
 
 // CreateViewTool creates a new view.
@@ -1513,7 +1664,7 @@ func (t *CreateViewTool) Name() string { return "create_view" }
 func (t *CreateViewTool) Description() string {
 	return `Create a saved view with predefined filters and query settings.
 
-**Related tools:** list_views, get_view, replace_view, delete_view, list_view_folders, create_view_folder
+**Related tools:** list_views, get_view, replace_view, delete_view, list_view_folders, create_view_folder, convert_dataprime_to_lucene (when the view needs Lucene)
 
 **Use Cases:**
 - Save commonly used log queries for quick access
@@ -1559,6 +1710,11 @@ func (t *CreateViewTool) InputSchema() interface{} {
 					},
 				},
 			},
+			"dry_run": map[string]interface{}{
+				"type":        "boolean",
+				"description": "If true, validates the view configuration without creating it. Use this to preview what will be created and check for errors.",
+				"default":     false,
+			},
 		},
 		"required": []string{"view"},
 		"examples": []interface{}{
@@ -1594,15 +1750,44 @@ func (t *CreateViewTool) InputSchema() interface{} {
 func (t *CreateViewTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	view, err := GetObjectParam(args, "view", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
+	}
+
+	// Check for dry-run mode
+	dryRun, _ := GetBoolParam(args, "dry_run", false)
+	if dryRun {
+		return t.validateView(view)
 	}
+
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "POST", Path: "/v1/views", Body: view})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	return t.FormatResponseWithSuggestions(res, "create_view")
 }
 
+// viewDryRunSpec defines dry-run validation for views
+var viewDryRunSpec = DryRunSpec{
+	ResourceType:   "View",
+	RequiredFields: []string{"name"},
+	SummaryFields:  []string{"name", "folder_id"},
+}
+
+// validateView performs dry-run validation for view creation
+func (t *CreateViewTool) validateView(view map[string]interface{}) (*mcp.CallToolResult, error) {
+	result := t.ValidateDryRun(view, viewDryRunSpec)
+
+	if sq, ok := view["search_query"].(map[string]interface{}); ok {
+		if q, _ := sq["query"].(string); q != "" {
+			result.Summary["query"] = q
+		}
+	} else if _, hasFilters := view["filters"]; !hasFilters {
+		result.Warnings = append(result.Warnings, "No search_query or filters specified - view will show all logs")
+	}
+
+	return t.FormatDryRun(result, viewDryRunSpec, view), nil
+}
+
 // GetViewTool retrieves a specific view by ID.
 type GetViewTool struct{ *BaseTool }
 
@@ -1626,15 +1811,20 @@ func (t *GetViewTool) InputSchema() interface{} {
 func (t *GetViewTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	id, err := GetStringParam(args, "id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
-	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/views/" + id})
+	res, err := t.GetView(ctx, id)
 	if err != nil {
 		return HandleGetError(err, "View", id, "list_views"), nil
 	}
 	return t.FormatResponseWithSuggestions(res, "get_view")
 }
 
+// GetView fetches a view by ID. It is shared with the view:// MCP resources.
+func (t *GetViewTool) GetView(ctx context.Context, id string) (map[string]interface{}, error) {
+	return t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/views/" + id})
+}
+
 // ReplaceViewTool replaces a view.
 type ReplaceViewTool struct{ *BaseTool }
 
@@ -1651,22 +1841,45 @@ func (t *ReplaceViewTool) Description() string { return "Replace a view" }
 
 // InputSchema returns the input schema
 func (t *ReplaceViewTool) InputSchema() interface{} {
-	return map[string]interface{}{"type": "object", "properties": map[string]interface{}{"id": map[string]interface{}{"type": "string"}, "view": map[string]interface{}{"type": "object"}}, "required": []string{"id", "view"}}
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"id":   map[string]interface{}{"type": "string", "description": "The unique identifier of the view"},
+			"view": map[string]interface{}{"type": "object", "description": "Full replacement view configuration (same structure as create_view)"},
+		},
+		"required": []string{"id", "view"},
+		"examples": []interface{}{
+			map[string]interface{}{
+				"id": "view-id",
+				"view": map[string]interface{}{
+					"name": "Production Errors",
+					"search_query": map[string]interface{}{
+						"query": "application:production AND level:error",
+					},
+					"time_selection": map[string]interface{}{
+						"quick_selection": map[string]interface{}{
+							"seconds": 86400,
+						},
+					},
+				},
+			},
+		},
+	}
 }
 
 // Execute executes the tool
 func (t *ReplaceViewTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	id, err := GetStringParam(args, "id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	view, err := GetObjectParam(args, "view", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "PUT", Path: "/v1/views/" + id, Body: view})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	return t.FormatResponseWithSuggestions(res, "replace_view")
 }
@@ -1699,11 +1912,11 @@ func (t *DeleteViewTool) InputSchema() interface{} {
 func (t *DeleteViewTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	id, err := GetStringParam(args, "id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "DELETE", Path: "/v1/views/" + id})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	return t.FormatResponseWithSuggestions(res, "delete_view")
 }
@@ -1724,16 +1937,16 @@ func (t *ListViewFoldersTool) Description() string { return "List all view folde
 
 // InputSchema returns the input schema
 func (t *ListViewFoldersTool) InputSchema() interface{} {
-	return map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+	return map[string]interface{}{"type": "object", "properties": withSummaryOnly(map[string]interface{}{})}
 }
 
 // Execute executes the tool
-func (t *ListViewFoldersTool) Execute(ctx context.Context, _ map[string]interface{}) (*mcp.CallToolResult, error) {
+func (t *ListViewFoldersTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/view_folders"})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
-	return t.FormatResponseWithSuggestions(res, "list_view_folders")
+	return t.FormatListResponse(res, args, "list_view_folders")
 }
 
 // CreateViewFolderTool creates a new view folder.
@@ -1752,18 +1965,35 @@ func (t *CreateViewFolderTool) Description() string { return "Create a new view
 
 // InputSchema returns the input schema
 func (t *CreateViewFolderTool) InputSchema() interface{} {
-	return map[string]interface{}{"type": "object", "properties": map[string]interface{}{"folder": map[string]interface{}{"type": "object"}}, "required": []string{"folder"}}
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"folder": map[string]interface{}{
+				"type":        "object",
+				"description": "View folder configuration",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{"type": "string", "description": "Folder name"},
+				},
+			},
+		},
+		"required": []string{"folder"},
+		"examples": []interface{}{
+			map[string]interface{}{
+				"folder": map[string]interface{}{"name": "Production"},
+			},
+		},
+	}
 }
 
 // Execute executes the tool
 func (t *CreateViewFolderTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	folder, err := GetObjectParam(args, "folder", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "POST", Path: "/v1/view_folders", Body: folder})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	return t.FormatResponseWithSuggestions(res, "create_view_folder")
 }
@@ -1791,7 +2021,7 @@ func (t *GetViewFolderTool) InputSchema() interface{} {
 func (t *GetViewFolderTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	id, err := GetStringParam(args, "id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/view_folders/" + id})
 	if err != nil {
@@ -1816,22 +2046,41 @@ func (t *ReplaceViewFolderTool) Description() string { return "Replace a view fo
 
 // InputSchema returns the input schema
 func (t *ReplaceViewFolderTool) InputSchema() interface{} {
-	return map[string]interface{}{"type": "object", "properties": map[string]interface{}{"id": map[string]interface{}{"type": "string"}, "folder": map[string]interface{}{"type": "object"}}, "required": []string{"id", "folder"}}
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"id": map[string]interface{}{"type": "string", "description": "The unique identifier of the view folder"},
+			"folder": map[string]interface{}{
+				"type":        "object",
+				"description": "Full replacement view folder configuration",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{"type": "string", "description": "Folder name"},
+				},
+			},
+		},
+		"required": []string{"id", "folder"},
+		"examples": []interface{}{
+			map[string]interface{}{
+				"id":     "view-folder-uuid",
+				"folder": map[string]interface{}{"name": "Production Services"},
+			},
+		},
+	}
 }
 
 // Execute executes the tool
 func (t *ReplaceViewFolderTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	id, err := GetStringParam(args, "id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	folder, err := GetObjectParam(args, "folder", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "PUT", Path: "/v1/view_folders/" + id, Body: folder})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	return t.FormatResponseWithSuggestions(res, "replace_view_folder")
 }
@@ -1864,11 +2113,11 @@ func (t *DeleteViewFolderTool) InputSchema() interface{} {
 func (t *DeleteViewFolderTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	id, err := GetStringParam(args, "id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "DELETE", Path: "/v1/view_folders/" + id})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	return t.FormatResponseWithSuggestions(res, "delete_view_folder")
 }
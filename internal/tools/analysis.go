@@ -3,7 +3,10 @@
 package tools
 
 import (
+	"encoding/json"
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 )
 
@@ -15,6 +18,33 @@ type ResultAnalysis struct {
 	Insights        []Insight   `json:"insights,omitempty"`
 	Recommendations []string    `json:"recommendations,omitempty"`
 	Statistics      *Statistics `json:"statistics,omitempty"`
+	// Traces is set when the analysis groups events by trace ID
+	Traces *TraceSummary `json:"traces,omitempty"`
+}
+
+// AnalysisOptions tunes AnalyzeQueryResultsWithOptions
+type AnalysisOptions struct {
+	// GroupByTrace treats the events of one trace as a single problem, so one failing
+	// request that logs 50 lines is not reported as 50 independent errors
+	GroupByTrace bool
+}
+
+// TraceSummary reports how the analyzed events spread across traces
+type TraceSummary struct {
+	UniqueTraces   int `json:"unique_traces"`
+	TracedEvents   int `json:"traced_events"`
+	UntracedEvents int `json:"untraced_events"`
+	// ByApplication counts the unique traces that logged in each application
+	ByApplication map[string]int `json:"traces_by_application,omitempty"`
+	TopTraces     []TraceGroup   `json:"top_traces,omitempty"`
+}
+
+// TraceGroup is the events of one trace
+type TraceGroup struct {
+	TraceID      string   `json:"trace_id"`
+	Events       int      `json:"events"`
+	Applications []string `json:"applications"`
+	Message      string   `json:"first_message,omitempty"`
 }
 
 // Trend represents a detected trend in the data
@@ -57,6 +87,11 @@ type TopValue struct {
 
 // AnalyzeQueryResults performs intelligent analysis on query results
 func AnalyzeQueryResults(result map[string]interface{}) *ResultAnalysis {
+	return AnalyzeQueryResultsWithOptions(result, AnalysisOptions{})
+}
+
+// AnalyzeQueryResultsWithOptions is AnalyzeQueryResults with optional trace grouping
+func AnalyzeQueryResultsWithOptions(result map[string]interface{}, opts AnalysisOptions) *ResultAnalysis {
 	analysis := &ResultAnalysis{
 		Trends:          []Trend{},
 		Anomalies:       []Anomaly{},
@@ -85,6 +120,16 @@ func AnalyzeQueryResults(result map[string]interface{}) *ResultAnalysis {
 
 	// Build summary
 	analysis.Summary = buildAnalysisSummary(events, severityDist, errorRate)
+	if opts.GroupByTrace {
+		analysis.Traces = SummarizeTraces(events, 5)
+		if analysis.Traces.UniqueTraces > 0 {
+			analysis.Summary += fmt.Sprintf(" The entries come from %d unique traces", analysis.Traces.UniqueTraces)
+			if analysis.Traces.UntracedEvents > 0 {
+				analysis.Summary += fmt.Sprintf(" plus %d entries without a trace ID", analysis.Traces.UntracedEvents)
+			}
+			analysis.Summary += "."
+		}
+	}
 
 	// Detect trends
 	analysis.Trends = detectTrends(events)
@@ -109,7 +154,7 @@ func buildStatistics(events []interface{}) *Statistics {
 	}
 
 	// Extract time span
-	timeRange := extractTimeRange(events)
+	timeRange := extractTimeRange(events, currentTimeDisplay())
 	if timeRange != "" {
 		stats.TimeSpan = timeRange
 	}
@@ -222,15 +267,7 @@ func countSeverityAbove(events []interface{}, threshold int) int {
 	count := 0
 	for _, event := range events {
 		if eventMap, ok := event.(map[string]interface{}); ok {
-			var severity int
-			if sev, ok := eventMap["severity"].(float64); ok {
-				severity = int(sev)
-			} else if metadata, ok := eventMap["metadata"].(map[string]interface{}); ok {
-				if sev, ok := metadata["severity"].(float64); ok {
-					severity = int(sev)
-				}
-			}
-			if severity >= threshold {
+			if severity, ok := eventSeverity(eventMap); ok && severity >= threshold {
 				count++
 			}
 		}
@@ -294,11 +331,11 @@ func generateInsights(events []interface{}, severityDist map[string]int, errorRa
 	}
 
 	// Large result set insight
-	if len(events) >= MaxSSEEvents {
+	if maxEvents := currentMaxSSEEvents(); len(events) >= maxEvents {
 		insights = append(insights, Insight{
 			Category:    "usage",
 			Title:       "Large Result Set",
-			Description: fmt.Sprintf("Query returned maximum %d events - there may be more data", MaxSSEEvents),
+			Description: fmt.Sprintf("Query returned maximum %d events - there may be more data", maxEvents),
 			Action:      "Use time-based pagination or add filters to get complete results",
 		})
 	}
@@ -342,6 +379,99 @@ func generateRecommendations(events []interface{}, severityDist map[string]int,
 	return recs
 }
 
+// traceIDFields are the user_data keys checked for a trace ID, in order
+var traceIDFields = []string{"trace_id", "traceId", "traceID", "trace.id"}
+
+// eventTraceID returns the trace ID of a log event, or "" when it has none
+func eventTraceID(eventMap map[string]interface{}) string {
+	if id, ok := eventMap["trace_id"].(string); ok && id != "" {
+		return id
+	}
+	var userData map[string]interface{}
+	switch ud := eventMap["user_data"].(type) {
+	case map[string]interface{}:
+		userData = ud
+	case string:
+		// Legacy entries carry user_data as a JSON string
+		if strings.HasPrefix(ud, "{") {
+			_ = json.Unmarshal([]byte(ud), &userData)
+		}
+	}
+	for _, field := range traceIDFields {
+		if id, ok := userData[field].(string); ok && id != "" {
+			return id
+		}
+	}
+	if trace, ok := userData["trace"].(map[string]interface{}); ok {
+		if id, ok := trace["id"].(string); ok {
+			return id
+		}
+	}
+	return ""
+}
+
+// SummarizeTraces groups events by trace ID and returns the trace counts with the
+// limit traces that logged the most events
+func SummarizeTraces(events []interface{}, limit int) *TraceSummary {
+	summary := &TraceSummary{ByApplication: make(map[string]int)}
+	groups := make(map[string]*TraceGroup)
+	var order []string
+	for _, event := range events {
+		eventMap, ok := event.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id := eventTraceID(eventMap)
+		if id == "" {
+			summary.UntracedEvents++
+			continue
+		}
+		summary.TracedEvents++
+		g, exists := groups[id]
+		if !exists {
+			g = &TraceGroup{TraceID: id, Applications: []string{}, Message: truncateString(extractErrorMessage(eventMap), 100)}
+			groups[id] = g
+			order = append(order, id)
+		}
+		g.Events++
+		app := findFieldValue(eventMap, "applicationname")
+		if app != "" && !slices.Contains(g.Applications, app) {
+			g.Applications = append(g.Applications, app)
+			summary.ByApplication[app]++
+		}
+	}
+	summary.UniqueTraces = len(groups)
+
+	for _, id := range order {
+		summary.TopTraces = append(summary.TopTraces, *groups[id])
+	}
+	sort.SliceStable(summary.TopTraces, func(i, j int) bool {
+		return summary.TopTraces[i].Events > summary.TopTraces[j].Events
+	})
+	if len(summary.TopTraces) > limit {
+		summary.TopTraces = summary.TopTraces[:limit]
+	}
+	return summary
+}
+
+// DedupeByTrace keeps the first event of each trace and every event without a trace ID
+func DedupeByTrace(events []interface{}) []interface{} {
+	seen := make(map[string]bool)
+	deduped := make([]interface{}, 0, len(events))
+	for _, event := range events {
+		if eventMap, ok := event.(map[string]interface{}); ok {
+			if id := eventTraceID(eventMap); id != "" {
+				if seen[id] {
+					continue
+				}
+				seen[id] = true
+			}
+		}
+		deduped = append(deduped, event)
+	}
+	return deduped
+}
+
 // AnalyzeResourceList provides analysis for list operations (dashboards, alerts, etc.)
 func AnalyzeResourceList(items []interface{}, resourceType string) *ResultAnalysis {
 	analysis := &ResultAnalysis{
@@ -0,0 +1,123 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// tracedErrors returns n error events of one trace logged by app
+func tracedErrors(traceID, app, message string, n int) []interface{} {
+	events := make([]interface{}, 0, n)
+	for i := 0; i < n; i++ {
+		events = append(events, map[string]interface{}{
+			"applicationname": app,
+			"severity":        "5",
+			"user_data":       map[string]interface{}{"message": message, "trace_id": traceID},
+		})
+	}
+	return events
+}
+
+func TestEventTraceID(t *testing.T) {
+	tests := []struct {
+		event map[string]interface{}
+		want  string
+	}{
+		{map[string]interface{}{"trace_id": "compact"}, "compact"},
+		{map[string]interface{}{"user_data": map[string]interface{}{"traceId": "camel"}}, "camel"},
+		{map[string]interface{}{"user_data": map[string]interface{}{"trace": map[string]interface{}{"id": "nested"}}}, "nested"},
+		{map[string]interface{}{"user_data": `{"trace_id":"legacy"}`}, "legacy"},
+		{map[string]interface{}{"user_data": "plain text"}, ""},
+		{map[string]interface{}{"message": "no trace"}, ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, eventTraceID(tt.event), "%v", tt.event)
+	}
+}
+
+func TestSummarizeTraces(t *testing.T) {
+	events := tracedErrors("t1", "checkout", "db timeout", 50)
+	events = append(events, tracedErrors("t2", "payments", "card declined", 2)...)
+	events = append(events, tracedErrors("t2", "checkout", "card declined", 1)...)
+	events = append(events, map[string]interface{}{"applicationname": "checkout", "message": "untraced"})
+
+	summary := SummarizeTraces(events, 5)
+	assert.Equal(t, 2, summary.UniqueTraces)
+	assert.Equal(t, 53, summary.TracedEvents)
+	assert.Equal(t, 1, summary.UntracedEvents)
+	assert.Equal(t, map[string]int{"checkout": 2, "payments": 1}, summary.ByApplication)
+	require.Len(t, summary.TopTraces, 2)
+	assert.Equal(t, TraceGroup{TraceID: "t1", Events: 50, Applications: []string{"checkout"}, Message: "db timeout"}, summary.TopTraces[0])
+	assert.Equal(t, []string{"payments", "checkout"}, summary.TopTraces[1].Applications)
+
+	assert.Len(t, SummarizeTraces(events, 1).TopTraces, 1)
+	assert.Len(t, DedupeByTrace(events), 3, "one event per trace plus the untraced event")
+}
+
+func TestAnalyzeQueryResultsWithOptions_GroupByTrace(t *testing.T) {
+	result := map[string]interface{}{"events": tracedErrors("t1", "checkout", "db timeout", 12)}
+
+	assert.Nil(t, AnalyzeQueryResults(result).Traces)
+
+	analysis := AnalyzeQueryResultsWithOptions(result, AnalysisOptions{GroupByTrace: true})
+	require.NotNil(t, analysis.Traces)
+	assert.Equal(t, 1, analysis.Traces.UniqueTraces)
+	assert.Contains(t, analysis.Summary, "1 unique traces")
+}
+
+func TestClusterLogs_CountsTraces(t *testing.T) {
+	var events []interface{}
+	for _, id := range []string{"t1", "t1", "t1", "t2", "t2"} {
+		events = append(events, map[string]interface{}{"message": "db timeout", "trace_id": id})
+	}
+	events = append(events, map[string]interface{}{"message": "untraced"})
+
+	clusters := ClusterLogs(events)
+	require.Len(t, clusters, 2)
+	assert.Equal(t, 5, clusters[0].Count)
+	assert.Equal(t, 2, clusters[0].Traces)
+	assert.Zero(t, clusters[1].Traces)
+	assert.Contains(t, FormatClusteredSummary(events, 5), "5x in 2 traces:** db timeout")
+}
+
+func TestInvestigateIncident_GroupByTrace(t *testing.T) {
+	events := tracedErrors("4bf92f35", "checkout", "connection refused", 40)
+	for i := 0; i < 3; i++ {
+		events = append(events, tracedErrors(fmt.Sprintf("trace-%d", i), "payments", "invalid amount", 1)...)
+	}
+	mock := client.NewMockClient()
+	mock.DoFunc = func(_ context.Context, req *client.Request) (*client.Response, error) {
+		data, _ := json.Marshal(map[string]interface{}{"events": events})
+		return &client.Response{StatusCode: http.StatusOK, Body: data}, nil
+	}
+	tool := NewInvestigateIncidentTool(mock, zap.NewNop())
+
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{"group_by_trace": true, "correlate_alerts": false})
+	require.NoError(t, err)
+	text := result.Content[0].(*mcp.TextContent).Text
+	for _, want := range []string{
+		"Found **43 error logs** from **4 unique traces**",
+		"- payments: 3 traces",
+		"- `4bf92f35`: 40 logs across checkout",
+	} {
+		assert.Contains(t, text, want)
+	}
+	// The single chatty trace no longer dominates the hypotheses
+	assert.NotContains(t, text, "connection issues")
+
+	result, _ = tool.Execute(testCtx(mock), map[string]interface{}{"correlate_alerts": false})
+	text = result.Content[0].(*mcp.TextContent).Text
+	assert.False(t, strings.Contains(text, "unique traces"))
+	assert.Contains(t, text, "connection issues")
+}
@@ -0,0 +1,218 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements the application allow and deny lists enforced on every log query.
+package tools
+
+import (
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+	mcperrors "github.com/tareqmamari/cloud-logs-mcp/internal/errors"
+)
+
+// ApplicationScope restricts the applications whose logs queries may read. When Allowed is
+// set, queries only see those applications; Denied applications are never visible and
+// queries that name them are rejected. Denied wins when an application is in both lists.
+type ApplicationScope struct {
+	Allowed []string
+	Denied  []string
+}
+
+// applicationScope holds the scope applied to queries; nil leaves queries unrestricted
+var (
+	applicationScopeMu sync.RWMutex
+	applicationScope   *ApplicationScope
+)
+
+// dataPrimeSourcePattern matches a DataPrime source command, used to detect subqueries
+var dataPrimeSourcePattern = regexp.MustCompile(`(?i)\bsource\s+[a-z]`)
+
+// SetApplicationScope configures the application allow and deny lists; empty lists remove the scope
+func SetApplicationScope(allowed, denied []string) {
+	var scope *ApplicationScope
+	if len(allowed) > 0 || len(denied) > 0 {
+		scope = &ApplicationScope{Allowed: allowed, Denied: denied}
+	}
+	applicationScopeMu.Lock()
+	applicationScope = scope
+	applicationScopeMu.Unlock()
+}
+
+// currentApplicationScope returns the configured scope, or nil when queries are unrestricted
+func currentApplicationScope() *ApplicationScope {
+	applicationScopeMu.RLock()
+	defer applicationScopeMu.RUnlock()
+	return applicationScope
+}
+
+// newApplicationScopeError reports a query the application scope does not permit
+func newApplicationScopeError(format string, args ...interface{}) *APIError {
+	return &APIError{
+		StatusCode: 403,
+		Code:       mcperrors.CodeForbidden,
+		Message:    "Not authorized: " + fmt.Sprintf(format, args...),
+	}
+}
+
+// ScopeQuery enforces the scope on a query of the given syntax. It rejects queries that name
+// a denied application, and restricts the rest by inserting a filter on $l.applicationname
+// directly after the source command, so later stages cannot see other applications.
+// Lucene queries cannot be rewritten safely and are rejected while a scope is configured.
+func (s *ApplicationScope) ScopeQuery(query, syntax string) (string, error) {
+	if s == nil {
+		return query, nil
+	}
+	switch syntax {
+	case "", "dataprime":
+	case "dataprime_utf8_base64":
+		decoded, err := base64.StdEncoding.DecodeString(query)
+		if err != nil {
+			return "", newApplicationScopeError("the base64 query could not be decoded to check its application scope")
+		}
+		scoped, err := s.ScopeQuery(string(decoded), "dataprime")
+		if err != nil {
+			return "", err
+		}
+		return base64.StdEncoding.EncodeToString([]byte(scoped)), nil
+	default:
+		return "", newApplicationScopeError("this server restricts queryable applications, which requires DataPrime syntax; %s queries are not allowed", syntax)
+	}
+
+	for _, literal := range dataPrimeStringLiterals(query) {
+		for _, app := range s.Denied {
+			if strings.EqualFold(literal, app) {
+				return "", newApplicationScopeError("application '%s' is denied on this server (denied_applications)", app)
+			}
+		}
+	}
+	if len(dataPrimeSourcePattern.FindAllString(stripDataPrimeStrings(query), -1)) > 1 {
+		return "", newApplicationScopeError("queries with more than one source command cannot be scoped to the allowed applications")
+	}
+
+	filter := "filter " + s.filterExpression()
+	stages := splitDataPrimeStages(query)
+	if len(stages) > 0 && strings.HasPrefix(strings.ToLower(stages[0]), "source ") {
+		return strings.Join(append([]string{stages[0], filter}, stages[1:]...), " | "), nil
+	}
+	return strings.Join(append([]string{filter}, stages...), " | "), nil
+}
+
+// filterExpression builds the DataPrime condition that admits only in-scope applications
+func (s *ApplicationScope) filterExpression() string {
+	var conditions []string
+	if len(s.Allowed) > 0 {
+		allowed := make([]string, len(s.Allowed))
+		for i, app := range s.Allowed {
+			allowed[i] = "$l.applicationname == " + dataPrimeQuote(app)
+		}
+		conditions = append(conditions, "("+strings.Join(allowed, " || ")+")")
+	}
+	for _, app := range s.Denied {
+		conditions = append(conditions, "$l.applicationname != "+dataPrimeQuote(app))
+	}
+	return strings.Join(conditions, " && ")
+}
+
+// dataPrimeQuote renders s as a single-quoted DataPrime string literal
+func dataPrimeQuote(s string) string {
+	return "'" + strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), "'", `\'`) + "'"
+}
+
+// dataPrimeStringLiterals returns the contents of the string literals in a query
+func dataPrimeStringLiterals(query string) []string {
+	var literals []string
+	var sb strings.Builder
+	var quote rune
+	runes := []rune(query)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case quote == 0:
+			if c == '\'' || c == '"' || c == '`' {
+				quote = c
+				sb.Reset()
+			}
+		case c == '\\' && i+1 < len(runes):
+			i++
+			sb.WriteRune(runes[i])
+		case c == quote:
+			literals = append(literals, sb.String())
+			quote = 0
+		default:
+			sb.WriteRune(c)
+		}
+	}
+	return literals
+}
+
+// stripDataPrimeStrings blanks out string literals so keywords inside them are not matched
+func stripDataPrimeStrings(query string) string {
+	var sb strings.Builder
+	var quote rune
+	runes := []rune(query)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case quote == 0:
+			if c == '\'' || c == '"' || c == '`' {
+				quote = c
+			}
+			sb.WriteRune(c)
+		case c == '\\' && i+1 < len(runes):
+			i++
+		case c == quote:
+			quote = 0
+			sb.WriteRune(c)
+		}
+	}
+	return sb.String()
+}
+
+// scopedQueryPaths are the endpoints that run log queries; their query is scoped
+var scopedQueryPaths = map[string]bool{
+	"/v1/query":            true,
+	"/v1/background_query": true,
+	"/v1/dataprime/query":  true,
+}
+
+// applyApplicationScope returns req with its query restricted to the configured application
+// scope. Requests other than log queries are returned unchanged; the caller's body is not
+// modified. Queries posted to an endpoint the scope does not know are refused, so a new
+// query endpoint cannot bypass it.
+func applyApplicationScope(req *client.Request) (*client.Request, error) {
+	scope := currentApplicationScope()
+	if scope == nil || req.Method != "POST" {
+		return req, nil
+	}
+	if !scopedQueryPaths[req.Path] {
+		if strings.HasSuffix(req.Path, "query") {
+			return nil, newApplicationScopeError("queries to %s cannot be checked against the application scope", req.Path)
+		}
+		return req, nil
+	}
+	body, ok := req.Body.(map[string]interface{})
+	if !ok {
+		return nil, newApplicationScopeError("the query request could not be checked against the application scope")
+	}
+	query, _ := body["query"].(string)
+	syntax, _ := body["syntax"].(string)
+	if metadata, ok := body["metadata"].(map[string]interface{}); ok {
+		if s, ok := metadata["syntax"].(string); ok {
+			syntax = s
+		}
+	}
+	scoped, err := scope.ScopeQuery(query, syntax)
+	if err != nil {
+		return nil, err
+	}
+
+	body = maps.Clone(body)
+	body["query"] = scoped
+	scopedReq := *req
+	scopedReq.Body = body
+	return &scopedReq, nil
+}
@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestApplicationScope_ScopeQuery(t *testing.T) {
+	scope := &ApplicationScope{Allowed: []string{"api", "web"}, Denied: []string{"payments"}}
+	tests := []struct {
+		name    string
+		query   string
+		syntax  string
+		want    string
+		wantErr string
+	}{
+		{"filter after source", "source logs | filter $m.severity >= ERROR | limit 10", "dataprime",
+			"source logs | filter ($l.applicationname == 'api' || $l.applicationname == 'web') && $l.applicationname != 'payments' | filter $m.severity >= ERROR | limit 10", ""},
+		{"no source command", "filter $d.status == 500", "",
+			"filter ($l.applicationname == 'api' || $l.applicationname == 'web') && $l.applicationname != 'payments' | filter $d.status == 500", ""},
+		{"pipe in literal kept", "source logs | filter $d.msg == 'a|b'", "dataprime",
+			"source logs | filter ($l.applicationname == 'api' || $l.applicationname == 'web') && $l.applicationname != 'payments' | filter $d.msg == 'a|b'", ""},
+		{"denied app named", "source logs | filter $l.applicationname == 'Payments'", "dataprime", "", "application 'payments' is denied"},
+		{"denied app in double quotes", `source logs | filter $l.applicationname ~ "payments"`, "dataprime", "", "denied"},
+		{"lucene rejected", "applicationname:payments", "lucene", "", "requires DataPrime syntax"},
+		{"second source rejected", "source logs | join (source logs | filter true) on $d.id == $d.id", "dataprime", "", "more than one source"},
+		{"source word in literal", "source logs | filter $d.msg == 'source code'", "dataprime",
+			"source logs | filter ($l.applicationname == 'api' || $l.applicationname == 'web') && $l.applicationname != 'payments' | filter $d.msg == 'source code'", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := scope.ScopeQuery(tt.query, tt.syntax)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Equal(t, "FORBIDDEN", string(ClassifyError(err)))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	encoded := base64.StdEncoding.EncodeToString([]byte("source logs"))
+	got, err := (&ApplicationScope{Denied: []string{"it's"}}).ScopeQuery(encoded, "dataprime_utf8_base64")
+	require.NoError(t, err)
+	decoded, _ := base64.StdEncoding.DecodeString(got)
+	assert.Equal(t, `source logs | filter $l.applicationname != 'it\'s'`, string(decoded))
+
+	unscoped, err := (*ApplicationScope)(nil).ScopeQuery("source logs", "lucene")
+	require.NoError(t, err)
+	assert.Equal(t, "source logs", unscoped)
+}
+
+func TestQueryTool_ApplicationScope(t *testing.T) {
+	SetApplicationScope([]string{"api"}, []string{"payments"})
+	t.Cleanup(func() { SetApplicationScope(nil, nil) })
+
+	mock := client.NewMockClient()
+	mock.DefaultResponse = &client.Response{StatusCode: 200, Body: []byte("data: {\"result\":{\"message\":\"test log\"}}\n")}
+	tool := NewQueryTool(mock, zap.NewNop())
+	args := func(query string) map[string]interface{} {
+		return map[string]interface{}{"query": query, "start_date": "2024-01-01T00:00:00Z", "end_date": "2024-01-02T00:00:00Z"}
+	}
+
+	result, err := tool.Execute(testCtx(mock), args("source logs | limit 10"))
+	require.NoError(t, err)
+	require.False(t, result.IsError, "%v", result.Content)
+	sent := mock.LastRequest().Body.(map[string]interface{})["query"].(string)
+	assert.Equal(t, "source logs | filter ($l.applicationname == 'api') && $l.applicationname != 'payments' | limit 10", sent)
+
+	mock.Reset()
+	result, err = tool.Execute(testCtx(mock), args("source logs | filter $l.applicationname == 'payments'"))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Equal(t, "FORBIDDEN", result.Meta["error_code"])
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "Not authorized: application 'payments' is denied")
+	assert.Zero(t, mock.RequestCount(), "denied queries never reach the API")
+}
+
+func TestApplyApplicationScope_QueryEndpoints(t *testing.T) {
+	SetApplicationScope(nil, []string{"payments"})
+	t.Cleanup(func() { SetApplicationScope(nil, nil) })
+
+	mock := client.NewMockClient()
+	mock.DefaultResponse = &client.Response{StatusCode: 200, Body: []byte(`{}`)}
+	result, err := NewDiscoverLogFieldsTool(mock, zap.NewNop()).Execute(testCtx(mock), map[string]interface{}{})
+	require.NoError(t, err)
+	require.False(t, result.IsError, "%v", result.Content)
+	assert.Equal(t, "/v1/dataprime/query", mock.LastRequest().Path)
+	assert.Contains(t, mock.LastRequest().Body.(map[string]interface{})["query"], "$l.applicationname != 'payments'")
+
+	_, err = applyApplicationScope(&client.Request{Method: "POST", Path: "/v2/query", Body: map[string]interface{}{"query": "source logs"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be checked against the application scope")
+
+	req := &client.Request{Method: "POST", Path: "/v1/alerts", Body: map[string]interface{}{"name": "a"}}
+	scoped, err := applyApplicationScope(req)
+	require.NoError(t, err)
+	assert.Same(t, req, scoped)
+}
@@ -0,0 +1,154 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements the audit trail of mutating tool calls.
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/audit"
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+const (
+	// auditApplicationName and auditSubsystemName label audit records ingested into Cloud Logs
+	auditApplicationName = "logs-mcp"
+	auditSubsystemName   = "audit"
+	// auditIngestTimeout bounds the ingestion of one audit record
+	auditIngestTimeout = 10 * time.Second
+)
+
+// BuildAuditEntry describes a tool call for the audit trail. It reports false for calls that
+// change nothing: read-only tools, dry runs the tool honored (flagged in the result by
+// MarkDryRun), and deletes still awaiting confirmation.
+func BuildAuditEntry(ctx context.Context, t Tool, args map[string]interface{}, result *mcp.CallToolResult, err error, duration time.Duration) (audit.Entry, bool) {
+	if IsReadOnlyTool(t) {
+		return audit.Entry{}, false
+	}
+	if result != nil && (result.Meta["dry_run"] == true || result.Meta["confirmation_required"] == true) {
+		return audit.Entry{}, false
+	}
+
+	entry := audit.Entry{
+		Timestamp:  time.Now().UTC(),
+		Tool:       t.Name(),
+		Instance:   GetInstanceNameFromContext(ctx),
+		Operation:  "write",
+		Success:    err == nil && (result == nil || !result.IsError),
+		Duration:   duration,
+		InputHash:  hashAuditInput(args),
+		ResourceID: auditResourceID(args, result),
+	}
+	if capability := GetToolCapability(t.Name()); capability != nil {
+		entry.Operation = capability.Category
+		entry.Resource = capability.ResourceType
+	}
+	switch {
+	case err != nil:
+		entry.ErrorMsg = err.Error()
+	case result != nil && result.IsError:
+		entry.ErrorCode, _ = result.Meta["error_code"].(string)
+		entry.ErrorMsg = truncateString(resultText(result), 500)
+	}
+	return entry, true
+}
+
+// hashAuditInput returns a SHA-256 of the call arguments; map keys are marshaled in sorted
+// order, so identical requests hash identically
+func hashAuditInput(args map[string]interface{}) string {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// auditResourceID takes the affected resource's id from the response, falling back to the
+// id or name arguments for updates and deletes whose responses carry none
+func auditResourceID(args map[string]interface{}, result *mcp.CallToolResult) string {
+	if result != nil && !result.IsError {
+		var body map[string]interface{}
+		// Responses may carry suggestions after the JSON body, so decode only the first value
+		if json.NewDecoder(strings.NewReader(resultText(result))).Decode(&body) == nil {
+			for _, key := range []string{"id", "unique_identifier"} {
+				if id := fmt.Sprint(body[key]); body[key] != nil && id != "" {
+					return id
+				}
+			}
+		}
+	}
+
+	var keys []string
+	for key := range args {
+		if key == "id" || strings.HasSuffix(key, "_id") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	// Session resources such as saved and scheduled queries are identified by name
+	keys = append(keys, "name")
+	for _, key := range keys {
+		if id, ok := args[key].(string); ok && id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// ingestAuditSink writes audit entries into the Cloud Logs instance through the ingestion endpoint
+type ingestAuditSink struct {
+	client client.Doer
+}
+
+// NewIngestAuditSink returns an audit sink that ingests each entry into the instance as a log
+// with applicationName "logs-mcp" and subsystemName "audit"
+func NewIngestAuditSink(c client.Doer) audit.Sink {
+	return &ingestAuditSink{client: c}
+}
+
+// Write ingests one audit entry
+func (s *ingestAuditSink) Write(ctx context.Context, entry audit.Entry) error {
+	var record map[string]interface{}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &record); err != nil {
+		return err
+	}
+	severity := 3 // Info
+	if !entry.Success {
+		severity = 4 // Warning
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), auditIngestTimeout)
+	defer cancel()
+	resp, err := s.client.Do(ctx, &client.Request{
+		Method: "POST",
+		Path:   "/logs/v1/singles",
+		Body: []map[string]interface{}{{
+			"applicationName": auditApplicationName,
+			"subsystemName":   auditSubsystemName,
+			"severity":        severity,
+			"timestamp":       float64(entry.Timestamp.UnixNano()) / 1e9,
+			"json":            record,
+		}},
+		UseIngressHost: true,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("audit ingestion failed with HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/audit"
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestBuildAuditEntry(t *testing.T) {
+	mock := client.NewMockClient()
+	logger := zap.NewNop()
+	ctx := WithInstanceName(context.Background(), "staging")
+	text := func(s string) *mcp.CallToolResult {
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: s}}}
+	}
+
+	_, ok := BuildAuditEntry(ctx, NewListAlertsTool(mock, logger), nil, text(`{"alerts": []}`), nil, time.Second)
+	assert.False(t, ok, "read-only tools are not audited")
+	preview, _ := MarkDryRun(text("{}"), nil)
+	_, ok = BuildAuditEntry(ctx, NewCreateAlertTool(mock, logger), map[string]interface{}{"dry_run": true}, preview, nil, time.Second)
+	assert.False(t, ok, "dry runs change nothing")
+	_, ok = BuildAuditEntry(ctx, NewDeleteAlertTool(mock, logger), map[string]interface{}{"id": "a1", "dry_run": true}, text("{}"), nil, time.Second)
+	assert.True(t, ok, "a dry_run argument the tool did not honor is audited")
+
+	args := map[string]interface{}{"name": "high errors", "severity": "critical"}
+	entry, ok := BuildAuditEntry(ctx, NewCreateAlertTool(mock, logger), args,
+		text(`{"id": "alert-123", "name": "high errors"}`+"\n\n💡 Next: get_alert"), nil, time.Second)
+	require.True(t, ok)
+	assert.Equal(t, "create_alert", entry.Tool)
+	assert.Equal(t, "staging", entry.Instance)
+	assert.Equal(t, "create", entry.Operation)
+	assert.Equal(t, "alert", entry.Resource)
+	assert.Equal(t, "alert-123", entry.ResourceID)
+	assert.True(t, entry.Success)
+	assert.Regexp(t, `^sha256:[0-9a-f]{64}$`, entry.InputHash)
+	again, _ := BuildAuditEntry(ctx, NewCreateAlertTool(mock, logger), map[string]interface{}{"severity": "critical", "name": "high errors"}, nil, nil, 0)
+	assert.Equal(t, entry.InputHash, again.InputHash, "the hash does not depend on argument order")
+
+	failed := NewToolResultErrorFromErr(&APIError{StatusCode: 404, Message: "alert not found"})
+	entry, ok = BuildAuditEntry(ctx, NewDeleteAlertTool(mock, logger), map[string]interface{}{"id": "alert-9"}, failed, nil, time.Second)
+	require.True(t, ok)
+	assert.False(t, entry.Success)
+	assert.Equal(t, "alert-9", entry.ResourceID)
+	assert.Equal(t, "NOT_FOUND", entry.ErrorCode)
+	assert.Contains(t, entry.ErrorMsg, "alert not found")
+
+	entry, _ = BuildAuditEntry(ctx, NewDeleteAlertTool(mock, logger), map[string]interface{}{"id": "alert-9"}, nil, errors.New("boom"), 0)
+	assert.Equal(t, "boom", entry.ErrorMsg)
+}
+
+func TestIngestAuditSink(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.RespondWith(200, map[string]interface{}{})
+	sink := NewIngestAuditSink(mock)
+
+	require.NoError(t, sink.Write(context.Background(), audit.Entry{
+		Timestamp: time.Unix(1700000000, 0), Tool: "delete_view", Operation: "delete", ResourceID: "42", Success: true,
+	}))
+	req := mock.LastRequest()
+	assert.Equal(t, "/logs/v1/singles", req.Path)
+	assert.True(t, req.UseIngressHost)
+	logs := req.Body.([]map[string]interface{})
+	require.Len(t, logs, 1)
+	assert.Equal(t, "logs-mcp", logs[0]["applicationName"])
+	assert.Equal(t, "audit", logs[0]["subsystemName"])
+	assert.Equal(t, "delete_view", logs[0]["json"].(map[string]interface{})["tool"])
+
+	mock.RespondWith(500, map[string]interface{}{})
+	assert.Error(t, sink.Write(context.Background(), audit.Entry{Tool: "delete_view"}))
+}
@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxExportEvents caps how many events are decoded when writing results to a file.
+// It is far above MaxSSEEvents because file exports don't count against response limits.
+const MaxExportEvents = 1_000_000
+
+var (
+	exportDirMu sync.RWMutex
+	exportDir   string
+
+	unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+)
+
+// SetExportDir sets the directory that file-mode query results are written to.
+// An empty dir restores the default (~/.logs-mcp/exports).
+func SetExportDir(dir string) {
+	exportDirMu.Lock()
+	exportDir = dir
+	exportDirMu.Unlock()
+}
+
+// currentExportDir returns the configured export directory or the default
+func currentExportDir() string {
+	exportDirMu.RLock()
+	dir := exportDir
+	exportDirMu.RUnlock()
+	if dir != "" {
+		return dir
+	}
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(homeDir, ".logs-mcp", "exports")
+	}
+	return filepath.Join(os.TempDir(), "logs-mcp-exports")
+}
+
+// writeEventsJSONL writes events one JSON object per line and returns the file path
+func writeEventsJSONL(dir, queryID string, events []interface{}) (string, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	name := fmt.Sprintf("background-query-%s-%s.jsonl",
+		unsafeFileChars.ReplaceAllString(queryID, "_"), time.Now().UTC().Format("20060102T150405Z"))
+	path := filepath.Join(dir, name)
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return "", fmt.Errorf("failed to create export file: %w", err)
+	}
+
+	enc := json.NewEncoder(f)
+	for _, event := range events {
+		if err := enc.Encode(event); err != nil {
+			_ = f.Close()
+			return "", fmt.Errorf("failed to write event: %w", err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("failed to close export file: %w", err)
+	}
+	return path, nil
+}
+
+// formatExportSummary renders the statistical summary returned instead of inline events
+func formatExportSummary(path string, events []interface{}, truncated bool) string {
+	var sb strings.Builder
+	sb.WriteString("## Background Query Results Exported\n\n")
+	fmt.Fprintf(&sb, "**File:** `%s`\n", path)
+	fmt.Fprintf(&sb, "**Format:** JSONL (one event per line)\n")
+	fmt.Fprintf(&sb, "**Events:** %d\n", len(events))
+	if truncated {
+		fmt.Fprintf(&sb, "**Note:** Results were capped at %d events\n", MaxExportEvents)
+	}
+	sb.WriteString("\n")
+
+	if severityDist := analyzeSeverityDistribution(events); len(severityDist) > 0 {
+		sb.WriteString("### Severity Distribution\n")
+		for _, sev := range []string{"Critical", "Error", "Warning", "Info", "Verbose", "Debug"} {
+			if count, ok := severityDist[sev]; ok {
+				fmt.Fprintf(&sb, "- **%s**: %d\n", sev, count)
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	if timeRange := extractTimeRange(events, currentTimeDisplay()); timeRange != "" {
+		fmt.Fprintf(&sb, "### Time Range\n%s\n", timeRange)
+	}
+	return sb.String()
+}
@@ -0,0 +1,74 @@
+package tools
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestGetBackgroundQueryData_FileMode(t *testing.T) {
+	dir := t.TempDir()
+	SetExportDir(dir)
+	defer SetExportDir("")
+
+	// More events than MaxSSEEvents to verify file mode isn't capped
+	total := MaxSSEEvents + 500
+	var body strings.Builder
+	for i := 0; i < total; i++ {
+		severity := 3
+		if i%10 == 0 {
+			severity = 5
+		}
+		fmt.Fprintf(&body, `data: {"result":{"results":[{"labels":[{"key":"applicationname","value":"api"}],"metadata":[{"key":"timestamp","value":"2026-03-09T10:%02d:00Z"},{"key":"severity","value":"%d"}],"user_data":"{\"message\":\"event %d\"}"}]}}`+"\n\n", i%60, severity, i)
+	}
+
+	mock := client.NewMockClient()
+	mock.DefaultResponse = &client.Response{StatusCode: 200, Body: []byte(body.String())}
+
+	tool := NewGetBackgroundQueryDataTool(nil, zap.NewNop())
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{
+		"query_id":    "abc/../123",
+		"output_mode": "file",
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	text := result.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, fmt.Sprintf("**Events:** %d", total))
+	assert.Contains(t, text, "Severity Distribution")
+	assert.Contains(t, text, "Time Range")
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.True(t, strings.HasPrefix(entries[0].Name(), "background-query-abc_123-"))
+
+	f, err := os.Open(dir + "/" + entries[0].Name())
+	require.NoError(t, err)
+	defer f.Close()
+	lines := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines++
+	}
+	assert.Equal(t, total, lines)
+}
+
+func TestGetBackgroundQueryData_InvalidOutputMode(t *testing.T) {
+	tool := NewGetBackgroundQueryDataTool(nil, zap.NewNop())
+	result, err := tool.Execute(testCtx(client.NewMockClient()), map[string]interface{}{
+		"query_id":    "abc",
+		"output_mode": "stream",
+	})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
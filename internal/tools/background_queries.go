@@ -0,0 +1,258 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+	mcperrors "github.com/tareqmamari/cloud-logs-mcp/internal/errors"
+)
+
+// backgroundQueryID extracts the query ID from a submit_background_query response
+func backgroundQueryID(result map[string]interface{}) string {
+	for _, key := range []string{"query_id", "_query_id", "id"} {
+		if id, ok := result[key].(string); ok && id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// backgroundQueryState maps a background query status response to running, completed,
+// failed, or cancelled. The API reports state as a oneof (waiting_for_execution, running,
+// terminated{success|error|cancelled}); a flat "status" string is accepted as well.
+func backgroundQueryState(status map[string]interface{}) string {
+	if terminated, ok := status["terminated"].(map[string]interface{}); ok {
+		switch {
+		case terminated["cancelled"] != nil:
+			return "cancelled"
+		case terminated["error"] != nil:
+			return "failed"
+		default:
+			return "completed"
+		}
+	}
+	if status["running"] != nil || status["waiting_for_execution"] != nil {
+		return "running"
+	}
+	if s, ok := status["status"].(string); ok && s != "" {
+		return strings.ToLower(s)
+	}
+	return "unknown"
+}
+
+// isBackgroundQueryFinished reports whether a state is terminal
+func isBackgroundQueryFinished(state string) bool {
+	switch state {
+	case "completed", "failed", "cancelled", "succeeded", "success", "error", "terminated":
+		return true
+	}
+	return false
+}
+
+// refreshBackgroundQueryState fetches the current state of a background query and records it
+// in the session. A 404 means the server no longer knows the query, which is treated as finished.
+func refreshBackgroundQueryState(ctx context.Context, t *BaseTool, session *SessionContext, queryID string) (string, error) {
+	status, err := t.ExecuteRequest(ctx, &client.Request{
+		Method: "GET",
+		Path:   "/v1/background_query/" + queryID + "/status",
+	})
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.IsNotFound() {
+			session.SetBackgroundQueryStatus(queryID, "expired")
+			return "expired", nil
+		}
+		return "", err
+	}
+	state := backgroundQueryState(status)
+	session.SetBackgroundQueryStatus(queryID, state)
+	return state, nil
+}
+
+// ListBackgroundQueriesTool lists background queries submitted in this session
+type ListBackgroundQueriesTool struct{ *BaseTool }
+
+// NewListBackgroundQueriesTool creates a new tool instance
+func NewListBackgroundQueriesTool(c client.Doer, l *zap.Logger) *ListBackgroundQueriesTool {
+	return &ListBackgroundQueriesTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *ListBackgroundQueriesTool) Name() string { return "list_background_queries" }
+
+// Annotations returns tool hints for LLMs
+func (t *ListBackgroundQueriesTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("List Background Queries")
+}
+
+// Description returns the tool description
+func (t *ListBackgroundQueriesTool) Description() string {
+	return `List background queries submitted in this session with their status and submit time.
+
+Statuses are refreshed from the API unless refresh=false. Only queries submitted through this
+server are tracked (most recent 50).
+
+**Related tools:** submit_background_query, get_background_query_data, cancel_all_background_queries`
+}
+
+// InputSchema returns the input schema
+func (t *ListBackgroundQueriesTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"refresh": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Fetch the current status of unfinished queries from the API (default: true)",
+				"default":     true,
+			},
+		},
+	}
+}
+
+// Execute executes the tool
+func (t *ListBackgroundQueriesTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	session := GetSessionFromContext(ctx)
+	refresh := true
+	if v, ok := args["refresh"].(bool); ok {
+		refresh = v
+	}
+
+	queries := session.GetBackgroundQueries()
+	if len(queries) == 0 {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: "No background queries have been submitted in this session.\n\nUse `submit_background_query` to start one."}},
+		}, nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## Background Queries (%d)\n\n", len(queries))
+	sb.WriteString("| Query ID | Status | Submitted | Query |\n")
+	sb.WriteString("|----------|--------|-----------|-------|\n")
+	for _, q := range queries {
+		state := q.Status
+		if refresh && !isBackgroundQueryFinished(state) && state != "expired" {
+			if s, err := refreshBackgroundQueryState(ctx, t.BaseTool, session, q.QueryID); err == nil {
+				state = s
+			} else {
+				state = state + " (refresh failed)"
+			}
+		}
+		query := q.Query
+		if len(query) > 60 {
+			query = query[:57] + "..."
+		}
+		fmt.Fprintf(&sb, "| %s | %s | %s | `%s` |\n", q.QueryID, state, q.SubmittedAt.UTC().Format(time.RFC3339), strings.ReplaceAll(query, "|", "\\|"))
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}},
+	}, nil
+}
+
+// CancelAllBackgroundQueriesTool cancels every running background query in this session
+type CancelAllBackgroundQueriesTool struct{ *BaseTool }
+
+// NewCancelAllBackgroundQueriesTool creates a new tool instance
+func NewCancelAllBackgroundQueriesTool(c client.Doer, l *zap.Logger) *CancelAllBackgroundQueriesTool {
+	return &CancelAllBackgroundQueriesTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *CancelAllBackgroundQueriesTool) Name() string { return "cancel_all_background_queries" }
+
+// Annotations returns tool hints for LLMs
+func (t *CancelAllBackgroundQueriesTool) Annotations() *mcp.ToolAnnotations {
+	return DeleteAnnotations("Cancel All Background Queries")
+}
+
+// Description returns the tool description
+func (t *CancelAllBackgroundQueriesTool) Description() string {
+	return `Cancel every running background query submitted in this session.
+
+Checks each tracked query's status first, cancels those still running, and reports which were
+cancelled versus already finished. Results of cancelled queries cannot be retrieved.
+
+**Related tools:** list_background_queries, cancel_background_query`
+}
+
+// InputSchema returns the input schema
+func (t *CancelAllBackgroundQueriesTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{},
+	}
+}
+
+// Execute executes the tool
+func (t *CancelAllBackgroundQueriesTool) Execute(ctx context.Context, _ map[string]interface{}) (*mcp.CallToolResult, error) {
+	session := GetSessionFromContext(ctx)
+	queries := session.GetBackgroundQueries()
+
+	var cancelled, finished, failed []string
+	for _, q := range queries {
+		state := q.Status
+		if !isBackgroundQueryFinished(state) && state != "expired" {
+			s, err := refreshBackgroundQueryState(ctx, t.BaseTool, session, q.QueryID)
+			if err != nil {
+				failed = append(failed, fmt.Sprintf("%s (status check failed: %v)", q.QueryID, err))
+				continue
+			}
+			state = s
+		}
+		if isBackgroundQueryFinished(state) || state == "expired" {
+			finished = append(finished, fmt.Sprintf("%s (%s)", q.QueryID, state))
+			continue
+		}
+
+		_, err := t.ExecuteRequest(ctx, &client.Request{
+			Method: "DELETE",
+			Path:   "/v1/background_query/" + q.QueryID,
+		})
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s (%v)", q.QueryID, err))
+			continue
+		}
+		session.SetBackgroundQueryStatus(q.QueryID, "cancelled")
+		cancelled = append(cancelled, q.QueryID)
+	}
+
+	session.RecordToolUse(t.Name(), len(failed) == 0, map[string]interface{}{
+		"cancelled": len(cancelled),
+		"finished":  len(finished),
+		"failed":    len(failed),
+	})
+
+	var sb strings.Builder
+	sb.WriteString("## Cancel All Background Queries\n\n")
+	if len(queries) == 0 {
+		sb.WriteString("No background queries have been submitted in this session.\n")
+	}
+	writeList := func(title string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		fmt.Fprintf(&sb, "### %s (%d)\n", title, len(items))
+		for _, item := range items {
+			fmt.Fprintf(&sb, "- %s\n", item)
+		}
+		sb.WriteString("\n")
+	}
+	writeList("Cancelled", cancelled)
+	writeList("Already Finished", finished)
+	writeList("Failed to Cancel", failed)
+
+	result := &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}},
+	}
+	if len(failed) > 0 && len(cancelled) == 0 && len(finished) == 0 {
+		withErrorCode(result, mcperrors.CodeUpstreamError)
+	}
+	return result, nil
+}
@@ -0,0 +1,106 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestBackgroundQueryState(t *testing.T) {
+	tests := []struct {
+		name   string
+		status map[string]interface{}
+		want   string
+	}{
+		{"running", map[string]interface{}{"running": map[string]interface{}{}}, "running"},
+		{"waiting", map[string]interface{}{"waiting_for_execution": map[string]interface{}{}}, "running"},
+		{"success", map[string]interface{}{"terminated": map[string]interface{}{"success": map[string]interface{}{}}}, "completed"},
+		{"error", map[string]interface{}{"terminated": map[string]interface{}{"error": map[string]interface{}{}}}, "failed"},
+		{"cancelled", map[string]interface{}{"terminated": map[string]interface{}{"cancelled": map[string]interface{}{}}}, "cancelled"},
+		{"flat status", map[string]interface{}{"status": "COMPLETED"}, "completed"},
+		{"empty", map[string]interface{}{}, "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, backgroundQueryState(tt.status))
+		})
+	}
+}
+
+func TestSubmitBackgroundQuery_TracksQueryID(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.RespondWith(200, map[string]interface{}{"query_id": "bq-1"})
+	ctx := testCtx(mock)
+
+	tool := NewSubmitBackgroundQueryTool(mock, zap.NewNop())
+	result, err := tool.Execute(ctx, map[string]interface{}{
+		"query":  "source logs | limit 10",
+		"syntax": "dataprime",
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	queries := GetSessionFromContext(ctx).GetBackgroundQueries()
+	require.Len(t, queries, 1)
+	assert.Equal(t, "bq-1", queries[0].QueryID)
+	assert.Equal(t, "running", queries[0].Status)
+}
+
+func TestCancelAllBackgroundQueries(t *testing.T) {
+	mock := client.NewMockClient()
+	var deleted []string
+	mock.DoFunc = func(_ context.Context, req *client.Request) (*client.Response, error) {
+		switch {
+		case req.Method == "DELETE":
+			deleted = append(deleted, req.Path)
+			return &client.Response{StatusCode: 200, Body: []byte(`{}`)}, nil
+		case strings.Contains(req.Path, "bq-done"):
+			return &client.Response{StatusCode: 200, Body: []byte(`{"terminated":{"success":{}}}`)}, nil
+		default:
+			return &client.Response{StatusCode: 200, Body: []byte(`{"running":{}}`)}, nil
+		}
+	}
+	ctx := testCtx(mock)
+	session := GetSessionFromContext(ctx)
+	session.TrackBackgroundQuery("bq-running", "source logs")
+	session.TrackBackgroundQuery("bq-done", "source logs | limit 1")
+
+	tool := NewCancelAllBackgroundQueriesTool(mock, zap.NewNop())
+	assert.True(t, *tool.Annotations().DestructiveHint)
+
+	result, err := tool.Execute(ctx, nil)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	assert.Equal(t, []string{"/v1/background_query/bq-running"}, deleted)
+	text := result.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, "### Cancelled (1)")
+	assert.Contains(t, text, "### Already Finished (1)")
+	assert.Contains(t, text, "bq-done (completed)")
+
+	for _, q := range session.GetBackgroundQueries() {
+		if q.QueryID == "bq-running" {
+			assert.Equal(t, "cancelled", q.Status)
+		}
+	}
+}
+
+func TestListBackgroundQueries(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.RespondWith(200, map[string]interface{}{"terminated": map[string]interface{}{"success": map[string]interface{}{}}})
+	ctx := testCtx(mock)
+	GetSessionFromContext(ctx).TrackBackgroundQuery("bq-1", "source logs")
+
+	result, err := NewListBackgroundQueriesTool(mock, zap.NewNop()).Execute(ctx, nil)
+	require.NoError(t, err)
+	text := result.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, "| bq-1 | completed |")
+}
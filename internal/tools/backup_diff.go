@@ -0,0 +1,182 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements diff_backup, which shows how the live instance has drifted from a
+// backup_instance bundle.
+package tools
+
+import (
+	"context"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// BackupTypeDiff is the drift of one resource type between a bundle and the live instance
+type BackupTypeDiff struct {
+	New       []string         `json:"new,omitempty"`     // Live only, created since the backup
+	Deleted   []string         `json:"deleted,omitempty"` // Bundle only, deleted since the backup
+	Modified  []BackupItemDiff `json:"modified,omitempty"`
+	Unchanged int              `json:"unchanged"`
+}
+
+// BackupItemDiff lists the field changes of one resource, from the bundle to the live instance
+type BackupItemDiff struct {
+	Name    string      `json:"name"`
+	Changes []FieldDiff `json:"changes"`
+}
+
+// DiffBackupTool compares a backup_instance bundle with the live instance
+type DiffBackupTool struct{ *BaseTool }
+
+// NewDiffBackupTool creates a new tool instance
+func NewDiffBackupTool(c client.Doer, l *zap.Logger) *DiffBackupTool {
+	return &DiffBackupTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *DiffBackupTool) Name() string { return "diff_backup" }
+
+// Annotations returns tool hints for LLMs
+func (t *DiffBackupTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Diff Backup")
+}
+
+// DefaultTimeout returns the timeout
+func (t *DiffBackupTool) DefaultTimeout() time.Duration {
+	return DefaultSlowToolTimeout
+}
+
+// Description returns the tool description
+func (t *DiffBackupTool) Description() string {
+	return `Compare a backup_instance bundle with the live instance to see what changed since the backup.
+
+The live resources of each type in the bundle are read and normalized the same way as a backup,
+then matched by name. Per type, resources are reported as new (live only), deleted (bundle only)
+or modified with field-level changes from the bundle value to the live value; references are
+compared by the referenced resource's name, so a recreated webhook with a new ID is no change.
+
+Use it to check for configuration drift, or before restore_instance to see what a restore would bring back.
+
+**Related tools:** backup_instance, restore_instance, compare_alerts`
+}
+
+// InputSchema returns the input schema
+func (t *DiffBackupTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"file": map[string]interface{}{
+				"type":        "string",
+				"description": "Path of a bundle written by backup_instance (must be in the export directory)",
+			},
+			"backup": map[string]interface{}{
+				"type":        "object",
+				"description": "The bundle itself, e.g. the contents of a backup file copied from another server",
+			},
+			"types": backupResourceTypesSchema("compare"),
+		},
+	}
+}
+
+// Execute executes the tool
+func (t *DiffBackupTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	backup, err := loadInstanceBackup(args)
+	if err != nil {
+		return NewToolResultErrorWithSuggestion(err.Error(), "Pass file with the path returned by backup_instance, or backup with the bundle itself."), nil
+	}
+	selected, err := selectBackupResources(args)
+	if err != nil {
+		return NewToolResultErrorWithSuggestion(err.Error(), "Omit types to compare every type in the bundle."), nil
+	}
+	// Types missing from the bundle were not backed up, so every live resource would look new
+	var resources []backupResource
+	for _, r := range selected {
+		if _, ok := backup.Resources[r.Type]; ok {
+			resources = append(resources, r)
+		}
+	}
+	if len(resources) == 0 {
+		return NewToolResultErrorWithSuggestion("The bundle holds none of the selected resource types",
+			"Omit types, or pick types that were backed up."), nil
+	}
+
+	live, err := t.collectBackup(ctx, resources)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	// Resources that could not be read live must not be reported as deleted
+	unreadable := make(map[string]map[string]bool)
+	for _, e := range live.Errors {
+		if unreadable[e.Type] == nil {
+			unreadable[e.Type] = make(map[string]bool)
+		}
+		unreadable[e.Type][e.Name] = true
+	}
+
+	diffs := make(map[string]*BackupTypeDiff, len(resources))
+	summary := map[string]int{"new": 0, "deleted": 0, "modified": 0, "unchanged": 0}
+	for _, r := range resources {
+		liveItems, ok := live.Resources[r.Type]
+		if !ok {
+			continue
+		}
+		d := diffBackupItems(backup.Resources[r.Type], liveItems, unreadable[r.Type])
+		diffs[r.Type] = d
+		summary["new"] += len(d.New)
+		summary["deleted"] += len(d.Deleted)
+		summary["modified"] += len(d.Modified)
+		summary["unchanged"] += d.Unchanged
+	}
+
+	response := map[string]interface{}{
+		"backup_created_at": backup.CreatedAt,
+		"in_sync":           summary["new"]+summary["deleted"]+summary["modified"] == 0 && len(live.Errors) == 0,
+		"summary":           summary,
+		"types":             diffs,
+	}
+	if len(live.Errors) > 0 {
+		response["errors"] = live.Errors
+		response["note"] = "Some live resources could not be read; they are left out of the comparison."
+	}
+	return t.FormatResponse(response)
+}
+
+// diffBackupItems matches bundle and live items by name and diffs each pair. Items sharing a
+// name are paired in order. Bundle items whose name is in skip are not reported as deleted.
+func diffBackupItems(bundle, live []map[string]interface{}, skip map[string]bool) *BackupTypeDiff {
+	d := &BackupTypeDiff{}
+	byName := make(map[string][]map[string]interface{}, len(live))
+	var liveOrder []string
+	for _, item := range live {
+		name := backupItemName(item)
+		if _, seen := byName[name]; !seen {
+			liveOrder = append(liveOrder, name)
+		}
+		byName[name] = append(byName[name], item)
+	}
+
+	for _, item := range bundle {
+		name := backupItemName(item)
+		candidates := byName[name]
+		if len(candidates) == 0 {
+			if !skip[name] {
+				d.Deleted = append(d.Deleted, name)
+			}
+			continue
+		}
+		byName[name] = candidates[1:]
+		if changes := DiffResources(item, candidates[0]); len(changes) > 0 {
+			d.Modified = append(d.Modified, BackupItemDiff{Name: name, Changes: changes})
+		} else {
+			d.Unchanged++
+		}
+	}
+	for _, name := range liveOrder {
+		for range byName[name] {
+			d.New = append(d.New, name)
+		}
+	}
+	return d
+}
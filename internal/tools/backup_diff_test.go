@@ -0,0 +1,66 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestDiffBackup(t *testing.T) {
+	webhookRef := map[string]interface{}{"$ref": "outgoing_webhooks", "name": "pagerduty", "field": "external_id"}
+	bundle := map[string]interface{}{
+		"format":     instanceBackupFormat,
+		"created_at": "2024-01-01T00:00:00Z",
+		"resources": map[string]interface{}{
+			"outgoing_webhooks": []interface{}{map[string]interface{}{"name": "pagerduty", "type": "pager_duty"}},
+			"alerts": []interface{}{
+				map[string]interface{}{"name": "Kept", "notification_groups": []interface{}{map[string]interface{}{"integration_id": webhookRef}}},
+				map[string]interface{}{"name": "Changed", "severity": "warning"},
+				map[string]interface{}{"name": "Gone", "severity": "error"},
+			},
+		},
+	}
+	// The webhook was recreated with a new external ID; "Kept" still notifies it by name
+	mock := newInstanceMock(t, map[string]interface{}{
+		"/v1/outgoing_webhooks": map[string]interface{}{"outgoing_webhooks": []interface{}{
+			map[string]interface{}{"id": "wh-new", "external_id": 42.0, "name": "pagerduty", "type": "pager_duty"},
+		}},
+		"/v1/alerts": map[string]interface{}{"alerts": []interface{}{
+			map[string]interface{}{"id": "a-1", "name": "Kept", "updated_at": "2024-06-01T00:00:00Z",
+				"notification_groups": []interface{}{map[string]interface{}{"integration_id": 42.0}}},
+			map[string]interface{}{"id": "a-2", "name": "Changed", "severity": "critical"},
+			map[string]interface{}{"id": "a-3", "name": "Fresh"},
+		}},
+	})
+
+	response := decodeFirstJSON(t, mustExecute(t, NewDiffBackupTool(mock, zap.NewNop()), map[string]interface{}{"backup": bundle}))
+	assert.Equal(t, false, response["in_sync"])
+	assert.Equal(t, map[string]interface{}{"new": 1.0, "deleted": 1.0, "modified": 1.0, "unchanged": 2.0}, response["summary"])
+	types := response["types"].(map[string]interface{})
+	assert.NotContains(t, types, "dashboards", "types missing from the bundle are not compared")
+
+	alerts := types["alerts"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"Fresh"}, alerts["new"])
+	assert.Equal(t, []interface{}{"Gone"}, alerts["deleted"])
+	assert.Equal(t, 1.0, alerts["unchanged"])
+	require.Len(t, alerts["modified"], 1)
+	assert.Equal(t, map[string]interface{}{
+		"name":    "Changed",
+		"changes": []interface{}{map[string]interface{}{"path": "severity", "change": "changed", "old": "warning", "new": "critical"}},
+	}, alerts["modified"].([]interface{})[0])
+}
+
+func TestDiffBackup_Errors(t *testing.T) {
+	tool := NewDiffBackupTool(newInstanceMock(t, nil), zap.NewNop())
+
+	result := mustExecute(t, tool, map[string]interface{}{})
+	require.True(t, result.IsError)
+	assert.Contains(t, resultText(result), "either file or backup is required")
+
+	bundle := map[string]interface{}{"format": instanceBackupFormat, "resources": map[string]interface{}{"views": []interface{}{}}}
+	result = mustExecute(t, tool, map[string]interface{}{"backup": bundle, "types": []interface{}{"alerts"}})
+	require.True(t, result.IsError)
+	assert.Contains(t, resultText(result), "none of the selected resource types")
+}
@@ -5,7 +5,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -13,6 +16,8 @@ import (
 
 	"github.com/tareqmamari/cloud-logs-mcp/internal/cache"
 	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+	mcperrors "github.com/tareqmamari/cloud-logs-mcp/internal/errors"
+	"github.com/tareqmamari/cloud-logs-mcp/internal/metrics"
 	"github.com/tareqmamari/cloud-logs-mcp/internal/tracing"
 )
 
@@ -87,31 +92,70 @@ func (t *BaseTool) GetClient(ctx context.Context) (client.Doer, error) {
 	return nil, ErrNoClientInContext
 }
 
+// toolMetrics receives API response and cache lookup metrics; nil until SetMetrics is called
+var toolMetrics atomic.Pointer[metrics.Metrics]
+
+// SetMetrics sets the tracker that ExecuteRequest and the result cache report to
+func SetMetrics(m *metrics.Metrics) {
+	toolMetrics.Store(m)
+}
+
+// recordAPIResponse reports an API call's outcome; statusCode is 0 when no response arrived
+func recordAPIResponse(success bool, latency time.Duration, statusCode int) {
+	if m := toolMetrics.Load(); m != nil {
+		m.RecordRequest(success, latency, statusCode)
+	}
+}
+
 // ExecuteRequest executes an API request and returns the response
 func (t *BaseTool) ExecuteRequest(ctx context.Context, req *client.Request) (map[string]interface{}, error) {
+	return t.ExecuteRequestWithMaxEvents(ctx, req, currentMaxSSEEvents())
+}
+
+// ExecuteRequestWithMaxEvents executes an API request, keeping at most maxEvents
+// log entries when the response is a Server-Sent Events stream
+func (t *BaseTool) ExecuteRequestWithMaxEvents(ctx context.Context, req *client.Request, maxEvents int) (map[string]interface{}, error) {
 	// Start OpenTelemetry span for API call
 	ctx, span := tracing.APISpan(ctx, req.Method, req.Path)
 	defer span.End()
 
+	// Log queries are restricted to the configured application scope here, where every
+	// tool's requests pass, so crafted DataPrime cannot reach other applications
+	req, err := applyApplicationScope(req)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
 	apiClient, err := t.GetClient(ctx)
 	if err != nil {
 		tracing.RecordError(span, err)
 		return nil, fmt.Errorf("failed to get API client: %w", err)
 	}
 
+	start := time.Now()
 	resp, err := apiClient.Do(ctx, req)
 	if err != nil {
+		recordAPIResponse(false, time.Since(start), 0)
 		tracing.RecordError(span, err)
 		// Check for context timeout/cancellation
 		if ctx.Err() != nil {
 			return nil, &APIError{
 				StatusCode: 408,
+				Code:       mcperrors.CodeTimeout,
 				Message:    fmt.Sprintf("Request timed out: %v", ctx.Err()),
+				Err:        ctx.Err(),
 			}
 		}
-		return nil, fmt.Errorf("API request failed: %w", err)
+		return nil, &APIError{
+			Code:    mcperrors.CodeUpstreamError,
+			Message: fmt.Sprintf("API request failed: %v", err),
+			Err:     err,
+		}
 	}
 
+	recordAPIResponse(resp.StatusCode < 400, time.Since(start), resp.StatusCode)
+
 	// Extract request ID from response headers (IBM Cloud uses X-Request-ID or X-Correlation-ID)
 	requestID := resp.Headers.Get("X-Request-ID")
 	if requestID == "" {
@@ -132,6 +176,7 @@ func (t *BaseTool) ExecuteRequest(ctx context.Context, req *client.Request) (map
 		}
 		apiErr := &APIError{
 			StatusCode: resp.StatusCode,
+			Code:       mcperrors.CodeForHTTPStatus(resp.StatusCode),
 			Message:    errorMessage,
 			RequestID:  requestID,
 			Details:    apiError,
@@ -147,7 +192,7 @@ func (t *BaseTool) ExecuteRequest(ctx context.Context, req *client.Request) (map
 	var result map[string]interface{}
 	if len(resp.Body) > 0 {
 		// Try parsing as Server-Sent Events first (for query responses)
-		if sseResult := parseSSEResponse(resp.Body); sseResult != nil {
+		if sseResult := parseSSEResponseWithLimit(resp.Body, maxEvents); sseResult != nil {
 			return sseResult, nil
 		}
 
@@ -160,6 +205,107 @@ func (t *BaseTool) ExecuteRequest(ctx context.Context, req *client.Request) (map
 	return result, nil
 }
 
+// DefaultMaxConcurrency caps in-flight requests for ExecuteConcurrent when no cap is given
+const DefaultMaxConcurrency = 4
+
+// RequestResult is the outcome of one request run by ExecuteConcurrent
+type RequestResult struct {
+	Result map[string]interface{}
+	Err    error
+}
+
+// ExecuteConcurrent runs the requests with at most maxConcurrency in flight and returns their
+// results in request order. Each request's error is recorded in its result rather than aborting
+// the batch; requests not yet started when ctx is cancelled fail with the context error.
+func (t *BaseTool) ExecuteConcurrent(ctx context.Context, reqs []*client.Request, maxConcurrency int) []RequestResult {
+	if maxConcurrency <= 0 {
+		maxConcurrency = DefaultMaxConcurrency
+	}
+	results := make([]RequestResult, len(reqs))
+	sem := make(chan struct{}, maxConcurrency)
+	var wg sync.WaitGroup
+	for i, req := range reqs {
+		wg.Add(1)
+		go func(i int, req *client.Request) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				results[i].Err = ctx.Err()
+				return
+			}
+			results[i].Result, results[i].Err = t.ExecuteRequest(ctx, req)
+		}(i, req)
+	}
+	wg.Wait()
+	return results
+}
+
+// DryRunSpec describes the validation a create tool applies in dry-run mode
+type DryRunSpec struct {
+	ResourceType   string              // Display name (e.g., "Webhook")
+	RequiredFields []string            // Fields that must be present
+	EnumFields     map[string][]string // Fields restricted to a set of values
+	SummaryFields  []string            // Fields echoed into the validation summary
+	RiskLevel      string              // Estimated risk: low (default), medium, high
+}
+
+// ValidateDryRun checks config against the spec's required and enum fields and
+// returns a ValidationResult that tools can extend with resource-specific checks.
+func (t *BaseTool) ValidateDryRun(config map[string]interface{}, spec DryRunSpec) *ValidationResult {
+	result := &ValidationResult{
+		Valid:   true,
+		Summary: make(map[string]interface{}),
+	}
+
+	if errs := ValidateRequiredFields(config, spec.RequiredFields); len(errs) > 0 {
+		result.Valid = false
+		result.Errors = append(result.Errors, errs...)
+	}
+
+	enumFields := make([]string, 0, len(spec.EnumFields))
+	for field := range spec.EnumFields {
+		enumFields = append(enumFields, field)
+	}
+	sort.Strings(enumFields)
+	for _, field := range enumFields {
+		if errMsg := ValidateEnumField(config, field, spec.EnumFields[field]); errMsg != "" {
+			result.Valid = false
+			result.Errors = append(result.Errors, errMsg)
+		}
+	}
+
+	for _, field := range spec.SummaryFields {
+		if val, ok := config[field]; ok {
+			result.Summary[field] = val
+		}
+	}
+
+	return result
+}
+
+// FormatDryRun adds the standard next-step suggestions and impact estimate to a
+// dry-run result and formats it. No API call is made.
+func (t *BaseTool) FormatDryRun(result *ValidationResult, spec DryRunSpec, config map[string]interface{}) *mcp.CallToolResult {
+	if result.Valid {
+		result.Suggestions = append(result.Suggestions, spec.ResourceType+" configuration is valid")
+		result.Suggestions = append(result.Suggestions, "Remove dry_run parameter to create the "+strings.ToLower(spec.ResourceType))
+	} else {
+		result.Suggestions = append(result.Suggestions, "Fix the errors above before creating")
+	}
+
+	if result.EstimatedImpact == nil {
+		riskLevel := spec.RiskLevel
+		if riskLevel == "" {
+			riskLevel = "low"
+		}
+		result.EstimatedImpact = &ImpactEstimate{RiskLevel: riskLevel}
+	}
+
+	return FormatDryRunResult(result, spec.ResourceType, config)
+}
+
 // SSEParseResult holds the classified output from parsing an SSE response.
 // It separates log entries from control messages (warnings, errors, query IDs)
 // so consumers get clean data without having to re-inspect every event.
@@ -190,12 +336,17 @@ type SSEParseResult struct {
 // The parser extracts individual log entries, parses user_data JSON strings,
 // and flattens labels/metadata into the entry for downstream consumption.
 func parseSSEResponse(body []byte) map[string]interface{} {
+	return parseSSEResponseWithLimit(body, currentMaxSSEEvents())
+}
+
+// parseSSEResponseWithLimit is parseSSEResponse with a caller-provided event cap
+func parseSSEResponseWithLimit(body []byte, maxEvents int) map[string]interface{} {
 	bodyStr := string(body)
 	if !strings.Contains(bodyStr, "data:") {
 		return nil
 	}
 
-	parsed := parseSSEMessages(bodyStr, MaxSSEEvents)
+	parsed := parseSSEMessages(bodyStr, maxEvents)
 	if len(parsed.Events) == 0 && len(parsed.Errors) == 0 && len(parsed.Warnings) == 0 && parsed.QueryID == "" {
 		return nil
 	}
@@ -473,19 +624,24 @@ func GetCacheHelper() *CacheHelper {
 	}
 }
 
-// GetCacheHelperFromContext returns a cache helper using the session from the given context.
+// GetCacheHelperFromContext returns a cache helper using the session from the given context,
+// scoped to the instance the call is routed to.
 func GetCacheHelperFromContext(ctx context.Context) *CacheHelper {
 	session := GetSessionFromContext(ctx)
 	return &CacheHelper{
 		userID:     session.UserID,
-		instanceID: session.InstanceID,
+		instanceID: instanceScope(ctx, session.InstanceID),
 		manager:    cache.GetManager(),
 	}
 }
 
 // Get retrieves a cached value for a tool
 func (h *CacheHelper) Get(toolName, cacheKey string) (interface{}, bool) {
-	return h.manager.Get(h.userID, h.instanceID, toolName, cacheKey)
+	value, ok := h.manager.Get(h.userID, h.instanceID, toolName, cacheKey)
+	if m := toolMetrics.Load(); m != nil && h.manager.IsEnabled() {
+		m.RecordCacheLookup(toolName, ok)
+	}
+	return value, ok
 }
 
 // Set stores a value in the cache for a tool
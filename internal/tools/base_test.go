@@ -1,8 +1,19 @@
 package tools
 
 import (
+	"context"
 	"sort"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+	"github.com/tareqmamari/cloud-logs-mcp/internal/metrics"
 )
 
 func TestGetStringParam(t *testing.T) {
@@ -687,3 +698,325 @@ func BenchmarkAnalyzeSeverityDistribution(b *testing.B) {
 		analyzeSeverityDistribution(events)
 	}
 }
+
+func TestBaseTool_ValidateDryRun(t *testing.T) {
+	tool := NewBaseTool(nil, nil)
+	spec := DryRunSpec{
+		ResourceType:   "Widget",
+		RequiredFields: []string{"name", "type"},
+		EnumFields:     map[string][]string{"type": {"a", "b"}},
+		SummaryFields:  []string{"name"},
+	}
+
+	t.Run("valid config", func(t *testing.T) {
+		result := tool.ValidateDryRun(map[string]interface{}{"name": "w", "type": "a"}, spec)
+		if !result.Valid {
+			t.Errorf("Expected valid result, got errors: %v", result.Errors)
+		}
+		if result.Summary["name"] != "w" {
+			t.Errorf("Summary name = %v, want w", result.Summary["name"])
+		}
+	})
+
+	t.Run("missing and invalid fields", func(t *testing.T) {
+		result := tool.ValidateDryRun(map[string]interface{}{"type": "c"}, spec)
+		if result.Valid {
+			t.Error("Expected invalid result")
+		}
+		if len(result.Errors) != 2 {
+			t.Errorf("Expected 2 errors (missing name, invalid type), got %v", result.Errors)
+		}
+	})
+
+	t.Run("format adds suggestions and default risk", func(t *testing.T) {
+		result := tool.ValidateDryRun(map[string]interface{}{"name": "w", "type": "b"}, spec)
+		out := tool.FormatDryRun(result, spec, map[string]interface{}{"name": "w"})
+		if out == nil || out.IsError {
+			t.Fatal("Expected non-error dry-run result")
+		}
+		if result.EstimatedImpact == nil || result.EstimatedImpact.RiskLevel != "low" {
+			t.Errorf("Expected default low risk level, got %+v", result.EstimatedImpact)
+		}
+		if len(result.Suggestions) != 2 {
+			t.Errorf("Expected 2 suggestions, got %v", result.Suggestions)
+		}
+	})
+}
+
+func TestCreateTools_DryRunMakesNoRequest(t *testing.T) {
+	mock := client.NewMockClient()
+	ctx := testCtx(mock)
+
+	cases := []struct {
+		tool Tool
+		args map[string]interface{}
+	}{
+		{NewCreateOutgoingWebhookTool(mock, nil), map[string]interface{}{"webhook": map[string]interface{}{"name": "w", "type": "slack", "url": "https://x"}}},
+		{NewCreateE2MTool(mock, nil), map[string]interface{}{"e2m": map[string]interface{}{"name": "m", "type": "logs2metrics"}}},
+		{NewCreateDataAccessRuleTool(mock, nil), map[string]interface{}{"rule": map[string]interface{}{"display_name": "r"}}},
+		{NewCreateEnrichmentTool(mock, nil), map[string]interface{}{"enrichment": map[string]interface{}{"field_name": "ip", "enrichment_type": "geo_ip"}}},
+		{NewCreateViewTool(mock, nil), map[string]interface{}{"view": map[string]interface{}{"name": "v"}}},
+		{NewCreateStreamTool(mock, nil), map[string]interface{}{"name": "s", "dpxl_expression": "<v1>true"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.tool.Name(), func(t *testing.T) {
+			tc.args["dry_run"] = true
+			result, err := tc.tool.Execute(ctx, tc.args)
+			if err != nil {
+				t.Fatalf("Execute error: %v", err)
+			}
+			text := result.Content[0].(*mcp.TextContent).Text
+			if !strings.Contains(text, "Dry-Run Validation Result") {
+				t.Errorf("Expected dry-run output, got: %s", text)
+			}
+			if !strings.Contains(text, "Valid - configuration is ready") {
+				t.Errorf("Expected valid configuration, got: %s", text)
+			}
+		})
+	}
+
+	if mock.RequestCount() != 0 {
+		t.Errorf("Dry runs must not call the API, got %d requests", mock.RequestCount())
+	}
+}
+
+func TestCreateEnrichmentTool_ConfigConsistency(t *testing.T) {
+	cases := []struct {
+		name       string
+		enrichment map[string]interface{}
+		wantErrors []string
+	}{
+		{
+			name:       "geo_ip without field_name",
+			enrichment: map[string]interface{}{"enrichment_type": "geo_ip"},
+			wantErrors: []string{"field_name: required for geo_ip"},
+		},
+		{
+			name: "geo_ip with custom config",
+			enrichment: map[string]interface{}{
+				"enrichment_type":          "geo_ip",
+				"field_name":               "json.client_ip",
+				"custom_enrichment_config": map[string]interface{}{"lookup_table_id": "t1"},
+			},
+			wantErrors: []string{"custom_enrichment_config: not allowed for geo_ip"},
+		},
+		{
+			name: "custom_enrichment without lookup table",
+			enrichment: map[string]interface{}{
+				"enrichment_type":          "custom_enrichment",
+				"field_name":               "json.customer_id",
+				"custom_enrichment_config": map[string]interface{}{},
+				"geo_ip_config":            map[string]interface{}{},
+			},
+			wantErrors: []string{"custom_enrichment_config.lookup_table_id: required", "geo_ip_config: not allowed"},
+		},
+		{
+			name: "valid custom_enrichment",
+			enrichment: map[string]interface{}{
+				"enrichment_type":          "custom_enrichment",
+				"field_name":               "json.customer_id",
+				"custom_enrichment_config": map[string]interface{}{"lookup_table_id": "t1"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := enrichmentConfigErrors(tc.enrichment)
+			if len(errs) != len(tc.wantErrors) {
+				t.Fatalf("got errors %v, want %v", errs, tc.wantErrors)
+			}
+			for i, want := range tc.wantErrors {
+				if !strings.HasPrefix(errs[i], want) {
+					t.Errorf("error %d = %q, want prefix %q", i, errs[i], want)
+				}
+			}
+		})
+	}
+
+	t.Run("invalid config is not sent", func(t *testing.T) {
+		mock := client.NewMockClient()
+		result, err := NewCreateEnrichmentTool(mock, nil).Execute(testCtx(mock), map[string]interface{}{
+			"enrichment": map[string]interface{}{"enrichment_type": "custom_enrichment", "field_name": "json.customer_id"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		text := result.Content[0].(*mcp.TextContent).Text
+		if !result.IsError || !strings.Contains(text, "custom_enrichment_config: required") {
+			t.Errorf("expected a field-level error, got: %s", text)
+		}
+		if mock.RequestCount() != 0 {
+			t.Errorf("invalid enrichment must not call the API, got %d requests", mock.RequestCount())
+		}
+	})
+
+	t.Run("dry run reports config errors", func(t *testing.T) {
+		mock := client.NewMockClient()
+		result, err := NewCreateEnrichmentTool(mock, nil).Execute(testCtx(mock), map[string]interface{}{
+			"enrichment": map[string]interface{}{"enrichment_type": "geo_ip", "field_name": "json.ip", "custom_enrichment_config": map[string]interface{}{}},
+			"dry_run":    true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		text := result.Content[0].(*mcp.TextContent).Text
+		if strings.Contains(text, "Valid - configuration is ready") || !strings.Contains(text, "custom_enrichment_config: not allowed for geo_ip") {
+			t.Errorf("expected an invalid dry run, got: %s", text)
+		}
+	})
+}
+
+// slowMock returns a mock client that takes delay per request, fails paths containing "fail",
+// and tracks the peak number of requests in flight
+func slowMock(delay time.Duration, peak *int32) *client.MockClient {
+	var inFlight int32
+	mock := client.NewMockClient()
+	mock.DoFunc = func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			p := atomic.LoadInt32(peak)
+			if n <= p || atomic.CompareAndSwapInt32(peak, p, n) {
+				break
+			}
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if strings.Contains(req.Path, "fail") {
+			return &client.Response{StatusCode: 500, Body: []byte(`{"message":"boom"}`)}, nil
+		}
+		return &client.Response{StatusCode: 200, Body: []byte(`{"path":"` + req.Path + `"}`)}, nil
+	}
+	return mock
+}
+
+func TestBaseTool_ExecuteConcurrent(t *testing.T) {
+	t.Run("preserves order, collects errors, and caps concurrency", func(t *testing.T) {
+		var peak int32
+		tool := NewBaseTool(slowMock(10*time.Millisecond, &peak), zap.NewNop())
+		paths := []string{"/v1/a", "/v1/fail", "/v1/b", "/v1/c", "/v1/d", "/v1/e"}
+		reqs := make([]*client.Request, len(paths))
+		for i, p := range paths {
+			reqs[i] = &client.Request{Method: "GET", Path: p}
+		}
+
+		results := tool.ExecuteConcurrent(context.Background(), reqs, 2)
+		if len(results) != len(paths) {
+			t.Fatalf("got %d results, want %d", len(results), len(paths))
+		}
+		for i, p := range paths {
+			if p == "/v1/fail" {
+				if results[i].Err == nil {
+					t.Errorf("expected error for %s", p)
+				}
+				continue
+			}
+			if results[i].Err != nil || results[i].Result["path"] != p {
+				t.Errorf("result %d = %v (err %v), want path %s", i, results[i].Result, results[i].Err, p)
+			}
+		}
+		if peak > 2 {
+			t.Errorf("peak in-flight requests = %d, want <= 2", peak)
+		}
+	})
+
+	t.Run("cancelled context fails pending requests", func(t *testing.T) {
+		var peak int32
+		tool := NewBaseTool(slowMock(time.Second, &peak), zap.NewNop())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		results := tool.ExecuteConcurrent(ctx, []*client.Request{{Method: "GET", Path: "/v1/a"}, {Method: "GET", Path: "/v1/b"}}, 1)
+		for i, r := range results {
+			if r.Err == nil {
+				t.Errorf("result %d: expected error for cancelled context", i)
+			}
+		}
+	})
+}
+
+func TestExecuteRequest_RecordsAPIMetrics(t *testing.T) {
+	m := metrics.NewWithRegistry(zap.NewNop(), prometheus.NewRegistry())
+	SetMetrics(m)
+	defer SetMetrics(nil)
+
+	mock := client.NewMockClient()
+	bt := NewBaseTool(mock, zap.NewNop())
+	req := &client.Request{Method: "GET", Path: "/v1/alerts"}
+
+	mock.RespondWith(200, map[string]interface{}{"alerts": []interface{}{}})
+	if _, err := bt.ExecuteRequest(testCtx(mock), req); err != nil {
+		t.Fatalf("ExecuteRequest failed: %v", err)
+	}
+	mock.RespondWith(503, map[string]interface{}{"message": "unavailable"})
+	_, _ = bt.ExecuteRequest(testCtx(mock), req)
+
+	stats := m.GetStats()
+	if stats.TotalRequests != 2 || stats.FailedRequests != 1 {
+		t.Errorf("requests total/failed = %d/%d, want 2/1", stats.TotalRequests, stats.FailedRequests)
+	}
+	if stats.ErrorsByStatus[503] != 1 {
+		t.Errorf("ErrorsByStatus[503] = %d, want 1", stats.ErrorsByStatus[503])
+	}
+}
+
+// throttledMockClient is a MockClient that reports a fixed rate limit state
+type throttledMockClient struct {
+	*client.MockClient
+	info client.RateLimitInfo
+}
+
+func (c *throttledMockClient) GetRateLimitInfo() client.RateLimitInfo { return c.info }
+
+func TestExecuteWithTimeout_RateLimitMeta(t *testing.T) {
+	tests := []struct {
+		name       string
+		available  float64
+		wantStatus string
+	}{
+		{name: "healthy bucket adds nothing", available: 15},
+		{name: "low bucket", available: 4, wantStatus: "low"},
+		{name: "empty bucket", available: 0, wantStatus: "critical"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &throttledMockClient{
+				MockClient: client.NewMockClient(),
+				info:       client.RateLimitInfo{Limit: 100, Burst: 20, Available: tt.available, Enabled: true},
+			}
+			mock.RespondWith(200, map[string]interface{}{"alerts": []interface{}{}})
+			tool := NewListAlertsTool(mock, zap.NewNop())
+
+			apiResult, err := tool.ExecuteRequest(context.Background(), &client.Request{Method: "GET", Path: "/v1/alerts"})
+			if err != nil {
+				t.Fatalf("ExecuteRequest failed: %v", err)
+			}
+			if _, ok := apiResult["_rate_limit"]; ok {
+				t.Error("rate limit state must not be added to API results, update tools send them back")
+			}
+
+			result, err := ExecuteWithTimeout(WithClient(context.Background(), mock), tool, map[string]interface{}{})
+			if err != nil {
+				t.Fatalf("ExecuteWithTimeout failed: %v", err)
+			}
+			rl, ok := result.Meta["rate_limit"].(map[string]interface{})
+			if tt.wantStatus == "" {
+				if ok {
+					t.Errorf("expected no rate_limit, got %v", rl)
+				}
+				return
+			}
+			if !ok {
+				t.Fatal("expected rate_limit in _meta")
+			}
+			if rl["status"] != tt.wantStatus || rl["available"] != int(tt.available) || rl["burst"] != 20 {
+				t.Errorf("rate_limit = %v, want status %s", rl, tt.wantStatus)
+			}
+		})
+	}
+}
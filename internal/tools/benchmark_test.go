@@ -1,12 +1,16 @@
 package tools
 
 import (
+	"context"
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
 )
 
 // testLogger creates a no-op logger for tests
@@ -48,6 +52,27 @@ func BenchmarkParseSSEResponseLarge(b *testing.B) {
 	}
 }
 
+// benchmarkFanOut issues ten 5ms requests, the shape of a deep health check, with the given concurrency
+func benchmarkFanOut(b *testing.B, maxConcurrency int) {
+	var peak int32
+	tool := NewBaseTool(slowMock(5*time.Millisecond, &peak), testLogger())
+	reqs := make([]*client.Request, 10)
+	for i := range reqs {
+		reqs[i] = &client.Request{Method: "POST", Path: "/v1/query"}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = tool.ExecuteConcurrent(context.Background(), reqs, maxConcurrency)
+	}
+}
+
+// BenchmarkExecuteConcurrent_Sequential is the one-at-a-time baseline (~50ms per op)
+func BenchmarkExecuteConcurrent_Sequential(b *testing.B) { benchmarkFanOut(b, 1) }
+
+// BenchmarkExecuteConcurrent_Parallel runs all requests at once (~5ms per op)
+func BenchmarkExecuteConcurrent_Parallel(b *testing.B) { benchmarkFanOut(b, 10) }
+
 // BenchmarkAnalyzeQueryResults benchmarks query result analysis
 func BenchmarkAnalyzeQueryResults(b *testing.B) {
 	result := generateTestEvents(200)
@@ -197,6 +222,34 @@ func TestLargeResultTruncation(t *testing.T) {
 	}
 }
 
+func TestLargeResultTruncation_ConfiguredLimits(t *testing.T) {
+	SetResponseLimits(20*1024, 30*1024)
+	defer SetResponseLimits(0, 0)
+
+	bt := &BaseTool{logger: testLogger()}
+	result, err := bt.FormatResponse(generateTestEvents(500))
+	if err != nil {
+		t.Fatalf("FormatResponse(large) returned error: %v", err)
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if len(text) > 30*1024 {
+		t.Errorf("Result exceeds configured final limit: %d > %d", len(text), 30*1024)
+	}
+	if !strings.Contains(text, "limit is 20480 bytes") {
+		t.Error("Truncation warning should report the configured limit")
+	}
+
+	SetResponseLimits(0, 0)
+	if currentMaxResultSize() != MaxResultSize || currentFinalResponseLimit() != FinalResponseLimit {
+		t.Error("SetResponseLimits(0, 0) should restore the defaults")
+	}
+
+	SetResponseLimits(200*1024, 50*1024)
+	if currentFinalResponseLimit() != 200*1024 {
+		t.Errorf("Final limit should be raised to the result size, got %d", currentFinalResponseLimit())
+	}
+}
+
 func TestSSEParsingEdgeCases(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -378,7 +431,7 @@ func TestGetQueryTemplates(t *testing.T) {
 	// Check categories are valid
 	validCategories := map[string]bool{
 		"discovery": true, "error": true, "performance": true, "security": true,
-		"health": true, "usage": true, "audit": true,
+		"health": true, "usage": true, "audit": true, "kubernetes": true,
 	}
 	for _, tmpl := range templates {
 		if !validCategories[tmpl.Category] {
@@ -511,7 +511,7 @@ func budgetGenerateInsights(data interface{}) *BudgetResultInsights {
 	}
 
 	// Extract time range
-	timeRange := extractTimeRange(events)
+	timeRange := extractTimeRange(events, currentTimeDisplay())
 	if timeRange != "" {
 		parts := strings.Split(timeRange, "\n")
 		if len(parts) >= 2 {
@@ -578,11 +578,11 @@ func budgetDetectAnomalies(events []interface{}) []string {
 	criticalCount := 0
 	for _, event := range events {
 		if eventMap, ok := event.(map[string]interface{}); ok {
-			if sev, ok := eventMap["severity"].(float64); ok {
-				if sev >= 5 {
+			if sev, ok := eventSeverity(eventMap); ok {
+				if sev >= LogSeverityError {
 					errorCount++
 				}
-				if sev >= 6 {
+				if sev >= LogSeverityCritical {
 					criticalCount++
 				}
 			}
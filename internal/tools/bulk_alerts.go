@@ -0,0 +1,238 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// maxBulkAlerts caps the number of alert specs a single bulk_create_alerts call accepts
+const maxBulkAlerts = 50
+
+// BulkAlertResult is the outcome of one alert spec in a bulk_create_alerts call
+type BulkAlertResult struct {
+	Index    int      `json:"index"`
+	Name     string   `json:"name,omitempty"`
+	Status   string   `json:"status"` // created, failed, skipped, valid or invalid
+	ID       string   `json:"id,omitempty"`
+	Errors   []string `json:"errors,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// BulkCreateAlertsTool creates several alerts from a list of specs
+type BulkCreateAlertsTool struct{ *BaseTool }
+
+// NewBulkCreateAlertsTool creates a new tool instance
+func NewBulkCreateAlertsTool(c client.Doer, l *zap.Logger) *BulkCreateAlertsTool {
+	return &BulkCreateAlertsTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *BulkCreateAlertsTool) Name() string { return "bulk_create_alerts" }
+
+// Annotations returns tool hints for LLMs
+func (t *BulkCreateAlertsTool) Annotations() *mcp.ToolAnnotations {
+	return CreateAnnotations("Bulk Create Alerts")
+}
+
+// Description returns the tool description
+func (t *BulkCreateAlertsTool) Description() string {
+	return fmt.Sprintf(`Create up to %d alerts in one call, e.g. when onboarding a new service.
+
+Each spec takes the same shape as create_alert's alert object and is validated the same way.
+Specs are created in order and each gets its own result: the created ID, or the errors that stopped it.
+A bad spec does not abort the rest unless stop_on_error is set. Specs similar to an existing alert,
+or to an earlier spec in the same call, fail as duplicates unless force is set.
+
+Use dry_run to validate every spec without creating any.
+
+**Related tools:** create_alert, validate_alert_condition, list_alerts, list_alert_definitions`, maxBulkAlerts)
+}
+
+// InputSchema returns the input schema
+func (t *BulkCreateAlertsTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"alerts": map[string]interface{}{
+				"type":        "array",
+				"description": "Alert specs, each in the same shape as create_alert's alert object",
+				"items":       map[string]interface{}{"type": "object"},
+				"minItems":    1,
+				"maxItems":    maxBulkAlerts,
+			},
+			"dry_run": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Validate every spec without creating any alerts",
+				"default":     false,
+			},
+			"stop_on_error": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Stop at the first spec that fails; the remaining specs are reported as skipped",
+				"default":     false,
+			},
+			"force": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Create alerts even if a similar existing alert is found",
+				"default":     false,
+			},
+		},
+		"required": []string{"alerts"},
+		"examples": []interface{}{
+			map[string]interface{}{
+				"alerts": []interface{}{
+					map[string]interface{}{
+						"name":                  "checkout - error spike",
+						"severity":              "error",
+						"alert_definition_id":   "alert-def-uuid-here",
+						"notification_group_id": "notification-group-uuid-here",
+					},
+					map[string]interface{}{
+						"name":     "checkout - new error code",
+						"severity": "warning",
+						"condition": map[string]interface{}{"new_value": map[string]interface{}{"parameters": map[string]interface{}{
+							"timeframe": "timeframe_24_h", "group_by": []string{"json.error_code"}}}},
+						"notification_group_id": "notification-group-uuid-here",
+					},
+				},
+				"dry_run": true,
+			},
+		},
+	}
+}
+
+// Execute executes the tool
+func (t *BulkCreateAlertsTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	session := GetSessionFromContext(ctx)
+	cacheHelper := GetCacheHelperFromContext(ctx)
+
+	specs, ok := args["alerts"].([]interface{})
+	if !ok || len(specs) == 0 {
+		return NewToolResultError("alerts must be a non-empty array of alert specs"), nil
+	}
+	if len(specs) > maxBulkAlerts {
+		return NewToolResultErrorWithSuggestion(
+			fmt.Sprintf("Too many alert specs: %d (maximum %d per call)", len(specs), maxBulkAlerts),
+			"Split the specs into several bulk_create_alerts calls"), nil
+	}
+	dryRun, _ := GetBoolParam(args, "dry_run", false)
+	stopOnError, _ := GetBoolParam(args, "stop_on_error", false)
+	force, _ := GetBoolParam(args, "force", false)
+
+	// Existing alerts are listed once (the first page only, as in create_alert); specs created
+	// in this call are added so that duplicates within the batch are caught too
+	var existing []interface{}
+	if !dryRun && !force {
+		res, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/alerts"})
+		if err != nil {
+			t.logger.Warn("Skipping duplicate alert check, failed to list alerts", zap.Error(err))
+		} else {
+			existing, _ = res["alerts"].([]interface{})
+		}
+	}
+
+	results := make([]BulkAlertResult, 0, len(specs))
+	counts := map[string]int{}
+	stopped := false
+	for i, raw := range specs {
+		result := BulkAlertResult{Index: i}
+		alert, ok := raw.(map[string]interface{})
+		if ok {
+			result.Name, _ = alert["name"].(string)
+		}
+
+		switch {
+		case stopped:
+			result.Status = "skipped"
+		case !ok:
+			result.Status = "failed"
+			result.Errors = []string{"alert spec must be an object"}
+		default:
+			t.processSpec(ctx, alert, dryRun, force, &existing, &result)
+		}
+
+		counts[result.Status]++
+		if result.Status == "failed" && stopOnError && !dryRun {
+			stopped = true
+		}
+		results = append(results, result)
+	}
+
+	if counts["created"] > 0 {
+		cacheHelper.InvalidateRelated(t.Name())
+	}
+	if !dryRun {
+		session.RecordToolUse(t.Name(), counts["failed"] == 0, map[string]interface{}{
+			"created": counts["created"],
+			"failed":  counts["failed"],
+		})
+	}
+
+	response := map[string]interface{}{
+		"dry_run": dryRun,
+		"total":   len(specs),
+		"results": results,
+	}
+	if dryRun {
+		response["valid"] = counts["valid"]
+		response["invalid"] = counts["invalid"] + counts["failed"]
+	} else {
+		response["created"] = counts["created"]
+		response["failed"] = counts["failed"]
+		response["skipped"] = counts["skipped"]
+	}
+	if dryRun {
+		return MarkDryRun(t.FormatResponseWithSuggestions(response, "bulk_create_alerts"))
+	}
+	return t.FormatResponseWithSuggestions(response, "bulk_create_alerts")
+}
+
+// processSpec validates one alert spec and, outside a dry run, creates it, recording the outcome
+func (t *BulkCreateAlertsTool) processSpec(ctx context.Context, alert map[string]interface{}, dryRun, force bool, existing *[]interface{}, result *BulkAlertResult) {
+	validation := checkAlertSpec(alert)
+	result.Warnings = validation.Warnings
+	if dryRun {
+		result.Status = "valid"
+		if !validation.Valid {
+			result.Status = "invalid"
+			result.Errors = validation.Errors
+		}
+		return
+	}
+	if !validation.Valid {
+		result.Status = "failed"
+		result.Errors = validation.Errors
+		return
+	}
+	if !force {
+		if match, reason := findDuplicateAlert(alert, *existing); match != nil {
+			id, _ := match["id"].(string)
+			name, _ := match["name"].(string)
+			result.Status = "failed"
+			result.Errors = []string{fmt.Sprintf("A similar alert already exists: %s (ID: %s) - %s; set force: true to create it anyway", name, id, reason)}
+			return
+		}
+	}
+
+	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "POST", Path: "/v1/alerts", Body: alert})
+	if err != nil {
+		result.Status = "failed"
+		result.Errors = []string{strings.TrimSpace(err.Error())}
+		return
+	}
+	result.Status = "created"
+	if id, ok := res["id"]; ok && id != nil {
+		result.ID = fmt.Sprint(id)
+	}
+	created := make(map[string]interface{}, len(alert)+1)
+	for k, v := range alert {
+		created[k] = v
+	}
+	created["id"] = result.ID
+	*existing = append(*existing, created)
+}
@@ -0,0 +1,158 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// bulkAlertSpec returns a valid alert spec for bulk_create_alerts tests
+func bulkAlertSpec(name string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                  name,
+		"severity":              "error",
+		"alert_definition_id":   "def-1",
+		"notification_group_id": "ng-1",
+	}
+}
+
+// bulkAlertsMock lists the given alerts, assigns IDs to created alerts and rejects names in failNames
+func bulkAlertsMock(existing []interface{}, failNames ...string) *client.MockClient {
+	mock := client.NewMockClient()
+	created := 0
+	mock.DoFunc = func(_ context.Context, req *client.Request) (*client.Response, error) {
+		var body interface{}
+		status := http.StatusOK
+		if req.Method == "GET" {
+			body = map[string]interface{}{"alerts": existing}
+		} else {
+			name := req.Body.(map[string]interface{})["name"]
+			for _, fail := range failNames {
+				if name == fail {
+					status = http.StatusBadRequest
+				}
+			}
+			created++
+			body = map[string]interface{}{"id": fmt.Sprintf("alert-%d", created), "name": name}
+		}
+		data, _ := json.Marshal(body)
+		return &client.Response{StatusCode: status, Body: data}, nil
+	}
+	return mock
+}
+
+// bulkResponse decodes a bulk_create_alerts result
+func bulkResponse(t *testing.T, result *mcp.CallToolResult) (map[string]interface{}, []BulkAlertResult) {
+	t.Helper()
+	if result.IsError {
+		t.Fatalf("unexpected error: %+v", result.Content)
+	}
+	// The JSON body is followed by suggested next steps
+	var response map[string]interface{}
+	if err := json.NewDecoder(strings.NewReader(result.Content[0].(*mcp.TextContent).Text)).Decode(&response); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(response["results"])
+	var results []BulkAlertResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		t.Fatal(err)
+	}
+	return response, results
+}
+
+func TestBulkCreateAlertsTool_ContinuesPastErrors(t *testing.T) {
+	mock := bulkAlertsMock([]interface{}{map[string]interface{}{"id": "old-1", "name": "payments - latency"}}, "rejected by api")
+	result, err := NewBulkCreateAlertsTool(mock, zap.NewNop()).Execute(testCtx(mock), map[string]interface{}{
+		"alerts": []interface{}{
+			bulkAlertSpec("checkout - errors"),
+			map[string]interface{}{"severity": "error"},
+			bulkAlertSpec("rejected by api"),
+			bulkAlertSpec("payments - latency"),
+			bulkAlertSpec("checkout - errors"),
+			bulkAlertSpec("search - errors"),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	response, results := bulkResponse(t, result)
+
+	want := []string{"created", "failed", "failed", "failed", "failed", "created"}
+	for i, status := range want {
+		if results[i].Status != status {
+			t.Errorf("results[%d].Status = %q, want %q (%+v)", i, results[i].Status, status, results[i])
+		}
+	}
+	if results[0].ID != "alert-1" || len(results[1].Errors) == 0 {
+		t.Errorf("unexpected results: %+v", results)
+	}
+	if response["created"] != 2.0 || response["failed"] != 4.0 {
+		t.Errorf("unexpected counts: %v", response)
+	}
+	// One list, then a POST for each valid non-duplicate spec
+	if mock.RequestCount() != 4 {
+		t.Errorf("RequestCount = %d, want 4", mock.RequestCount())
+	}
+}
+
+func TestBulkCreateAlertsTool_StopOnError(t *testing.T) {
+	mock := bulkAlertsMock(nil)
+	result, err := NewBulkCreateAlertsTool(mock, zap.NewNop()).Execute(testCtx(mock), map[string]interface{}{
+		"alerts":        []interface{}{bulkAlertSpec("first"), map[string]interface{}{}, bulkAlertSpec("third")},
+		"stop_on_error": true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	response, results := bulkResponse(t, result)
+	if results[0].Status != "created" || results[1].Status != "failed" || results[2].Status != "skipped" {
+		t.Errorf("unexpected results: %+v", results)
+	}
+	if response["skipped"] != 1.0 {
+		t.Errorf("unexpected counts: %v", response)
+	}
+}
+
+func TestBulkCreateAlertsTool_DryRun(t *testing.T) {
+	mock := client.NewMockClient()
+	result, err := NewBulkCreateAlertsTool(mock, zap.NewNop()).Execute(testCtx(mock), map[string]interface{}{
+		"alerts":  []interface{}{bulkAlertSpec("ok"), map[string]interface{}{"name": "no condition"}},
+		"dry_run": true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	response, results := bulkResponse(t, result)
+	if results[0].Status != "valid" || results[1].Status != "invalid" || len(results[1].Errors) == 0 {
+		t.Errorf("unexpected results: %+v", results)
+	}
+	if response["valid"] != 1.0 || response["invalid"] != 1.0 {
+		t.Errorf("unexpected counts: %v", response)
+	}
+	if mock.RequestCount() != 0 {
+		t.Errorf("dry run should not call the API, got %d requests", mock.RequestCount())
+	}
+}
+
+func TestBulkCreateAlertsTool_RejectsOversizedBatch(t *testing.T) {
+	specs := make([]interface{}, maxBulkAlerts+1)
+	for i := range specs {
+		specs[i] = bulkAlertSpec(fmt.Sprintf("alert %d", i))
+	}
+	mock := client.NewMockClient()
+	result, err := NewBulkCreateAlertsTool(mock, zap.NewNop()).Execute(testCtx(mock), map[string]interface{}{"alerts": specs})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.IsError || mock.RequestCount() != 0 {
+		t.Errorf("expected an error without requests, got %+v", result.Content)
+	}
+}
@@ -0,0 +1,121 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements time decay and surfacing thresholds for learned tool chains.
+package tools
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultChainDecayHalfLife is how long it takes an unused learned sequence to lose half its weight
+	DefaultChainDecayHalfLife = 7 * 24 * time.Hour
+
+	// DefaultChainMinObservations is how many times a sequence must be seen before it is suggested
+	DefaultChainMinObservations = 2
+
+	// MaxLearnedSequences caps how many tool sequences a session remembers
+	MaxLearnedSequences = 20
+
+	// staleSequenceWeight is the decayed weight below which a sequence is forgotten
+	staleSequenceWeight = 0.1
+)
+
+// chainLearning holds the decay and surfacing settings from configuration
+var (
+	chainLearningMu      sync.RWMutex
+	chainDecayHalfLife   = DefaultChainDecayHalfLife
+	chainMinObservations = DefaultChainMinObservations
+)
+
+// SetChainLearning configures learned chain decay. A zero halfLife restores the default and a
+// negative one disables decay; a non-positive minObservations restores the default.
+func SetChainLearning(halfLife time.Duration, minObservations int) {
+	if halfLife == 0 {
+		halfLife = DefaultChainDecayHalfLife
+	}
+	if minObservations <= 0 {
+		minObservations = DefaultChainMinObservations
+	}
+	chainLearningMu.Lock()
+	chainDecayHalfLife = halfLife
+	chainMinObservations = minObservations
+	chainLearningMu.Unlock()
+}
+
+// chainLearningSettings returns the configured decay half-life and minimum observations
+func chainLearningSettings() (time.Duration, int) {
+	chainLearningMu.RLock()
+	defer chainLearningMu.RUnlock()
+	return chainDecayHalfLife, chainMinObservations
+}
+
+// decayFactor returns the fraction of weight left after age with the given half-life.
+// Decay is disabled when halfLife is not positive.
+func decayFactor(age, halfLife time.Duration) float64 {
+	if halfLife <= 0 || age <= 0 {
+		return 1
+	}
+	return math.Pow(0.5, float64(age)/float64(halfLife))
+}
+
+// DecayedWeight returns the sequence's observation weight at now. Sequences saved before
+// weights were tracked fall back to their raw count.
+func (p PatternSequence) DecayedWeight(now time.Time, halfLife time.Duration) float64 {
+	weight := p.Weight
+	if weight == 0 {
+		weight = float64(p.Count)
+	}
+	return weight * decayFactor(now.Sub(p.LastUsed), halfLife)
+}
+
+// GetLearnedSequences returns copies of the learned sequences that have been observed at least
+// minObservations times, with Weight set to the decayed weight at now, heaviest first
+func (s *SessionContext) GetLearnedSequences(now time.Time) []PatternSequence {
+	halfLife, minObservations := chainLearningSettings()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.LearnedPatterns == nil {
+		return nil
+	}
+
+	var seqs []PatternSequence
+	for _, seq := range s.LearnedPatterns.FrequentSequences {
+		if seq.Count < minObservations {
+			continue
+		}
+		weight := seq.DecayedWeight(now, halfLife)
+		if weight < staleSequenceWeight {
+			continue
+		}
+		seq.Tools = append([]string(nil), seq.Tools...)
+		seq.Weight = weight
+		seqs = append(seqs, seq)
+	}
+	sort.SliceStable(seqs, func(i, j int) bool { return seqs[i].Weight > seqs[j].Weight })
+	return seqs
+}
+
+// pruneLearnedSequences drops sequences that have decayed to nothing and keeps the
+// MaxLearnedSequences heaviest. Caller must hold s.mu.
+func (s *SessionContext) pruneLearnedSequences(now time.Time) {
+	halfLife, _ := chainLearningSettings()
+	seqs := s.LearnedPatterns.FrequentSequences
+
+	kept := seqs[:0]
+	for _, seq := range seqs {
+		if seq.DecayedWeight(now, halfLife) >= staleSequenceWeight {
+			kept = append(kept, seq)
+		}
+	}
+	if len(kept) > MaxLearnedSequences {
+		sort.SliceStable(kept, func(i, j int) bool {
+			return kept[i].DecayedWeight(now, halfLife) > kept[j].DecayedWeight(now, halfLife)
+		})
+		kept = kept[:MaxLearnedSequences]
+	}
+	s.LearnedPatterns.FrequentSequences = kept
+}
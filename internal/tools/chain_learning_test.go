@@ -0,0 +1,91 @@
+package tools
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDecayedWeight(t *testing.T) {
+	now := time.Now()
+	seq := PatternSequence{Count: 8, Weight: 8, LastUsed: now.Add(-14 * 24 * time.Hour)}
+
+	if got := seq.DecayedWeight(now, 7*24*time.Hour); got < 1.99 || got > 2.01 {
+		t.Errorf("Expected two half-lives to leave weight 2, got %f", got)
+	}
+	if got := seq.DecayedWeight(now, -1); got != 8 {
+		t.Errorf("Expected negative half-life to disable decay, got %f", got)
+	}
+
+	// Sequences saved before weights were tracked fall back to their count
+	legacy := PatternSequence{Count: 4, LastUsed: now}
+	if got := legacy.DecayedWeight(now, DefaultChainDecayHalfLife); got != 4 {
+		t.Errorf("Expected legacy weight to equal count, got %f", got)
+	}
+}
+
+func TestGetLearnedSequencesThresholdAndDecay(t *testing.T) {
+	SetChainLearning(24*time.Hour, 3)
+	defer SetChainLearning(0, 0)
+
+	now := time.Now()
+	session := NewSessionContext("chain-user", "chain-instance")
+	session.LearnedPatterns.FrequentSequences = []PatternSequence{
+		{Tools: []string{"query_logs", "create_alert"}, Count: 2, Weight: 2, SuccessRate: 100, LastUsed: now},
+		{Tools: []string{"list_alerts", "get_alert"}, Count: 10, Weight: 10, SuccessRate: 100, LastUsed: now.Add(-48 * time.Hour)},
+		{Tools: []string{"query_logs", "investigate_incident"}, Count: 4, Weight: 4, SuccessRate: 100, LastUsed: now},
+		{Tools: []string{"list_views", "get_view"}, Count: 50, Weight: 50, SuccessRate: 100, LastUsed: now.Add(-30 * 24 * time.Hour)},
+	}
+
+	seqs := session.GetLearnedSequences(now)
+	if len(seqs) != 2 {
+		t.Fatalf("Expected 2 surfaced sequences (below-threshold and stale dropped), got %d: %+v", len(seqs), seqs)
+	}
+	if seqs[0].Tools[1] != "investigate_incident" {
+		t.Errorf("Expected recently used sequence to outrank the decayed one, got %v first", seqs[0].Tools)
+	}
+	if seqs[1].Weight < 2.49 || seqs[1].Weight > 2.51 {
+		t.Errorf("Expected decayed weight 2.5, got %f", seqs[1].Weight)
+	}
+}
+
+func TestRecordSequenceDecaysWeight(t *testing.T) {
+	SetChainLearning(time.Hour, 0)
+	defer SetChainLearning(0, 0)
+
+	session := NewSessionContext("chain-user", "chain-instance")
+	session.LearnedPatterns.FrequentSequences = []PatternSequence{
+		{Tools: []string{"query_logs", "create_alert"}, Count: 4, Weight: 4, SuccessRate: 100, LastUsed: time.Now().Add(-time.Hour)},
+	}
+
+	session.recordSequence([]string{"query_logs", "create_alert"}, true)
+
+	seq := session.LearnedPatterns.FrequentSequences[0]
+	if seq.Count != 5 {
+		t.Errorf("Expected count 5, got %d", seq.Count)
+	}
+	if seq.Weight < 2.99 || seq.Weight > 3.01 {
+		t.Errorf("Expected weight 4 decayed by one half-life plus one use (3), got %f", seq.Weight)
+	}
+}
+
+func TestAdaptiveChainsIgnoreStaleSequences(t *testing.T) {
+	defer SetChainLearning(0, 0)
+
+	registry := NewToolRegistry()
+	session := NewSessionContext("chain-user", "chain-instance")
+	session.LearnedPatterns.FrequentSequences = []PatternSequence{
+		{Tools: []string{"list_alerts", "create_alert"}, Count: 20, Weight: 20, SuccessRate: 100, LastUsed: time.Now().Add(-90 * 24 * time.Hour)},
+	}
+
+	for _, chain := range registry.generateAdaptiveChains(nil, session) {
+		if chain.Source == "learned" {
+			t.Errorf("Expected stale sequence not to be surfaced, got %+v", chain)
+		}
+	}
+
+	SetChainLearning(-1, 0)
+	chains := registry.generateAdaptiveChains(nil, session)
+	if len(chains) != 1 || chains[0].Source != "learned" || chains[0].Confidence != "high" {
+		t.Errorf("Expected the sequence as a high confidence chain with decay disabled, got %+v", chains)
+	}
+}
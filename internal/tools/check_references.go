@@ -0,0 +1,264 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements check_references, an audit for references to resources that no longer exist.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// maxReferenceCheckConcurrency bounds the collections listed at once
+const maxReferenceCheckConcurrency = 3
+
+// referenceCollection is a resource collection listed by check_references
+type referenceCollection struct {
+	Name     string
+	Path     string
+	ListKeys []string
+}
+
+// referenceCollections are listed once and shared by every check
+var referenceCollections = []referenceCollection{
+	{Name: "alerts", Path: "/v1/alerts", ListKeys: []string{"alerts"}},
+	{Name: "alert_definitions", Path: "/v1/alert_definitions", ListKeys: []string{"alert_definitions", "alert_defs"}},
+	{Name: "outgoing_webhooks", Path: "/v1/outgoing_webhooks", ListKeys: []string{"outgoing_webhooks", "webhooks"}},
+	{Name: "views", Path: "/v1/views", ListKeys: []string{"views"}},
+	{Name: "view_folders", Path: "/v1/view_folders", ListKeys: []string{"view_folders", "folders"}},
+	{Name: "dashboard_folders", Path: "/v1/folders", ListKeys: []string{"folders"}},
+}
+
+// referenceCheck finds references from one collection to items missing from another
+type referenceCheck struct {
+	Problem string   // Report group, e.g. "alert_missing_webhook"
+	From    string   // Referencing collection
+	To      string   // Referenced collection
+	RefKeys []string // Fields holding the reference, matched at any depth
+	// TargetKeys are the fields of a referenced item a reference may match (default: id)
+	TargetKeys []string
+}
+
+// referenceChecks are run in report order
+var referenceChecks = []referenceCheck{
+	{Problem: "alert_missing_webhook", From: "alerts", To: "outgoing_webhooks",
+		RefKeys: []string{"webhook_id", "integration_id", "outgoing_webhook_id"}, TargetKeys: []string{"id", "external_id"}},
+	{Problem: "alert_missing_definition", From: "alerts", To: "alert_definitions", RefKeys: []string{"alert_definition_id"}},
+	{Problem: "alert_definition_missing_webhook", From: "alert_definitions", To: "outgoing_webhooks",
+		RefKeys: []string{"webhook_id", "integration_id", "outgoing_webhook_id"}, TargetKeys: []string{"id", "external_id"}},
+	{Problem: "view_missing_folder", From: "views", To: "view_folders", RefKeys: []string{"folder_id"}},
+	{Problem: "dashboard_folder_missing_parent", From: "dashboard_folders", To: "dashboard_folders", RefKeys: []string{"parent_id"}},
+}
+
+// DanglingReference is a resource field pointing at an ID that does not exist
+type DanglingReference struct {
+	ResourceID   string `json:"resource_id"`
+	ResourceName string `json:"resource_name,omitempty"`
+	Field        string `json:"field"`
+	MissingID    string `json:"missing_id"`
+}
+
+// fieldReference is one reference value found in a resource
+type fieldReference struct {
+	Field string
+	Value string
+}
+
+// CheckReferencesTool audits the instance for references to deleted resources
+type CheckReferencesTool struct{ *BaseTool }
+
+// NewCheckReferencesTool creates a new tool instance
+func NewCheckReferencesTool(c client.Doer, l *zap.Logger) *CheckReferencesTool {
+	return &CheckReferencesTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *CheckReferencesTool) Name() string { return "check_references" }
+
+// Annotations returns tool hints for LLMs
+func (t *CheckReferencesTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Check References")
+}
+
+// DefaultTimeout returns the timeout
+func (t *CheckReferencesTool) DefaultTimeout() time.Duration {
+	return DefaultListTimeout
+}
+
+// Description returns the tool description
+func (t *CheckReferencesTool) Description() string {
+	return `Audit the instance for dangling references: configuration that points at resources which no longer exist.
+
+Checks alerts and alert definitions notifying through deleted outgoing webhooks, alerts built on
+deleted alert definitions, views in deleted view folders, and dashboard folders whose parent is
+gone. Returns the problems grouped by type with the offending resource IDs and the missing IDs.
+If a collection cannot be listed, the checks that need it are reported as skipped and the rest
+still run.
+
+**When to use:**
+- Periodic configuration health audits
+- After bulk deletes or migrations between instances
+
+**Related tools:** delete_outgoing_webhook, list_alerts, list_views, list_dashboard_folders`
+}
+
+// InputSchema returns the input schema
+func (t *CheckReferencesTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{},
+	}
+}
+
+// Execute executes the tool
+func (t *CheckReferencesTool) Execute(ctx context.Context, _ map[string]interface{}) (*mcp.CallToolResult, error) {
+	reqs := make([]*client.Request, len(referenceCollections))
+	for i, c := range referenceCollections {
+		reqs[i] = &client.Request{Method: "GET", Path: c.Path}
+	}
+	items := make(map[string][]map[string]interface{}, len(referenceCollections))
+	failed := make(map[string]error)
+	var firstErr error
+	for i, res := range t.ExecuteConcurrent(ctx, reqs, maxReferenceCheckConcurrency) {
+		name := referenceCollections[i].Name
+		if res.Err != nil {
+			failed[name] = res.Err
+			if firstErr == nil {
+				firstErr = res.Err
+			}
+			continue
+		}
+		for _, item := range listItems(res.Result, referenceCollections[i].ListKeys) {
+			if m, ok := item.(map[string]interface{}); ok {
+				items[name] = append(items[name], m)
+			}
+		}
+	}
+	if len(failed) == len(referenceCollections) {
+		return NewToolResultErrorFromErr(firstErr), nil
+	}
+
+	problems := make(map[string][]DanglingReference)
+	var skipped []map[string]interface{}
+	total := 0
+	for _, check := range referenceChecks {
+		var reasons []string
+		for _, name := range []string{check.From, check.To} {
+			if err, ok := failed[name]; ok && (name != check.To || check.To != check.From) {
+				reasons = append(reasons, fmt.Sprintf("%s: %v", name, err))
+			}
+		}
+		if len(reasons) > 0 {
+			skipped = append(skipped, map[string]interface{}{"check": check.Problem, "reason": strings.Join(reasons, "; ")})
+			continue
+		}
+		if dangling := FindDanglingReferences(items[check.From], items[check.To], check); len(dangling) > 0 {
+			problems[check.Problem] = dangling
+			total += len(dangling)
+		}
+	}
+
+	checked := make(map[string]int, len(items))
+	for _, c := range referenceCollections {
+		if _, ok := failed[c.Name]; !ok {
+			checked[c.Name] = len(items[c.Name])
+		}
+	}
+	response := map[string]interface{}{
+		"problem_count": total,
+		"problems":      problems,
+		"checked":       checked,
+	}
+	if len(skipped) > 0 {
+		response["skipped"] = skipped
+	}
+	if total == 0 && len(skipped) == 0 {
+		response["message"] = "No dangling references found."
+	}
+	return t.FormatResponse(response)
+}
+
+// FindDanglingReferences returns the references from items in from whose value matches no
+// item in to, ordered by resource ID
+func FindDanglingReferences(from, to []map[string]interface{}, check referenceCheck) []DanglingReference {
+	targetKeys := check.TargetKeys
+	if len(targetKeys) == 0 {
+		targetKeys = []string{"id"}
+	}
+	existing := make(map[string]bool, len(to))
+	for _, item := range to {
+		for _, key := range targetKeys {
+			if v, ok := item[key]; ok && v != nil {
+				existing[fmt.Sprint(v)] = true
+			}
+		}
+	}
+
+	var dangling []DanglingReference
+	for _, item := range from {
+		var refs []fieldReference
+		collectReferences(item, check.RefKeys, "", &refs)
+		id, _ := item["id"].(string)
+		name, _ := item["name"].(string)
+		for _, ref := range refs {
+			if !existing[ref.Value] {
+				dangling = append(dangling, DanglingReference{ResourceID: id, ResourceName: name, Field: ref.Field, MissingID: ref.Value})
+			}
+		}
+	}
+	sort.SliceStable(dangling, func(i, j int) bool { return dangling[i].ResourceID < dangling[j].ResourceID })
+	return dangling
+}
+
+// collectReferences appends every non-empty refKeys value found at any depth of value
+func collectReferences(value interface{}, refKeys []string, path string, out *[]fieldReference) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			child := v[key]
+			if isRefKey(key, refKeys) {
+				if ref := referenceValue(child); ref != "" {
+					*out = append(*out, fieldReference{Field: joinPath(path, key), Value: ref})
+				}
+				continue
+			}
+			collectReferences(child, refKeys, joinPath(path, key), out)
+		}
+	case []interface{}:
+		for i, child := range v {
+			collectReferences(child, refKeys, fmt.Sprintf("%s[%d]", path, i), out)
+		}
+	}
+}
+
+// isRefKey reports whether key is one of refKeys
+func isRefKey(key string, refKeys []string) bool {
+	for _, k := range refKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// referenceValue returns a scalar reference as a string, or "" for empty or non-scalar values
+func referenceValue(v interface{}) string {
+	switch ref := v.(type) {
+	case string:
+		return ref
+	case float64, int, int64:
+		return fmt.Sprint(ref)
+	}
+	return ""
+}
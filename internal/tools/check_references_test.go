@@ -0,0 +1,124 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+type checkReferencesResponse struct {
+	ProblemCount int                            `json:"problem_count"`
+	Problems     map[string][]DanglingReference `json:"problems"`
+	Checked      map[string]int                 `json:"checked"`
+	Skipped      []map[string]string            `json:"skipped"`
+}
+
+// referencesMock serves every collection check_references lists; failing paths return 500
+func referencesMock(t *testing.T, failing ...string) *client.MockClient {
+	bodies := map[string]interface{}{
+		"/v1/alerts": map[string]interface{}{"alerts": []interface{}{
+			map[string]interface{}{"id": "a1", "name": "Errors", "alert_definition_id": "def-gone", "notification_groups": []interface{}{
+				map[string]interface{}{"notifications": []interface{}{
+					map[string]interface{}{"integration_id": 42},
+					map[string]interface{}{"webhook_id": "wh-gone"},
+				}},
+			}},
+			map[string]interface{}{"id": "a2", "name": "Latency", "alert_definition_id": "def-1"},
+		}},
+		"/v1/alert_definitions": map[string]interface{}{"alert_definitions": []interface{}{
+			map[string]interface{}{"id": "def-1", "name": "Latency"},
+		}},
+		"/v1/outgoing_webhooks": map[string]interface{}{"outgoing_webhooks": []interface{}{
+			map[string]interface{}{"id": "wh-1", "external_id": 42},
+		}},
+		"/v1/views": map[string]interface{}{"views": []interface{}{
+			map[string]interface{}{"id": 7, "name": "Prod errors", "folder_id": "vf-gone"},
+			map[string]interface{}{"id": 8, "name": "Unfiled"},
+		}},
+		"/v1/view_folders": map[string]interface{}{"view_folders": []interface{}{
+			map[string]interface{}{"id": "vf-1", "name": "Prod"},
+		}},
+		"/v1/folders": map[string]interface{}{"folders": []interface{}{
+			map[string]interface{}{"id": "f1", "name": "Root"},
+			map[string]interface{}{"id": "f2", "name": "Child", "parent_id": "f1"},
+		}},
+	}
+	mock := client.NewMockClient()
+	mock.DoFunc = func(_ context.Context, req *client.Request) (*client.Response, error) {
+		for _, path := range failing {
+			if req.Path == path {
+				return &client.Response{StatusCode: 500, Body: []byte(`{"message":"internal error"}`)}, nil
+			}
+		}
+		data, err := json.Marshal(bodies[req.Path])
+		require.NoError(t, err)
+		return &client.Response{StatusCode: 200, Body: data}, nil
+	}
+	return mock
+}
+
+func decodeCheckReferences(t *testing.T, result *mcp.CallToolResult) checkReferencesResponse {
+	t.Helper()
+	require.False(t, result.IsError, "%+v", result.Content)
+	var resp checkReferencesResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &resp))
+	return resp
+}
+
+func TestCheckReferencesTool_GroupsProblems(t *testing.T) {
+	mock := referencesMock(t)
+	tool := NewCheckReferencesTool(mock, zap.NewNop())
+
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{})
+	require.NoError(t, err)
+	resp := decodeCheckReferences(t, result)
+
+	assert.Equal(t, 3, resp.ProblemCount)
+	assert.Equal(t, []DanglingReference{{ResourceID: "a1", ResourceName: "Errors",
+		Field: "notification_groups[0].notifications[1].webhook_id", MissingID: "wh-gone"}}, resp.Problems["alert_missing_webhook"],
+		"a webhook referenced by external_id is not dangling")
+	assert.Equal(t, []DanglingReference{{ResourceID: "a1", ResourceName: "Errors", Field: "alert_definition_id", MissingID: "def-gone"}},
+		resp.Problems["alert_missing_definition"])
+	require.Len(t, resp.Problems["view_missing_folder"], 1)
+	assert.Equal(t, "vf-gone", resp.Problems["view_missing_folder"][0].MissingID)
+	assert.NotContains(t, resp.Problems, "dashboard_folder_missing_parent")
+	assert.Equal(t, 2, resp.Checked["alerts"])
+	assert.Empty(t, resp.Skipped)
+	assert.Equal(t, len(referenceCollections), mock.RequestCount())
+}
+
+func TestCheckReferencesTool_PartialListFailure(t *testing.T) {
+	mock := referencesMock(t, "/v1/outgoing_webhooks")
+	tool := NewCheckReferencesTool(mock, zap.NewNop())
+
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{})
+	require.NoError(t, err)
+	resp := decodeCheckReferences(t, result)
+
+	assert.NotContains(t, resp.Problems, "alert_missing_webhook")
+	assert.Contains(t, resp.Problems, "alert_missing_definition")
+	require.Len(t, resp.Skipped, 2)
+	assert.Equal(t, "alert_missing_webhook", resp.Skipped[0]["check"])
+	assert.Contains(t, resp.Skipped[0]["reason"], "outgoing_webhooks")
+	assert.NotContains(t, resp.Checked, "outgoing_webhooks")
+}
+
+func TestCheckReferencesTool_AllListsFail(t *testing.T) {
+	paths := make([]string, len(referenceCollections))
+	for i, c := range referenceCollections {
+		paths[i] = c.Path
+	}
+	mock := referencesMock(t, paths...)
+	tool := NewCheckReferencesTool(mock, zap.NewNop())
+
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
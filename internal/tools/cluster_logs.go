@@ -0,0 +1,204 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements cluster_logs, which mines the message patterns of a query's results.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+const (
+	// defaultClusterLogLimit is the number of events clustered unless set
+	defaultClusterLogLimit = 2000
+	// maxClusterLogLimit caps the events fetched for one clustering run
+	maxClusterLogLimit = 10000
+	// defaultMaxClusters is the number of patterns returned unless set
+	defaultMaxClusters = 20
+	// maxMaxClusters caps the patterns returned
+	maxMaxClusters = 200
+)
+
+// ClusterLogsTool runs a query and returns its events grouped into message patterns
+type ClusterLogsTool struct{ *BaseTool }
+
+// NewClusterLogsTool creates a new tool instance
+func NewClusterLogsTool(c client.Doer, l *zap.Logger) *ClusterLogsTool {
+	return &ClusterLogsTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *ClusterLogsTool) Name() string { return "cluster_logs" }
+
+// Annotations returns tool hints for LLMs
+func (t *ClusterLogsTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Cluster Logs")
+}
+
+// DefaultTimeout returns the timeout for the clustering query
+func (t *ClusterLogsTool) DefaultTimeout() time.Duration {
+	return DefaultQueryTimeout
+}
+
+// Description returns the tool description
+func (t *ClusterLogsTool) Description() string {
+	return fmt.Sprintf(`Group the logs matched by a query into message patterns and return them as structured JSON.
+
+Messages that differ only in variable parts such as IDs, numbers and hosts are merged into one
+pattern with those parts shown as <*>. Each pattern reports its count, share of events, severity,
+applications, first/last occurrence, a sample message and a likely root cause with a suggested
+remediation; root causes are also totalled across patterns. Teams can add their own failure
+signatures with LOGS_ROOT_CAUSE_RULES_FILE. Up to limit events are clustered (default %d, max %d) and the
+largest max_clusters patterns are returned (default %d).
+
+**Related tools:** query_logs, investigate_incident, trace_logs`, defaultClusterLogLimit, maxClusterLogLimit, defaultMaxClusters)
+}
+
+// InputSchema returns the input schema
+func (t *ClusterLogsTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"query": map[string]interface{}{
+				"type":        "string",
+				"description": "DataPrime query selecting the logs to cluster (default: source logs), e.g. source logs | filter $m.severity >= ERROR",
+			},
+			"applicationName": map[string]interface{}{
+				"type":        "string",
+				"description": "Only cluster logs from this application",
+			},
+			"subsystemName": map[string]interface{}{
+				"type":        "string",
+				"description": "Only cluster logs from this subsystem",
+			},
+			"time_range": map[string]interface{}{
+				"type":        "string",
+				"description": "How far back to search when start_date is not set (default: 24h)",
+				"enum":        []string{"15m", "1h", "6h", "24h", "7d"},
+				"default":     "24h",
+			},
+			"start_date": map[string]interface{}{
+				"type":        "string",
+				"description": "Start of the search window (RFC3339). Overrides time_range.",
+			},
+			"end_date": map[string]interface{}{
+				"type":        "string",
+				"description": "End of the search window (RFC3339, default: now)",
+			},
+			"tier": map[string]interface{}{
+				"type":        "string",
+				"description": "Tier to search (default: archive)",
+				"enum":        []string{"archive", "frequent_search"},
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"description": fmt.Sprintf("Maximum events to cluster (default: %d, max: %d)", defaultClusterLogLimit, maxClusterLogLimit),
+				"minimum":     1,
+				"maximum":     maxClusterLogLimit,
+			},
+			"max_clusters": map[string]interface{}{
+				"type":        "integer",
+				"description": fmt.Sprintf("Maximum patterns to return, largest first (default: %d, max: %d)", defaultMaxClusters, maxMaxClusters),
+				"minimum":     1,
+				"maximum":     maxMaxClusters,
+			},
+			"clustering_sensitivity": clusteringSensitivitySchema,
+		},
+	}
+}
+
+// Execute executes the tool
+func (t *ClusterLogsTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	query, _ := GetStringParam(args, "query", false)
+	if query == "" {
+		query = "source logs"
+	}
+	query = applyQueryFilters(query, args)
+
+	sensitivityArg, _ := GetStringParam(args, "clustering_sensitivity", false)
+	sensitivity, err := ParseClusteringSensitivity(sensitivityArg)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	start, end, err := resolveQueryWindow(args)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+
+	tier, _ := GetStringParam(args, "tier", false)
+	if tier == "" {
+		tier = "archive"
+	} else {
+		tier = normalizeTier(tier)
+	}
+	limit, err := GetIntParam(args, "limit", false)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	if limit <= 0 {
+		limit = defaultClusterLogLimit
+	}
+	if limit > maxClusterLogLimit {
+		limit = maxClusterLogLimit
+	}
+	maxClusters, err := GetIntParam(args, "max_clusters", false)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	if maxClusters <= 0 {
+		maxClusters = defaultMaxClusters
+	}
+	if maxClusters > maxMaxClusters {
+		maxClusters = maxMaxClusters
+	}
+
+	query, _, err = PrepareQuery(query, tier, "dataprime")
+	if err != nil {
+		return NewToolResultError(fmt.Sprintf("Invalid query: %v", err)), nil
+	}
+
+	result, err := t.ExecuteRequestWithMaxEvents(ctx, &client.Request{
+		Method: "POST",
+		Path:   "/v1/query",
+		Body: map[string]interface{}{
+			"query": query,
+			"metadata": map[string]interface{}{
+				"tier":       tier,
+				"syntax":     "dataprime",
+				"start_date": start.Format(time.RFC3339),
+				"end_date":   end.Format(time.RFC3339),
+				"limit":      limit,
+			},
+		},
+		AcceptSSE: true,
+		Timeout:   DefaultQueryTimeout,
+	}, limit)
+	if err != nil {
+		return NewToolResultError(FormatQueryError(query, err.Error())), nil
+	}
+
+	events, _ := result["events"].([]interface{})
+	analysis := AnalyzeClusters(events, sensitivity, maxClusters)
+
+	response := map[string]interface{}{
+		"query": query,
+		"window": map[string]interface{}{
+			"start_date": start.Format(time.RFC3339),
+			"end_date":   end.Format(time.RFC3339),
+		},
+		"truncated": len(events) >= limit,
+		"analysis":  analysis,
+	}
+	switch {
+	case len(events) == 0:
+		response["note"] = "No logs matched in this window. Widen time_range or relax the query."
+	case len(events) >= limit:
+		response["note"] = fmt.Sprintf("Only the first %d events were clustered; raise limit (max %d) or narrow the window for complete counts.", limit, maxClusterLogLimit)
+	}
+	return t.FormatResponse(response)
+}
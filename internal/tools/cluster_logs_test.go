@@ -0,0 +1,177 @@
+package tools
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestMatchRootCause(t *testing.T) {
+	tests := map[string]string{
+		"request to <*> timed out after <*>":  "Timeout calling a slow or unresponsive dependency",
+		"dial tcp <*> connection refused":     "Connection failure to a downstream service",
+		"container OOMKilled":                 "Memory exhaustion",
+		"permission denied for table orders":  "Authentication or authorization failure",
+		"write failed: no space left on disk": "Disk exhaustion",
+		"cache warmed":                        "",
+	}
+	for msg, want := range tests {
+		rule := MatchRootCause(msg)
+		if want == "" {
+			assert.Nil(t, rule, msg)
+			continue
+		}
+		require.NotNil(t, rule, msg)
+		assert.Equal(t, want, rule.Label, msg)
+		assert.NotEmpty(t, rule.Remediation, msg)
+	}
+}
+
+func TestLoadRootCauseRules(t *testing.T) {
+	t.Cleanup(func() { SetRootCauseRules(nil) })
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "rules.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[
+		{"pattern": "(?i)connection pool exhausted", "root_cause": "DB pool exhausted", "remediation": "Follow runbook RB-12"},
+		{"pattern": "(?i)timed out waiting for lease", "root_cause": "Leader election stalled"}
+	]`), 0o600))
+	n, err := LoadRootCauseRules(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	rule := MatchRootCause("connection pool exhausted after 30s")
+	require.NotNil(t, rule)
+	assert.Equal(t, "DB pool exhausted", rule.Label)
+	assert.Equal(t, "Follow runbook RB-12", rule.Remediation)
+	assert.Equal(t, "Leader election stalled", MatchRootCause("timed out waiting for lease").Label, "custom rules precede built-ins")
+	assert.Equal(t, "Memory exhaustion", MatchRootCause("container OOMKilled").Label, "built-ins still apply")
+
+	analysis := AnalyzeClusters([]interface{}{
+		map[string]interface{}{"message": "connection pool exhausted"},
+	}, ClusteringMedium, 0)
+	require.Len(t, analysis.Patterns, 1)
+	assert.Equal(t, "Follow runbook RB-12", analysis.Patterns[0].Remediation)
+	assert.Equal(t, "Follow runbook RB-12", analysis.RootCauses[0].Remediation)
+
+	for name, content := range map[string]string{
+		"bad_regex.json": `[{"pattern": "(", "root_cause": "x"}]`,
+		"no_label.json":  `[{"pattern": "x"}]`,
+		"empty.json":     `[]`,
+		"not_array.json": `{"pattern": "x"}`,
+	} {
+		p := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+		_, err := LoadRootCauseRules(p)
+		assert.Error(t, err, name)
+	}
+	_, err = LoadRootCauseRules(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+	assert.Equal(t, "DB pool exhausted", MatchRootCause("connection pool exhausted").Label, "failed loads keep the previous rules")
+}
+
+func TestAnalyzeClusters(t *testing.T) {
+	var events []interface{}
+	for i, ts := range []string{"2024-01-01T00:00:03Z", "2024-01-01T00:00:01Z", "2024-01-01T00:00:02Z"} {
+		app := "checkout"
+		if i == 2 {
+			app = "payments"
+		}
+		events = append(events, map[string]interface{}{
+			"timestamp": ts, "applicationname": app, "severity": "5",
+			"user_data": map[string]interface{}{"message": "call to db-" + string(rune('1'+i)) + " timed out"},
+		})
+	}
+	events = append(events, map[string]interface{}{"timestamp": "2024-01-01T00:00:09Z", "message": "cache warmed"})
+
+	analysis := AnalyzeClusters(events, ClusteringMedium, 0)
+	assert.Equal(t, 4, analysis.TotalEvents)
+	assert.Equal(t, 2, analysis.ClusterCount)
+	assert.Equal(t, "2024-01-01T00:00:01Z", analysis.FirstSeen)
+	assert.Equal(t, "2024-01-01T00:00:09Z", analysis.LastSeen)
+	require.Len(t, analysis.Patterns, 2)
+
+	top := analysis.Patterns[0]
+	assert.Equal(t, "call to <*> timed out", top.Pattern)
+	assert.Equal(t, 3, top.Count)
+	assert.Equal(t, 75.0, top.Percent)
+	assert.Equal(t, []string{"checkout", "payments"}, top.Applications)
+	assert.Equal(t, "2024-01-01T00:00:01Z", top.FirstSeen)
+	assert.Equal(t, "2024-01-01T00:00:03Z", top.LastSeen)
+	assert.Equal(t, "call to db-1 timed out", top.Sample)
+	assert.Equal(t, "Timeout calling a slow or unresponsive dependency", top.RootCause)
+	assert.Empty(t, analysis.Patterns[1].RootCause)
+	assert.Equal(t, []RootCauseSummary{{RootCause: top.RootCause, Remediation: top.Remediation, Patterns: 1, Events: 3}}, analysis.RootCauses)
+
+	limited := AnalyzeClusters(events, ClusteringMedium, 1)
+	assert.Len(t, limited.Patterns, 1)
+	assert.Equal(t, 2, limited.ClusterCount, "cluster_count covers patterns beyond max_clusters")
+}
+
+func TestClusterLogsTool(t *testing.T) {
+	var body strings.Builder
+	body.WriteString(`data: {"result":{"results":[`)
+	for i, msg := range clusteringInput {
+		if i > 0 {
+			body.WriteString(",")
+		}
+		body.WriteString(`{"metadata":[{"key":"timestamp","value":"2024-01-01T00:00:0` + string(rune('0'+i)) + `Z"}],"user_data":"{\"message\":\"` + msg + `\"}"}`)
+	}
+	body.WriteString("]}}\n")
+	mock := client.NewMockClient()
+	mock.DefaultResponse = &client.Response{StatusCode: 200, Body: []byte(body.String())}
+	tool := NewClusterLogsTool(mock, zap.NewNop())
+
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{
+		"query": "source logs", "applicationName": "api", "start_date": "2024-01-01T00:00:00Z", "end_date": "2024-01-01T01:00:00Z",
+		"clustering_sensitivity": "coarse", "max_clusters": 2,
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError, "%+v", result.Content)
+
+	var response struct {
+		Query     string            `json:"query"`
+		Truncated bool              `json:"truncated"`
+		Analysis  ClusteredAnalysis `json:"analysis"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &response))
+	assert.Contains(t, response.Query, "$l.applicationname == 'api'")
+	assert.False(t, response.Truncated)
+	assert.Equal(t, ClusteringCoarse, response.Analysis.Sensitivity)
+	assert.Equal(t, 9, response.Analysis.TotalEvents)
+	assert.Equal(t, 5, response.Analysis.ClusterCount)
+	assert.Len(t, response.Analysis.Patterns, 2)
+	assert.Equal(t, "2024-01-01T00:00:00Z", response.Analysis.FirstSeen)
+	assert.Equal(t, "2024-01-01T00:00:08Z", response.Analysis.LastSeen)
+
+	req := mock.LastRequest()
+	require.NotNil(t, req)
+	metadata := req.Body.(map[string]interface{})["metadata"].(map[string]interface{})
+	assert.Equal(t, defaultClusterLogLimit, metadata["limit"])
+	assert.Equal(t, "2024-01-01T00:00:00Z", metadata["start_date"])
+}
+
+func TestClusterLogsTool_InvalidArguments(t *testing.T) {
+	mock := client.NewMockClient()
+	tool := NewClusterLogsTool(mock, zap.NewNop())
+
+	for _, args := range []map[string]interface{}{
+		{"clustering_sensitivity": "extreme"},
+		{"time_range": "30d"},
+		{"start_date": "yesterday"},
+	} {
+		result, err := tool.Execute(testCtx(mock), args)
+		require.NoError(t, err)
+		assert.True(t, result.IsError, "%v", args)
+	}
+	assert.Zero(t, mock.RequestCount())
+}
@@ -0,0 +1,265 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements message templating and the sensitivity of log clustering.
+package tools
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ClusteringSensitivity controls how aggressively similar messages are merged into one pattern
+type ClusteringSensitivity string
+
+// Clustering sensitivities, from fewest and broadest patterns to most and most specific
+const (
+	// ClusteringCoarse masks every token with a digit and merges messages of the same length
+	// that differ in up to 3 other tokens
+	ClusteringCoarse ClusteringSensitivity = "coarse"
+	// ClusteringMedium masks every token with a digit and merges messages that differ in one other token
+	ClusteringMedium ClusteringSensitivity = "medium"
+	// ClusteringFine masks only numbers, UUIDs, hex IDs and IP addresses and merges identical templates
+	ClusteringFine ClusteringSensitivity = "fine"
+)
+
+// DefaultClusteringSensitivity is used when no sensitivity is requested
+const DefaultClusteringSensitivity = ClusteringMedium
+
+// templateWildcard replaces the variable tokens of a message template
+const templateWildcard = "<*>"
+
+// clusteringMaxDiffs is how many unmasked tokens two templates may differ in and still merge
+var clusteringMaxDiffs = map[ClusteringSensitivity]int{
+	ClusteringCoarse: 3,
+	ClusteringMedium: 1,
+	ClusteringFine:   0,
+}
+
+// clusteringSensitivitySchema is the schema of the clustering_sensitivity argument
+var clusteringSensitivitySchema = map[string]interface{}{
+	"type": "string",
+	"description": "How aggressively to merge similar messages into one pattern. coarse: fewer, broader patterns " +
+		"(up to 3 differing words merge; may hide distinct errors). medium (default): one differing word merges. " +
+		"fine: only numbers and IDs vary; more, specific patterns that may split one problem.",
+	"enum":    []string{"coarse", "medium", "fine"},
+	"default": string(DefaultClusteringSensitivity),
+}
+
+// ParseClusteringSensitivity validates a sensitivity name; empty selects the default
+func ParseClusteringSensitivity(s string) (ClusteringSensitivity, error) {
+	if s == "" {
+		return DefaultClusteringSensitivity, nil
+	}
+	sensitivity := ClusteringSensitivity(strings.ToLower(s))
+	if _, ok := clusteringMaxDiffs[sensitivity]; !ok {
+		return "", fmt.Errorf("invalid clustering_sensitivity '%s' (valid: coarse, medium, fine)", s)
+	}
+	return sensitivity, nil
+}
+
+// fineVariableToken matches the tokens masked at fine sensitivity: numbers with optional
+// units, UUIDs, long hex IDs and IPv4 addresses
+var fineVariableToken = regexp.MustCompile(`^(?:[-+]?\d+(?:[.,:]\d+)*[a-zA-Z%]{0,3}|[0-9a-fA-F]{8}(?:-[0-9a-fA-F]{4}){3}-[0-9a-fA-F]{12}|(?:0x)?[0-9a-fA-F]{8,}|\d{1,3}(?:\.\d{1,3}){3}(?::\d+)?)$`)
+
+// messageTemplate splits a message into tokens with its variable tokens masked
+func messageTemplate(message string, sensitivity ClusteringSensitivity) []string {
+	tokens := strings.Fields(message)
+	for i, tok := range tokens {
+		core := strings.TrimFunc(tok, func(r rune) bool { return unicode.IsPunct(r) && r != '-' && r != '+' })
+		if core == "" {
+			continue
+		}
+		var variable bool
+		if sensitivity == ClusteringFine {
+			variable = fineVariableToken.MatchString(core) && strings.ContainsAny(core, "0123456789")
+		} else {
+			variable = strings.ContainsAny(core, "0123456789")
+		}
+		if variable {
+			tokens[i] = templateWildcard
+		}
+	}
+	return tokens
+}
+
+// templateDiffs counts the positions where two equal-length templates differ, ignoring wildcards
+func templateDiffs(a, b []string) int {
+	diffs := 0
+	for i := range a {
+		if a[i] != b[i] && a[i] != templateWildcard && b[i] != templateWildcard {
+			diffs++
+		}
+	}
+	return diffs
+}
+
+// templateMatches reports whether a message template belongs to a cluster template: same
+// length, at most maxDiffs differing words, and a majority of the words shared
+func templateMatches(cluster, tokens []string, maxDiffs int) bool {
+	if maxDiffs == 0 {
+		return slices.Equal(cluster, tokens)
+	}
+	if len(cluster) != len(tokens) {
+		return false
+	}
+	diffs := templateDiffs(cluster, tokens)
+	return diffs <= maxDiffs && (diffs == 0 || diffs*2 < len(tokens))
+}
+
+// mergeTemplate widens a cluster template with the differing words of a new member
+func mergeTemplate(cluster, tokens []string) {
+	for i := range cluster {
+		if cluster[i] != tokens[i] {
+			cluster[i] = templateWildcard
+		}
+	}
+}
+
+// clusteringSensitivityFromResult returns the sensitivity recorded in a query result's
+// _query_metadata (from the clustering_sensitivity argument) or the default
+func clusteringSensitivityFromResult(result map[string]interface{}) ClusteringSensitivity {
+	meta, _ := result["_query_metadata"].(map[string]interface{})
+	if s, ok := meta["clustering_sensitivity"].(string); ok {
+		if sensitivity, err := ParseClusteringSensitivity(s); err == nil {
+			return sensitivity
+		}
+	}
+	return DefaultClusteringSensitivity
+}
+
+// ClusteredAnalysis is the structured result of mining patterns from a set of log events
+type ClusteredAnalysis struct {
+	Sensitivity  ClusteringSensitivity `json:"sensitivity"`
+	TotalEvents  int                   `json:"total_events"`
+	ClusterCount int                   `json:"cluster_count"`
+	FirstSeen    string                `json:"first_seen,omitempty"`
+	LastSeen     string                `json:"last_seen,omitempty"`
+	Patterns     []PatternSummary      `json:"patterns"`
+	RootCauses   []RootCauseSummary    `json:"root_causes,omitempty"`
+}
+
+// PatternSummary describes one cluster of similar messages
+type PatternSummary struct {
+	Pattern      string   `json:"pattern"`
+	Count        int      `json:"count"`
+	Percent      float64  `json:"percent"`
+	Severity     string   `json:"severity"`
+	Traces       int      `json:"traces,omitempty"`
+	Applications []string `json:"applications,omitempty"`
+	FirstSeen    string   `json:"first_seen,omitempty"`
+	LastSeen     string   `json:"last_seen,omitempty"`
+	RootCause    string   `json:"root_cause,omitempty"`
+	Remediation  string   `json:"remediation,omitempty"`
+	Sample       string   `json:"sample"`
+}
+
+// RootCauseSummary totals the patterns and events attributed to one root cause
+type RootCauseSummary struct {
+	RootCause   string `json:"root_cause"`
+	Remediation string `json:"remediation,omitempty"`
+	Patterns    int    `json:"patterns"`
+	Events      int    `json:"events"`
+}
+
+// AnalyzeClusters clusters events at the given sensitivity and returns the largest
+// maxClusters patterns with their spread, time range and likely root cause. Root-cause
+// totals cover every pattern, including those beyond maxClusters.
+func AnalyzeClusters(events []interface{}, sensitivity ClusteringSensitivity, maxClusters int) *ClusteredAnalysis {
+	clusters := ClusterLogsWithSensitivity(events, sensitivity)
+	analysis := &ClusteredAnalysis{
+		Sensitivity:  sensitivity,
+		ClusterCount: len(clusters),
+		Patterns:     []PatternSummary{},
+	}
+	for _, c := range clusters {
+		analysis.TotalEvents += c.Count
+	}
+
+	rootCauses := make(map[string]*RootCauseSummary)
+	var causeOrder []string
+	for i, c := range clusters {
+		p := PatternSummary{
+			Pattern:  c.Pattern,
+			Count:    c.Count,
+			Severity: c.Severity,
+			Traces:   c.Traces,
+		}
+		if analysis.TotalEvents > 0 {
+			p.Percent = math.Round(float64(c.Count)*1000/float64(analysis.TotalEvents)) / 10
+		}
+		seenApp := make(map[string]bool)
+		for _, event := range c.Events {
+			eventMap, _ := event.(map[string]interface{})
+			if p.Sample == "" {
+				p.Sample = truncateString(extractMessage(eventMap), 500)
+			}
+			if app := findFieldValue(eventMap, "applicationname"); app != "" && !seenApp[app] {
+				seenApp[app] = true
+				p.Applications = append(p.Applications, app)
+			}
+			if ts := eventTimestamp(eventMap); ts != "" {
+				if p.FirstSeen == "" || ts < p.FirstSeen {
+					p.FirstSeen = ts
+				}
+				if ts > p.LastSeen {
+					p.LastSeen = ts
+				}
+			}
+		}
+		rule := MatchRootCause(c.Pattern)
+		if rule == nil {
+			rule = MatchRootCause(p.Sample)
+		}
+		if rule != nil {
+			p.RootCause, p.Remediation = rule.Label, rule.Remediation
+		}
+
+		if p.FirstSeen != "" && (analysis.FirstSeen == "" || p.FirstSeen < analysis.FirstSeen) {
+			analysis.FirstSeen = p.FirstSeen
+		}
+		if p.LastSeen > analysis.LastSeen {
+			analysis.LastSeen = p.LastSeen
+		}
+		if p.RootCause != "" {
+			rc, ok := rootCauses[p.RootCause]
+			if !ok {
+				rc = &RootCauseSummary{RootCause: p.RootCause, Remediation: p.Remediation}
+				rootCauses[p.RootCause] = rc
+				causeOrder = append(causeOrder, p.RootCause)
+			}
+			rc.Patterns++
+			rc.Events += p.Count
+		}
+		if maxClusters <= 0 || i < maxClusters {
+			analysis.Patterns = append(analysis.Patterns, p)
+		}
+	}
+
+	for _, cause := range causeOrder {
+		analysis.RootCauses = append(analysis.RootCauses, *rootCauses[cause])
+	}
+	sort.SliceStable(analysis.RootCauses, func(i, j int) bool {
+		return analysis.RootCauses[i].Events > analysis.RootCauses[j].Events
+	})
+	return analysis
+}
+
+// eventTimestamp returns the raw timestamp of an event, or ""
+func eventTimestamp(eventMap map[string]interface{}) string {
+	if ts, ok := eventMap["timestamp"].(string); ok {
+		return ts
+	}
+	if ts, ok := eventMap["@timestamp"].(string); ok {
+		return ts
+	}
+	if metadata, ok := eventMap["metadata"].(map[string]interface{}); ok {
+		if ts, ok := metadata["timestamp"].(string); ok {
+			return ts
+		}
+	}
+	return ""
+}
@@ -0,0 +1,115 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// clusteringInput mixes messages that differ only in IDs, in one word, and in several words
+var clusteringInput = []string{
+	"request 8f14e45f-ceea-4e2c-9a0f-6b2d1c7e5a10 failed after 30ms",
+	"request 1c2d3e4f-aaaa-4bbb-8ccc-0123456789ab failed after 45ms",
+	"connection to db-1 refused",
+	"connection to db-2 refused",
+	"user alice login failed",
+	"user bob login failed",
+	"payment for order 17 declined by issuer",
+	"payment for invoice 18 rejected by gateway",
+	"cache warmed",
+}
+
+func clusteringEvents() []interface{} {
+	events := make([]interface{}, len(clusteringInput))
+	for i, msg := range clusteringInput {
+		events[i] = map[string]interface{}{"message": msg, "severity": "5"}
+	}
+	return events
+}
+
+func TestClusterLogsWithSensitivity(t *testing.T) {
+	events := clusteringEvents()
+	fine := ClusterLogsWithSensitivity(events, ClusteringFine)
+	medium := ClusterLogsWithSensitivity(events, ClusteringMedium)
+	coarse := ClusterLogsWithSensitivity(events, ClusteringCoarse)
+
+	assert.Less(t, len(coarse), len(medium))
+	assert.Less(t, len(medium), len(fine))
+	assert.Len(t, fine, 8, "only the ID-only variants merge at fine")
+	assert.Len(t, medium, 6)
+	assert.Len(t, coarse, 5)
+
+	patterns := func(clusters []LogCluster) []string {
+		out := make([]string, len(clusters))
+		for i, c := range clusters {
+			out[i] = c.Pattern
+		}
+		return out
+	}
+	assert.Contains(t, patterns(fine), "request <*> failed after <*>")
+	assert.Contains(t, patterns(fine), "connection to db-1 refused")
+	assert.Contains(t, patterns(medium), "connection to <*> refused")
+	assert.Contains(t, patterns(medium), "user <*> login failed")
+	assert.Contains(t, patterns(coarse), "payment for <*> <*> <*> by <*>")
+
+	total := 0
+	for _, c := range coarse {
+		total += c.Count
+	}
+	assert.Equal(t, len(events), total, "every event belongs to exactly one cluster")
+}
+
+func TestClusterLogsWithSensitivity_KeepsShortMessagesApart(t *testing.T) {
+	events := []interface{}{
+		map[string]interface{}{"message": "disk full"},
+		map[string]interface{}{"message": "cache miss"},
+	}
+	assert.Len(t, ClusterLogsWithSensitivity(events, ClusteringCoarse), 2,
+		"messages sharing no words never merge, even at coarse")
+}
+
+func TestParseClusteringSensitivity(t *testing.T) {
+	s, err := ParseClusteringSensitivity("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultClusteringSensitivity, s)
+	s, err = ParseClusteringSensitivity("COARSE")
+	require.NoError(t, err)
+	assert.Equal(t, ClusteringCoarse, s)
+	_, err = ParseClusteringSensitivity("extreme")
+	assert.Error(t, err)
+}
+
+func TestQueryTool_ClusteringSensitivity(t *testing.T) {
+	var body []byte
+	body = append(body, "data: {\"result\":{\"results\":["...)
+	for i, msg := range append(clusteringInput, "cache warmed") {
+		if i > 0 {
+			body = append(body, ',')
+		}
+		body = append(body, `{"user_data":"{\"message\":\"`+msg+`\"}"}`...)
+	}
+	body = append(body, "]}}\n"...)
+	mock := client.NewMockClient()
+	mock.DefaultResponse = &client.Response{StatusCode: 200, Body: body}
+	tool := NewQueryTool(mock, zap.NewNop())
+	args := func(sensitivity string) map[string]interface{} {
+		return map[string]interface{}{
+			"query": "source logs", "start_date": "2024-01-01T00:00:00Z", "end_date": "2024-01-01T01:00:00Z",
+			"summary_only": true, "clustering_sensitivity": sensitivity, "use_cache": false,
+		}
+	}
+
+	coarse, _ := tool.Execute(testCtx(mock), args("coarse"))
+	require.False(t, coarse.IsError, "%+v", coarse.Content)
+	assert.Contains(t, coarse.Content[0].(*mcp.TextContent).Text, "(5 clusters from 10 events)")
+	fine, _ := tool.Execute(testCtx(mock), args("fine"))
+	assert.Contains(t, fine.Content[0].(*mcp.TextContent).Text, "(8 clusters from 10 events)")
+
+	invalid, _ := tool.Execute(testCtx(mock), args("extreme"))
+	assert.True(t, invalid.IsError)
+}
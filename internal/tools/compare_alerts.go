@@ -0,0 +1,235 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// FieldDiff describes a single field-level difference between two resources
+type FieldDiff struct {
+	Path   string      `json:"path"`
+	Change string      `json:"change"` // added, removed, or changed
+	Old    interface{} `json:"old,omitempty"`
+	New    interface{} `json:"new,omitempty"`
+}
+
+// DiffResources returns the field-level differences between two resources, sorted by path.
+// Nested objects and arrays are compared element by element; server-managed top-level
+// fields (id, timestamps) are ignored.
+func DiffResources(oldRes, newRes map[string]interface{}) []FieldDiff {
+	oldFlat := make(map[string]interface{})
+	newFlat := make(map[string]interface{})
+	flattenForDiff("", stripServerManaged(oldRes), oldFlat)
+	flattenForDiff("", stripServerManaged(newRes), newFlat)
+
+	var diffs []FieldDiff
+	for path, oldVal := range oldFlat {
+		newVal, ok := newFlat[path]
+		switch {
+		case !ok:
+			diffs = append(diffs, FieldDiff{Path: path, Change: "removed", Old: oldVal})
+		case !reflect.DeepEqual(oldVal, newVal):
+			diffs = append(diffs, FieldDiff{Path: path, Change: "changed", Old: oldVal, New: newVal})
+		}
+	}
+	for path, newVal := range newFlat {
+		if _, ok := oldFlat[path]; !ok {
+			diffs = append(diffs, FieldDiff{Path: path, Change: "added", New: newVal})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Path < diffs[j].Path })
+	return diffs
+}
+
+// stripServerManaged returns a copy of the resource without server-managed fields
+func stripServerManaged(res map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(res))
+	for k, v := range res {
+		if !serverManagedFields[k] {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// flattenForDiff flattens nested objects and arrays into dotted/indexed paths
+func flattenForDiff(prefix string, value interface{}, out map[string]interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 && prefix != "" {
+			out[prefix] = v
+			return
+		}
+		for k, child := range v {
+			path := k
+			if prefix != "" {
+				path = prefix + "." + k
+			}
+			flattenForDiff(path, child, out)
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			out[prefix] = v
+			return
+		}
+		for i, child := range v {
+			flattenForDiff(fmt.Sprintf("%s[%d]", prefix, i), child, out)
+		}
+	default:
+		out[prefix] = v
+	}
+}
+
+// FormatFieldDiffs renders diffs as "field: old → new" lines
+func FormatFieldDiffs(diffs []FieldDiff) string {
+	var sb strings.Builder
+	for _, d := range diffs {
+		switch d.Change {
+		case "added":
+			fmt.Fprintf(&sb, "- `%s`: (not set) → %s\n", d.Path, diffValueString(d.New))
+		case "removed":
+			fmt.Fprintf(&sb, "- `%s`: %s → (not set)\n", d.Path, diffValueString(d.Old))
+		default:
+			fmt.Fprintf(&sb, "- `%s`: %s → %s\n", d.Path, diffValueString(d.Old), diffValueString(d.New))
+		}
+	}
+	return sb.String()
+}
+
+// diffValueString renders a diff value compactly
+func diffValueString(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+// CompareAlertsTool diffs the configuration of two alerts
+type CompareAlertsTool struct{ *BaseTool }
+
+// NewCompareAlertsTool creates a new tool instance
+func NewCompareAlertsTool(c client.Doer, l *zap.Logger) *CompareAlertsTool {
+	return &CompareAlertsTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *CompareAlertsTool) Name() string { return "compare_alerts" }
+
+// Annotations returns tool hints for LLMs
+func (t *CompareAlertsTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Compare Alerts")
+}
+
+// DefaultTimeout returns the timeout (two alerts are fetched)
+func (t *CompareAlertsTool) DefaultTimeout() time.Duration {
+	return 2 * DefaultGetTimeout
+}
+
+// Description returns the tool description
+func (t *CompareAlertsTool) Description() string {
+	return `Compare two alert configurations and show a field-level diff.
+
+Fetches both alerts and lists every added, removed, or changed field as "field: old → new",
+treating the first alert as the baseline. Server-managed fields (id, timestamps) are ignored.
+
+**When to use:**
+- Auditing configuration drift between environments
+- Reviewing how a copied alert differs from its original
+
+**Related tools:** get_alert, list_alerts, update_alert`
+}
+
+// InputSchema returns the input schema
+func (t *CompareAlertsTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"first_id": map[string]interface{}{
+				"type":        "string",
+				"description": "ID of the baseline alert (values shown on the left of →)",
+			},
+			"second_id": map[string]interface{}{
+				"type":        "string",
+				"description": "ID of the alert to compare against the baseline (values shown on the right of →)",
+			},
+		},
+		"required": []string{"first_id", "second_id"},
+	}
+}
+
+// Execute executes the tool
+func (t *CompareAlertsTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	firstID, err := GetStringParam(args, "first_id", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	secondID, err := GetStringParam(args, "second_id", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+
+	sides := []struct {
+		label string
+		id    string
+		alert map[string]interface{}
+	}{
+		{label: "First", id: firstID},
+		{label: "Second", id: secondID},
+	}
+
+	var missing []string
+	for i := range sides {
+		res, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/alerts/" + sides[i].id})
+		if err != nil {
+			var apiErr *APIError
+			if errors.As(err, &apiErr) && apiErr.IsNotFound() {
+				missing = append(missing, fmt.Sprintf("%s alert not found with ID: %s", sides[i].label, sides[i].id))
+				continue
+			}
+			return NewToolResultErrorWithCode(ClassifyError(err), fmt.Sprintf("Failed to fetch %s alert (%s): %v", strings.ToLower(sides[i].label), sides[i].id, err)), nil
+		}
+		sides[i].alert = res
+	}
+	if len(missing) > 0 {
+		return NewToolResultErrorWithSuggestion(strings.Join(missing, "; "), "Use 'list_alerts' to see available alerts and their IDs."), nil
+	}
+
+	diffs := DiffResources(sides[0].alert, sides[1].alert)
+
+	firstName, _ := sides[0].alert["name"].(string)
+	secondName, _ := sides[1].alert["name"].(string)
+
+	var sb strings.Builder
+	sb.WriteString("## Alert Comparison\n\n")
+	fmt.Fprintf(&sb, "**First:** %s (%s)\n", firstName, firstID)
+	fmt.Fprintf(&sb, "**Second:** %s (%s)\n\n", secondName, secondID)
+
+	if len(diffs) == 0 {
+		sb.WriteString("The alerts have identical configurations.\n")
+	} else {
+		counts := map[string]int{}
+		for _, d := range diffs {
+			counts[d.Change]++
+		}
+		fmt.Fprintf(&sb, "**%d difference(s):** %d changed, %d added, %d removed\n\n",
+			len(diffs), counts["changed"], counts["added"], counts["removed"])
+		sb.WriteString(FormatFieldDiffs(diffs))
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: ensureResponseLimit(sb.String(), t.logger)}},
+	}, nil
+}
@@ -0,0 +1,110 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestDiffResources(t *testing.T) {
+	oldRes := map[string]interface{}{
+		"id":         "a1",
+		"updated_at": "2024-01-01T00:00:00Z",
+		"name":       "High errors",
+		"severity":   "warning",
+		"condition":  map[string]interface{}{"threshold": float64(5)},
+		"tags":       []interface{}{"prod"},
+	}
+	newRes := map[string]interface{}{
+		"id":          "b2",
+		"updated_at":  "2024-02-01T00:00:00Z",
+		"name":        "High errors",
+		"severity":    "critical",
+		"condition":   map[string]interface{}{"threshold": float64(10)},
+		"description": "new",
+	}
+
+	diffs := DiffResources(oldRes, newRes)
+	got := make(map[string]string)
+	for _, d := range diffs {
+		got[d.Path] = d.Change
+	}
+	want := map[string]string{
+		"condition.threshold": "changed",
+		"description":         "added",
+		"severity":            "changed",
+		"tags[0]":             "removed",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("DiffResources() = %v, want %v", got, want)
+	}
+	for path, change := range want {
+		if got[path] != change {
+			t.Errorf("diff for %s = %q, want %q", path, got[path], change)
+		}
+	}
+
+	lines := FormatFieldDiffs(diffs)
+	if !strings.Contains(lines, "- `severity`: \"warning\" → \"critical\"") {
+		t.Errorf("Unexpected formatted diff:\n%s", lines)
+	}
+	if !strings.Contains(lines, "- `description`: (not set) → \"new\"") {
+		t.Errorf("Unexpected formatted diff:\n%s", lines)
+	}
+}
+
+func TestCompareAlertsTool_Execute(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.DoFunc = func(_ context.Context, req *client.Request) (*client.Response, error) {
+		body := `{"id":"a1","name":"Errors","severity":"warning"}`
+		if strings.HasSuffix(req.Path, "/a2") {
+			body = `{"id":"a2","name":"Errors","severity":"critical"}`
+		}
+		return &client.Response{StatusCode: http.StatusOK, Body: []byte(body)}, nil
+	}
+
+	tool := NewCompareAlertsTool(mock, zap.NewNop())
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{"first_id": "a1", "second_id": "a2"})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Expected success, got error result")
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(text, "`severity`: \"warning\" → \"critical\"") {
+		t.Errorf("Expected severity diff, got:\n%s", text)
+	}
+	if strings.Contains(text, "`id`") {
+		t.Errorf("Server-managed id should be ignored:\n%s", text)
+	}
+}
+
+func TestCompareAlertsTool_Execute_Missing(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.DoFunc = func(_ context.Context, req *client.Request) (*client.Response, error) {
+		if strings.HasSuffix(req.Path, "/gone") {
+			return &client.Response{StatusCode: http.StatusNotFound, Body: []byte(`{"message":"not found"}`)}, nil
+		}
+		return &client.Response{StatusCode: http.StatusOK, Body: []byte(`{"id":"a1","name":"Errors"}`)}, nil
+	}
+
+	tool := NewCompareAlertsTool(mock, zap.NewNop())
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{"first_id": "a1", "second_id": "gone"})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("Expected error result for missing alert")
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(text, "Second alert not found with ID: gone") {
+		t.Errorf("Error should identify the missing side, got: %s", text)
+	}
+}
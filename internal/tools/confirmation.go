@@ -0,0 +1,255 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements the two-phase confirmation required of destructive tools.
+package tools
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	mcperrors "github.com/tareqmamari/cloud-logs-mcp/internal/errors"
+)
+
+// ConfirmationTokenTTL is how long a confirmation token can be used to carry out a delete
+const ConfirmationTokenTTL = 5 * time.Minute
+
+// confirmationKey signs confirmation tokens; nil when confirmation is not required. It is
+// generated per process, so tokens do not survive a restart. usedConfirmations holds the
+// expiry of each token already redeemed, so a token confirms a single call.
+var (
+	confirmationMu    sync.RWMutex
+	confirmationKey   []byte
+	usedConfirmations map[string]int64
+)
+
+// confirmationClaims is the signed content of a confirmation token. The instance and args
+// hash bind the token to the exact call it was issued for.
+type confirmationClaims struct {
+	Tool       string `json:"tool"`
+	Instance   string `json:"instance,omitempty"`
+	ResourceID string `json:"resource_id,omitempty"`
+	ArgsHash   string `json:"args_hash"`
+	ExpiresAt  int64  `json:"exp"`
+}
+
+// SetRequireConfirmation turns the two-phase confirmation of destructive tools on or off
+func SetRequireConfirmation(enabled bool) {
+	var key []byte
+	if enabled {
+		key = make([]byte, 32)
+		_, _ = rand.Read(key) // crypto/rand.Read never fails
+	}
+	confirmationMu.Lock()
+	confirmationKey = key
+	usedConfirmations = make(map[string]int64)
+	confirmationMu.Unlock()
+}
+
+// IsConfirmationRequired reports whether destructive tools require a confirmation token
+func IsConfirmationRequired() bool {
+	confirmationMu.RLock()
+	defer confirmationMu.RUnlock()
+	return confirmationKey != nil
+}
+
+// IsDestructiveTool reports whether t deletes or cancels resources
+func IsDestructiveTool(t Tool) bool {
+	a := t.Annotations()
+	return a != nil && a.DestructiveHint != nil && *a.DestructiveHint
+}
+
+// SupportsDryRun reports whether t honors dry_run, previewing instead of carrying out the call
+func SupportsDryRun(t Tool) bool {
+	capability := GetToolCapability(t.Name())
+	return capability != nil && capability.SupportsDryRun
+}
+
+// StripUnsupportedDryRun removes dry_run from the arguments of a destructive tool that does
+// not honor it, so the flag cannot make a real delete look like a preview
+func StripUnsupportedDryRun(t Tool, args map[string]interface{}) {
+	if IsDestructiveTool(t) && !SupportsDryRun(t) {
+		delete(args, "dry_run")
+	}
+}
+
+// WithConfirmationParam returns a copy of a destructive tool's input schema with the
+// confirmation_token property added. Other schemas, and all schemas while confirmation is
+// not required, are returned unchanged.
+func WithConfirmationParam(t Tool, schema interface{}) interface{} {
+	if !IsConfirmationRequired() || !IsDestructiveTool(t) {
+		return schema
+	}
+	m, ok := schema.(map[string]interface{})
+	if !ok {
+		return schema
+	}
+	props, _ := m["properties"].(map[string]interface{})
+	newProps := make(map[string]interface{}, len(props)+1)
+	for k, v := range props {
+		newProps[k] = v
+	}
+	newProps["confirmation_token"] = map[string]interface{}{
+		"type":        "string",
+		"description": "Token returned by a first call without it. Show the user what will be deleted and pass the token only after they approve.",
+	}
+
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	out["properties"] = newProps
+	return out
+}
+
+// CheckConfirmation enforces the confirmation of a destructive call. It returns nil when the
+// call may proceed: confirmation is not required, the tool is not destructive, the call is a
+// dry run of a tool that supports them, or it carries a valid, unused token for these
+// arguments on the instance the call is routed to. Otherwise it returns the result to send
+// instead: a new token describing the delete, or an error for a bad token.
+func CheckConfirmation(ctx context.Context, t Tool, args map[string]interface{}) *mcp.CallToolResult {
+	confirmationMu.RLock()
+	key := confirmationKey
+	confirmationMu.RUnlock()
+	if key == nil || !IsDestructiveTool(t) {
+		return nil
+	}
+	if dryRun, _ := args["dry_run"].(bool); dryRun && SupportsDryRun(t) {
+		return nil
+	}
+
+	claims := confirmationClaims{
+		Tool:       t.Name(),
+		Instance:   GetInstanceNameFromContext(ctx),
+		ResourceID: auditResourceID(args, nil),
+		ArgsHash:   hashConfirmedArgs(args),
+	}
+	token, _ := args["confirmation_token"].(string)
+	if token == "" {
+		claims.ExpiresAt = time.Now().Add(ConfirmationTokenTTL).Unix()
+		return newConfirmationRequest(t, claims, signConfirmation(key, claims))
+	}
+
+	issued, err := verifyConfirmation(key, token)
+	if err == nil && (issued.Tool != claims.Tool || issued.Instance != claims.Instance || issued.ArgsHash != claims.ArgsHash) {
+		err = fmt.Errorf("the token was issued for a different call; pass exactly the arguments of the call that returned it")
+	}
+	if err == nil {
+		err = redeemConfirmation(token, issued.ExpiresAt)
+	}
+	if err != nil {
+		return newToolError(mcperrors.CodeInvalidInput, "Invalid confirmation token: "+err.Error(),
+			"Call "+t.Name()+" again without confirmation_token to get a new token.")
+	}
+	return nil
+}
+
+// redeemConfirmation marks a token as used, failing if it already was. Expired entries are
+// pruned since verifyConfirmation rejects those tokens anyway.
+func redeemConfirmation(token string, expiresAt int64) error {
+	confirmationMu.Lock()
+	defer confirmationMu.Unlock()
+	now := time.Now().Unix()
+	for used, exp := range usedConfirmations {
+		if now > exp {
+			delete(usedConfirmations, used)
+		}
+	}
+	if _, used := usedConfirmations[token]; used {
+		return fmt.Errorf("the token was already used; each token confirms a single call")
+	}
+	if usedConfirmations == nil {
+		usedConfirmations = make(map[string]int64)
+	}
+	usedConfirmations[token] = expiresAt
+	return nil
+}
+
+// hashConfirmedArgs hashes the arguments that decide what a call deletes
+func hashConfirmedArgs(args map[string]interface{}) string {
+	filtered := make(map[string]interface{}, len(args))
+	for k, v := range args {
+		if k != "confirmation_token" && k != "timeout_seconds" {
+			filtered[k] = v
+		}
+	}
+	return hashAuditInput(filtered)
+}
+
+// signConfirmation encodes claims as payload.signature, both base64url
+func signConfirmation(key []byte, claims confirmationClaims) string {
+	payload, _ := json.Marshal(claims)
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// verifyConfirmation checks a token's signature and expiry and returns its claims
+func verifyConfirmation(key []byte, token string) (*confirmationClaims, error) {
+	encodedPayload, encodedSig, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, fmt.Errorf("malformed token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, fmt.Errorf("malformed token")
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(encodedSig)
+	if err != nil {
+		return nil, fmt.Errorf("malformed token")
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return nil, fmt.Errorf("signature mismatch (tokens do not survive a server restart)")
+	}
+
+	var claims confirmationClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("malformed token")
+	}
+	if time.Now().Unix() > claims.ExpiresAt {
+		return nil, fmt.Errorf("the token expired at %s", time.Unix(claims.ExpiresAt, 0).UTC().Format(time.RFC3339))
+	}
+	return &claims, nil
+}
+
+// newConfirmationRequest describes the pending delete and hands out its token. It is not an
+// error result: nothing failed, the call is waiting for the user's approval.
+func newConfirmationRequest(t Tool, claims confirmationClaims, token string) *mcp.CallToolResult {
+	action := t.Name()
+	if a := t.Annotations(); a != nil && a.Title != "" {
+		action = a.Title
+	}
+	if claims.ResourceID != "" {
+		action += " '" + claims.ResourceID + "'"
+	}
+	response := map[string]interface{}{
+		"confirmation_required": true,
+		"action":                action,
+		"tool":                  claims.Tool,
+		"confirmation_token":    token,
+		"expires_at":            time.Unix(claims.ExpiresAt, 0).UTC().Format(time.RFC3339),
+		"next_step": fmt.Sprintf("Nothing was deleted. Ask the user to approve \"%s\"; if they do, call %s again with the same arguments plus confirmation_token.",
+			action, claims.Tool),
+	}
+	if claims.ResourceID != "" {
+		response["resource_id"] = claims.ResourceID
+	}
+	if capability := GetToolCapability(claims.Tool); capability != nil && capability.ResourceType != "" {
+		response["resource_type"] = capability.ResourceType
+	}
+	data, _ := json.MarshalIndent(response, "", "  ")
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+		Meta:    mcp.Meta{"confirmation_required": true},
+	}
+}
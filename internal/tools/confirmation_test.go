@@ -0,0 +1,100 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestCheckConfirmation(t *testing.T) {
+	mock := client.NewMockClient()
+	logger := zap.NewNop()
+	deleteAlert := NewDeleteAlertTool(mock, logger)
+	ctx := context.Background()
+	assert.Nil(t, CheckConfirmation(ctx, deleteAlert, map[string]interface{}{"id": "a1"}), "off by default")
+
+	SetRequireConfirmation(true)
+	t.Cleanup(func() { SetRequireConfirmation(false) })
+
+	assert.Nil(t, CheckConfirmation(ctx, NewListAlertsTool(mock, logger), nil), "only destructive tools are confirmed")
+	assert.Nil(t, CheckConfirmation(ctx, NewDeletePolicyTool(mock, logger), map[string]interface{}{"id": "p1", "dry_run": true}))
+
+	pending := CheckConfirmation(ctx, deleteAlert, map[string]interface{}{"id": "a1"})
+	require.NotNil(t, pending)
+	assert.False(t, pending.IsError)
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(pending.Content[0].(*mcp.TextContent).Text), &response))
+	assert.Equal(t, "Delete Alert 'a1'", response["action"])
+	assert.Equal(t, "a1", response["resource_id"])
+	token := response["confirmation_token"].(string)
+
+	assert.NotNil(t, CheckConfirmation(ctx, deleteAlert, map[string]interface{}{"id": "a1", "dry_run": true}), "delete_alert has no dry run")
+	bad := CheckConfirmation(WithInstanceName(ctx, "staging"), deleteAlert, map[string]interface{}{"id": "a1", "confirmation_token": token})
+	require.NotNil(t, bad)
+	assert.Contains(t, bad.Content[0].(*mcp.TextContent).Text, "issued for a different call", "tokens are bound to the instance")
+
+	assert.Nil(t, CheckConfirmation(ctx, deleteAlert, map[string]interface{}{"id": "a1", "confirmation_token": token, "timeout_seconds": 30.0}))
+	replayed := CheckConfirmation(ctx, deleteAlert, map[string]interface{}{"id": "a1", "confirmation_token": token})
+	require.NotNil(t, replayed)
+	assert.Contains(t, replayed.Content[0].(*mcp.TextContent).Text, "already used")
+
+	bad = CheckConfirmation(ctx, deleteAlert, map[string]interface{}{"id": "a2", "confirmation_token": token})
+	require.NotNil(t, bad)
+	assert.True(t, bad.IsError)
+	assert.Contains(t, bad.Content[0].(*mcp.TextContent).Text, "issued for a different call")
+
+	bad = CheckConfirmation(ctx, NewDeleteViewTool(mock, logger), map[string]interface{}{"id": "a1", "confirmation_token": token})
+	assert.Contains(t, bad.Content[0].(*mcp.TextContent).Text, "issued for a different call")
+
+	bad = CheckConfirmation(ctx, deleteAlert, map[string]interface{}{"id": "a1", "confirmation_token": token[:len(token)-2] + "AA"})
+	assert.Contains(t, bad.Content[0].(*mcp.TextContent).Text, "signature mismatch")
+
+	key := make([]byte, 32)
+	expired := signConfirmation(key, confirmationClaims{Tool: "delete_alert", ArgsHash: hashConfirmedArgs(map[string]interface{}{"id": "a1"}), ExpiresAt: 1})
+	_, err := verifyConfirmation(key, expired)
+	assert.ErrorContains(t, err, "expired")
+}
+
+func TestExecuteWithTimeout_RequireConfirmation(t *testing.T) {
+	SetRequireConfirmation(true)
+	t.Cleanup(func() { SetRequireConfirmation(false) })
+
+	mock := client.NewMockClient()
+	mock.RespondWith(204, nil)
+	tool := NewDeleteAlertTool(mock, zap.NewNop())
+
+	result, err := ExecuteWithTimeout(context.Background(), tool, map[string]interface{}{"id": "a1"})
+	require.NoError(t, err)
+	assert.Equal(t, true, result.Meta["confirmation_required"])
+	assert.Zero(t, mock.RequestCount(), "nothing is deleted before confirmation")
+	_, audited := BuildAuditEntry(context.Background(), tool, map[string]interface{}{"id": "a1"}, result, nil, 0)
+	assert.False(t, audited)
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &response))
+	result, err = ExecuteWithTimeout(testCtx(mock), tool, map[string]interface{}{"id": "a1", "confirmation_token": response["confirmation_token"]})
+	require.NoError(t, err)
+	require.False(t, result.IsError, "%v", result.Content)
+	assert.Equal(t, "DELETE", mock.LastRequest().Method)
+	assert.Equal(t, "/v1/alerts/a1", mock.LastRequest().Path)
+
+	// dry_run does not bypass the confirmation of a tool that would ignore it
+	args := map[string]interface{}{"id": "a2", "dry_run": true}
+	result, err = ExecuteWithTimeout(testCtx(mock), tool, args)
+	require.NoError(t, err)
+	assert.Equal(t, true, result.Meta["confirmation_required"])
+	assert.NotContains(t, args, "dry_run")
+	assert.Equal(t, 1, mock.RequestCount())
+
+	schema := WithConfirmationParam(tool, tool.InputSchema()).(map[string]interface{})
+	assert.Contains(t, schema["properties"], "confirmation_token")
+	schema = WithConfirmationParam(NewListAlertsTool(mock, zap.NewNop()), NewListAlertsTool(mock, zap.NewNop()).InputSchema()).(map[string]interface{})
+	assert.NotContains(t, schema["properties"], "confirmation_token")
+}
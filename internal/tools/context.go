@@ -18,6 +18,8 @@ const (
 	sessionContextKey contextKey = "session"
 	// sessionProviderContextKey is the context key for the session provider.
 	sessionProviderContextKey contextKey = "session_provider"
+	// instanceContextKey is the context key for the name of the instance a call is routed to.
+	instanceContextKey contextKey = "instance"
 )
 
 // ErrNoClientInContext is returned when no API client is found in the context.
@@ -40,6 +42,18 @@ func GetClientFromContext(ctx context.Context) (client.Doer, error) {
 	return c, nil
 }
 
+// WithInstanceName records the name of the instance a call is routed to.
+func WithInstanceName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, instanceContextKey, name)
+}
+
+// GetInstanceNameFromContext returns the name of the instance a call is routed to,
+// or "" when the call was not routed (single instance, tests).
+func GetInstanceNameFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(instanceContextKey).(string)
+	return name
+}
+
 // WithSession adds a session context to the context.
 // This enables per-request session injection for better testability
 // and multi-tenant scenarios.
@@ -48,7 +62,7 @@ func WithSession(ctx context.Context, session *SessionContext) context.Context {
 }
 
 // GetSessionFromContext retrieves the session from the context.
-// Falls back to the global session if not found in context.
+// Falls back to the global session if not found in context, so it never returns nil.
 // This provides backward compatibility while enabling context-based testing.
 func GetSessionFromContext(ctx context.Context) *SessionContext {
 	if session, ok := ctx.Value(sessionContextKey).(*SessionContext); ok && session != nil {
@@ -0,0 +1,266 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// alertPriorityBySeverity maps alert severities to alert definition priorities
+var alertPriorityBySeverity = map[string]string{
+	"critical": "P1",
+	"error":    "P2",
+	"warning":  "P3",
+	"info":     "P4",
+}
+
+// aggregationStagePattern matches DataPrime stages that change the result shape.
+// Threshold alerts count matching events, so the query must be filter-only.
+var aggregationStagePattern = regexp.MustCompile(`(?i)\|\s*(groupby|aggregate|count|countby|distinct|top|bottom|choose|limit)\b`)
+
+// Alert time window bounds for threshold alerts
+const (
+	minAlertTimeWindow = time.Minute
+	maxAlertTimeWindow = 24 * time.Hour
+)
+
+// CreateAlertFromQueryTool turns an ad-hoc query into a threshold alert definition
+type CreateAlertFromQueryTool struct{ *BaseTool }
+
+// NewCreateAlertFromQueryTool creates a new tool instance
+func NewCreateAlertFromQueryTool(c client.Doer, l *zap.Logger) *CreateAlertFromQueryTool {
+	return &CreateAlertFromQueryTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *CreateAlertFromQueryTool) Name() string { return "create_alert_from_query" }
+
+// Annotations returns tool hints for LLMs
+func (t *CreateAlertFromQueryTool) Annotations() *mcp.ToolAnnotations {
+	return CreateAnnotations("Create Alert From Query")
+}
+
+// Description returns the tool description
+func (t *CreateAlertFromQueryTool) Description() string {
+	return `Create a threshold alert definition directly from a DataPrime query.
+
+Assembles a logs_threshold alert definition that triggers when the number of events matching
+the query crosses the threshold within the time window, and optionally notifies a webhook.
+The query must be filter-only (no groupby/count/limit stages) since the alert counts matches.
+Alert definitions filter with Lucene, so the filter is converted (see convert_dataprime_to_lucene)
+and queries without an exact Lucene equivalent are refused.
+
+**When to use:**
+- After query_logs found a problem you want to be alerted on next time
+- Closing the loop in an error investigation without hand-building the alert body
+
+**Tip:** Run with dry_run=true first to preview the generated definition.
+
+**Related tools:** query_logs, create_alert_definition, list_outgoing_webhooks, suggest_alert`
+}
+
+// InputSchema returns the input schema
+func (t *CreateAlertFromQueryTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"query": map[string]interface{}{
+				"type":        "string",
+				"description": "Filter-only DataPrime query selecting the events to count",
+				"examples": []interface{}{
+					"source logs | filter $m.severity >= ERROR && $l.applicationname == 'api-gateway'",
+				},
+			},
+			"threshold": map[string]interface{}{
+				"type":        "number",
+				"description": "Number of matching events that triggers the alert",
+				"minimum":     0,
+			},
+			"time_window": map[string]interface{}{
+				"type":        "string",
+				"description": "Evaluation window as a duration (1m to 24h)",
+				"default":     "5m",
+				"examples":    []interface{}{"5m", "15m", "1h"},
+			},
+			"severity": map[string]interface{}{
+				"type":        "string",
+				"description": "Alert severity, mapped to priority (critical=P1, error=P2, warning=P3, info=P4)",
+				"enum":        []string{"info", "warning", "error", "critical"},
+				"default":     "error",
+			},
+			"condition": map[string]interface{}{
+				"type":        "string",
+				"description": "Trigger when the count is more_than or less_than the threshold",
+				"enum":        []string{"more_than", "less_than"},
+				"default":     "more_than",
+			},
+			"name": map[string]interface{}{
+				"type":        "string",
+				"description": "Alert name (default: derived from severity and threshold)",
+			},
+			"webhook_id": map[string]interface{}{
+				"type":        "string",
+				"description": "Optional outgoing webhook ID to notify when the alert triggers",
+			},
+			"dry_run": map[string]interface{}{
+				"type":        "boolean",
+				"description": "If true, validate and preview the generated alert definition without creating it",
+				"default":     false,
+			},
+		},
+		"required": []string{"query", "threshold"},
+	}
+}
+
+// Execute executes the tool
+func (t *CreateAlertFromQueryTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	def, err := buildAlertDefinitionFromQuery(args)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+
+	if dryRun, _ := GetBoolParam(args, "dry_run", false); dryRun {
+		result := &ValidationResult{
+			Valid: true,
+			Summary: map[string]interface{}{
+				"name":     def["name"],
+				"type":     def["type"],
+				"priority": def["priority"],
+			},
+			EstimatedImpact: &ImpactEstimate{RiskLevel: "low"},
+		}
+		if _, hasNotifications := def["notification_groups"]; !hasNotifications {
+			result.Warnings = append(result.Warnings, "No webhook_id provided - the alert will trigger without sending notifications")
+		}
+		result.Suggestions = append(result.Suggestions, "Remove dry_run parameter to create the alert definition")
+		return FormatDryRunResult(result, "Alert Definition", def), nil
+	}
+
+	session := GetSessionFromContext(ctx)
+	result, err := t.ExecuteRequest(ctx, &client.Request{Method: "POST", Path: "/v1/alert_definitions", Body: def})
+	if err != nil {
+		session.RecordToolUse(t.Name(), false, map[string]interface{}{"error": err.Error()})
+		return NewToolResultErrorFromErr(err), nil
+	}
+
+	GetCacheHelperFromContext(ctx).InvalidateRelated("create_alert_definition")
+	session.RecordToolUse(t.Name(), true, map[string]interface{}{"alert_name": def["name"]})
+
+	return t.FormatResponseWithSuggestions(result, "create_alert_definition")
+}
+
+// buildAlertDefinitionFromQuery validates the arguments and assembles a logs_threshold alert definition
+func buildAlertDefinitionFromQuery(args map[string]interface{}) (map[string]interface{}, error) {
+	query, err := GetStringParam(args, "query", true)
+	if err != nil {
+		return nil, err
+	}
+	if aggregationStagePattern.MatchString(query) {
+		return nil, fmt.Errorf("query must be filter-only: threshold alerts count matching events, so remove groupby/count/aggregate/limit stages")
+	}
+	query, _, err = PrepareQuery(query, "frequent_search", "dataprime")
+	if err != nil {
+		return nil, err
+	}
+	// The alert definition's simple filter takes Lucene, so the query must convert exactly
+	conversion := ConvertDataPrimeToLucene(query)
+	if !conversion.Exact {
+		reasons := make([]string, len(conversion.Unsupported))
+		for i, u := range conversion.Unsupported {
+			reasons[i] = fmt.Sprintf("%s (%s)", u.Construct, u.Reason)
+		}
+		return nil, fmt.Errorf("query cannot be used as an alert filter: it has no exact Lucene equivalent: %s", strings.Join(reasons, "; "))
+	}
+
+	threshold, ok := args["threshold"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("threshold is required and must be a number")
+	}
+	if threshold < 0 {
+		return nil, fmt.Errorf("threshold must not be negative")
+	}
+
+	windowStr, _ := GetStringParam(args, "time_window", false)
+	if windowStr == "" {
+		windowStr = "5m"
+	}
+	window, err := time.ParseDuration(windowStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time_window '%s': use a duration such as 5m or 1h", windowStr)
+	}
+	if window < minAlertTimeWindow || window > maxAlertTimeWindow {
+		return nil, fmt.Errorf("time_window must be between %s and %s", minAlertTimeWindow, maxAlertTimeWindow)
+	}
+
+	severity, _ := GetStringParam(args, "severity", false)
+	if severity == "" {
+		severity = "error"
+	}
+	priority, ok := alertPriorityBySeverity[severity]
+	if !ok {
+		return nil, fmt.Errorf("invalid severity '%s' (valid: info, warning, error, critical)", severity)
+	}
+
+	condition, _ := GetStringParam(args, "condition", false)
+	if condition == "" {
+		condition = "more_than"
+	}
+	if condition != "more_than" && condition != "less_than" {
+		return nil, fmt.Errorf("invalid condition '%s' (valid: more_than, less_than)", condition)
+	}
+
+	name, _ := GetStringParam(args, "name", false)
+	if name == "" {
+		direction := "more than"
+		if condition == "less_than" {
+			direction = "fewer than"
+		}
+		name = fmt.Sprintf("%s: %s %g matching events in %s", severity, direction, threshold, windowStr)
+	}
+
+	def := map[string]interface{}{
+		"name":        name,
+		"description": "Created from query: " + query,
+		"enabled":     true,
+		"priority":    priority,
+		"type":        "logs_threshold",
+		"condition": map[string]interface{}{
+			"threshold": map[string]interface{}{
+				"condition":            condition,
+				"threshold":            threshold,
+				"time_window_seconds":  int(window.Seconds()),
+				"group_by_keys":        []string{},
+				"condition_match_type": "any",
+			},
+		},
+		"filter": map[string]interface{}{
+			"simple_filter": map[string]interface{}{
+				"query": conversion.LuceneQuery,
+			},
+		},
+	}
+
+	if webhookID, _ := GetStringParam(args, "webhook_id", false); webhookID != "" {
+		def["notification_groups"] = []interface{}{
+			map[string]interface{}{
+				"notifications": []interface{}{
+					map[string]interface{}{
+						"webhook_id":                  webhookID,
+						"notify_on":                   "triggered_only",
+						"retriggering_period_seconds": 60,
+						"notify_on_resolved":          true,
+					},
+				},
+			},
+		}
+	}
+
+	return def, nil
+}
@@ -0,0 +1,105 @@
+package tools
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestBuildAlertDefinitionFromQuery(t *testing.T) {
+	def, err := buildAlertDefinitionFromQuery(map[string]interface{}{
+		"query":       "source logs | filter $m.severity >= ERROR",
+		"threshold":   float64(50),
+		"time_window": "15m",
+		"severity":    "critical",
+		"webhook_id":  "wh-1",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "logs_threshold", def["type"])
+	assert.Equal(t, "P1", def["priority"])
+
+	threshold := def["condition"].(map[string]interface{})["threshold"].(map[string]interface{})
+	assert.Equal(t, float64(50), threshold["threshold"])
+	assert.Equal(t, 900, threshold["time_window_seconds"])
+	assert.Equal(t, "more_than", threshold["condition"])
+
+	filter := def["filter"].(map[string]interface{})["simple_filter"].(map[string]interface{})
+	assert.Equal(t, "severity:>=5", filter["query"])
+	assert.NotContains(t, filter["query"], "source logs", "the simple filter takes Lucene, not DataPrime")
+
+	groups := def["notification_groups"].([]interface{})
+	notification := groups[0].(map[string]interface{})["notifications"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "wh-1", notification["webhook_id"])
+}
+
+func TestBuildAlertDefinitionFromQuery_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]interface{}
+		wantErr string
+	}{
+		{"missing query", map[string]interface{}{"threshold": float64(1)}, "query"},
+		{"missing threshold", map[string]interface{}{"query": "source logs"}, "threshold"},
+		{"aggregation query", map[string]interface{}{"query": "source logs | groupby $l.applicationname count()", "threshold": float64(1)}, "filter-only"},
+		{"bad window", map[string]interface{}{"query": "source logs", "threshold": float64(1), "time_window": "soon"}, "time_window"},
+		{"window too long", map[string]interface{}{"query": "source logs", "threshold": float64(1), "time_window": "48h"}, "time_window"},
+		{"no lucene equivalent", map[string]interface{}{"query": "source logs | filter $d.message:string.contains('timeout') | extract $d.message into $d.parsed using regexp(e=/(?<code>\\d+)/)", "threshold": float64(1)}, "no exact Lucene equivalent"},
+		{"bad severity", map[string]interface{}{"query": "source logs", "threshold": float64(1), "severity": "fatal"}, "severity"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildAlertDefinitionFromQuery(tt.args)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCreateAlertFromQueryTool_DryRun(t *testing.T) {
+	mock := client.NewMockClient()
+	tool := NewCreateAlertFromQueryTool(mock, zap.NewNop())
+
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{
+		"query":     "source logs | filter $m.severity >= ERROR",
+		"threshold": float64(10),
+		"dry_run":   true,
+	})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Equal(t, 0, mock.RequestCount())
+
+	text := result.Content[0].(*mcp.TextContent).Text
+	assert.True(t, strings.Contains(text, "No webhook_id provided"))
+}
+
+func TestCreateAlertFromQueryTool_Create(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.RespondWith(200, map[string]interface{}{"id": "def-1", "name": "error alert"})
+	tool := NewCreateAlertFromQueryTool(mock, zap.NewNop())
+
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{
+		"query":     "source logs | filter $m.severity >= ERROR",
+		"threshold": float64(10),
+	})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+
+	req := mock.LastRequest()
+	assert.Equal(t, "POST", req.Method)
+	assert.Equal(t, "/v1/alert_definitions", req.Path)
+
+	body, err := json.Marshal(req.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"type":"logs_threshold"`)
+	filter := req.Body.(map[string]interface{})["filter"].(map[string]interface{})["simple_filter"].(map[string]interface{})
+	assert.Equal(t, "severity:>=5", filter["query"])
+}
@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	// Wednesday
+	from := time.Date(2024, 5, 1, 20, 47, 12, 0, time.UTC)
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2024, 5, 1, 21, 0, 0, 0, time.UTC)},
+		{"0 8 * * *", time.Date(2024, 5, 2, 8, 0, 0, 0, time.UTC)},
+		{"30 9 * * 1-5", time.Date(2024, 5, 2, 9, 30, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, 5, 5, 0, 0, 0, 0, time.UTC)},
+		{"0 6 1,15 * *", time.Date(2024, 5, 15, 6, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, 5, 1, 21, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2024, 5, 5, 0, 0, 0, 0, time.UTC)},
+		{"@every 90m", time.Date(2024, 5, 1, 22, 17, 12, 0, time.UTC)},
+		// Both day fields restricted: either matches (the 3rd, or a Monday)
+		{"0 0 3 * 1", time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			s, err := ParseCronSchedule(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, s.Next(from))
+			assert.Equal(t, tt.spec, s.String())
+		})
+	}
+}
+
+func TestParseCronScheduleErrors(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"0 24 * * *",
+		"0 0 0 * *",
+		"0 0 * 13 *",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"0 0 30 2 *",
+		"@every 10s",
+		"@every soon",
+		"@yearly",
+	} {
+		_, err := ParseCronSchedule(spec)
+		assert.Error(t, err, spec)
+	}
+}
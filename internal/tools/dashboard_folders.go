@@ -3,6 +3,7 @@ package tools
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"go.uber.org/zap"
@@ -29,19 +30,19 @@ func (t *ListDashboardFoldersTool) Name() string {
 
 // Description returns a human-readable description of the tool.
 func (t *ListDashboardFoldersTool) Description() string {
-	return "List all dashboard folders for organizing dashboards in IBM Cloud Logs"
+	return "List all dashboard folders for organizing dashboards in IBM Cloud Logs. Each folder includes its id, parent_id, and a path such as \"Production / Payments\" showing where it is nested."
 }
 
 // InputSchema returns the JSON schema for the tool's input parameters.
 func (t *ListDashboardFoldersTool) InputSchema() interface{} {
 	return map[string]interface{}{
 		"type":       "object",
-		"properties": map[string]interface{}{},
+		"properties": withSummaryOnly(map[string]interface{}{}),
 	}
 }
 
 // Execute lists all dashboard folders.
-func (t *ListDashboardFoldersTool) Execute(ctx context.Context, _ map[string]interface{}) (*mcp.CallToolResult, error) {
+func (t *ListDashboardFoldersTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	req := &client.Request{
 		Method: "GET",
 		Path:   "/v1/folders",
@@ -49,10 +50,48 @@ func (t *ListDashboardFoldersTool) Execute(ctx context.Context, _ map[string]int
 
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
+	}
+	annotateFolderPaths(result)
+
+	return t.FormatListResponse(result, args, "list_dashboard_folders")
+}
+
+// annotateFolderPaths sets each folder's path (ancestor names joined with " / ") and depth
+// (0 for top-level folders) from the parent_id links. Folders whose parent is missing from
+// the list are treated as top-level; parent cycles are cut at the first repeat.
+func annotateFolderPaths(result map[string]interface{}) {
+	folders, _ := result["folders"].([]interface{})
+	byID := make(map[string]map[string]interface{}, len(folders))
+	for _, f := range folders {
+		if folder, ok := f.(map[string]interface{}); ok {
+			if id, _ := folder["id"].(string); id != "" {
+				byID[id] = folder
+			}
+		}
+	}
+
+	for _, f := range folders {
+		folder, ok := f.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		var names []string
+		visited := map[string]bool{}
+		for current := folder; current != nil; {
+			id, _ := current["id"].(string)
+			if visited[id] {
+				break
+			}
+			visited[id] = true
+			name, _ := current["name"].(string)
+			names = append([]string{name}, names...)
+			parentID, _ := current["parent_id"].(string)
+			current = byID[parentID]
+		}
+		folder["path"] = strings.Join(names, " / ")
+		folder["depth"] = len(names) - 1
 	}
-
-	return t.FormatResponseWithSuggestions(result, "list_dashboard_folders")
 }
 
 // GetDashboardFolderTool gets a specific dashboard folder by ID.
@@ -148,6 +187,10 @@ func (t *CreateDashboardFolderTool) InputSchema() interface{} {
 			},
 		},
 		"required": []string{"name"},
+		"examples": []interface{}{
+			map[string]interface{}{"name": "Production"},
+			map[string]interface{}{"name": "Payments", "parent_id": "parent-folder-uuid"},
+		},
 	}
 }
 
@@ -174,7 +217,7 @@ func (t *CreateDashboardFolderTool) Execute(ctx context.Context, arguments map[s
 
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	return t.FormatResponseWithSuggestions(result, "create_dashboard_folder")
@@ -221,6 +264,9 @@ func (t *UpdateDashboardFolderTool) InputSchema() interface{} {
 			},
 		},
 		"required": []string{"folder_id", "name"},
+		"examples": []interface{}{
+			map[string]interface{}{"folder_id": "folder-uuid", "name": "Production Services"},
+		},
 	}
 }
 
@@ -241,6 +287,9 @@ func (t *UpdateDashboardFolderTool) Execute(ctx context.Context, arguments map[s
 	}
 
 	if parentID, ok := arguments["parent_id"].(string); ok && parentID != "" {
+		if parentID == folderID {
+			return NewToolResultError("parent_id cannot be the folder itself"), nil
+		}
 		body["parent_id"] = parentID
 	}
 
@@ -252,7 +301,7 @@ func (t *UpdateDashboardFolderTool) Execute(ctx context.Context, arguments map[s
 
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	return t.FormatResponseWithSuggestions(result, "update_dashboard_folder")
@@ -313,7 +362,7 @@ func (t *DeleteDashboardFolderTool) Execute(ctx context.Context, arguments map[s
 
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	return t.FormatResponseWithSuggestions(result, "delete_dashboard_folder")
@@ -378,7 +427,7 @@ func (t *MoveDashboardToFolderTool) Execute(ctx context.Context, arguments map[s
 
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	return t.FormatResponseWithSuggestions(result, "move_dashboard_to_folder")
@@ -434,10 +483,10 @@ func (t *PinDashboardTool) Execute(ctx context.Context, arguments map[string]int
 
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return HandleGetError(err, "Dashboard", dashboardID, "list_dashboards"), nil
 	}
 
-	return t.FormatResponseWithSuggestions(result, "pin_dashboard")
+	return t.FormatResponseWithSuggestions(dashboardStateResult(result, dashboardID, "pinned", true), "pin_dashboard")
 }
 
 // UnpinDashboardTool unpins a dashboard.
@@ -490,10 +539,10 @@ func (t *UnpinDashboardTool) Execute(ctx context.Context, arguments map[string]i
 
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return HandleGetError(err, "Dashboard", dashboardID, "list_dashboards"), nil
 	}
 
-	return t.FormatResponseWithSuggestions(result, "unpin_dashboard")
+	return t.FormatResponseWithSuggestions(dashboardStateResult(result, dashboardID, "pinned", false), "unpin_dashboard")
 }
 
 // SetDefaultDashboardTool sets a dashboard as the default.
@@ -546,8 +595,23 @@ func (t *SetDefaultDashboardTool) Execute(ctx context.Context, arguments map[str
 
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return HandleGetError(err, "Dashboard", dashboardID, "list_dashboards"), nil
 	}
 
-	return t.FormatResponseWithSuggestions(result, "set_default_dashboard")
+	return t.FormatResponseWithSuggestions(dashboardStateResult(result, dashboardID, "is_default", true), "set_default_dashboard")
+}
+
+// dashboardStateResult reports a dashboard's pinned or default state after a change. The
+// catalog endpoints usually return an empty body, so the state is filled in when missing.
+func dashboardStateResult(result map[string]interface{}, dashboardID, field string, value bool) map[string]interface{} {
+	if result == nil {
+		result = make(map[string]interface{})
+	}
+	if _, ok := result["dashboard_id"]; !ok {
+		result["dashboard_id"] = dashboardID
+	}
+	if _, ok := result[field]; !ok {
+		result[field] = value
+	}
+	return result
 }
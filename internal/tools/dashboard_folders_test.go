@@ -3,7 +3,11 @@ package tools
 import (
 	"testing"
 
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
 )
 
 func TestListDashboardFoldersTool_InputSchema(t *testing.T) {
@@ -84,3 +88,83 @@ func TestMoveDashboardToFolderTool_InputSchema(t *testing.T) {
 	folderIDProp := props["folder_id"].(map[string]interface{})
 	assert.Equal(t, "string", folderIDProp["type"])
 }
+
+func TestPinAndDefaultDashboardTools_Execute(t *testing.T) {
+	tests := []struct {
+		name   string
+		tool   func(client.Doer) Tool
+		method string
+		path   string
+		state  string
+	}{
+		{"pin", func(c client.Doer) Tool { return NewPinDashboardTool(c, zap.NewNop()) }, "PUT", "/v1/dashboards/dash-1/pinned", `"pinned": true`},
+		{"unpin", func(c client.Doer) Tool { return NewUnpinDashboardTool(c, zap.NewNop()) }, "DELETE", "/v1/dashboards/dash-1/pinned", `"pinned": false`},
+		{"set default", func(c client.Doer) Tool { return NewSetDefaultDashboardTool(c, zap.NewNop()) }, "PUT", "/v1/dashboards/dash-1/default", `"is_default": true`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := client.NewMockClient()
+			mock.RespondWith(200, map[string]interface{}{})
+
+			result, err := tt.tool(mock).Execute(testCtx(mock), map[string]interface{}{"dashboard_id": "dash-1"})
+			assert.NoError(t, err)
+			assert.False(t, result.IsError)
+			assert.Equal(t, tt.method, mock.LastRequest().Method)
+			assert.Equal(t, tt.path, mock.LastRequest().Path)
+			assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, tt.state)
+		})
+	}
+
+	t.Run("missing dashboard", func(t *testing.T) {
+		mock := client.NewMockClient()
+		mock.RespondWith(404, map[string]interface{}{"message": "not found"})
+
+		result, _ := NewPinDashboardTool(mock, zap.NewNop()).Execute(testCtx(mock), map[string]interface{}{"dashboard_id": "missing"})
+		assert.True(t, result.IsError)
+		text := result.Content[0].(*mcp.TextContent).Text
+		assert.Contains(t, text, "Dashboard not found with ID: missing")
+		assert.Contains(t, text, "list_dashboards")
+	})
+}
+
+func TestAnnotateFolderPaths(t *testing.T) {
+	result := map[string]interface{}{
+		"folders": []interface{}{
+			map[string]interface{}{"id": "f1", "name": "Production"},
+			map[string]interface{}{"id": "f2", "name": "Payments", "parent_id": "f1"},
+			map[string]interface{}{"id": "f3", "name": "Refunds", "parent_id": "f2"},
+			map[string]interface{}{"id": "f4", "name": "Orphan", "parent_id": "missing"},
+			map[string]interface{}{"id": "f5", "name": "Loop", "parent_id": "f5"},
+		},
+	}
+	annotateFolderPaths(result)
+
+	folders := result["folders"].([]interface{})
+	want := []struct {
+		path  string
+		depth int
+	}{
+		{"Production", 0},
+		{"Production / Payments", 1},
+		{"Production / Payments / Refunds", 2},
+		{"Orphan", 0},
+		{"Loop", 0},
+	}
+	for i, w := range want {
+		folder := folders[i].(map[string]interface{})
+		assert.Equal(t, w.path, folder["path"])
+		assert.Equal(t, w.depth, folder["depth"])
+	}
+}
+
+func TestUpdateDashboardFolderTool_RejectsSelfParent(t *testing.T) {
+	mock := client.NewMockClient()
+	tool := NewUpdateDashboardFolderTool(mock, zap.NewNop())
+
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{
+		"folder_id": "f1", "name": "Production", "parent_id": "f1",
+	})
+	assert.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Equal(t, 0, mock.RequestCount())
+}
@@ -0,0 +1,308 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// templateWidget is one widget of a dashboard template. Query is a DataPrime query
+// with an {APP_FILTER} placeholder that is replaced by the application filter.
+type templateWidget struct {
+	Title string
+	Type  string // "line_chart", "pie_chart", or "data_table"
+	Query string
+}
+
+// dashboardTemplate is a named dashboard layout scoped to one application
+type dashboardTemplate struct {
+	Description string
+	Rows        [][]templateWidget
+}
+
+// dashboardTemplates holds the layouts offered by create_dashboard_from_template
+var dashboardTemplates = map[string]dashboardTemplate{
+	"service_overview": {
+		Description: "Error trend, severity breakdown, and busiest subsystems",
+		Rows: [][]templateWidget{
+			{
+				{Title: "Error Count", Type: "line_chart", Query: "source logs | filter {APP_FILTER} && $m.severity >= 5 | groupby roundTime($m.timestamp, 1m) as time_bucket aggregate count() as errors"},
+				{Title: "Logs by Severity", Type: "pie_chart", Query: "source logs | filter {APP_FILTER} | groupby $m.severity aggregate count() as logs"},
+			},
+			{
+				{Title: "Top Subsystems", Type: "data_table", Query: "source logs | filter {APP_FILTER} | groupby $l.subsystemname aggregate count() as logs | sortby -logs | limit 10"},
+			},
+		},
+	},
+	"error_monitoring": {
+		Description: "Error and critical trends, errors by subsystem, and top error messages",
+		Rows: [][]templateWidget{
+			{
+				{Title: "Error Count", Type: "line_chart", Query: "source logs | filter {APP_FILTER} && $m.severity >= 5 | groupby roundTime($m.timestamp, 1m) as time_bucket aggregate count() as errors"},
+				{Title: "Errors by Subsystem", Type: "pie_chart", Query: "source logs | filter {APP_FILTER} && $m.severity >= 5 | groupby $l.subsystemname aggregate count() as errors"},
+			},
+			{
+				{Title: "Top Error Messages", Type: "data_table", Query: "source logs | filter {APP_FILTER} && $m.severity >= 5 | groupby $d.message:string aggregate count() as occurrences | sortby -occurrences | limit 10"},
+			},
+		},
+	},
+	"latency": {
+		Description: "Average and p95 response time, error trend, and slowest subsystems",
+		Rows: [][]templateWidget{
+			{
+				{Title: "Response Time", Type: "line_chart", Query: "source logs | filter {APP_FILTER} && $d.response_time_ms > 0 | groupby roundTime($m.timestamp, 1m) as time_bucket aggregate avg($d.response_time_ms) as avg_ms, percentile($d.response_time_ms, 95) as p95_ms"},
+				{Title: "Error Count", Type: "line_chart", Query: "source logs | filter {APP_FILTER} && $m.severity >= 5 | groupby roundTime($m.timestamp, 1m) as time_bucket aggregate count() as errors"},
+			},
+			{
+				{Title: "Slowest Subsystems", Type: "data_table", Query: "source logs | filter {APP_FILTER} && $d.response_time_ms > 0 | groupby $l.subsystemname aggregate percentile($d.response_time_ms, 95) as p95_ms, count() as requests | sortby -p95_ms | limit 10"},
+			},
+		},
+	},
+}
+
+// dashboardTemplateNames returns the supported template names in sorted order
+func dashboardTemplateNames() []string {
+	names := make([]string, 0, len(dashboardTemplates))
+	for name := range dashboardTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BuildTemplateLayout generates a complete dashboard layout for the template, with every
+// query scoped to the application
+func BuildTemplateLayout(tmpl dashboardTemplate, application string) map[string]interface{} {
+	appFilter := fmt.Sprintf("$l.applicationname == '%s'", escapeDataPrimeString(application))
+
+	rows := make([]interface{}, 0, len(tmpl.Rows))
+	widgetNum := 0
+	for i, row := range tmpl.Rows {
+		widgets := make([]interface{}, 0, len(row))
+		for _, w := range row {
+			widgetNum++
+			query := strings.ReplaceAll(w.Query, "{APP_FILTER}", appFilter)
+			widgets = append(widgets, map[string]interface{}{
+				"id":         map[string]interface{}{"value": fmt.Sprintf("widget-%d", widgetNum)},
+				"title":      w.Title,
+				"definition": map[string]interface{}{w.Type: templateWidgetDefinition(w.Type, query)},
+			})
+		}
+		rows = append(rows, map[string]interface{}{
+			"id":         map[string]interface{}{"value": fmt.Sprintf("row-%d", i+1)},
+			"appearance": map[string]interface{}{"height": 19},
+			"widgets":    widgets,
+		})
+	}
+
+	layout := map[string]interface{}{
+		"sections": []interface{}{
+			map[string]interface{}{
+				"id":   map[string]interface{}{"value": "section-1"},
+				"rows": rows,
+			},
+		},
+	}
+	ensureRequiredDashboardFields(layout)
+	return layout
+}
+
+// templateWidgetDefinition returns the widget definition body for a DataPrime query
+func templateWidgetDefinition(widgetType, query string) map[string]interface{} {
+	dataprimeQuery := func() map[string]interface{} {
+		return map[string]interface{}{
+			"dataprime": map[string]interface{}{
+				"dataprime_query": map[string]interface{}{"text": query},
+			},
+		}
+	}
+
+	switch widgetType {
+	case "line_chart":
+		return map[string]interface{}{
+			"query_definitions": []interface{}{
+				map[string]interface{}{
+					"id":                 "query-1",
+					"name":               "Query1",
+					"color_scheme":       "classic",
+					"series_count_limit": "20",
+					"query":              dataprimeQuery(),
+				},
+			},
+		}
+	case "pie_chart":
+		return map[string]interface{}{
+			"query":                dataprimeQuery(),
+			"max_slices_per_chart": 10,
+			"label_definition": map[string]interface{}{
+				"label_source":    "inner",
+				"is_visible":      true,
+				"show_name":       true,
+				"show_value":      true,
+				"show_percentage": true,
+			},
+		}
+	default: // data_table
+		return map[string]interface{}{
+			"query":            dataprimeQuery(),
+			"results_per_page": 10,
+			"row_style":        "one_line",
+		}
+	}
+}
+
+// CreateDashboardFromTemplateTool creates a dashboard from a predefined layout scoped to an application
+type CreateDashboardFromTemplateTool struct {
+	*BaseTool
+}
+
+// NewCreateDashboardFromTemplateTool creates a new CreateDashboardFromTemplateTool instance.
+func NewCreateDashboardFromTemplateTool(client client.Doer, logger *zap.Logger) *CreateDashboardFromTemplateTool {
+	return &CreateDashboardFromTemplateTool{
+		BaseTool: NewBaseTool(client, logger),
+	}
+}
+
+// Name returns the tool name for MCP registration.
+func (t *CreateDashboardFromTemplateTool) Name() string {
+	return "create_dashboard_from_template"
+}
+
+// Annotations returns tool hints for LLMs
+func (t *CreateDashboardFromTemplateTool) Annotations() *mcp.ToolAnnotations {
+	return CreateAnnotations("Create Dashboard from Template")
+}
+
+// Description returns a human-readable description of the tool.
+func (t *CreateDashboardFromTemplateTool) Description() string {
+	return `Create a dashboard for one application from a predefined layout, without writing dashboard JSON.
+
+**Templates:**
+- service_overview: Error count over time, logs by severity, top subsystems
+- error_monitoring: Error count over time, errors by subsystem, top error messages
+- latency: Average and p95 response time, error count over time, slowest subsystems
+
+All widgets use DataPrime queries filtered to the given application. Returns the created dashboard ID.
+
+**Related tools:** create_dashboard, get_dashboard, update_dashboard`
+}
+
+// InputSchema returns the JSON schema for the tool's input parameters.
+func (t *CreateDashboardFromTemplateTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"template": map[string]interface{}{
+				"type":        "string",
+				"description": "Dashboard template to use",
+				"enum":        dashboardTemplateNames(),
+			},
+			"application": map[string]interface{}{
+				"type":        "string",
+				"description": "Application name to scope every widget query to",
+				"examples":    []string{"checkout", "api-gateway"},
+			},
+			"name": map[string]interface{}{
+				"type":        "string",
+				"description": "Dashboard name (default: '<application> - <template>')",
+			},
+			"description": map[string]interface{}{
+				"type":        "string",
+				"description": "Optional description of the dashboard purpose",
+			},
+			"dry_run": map[string]interface{}{
+				"type":        "boolean",
+				"description": "If true, returns the generated layout without creating the dashboard",
+				"default":     false,
+			},
+		},
+		"required": []string{"template", "application"},
+	}
+}
+
+// Metadata returns semantic metadata for AI-driven discovery
+func (t *CreateDashboardFromTemplateTool) Metadata() *ToolMetadata {
+	return &ToolMetadata{
+		Categories:   []ToolCategory{CategoryDashboard, CategoryVisualization},
+		Keywords:     []string{"dashboard", "template", "create", "service", "overview", "errors", "latency"},
+		Complexity:   ComplexitySimple,
+		UseCases:     []string{"Create a service dashboard quickly", "Monitor errors for an application", "Track application latency"},
+		RelatedTools: []string{"create_dashboard", "get_dashboard", "update_dashboard"},
+		OutputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"id":   map[string]string{"type": "string"},
+				"name": map[string]string{"type": "string"},
+			},
+		},
+		ChainPosition: ChainEnd,
+	}
+}
+
+// Execute generates the template layout and creates the dashboard.
+func (t *CreateDashboardFromTemplateTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	templateName, err := GetStringParam(arguments, "template", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	tmpl, ok := dashboardTemplates[templateName]
+	if !ok {
+		return NewToolResultError(fmt.Sprintf("unknown template '%s'. Valid templates: %s",
+			templateName, strings.Join(dashboardTemplateNames(), ", "))), nil
+	}
+
+	application, err := GetStringParam(arguments, "application", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	application = strings.TrimSpace(application)
+	if application == "" {
+		return NewToolResultError("application must not be empty"), nil
+	}
+
+	name, _ := GetStringParam(arguments, "name", false)
+	if name == "" {
+		name = fmt.Sprintf("%s - %s", application, strings.ReplaceAll(templateName, "_", " "))
+	}
+	description, _ := GetStringParam(arguments, "description", false)
+	if description == "" {
+		description = fmt.Sprintf("%s for application %s", tmpl.Description, application)
+	}
+
+	layout := BuildTemplateLayout(tmpl, application)
+	body := map[string]interface{}{
+		"name":        name,
+		"description": description,
+		"layout":      layout,
+	}
+
+	if dryRun, _ := GetBoolParam(arguments, "dry_run", false); dryRun {
+		return FormatDryRunResult(&ValidationResult{
+			Valid: true,
+			Summary: map[string]interface{}{
+				"name":        name,
+				"template":    templateName,
+				"application": application,
+				"widgets":     countWidgets(layout),
+			},
+			Suggestions: []string{"Remove dry_run parameter to create the dashboard"},
+		}, "Dashboard", body), nil
+	}
+
+	result, err := t.ExecuteRequest(ctx, &client.Request{
+		Method: "POST",
+		Path:   "/v1/dashboards",
+		Body:   body,
+	})
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+
+	return t.FormatResponseWithSuggestions(result, "create_dashboard")
+}
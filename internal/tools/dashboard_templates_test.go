@@ -0,0 +1,91 @@
+package tools
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestCreateDashboardFromTemplateTool_Execute(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.RespondWith(200, map[string]interface{}{"id": "dash-1", "name": "checkout - service overview"})
+
+	tool := NewCreateDashboardFromTemplateTool(mock, zap.NewNop())
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{
+		"template":    "service_overview",
+		"application": "checkout",
+	})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Expected success result: %s", result.Content[0].(*mcp.TextContent).Text)
+	}
+	if !strings.Contains(result.Content[0].(*mcp.TextContent).Text, "dash-1") {
+		t.Error("result should include the created dashboard ID")
+	}
+
+	req := mock.LastRequest()
+	if req.Method != "POST" || req.Path != "/v1/dashboards" {
+		t.Fatalf("unexpected request %s %s", req.Method, req.Path)
+	}
+	body := req.Body.(map[string]interface{})
+	if body["name"] != "checkout - service overview" {
+		t.Errorf("name = %v, want default name", body["name"])
+	}
+
+	queries := ExtractWidgetQueries(body["layout"].(map[string]interface{}))
+	if len(queries) != 3 {
+		t.Fatalf("expected 3 widget queries, got %d", len(queries))
+	}
+	for _, q := range queries {
+		if q.Syntax != "dataprime" || !strings.Contains(q.Query, "$l.applicationname == 'checkout'") {
+			t.Errorf("widget %q query not scoped to the application: %s", q.WidgetTitle, q.Query)
+		}
+	}
+}
+
+func TestCreateDashboardFromTemplateTool_UnknownTemplate(t *testing.T) {
+	mock := client.NewMockClient()
+	tool := NewCreateDashboardFromTemplateTool(mock, zap.NewNop())
+
+	result, _ := tool.Execute(testCtx(mock), map[string]interface{}{
+		"template":    "kitchen_sink",
+		"application": "checkout",
+	})
+	if !result.IsError {
+		t.Fatal("expected error for unknown template")
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(text, "error_monitoring, latency, service_overview") {
+		t.Errorf("error should list the valid templates: %s", text)
+	}
+	if mock.RequestCount() != 0 {
+		t.Error("no request should be sent for an unknown template")
+	}
+}
+
+func TestBuildTemplateLayout(t *testing.T) {
+	for name, tmpl := range dashboardTemplates {
+		t.Run(name, func(t *testing.T) {
+			layout := BuildTemplateLayout(tmpl, "o'brien")
+			if got := countWidgets(layout); got != 3 {
+				t.Errorf("countWidgets = %d, want 3", got)
+			}
+			for _, q := range ExtractWidgetQueries(layout) {
+				if !strings.Contains(q.Query, `'o\'brien'`) || strings.Contains(q.Query, "{APP_FILTER}") {
+					t.Errorf("query not scoped with an escaped application name: %s", q.Query)
+				}
+				// groupby and aggregate must share one stage to chart one value per group
+				if !regexp.MustCompile(`\| groupby [^|]+ aggregate `).MatchString(q.Query) || strings.Contains(q.Query, "| aggregate") {
+					t.Errorf("query does not aggregate per group in a single groupby stage: %s", q.Query)
+				}
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package tools
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -170,6 +172,146 @@ func extractNestedQueries(v map[string]interface{}, path string) []QueryInfo {
 	return queries
 }
 
+// WidgetQuery is a query powering a dashboard widget
+type WidgetQuery struct {
+	WidgetTitle string `json:"widget_title"`
+	Query       string `json:"query"`
+	Syntax      string `json:"syntax"` // "dataprime", "lucene", or "promql"
+}
+
+// ExtractWidgetQueries walks the layout's sections, rows, and widgets and returns the
+// queries behind each widget, covering both logs and metrics query shapes. Widgets
+// without queries (e.g., markdown) are skipped.
+func ExtractWidgetQueries(layout map[string]interface{}) []WidgetQuery {
+	var queries []WidgetQuery
+	sections, _ := layout["sections"].([]interface{})
+	for _, section := range sections {
+		sectionMap, _ := section.(map[string]interface{})
+		rows, _ := sectionMap["rows"].([]interface{})
+		for _, row := range rows {
+			rowMap, _ := row.(map[string]interface{})
+			widgets, _ := rowMap["widgets"].([]interface{})
+			for _, widget := range widgets {
+				widgetMap, ok := widget.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				title, _ := widgetMap["title"].(string)
+				for _, qi := range collectWidgetQueries(widgetMap["definition"]) {
+					queries = append(queries, WidgetQuery{WidgetTitle: title, Query: qi.Query, Syntax: qi.Syntax})
+				}
+			}
+		}
+	}
+	return queries
+}
+
+// collectWidgetQueries finds the lucene_query, dataprime_query, and promql_query blocks anywhere
+// in a widget definition, in query_definitions (line charts) as well as the single query of
+// bar, pie, table, and gauge widgets
+func collectWidgetQueries(v interface{}) []QueryInfo {
+	var queries []QueryInfo
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for _, shape := range []struct{ key, field, syntax string }{
+			{"lucene_query", "value", "lucene"},
+			{"dataprime_query", "text", "dataprime"},
+			{"promql_query", "value", "promql"},
+		} {
+			if block, ok := val[shape.key].(map[string]interface{}); ok {
+				if text, ok := block[shape.field].(string); ok && text != "" {
+					queries = append(queries, QueryInfo{Query: text, Syntax: shape.syntax})
+				}
+			}
+		}
+		keys := make([]string, 0, len(val))
+		for key := range val {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			queries = append(queries, collectWidgetQueries(val[key])...)
+		}
+	case []interface{}:
+		for _, item := range val {
+			queries = append(queries, collectWidgetQueries(item)...)
+		}
+	}
+	return queries
+}
+
+// DescribeDashboard renders a dashboard as markdown: its name and description, then each
+// section's widgets with their chart type and queries
+func DescribeDashboard(dashboard map[string]interface{}) string {
+	var sb strings.Builder
+	name, _ := dashboard["name"].(string)
+	if name == "" {
+		name = "Untitled dashboard"
+	}
+	fmt.Fprintf(&sb, "# %s\n\n", name)
+	if desc, _ := dashboard["description"].(string); desc != "" {
+		fmt.Fprintf(&sb, "%s\n\n", desc)
+	}
+
+	layout, _ := dashboard["layout"].(map[string]interface{})
+	sections, _ := layout["sections"].([]interface{})
+	if len(sections) == 0 {
+		sb.WriteString("This dashboard has no sections.\n")
+		return sb.String()
+	}
+	for i, section := range sections {
+		sectionMap, _ := section.(map[string]interface{})
+		fmt.Fprintf(&sb, "## %s\n\n", dashboardSectionName(sectionMap, i))
+		widgetCount := 0
+		rows, _ := sectionMap["rows"].([]interface{})
+		for _, row := range rows {
+			rowMap, _ := row.(map[string]interface{})
+			widgets, _ := rowMap["widgets"].([]interface{})
+			for _, widget := range widgets {
+				widgetMap, ok := widget.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				widgetCount++
+				title, _ := widgetMap["title"].(string)
+				if title == "" {
+					title = "(untitled)"
+				}
+				fmt.Fprintf(&sb, "- **%s** (%s)\n", title, widgetType(widgetMap))
+				for _, q := range collectWidgetQueries(widgetMap["definition"]) {
+					fmt.Fprintf(&sb, "  - %s: `%s`\n", q.Syntax, q.Query)
+				}
+			}
+		}
+		if widgetCount == 0 {
+			sb.WriteString("No widgets.\n")
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// dashboardSectionName returns a section's custom name, or its 1-based position
+func dashboardSectionName(section map[string]interface{}, index int) string {
+	if options, ok := section["options"].(map[string]interface{}); ok {
+		if custom, ok := options["custom"].(map[string]interface{}); ok {
+			if name, _ := custom["name"].(string); name != "" {
+				return name
+			}
+		}
+	}
+	return fmt.Sprintf("Section %d", index+1)
+}
+
+// widgetType returns the chart type of a widget, the single key of its definition
+func widgetType(widget map[string]interface{}) string {
+	definition, _ := widget["definition"].(map[string]interface{})
+	for key := range definition {
+		return strings.ReplaceAll(key, "_", " ")
+	}
+	return "unknown"
+}
+
 // validateQuery tests a query by executing it with a minimal time range.
 // Returns an error if the query is invalid.
 // It auto-detects whether the query is DataPrime or Lucene syntax.
@@ -303,7 +445,7 @@ func (t *ListDashboardsTool) Description() string {
 func (t *ListDashboardsTool) InputSchema() interface{} {
 	return map[string]interface{}{
 		"type":       "object",
-		"properties": map[string]interface{}{},
+		"properties": withSummaryOnly(OffsetPaginationSchema()),
 	}
 }
 
@@ -332,22 +474,28 @@ func (t *ListDashboardsTool) Metadata() *ToolMetadata {
 func (t *ListDashboardsTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	session := GetSessionFromContext(ctx)
 
-	req := &client.Request{
-		Method: "GET",
-		Path:   "/v1/dashboards",
+	result, err := t.ListDashboards(ctx)
+	if err != nil {
+		session.RecordToolUse(t.Name(), false, arguments)
+		return NewToolResultErrorFromErr(err), nil
 	}
 
-	result, err := t.ExecuteRequest(ctx, req)
+	paged, err := ApplyOffsetPagination(result, arguments, "items", "dashboards")
 	if err != nil {
 		session.RecordToolUse(t.Name(), false, arguments)
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	// Record successful tool use and cache result
 	session.RecordToolUse(t.Name(), true, arguments)
 	session.CacheResult(t.Name(), result)
 
-	return t.FormatResponseWithSuggestions(result, "list_dashboards")
+	return t.FormatListResponse(paged, arguments, "list_dashboards")
+}
+
+// ListDashboards fetches the dashboard catalog. It is shared with the dashboard:// MCP resources.
+func (t *ListDashboardsTool) ListDashboards(ctx context.Context) (map[string]interface{}, error) {
+	return t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/dashboards"})
 }
 
 // GetDashboardTool gets a specific dashboard by ID.
@@ -369,7 +517,10 @@ func (t *GetDashboardTool) Name() string {
 
 // Description returns a human-readable description of the tool.
 func (t *GetDashboardTool) Description() string {
-	return "Get a specific dashboard by ID from IBM Cloud Logs"
+	return `Get a specific dashboard by ID from IBM Cloud Logs.
+
+Set extract_queries=true to get just the DataPrime, Lucene, and PromQL queries powering each widget,
+e.g. to reuse them for ad-hoc investigation with query_logs.`
 }
 
 // InputSchema returns the JSON schema for the tool's input parameters.
@@ -381,6 +532,11 @@ func (t *GetDashboardTool) InputSchema() interface{} {
 				"type":        "string",
 				"description": "The unique identifier of the dashboard",
 			},
+			"extract_queries": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Return only a flat list of {widget_title, query} pairs for the widgets instead of the full layout",
+				"default":     false,
+			},
 		},
 		"required": []string{"dashboard_id"},
 	}
@@ -417,12 +573,7 @@ func (t *GetDashboardTool) Execute(ctx context.Context, arguments map[string]int
 		return NewToolResultError("dashboard_id is required and must be a string"), nil
 	}
 
-	req := &client.Request{
-		Method: "GET",
-		Path:   fmt.Sprintf("/v1/dashboards/%s", dashboardID),
-	}
-
-	result, err := t.ExecuteRequest(ctx, req)
+	result, err := t.GetDashboard(ctx, dashboardID)
 	if err != nil {
 		session.RecordToolUse(t.Name(), false, arguments)
 		return HandleGetError(err, "Dashboard", dashboardID, "list_dashboards"), nil
@@ -432,9 +583,25 @@ func (t *GetDashboardTool) Execute(ctx context.Context, arguments map[string]int
 	session.RecordToolUse(t.Name(), true, map[string]interface{}{"dashboard_id": dashboardID})
 	session.CacheResult(t.Name(), result)
 
+	if extract, _ := GetBoolParam(arguments, "extract_queries", false); extract {
+		layout, _ := result["layout"].(map[string]interface{})
+		queries := ExtractWidgetQueries(layout)
+		return t.FormatResponseWithSuggestions(map[string]interface{}{
+			"dashboard_id": dashboardID,
+			"name":         result["name"],
+			"total":        len(queries),
+			"queries":      queries,
+		}, "get_dashboard")
+	}
+
 	return t.FormatResponseWithSuggestions(result, "get_dashboard")
 }
 
+// GetDashboard fetches a dashboard by ID. It is shared with the dashboard:// MCP resources.
+func (t *GetDashboardTool) GetDashboard(ctx context.Context, dashboardID string) (map[string]interface{}, error) {
+	return t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: fmt.Sprintf("/v1/dashboards/%s", dashboardID)})
+}
+
 // CreateDashboardTool creates a new dashboard.
 type CreateDashboardTool struct {
 	*BaseTool
@@ -866,7 +1033,7 @@ func (t *CreateDashboardTool) Execute(ctx context.Context, arguments map[string]
 
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	return t.FormatResponseWithSuggestions(result, "create_dashboard")
@@ -1009,6 +1176,14 @@ func (t *UpdateDashboardTool) InputSchema() interface{} {
 			},
 		},
 		"required": []string{"dashboard_id", "name", "layout"},
+		"examples": []interface{}{
+			map[string]interface{}{
+				"dashboard_id": "dashboard-id",
+				"name":         DashboardExample["name"],
+				"description":  DashboardExample["description"],
+				"layout":       DashboardExample["layout"],
+			},
+		},
 	}
 }
 
@@ -1083,7 +1258,7 @@ func (t *UpdateDashboardTool) Execute(ctx context.Context, arguments map[string]
 
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	return t.FormatResponseWithSuggestions(result, "update_dashboard")
@@ -1162,7 +1337,7 @@ func (t *DeleteDashboardTool) Execute(ctx context.Context, arguments map[string]
 
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	return t.FormatResponseWithSuggestions(result, "delete_dashboard")
@@ -495,3 +495,107 @@ func TestEnsureRequiredDashboardFields(t *testing.T) {
 		assert.NotNil(t, layout, "layout should still be valid after processing")
 	})
 }
+
+func TestExtractWidgetQueries(t *testing.T) {
+	layout := map[string]interface{}{
+		"sections": []interface{}{
+			map[string]interface{}{
+				"rows": []interface{}{
+					map[string]interface{}{
+						"widgets": []interface{}{
+							map[string]interface{}{
+								"title": "Errors over time",
+								"definition": map[string]interface{}{
+									"line_chart": map[string]interface{}{
+										"query_definitions": []interface{}{
+											map[string]interface{}{"query": map[string]interface{}{
+												"logs": map[string]interface{}{"lucene_query": map[string]interface{}{"value": "severity:>=5"}},
+											}},
+											map[string]interface{}{"query": map[string]interface{}{
+												"metrics": map[string]interface{}{"promql_query": map[string]interface{}{"value": "sum(rate(errors_total[5m]))"}},
+											}},
+										},
+									},
+								},
+							},
+							map[string]interface{}{
+								"title": "Notes",
+								"definition": map[string]interface{}{
+									"markdown": map[string]interface{}{"markdown_text": "# Runbook"},
+								},
+							},
+						},
+					},
+					map[string]interface{}{
+						"widgets": []interface{}{
+							map[string]interface{}{
+								"title": "Top apps",
+								"definition": map[string]interface{}{
+									"bar_chart": map[string]interface{}{
+										"query": map[string]interface{}{
+											"dataprime": map[string]interface{}{
+												"dataprime_query": map[string]interface{}{"text": "source logs | groupby $l.applicationname aggregate count()"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	queries := ExtractWidgetQueries(layout)
+	require.Len(t, queries, 3)
+	assert.Equal(t, WidgetQuery{WidgetTitle: "Errors over time", Query: "severity:>=5", Syntax: "lucene"}, queries[0])
+	assert.Equal(t, WidgetQuery{WidgetTitle: "Errors over time", Query: "sum(rate(errors_total[5m]))", Syntax: "promql"}, queries[1])
+	assert.Equal(t, "Top apps", queries[2].WidgetTitle)
+	assert.Equal(t, "dataprime", queries[2].Syntax)
+
+	assert.Empty(t, ExtractWidgetQueries(nil))
+}
+
+func TestDescribeDashboard(t *testing.T) {
+	dashboard := map[string]interface{}{
+		"name":        "Checkout health",
+		"description": "Errors and latency for checkout",
+		"layout": map[string]interface{}{
+			"sections": []interface{}{
+				map[string]interface{}{
+					"options": map[string]interface{}{"custom": map[string]interface{}{"name": "Errors"}},
+					"rows": []interface{}{
+						map[string]interface{}{
+							"widgets": []interface{}{
+								map[string]interface{}{
+									"title": "Errors over time",
+									"definition": map[string]interface{}{
+										"line_chart": map[string]interface{}{
+											"query_definitions": []interface{}{
+												map[string]interface{}{"query": map[string]interface{}{
+													"logs": map[string]interface{}{"lucene_query": map[string]interface{}{"value": "severity:>=5"}},
+												}},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				map[string]interface{}{"rows": []interface{}{}},
+			},
+		},
+	}
+
+	out := DescribeDashboard(dashboard)
+	assert.Contains(t, out, "# Checkout health")
+	assert.Contains(t, out, "Errors and latency for checkout")
+	assert.Contains(t, out, "## Errors")
+	assert.Contains(t, out, "- **Errors over time** (line chart)")
+	assert.Contains(t, out, "lucene: `severity:>=5`")
+	assert.Contains(t, out, "## Section 2\n\nNo widgets.")
+
+	assert.Contains(t, DescribeDashboard(map[string]interface{}{}), "This dashboard has no sections.")
+}
@@ -0,0 +1,344 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// dataSetsPath is the custom enrichment data endpoint backing the data set tools
+const dataSetsPath = "/v1/custom_enrichments"
+
+// dataSetCSV builds and validates the CSV content of a data set from either csv text or rows.
+// The first row must be a header of unique, non-empty column names and every row must have
+// the same number of columns. It returns the CSV text, the header and the number of data rows.
+func dataSetCSV(args map[string]interface{}) (string, []string, int, error) {
+	text, _ := GetStringParam(args, "csv", false)
+	rawRows, hasRows := args["rows"].([]interface{})
+	if text != "" && hasRows {
+		return "", nil, 0, fmt.Errorf("provide either csv or rows, not both")
+	}
+
+	var records [][]string
+	switch {
+	case text != "":
+		reader := csv.NewReader(strings.NewReader(text))
+		reader.TrimLeadingSpace = true
+		var err error
+		if records, err = reader.ReadAll(); err != nil {
+			return "", nil, 0, fmt.Errorf("invalid CSV: %w", err)
+		}
+	case hasRows:
+		for i, raw := range rawRows {
+			cells, ok := raw.([]interface{})
+			if !ok {
+				return "", nil, 0, fmt.Errorf("rows[%d] must be an array of values", i)
+			}
+			record := make([]string, len(cells))
+			for j, cell := range cells {
+				if cell != nil {
+					record[j] = fmt.Sprint(cell)
+				}
+			}
+			if i > 0 && len(record) != len(records[0]) {
+				return "", nil, 0, fmt.Errorf("rows[%d] has %d values, header has %d", i, len(record), len(records[0]))
+			}
+			records = append(records, record)
+		}
+	default:
+		return "", nil, 0, fmt.Errorf("provide the data set content as csv text or rows")
+	}
+
+	if len(records) == 0 {
+		return "", nil, 0, fmt.Errorf("data set is empty: the first row must be a header")
+	}
+	header := records[0]
+	seen := make(map[string]bool, len(header))
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		if column == "" {
+			return "", nil, 0, fmt.Errorf("header column %d is empty: the first row must name every column", i+1)
+		}
+		if seen[column] {
+			return "", nil, 0, fmt.Errorf("header column '%s' appears more than once", column)
+		}
+		seen[column] = true
+		header[i] = column
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.WriteAll(records); err != nil {
+		return "", nil, 0, fmt.Errorf("failed to encode CSV: %w", err)
+	}
+	return buf.String(), header, len(records) - 1, nil
+}
+
+// dataSetID returns the identifier of a data set from a create response
+func dataSetID(res map[string]interface{}) (interface{}, bool) {
+	for _, key := range []string{"custom_enrichment_id", "id"} {
+		if id, ok := res[key]; ok && id != nil && id != "" {
+			return id, true
+		}
+	}
+	return nil, false
+}
+
+// ListDataSetsTool lists the lookup tables available to custom enrichments.
+type ListDataSetsTool struct{ *BaseTool }
+
+// NewListDataSetsTool creates a new tool instance
+func NewListDataSetsTool(c client.Doer, l *zap.Logger) *ListDataSetsTool {
+	return &ListDataSetsTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *ListDataSetsTool) Name() string { return "list_data_sets" }
+
+// Annotations returns tool hints for LLMs
+func (t *ListDataSetsTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("List Data Sets")
+}
+
+// Description returns the tool description
+func (t *ListDataSetsTool) Description() string {
+	return `List the data sets (lookup tables) used by custom enrichments.
+
+A data set's id is the lookup_table_id in a custom_enrichment's custom_enrichment_config.
+
+**Related tools:** get_data_set, create_data_set, delete_data_set, create_enrichment`
+}
+
+// InputSchema returns the input schema
+func (t *ListDataSetsTool) InputSchema() interface{} {
+	return map[string]interface{}{"type": "object", "properties": withSummaryOnly(OffsetPaginationSchema())}
+}
+
+// Execute executes the tool
+func (t *ListDataSetsTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: dataSetsPath})
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	paged, err := ApplyOffsetPagination(res, args, "custom_enrichments", "data_sets")
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	return t.FormatListResponse(paged, args, "list_data_sets")
+}
+
+// GetDataSetTool retrieves a data set by ID.
+type GetDataSetTool struct{ *BaseTool }
+
+// NewGetDataSetTool creates a new tool instance
+func NewGetDataSetTool(c client.Doer, l *zap.Logger) *GetDataSetTool {
+	return &GetDataSetTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *GetDataSetTool) Name() string { return "get_data_set" }
+
+// Annotations returns tool hints for LLMs
+func (t *GetDataSetTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Get Data Set")
+}
+
+// Description returns the tool description
+func (t *GetDataSetTool) Description() string {
+	return `Get a custom enrichment data set (lookup table) by ID.
+
+**Related tools:** list_data_sets, delete_data_set, create_enrichment`
+}
+
+// InputSchema returns the input schema
+func (t *GetDataSetTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"id": map[string]interface{}{
+				"type":        "string",
+				"description": "Data set ID (the lookup_table_id of custom enrichments)",
+			},
+		},
+		"required": []string{"id"},
+	}
+}
+
+// Execute executes the tool
+func (t *GetDataSetTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	id, err := GetStringParam(args, "id", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: dataSetsPath + "/" + id})
+	if err != nil {
+		return HandleGetError(err, "Data set", id, "list_data_sets"), nil
+	}
+	return t.FormatResponseWithSuggestions(res, "get_data_set")
+}
+
+// CreateDataSetTool uploads CSV content as a new data set.
+type CreateDataSetTool struct{ *BaseTool }
+
+// NewCreateDataSetTool creates a new tool instance
+func NewCreateDataSetTool(c client.Doer, l *zap.Logger) *CreateDataSetTool {
+	return &CreateDataSetTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *CreateDataSetTool) Name() string { return "create_data_set" }
+
+// Annotations returns tool hints for LLMs
+func (t *CreateDataSetTool) Annotations() *mcp.ToolAnnotations {
+	return CreateAnnotations("Create Data Set")
+}
+
+// Description returns the tool description
+func (t *CreateDataSetTool) Description() string {
+	return `Upload a lookup table (data set) for custom enrichments from CSV text or rows.
+
+The first row must be a header naming each column; the first column is usually the lookup key
+matched against the enrichment's field_name. The response includes lookup_table_id, which goes
+into create_enrichment as custom_enrichment_config.lookup_table_id.
+
+**Related tools:** list_data_sets, get_data_set, delete_data_set, create_enrichment`
+}
+
+// InputSchema returns the input schema
+func (t *CreateDataSetTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name": map[string]interface{}{
+				"type":        "string",
+				"description": "Data set name",
+			},
+			"description": map[string]interface{}{
+				"type":        "string",
+				"description": "What the data set maps (optional)",
+			},
+			"csv": map[string]interface{}{
+				"type":        "string",
+				"description": "CSV content with a header row. Use this or rows.",
+			},
+			"rows": map[string]interface{}{
+				"type":        "array",
+				"description": "Rows of values, the first being the header. Use this or csv.",
+				"items": map[string]interface{}{
+					"type":  "array",
+					"items": map[string]interface{}{"type": "string"},
+				},
+			},
+		},
+		"required": []string{"name"},
+		"examples": []interface{}{
+			map[string]interface{}{
+				"name":        "customer-tiers",
+				"description": "Customer ID to tier and region",
+				"csv":         "customer_id,tier,region\nc-1001,gold,eu\nc-1002,silver,us\n",
+			},
+			map[string]interface{}{
+				"name": "error-codes",
+				"rows": []interface{}{
+					[]interface{}{"code", "meaning"},
+					[]interface{}{"E1001", "Payment declined"},
+					[]interface{}{"E1002", "Card expired"},
+				},
+			},
+		},
+	}
+}
+
+// Execute executes the tool
+func (t *CreateDataSetTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	name, err := GetStringParam(args, "name", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	description, _ := GetStringParam(args, "description", false)
+	content, header, rows, err := dataSetCSV(args)
+	if err != nil {
+		return NewToolResultErrorWithSuggestion(fmt.Sprintf("Invalid data set: %v", err),
+			"Start the CSV with a header row, e.g. customer_id,tier,region"), nil
+	}
+
+	body := map[string]interface{}{
+		"name": name,
+		"file": map[string]interface{}{
+			"name":      name + ".csv",
+			"extension": "csv",
+			"textual":   content,
+		},
+	}
+	if description != "" {
+		body["description"] = description
+	}
+
+	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "POST", Path: dataSetsPath, Body: body})
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	if id, ok := dataSetID(res); ok {
+		res["lookup_table_id"] = fmt.Sprint(id)
+	}
+	res["columns"] = header
+	res["row_count"] = rows
+	return t.FormatResponseWithSuggestions(res, "create_data_set")
+}
+
+// DeleteDataSetTool deletes a data set.
+type DeleteDataSetTool struct{ *BaseTool }
+
+// NewDeleteDataSetTool creates a new tool instance
+func NewDeleteDataSetTool(c client.Doer, l *zap.Logger) *DeleteDataSetTool {
+	return &DeleteDataSetTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *DeleteDataSetTool) Name() string { return "delete_data_set" }
+
+// Annotations returns tool hints for LLMs
+func (t *DeleteDataSetTool) Annotations() *mcp.ToolAnnotations {
+	return DeleteAnnotations("Delete Data Set")
+}
+
+// Description returns the tool description
+func (t *DeleteDataSetTool) Description() string {
+	return `Delete a custom enrichment data set. Custom enrichments that use it as their lookup table stop adding fields.
+
+**Related tools:** list_data_sets, list_enrichments`
+}
+
+// InputSchema returns the input schema
+func (t *DeleteDataSetTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"id": map[string]interface{}{
+				"type":        "string",
+				"description": "Data set ID to delete",
+			},
+		},
+		"required": []string{"id"},
+	}
+}
+
+// Execute executes the tool
+func (t *DeleteDataSetTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	id, err := GetStringParam(args, "id", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "DELETE", Path: dataSetsPath + "/" + id})
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	return t.FormatResponseWithSuggestions(res, "delete_data_set")
+}
@@ -0,0 +1,89 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestDataSetCSV(t *testing.T) {
+	content, header, rows, err := dataSetCSV(map[string]interface{}{
+		"rows": []interface{}{
+			[]interface{}{"code", "meaning"},
+			[]interface{}{"E1", "Payment declined, retry"},
+			[]interface{}{"E2", 42.0},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content != "code,meaning\nE1,\"Payment declined, retry\"\nE2,42\n" {
+		t.Errorf("unexpected CSV: %q", content)
+	}
+	if strings.Join(header, "|") != "code|meaning" || rows != 2 {
+		t.Errorf("unexpected header %v or row count %d", header, rows)
+	}
+
+	invalid := map[string]map[string]interface{}{
+		"no content":          {},
+		"both inputs":         {"csv": "a,b\n", "rows": []interface{}{}},
+		"empty":               {"rows": []interface{}{}},
+		"empty header column": {"csv": "id,,tier\n1,2,3\n"},
+		"duplicate column":    {"csv": "id,id\n1,2\n"},
+		"ragged csv":          {"csv": "id,tier\n1\n"},
+		"ragged rows":         {"rows": []interface{}{[]interface{}{"id", "tier"}, []interface{}{"1"}}},
+		"row not an array":    {"rows": []interface{}{"id,tier"}},
+	}
+	for name, args := range invalid {
+		if _, _, _, err := dataSetCSV(args); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestCreateDataSetTool_Execute(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.RespondWith(200, map[string]interface{}{"custom_enrichment_id": 17})
+
+	result, err := NewCreateDataSetTool(mock, zap.NewNop()).Execute(testCtx(mock), map[string]interface{}{
+		"name": "customer-tiers",
+		"csv":  "customer_id, tier\nc-1,gold\n",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", text)
+	}
+	if !strings.Contains(text, `"lookup_table_id": "17"`) || !strings.Contains(text, `"row_count": 1`) {
+		t.Errorf("expected lookup_table_id and row_count in result: %s", text)
+	}
+
+	req := mock.LastRequest()
+	if req.Method != "POST" || req.Path != dataSetsPath {
+		t.Errorf("unexpected request %s %s", req.Method, req.Path)
+	}
+	file := req.Body.(map[string]interface{})["file"].(map[string]interface{})
+	if file["extension"] != "csv" || file["textual"] != "customer_id,tier\nc-1,gold\n" {
+		t.Errorf("unexpected file payload: %v", file)
+	}
+}
+
+func TestCreateDataSetTool_RejectsMissingHeader(t *testing.T) {
+	mock := client.NewMockClient()
+	result, err := NewCreateDataSetTool(mock, zap.NewNop()).Execute(testCtx(mock), map[string]interface{}{
+		"name": "empty",
+		"csv":  " ",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.IsError || mock.RequestCount() != 0 {
+		t.Errorf("expected a validation error without a request, got %+v", result.Content)
+	}
+}
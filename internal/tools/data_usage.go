@@ -2,6 +2,10 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"go.uber.org/zap"
@@ -28,30 +32,223 @@ func (t *ExportDataUsageTool) Name() string {
 
 // Description returns the tool description
 func (t *ExportDataUsageTool) Description() string {
-	return "Export data usage metrics for the IBM Cloud Logs instance"
+	return `Export data usage (ingested volume) for the IBM Cloud Logs instance over a period, broken down
+by application, subsystem, or TCO priority.
+
+Returns a summary with the top consumers and optimization hints (e.g., high-volume applications that
+are candidates for Events2Metrics or a low-priority TCO policy), followed by the breakdown as JSON.
+
+**Related tools:** list_policies, create_policy, create_e2m, estimate_query_cost`
 }
 
 // InputSchema returns the input schema
 func (t *ExportDataUsageTool) InputSchema() interface{} {
 	return map[string]interface{}{
-		"type":       "object",
-		"properties": map[string]interface{}{},
+		"type": "object",
+		"properties": map[string]interface{}{
+			"range": map[string]interface{}{
+				"type":        "string",
+				"description": "Period to report on",
+				"enum":        []string{"last_week", "last_30_days", "last_90_days", "current_month"},
+				"default":     "last_30_days",
+			},
+			"aggregate": map[string]interface{}{
+				"type":        "string",
+				"description": "Dimension to break usage down by",
+				"enum":        []string{"application", "subsystem", "priority"},
+				"default":     "application",
+			},
+		},
 	}
 }
 
+// usageHighShare is the share of total volume (%) above which a consumer gets an optimization hint
+const usageHighShare = 20.0
+
+// UsageBreakdownEntry is the usage attributed to one dimension value
+type UsageBreakdownEntry struct {
+	Name    string  `json:"name"`
+	SizeGB  float64 `json:"size_gb"`
+	Units   float64 `json:"units"`
+	Percent float64 `json:"percent"`
+}
+
+// UsageBreakdown is the machine-readable result of export_data_usage
+type UsageBreakdown struct {
+	Range       string                `json:"range"`
+	AggregateBy string                `json:"aggregate_by"`
+	TotalGB     float64               `json:"total_gb"`
+	TotalUnits  float64               `json:"total_units"`
+	Entries     []UsageBreakdownEntry `json:"entries"`
+	Hints       []string              `json:"hints,omitempty"`
+}
+
 // Execute executes the tool
-func (t *ExportDataUsageTool) Execute(ctx context.Context, _ map[string]interface{}) (*mcp.CallToolResult, error) {
+func (t *ExportDataUsageTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	usageRange, _ := GetStringParam(args, "range", false)
+	if usageRange == "" {
+		usageRange = "last_30_days"
+	}
+	aggregate, _ := GetStringParam(args, "aggregate", false)
+	if aggregate == "" {
+		aggregate = "application"
+	}
+	if aggregate != "application" && aggregate != "subsystem" && aggregate != "priority" {
+		return NewToolResultError(fmt.Sprintf("Invalid aggregate '%s'. Valid values: application, subsystem, priority", aggregate)), nil
+	}
+
 	req := &client.Request{
 		Method: "GET",
 		Path:   "/v1/data_usage",
+		Query: map[string]string{
+			"range":     usageRange,
+			"aggregate": aggregate,
+		},
 	}
 
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
-	return t.FormatResponseWithSuggestions(result, "export_data_usage")
+	breakdown := BuildUsageBreakdown(result, usageRange, aggregate)
+	data, err := json.MarshalIndent(breakdown, "", "  ")
+	if err != nil {
+		return NewToolResultError(fmt.Sprintf("Failed to format response: %v", err)), nil
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: formatUsageSummary(breakdown)},
+			&mcp.TextContent{Text: string(data)},
+		},
+	}, nil
+}
+
+// BuildUsageBreakdown sums usage entries by the aggregated dimension, largest first, and
+// derives optimization hints. Entries may come as an entries array or as streamed events.
+func BuildUsageBreakdown(result map[string]interface{}, usageRange, aggregate string) *UsageBreakdown {
+	breakdown := &UsageBreakdown{Range: usageRange, AggregateBy: aggregate, Entries: []UsageBreakdownEntry{}}
+
+	var raw []interface{}
+	for _, key := range []string{"entries", "events"} {
+		items, _ := result[key].([]interface{})
+		for _, item := range items {
+			// Streamed messages may wrap their own entries array
+			if m, ok := item.(map[string]interface{}); ok {
+				if nested, ok := m["entries"].([]interface{}); ok {
+					raw = append(raw, nested...)
+					continue
+				}
+			}
+			raw = append(raw, item)
+		}
+	}
+
+	byName := make(map[string]*UsageBreakdownEntry)
+	for _, item := range raw {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name := usageDimensionValue(entry, aggregate)
+		e, ok := byName[name]
+		if !ok {
+			e = &UsageBreakdownEntry{Name: name}
+			byName[name] = e
+		}
+		sizeGB, _ := entry["size_gb"].(float64)
+		units, _ := entry["units"].(float64)
+		e.SizeGB += sizeGB
+		e.Units += units
+		breakdown.TotalGB += sizeGB
+		breakdown.TotalUnits += units
+	}
+
+	for _, e := range byName {
+		if breakdown.TotalGB > 0 {
+			e.Percent = e.SizeGB * 100 / breakdown.TotalGB
+		}
+		breakdown.Entries = append(breakdown.Entries, *e)
+	}
+	sort.Slice(breakdown.Entries, func(i, j int) bool {
+		if breakdown.Entries[i].SizeGB != breakdown.Entries[j].SizeGB {
+			return breakdown.Entries[i].SizeGB > breakdown.Entries[j].SizeGB
+		}
+		return breakdown.Entries[i].Name < breakdown.Entries[j].Name
+	})
+
+	breakdown.Hints = usageHints(breakdown)
+	return breakdown
+}
+
+// usageDimensionValue returns the entry's value for the aggregated dimension. Dimensions are
+// a list of {key, value} pairs; key names vary (application_name, applicationname, ...).
+func usageDimensionValue(entry map[string]interface{}, aggregate string) string {
+	dims, _ := entry["dimensions"].([]interface{})
+	for _, d := range dims {
+		dim, ok := d.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if generic, ok := dim["generic"].(map[string]interface{}); ok {
+			dim = generic
+		}
+		key, _ := dim["key"].(string)
+		if strings.HasPrefix(strings.ReplaceAll(strings.ToLower(key), "_", ""), aggregate) {
+			if value, ok := dim["value"].(string); ok && value != "" {
+				return value
+			}
+		}
+	}
+	return "unknown"
+}
+
+// usageHints suggests optimizations for the largest consumers
+func usageHints(b *UsageBreakdown) []string {
+	var hints []string
+	for _, e := range b.Entries {
+		if e.Percent < usageHighShare || e.Name == "unknown" {
+			continue
+		}
+		switch b.AggregateBy {
+		case "priority":
+			if strings.Contains(e.Name, "high") {
+				hints = append(hints, fmt.Sprintf("%.0f%% of volume is high priority (Priority Insights). Review list_policies and move verbose or rarely searched logs to medium (archive only) or low (blocked) priority.", e.Percent))
+			}
+		default:
+			hints = append(hints, fmt.Sprintf("%s %s accounts for %.0f%% of volume (%.2f GB). If its logs are mostly used for counts or trends, convert them to metrics with create_e2m; if rarely searched, add a TCO policy (create_policy) with medium or low priority.", b.AggregateBy, e.Name, e.Percent, e.SizeGB))
+		}
+	}
+	if b.TotalGB == 0 {
+		hints = append(hints, "No usage recorded for this period. Check that data usage metrics export is enabled (update_data_usage_metrics_export_status).")
+	}
+	return hints
+}
+
+// formatUsageSummary renders the human-readable part of the usage report
+func formatUsageSummary(b *UsageBreakdown) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## Data Usage (%s)\n\n", strings.ReplaceAll(b.Range, "_", " "))
+	fmt.Fprintf(&sb, "**Total:** %.2f GB (%.2f units) across %d %s value(s)\n\n", b.TotalGB, b.TotalUnits, len(b.Entries), b.AggregateBy)
+
+	if len(b.Entries) > 0 {
+		fmt.Fprintf(&sb, "| %s | GB | Units | Share |\n|---|---|---|---|\n", strings.ToUpper(b.AggregateBy[:1])+b.AggregateBy[1:])
+		for i, e := range b.Entries {
+			if i == 10 {
+				fmt.Fprintf(&sb, "| ... %d more (see JSON breakdown) | | | |\n", len(b.Entries)-10)
+				break
+			}
+			fmt.Fprintf(&sb, "| %s | %.2f | %.2f | %.1f%% |\n", e.Name, e.SizeGB, e.Units, e.Percent)
+		}
+	}
+
+	if len(b.Hints) > 0 {
+		sb.WriteString("\n### 💡 Optimization Hints\n\n")
+		for _, h := range b.Hints {
+			fmt.Fprintf(&sb, "- %s\n", h)
+		}
+	}
+	return sb.String()
 }
 
 // UpdateDataUsageMetricsExportStatusTool updates the data usage metrics export status
@@ -87,6 +284,9 @@ func (t *UpdateDataUsageMetricsExportStatusTool) InputSchema() interface{} {
 			},
 		},
 		"required": []string{"enabled"},
+		"examples": []interface{}{
+			map[string]interface{}{"enabled": true},
+		},
 	}
 }
 
@@ -107,7 +307,7 @@ func (t *UpdateDataUsageMetricsExportStatusTool) Execute(ctx context.Context, ar
 
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	return t.FormatResponseWithSuggestions(result, "update_data_usage_metrics_export_status")
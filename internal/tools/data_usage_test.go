@@ -0,0 +1,102 @@
+package tools
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func usageEntry(app string, sizeGB, units float64) map[string]interface{} {
+	return map[string]interface{}{
+		"timestamp": "2026-10-01T00:00:00Z",
+		"size_gb":   sizeGB,
+		"units":     units,
+		"dimensions": []interface{}{
+			map[string]interface{}{"key": "application_name", "value": app},
+		},
+	}
+}
+
+func TestExportDataUsageTool_Execute(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.RespondWith(200, map[string]interface{}{
+		"entries": []interface{}{
+			usageEntry("checkout", 60, 6),
+			usageEntry("checkout", 20, 2),
+			usageEntry("auth", 15, 1.5),
+			usageEntry("batch", 5, 0.5),
+		},
+	})
+
+	tool := NewExportDataUsageTool(mock, zap.NewNop())
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{"range": "last_week"})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Expected success result")
+	}
+
+	req := mock.LastRequest()
+	if req.Query["range"] != "last_week" || req.Query["aggregate"] != "application" {
+		t.Errorf("Query = %v, want range=last_week aggregate=application", req.Query)
+	}
+
+	if len(result.Content) != 2 {
+		t.Fatalf("expected summary and JSON content, got %d items", len(result.Content))
+	}
+	summary := result.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(summary, "**Total:** 100.00 GB") || !strings.Contains(summary, "| checkout | 80.00 | 8.00 | 80.0% |") {
+		t.Errorf("unexpected summary:\n%s", summary)
+	}
+	if !strings.Contains(summary, "application checkout accounts for 80%") || !strings.Contains(summary, "create_e2m") {
+		t.Errorf("expected optimization hint for checkout:\n%s", summary)
+	}
+
+	var breakdown UsageBreakdown
+	if err := json.Unmarshal([]byte(result.Content[1].(*mcp.TextContent).Text), &breakdown); err != nil {
+		t.Fatalf("breakdown is not JSON: %v", err)
+	}
+	if len(breakdown.Entries) != 3 || breakdown.Entries[0].Name != "checkout" || breakdown.Entries[2].Name != "batch" {
+		t.Errorf("entries = %+v, want checkout, auth, batch by size", breakdown.Entries)
+	}
+	if len(breakdown.Hints) != 1 {
+		t.Errorf("hints = %v, want only checkout above the share threshold", breakdown.Hints)
+	}
+}
+
+func TestBuildUsageBreakdown(t *testing.T) {
+	t.Run("streamed priority entries", func(t *testing.T) {
+		result := map[string]interface{}{
+			"events": []interface{}{
+				map[string]interface{}{"entries": []interface{}{
+					map[string]interface{}{"size_gb": 9.0, "dimensions": []interface{}{
+						map[string]interface{}{"generic": map[string]interface{}{"key": "priority", "value": "high"}},
+					}},
+					map[string]interface{}{"size_gb": 1.0, "dimensions": []interface{}{
+						map[string]interface{}{"key": "priority", "value": "low"},
+					}},
+				}},
+			},
+		}
+		b := BuildUsageBreakdown(result, "last_30_days", "priority")
+		if b.TotalGB != 10 || len(b.Entries) != 2 || b.Entries[0].Name != "high" {
+			t.Errorf("unexpected breakdown: %+v", b)
+		}
+		if len(b.Hints) != 1 || !strings.Contains(b.Hints[0], "90% of volume is high priority") {
+			t.Errorf("hints = %v", b.Hints)
+		}
+	})
+
+	t.Run("no usage", func(t *testing.T) {
+		b := BuildUsageBreakdown(map[string]interface{}{}, "last_week", "application")
+		if len(b.Entries) != 0 || len(b.Hints) != 1 || !strings.Contains(b.Hints[0], "metrics export") {
+			t.Errorf("unexpected empty breakdown: %+v", b)
+		}
+	})
+}
@@ -0,0 +1,546 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements convert_dataprime_to_lucene, which translates DataPrime filters to Lucene.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// ConvertDataPrimeToLuceneTool translates the filter stages of a DataPrime query into Lucene
+type ConvertDataPrimeToLuceneTool struct {
+	*BaseTool
+}
+
+// NewConvertDataPrimeToLuceneTool creates a new ConvertDataPrimeToLuceneTool
+func NewConvertDataPrimeToLuceneTool(c client.Doer, l *zap.Logger) *ConvertDataPrimeToLuceneTool {
+	return &ConvertDataPrimeToLuceneTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *ConvertDataPrimeToLuceneTool) Name() string { return "convert_dataprime_to_lucene" }
+
+// Annotations returns tool hints for LLMs
+func (t *ConvertDataPrimeToLuceneTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Convert DataPrime to Lucene")
+}
+
+// Description returns the tool description
+func (t *ConvertDataPrimeToLuceneTool) Description() string {
+	return `Translate a DataPrime query into Lucene for views and widgets that require Lucene, without executing it.
+
+Only filtering maps to Lucene: filter stages with comparisons (==, !=, >, >=, <, <=), null checks, contains/startsWith/endsWith/matches/in, and &&, ||, ! combinations. Pipeline stages with no Lucene equivalent (groupby, aggregate, extract, window, orderby, limit, ...) and filter expressions that cannot be expressed are listed under unsupported; exact is true only when nothing was left out.
+
+**Related tools:** create_view, replace_view, convert_lucene_to_dataprime, validate_query`
+}
+
+// InputSchema returns the input schema
+func (t *ConvertDataPrimeToLuceneTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"query": map[string]interface{}{
+				"type":        "string",
+				"description": "The DataPrime query to convert",
+				"examples":    []string{"source logs | filter $l.applicationname == 'api' && $m.severity >= ERROR"},
+			},
+		},
+		"required": []string{"query"},
+	}
+}
+
+// UnsupportedConstruct is a part of a DataPrime query that has no Lucene translation
+type UnsupportedConstruct struct {
+	Construct string `json:"construct"`
+	Reason    string `json:"reason"`
+}
+
+// DataPrimeConversion is the result of convert_dataprime_to_lucene
+type DataPrimeConversion struct {
+	DataPrimeQuery string                 `json:"dataprime_query"`
+	LuceneQuery    string                 `json:"lucene_query"`
+	Exact          bool                   `json:"exact"`
+	Unsupported    []UnsupportedConstruct `json:"unsupported,omitempty"`
+}
+
+// Execute converts the query
+func (t *ConvertDataPrimeToLuceneTool) Execute(_ context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	query, err := GetStringParam(args, "query", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+
+	data, err := json.MarshalIndent(ConvertDataPrimeToLucene(query), "", "  ")
+	if err != nil {
+		return NewToolResultError(fmt.Sprintf("Failed to format conversion: %v", err)), nil
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(data)},
+		},
+	}, nil
+}
+
+// dataPrimeStageReasons explains why common pipeline stages cannot be expressed in Lucene
+var dataPrimeStageReasons = map[string]string{
+	"groupby":   "aggregation has no Lucene equivalent",
+	"aggregate": "aggregation has no Lucene equivalent",
+	"count":     "aggregation has no Lucene equivalent",
+	"countby":   "aggregation has no Lucene equivalent",
+	"distinct":  "aggregation has no Lucene equivalent",
+	"top":       "aggregation has no Lucene equivalent",
+	"bottom":    "aggregation has no Lucene equivalent",
+	"extract":   "Lucene cannot derive fields from log content",
+	"create":    "Lucene cannot derive fields from log content",
+	"replace":   "Lucene cannot modify fields",
+	"remove":    "Lucene cannot modify fields",
+	"choose":    "Lucene cannot select fields",
+	"select":    "Lucene cannot select fields",
+	"window":    "windowed computation has no Lucene equivalent",
+	"orderby":   "Lucene queries do not control result order",
+	"sortby":    "Lucene queries do not control result order",
+	"limit":     "Lucene queries do not limit results; use the view or query limit instead",
+	"join":      "Lucene cannot combine datasets",
+	"enrich":    "Lucene cannot enrich logs",
+}
+
+// ConvertDataPrimeToLucene translates the filter stages of a DataPrime query into a Lucene
+// query. Filter stages are ANDed together; every other stage, and any filter expression that
+// has no Lucene form, is reported as unsupported.
+func ConvertDataPrimeToLucene(query string) *DataPrimeConversion {
+	conversion := &DataPrimeConversion{DataPrimeQuery: query}
+	unsupported := func(construct, reason string) {
+		conversion.Unsupported = append(conversion.Unsupported, UnsupportedConstruct{Construct: construct, Reason: reason})
+	}
+
+	var clauses []luceneClause
+	for _, stage := range splitDataPrimeStages(query) {
+		command, rest, _ := strings.Cut(stage, " ")
+		command = strings.ToLower(command)
+		rest = strings.TrimSpace(rest)
+
+		switch command {
+		case "source":
+			if strings.ToLower(rest) != "logs" {
+				unsupported(stage, "Lucene only searches logs")
+			}
+		case "filter", "f", "where":
+			clause, err := (&dataPrimeExprParser{tokens: tokenizeDataPrime(rest)}).parse()
+			if err != nil {
+				unsupported(stage, err.Error())
+				continue
+			}
+			clauses = append(clauses, clause)
+		default:
+			reason, ok := dataPrimeStageReasons[command]
+			if !ok {
+				reason = fmt.Sprintf("the %s stage has no Lucene equivalent", command)
+			}
+			unsupported(stage, reason)
+		}
+	}
+
+	switch len(clauses) {
+	case 0:
+		conversion.LuceneQuery = "*"
+	case 1:
+		conversion.LuceneQuery = clauses[0].text
+	default:
+		parts := make([]string, len(clauses))
+		for i, c := range clauses {
+			parts[i] = c.wrapUnless("AND")
+		}
+		conversion.LuceneQuery = strings.Join(parts, " AND ")
+	}
+	conversion.Exact = len(conversion.Unsupported) == 0
+	return conversion
+}
+
+// splitDataPrimeStages splits a query on pipes, ignoring || and pipes inside string literals
+func splitDataPrimeStages(query string) []string {
+	var stages []string
+	var sb strings.Builder
+	var quote rune
+	runes := []rune(query)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && i+1 < len(runes) {
+				sb.WriteRune(c)
+				i++
+				c = runes[i]
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '|' && i+1 < len(runes) && runes[i+1] == '|':
+			sb.WriteString("||")
+			i++
+			continue
+		case c == '|':
+			if s := strings.TrimSpace(sb.String()); s != "" {
+				stages = append(stages, s)
+			}
+			sb.Reset()
+			continue
+		}
+		sb.WriteRune(c)
+	}
+	if s := strings.TrimSpace(sb.String()); s != "" {
+		stages = append(stages, s)
+	}
+	return stages
+}
+
+// dataPrimeToken is a lexical token of a DataPrime filter expression
+type dataPrimeToken struct {
+	kind  string // "op", "string", "regex", "number", "ident", "punct"
+	value string
+}
+
+// tokenizeDataPrime splits a filter expression into tokens. Field references keep their
+// path, type casts and method name together, e.g. "$d.message:string.contains".
+func tokenizeDataPrime(expr string) []dataPrimeToken {
+	var tokens []dataPrimeToken
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		c := runes[i]
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '\'' || c == '"':
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != c; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) {
+					j++
+				}
+				sb.WriteRune(runes[j])
+			}
+			tokens = append(tokens, dataPrimeToken{"string", sb.String()})
+			i = j + 1
+		case c == '/' && len(tokens) > 0 && tokens[len(tokens)-1].value == "(":
+			j := i + 1
+			for ; j < len(runes) && runes[j] != '/'; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) {
+					j++
+				}
+			}
+			end := min(j, len(runes))
+			tokens = append(tokens, dataPrimeToken{"regex", string(runes[i+1 : end])})
+			i = j + 1
+		case strings.ContainsRune("(),", c):
+			tokens = append(tokens, dataPrimeToken{"punct", string(c)})
+			i++
+		case strings.ContainsRune("=!<>&|~", c):
+			j := i + 1
+			if j < len(runes) && strings.ContainsRune("=&|~", runes[j]) {
+				j++
+			}
+			tokens = append(tokens, dataPrimeToken{"op", string(runes[i:j])})
+			i = j
+		case unicode.IsDigit(c) || (c == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			j := i + 1
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, dataPrimeToken{"number", string(runes[i:j])})
+			i = j
+		default:
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || strings.ContainsRune("$._:-", runes[j])) {
+				j++
+			}
+			if j == i {
+				j++
+			}
+			tokens = append(tokens, dataPrimeToken{"ident", string(runes[i:j])})
+			i = j
+		}
+	}
+	return tokens
+}
+
+// luceneClause is a translated expression; op is the top-level operator joining its
+// parts ("AND" or "OR"), empty for a single term or a parenthesized group
+type luceneClause struct {
+	text string
+	op   string
+}
+
+// wrapUnless parenthesizes the clause when it is joined by an operator other than op
+func (c luceneClause) wrapUnless(op string) string {
+	if c.op == "" || c.op == op {
+		return c.text
+	}
+	return "(" + c.text + ")"
+}
+
+// dataPrimeExprParser converts a tokenized DataPrime boolean expression to Lucene
+type dataPrimeExprParser struct {
+	tokens []dataPrimeToken
+	pos    int
+}
+
+func (p *dataPrimeExprParser) peek() dataPrimeToken {
+	if p.pos >= len(p.tokens) {
+		return dataPrimeToken{}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *dataPrimeExprParser) next() dataPrimeToken {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+// isBoolOp reports whether the next token is the given boolean operator in symbol or word form
+func (p *dataPrimeExprParser) isBoolOp(symbol, word string) bool {
+	t := p.peek()
+	return (t.kind == "op" && t.value == symbol) || (t.kind == "ident" && strings.EqualFold(t.value, word))
+}
+
+func (p *dataPrimeExprParser) parse() (luceneClause, error) {
+	if len(p.tokens) == 0 {
+		return luceneClause{}, fmt.Errorf("empty filter")
+	}
+	clause, err := p.parseOr()
+	if err != nil {
+		return luceneClause{}, err
+	}
+	if p.pos < len(p.tokens) {
+		return luceneClause{}, fmt.Errorf("cannot translate %q", p.peek().value)
+	}
+	return clause, nil
+}
+
+func (p *dataPrimeExprParser) parseOr() (luceneClause, error) {
+	return p.parseBinary("||", "or", "OR", p.parseAnd)
+}
+
+func (p *dataPrimeExprParser) parseAnd() (luceneClause, error) {
+	return p.parseBinary("&&", "and", "AND", p.parseUnary)
+}
+
+func (p *dataPrimeExprParser) parseBinary(symbol, word, op string, operand func() (luceneClause, error)) (luceneClause, error) {
+	first, err := operand()
+	if err != nil {
+		return luceneClause{}, err
+	}
+	parts := []string{first.wrapUnless(op)}
+	for p.isBoolOp(symbol, word) {
+		p.pos++
+		next, err := operand()
+		if err != nil {
+			return luceneClause{}, err
+		}
+		parts = append(parts, next.wrapUnless(op))
+	}
+	if len(parts) == 1 {
+		return first, nil
+	}
+	return luceneClause{text: strings.Join(parts, " "+op+" "), op: op}, nil
+}
+
+func (p *dataPrimeExprParser) parseUnary() (luceneClause, error) {
+	if p.isBoolOp("!", "not") {
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return luceneClause{}, err
+		}
+		return luceneClause{text: "NOT " + operand.wrapUnless("")}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *dataPrimeExprParser) parsePrimary() (luceneClause, error) {
+	t := p.next()
+	if t.kind == "punct" && t.value == "(" {
+		inner, err := p.parseOr()
+		if err != nil {
+			return luceneClause{}, err
+		}
+		if p.next().value != ")" {
+			return luceneClause{}, fmt.Errorf("unbalanced parenthesis")
+		}
+		if inner.op == "" {
+			return inner, nil
+		}
+		return luceneClause{text: "(" + inner.text + ")"}, nil
+	}
+	if t.kind != "ident" || !strings.HasPrefix(t.value, "$") {
+		return luceneClause{}, fmt.Errorf("cannot translate %q: only comparisons on $l, $m and $d fields map to Lucene", t.value)
+	}
+
+	// Method call: $d.field.contains('x')
+	if p.peek().value == "(" {
+		dot := strings.LastIndex(t.value, ".")
+		if dot < 0 {
+			return luceneClause{}, fmt.Errorf("cannot translate function %s()", t.value)
+		}
+		return p.parseMethod(t.value[:dot], t.value[dot+1:])
+	}
+
+	field, err := dataPrimeRefToLucene(t.value)
+	if err != nil {
+		return luceneClause{}, err
+	}
+	op := p.peek()
+	if op.kind != "op" || op.value == "&&" || op.value == "||" || op.value == "!" {
+		// A bare boolean field
+		return luceneClause{text: field + ":true"}, nil
+	}
+	p.pos++
+	value := p.next()
+	if value.kind == "" {
+		return luceneClause{}, fmt.Errorf("missing value after %s %s", t.value, op.value)
+	}
+	return compareToLucene(field, op.value, value)
+}
+
+// parseMethod converts a string method call on ref
+func (p *dataPrimeExprParser) parseMethod(ref, method string) (luceneClause, error) {
+	p.pos++ // (
+	var args []dataPrimeToken
+	for {
+		arg := p.next()
+		switch {
+		case arg.kind == "":
+			return luceneClause{}, fmt.Errorf("unterminated call to %s()", method)
+		case arg.value == ")":
+		case arg.value == ",":
+			continue
+		case arg.kind == "string" || arg.kind == "number" || arg.kind == "regex":
+			args = append(args, arg)
+			continue
+		default:
+			return luceneClause{}, fmt.Errorf("cannot translate argument %q of %s()", arg.value, method)
+		}
+		break
+	}
+
+	field, err := dataPrimeRefToLucene(ref)
+	if err != nil {
+		return luceneClause{}, err
+	}
+	if method == "in" && len(args) > 0 {
+		values := make([]string, len(args))
+		for i, a := range args {
+			values[i] = field + ":" + luceneValue(a.value)
+		}
+		if len(values) == 1 {
+			return luceneClause{text: values[0]}, nil
+		}
+		return luceneClause{text: "(" + strings.Join(values, " OR ") + ")"}, nil
+	}
+	if len(args) != 1 {
+		return luceneClause{}, fmt.Errorf("cannot translate %s() with %d arguments", method, len(args))
+	}
+	arg := args[0]
+	switch {
+	case method == "matches" && arg.kind == "regex":
+		return luceneClause{text: field + ":/" + arg.value + "/"}, nil
+	case method == "contains":
+		return luceneClause{text: field + ":*" + luceneEscape(arg.value) + "*"}, nil
+	case method == "startsWith":
+		return luceneClause{text: field + ":" + luceneEscape(arg.value) + "*"}, nil
+	case method == "endsWith":
+		return luceneClause{text: field + ":*" + luceneEscape(arg.value)}, nil
+	}
+	return luceneClause{}, fmt.Errorf("%s() has no Lucene equivalent", method)
+}
+
+// compareToLucene converts field <op> value
+func compareToLucene(field, op string, value dataPrimeToken) (luceneClause, error) {
+	if value.kind == "ident" && strings.EqualFold(value.value, "null") {
+		switch op {
+		case "==":
+			return luceneClause{text: "NOT _exists_:" + field}, nil
+		case "!=":
+			return luceneClause{text: "_exists_:" + field}, nil
+		}
+		return luceneClause{}, fmt.Errorf("cannot compare %s with null using %s", field, op)
+	}
+
+	v := value.value
+	if field == "severity" {
+		if level, ok := NormalizeSeverity(v); ok {
+			v = strconv.Itoa(level)
+		}
+	}
+	switch value.kind {
+	case "string", "number", "ident":
+	default:
+		return luceneClause{}, fmt.Errorf("cannot translate value %q", value.value)
+	}
+
+	switch op {
+	case "==":
+		return luceneClause{text: field + ":" + luceneValue(v)}, nil
+	case "!=":
+		return luceneClause{text: "NOT " + field + ":" + luceneValue(v)}, nil
+	case ">", ">=", "<", "<=":
+		return luceneClause{text: field + ":" + op + luceneEscape(v)}, nil
+	case "~":
+		return luceneClause{text: field + ":*" + luceneEscape(v) + "*"}, nil
+	}
+	return luceneClause{}, fmt.Errorf("operator %s has no Lucene equivalent", op)
+}
+
+// dataPrimeRefToLucene maps a DataPrime field reference to the Lucene field name used by
+// build_query: labels and metadata by their bare name, data fields by their path
+func dataPrimeRefToLucene(ref string) (string, error) {
+	var path string
+	switch {
+	case strings.HasPrefix(ref, "$l."), strings.HasPrefix(ref, "$m."), strings.HasPrefix(ref, "$d."):
+		path = ref[3:]
+	default:
+		return "", fmt.Errorf("cannot translate field %s", ref)
+	}
+	// Drop type casts such as $d.message:string
+	if i := strings.Index(path, ":"); i >= 0 {
+		path = path[:i]
+	}
+	if path == "" {
+		return "", fmt.Errorf("cannot translate field %s", ref)
+	}
+	return path, nil
+}
+
+// luceneSpecialChars are the characters Lucene requires to be escaped in terms
+const luceneSpecialChars = `+-&|!(){}[]^"~*?:\/ `
+
+// luceneEscape backslash-escapes Lucene special characters so a value can be used in a
+// wildcard or range term, where quoting is not allowed
+func luceneEscape(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(luceneSpecialChars, r) {
+			sb.WriteRune('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// luceneValue renders an exact-match value, quoting it when it contains special characters
+func luceneValue(s string) string {
+	if s != "" && !strings.ContainsAny(s, luceneSpecialChars) {
+		return s
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestConvertDataPrimeToLucene(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "labels and severity",
+			query: "source logs | filter $l.applicationname == 'api' && $m.severity >= ERROR",
+			want:  "applicationname:api AND severity:>=5",
+		},
+		{
+			name:  "or nested in and keeps grouping",
+			query: "source logs | filter ($d.status_code >= 500 || $d.message:string.contains('timeout')) && !($l.subsystemname == 'health check')",
+			want:  `(status_code:>=500 OR message:*timeout*) AND NOT subsystemname:"health check"`,
+		},
+		{
+			name:  "and nested in or is parenthesized",
+			query: "source logs | filter $d.a == 'x' && $d.b == 'y' || $d.c != 'z'",
+			want:  "(a:x AND b:y) OR NOT c:z",
+		},
+		{
+			name:  "multiple filter stages",
+			query: "source logs | filter $d.trace_id != null | filter $d.user.startsWith('adm') || $d.user.endsWith('root')",
+			want:  `_exists_:trace_id AND (user:adm* OR user:*root)`,
+		},
+		{
+			name:  "regex and in",
+			query: "source logs | filter $d.path.matches(/^\\/api\\/v[12]/) && $l.applicationname.in('a', 'b')",
+			want:  `path:/^\/api\/v[12]/ AND (applicationname:a OR applicationname:b)`,
+		},
+		{
+			name:  "null check and escaping",
+			query: "source logs | filter $d.error == null && $d.host.contains('web 1') && $d.q == 'a|b'",
+			want:  `NOT _exists_:error AND host:*web\ 1* AND q:"a|b"`,
+		},
+		{
+			name:  "no filter",
+			query: "source logs",
+			want:  "*",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ConvertDataPrimeToLucene(tt.query)
+			assert.Equal(t, tt.want, got.LuceneQuery)
+			assert.True(t, got.Exact)
+			assert.Empty(t, got.Unsupported)
+		})
+	}
+}
+
+func TestConvertDataPrimeToLucene_Unsupported(t *testing.T) {
+	got := ConvertDataPrimeToLucene("source logs | filter $l.applicationname == 'api' | extract $d.msg into $d.x using regexp(e=/(?<x>.*)/) | filter toLowerCase($d.x) == 'a' | groupby $l.subsystemname aggregate count() as c | window 5m")
+
+	assert.Equal(t, "applicationname:api", got.LuceneQuery)
+	assert.False(t, got.Exact)
+	require.Len(t, got.Unsupported, 4)
+	assert.Contains(t, got.Unsupported[0].Construct, "extract")
+	assert.Contains(t, got.Unsupported[1].Construct, "toLowerCase")
+	assert.Contains(t, got.Unsupported[2].Reason, "aggregation")
+	assert.Contains(t, got.Unsupported[3].Reason, "window")
+}
+
+func TestConvertDataPrimeToLucene_RoundTrip(t *testing.T) {
+	lucene := `applicationname:api AND severity:>=4 AND NOT message:*healthz*`
+	dp, err := ConvertLuceneToDataPrime(lucene, "AND")
+	require.NoError(t, err)
+
+	back := ConvertDataPrimeToLucene(dp.DataPrimeQuery)
+	assert.True(t, back.Exact)
+	assert.Equal(t, lucene, back.LuceneQuery)
+}
+
+func TestConvertDataPrimeToLuceneTool_Execute(t *testing.T) {
+	mock := client.NewMockClient()
+	tool := NewConvertDataPrimeToLuceneTool(mock, zap.NewNop())
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{
+		"query": "source logs | filter $m.severity == CRITICAL | limit 5",
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Zero(t, mock.RequestCount())
+
+	var out DataPrimeConversion
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &out))
+	assert.Equal(t, "severity:6", out.LuceneQuery)
+	assert.False(t, out.Exact)
+	require.Len(t, out.Unsupported, 1)
+	assert.Equal(t, "limit 5", out.Unsupported[0].Construct)
+}
@@ -0,0 +1,244 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements the dependents check that guards delete tools against orphaning references.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// Dependent is a resource that references the target of a delete
+type Dependent struct {
+	Type  string `json:"type"`
+	ID    string `json:"id"`
+	Name  string `json:"name,omitempty"`
+	Field string `json:"field"`
+}
+
+// dependentSource is a collection that may reference a deleted resource through RefKeys
+type dependentSource struct {
+	Type     string
+	Path     string
+	ListKeys []string
+	RefKeys  []string
+}
+
+// deleteGuard describes how a delete tool checks for dependents before deleting
+type deleteGuard struct {
+	ResourceType string            // Display name, e.g. "outgoing webhook"
+	Path         string            // Item path prefix; the ID is appended
+	ListTool     string            // Tool that lists the resource type
+	Sources      []dependentSource // Collections scanned for references
+	// TargetIDs returns the identifiers references may use besides the ID, such as a
+	// webhook's numeric external_id
+	TargetIDs func(target map[string]interface{}) []string
+	// Impact explains what deleting does when nothing references the target
+	Impact string
+}
+
+// webhookDeleteGuard finds alerts and alert definitions notifying through a webhook
+var webhookDeleteGuard = deleteGuard{
+	ResourceType: "outgoing webhook",
+	Path:         "/v1/outgoing_webhooks/",
+	ListTool:     "list_outgoing_webhooks",
+	Sources: []dependentSource{
+		{Type: "alert", Path: "/v1/alerts", ListKeys: []string{"alerts"}, RefKeys: []string{"webhook_id", "integration_id", "outgoing_webhook_id"}},
+		{Type: "alert_definition", Path: "/v1/alert_definitions", ListKeys: []string{"alert_definitions", "alert_defs"}, RefKeys: []string{"webhook_id", "integration_id", "outgoing_webhook_id"}},
+	},
+	TargetIDs: func(target map[string]interface{}) []string {
+		if ext, ok := target["external_id"]; ok && ext != nil {
+			return []string{fmt.Sprint(ext)}
+		}
+		return nil
+	},
+	Impact: "Alerts notifying through this webhook would stop sending notifications.",
+}
+
+// policyDeleteGuard has no referencing collections; its preview explains the routing impact
+var policyDeleteGuard = deleteGuard{
+	ResourceType: "policy",
+	Path:         "/v1/policies/",
+	ListTool:     "list_policies",
+	Impact:       "Logs matched by this policy fall back to the default priority and archive routing.",
+}
+
+// deleteDependentsSchema adds the dry_run argument, and the force argument when the guard has
+// referencing collections to check, to a delete tool's id schema
+func deleteDependentsSchema(g deleteGuard) map[string]interface{} {
+	dryRun := "If true, do not delete; confirm it exists and explain what deleting would affect"
+	if len(g.Sources) > 0 {
+		dryRun = "If true, do not delete; list the resources that reference it and what deleting would affect"
+	}
+	properties := map[string]interface{}{
+		"id": map[string]interface{}{
+			"type":        "string",
+			"description": fmt.Sprintf("ID of the %s to delete", g.ResourceType),
+		},
+		"dry_run": map[string]interface{}{
+			"type":        "boolean",
+			"description": dryRun,
+			"default":     false,
+		},
+	}
+	if len(g.Sources) > 0 {
+		properties["force"] = map[string]interface{}{
+			"type":        "boolean",
+			"description": "Delete even if other resources reference it, skipping the dependents check",
+			"default":     false,
+		}
+	}
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+		"required":   []string{"id"},
+	}
+}
+
+// guardedDelete previews or performs a delete. Without force, the delete is refused when
+// other resources reference the target, so they are not silently broken. Guards without
+// referencing collections take no force and always confirm the target exists first.
+func (t *BaseTool) guardedDelete(ctx context.Context, args map[string]interface{}, g deleteGuard, toolName string) (*mcp.CallToolResult, error) {
+	id, err := GetStringParam(args, "id", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	dryRun, _ := GetBoolParam(args, "dry_run", false)
+	force, _ := GetBoolParam(args, "force", false)
+	force = force && len(g.Sources) > 0
+
+	if dryRun || !force {
+		target, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: g.Path + id})
+		if err != nil {
+			return HandleGetError(err, g.ResourceType, id, g.ListTool), nil
+		}
+		dependents, failed := t.findDependents(ctx, g, id, target)
+
+		if dryRun {
+			name, _ := target["name"].(string)
+			preview := map[string]interface{}{
+				"dry_run":         true,
+				"resource_type":   g.ResourceType,
+				"id":              id,
+				"name":            name,
+				"dependent_count": len(dependents),
+				"dependents":      dependents,
+			}
+			switch {
+			case len(dependents) > 0:
+				preview["warning"] = fmt.Sprintf("%d resource(s) reference this %s and would be left with a dangling reference. %s",
+					len(dependents), g.ResourceType, g.Impact)
+			case g.Impact != "":
+				preview["impact"] = g.Impact
+			}
+			if len(failed) > 0 {
+				preview["checks_failed"] = failed
+			}
+			return MarkDryRun(t.FormatResponse(preview))
+		}
+
+		if len(dependents) > 0 {
+			return NewToolResultErrorWithSuggestion(
+				fmt.Sprintf("Refusing to delete %s %s: it is referenced by %s", g.ResourceType, id, describeDependents(dependents)),
+				"Detach it from these resources first, or pass force=true to delete anyway. Use dry_run=true to review the dependents."), nil
+		}
+		if len(failed) > 0 {
+			return NewToolResultErrorWithSuggestion(
+				fmt.Sprintf("Refusing to delete %s %s: could not check for dependents (%s)", g.ResourceType, id, strings.Join(failed, "; ")),
+				"Retry, or pass force=true to delete without the dependents check."), nil
+		}
+	}
+
+	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "DELETE", Path: g.Path + id})
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	return t.FormatResponseWithSuggestions(res, toolName)
+}
+
+// findDependents lists the guard's sources concurrently and returns the items referencing
+// the target, plus a description of each source that could not be listed
+func (t *BaseTool) findDependents(ctx context.Context, g deleteGuard, id string, target map[string]interface{}) ([]Dependent, []string) {
+	ids := map[string]bool{id: true}
+	if g.TargetIDs != nil {
+		for _, alt := range g.TargetIDs(target) {
+			ids[alt] = true
+		}
+	}
+
+	reqs := make([]*client.Request, len(g.Sources))
+	for i, src := range g.Sources {
+		reqs[i] = &client.Request{Method: "GET", Path: src.Path}
+	}
+	dependents := []Dependent{}
+	var failed []string
+	for i, res := range t.ExecuteConcurrent(ctx, reqs, len(reqs)) {
+		src := g.Sources[i]
+		if res.Err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", src.Path, res.Err))
+			continue
+		}
+		for _, item := range listItems(res.Result, src.ListKeys) {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if field := findReference(m, src.RefKeys, ids, ""); field != "" {
+				depID, _ := m["id"].(string)
+				name, _ := m["name"].(string)
+				dependents = append(dependents, Dependent{Type: src.Type, ID: depID, Name: name, Field: field})
+			}
+		}
+	}
+	return dependents, failed
+}
+
+// findReference returns the path of the first refKeys field, at any depth, whose value is
+// one of ids, or ""
+func findReference(value interface{}, refKeys []string, ids map[string]bool, path string) string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, key := range refKeys {
+			if ref, ok := v[key]; ok && ref != nil && ids[fmt.Sprint(ref)] {
+				return joinPath(path, key)
+			}
+		}
+		for key, child := range v {
+			if field := findReference(child, refKeys, ids, joinPath(path, key)); field != "" {
+				return field
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			if field := findReference(child, refKeys, ids, fmt.Sprintf("%s[%d]", path, i)); field != "" {
+				return field
+			}
+		}
+	}
+	return ""
+}
+
+// joinPath appends key to a dotted path
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// describeDependents lists dependents as "alert 'High errors' (id)"
+func describeDependents(dependents []Dependent) string {
+	parts := make([]string, 0, len(dependents))
+	for _, d := range dependents {
+		if d.Name != "" {
+			parts = append(parts, fmt.Sprintf("%s '%s' (%s)", d.Type, d.Name, d.ID))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s %s", d.Type, d.ID))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
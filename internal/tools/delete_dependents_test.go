@@ -0,0 +1,137 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// webhookDependentsMock serves a webhook, alerts and alert definitions; the first alert
+// notifies through the webhook's external_id
+func webhookDependentsMock(t *testing.T, alertsStatus int) *client.MockClient {
+	mock := client.NewMockClient()
+	mock.DoFunc = func(_ context.Context, req *client.Request) (*client.Response, error) {
+		var body interface{}
+		status := 200
+		switch {
+		case req.Method == "DELETE":
+			body = map[string]interface{}{}
+		case req.Path == "/v1/outgoing_webhooks/wh-1":
+			body = map[string]interface{}{"id": "wh-1", "name": "Slack", "external_id": 42}
+		case req.Path == "/v1/alerts":
+			status = alertsStatus
+			body = map[string]interface{}{"alerts": []interface{}{
+				map[string]interface{}{"id": "a1", "name": "High errors", "notification_groups": []interface{}{
+					map[string]interface{}{"notifications": []interface{}{map[string]interface{}{"integration_id": 42}}},
+				}},
+				map[string]interface{}{"id": "a2", "name": "Other", "notification_groups": []interface{}{
+					map[string]interface{}{"notifications": []interface{}{map[string]interface{}{"webhook_id": "wh-2"}}},
+				}},
+			}}
+		case req.Path == "/v1/alert_definitions":
+			body = map[string]interface{}{"alert_definitions": []interface{}{}}
+		default:
+			status = 404
+			body = map[string]interface{}{"message": "not found"}
+		}
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		return &client.Response{StatusCode: status, Body: data}, nil
+	}
+	return mock
+}
+
+func countDeletes(mock *client.MockClient) int {
+	n := 0
+	for _, req := range mock.Requests {
+		if req.Method == "DELETE" {
+			n++
+		}
+	}
+	return n
+}
+
+func TestDeleteOutgoingWebhookTool_DryRunListsDependents(t *testing.T) {
+	mock := webhookDependentsMock(t, 200)
+	tool := NewDeleteOutgoingWebhookTool(mock, zap.NewNop())
+
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{"id": "wh-1", "dry_run": true})
+	require.NoError(t, err)
+	require.False(t, result.IsError, "%+v", result.Content)
+	var preview struct {
+		Dependents []Dependent `json:"dependents"`
+		Warning    string      `json:"warning"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &preview))
+	require.Len(t, preview.Dependents, 1)
+	assert.Equal(t, Dependent{Type: "alert", ID: "a1", Name: "High errors", Field: "notification_groups[0].notifications[0].integration_id"}, preview.Dependents[0])
+	assert.Contains(t, preview.Warning, "1 resource(s) reference this outgoing webhook")
+	assert.Zero(t, countDeletes(mock))
+}
+
+func TestDeleteOutgoingWebhookTool_RefusesWithDependents(t *testing.T) {
+	mock := webhookDependentsMock(t, 200)
+	tool := NewDeleteOutgoingWebhookTool(mock, zap.NewNop())
+
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{"id": "wh-1"})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "alert 'High errors' (a1)")
+	assert.Zero(t, countDeletes(mock))
+
+	mock.Reset()
+	result, err = tool.Execute(testCtx(mock), map[string]interface{}{"id": "wh-1", "force": true})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Equal(t, 1, countDeletes(mock))
+	assert.Equal(t, 1, mock.RequestCount(), "force skips the dependents check")
+}
+
+func TestDeleteOutgoingWebhookTool_FailedCheckRefusesDelete(t *testing.T) {
+	mock := webhookDependentsMock(t, 500)
+	tool := NewDeleteOutgoingWebhookTool(mock, zap.NewNop())
+
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{"id": "wh-1"})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "could not check for dependents")
+	assert.Zero(t, countDeletes(mock))
+}
+
+func TestDeletePolicyTool_DeletesWithoutDependents(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.RespondWith(200, map[string]interface{}{"id": "p1", "name": "Block debug"})
+	mock.RespondWith(200, map[string]interface{}{})
+	tool := NewDeletePolicyTool(mock, zap.NewNop())
+
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{"id": "p1"})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	require.Len(t, mock.Requests, 2)
+	assert.Equal(t, "DELETE", mock.Requests[1].Method)
+	assert.Equal(t, "/v1/policies/p1", mock.Requests[1].Path)
+
+	mock.Reset()
+	mock.RespondWith(404, map[string]interface{}{"message": "not found"})
+	result, err = tool.Execute(testCtx(mock), map[string]interface{}{"id": "missing", "dry_run": true})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Equal(t, 1, mock.RequestCount())
+
+	// Policies have no dependents check, so force is not offered and does not skip the lookup
+	properties := tool.InputSchema().(map[string]interface{})["properties"].(map[string]interface{})
+	assert.NotContains(t, properties, "force")
+	mock.Reset()
+	mock.RespondWith(404, map[string]interface{}{"message": "not found"})
+	result, err = tool.Execute(testCtx(mock), map[string]interface{}{"id": "missing", "force": true})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Zero(t, countDeletes(mock))
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"go.uber.org/zap"
@@ -81,8 +82,9 @@ type AdaptiveChain struct {
 	Tools       []string `json:"tools"`
 	SuccessRate float64  `json:"success_rate"`
 	UseCount    int      `json:"use_count"`
-	Confidence  string   `json:"confidence"` // "high", "medium", "low"
-	Source      string   `json:"source"`     // "learned", "suggested", "static"
+	Weight      float64  `json:"weight,omitempty"` // UseCount decayed by time since last use (learned chains only)
+	Confidence  string   `json:"confidence"`       // "high", "medium", "low"
+	Source      string   `json:"source"`           // "learned", "suggested", "static"
 }
 
 // ToolMatch represents a tool that matches the discovery query
@@ -490,7 +492,7 @@ func (r *ToolRegistry) registerToolChains() {
 			Description: "Investigate error spikes and set up alerting",
 			Trigger:     "query_logs",
 			Condition:   "High error rate detected",
-			Sequence:    []string{"query_logs", "investigate_incident", "suggest_alert", "create_alert"},
+			Sequence:    []string{"query_logs", "investigate_incident", "suggest_alert", "create_alert_from_query"},
 			UseCases:    []string{"Error investigation", "Incident response"},
 		},
 		{
@@ -1013,6 +1015,12 @@ func (r *ToolRegistry) buildIntentIndex() {
 
 // DiscoverTools finds tools matching the given intent or criteria
 func (r *ToolRegistry) DiscoverTools(intent string, category ToolCategory, complexity string) *DiscoveryResult {
+	return r.DiscoverToolsForSession(GetSession(), intent, category, complexity)
+}
+
+// DiscoverToolsForSession finds tools matching the given intent or criteria, using session for
+// feedback, learned chains, and recommendations. A nil session yields results without session context.
+func (r *ToolRegistry) DiscoverToolsForSession(session *SessionContext, intent string, category ToolCategory, complexity string) *DiscoveryResult {
 	result := &DiscoveryResult{
 		Intent:       intent,
 		MatchedTools: []ToolMatch{},
@@ -1092,8 +1100,11 @@ func (r *ToolRegistry) DiscoverTools(intent string, category ToolCategory, compl
 			continue
 		}
 
-		// Calculate relevance from keyword matching
+		// Calculate relevance from keyword matching, nudged by explicit record_feedback votes
 		relevance, reason := calculateRelevance(intentWords, meta)
+		if relevance > 0 && session != nil {
+			relevance = min(1, max(0, relevance+session.ToolFeedbackScore(toolName)*feedbackRelevanceBoost))
+		}
 		if relevance > 0.3 {
 			result.MatchedTools = append(result.MatchedTools, ToolMatch{
 				Name:       toolName,
@@ -1106,6 +1117,15 @@ func (r *ToolRegistry) DiscoverTools(intent string, category ToolCategory, compl
 		}
 	}
 
+	// Drop tools the server's access policy does not expose
+	allowed := result.MatchedTools[:0]
+	for _, m := range result.MatchedTools {
+		if isToolNameAllowed(m.Name) {
+			allowed = append(allowed, m)
+		}
+	}
+	result.MatchedTools = allowed
+
 	// Sort by relevance
 	sort.Slice(result.MatchedTools, func(i, j int) bool {
 		return result.MatchedTools[i].Relevance > result.MatchedTools[j].Relevance
@@ -1119,8 +1139,9 @@ func (r *ToolRegistry) DiscoverTools(intent string, category ToolCategory, compl
 	result.SuggestedChain = r.findMatchingChain(intentLower)
 
 	// Add session context
-	session := GetSession()
-	result.SessionContext = session.GetSessionSummary()
+	if session != nil {
+		result.SessionContext = session.GetSessionSummary()
+	}
 
 	// Generate adaptive chains based on learned patterns
 	result.AdaptiveChains = r.generateAdaptiveChains(result.MatchedTools, session)
@@ -1353,9 +1374,9 @@ func (r *ToolRegistry) generateAdaptiveChains(matches []ToolMatch, session *Sess
 		return chains
 	}
 
-	// Get learned patterns from session
-	patterns := session.LearnedPatterns
-	if patterns == nil || len(patterns.FrequentSequences) == 0 {
+	// Get learned sequences that have enough observations and haven't decayed away
+	sequences := session.GetLearnedSequences(time.Now())
+	if len(sequences) == 0 {
 		// No learned patterns yet - suggest chains based on matched tools
 		return r.suggestChainsFromMatches(matches)
 	}
@@ -1366,13 +1387,14 @@ func (r *ToolRegistry) generateAdaptiveChains(matches []ToolMatch, session *Sess
 		matchedToolNames[m.Name] = true
 	}
 
-	for _, seq := range patterns.FrequentSequences {
-		// Only include sequences that start with a matched tool or are highly successful
+	for _, seq := range sequences {
+		// Only include sequences that start with a matched tool or are highly successful.
+		// Decayed weight stands in for the use count so chains unused for a while fade out.
 		includeChain := false
 		if len(seq.Tools) > 0 && matchedToolNames[seq.Tools[0]] {
 			includeChain = true
 		}
-		if seq.SuccessRate >= 80.0 && seq.Count >= 3 {
+		if seq.SuccessRate >= 80.0 && seq.Weight >= 3 {
 			includeChain = true
 		}
 
@@ -1380,11 +1402,11 @@ func (r *ToolRegistry) generateAdaptiveChains(matches []ToolMatch, session *Sess
 			continue
 		}
 
-		// Determine confidence based on usage and success rate
+		// Determine confidence based on recent usage and success rate
 		confidence := "low"
-		if seq.Count >= 5 && seq.SuccessRate >= 80.0 {
+		if seq.Weight >= 5 && seq.SuccessRate >= 80.0 {
 			confidence = "high"
-		} else if seq.Count >= 3 && seq.SuccessRate >= 60.0 {
+		} else if seq.Weight >= 3 && seq.SuccessRate >= 60.0 {
 			confidence = "medium"
 		}
 
@@ -1394,6 +1416,7 @@ func (r *ToolRegistry) generateAdaptiveChains(matches []ToolMatch, session *Sess
 			Tools:       seq.Tools,
 			SuccessRate: seq.SuccessRate,
 			UseCount:    seq.Count,
+			Weight:      seq.Weight,
 			Confidence:  confidence,
 			Source:      "learned",
 		}
@@ -1538,7 +1561,8 @@ func sortAdaptiveChains(chains []*AdaptiveChain) {
 	}
 }
 
-// chainScore calculates a score for sorting chains
+// chainScore calculates a score for sorting chains. Explicit record_feedback on a learned
+// chain reaches the score through SuccessRate, where it counts as FeedbackWeight uses.
 func chainScore(chain *AdaptiveChain) float64 {
 	confidenceScore := 0.0
 	switch chain.Confidence {
@@ -1550,8 +1574,12 @@ func chainScore(chain *AdaptiveChain) float64 {
 		confidenceScore = 1.0
 	}
 
-	// Combine confidence with success rate and usage
-	return confidenceScore*100 + chain.SuccessRate + float64(chain.UseCount)*0.1
+	// Combine confidence with success rate and usage, preferring the decayed weight when known
+	usage := float64(chain.UseCount)
+	if chain.Weight > 0 {
+		usage = chain.Weight
+	}
+	return confidenceScore*100 + chain.SuccessRate + usage*0.1
 }
 
 // generateAdaptiveChainRecommendations creates recommendations based on adaptive chains
@@ -1731,6 +1759,9 @@ func (r *ToolRegistry) generateRecommendations(matches []ToolMatch, session *Ses
 	}
 
 	// Recommend based on session
+	if session == nil {
+		return recs
+	}
 	if session.GetLastQuery() != "" {
 		recs = append(recs, "Previous query context available - tools can reference it")
 	}
@@ -1842,7 +1873,7 @@ func (t *DiscoverToolsTool) Annotations() *mcp.ToolAnnotations {
 }
 
 // Execute executes the tool
-func (t *DiscoverToolsTool) Execute(_ context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+func (t *DiscoverToolsTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	intent, _ := GetStringParam(args, "intent", false)
 	categoryStr, _ := GetStringParam(args, "category", false)
 	complexity, _ := GetStringParam(args, "complexity", false)
@@ -1857,7 +1888,7 @@ func (t *DiscoverToolsTool) Execute(_ context.Context, args map[string]interface
 	}
 
 	registry := GetToolRegistry()
-	result := registry.DiscoverTools(intent, category, complexity)
+	result := registry.DiscoverToolsForSession(GetSessionFromContext(ctx), intent, category, complexity)
 
 	// Format output
 	output, err := json.MarshalIndent(result, "", "  ")
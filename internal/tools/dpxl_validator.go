@@ -0,0 +1,253 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// dpxlVersionPrefix is the version tag every DPXL stream filter starts with
+const dpxlVersionPrefix = "<v1>"
+
+// dpxlOperators are the operators a DPXL expression may use
+var dpxlOperators = map[string]bool{
+	"==": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true,
+	"&&": true, "||": true, "!": true,
+}
+
+// dpxlOperatorHints suggest the DPXL spelling of operators from other languages
+var dpxlOperatorHints = map[string]string{
+	"=": "==", "===": "==", "<>": "!=", "!==": "!=", "&": "&&", "|": "||", "=<": "<=", "=>": ">=",
+}
+
+// dpxlTokenKind classifies a DPXL token for the structural checks
+type dpxlTokenKind int
+
+const (
+	dpxlOperand dpxlTokenKind = iota // field path, string, number, or keyword literal
+	dpxlBinaryOp
+	dpxlNotOp
+	dpxlOpenParen
+	dpxlCloseParen
+	dpxlComma
+)
+
+type dpxlToken struct {
+	kind dpxlTokenKind
+	text string
+	pos  int
+}
+
+// ValidateDPXLExpression checks a stream filter expression for the mistakes the API would
+// reject: an empty body, unbalanced parentheses, unterminated strings, unknown operators, and
+// operators or operands out of place. A missing <v1> prefix is tolerated; callers warn about it.
+func ValidateDPXLExpression(expr string) error {
+	offset := len(expr) - len(strings.TrimLeft(expr, " \t\r\n"))
+	if strings.HasPrefix(expr[offset:], dpxlVersionPrefix) {
+		offset += len(dpxlVersionPrefix)
+	}
+	if strings.TrimSpace(expr[offset:]) == "" {
+		return fmt.Errorf("DPXL expression must not be empty")
+	}
+
+	tokens, err := tokenizeDPXL(expr, offset)
+	if err != nil {
+		return err
+	}
+
+	var open []int
+	for i, tok := range tokens {
+		var prev *dpxlToken
+		if i > 0 {
+			prev = &tokens[i-1]
+		}
+		switch tok.kind {
+		case dpxlOpenParen:
+			open = append(open, tok.pos)
+		case dpxlCloseParen:
+			if len(open) == 0 {
+				return fmt.Errorf("DPXL expression has an unmatched ')' at position %d", tok.pos+1)
+			}
+			open = open[:len(open)-1]
+			if prev != nil && (prev.kind == dpxlBinaryOp || prev.kind == dpxlNotOp || prev.kind == dpxlComma) {
+				return fmt.Errorf("DPXL expression is missing an operand before ')' at position %d", tok.pos+1)
+			}
+		case dpxlBinaryOp, dpxlComma:
+			if prev == nil || prev.kind == dpxlBinaryOp || prev.kind == dpxlNotOp || prev.kind == dpxlOpenParen || prev.kind == dpxlComma {
+				return fmt.Errorf("DPXL expression is missing an operand before '%s' at position %d", tok.text, tok.pos+1)
+			}
+		case dpxlOperand, dpxlNotOp:
+			if prev != nil && (prev.kind == dpxlOperand || prev.kind == dpxlCloseParen) {
+				return fmt.Errorf("DPXL expression is missing an operator between '%s' and '%s' at position %d (use && or ||)",
+					prev.text, tok.text, tok.pos+1)
+			}
+		}
+	}
+	if len(open) > 0 {
+		return fmt.Errorf("DPXL expression has an unclosed '(' at position %d", open[len(open)-1]+1)
+	}
+	if last := tokens[len(tokens)-1]; last.kind == dpxlBinaryOp || last.kind == dpxlNotOp || last.kind == dpxlComma {
+		return fmt.Errorf("DPXL expression ends with '%s' and is missing an operand", last.text)
+	}
+	return nil
+}
+
+// tokenizeDPXL splits expr into tokens from offset, recording positions within expr
+func tokenizeDPXL(expr string, offset int) ([]dpxlToken, error) {
+	var tokens []dpxlToken
+	for i := offset; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, dpxlToken{kind: dpxlOpenParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, dpxlToken{kind: dpxlCloseParen, text: ")", pos: i})
+			i++
+		case c == ',':
+			tokens = append(tokens, dpxlToken{kind: dpxlComma, text: ",", pos: i})
+			i++
+		case c == '"' || c == '\'':
+			end := i + 1
+			for end < len(expr) && expr[end] != c {
+				if expr[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(expr) {
+				return nil, fmt.Errorf("DPXL expression has an unterminated string starting at position %d", i+1)
+			}
+			tokens = append(tokens, dpxlToken{kind: dpxlOperand, text: expr[i : end+1], pos: i})
+			i = end + 1
+		case strings.IndexByte("=!<>&|", c) >= 0:
+			end := i
+			for end < len(expr) && strings.IndexByte("=!<>&|", expr[end]) >= 0 {
+				end++
+			}
+			op := expr[i:end]
+			if !dpxlOperators[op] {
+				if hint, ok := dpxlOperatorHints[op]; ok {
+					return nil, fmt.Errorf("DPXL expression uses unknown operator '%s' at position %d; use '%s'", op, i+1, hint)
+				}
+				return nil, fmt.Errorf("DPXL expression uses unknown operator '%s' at position %d (supported: ==, !=, <, <=, >, >=, &&, ||, !)", op, i+1)
+			}
+			kind := dpxlBinaryOp
+			if op == "!" {
+				kind = dpxlNotOp
+			}
+			tokens = append(tokens, dpxlToken{kind: kind, text: op, pos: i})
+			i = end
+		case isDPXLWordByte(c):
+			end := i
+			for end < len(expr) && isDPXLWordByte(expr[end]) {
+				end++
+			}
+			tokens = append(tokens, dpxlToken{kind: dpxlOperand, text: expr[i:end], pos: i})
+			i = end
+		default:
+			return nil, fmt.Errorf("DPXL expression has an unexpected character '%c' at position %d", c, i+1)
+		}
+	}
+	return tokens, nil
+}
+
+// dpxlErrorResult reports a malformed DPXL expression with an example of the expected form
+func dpxlErrorResult(err error) *mcp.CallToolResult {
+	return NewToolResultErrorWithSuggestion(err.Error(),
+		`DPXL filters look like <v1>contains(kubernetes.labels.app, "frontend") && severity >= 5`)
+}
+
+// isDPXLWordByte reports whether c can be part of a field path, function name, or number
+func isDPXLWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '_' || c == '.' || c == '$' || c == '-'
+}
+
+// dpxlLabelFields are DPXL fields that DataPrime reads from labels ($l) rather than user data
+var dpxlLabelFields = map[string]bool{
+	"applicationname": true, "subsystemname": true, "computername": true, "ipaddress": true,
+	"threadid": true, "processid": true, "classname": true, "methodname": true, "category": true,
+}
+
+// dpxlMetadataFields are DPXL fields that DataPrime reads from metadata ($m)
+var dpxlMetadataFields = map[string]bool{"severity": true, "timestamp": true, "priorityclass": true}
+
+// dpxlSeverityNames maps DPXL's numeric severities (1-6) to DataPrime severity names
+var dpxlSeverityNames = map[string]string{
+	"1": "DEBUG", "2": "VERBOSE", "3": "INFO", "4": "WARNING", "5": "ERROR", "6": "CRITICAL",
+}
+
+// DPXLToDataPrime translates a validated DPXL stream filter into the equivalent DataPrime
+// filter expression, so it can be run as a query: label and metadata fields get their $l and
+// $m prefixes, other fields are read from $d, strings use single quotes, and numeric
+// severities become severity names.
+func DPXLToDataPrime(expr string) (string, error) {
+	if err := ValidateDPXLExpression(expr); err != nil {
+		return "", err
+	}
+	offset := len(expr) - len(strings.TrimLeft(expr, " \t\r\n"))
+	if strings.HasPrefix(expr[offset:], dpxlVersionPrefix) {
+		offset += len(dpxlVersionPrefix)
+	}
+	tokens, err := tokenizeDPXL(expr, offset)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	for i, tok := range tokens {
+		text := tok.text
+		if tok.kind == dpxlOperand {
+			isCall := i+1 < len(tokens) && tokens[i+1].kind == dpxlOpenParen
+			text = dpxlOperandToDataPrime(text, isCall)
+			if name, ok := dpxlSeverityNames[tok.text]; ok && i >= 2 && tokens[i-1].kind == dpxlBinaryOp &&
+				strings.EqualFold(tokens[i-2].text, "severity") {
+				text = name
+			}
+		}
+		if i > 0 && needsDPXLSpace(tokens[i-1], tok) {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(text)
+	}
+	return sb.String(), nil
+}
+
+// dpxlOperandToDataPrime rewrites one DPXL operand; function names are kept as they are
+func dpxlOperandToDataPrime(text string, isCall bool) string {
+	switch {
+	case text[0] == '"':
+		inner := strings.ReplaceAll(text[1:len(text)-1], `\"`, `"`)
+		return "'" + strings.ReplaceAll(inner, "'", `\'`) + "'"
+	case text[0] == '\'', isCall, strings.HasPrefix(text, "$"):
+		return text
+	case text[0] >= '0' && text[0] <= '9', text[0] == '-':
+		return text
+	}
+	switch lower := strings.ToLower(text); {
+	case lower == "true" || lower == "false" || lower == "null":
+		return lower
+	case dpxlLabelFields[lower]:
+		return "$l." + lower
+	case dpxlMetadataFields[lower]:
+		return "$m." + lower
+	}
+	return "$d." + text
+}
+
+// needsDPXLSpace reports whether a space separates two adjacent tokens in the translation
+func needsDPXLSpace(prev, tok dpxlToken) bool {
+	switch {
+	case tok.kind == dpxlCloseParen || tok.kind == dpxlComma:
+		return false
+	case prev.kind == dpxlOpenParen || prev.kind == dpxlNotOp:
+		return false
+	case tok.kind == dpxlOpenParen && prev.kind == dpxlOperand:
+		return false
+	}
+	return true
+}
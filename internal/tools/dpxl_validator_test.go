@@ -0,0 +1,76 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestValidateDPXLExpression(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr string
+	}{
+		{"contains", `<v1>contains(kubernetes.labels.app, "frontend")`, ""},
+		{"comparison", `<v1>severity >= 5`, ""},
+		{"combined", `<v1>(applicationname == "api" || applicationname == 'web') && !contains(text, "health")`, ""},
+		{"no prefix", `subsystemname != "probe"`, ""},
+		{"empty", `<v1>  `, "must not be empty"},
+		{"unclosed paren", `<v1>contains(app, "x"`, "unclosed '(' at position 13"},
+		{"unmatched paren", `<v1>severity >= 5)`, "unmatched ')' at position 18"},
+		{"single equals", `<v1>applicationname = "api"`, "unknown operator '=' at position 21; use '=='"},
+		{"sql operator", `<v1>severity <> 5`, "use '!='"},
+		{"unterminated string", `<v1>applicationname == "api`, "unterminated string"},
+		{"dangling operator", `<v1>severity >= 5 &&`, "ends with '&&'"},
+		{"missing operator", `<v1>severity >= 5 applicationname == "api"`, "missing an operator between '5' and 'applicationname'"},
+		{"leading operator", `<v1>&& severity >= 5`, "missing an operand before '&&'"},
+		{"unexpected character", `<v1>severity >= 5; drop`, "unexpected character ';'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDPXLExpression(tt.expr)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestStreamTools_RejectMalformedDPXL(t *testing.T) {
+	mock := client.NewMockClient()
+	logger := zap.NewNop()
+	bad := `<v1>applicationname = "api"`
+	events := map[string]interface{}{"brokers": "b:9093", "topic": "t"}
+
+	for _, tc := range []struct {
+		tool Tool
+		args map[string]interface{}
+	}{
+		{NewCreateStreamTool(mock, logger), map[string]interface{}{"name": "s", "dpxl_expression": bad}},
+		{NewUpdateStreamTool(mock, logger), map[string]interface{}{"stream_id": "1", "name": "s", "dpxl_expression": bad,
+			"compression_type": "gzip", "ibm_event_streams": events}},
+		{NewCreateEventStreamTargetTool(mock, logger), map[string]interface{}{"name": "s", "dpxl_expression": bad}},
+		{NewUpdateEventStreamTargetTool(mock, logger), map[string]interface{}{"stream_id": "1", "name": "s", "dpxl_expression": bad}},
+	} {
+		result, err := tc.tool.Execute(testCtx(mock), tc.args)
+		require.NoError(t, err)
+		assert.True(t, result.IsError, tc.tool.Name())
+		assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "unknown operator '='", tc.tool.Name())
+	}
+	assert.Zero(t, mock.RequestCount(), "malformed expressions are rejected before the API call")
+
+	result, err := NewCreateStreamTool(mock, logger).Execute(testCtx(mock),
+		map[string]interface{}{"name": "s", "dpxl_expression": bad, "dry_run": true})
+	require.NoError(t, err)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "unknown operator '='")
+	assert.Zero(t, mock.RequestCount())
+}
@@ -130,7 +130,7 @@ func (t *SearchToolsTool) Annotations() *mcp.ToolAnnotations {
 }
 
 // Execute searches tools and returns brief results
-func (t *SearchToolsTool) Execute(_ context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+func (t *SearchToolsTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	query, _ := GetStringParam(args, "query", false)
 	category, _ := GetStringParam(args, "category", false)
 	limit, _ := GetIntParam(args, "limit", false)
@@ -148,7 +148,7 @@ func (t *SearchToolsTool) Execute(_ context.Context, args map[string]interface{}
 
 	// Use existing discovery logic but return minimal info
 	registry := GetToolRegistry()
-	result := registry.DiscoverTools(query, ToolCategory(category), "")
+	result := registry.DiscoverToolsForSession(GetSessionFromContext(ctx), query, ToolCategory(category), "")
 
 	// Convert to brief format
 	briefs := make([]ToolBrief, 0, len(result.MatchedTools))
@@ -0,0 +1,357 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file contains the parse_and_explain_error tool for stack traces and error messages.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// maxKeyFrames is the number of stack frames kept in a parsed error
+const maxKeyFrames = 5
+
+// maxQuerySubstring caps the message substring used in suggested queries
+const maxQuerySubstring = 80
+
+// ParseErrorTool breaks a stack trace or error message into its parts and suggests a query
+type ParseErrorTool struct {
+	*BaseTool
+}
+
+// NewParseErrorTool creates a new ParseErrorTool
+func NewParseErrorTool(c client.Doer, l *zap.Logger) *ParseErrorTool {
+	return &ParseErrorTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *ParseErrorTool) Name() string { return "parse_and_explain_error" }
+
+// Annotations returns tool hints for LLMs
+func (t *ParseErrorTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Parse and Explain Error")
+}
+
+// Description returns the tool description
+func (t *ParseErrorTool) Description() string {
+	return `Parse a pasted stack trace or error message and explain it.
+
+Detects the language (Java, Python, Go, Node.js), extracts the exception type, message, and key
+stack frames, and returns a ready-to-run DataPrime query to find similar occurrences.
+Runs locally without calling the API.
+
+**Use Cases:**
+- Make sense of a stack trace copied from a log entry or ticket
+- Find how often, and where, the same error occurs
+
+**Related tools:** query_logs, investigate_incident, get_query_templates`
+}
+
+// InputSchema returns the input schema
+func (t *ParseErrorTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"error_text": map[string]interface{}{
+				"type":        "string",
+				"description": "Raw log message or stack trace to parse",
+			},
+		},
+		"required": []string{"error_text"},
+	}
+}
+
+// StackFrame is one frame of a parsed stack trace
+type StackFrame struct {
+	Function string `json:"function,omitempty"`
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+}
+
+// ParsedError is the structured breakdown of an error message or stack trace
+type ParsedError struct {
+	Language          string       `json:"language"` // java, python, go, node, or unknown
+	ExceptionType     string       `json:"exception_type,omitempty"`
+	Message           string       `json:"message,omitempty"`
+	CausedBy          []string     `json:"caused_by,omitempty"`
+	TopFrame          *StackFrame  `json:"top_frame,omitempty"`
+	KeyFrames         []StackFrame `json:"key_frames,omitempty"`
+	Explanation       string       `json:"explanation,omitempty"`
+	SuggestedQuery    string       `json:"suggested_query"`
+	DistributionQuery string       `json:"distribution_query"`
+}
+
+// Execute executes the tool
+func (t *ParseErrorTool) Execute(_ context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	text, err := GetStringParam(args, "error_text", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	if strings.TrimSpace(text) == "" {
+		return NewToolResultError("error_text must not be empty"), nil
+	}
+
+	result, err := json.MarshalIndent(ParseError(text), "", "  ")
+	if err != nil {
+		return NewToolResultError(fmt.Sprintf("Failed to format parsed error: %v", err)), nil
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(result),
+			},
+		},
+	}, nil
+}
+
+var (
+	javaFrameRe    = regexp.MustCompile(`^\s*at ([\w$.<>/]+)\(([^:)]*)(?::(\d+))?\)\s*$`)
+	javaHeaderRe   = regexp.MustCompile(`^(?:Exception in thread "[^"]*" )?([\w$]+(?:\.[\w$]+)+|[\w$]*(?:Exception|Error|Throwable))(?::\s*(.*))?$`)
+	javaCausedByRe = regexp.MustCompile(`^\s*Caused by: ([\w$.]+)(?::\s*(.*))?$`)
+
+	pythonFrameRe     = regexp.MustCompile(`^\s*File "([^"]+)", line (\d+), in (\S+)`)
+	pythonExceptionRe = regexp.MustCompile(`^([A-Za-z_][\w.]*)(?::\s*(.*))?$`)
+
+	goFuncRe = regexp.MustCompile(`^([\w./*()\-]+)\(.*\)$`)
+	goFileRe = regexp.MustCompile(`^\s+(\S+\.go):(\d+)`)
+
+	nodeFrameRe  = regexp.MustCompile(`^\s*at (?:(.+?) \()?(\S+?):(\d+):\d+\)?\s*$`)
+	nodeHeaderRe = regexp.MustCompile(`^(?:Uncaught )?([A-Z]\w*(?:Error|Exception))(?::\s*(.*))?$`)
+
+	logicalWordRe = regexp.MustCompile(`(?i)\s+(?:and|or)\s+`)
+)
+
+// ParseError identifies the language of an error message or stack trace and extracts the
+// exception type, message, and key frames
+func ParseError(text string) *ParsedError {
+	lines := strings.Split(strings.ReplaceAll(strings.TrimSpace(text), "\r\n", "\n"), "\n")
+
+	var parsed *ParsedError
+	switch {
+	case strings.Contains(text, "Traceback (most recent call last)") || matchesAny(lines, pythonFrameRe):
+		parsed = parsePythonTrace(lines)
+	case strings.HasPrefix(strings.TrimSpace(lines[0]), "panic:") || strings.Contains(text, "goroutine "):
+		parsed = parseGoTrace(lines)
+	case matchesAny(lines, nodeFrameRe) && !matchesAny(lines, javaFrameRe):
+		parsed = parseNodeTrace(lines)
+	case matchesAny(lines, javaFrameRe):
+		parsed = parseJavaTrace(lines)
+	default:
+		parsed = &ParsedError{Language: "unknown", Message: strings.TrimSpace(lines[0])}
+	}
+
+	if len(parsed.KeyFrames) > 0 && parsed.TopFrame == nil {
+		parsed.TopFrame = &parsed.KeyFrames[0]
+	}
+	parsed.Explanation = explainParsedError(parsed)
+	parsed.SuggestedQuery, parsed.DistributionQuery = errorQueries(parsed)
+	return parsed
+}
+
+// matchesAny reports whether any line matches re
+func matchesAny(lines []string, re *regexp.Regexp) bool {
+	for _, line := range lines {
+		if re.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseJavaTrace parses a Java stack trace; the first "at" frame is the top frame
+func parseJavaTrace(lines []string) *ParsedError {
+	parsed := &ParsedError{Language: "java"}
+	for _, line := range lines {
+		if m := javaCausedByRe.FindStringSubmatch(line); m != nil {
+			parsed.CausedBy = append(parsed.CausedBy, joinTypeAndMessage(m[1], m[2]))
+			continue
+		}
+		if m := javaFrameRe.FindStringSubmatch(line); m != nil {
+			if len(parsed.KeyFrames) < maxKeyFrames {
+				parsed.KeyFrames = append(parsed.KeyFrames, StackFrame{Function: m[1], File: m[2], Line: atoiOrZero(m[3])})
+			}
+			continue
+		}
+		if parsed.ExceptionType == "" {
+			if m := javaHeaderRe.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+				parsed.ExceptionType, parsed.Message = m[1], strings.TrimSpace(m[2])
+			}
+		}
+	}
+	return parsed
+}
+
+// parsePythonTrace parses a Python traceback. Frames are listed oldest first, so the last
+// frame is the top frame, and the exception is on the last line.
+func parsePythonTrace(lines []string) *ParsedError {
+	parsed := &ParsedError{Language: "python"}
+	var frames []StackFrame
+	for _, line := range lines {
+		if m := pythonFrameRe.FindStringSubmatch(line); m != nil {
+			frames = append(frames, StackFrame{Function: m[3], File: m[1], Line: atoiOrZero(m[2])})
+		}
+	}
+	for i := len(frames) - 1; i >= 0 && len(parsed.KeyFrames) < maxKeyFrames; i-- {
+		parsed.KeyFrames = append(parsed.KeyFrames, frames[i])
+	}
+
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := lines[i]
+		if line == "" || strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			continue
+		}
+		if m := pythonExceptionRe.FindStringSubmatch(line); m != nil && !strings.HasPrefix(line, "Traceback") {
+			parsed.ExceptionType, parsed.Message = m[1], strings.TrimSpace(m[2])
+		}
+		break
+	}
+	return parsed
+}
+
+// parseGoTrace parses a Go panic. Each frame is a function line followed by an indented
+// file:line; runtime frames are skipped so the top frame is the panicking code.
+func parseGoTrace(lines []string) *ParsedError {
+	parsed := &ParsedError{Language: "go", ExceptionType: "panic"}
+	if msg, ok := strings.CutPrefix(strings.TrimSpace(lines[0]), "panic:"); ok {
+		parsed.Message = strings.TrimSpace(msg)
+		if strings.HasPrefix(parsed.Message, "runtime error:") {
+			parsed.ExceptionType = "runtime error"
+		}
+	}
+
+	for i := 0; i < len(lines)-1 && len(parsed.KeyFrames) < maxKeyFrames; i++ {
+		fn := goFuncRe.FindStringSubmatch(strings.TrimSpace(lines[i]))
+		file := goFileRe.FindStringSubmatch(lines[i+1])
+		if fn == nil || file == nil {
+			continue
+		}
+		i++
+		if strings.HasPrefix(fn[1], "runtime.") || fn[1] == "panic" {
+			continue
+		}
+		parsed.KeyFrames = append(parsed.KeyFrames, StackFrame{Function: fn[1], File: file[1], Line: atoiOrZero(file[2])})
+	}
+	return parsed
+}
+
+// parseNodeTrace parses a Node.js error stack; the first "at" frame is the top frame
+func parseNodeTrace(lines []string) *ParsedError {
+	parsed := &ParsedError{Language: "node"}
+	for _, line := range lines {
+		if m := nodeFrameRe.FindStringSubmatch(line); m != nil {
+			if len(parsed.KeyFrames) < maxKeyFrames {
+				parsed.KeyFrames = append(parsed.KeyFrames, StackFrame{Function: m[1], File: m[2], Line: atoiOrZero(m[3])})
+			}
+			continue
+		}
+		if parsed.ExceptionType == "" {
+			if m := nodeHeaderRe.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+				parsed.ExceptionType, parsed.Message = m[1], strings.TrimSpace(m[2])
+			}
+		}
+	}
+	return parsed
+}
+
+// commonErrorHints maps substrings of well-known errors to a short explanation
+var commonErrorHints = []struct {
+	Pattern string
+	Hint    string
+}{
+	{"NullPointerException", "A null reference was dereferenced. Check which value in the top frame can be null."},
+	{"nil pointer dereference", "A nil pointer was dereferenced. Check which pointer or interface in the top frame can be nil."},
+	{"index out of range", "A slice or array was indexed past its length. Check the bounds in the top frame."},
+	{"OutOfMemoryError", "The JVM ran out of heap. Look for memory leaks or raise the heap size."},
+	{"KeyError", "A dictionary key was missing. Check the input that produced the key."},
+	{"AttributeError", "An attribute was accessed on an object that does not have it, often None."},
+	{"Cannot read propert", "A property was read from undefined or null. Check the object in the top frame."},
+	{"ECONNREFUSED", "A connection was refused. The downstream service may be down or unreachable."},
+	{"timeout", "An operation timed out. Check downstream latency and timeout settings."},
+}
+
+// explainParsedError returns a short explanation of the error
+func explainParsedError(p *ParsedError) string {
+	subject := strings.TrimSpace(p.ExceptionType + " " + p.Message)
+	var sb strings.Builder
+	if p.ExceptionType != "" {
+		fmt.Fprintf(&sb, "%s %s", languageName(p.Language), p.ExceptionType)
+	} else {
+		sb.WriteString("Error")
+	}
+	if p.TopFrame != nil {
+		fmt.Fprintf(&sb, " raised in %s", p.TopFrame.Function)
+		if p.TopFrame.File != "" {
+			fmt.Fprintf(&sb, " (%s:%d)", p.TopFrame.File, p.TopFrame.Line)
+		}
+	}
+	sb.WriteString(".")
+	for _, h := range commonErrorHints {
+		if strings.Contains(strings.ToLower(subject), strings.ToLower(h.Pattern)) {
+			sb.WriteString(" " + h.Hint)
+			break
+		}
+	}
+	if len(p.CausedBy) > 0 {
+		fmt.Fprintf(&sb, " Root cause: %s.", p.CausedBy[len(p.CausedBy)-1])
+	}
+	return sb.String()
+}
+
+// errorQueries returns a query listing similar occurrences and a query counting them by
+// application and subsystem. They match on the exception type, or on the message when the
+// type is too generic to be selective.
+func errorQueries(p *ParsedError) (string, string) {
+	needle := p.ExceptionType
+	if needle == "" || needle == "panic" || needle == "runtime error" {
+		needle = p.Message
+	}
+	// The query validator rejects standalone AND/OR anywhere in a query, including inside
+	// string literals, so stop the substring before the first one
+	if loc := logicalWordRe.FindStringIndex(needle); loc != nil {
+		needle = needle[:loc[0]]
+	}
+	if len(needle) > maxQuerySubstring {
+		needle = needle[:maxQuerySubstring]
+	}
+	filter := fmt.Sprintf("source logs | filter $d.message:string.contains('%s')", escapeDataPrimeString(needle))
+	return filter + " | sortby -$m.timestamp | limit 100",
+		filter + " | groupby $l.applicationname, $l.subsystemname | aggregate count() as occurrences | sortby -occurrences | limit 20"
+}
+
+// languageName returns the display name for a detected language
+func languageName(language string) string {
+	switch language {
+	case "java":
+		return "Java"
+	case "python":
+		return "Python"
+	case "go":
+		return "Go"
+	case "node":
+		return "Node.js"
+	}
+	return ""
+}
+
+// joinTypeAndMessage formats an exception type with its optional message
+func joinTypeAndMessage(exceptionType, message string) string {
+	if message = strings.TrimSpace(message); message != "" {
+		return exceptionType + ": " + message
+	}
+	return exceptionType
+}
+
+// atoiOrZero parses s as an integer, returning 0 when it is empty or invalid
+func atoiOrZero(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
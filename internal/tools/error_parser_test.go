@@ -0,0 +1,162 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		language      string
+		exceptionType string
+		message       string
+		topFunction   string
+		topLine       int
+		queryContains string
+	}{
+		{
+			name: "java",
+			text: `Exception in thread "main" java.lang.NullPointerException: Cannot invoke "String.length()" because "name" is null
+	at com.example.orders.OrderService.validate(OrderService.java:42)
+	at com.example.orders.OrderController.create(OrderController.java:17)
+Caused by: java.lang.IllegalStateException: cache not loaded
+	at com.example.cache.Loader.get(Loader.java:88)
+	... 3 more`,
+			language:      "java",
+			exceptionType: "java.lang.NullPointerException",
+			message:       `Cannot invoke "String.length()" because "name" is null`,
+			topFunction:   "com.example.orders.OrderService.validate",
+			topLine:       42,
+			queryContains: "contains('java.lang.NullPointerException')",
+		},
+		{
+			name: "python",
+			text: `Traceback (most recent call last):
+  File "/app/main.py", line 10, in <module>
+    run()
+  File "/app/worker.py", line 55, in process
+    total = item["price"]
+KeyError: 'price'`,
+			language:      "python",
+			exceptionType: "KeyError",
+			message:       "'price'",
+			topFunction:   "process",
+			topLine:       55,
+			queryContains: "contains('KeyError')",
+		},
+		{
+			name: "go",
+			text: `panic: runtime error: invalid memory address or nil pointer dereference
+[signal SIGSEGV: segmentation violation code=0x1 addr=0x0 pc=0x4a1b2c]
+
+goroutine 1 [running]:
+runtime.panicmem()
+	/usr/local/go/src/runtime/panic.go:261 +0x6c
+main.(*Server).handle(0x0, {0x0, 0x0})
+	/src/server.go:87 +0x1c
+main.main()
+	/src/main.go:12 +0x25`,
+			language:      "go",
+			exceptionType: "runtime error",
+			message:       "runtime error: invalid memory address or nil pointer dereference",
+			topFunction:   "main.(*Server).handle",
+			topLine:       87,
+			queryContains: "contains('runtime error: invalid memory address')",
+		},
+		{
+			name: "node",
+			text: `TypeError: Cannot read properties of undefined (reading 'id')
+    at getUser (/app/src/users.js:23:18)
+    at /app/src/routes.js:9:5
+    at processTicksAndRejections (node:internal/process/task_queues:95:5)`,
+			language:      "node",
+			exceptionType: "TypeError",
+			message:       "Cannot read properties of undefined (reading 'id')",
+			topFunction:   "getUser",
+			topLine:       23,
+			queryContains: "contains('TypeError')",
+		},
+		{
+			name:          "plain message",
+			text:          "connection to db-01 failed: ECONNREFUSED",
+			language:      "unknown",
+			message:       "connection to db-01 failed: ECONNREFUSED",
+			queryContains: "contains('connection to db-01 failed: ECONNREFUSED')",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := ParseError(tt.text)
+			if p.Language != tt.language {
+				t.Errorf("Language = %q, want %q", p.Language, tt.language)
+			}
+			if p.ExceptionType != tt.exceptionType {
+				t.Errorf("ExceptionType = %q, want %q", p.ExceptionType, tt.exceptionType)
+			}
+			if p.Message != tt.message {
+				t.Errorf("Message = %q, want %q", p.Message, tt.message)
+			}
+			if tt.topFunction != "" {
+				if p.TopFrame == nil {
+					t.Fatal("expected a top frame")
+				}
+				if p.TopFrame.Function != tt.topFunction || p.TopFrame.Line != tt.topLine {
+					t.Errorf("TopFrame = %+v, want %s line %d", *p.TopFrame, tt.topFunction, tt.topLine)
+				}
+			}
+			if !strings.Contains(p.SuggestedQuery, tt.queryContains) {
+				t.Errorf("SuggestedQuery = %q, want it to contain %q", p.SuggestedQuery, tt.queryContains)
+			}
+			for _, q := range []string{p.SuggestedQuery, p.DistributionQuery} {
+				if _, _, err := PrepareQuery(q, "frequent_search", "dataprime"); err != nil {
+					t.Errorf("generated query is invalid: %v\n%s", err, q)
+				}
+			}
+		})
+	}
+}
+
+func TestParseError_JavaCausedByAndHint(t *testing.T) {
+	p := ParseError(`java.lang.NullPointerException
+	at com.example.A.run(A.java:5)
+Caused by: java.io.IOException: disk full`)
+	if len(p.CausedBy) != 1 || p.CausedBy[0] != "java.io.IOException: disk full" {
+		t.Errorf("CausedBy = %v", p.CausedBy)
+	}
+	if !strings.Contains(p.Explanation, "null reference") || !strings.Contains(p.Explanation, "Root cause: java.io.IOException") {
+		t.Errorf("unexpected explanation: %s", p.Explanation)
+	}
+}
+
+func TestParseErrorTool_Execute(t *testing.T) {
+	tool := NewParseErrorTool(client.NewMockClient(), zap.NewNop())
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{
+		"error_text": "KeyError: 'o''brien'",
+	})
+	if err != nil || result.IsError {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var parsed ParsedError
+	if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &parsed); err != nil {
+		t.Fatalf("response is not JSON: %v", err)
+	}
+	if parsed.SuggestedQuery == "" || parsed.DistributionQuery == "" {
+		t.Errorf("expected suggested queries, got %+v", parsed)
+	}
+
+	result, _ = tool.Execute(context.Background(), map[string]interface{}{"error_text": "  "})
+	if !result.IsError {
+		t.Error("expected error for empty error_text")
+	}
+}
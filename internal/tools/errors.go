@@ -1,19 +1,24 @@
 package tools
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	mcperrors "github.com/tareqmamari/cloud-logs-mcp/internal/errors"
 )
 
 // APIError represents a structured API error with status code
 type APIError struct {
 	StatusCode int
+	Code       mcperrors.ErrorCode // Tool error code derived from the status (see mcperrors.CodeForHTTPStatus)
 	Message    string
 	RequestID  string // Request ID for support/debugging
 	Details    map[string]interface{}
+	Err        error // Underlying transport error, if any
 }
 
 func (e *APIError) Error() string {
@@ -23,6 +28,19 @@ func (e *APIError) Error() string {
 	return e.Message
 }
 
+// Unwrap returns the underlying transport error
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorCode returns the tool error code, deriving it from the status code when unset
+func (e *APIError) ErrorCode() mcperrors.ErrorCode {
+	if e.Code != "" {
+		return e.Code
+	}
+	return mcperrors.CodeForHTTPStatus(e.StatusCode)
+}
+
 // IsNotFound returns true if this is a 404 error
 func (e *APIError) IsNotFound() bool {
 	return e.StatusCode == 404
@@ -33,47 +51,88 @@ func (e *APIError) IsTimeout() bool {
 	return e.StatusCode == 408 || e.StatusCode == 504
 }
 
-// NewToolResultError creates a new tool result with an error message
-func NewToolResultError(message string) *mcp.CallToolResult {
+// newToolError builds an error result tagged with a machine-readable code. The code is
+// included in the text as a [CODE] prefix and in the result metadata as error_code.
+func newToolError(code mcperrors.ErrorCode, message, suggestion string) *mcp.CallToolResult {
 	// Ensure message is never empty
 	if message == "" {
 		message = "An unknown error occurred"
 	}
+	text := fmt.Sprintf("[%s] %s", code, message)
+	if suggestion != "" {
+		text += fmt.Sprintf("\n\n💡 **Suggestion:** %s", suggestion)
+	}
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{
-				Text: message,
+				Text: text,
 			},
 		},
 		IsError: true,
+		Meta: mcp.Meta{
+			"error_code": string(code),
+			"retryable":  mcperrors.IsRetryable(code),
+		},
 	}
 }
 
+// NewToolResultError creates a new tool result with an error message.
+// Use it for bad input; errors returned by the API should go through NewToolResultErrorFromErr.
+func NewToolResultError(message string) *mcp.CallToolResult {
+	return newToolError(mcperrors.CodeInvalidInput, message, "")
+}
+
+// NewToolResultErrorWithCode creates a tool result with an error message and an explicit code
+func NewToolResultErrorWithCode(code mcperrors.ErrorCode, message string) *mcp.CallToolResult {
+	return newToolError(code, message, "")
+}
+
+// NewToolResultErrorFromErr creates a tool result from an error, classifying it into an error code
+func NewToolResultErrorFromErr(err error) *mcp.CallToolResult {
+	return newToolError(ClassifyError(err), err.Error(), "")
+}
+
 // NewToolResultErrorWithSuggestion creates a tool result with an error and recovery guidance
 func NewToolResultErrorWithSuggestion(message, suggestion string) *mcp.CallToolResult {
-	fullMessage := fmt.Sprintf("%s\n\n💡 **Suggestion:** %s", message, suggestion)
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fullMessage,
-			},
-		},
-		IsError: true,
+	return newToolError(mcperrors.CodeInvalidInput, message, suggestion)
+}
+
+// withErrorCode marks a report-style result as an error and attaches the error code metadata,
+// for results that summarize several outcomes and so don't use the [CODE] text prefix
+func withErrorCode(result *mcp.CallToolResult, code mcperrors.ErrorCode) *mcp.CallToolResult {
+	result.IsError = true
+	result.Meta = mcp.Meta{
+		"error_code": string(code),
+		"retryable":  mcperrors.IsRetryable(code),
+	}
+	return result
+}
+
+// ClassifyError maps an error to the tool error taxonomy. API errors use their HTTP status,
+// deadline errors are timeouts, and anything else is treated as invalid input.
+func ClassifyError(err error) mcperrors.ErrorCode {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorCode()
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return mcperrors.CodeTimeout
 	}
+	return mcperrors.CodeInvalidInput
 }
 
 // NewResourceNotFoundError creates an error for missing resources with list suggestion
 func NewResourceNotFoundError(resourceType, id, listToolName string) *mcp.CallToolResult {
 	message := fmt.Sprintf("%s not found with ID: %s", resourceType, id)
 	suggestion := fmt.Sprintf("Use '%s' to see available %ss and their IDs.", listToolName, strings.ToLower(resourceType))
-	return NewToolResultErrorWithSuggestion(message, suggestion)
+	return newToolError(mcperrors.CodeNotFound, message, suggestion)
 }
 
 // NewTimeoutErrorWithFallback creates a timeout error with alternative tool suggestion
 func NewTimeoutErrorWithFallback(operation, fallbackTool, fallbackReason string) *mcp.CallToolResult {
 	message := fmt.Sprintf("Operation '%s' timed out", operation)
 	suggestion := fmt.Sprintf("For large operations, use '%s' which %s.", fallbackTool, fallbackReason)
-	return NewToolResultErrorWithSuggestion(message, suggestion)
+	return newToolError(mcperrors.CodeTimeout, message, suggestion)
 }
 
 // HandleGetError handles errors from get_* tools with appropriate suggestions
@@ -84,13 +143,13 @@ func HandleGetError(err error, resourceType, resourceID, listToolName string) *m
 			return NewResourceNotFoundError(resourceType, resourceID, listToolName)
 		}
 		if apiErr.IsTimeout() {
-			return NewToolResultErrorWithSuggestion(
+			return newToolError(mcperrors.CodeTimeout,
 				apiErr.Message,
 				"Try again in a few moments, or check your network connection.",
 			)
 		}
 	}
-	return NewToolResultError(err.Error())
+	return NewToolResultErrorFromErr(err)
 }
 
 // HandleQueryError handles errors from query tools with appropriate suggestions
@@ -114,5 +173,5 @@ func HandleQueryError(err error, queryType string) *mcp.CallToolResult {
 			"processes queries asynchronously and can handle larger time ranges",
 		)
 	}
-	return NewToolResultError(err.Error())
+	return NewToolResultErrorFromErr(err)
 }
@@ -0,0 +1,78 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+	mcperrors "github.com/tareqmamari/cloud-logs-mcp/internal/errors"
+)
+
+func TestExecuteRequest_ErrorCodes(t *testing.T) {
+	tests := []struct {
+		status int
+		want   mcperrors.ErrorCode
+	}{
+		{400, mcperrors.CodeInvalidInput},
+		{401, mcperrors.CodeUnauthorized},
+		{404, mcperrors.CodeNotFound},
+		{429, mcperrors.CodeRateLimited},
+		{500, mcperrors.CodeUpstreamError},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("HTTP %d", tt.status), func(t *testing.T) {
+			mock := client.NewMockClient()
+			mock.RespondWith(tt.status, map[string]interface{}{"message": "nope"})
+			bt := NewBaseTool(mock, zap.NewNop())
+
+			_, err := bt.ExecuteRequest(testCtx(mock), &client.Request{Method: "GET", Path: "/v1/alerts"})
+			require.Error(t, err)
+			assert.Equal(t, tt.want, ClassifyError(err))
+
+			result := NewToolResultErrorFromErr(err)
+			assert.True(t, result.IsError)
+			assert.Equal(t, string(tt.want), result.Meta["error_code"])
+			assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "["+string(tt.want)+"]")
+		})
+	}
+}
+
+func TestExecuteRequest_TransportErrorIsUpstream(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.DoFunc = func(_ context.Context, _ *client.Request) (*client.Response, error) {
+		return nil, errors.New("connection refused")
+	}
+	bt := NewBaseTool(mock, zap.NewNop())
+
+	_, err := bt.ExecuteRequest(testCtx(mock), &client.Request{Method: "GET", Path: "/v1/alerts"})
+	require.Error(t, err)
+	assert.Equal(t, mcperrors.CodeUpstreamError, ClassifyError(err))
+	assert.Contains(t, err.Error(), "connection refused")
+}
+
+func TestHandleGetError_NotFound(t *testing.T) {
+	result := HandleGetError(&APIError{StatusCode: 404, Message: "missing"}, "Alert", "a1", "list_alerts")
+	assert.True(t, result.IsError)
+	assert.Equal(t, "NOT_FOUND", result.Meta["error_code"])
+	assert.Equal(t, false, result.Meta["retryable"])
+	text := result.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, "[NOT_FOUND] Alert not found with ID: a1")
+	assert.Contains(t, text, "list_alerts")
+}
+
+func TestNewToolResultError_DefaultsToInvalidInput(t *testing.T) {
+	result := NewToolResultError("id is required")
+	assert.Equal(t, "INVALID_INPUT", result.Meta["error_code"])
+	assert.Equal(t, "[INVALID_INPUT] id is required", result.Content[0].(*mcp.TextContent).Text)
+
+	assert.Equal(t, mcperrors.CodeTimeout, ClassifyError(fmt.Errorf("wrapped: %w", context.DeadlineExceeded)))
+	assert.Equal(t, "RATE_LIMITED", NewToolResultErrorFromErr(&APIError{StatusCode: 429, Message: "slow down"}).Meta["error_code"])
+	assert.Equal(t, true, NewToolResultErrorFromErr(&APIError{StatusCode: 429, Message: "slow down"}).Meta["retryable"])
+}
@@ -48,7 +48,7 @@ func (t *GetEventStreamTargetsTool) Execute(ctx context.Context, _ map[string]in
 
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	return t.FormatResponseWithSuggestions(result, "get_event_stream_targets")
@@ -114,6 +114,18 @@ func (t *CreateEventStreamTargetTool) InputSchema() interface{} {
 			},
 		},
 		"required": []string{"name", "dpxl_expression"},
+		"examples": []interface{}{
+			map[string]interface{}{
+				"name":             "frontend-errors",
+				"dpxl_expression":  "<v1>contains(kubernetes.labels.app, \"frontend\")",
+				"is_active":        true,
+				"compression_type": "gzip",
+				"ibm_event_streams": map[string]interface{}{
+					"brokers": "broker-0.kafka.example.com:9093,broker-1.kafka.example.com:9093",
+					"topic":   "cloud-logs-errors",
+				},
+			},
+		},
 	}
 }
 
@@ -121,12 +133,15 @@ func (t *CreateEventStreamTargetTool) InputSchema() interface{} {
 func (t *CreateEventStreamTargetTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	name, err := GetStringParam(arguments, "name", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	dpxlExpression, err := GetStringParam(arguments, "dpxl_expression", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
+	}
+	if err := ValidateDPXLExpression(dpxlExpression); err != nil {
+		return dpxlErrorResult(err), nil
 	}
 
 	body := map[string]interface{}{
@@ -155,7 +170,7 @@ func (t *CreateEventStreamTargetTool) Execute(ctx context.Context, arguments map
 
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	return t.FormatResponseWithSuggestions(result, "create_event_stream_target")
@@ -215,6 +230,14 @@ func (t *UpdateEventStreamTargetTool) InputSchema() interface{} {
 			},
 		},
 		"required": []string{"stream_id", "name", "dpxl_expression"},
+		"examples": []interface{}{
+			map[string]interface{}{
+				"stream_id":       "stream-id",
+				"name":            "frontend-errors",
+				"dpxl_expression": "<v1>contains(kubernetes.labels.app, \"frontend\")",
+				"is_active":       false,
+			},
+		},
 	}
 }
 
@@ -222,17 +245,20 @@ func (t *UpdateEventStreamTargetTool) InputSchema() interface{} {
 func (t *UpdateEventStreamTargetTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	streamID, err := GetStringParam(arguments, "stream_id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	name, err := GetStringParam(arguments, "name", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	dpxlExpression, err := GetStringParam(arguments, "dpxl_expression", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
+	}
+	if err := ValidateDPXLExpression(dpxlExpression); err != nil {
+		return dpxlErrorResult(err), nil
 	}
 
 	body := map[string]interface{}{
@@ -261,7 +287,7 @@ func (t *UpdateEventStreamTargetTool) Execute(ctx context.Context, arguments map
 
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	return t.FormatResponseWithSuggestions(result, "update_event_stream_target")
@@ -312,7 +338,7 @@ func (t *DeleteEventStreamTargetTool) InputSchema() interface{} {
 func (t *DeleteEventStreamTargetTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	streamID, err := GetStringParam(arguments, "stream_id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	req := &client.Request{
@@ -322,7 +348,7 @@ func (t *DeleteEventStreamTargetTool) Execute(ctx context.Context, arguments map
 
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	return t.FormatResponseWithSuggestions(result, "delete_event_stream_target")
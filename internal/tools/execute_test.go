@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"go.uber.org/zap"
 
 	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
@@ -326,6 +328,32 @@ func TestQueryTool_Execute_Success(t *testing.T) {
 	}
 }
 
+func TestQueryTool_Execute_AutoCorrectDisabled(t *testing.T) {
+	mock := client.NewMockClient()
+	tool := NewQueryTool(mock, zap.NewNop())
+	ctx := testCtx(mock)
+
+	result, err := tool.Execute(ctx, map[string]interface{}{
+		"query":                "source logs | filter $d.message.contains('error')",
+		"start_date":           "2024-01-01T00:00:00Z",
+		"end_date":             "2024-01-02T00:00:00Z",
+		"auto_correct_queries": false,
+	})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("Expected validation error when auto-correction is disabled")
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(text, "$d.message:string.contains('error')") {
+		t.Errorf("Error should include the corrected query, got: %s", text)
+	}
+	if mock.RequestCount() != 0 {
+		t.Error("No API call should be made when correction is rejected")
+	}
+}
+
 func TestQueryTool_Execute_MissingRequiredParams(t *testing.T) {
 	mock := client.NewMockClient()
 	tool := NewQueryTool(mock, zap.NewNop())
@@ -555,3 +583,151 @@ func TestGetOutgoingWebhookTool_Execute_Success(t *testing.T) {
 		t.Errorf("Path = %q, want /v1/outgoing_webhooks/wh-123", req.Path)
 	}
 }
+
+// --- HealthCheckTool deep mode tests ---
+
+func TestHealthCheckTool_Execute_Deep(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.DoFunc = func(_ context.Context, req *client.Request) (*client.Response, error) {
+		body := req.Body.(map[string]interface{})
+		query := body["query"].(string)
+
+		grouped := strings.Contains(query, "groupby")
+		var events []interface{}
+		switch {
+		case strings.Contains(query, "CRITICAL"):
+			events = []interface{}{map[string]interface{}{"count": 2}}
+		case strings.Contains(query, "ERROR") && grouped:
+			// batch is not among the busiest applications, so it has no reliable error rate
+			events = []interface{}{
+				map[string]interface{}{"applicationname": "api", "count": 20},
+				map[string]interface{}{"applicationname": "batch", "count": 5},
+			}
+		case strings.Contains(query, "ERROR"):
+			events = []interface{}{map[string]interface{}{"count": 25}}
+		case grouped:
+			events = []interface{}{
+				map[string]interface{}{"applicationname": "web", "count": 900},
+				map[string]interface{}{"applicationname": "api", "count": 100},
+			}
+		default:
+			events = []interface{}{map[string]interface{}{"count": 1005}}
+		}
+		data, _ := json.Marshal(map[string]interface{}{"events": events})
+		return &client.Response{StatusCode: 200, Body: data}, nil
+	}
+
+	tool := NewHealthCheckTool(mock, zap.NewNop())
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{"deep": true})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Expected success result")
+	}
+
+	// Five bounded queries for each of the current and previous periods
+	if mock.RequestCount() != 10 {
+		t.Errorf("RequestCount = %d, want 10", mock.RequestCount())
+	}
+	for _, req := range mock.Requests {
+		query := req.Body.(map[string]interface{})["query"].(string)
+		if strings.Contains(query, "groupby") && !strings.Contains(query, "| sortby -count |") {
+			t.Errorf("per-application query %q is not sorted by count", query)
+		}
+	}
+
+	var report map[string]interface{}
+	if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &report); err != nil {
+		t.Fatalf("Deep report is not JSON: %v", err)
+	}
+	// api has a 20% error rate, above the default critical threshold
+	if report["overall_status"] != "critical" {
+		t.Errorf("overall_status = %v, want critical", report["overall_status"])
+	}
+	checks, _ := report["checks"].([]interface{})
+	names := map[string]bool{}
+	for _, c := range checks {
+		names[c.(map[string]interface{})["name"].(string)] = true
+	}
+	if !names["application:api"] {
+		t.Errorf("Expected per-application check for api, got %v", checks)
+	}
+	if names["application:batch"] {
+		t.Errorf("batch is missing from the busiest applications and should not be reported, got %v", checks)
+	}
+}
+
+func TestHealthCheckTool_Execute_DeepPartialFailure(t *testing.T) {
+	now := time.Now().UTC()
+	mock := client.NewMockClient()
+	mock.DoFunc = func(_ context.Context, req *client.Request) (*client.Response, error) {
+		body := req.Body.(map[string]interface{})
+		// Fail every query for the previous window, which ends before the last 30 minutes
+		if end, _ := time.Parse(time.RFC3339, body["end_date"].(string)); end.Before(now.Add(-30 * time.Minute)) {
+			return &client.Response{StatusCode: 503, Body: []byte(`{"message":"unavailable"}`)}, nil
+		}
+		data, _ := json.Marshal(map[string]interface{}{"events": []interface{}{
+			map[string]interface{}{"applicationname": "api", "count": 100},
+		}})
+		return &client.Response{StatusCode: 200, Body: data}, nil
+	}
+
+	tool := NewHealthCheckTool(mock, zap.NewNop())
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{"deep": true})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("previous-window failures should not fail the check: %s", result.Content[0].(*mcp.TextContent).Text)
+	}
+
+	var report map[string]interface{}
+	if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &report); err != nil {
+		t.Fatalf("Deep report is not JSON: %v", err)
+	}
+	failures, _ := report["partial_failures"].([]interface{})
+	if len(failures) != 5 {
+		t.Errorf("partial_failures = %v, want the 5 previous-window queries", report["partial_failures"])
+	}
+}
+
+func TestBuildDeepHealthReport(t *testing.T) {
+	th := HealthThresholds{WarningErrorRate: 5, CriticalErrorRate: 10, ErrorRateIncrease: 100}
+
+	t.Run("healthy", func(t *testing.T) {
+		cur := &healthWindowCounts{Total: 1000, Errors: 10, AppTotal: map[string]float64{"api": 1000}, AppErrors: map[string]float64{"api": 10}}
+		prev := &healthWindowCounts{Total: 1000, Errors: 10, AppTotal: map[string]float64{"api": 1000}, AppErrors: map[string]float64{"api": 10}}
+		report := BuildDeepHealthReport(cur, prev, th)
+		if report["overall_status"] != "healthy" {
+			t.Errorf("overall_status = %v, want healthy", report["overall_status"])
+		}
+	})
+
+	t.Run("error rate doubled", func(t *testing.T) {
+		cur := &healthWindowCounts{Total: 1000, Errors: 30, AppTotal: map[string]float64{"api": 1000}, AppErrors: map[string]float64{"api": 30}}
+		prev := &healthWindowCounts{Total: 1000, Errors: 10, AppTotal: map[string]float64{"api": 1000}, AppErrors: map[string]float64{"api": 10}}
+		report := BuildDeepHealthReport(cur, prev, th)
+		if report["overall_status"] != "warning" {
+			t.Errorf("overall_status = %v, want warning", report["overall_status"])
+		}
+	})
+
+	t.Run("overall counts cover applications beyond the per-application limit", func(t *testing.T) {
+		// The per-application results only list api, but the instance-wide rate is still low
+		cur := &healthWindowCounts{Total: 100000, Errors: 100, AppTotal: map[string]float64{"api": 100}, AppErrors: map[string]float64{"api": 1}}
+		report := BuildDeepHealthReport(cur, &healthWindowCounts{}, th)
+		if report["overall_status"] != "healthy" {
+			t.Errorf("overall_status = %v, want healthy", report["overall_status"])
+		}
+	})
+
+	t.Run("ingestion stopped", func(t *testing.T) {
+		cur := &healthWindowCounts{}
+		prev := &healthWindowCounts{Total: 1000}
+		report := BuildDeepHealthReport(cur, prev, th)
+		if report["overall_status"] != "critical" {
+			t.Errorf("overall_status = %v, want critical", report["overall_status"])
+		}
+	})
+}
@@ -0,0 +1,257 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements explicit helpful/not-helpful feedback on tools and chains.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+const (
+	// FeedbackWeight is how many observed uses one piece of explicit feedback counts as
+	// when it is folded into a learned chain's success rate
+	FeedbackWeight = 3
+
+	// MaxFeedbackEntries caps how many feedback entries a session keeps
+	MaxFeedbackEntries = 50
+
+	// feedbackRelevanceBoost is the most explicit feedback can move a tool's discovery relevance
+	feedbackRelevanceBoost = 0.1
+)
+
+// FeedbackEntry is one record_feedback call
+type FeedbackEntry struct {
+	Tools     []string  `json:"tools"`
+	Helpful   bool      `json:"helpful"`
+	Comment   string    `json:"comment,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// FeedbackTally counts explicit feedback for a single tool
+type FeedbackTally struct {
+	Helpful    int `json:"helpful"`
+	NotHelpful int `json:"not_helpful"`
+}
+
+// Score returns the net feedback in (-1, 1), damped so a single vote has a modest effect
+func (f FeedbackTally) Score() float64 {
+	return float64(f.Helpful-f.NotHelpful) / float64(f.Helpful+f.NotHelpful+2)
+}
+
+// FeedbackOutcome describes what a piece of feedback updated
+type FeedbackOutcome struct {
+	// ChainLearned is true when the feedback matched a learned chain
+	ChainLearned bool
+	// SuccessRate is the chain's success rate after the feedback (learned chains only)
+	SuccessRate float64
+	// Tallies are the per-tool feedback counts after the feedback
+	Tallies map[string]FeedbackTally
+}
+
+// RecordFeedback folds explicit feedback into the learned patterns. For a learned chain
+// (two or more tools matching a learned sequence) the feedback counts as FeedbackWeight
+// observations in the chain's success rate. Otherwise it is tallied for each tool.
+func (s *SessionContext) RecordFeedback(tools []string, helpful bool, comment string) FeedbackOutcome {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.LearnedPatterns == nil {
+		s.LearnedPatterns = &LearnedPatterns{CommonFilters: make(map[string]string)}
+	}
+	patterns := s.LearnedPatterns
+	now := time.Now()
+
+	patterns.Feedback = append(patterns.Feedback, FeedbackEntry{
+		Tools:     append([]string(nil), tools...),
+		Helpful:   helpful,
+		Comment:   comment,
+		Timestamp: now,
+	})
+	if len(patterns.Feedback) > MaxFeedbackEntries {
+		patterns.Feedback = patterns.Feedback[len(patterns.Feedback)-MaxFeedbackEntries:]
+	}
+
+	target := 0.0
+	if helpful {
+		target = 100.0
+	}
+
+	outcome := FeedbackOutcome{Tallies: make(map[string]FeedbackTally)}
+	if len(tools) >= 2 {
+		for i := range patterns.FrequentSequences {
+			seq := &patterns.FrequentSequences[i]
+			if !sameToolSequence(seq.Tools, tools) {
+				continue
+			}
+			n := float64(seq.Count)
+			seq.SuccessRate = (seq.SuccessRate*n + target*FeedbackWeight) / (n + FeedbackWeight)
+			outcome.ChainLearned = true
+			outcome.SuccessRate = seq.SuccessRate
+			break
+		}
+	}
+
+	if !outcome.ChainLearned {
+		if patterns.ToolFeedback == nil {
+			patterns.ToolFeedback = make(map[string]FeedbackTally)
+		}
+		for _, tool := range tools {
+			tally := patterns.ToolFeedback[tool]
+			if helpful {
+				tally.Helpful++
+			} else {
+				tally.NotHelpful++
+			}
+			patterns.ToolFeedback[tool] = tally
+			outcome.Tallies[tool] = tally
+		}
+	}
+
+	patterns.LastUpdated = now
+	s.UpdatedAt = now
+	s.notifyChange()
+	return outcome
+}
+
+// ToolFeedbackScore returns the net explicit feedback for a tool in (-1, 1), 0 without feedback
+func (s *SessionContext) ToolFeedbackScore(tool string) float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.LearnedPatterns == nil {
+		return 0
+	}
+	return s.LearnedPatterns.ToolFeedback[tool].Score()
+}
+
+// sameToolSequence reports whether two tool sequences are identical
+func sameToolSequence(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// RecordFeedbackTool records whether a suggested tool or chain was helpful
+type RecordFeedbackTool struct{ *BaseTool }
+
+// NewRecordFeedbackTool creates a new tool instance
+func NewRecordFeedbackTool(c client.Doer, l *zap.Logger) *RecordFeedbackTool {
+	return &RecordFeedbackTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *RecordFeedbackTool) Name() string { return "record_feedback" }
+
+// Annotations returns tool hints for LLMs
+func (t *RecordFeedbackTool) Annotations() *mcp.ToolAnnotations {
+	return UpdateAnnotations("Record Feedback")
+}
+
+// Description returns the tool description
+func (t *RecordFeedbackTool) Description() string {
+	return `Record whether a suggested tool or tool chain was helpful.
+
+Feedback on a learned chain (discover_tools adaptive_chains with source "learned") counts as
+several observed uses in the chain's success rate, which ranks chains and sets their confidence.
+Feedback on a single tool, or on a chain that was not learned, is tallied per tool and nudges
+that tool's relevance in discover_tools. Nothing is sent to IBM Cloud Logs.
+
+**Related tools:** discover_tools, session_context`
+}
+
+// InputSchema returns the input schema
+func (t *RecordFeedbackTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"tool": map[string]interface{}{
+				"type":        "string",
+				"description": "Name of the tool the feedback is about. Use chain instead for a tool chain.",
+				"examples":    []string{"suggest_alert"},
+			},
+			"chain": map[string]interface{}{
+				"type":        "array",
+				"description": "Tools of the chain the feedback is about, in order (an adaptive chain's tools)",
+				"items":       map[string]interface{}{"type": "string"},
+				"examples":    [][]string{{"query_logs", "investigate_incident"}},
+			},
+			"helpful": map[string]interface{}{
+				"type":        "boolean",
+				"description": "true if the suggestion was helpful, false if not",
+			},
+			"comment": map[string]interface{}{
+				"type":        "string",
+				"description": "Optional free-text note on why",
+				"maxLength":   500,
+			},
+		},
+		"required": []string{"helpful"},
+	}
+}
+
+// Execute executes the tool
+func (t *RecordFeedbackTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	helpful, ok := args["helpful"].(bool)
+	if !ok {
+		return NewToolResultError("helpful is required and must be true or false"), nil
+	}
+	tool, _ := GetStringParam(args, "tool", false)
+	chain, err := GetStringArrayParam(args, "chain", false)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	comment, _ := GetStringParam(args, "comment", false)
+	if len(comment) > 500 {
+		comment = comment[:500]
+	}
+
+	var tools []string
+	switch {
+	case tool != "" && len(chain) > 0:
+		return NewToolResultError("Use either tool or chain, not both"), nil
+	case tool != "":
+		tools = []string{tool}
+	case len(chain) > 0:
+		tools = chain
+	default:
+		return NewToolResultError("tool or chain is required"), nil
+	}
+	for _, name := range tools {
+		if _, known := ToolCapabilities[name]; !known {
+			return NewToolResultErrorWithSuggestion(fmt.Sprintf("Unknown tool '%s'.", name),
+				"Use tool names as listed by discover_tools or search_tools."), nil
+		}
+	}
+
+	outcome := GetSessionFromContext(ctx).RecordFeedback(tools, helpful, comment)
+
+	verdict := "not helpful"
+	if helpful {
+		verdict = "helpful"
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Recorded %s feedback for %s.\n\n", verdict, strings.Join(tools, " → "))
+	if outcome.ChainLearned {
+		fmt.Fprintf(&sb, "Learned chain success rate is now %.0f%%.", outcome.SuccessRate)
+	} else {
+		for _, name := range tools {
+			tally := outcome.Tallies[name]
+			fmt.Fprintf(&sb, "- %s: %d helpful, %d not helpful\n", name, tally.Helpful, tally.NotHelpful)
+		}
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: strings.TrimRight(sb.String(), "\n")}},
+	}, nil
+}
@@ -0,0 +1,117 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestRecordFeedback_LearnedChain(t *testing.T) {
+	session := NewSessionContext("feedback-user", "feedback-instance")
+	session.LearnedPatterns.FrequentSequences = []PatternSequence{
+		{Tools: []string{"query_logs", "investigate_incident"}, Count: 3, Weight: 3, SuccessRate: 100, LastUsed: time.Now()},
+	}
+
+	outcome := session.RecordFeedback([]string{"query_logs", "investigate_incident"}, false, "wrong app")
+	if !outcome.ChainLearned {
+		t.Fatal("Expected feedback to match the learned chain")
+	}
+	// (100*3 + 0*3) / 6
+	if outcome.SuccessRate != 50 {
+		t.Errorf("Expected success rate 50, got %f", outcome.SuccessRate)
+	}
+	if len(session.LearnedPatterns.ToolFeedback) != 0 {
+		t.Errorf("Chain feedback should not be tallied per tool: %v", session.LearnedPatterns.ToolFeedback)
+	}
+	if len(session.LearnedPatterns.Feedback) != 1 || session.LearnedPatterns.Feedback[0].Comment != "wrong app" {
+		t.Errorf("Expected the feedback entry to be kept, got %+v", session.LearnedPatterns.Feedback)
+	}
+}
+
+func TestRecordFeedback_ToolTally(t *testing.T) {
+	session := NewSessionContext("feedback-user", "feedback-instance")
+
+	session.RecordFeedback([]string{"suggest_alert"}, true, "")
+	outcome := session.RecordFeedback([]string{"suggest_alert"}, true, "")
+	if outcome.ChainLearned {
+		t.Fatal("Single tool feedback should not match a chain")
+	}
+	if got := outcome.Tallies["suggest_alert"]; got.Helpful != 2 || got.NotHelpful != 0 {
+		t.Errorf("Unexpected tally: %+v", got)
+	}
+	if score := session.ToolFeedbackScore("suggest_alert"); score != 0.5 {
+		t.Errorf("Expected score 2/4, got %f", score)
+	}
+	if score := session.ToolFeedbackScore("query_logs"); score != 0 {
+		t.Errorf("Expected no score without feedback, got %f", score)
+	}
+}
+
+func TestRecordFeedbackTool_Execute(t *testing.T) {
+	mock := client.NewMockClient()
+	tool := NewRecordFeedbackTool(mock, zap.NewNop())
+	ctx := testCtx(mock)
+
+	tests := []struct {
+		name    string
+		args    map[string]interface{}
+		wantErr string
+	}{
+		{"missing helpful", map[string]interface{}{"tool": "query_logs"}, "helpful is required"},
+		{"missing target", map[string]interface{}{"helpful": true}, "tool or chain is required"},
+		{"both targets", map[string]interface{}{"helpful": true, "tool": "query_logs", "chain": []interface{}{"query_logs", "create_alert"}}, "not both"},
+		{"unknown tool", map[string]interface{}{"helpful": true, "tool": "make_coffee"}, "Unknown tool"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tool.Execute(ctx, tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !result.IsError || !strings.Contains(result.Content[0].(*mcp.TextContent).Text, tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %+v", tt.wantErr, result.Content)
+			}
+		})
+	}
+
+	result, err := tool.Execute(ctx, map[string]interface{}{
+		"helpful": false,
+		"chain":   []interface{}{"query_logs", "create_alert"},
+	})
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected failure: %v %+v", err, result)
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(text, "not helpful feedback for query_logs → create_alert") || !strings.Contains(text, "create_alert: 0 helpful, 1 not helpful") {
+		t.Errorf("Unexpected output: %s", text)
+	}
+	if mock.RequestCount() != 0 {
+		t.Errorf("Feedback should not call the API, got %d requests", mock.RequestCount())
+	}
+}
+
+func TestFeedbackAffectsChainRanking(t *testing.T) {
+	registry := NewToolRegistry()
+	session := NewSessionContext("feedback-user", "feedback-instance")
+	now := time.Now()
+	session.LearnedPatterns.FrequentSequences = []PatternSequence{
+		{Tools: []string{"query_logs", "investigate_incident"}, Count: 6, Weight: 6, SuccessRate: 100, LastUsed: now},
+		{Tools: []string{"list_alerts", "create_alert"}, Count: 6, Weight: 6, SuccessRate: 90, LastUsed: now},
+	}
+
+	chains := registry.generateAdaptiveChains(nil, session)
+	if len(chains) != 2 || chains[0].Tools[0] != "query_logs" {
+		t.Fatalf("Expected query_logs chain first before feedback, got %+v", chains)
+	}
+
+	session.RecordFeedback([]string{"query_logs", "investigate_incident"}, false, "")
+	chains = registry.generateAdaptiveChains(nil, session)
+	if len(chains) == 0 || chains[0].Tools[0] != "list_alerts" {
+		t.Errorf("Expected negative feedback to demote the query_logs chain, got %+v", chains)
+	}
+}
@@ -0,0 +1,266 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+const (
+	// defaultFieldSampleSize is the number of logs sampled by discover_fields
+	defaultFieldSampleSize = 100
+	// maxFieldSampleSize bounds the sample so discovery stays a cheap query
+	maxFieldSampleSize = 500
+	// maxFieldExamples is the number of distinct example values kept per field
+	maxFieldExamples = 3
+	// maxFieldExampleLength truncates long example values
+	maxFieldExampleLength = 80
+)
+
+// DiscoveredField describes one user data field seen in the sampled logs
+type DiscoveredField struct {
+	Path     string   `json:"path"`
+	Query    string   `json:"query"`
+	Types    []string `json:"types"`
+	Seen     int      `json:"seen"`
+	Examples []string `json:"examples,omitempty"`
+}
+
+// fieldCollector accumulates the union of user data keys across sampled logs
+type fieldCollector struct {
+	fields map[string]*DiscoveredField
+}
+
+// add records every leaf path of a parsed user data object
+func (c *fieldCollector) add(data map[string]interface{}, prefix string) {
+	for key, value := range data {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			c.add(nested, path)
+			continue
+		}
+		fieldType := inferFieldType(value)
+		if fieldType == "" {
+			continue
+		}
+		f, ok := c.fields[path]
+		if !ok {
+			f = &DiscoveredField{Path: path, Query: "$d." + path}
+			c.fields[path] = f
+		}
+		f.Seen++
+		if !slices.Contains(f.Types, fieldType) {
+			f.Types = append(f.Types, fieldType)
+			sort.Strings(f.Types)
+		}
+		if example := fieldExample(value); example != "" && len(f.Examples) < maxFieldExamples && !slices.Contains(f.Examples, example) {
+			f.Examples = append(f.Examples, example)
+		}
+	}
+}
+
+// sorted returns the collected fields ordered by path
+func (c *fieldCollector) sorted() []*DiscoveredField {
+	out := make([]*DiscoveredField, 0, len(c.fields))
+	for _, f := range c.fields {
+		out = append(out, f)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out
+}
+
+// inferFieldType maps a decoded JSON value to string, number, bool, array or object.
+// Nulls return "" so they don't contribute a type.
+func inferFieldType(value interface{}) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case float64, int, int64:
+		return "number"
+	case bool:
+		return "bool"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return ""
+	}
+}
+
+// fieldExample renders a scalar value as a short example; arrays and objects have none
+func fieldExample(value interface{}) string {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case float64, int, int64, bool:
+		s = fmt.Sprint(v)
+	default:
+		return ""
+	}
+	if len(s) > maxFieldExampleLength {
+		s = s[:maxFieldExampleLength] + "..."
+	}
+	return s
+}
+
+// DiscoverFieldsTool samples recent logs of an application and reports the user data fields seen
+type DiscoverFieldsTool struct{ *BaseTool }
+
+// NewDiscoverFieldsTool creates a new tool instance
+func NewDiscoverFieldsTool(c client.Doer, l *zap.Logger) *DiscoverFieldsTool {
+	return &DiscoverFieldsTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *DiscoverFieldsTool) Name() string { return "discover_fields" }
+
+// Annotations returns tool hints for LLMs
+func (t *DiscoverFieldsTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Discover Fields")
+}
+
+// DefaultTimeout returns the timeout for the sample query
+func (t *DiscoverFieldsTool) DefaultTimeout() time.Duration {
+	return DefaultQueryTimeout
+}
+
+// Description returns the tool description
+func (t *DiscoverFieldsTool) Description() string {
+	return `Discover the user data fields ($d.*) logged by an application, with inferred types and example values.
+
+Samples recent logs of the application and returns the union of keys found in their JSON payload,
+each with its DataPrime path, type(s) (string/number/bool/array), how many sampled logs contained
+it, and up to 3 example values. Use the paths when calling build_query or writing filters.
+
+The result reflects a bounded sample, not an exhaustive schema: rare fields may be missing.
+
+**Related tools:** list_applications, build_query, query_logs`
+}
+
+// InputSchema returns the input schema
+func (t *DiscoverFieldsTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"application_name": map[string]interface{}{
+				"type":        "string",
+				"description": "Application whose logs are sampled (see list_applications)",
+			},
+			"subsystem_name": map[string]interface{}{
+				"type":        "string",
+				"description": "Only sample logs of this subsystem (optional)",
+			},
+			"time_range": map[string]interface{}{
+				"type":        "string",
+				"description": "How far back to sample logs (default: 1h)",
+				"enum":        []string{"15m", "1h", "6h", "24h", "7d"},
+				"default":     "1h",
+			},
+			"sample_size": map[string]interface{}{
+				"type":        "integer",
+				"description": fmt.Sprintf("Number of logs to sample (default: %d, max: %d)", defaultFieldSampleSize, maxFieldSampleSize),
+				"default":     defaultFieldSampleSize,
+				"minimum":     1,
+				"maximum":     maxFieldSampleSize,
+			},
+		},
+		"required": []string{"application_name"},
+	}
+}
+
+// Execute executes the tool
+func (t *DiscoverFieldsTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	appName, err := GetStringParam(args, "application_name", true)
+	if err != nil {
+		return NewToolResultError(err.Error()), nil
+	}
+	subsystem, _ := GetStringParam(args, "subsystem_name", false)
+	timeRange, _ := GetStringParam(args, "time_range", false)
+	if timeRange == "" {
+		timeRange = "1h"
+	}
+	window, ok := logSourceWindows[timeRange]
+	if !ok {
+		return NewToolResultError(fmt.Sprintf("time_range must be one of 15m, 1h, 6h, 24h, 7d (got %q)", timeRange)), nil
+	}
+	sampleSize, err := GetIntParam(args, "sample_size", false)
+	if err != nil {
+		return NewToolResultError(err.Error()), nil
+	}
+	if sampleSize <= 0 {
+		sampleSize = defaultFieldSampleSize
+	}
+	if sampleSize > maxFieldSampleSize {
+		sampleSize = maxFieldSampleSize
+	}
+
+	filters := []string{fmt.Sprintf("$l.applicationname == '%s'", escapeDataPrimeString(appName))}
+	if subsystem != "" {
+		filters = append(filters, fmt.Sprintf("$l.subsystemname == '%s'", escapeDataPrimeString(subsystem)))
+	}
+	query := fmt.Sprintf("source logs | filter %s | limit %d", strings.Join(filters, " && "), sampleSize)
+	query, _, err = PrepareQuery(query, "archive", "dataprime")
+	if err != nil {
+		return NewToolResultError(fmt.Sprintf("Invalid query: %v", err)), nil
+	}
+
+	endDate := time.Now().UTC()
+	result, err := t.ExecuteRequest(ctx, &client.Request{
+		Method: "POST",
+		Path:   "/v1/query",
+		Body: map[string]interface{}{
+			"query":      query,
+			"tier":       "archive",
+			"syntax":     "dataprime",
+			"start_date": endDate.Add(-window).Format(time.RFC3339),
+			"end_date":   endDate.Format(time.RFC3339),
+		},
+	})
+	if err != nil {
+		return NewToolResultErrorWithCode(ClassifyError(err), fmt.Sprintf("Failed to query logs: %v", err)), nil
+	}
+
+	events, _ := result["events"].([]interface{})
+	collector := &fieldCollector{fields: make(map[string]*DiscoveredField)}
+	for _, event := range events {
+		eventMap, ok := event.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, key := range []string{"user_data", "json"} {
+			if data, ok := eventMap[key].(map[string]interface{}); ok {
+				collector.add(data, "")
+			}
+		}
+	}
+	fields := collector.sorted()
+
+	response := map[string]interface{}{
+		"application_name": appName,
+		"time_range":       timeRange,
+		"sampled_logs":     len(events),
+		"fields":           fields,
+		"note": fmt.Sprintf("Based on a sample of %d logs, not an exhaustive schema. Fields that are rare or absent from the sample are not listed.",
+			len(events)),
+	}
+	if subsystem != "" {
+		response["subsystem_name"] = subsystem
+	}
+	if len(events) == 0 {
+		response["note"] = fmt.Sprintf("No logs found for application '%s' in the last %s. Check the name with list_applications or widen time_range.", appName, timeRange)
+	}
+	return t.FormatResponse(response)
+}
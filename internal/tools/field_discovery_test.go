@@ -0,0 +1,69 @@
+package tools
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestDiscoverFieldsTool_Execute(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.RespondWith(http.StatusOK, map[string]interface{}{"events": []interface{}{
+		map[string]interface{}{"user_data": map[string]interface{}{
+			"message":  "payment failed",
+			"duration": 120,
+			"http":     map[string]interface{}{"status": 500, "ok": false},
+			"trace_id": nil,
+		}},
+		map[string]interface{}{"user_data": map[string]interface{}{
+			"message":  "payment ok",
+			"duration": "fast",
+			"http":     map[string]interface{}{"status": 200},
+		}},
+	}})
+
+	tool := NewDiscoverFieldsTool(mock, zap.NewNop())
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{"application_name": "checkout", "sample_size": 5000})
+	if err != nil || result.IsError {
+		t.Fatalf("unexpected error: %v %v", err, result.Content)
+	}
+
+	var got struct {
+		SampledLogs int                `json:"sampled_logs"`
+		Fields      []*DiscoveredField `json:"fields"`
+		Note        string             `json:"note"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &got); err != nil {
+		t.Fatalf("response is not JSON: %v", err)
+	}
+	byPath := make(map[string]*DiscoveredField)
+	for _, f := range got.Fields {
+		byPath[f.Path] = f
+	}
+	if len(byPath) != 4 {
+		t.Errorf("expected 4 fields (nulls skipped), got %v", got.Fields)
+	}
+	if f := byPath["http.status"]; f == nil || f.Query != "$d.http.status" || f.Seen != 2 || f.Types[0] != "number" {
+		t.Errorf("unexpected nested field: %+v", f)
+	}
+	if f := byPath["duration"]; f == nil || strings.Join(f.Types, ",") != "number,string" {
+		t.Errorf("expected mixed types for duration, got %+v", f)
+	}
+	if f := byPath["message"]; f == nil || len(f.Examples) != 2 {
+		t.Errorf("expected two message examples, got %+v", f)
+	}
+	if got.SampledLogs != 2 || !strings.Contains(got.Note, "not an exhaustive schema") {
+		t.Errorf("unexpected sample summary: %d %q", got.SampledLogs, got.Note)
+	}
+
+	query := mock.LastRequest().Body.(map[string]interface{})["query"].(string)
+	if !strings.Contains(query, "$l.applicationname == 'checkout'") || !strings.Contains(query, "limit 500") {
+		t.Errorf("sample query should filter the application and cap the sample: %s", query)
+	}
+}
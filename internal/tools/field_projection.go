@@ -0,0 +1,101 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements the fields projection of compacted query results.
+package tools
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// compactEntryFields are the keys formatSingleLogEntry renders in its fixed layout
+var compactEntryFields = map[string]bool{
+	"time": true, "severity": true, "app": true, "subsystem": true, "message": true, "exec_ms": true,
+	"repeat_count": true, "first_time": true, "last_time": true,
+}
+
+// fieldsSchema is the schema of the fields argument of query_logs
+var fieldsSchema = map[string]interface{}{
+	"type":        "array",
+	"description": "Keys to keep in each compacted log entry, e.g. [\"time\",\"severity\",\"message\"]. Available keys: time, severity, app, subsystem, message, level, logger, trace_id, span_id, sql, exec_ms. Requested keys no entry has are listed in the query metadata. Ignored with raw_output.",
+	"items":       map[string]interface{}{"type": "string"},
+	"examples":    [][]string{{"time", "severity", "message"}},
+}
+
+// setResultFields records a fields projection in the result's _query_metadata so
+// CleanQueryResults keeps only those keys. An empty list keeps every key.
+func setResultFields(result map[string]interface{}, fields []string) {
+	if len(fields) == 0 {
+		return
+	}
+	meta, ok := result["_query_metadata"].(map[string]interface{})
+	if !ok {
+		meta = make(map[string]interface{})
+		result["_query_metadata"] = meta
+	}
+	projected := make([]interface{}, len(fields))
+	for i, f := range fields {
+		projected[i] = f
+	}
+	meta["fields"] = projected
+}
+
+// projectedFields returns the fields projection recorded in a result's _query_metadata
+func projectedFields(result map[string]interface{}) []string {
+	meta, ok := result["_query_metadata"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	fields, _ := GetStringArrayParam(meta, "fields", false)
+	return fields
+}
+
+// ProjectLogFields keeps only the given keys in each compacted log entry. It returns the
+// requested keys that no entry had, sorted, so callers can report them once.
+func ProjectLogFields(entries []interface{}, fields []string) ([]interface{}, []string) {
+	seen := make(map[string]bool, len(fields))
+	projected := make([]interface{}, 0, len(entries))
+	for _, e := range entries {
+		entry, ok := e.(map[string]interface{})
+		if !ok {
+			projected = append(projected, e)
+			continue
+		}
+		kept := make(map[string]interface{}, len(fields))
+		for _, f := range fields {
+			if v, ok := entry[f]; ok {
+				kept[f] = v
+				seen[f] = true
+			}
+		}
+		for _, f := range dedupeEntryFields {
+			if v, ok := entry[f]; ok {
+				kept[f] = v
+			}
+		}
+		projected = append(projected, kept)
+	}
+
+	var missing []string
+	for _, f := range fields {
+		if !seen[f] && !slices.Contains(missing, f) {
+			missing = append(missing, f)
+		}
+	}
+	sort.Strings(missing)
+	return projected, missing
+}
+
+// writeProjectedFields writes the projected keys of an entry that the fixed compact
+// layout does not show, such as trace_id or level
+func writeProjectedFields(sb *strings.Builder, logMap map[string]interface{}, fields []string) {
+	for _, f := range fields {
+		if compactEntryFields[f] {
+			continue
+		}
+		if v, ok := logMap[f]; ok {
+			fmt.Fprintf(sb, "  - %s: %v\n", f, v)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file correlates alerts that fired during an investigation window with its error patterns.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// maxCorrelatedPatterns caps how many error patterns are listed per fired alert
+const maxCorrelatedPatterns = 3
+
+// FiredAlert is an alert whose last trigger falls inside an investigation window
+type FiredAlert struct {
+	ID           string
+	Name         string
+	Severity     string
+	TriggeredAt  time.Time
+	Applications []string
+	Query        string
+}
+
+// AlertCorrelation links a fired alert to the error patterns that plausibly caused it
+type AlertCorrelation struct {
+	Alert    FiredAlert
+	Patterns []LogCluster
+	// MatchedBy explains the link: "application", "query" or "" when nothing matched
+	MatchedBy string
+}
+
+// fetchFiredAlerts lists alerts and returns those that fired between start and end
+func (t *InvestigateIncidentTool) fetchFiredAlerts(ctx context.Context, start, end time.Time) ([]FiredAlert, error) {
+	result, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/alerts"})
+	if err != nil {
+		return nil, err
+	}
+	return FiredAlertsInWindow(result, start, end), nil
+}
+
+// FiredAlertsInWindow returns the alerts of a list_alerts response whose last_triggered_at
+// falls between start and end, oldest first
+func FiredAlertsInWindow(result map[string]interface{}, start, end time.Time) []FiredAlert {
+	alerts, _ := result["alerts"].([]interface{})
+	var fired []FiredAlert
+	for _, a := range alerts {
+		alert, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		triggered, _ := alert["last_triggered_at"].(string)
+		at, err := time.Parse(time.RFC3339Nano, triggered)
+		if err != nil || at.Before(start) || at.After(end) {
+			continue
+		}
+		id, _ := alert["id"].(string)
+		name, _ := alert["name"].(string)
+		severity, _ := alert["severity"].(string)
+		fired = append(fired, FiredAlert{
+			ID:           id,
+			Name:         name,
+			Severity:     severity,
+			TriggeredAt:  at,
+			Applications: alertApplications(alert),
+			Query:        alertQuery(alert),
+		})
+	}
+	sort.Slice(fired, func(i, j int) bool { return fired[i].TriggeredAt.Before(fired[j].TriggeredAt) })
+	return fired
+}
+
+// alertApplications returns the application names an alert's filters are scoped to
+func alertApplications(alert map[string]interface{}) []string {
+	filters, _ := alert["filters"].(map[string]interface{})
+	apps, _ := filters["applications"].([]interface{})
+	var names []string
+	for _, app := range apps {
+		switch v := app.(type) {
+		case string:
+			names = append(names, v)
+		case map[string]interface{}:
+			if name, ok := v["name"].(string); ok && name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// alertQuery returns the query text an alert filters on, if any
+func alertQuery(alert map[string]interface{}) string {
+	if filter, ok := alert["filter"].(map[string]interface{}); ok {
+		if simple, ok := filter["simple_filter"].(map[string]interface{}); ok {
+			if q, ok := simple["query"].(string); ok {
+				return q
+			}
+		}
+	}
+	if filters, ok := alert["filters"].(map[string]interface{}); ok {
+		if text, ok := filters["text"].(string); ok {
+			return text
+		}
+	}
+	return ""
+}
+
+// CorrelateFiredAlerts clusters the investigation's error events and links each fired alert
+// to the clusters from its applications, or failing that to clusters whose message contains
+// a term of the alert's query
+func CorrelateFiredAlerts(fired []FiredAlert, events []interface{}) []AlertCorrelation {
+	clusters := ClusterLogs(events)
+	correlations := make([]AlertCorrelation, 0, len(fired))
+	for _, alert := range fired {
+		corr := AlertCorrelation{Alert: alert}
+		if len(alert.Applications) > 0 {
+			for _, c := range clusters {
+				if clusterInApplications(c, alert.Applications) {
+					corr.Patterns = append(corr.Patterns, c)
+				}
+			}
+			if len(corr.Patterns) > 0 {
+				corr.MatchedBy = "application"
+			}
+		}
+		if len(corr.Patterns) == 0 {
+			terms := alertQueryTerms(alert.Query)
+			for _, c := range clusters {
+				if containsAnyTerm(strings.ToLower(c.Pattern), terms) {
+					corr.Patterns = append(corr.Patterns, c)
+				}
+			}
+			if len(corr.Patterns) > 0 {
+				corr.MatchedBy = "query"
+			}
+		}
+		if len(corr.Patterns) > maxCorrelatedPatterns {
+			corr.Patterns = corr.Patterns[:maxCorrelatedPatterns]
+		}
+		correlations = append(correlations, corr)
+	}
+	return correlations
+}
+
+// clusterInApplications reports whether any event of a cluster comes from one of apps
+func clusterInApplications(c LogCluster, apps []string) bool {
+	for _, e := range c.Events {
+		eventMap, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		app := findFieldValue(eventMap, "applicationname")
+		for _, a := range apps {
+			if app != "" && strings.EqualFold(app, a) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// alertQueryTerms returns the lowercased words of an alert query that are specific enough
+// to match on, skipping field names, operators and short tokens
+func alertQueryTerms(query string) []string {
+	var terms []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_' || r == '-')
+	}) {
+		switch word {
+		case "source", "logs", "filter", "severity", "error", "and", "not", "contains", "text", "message":
+			continue
+		}
+		if len(word) >= 4 {
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
+
+// containsAnyTerm reports whether s contains one of terms
+func containsAnyTerm(s string, terms []string) bool {
+	for _, term := range terms {
+		if strings.Contains(s, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeFiredAlerts writes the fired alerts section of an investigation report
+func writeFiredAlerts(response *strings.Builder, correlations []AlertCorrelation, display TimeDisplay) {
+	response.WriteString("\n## 🚨 Alerts Fired During Incident\n")
+	if len(correlations) == 0 {
+		response.WriteString("No alerts fired in the investigation window.\n")
+		return
+	}
+	for _, corr := range correlations {
+		a := corr.Alert
+		fmt.Fprintf(response, "- **%s**", a.Name)
+		if a.Severity != "" {
+			fmt.Fprintf(response, " [%s]", a.Severity)
+		}
+		fmt.Fprintf(response, " fired at %s", display.FormatWithRaw(a.TriggeredAt.Format(time.RFC3339)))
+		if a.ID != "" {
+			fmt.Fprintf(response, " (id: `%s`)", a.ID)
+		}
+		response.WriteString("\n")
+		if len(corr.Patterns) == 0 {
+			response.WriteString("  - No matching error pattern in this window\n")
+			continue
+		}
+		fmt.Fprintf(response, "  - Correlated error patterns (by %s):\n", corr.MatchedBy)
+		for _, c := range corr.Patterns {
+			fmt.Fprintf(response, "    - %dx `%s`\n", c.Count, truncateString(c.Pattern, 100))
+		}
+	}
+}
+
+// firedAlertHypotheses turns correlated alerts into root-cause hypotheses
+func firedAlertHypotheses(correlations []AlertCorrelation) []string {
+	var hypotheses []string
+	for _, corr := range correlations {
+		if len(corr.Patterns) == 0 {
+			continue
+		}
+		hypotheses = append(hypotheses, fmt.Sprintf(
+			"Alert '%s' fired at %s alongside %d occurrences of \"%s\" - this pattern is the likely trigger",
+			corr.Alert.Name, corr.Alert.TriggeredAt.UTC().Format("15:04 MST"),
+			corr.Patterns[0].Count, truncateString(corr.Patterns[0].Pattern, 60)))
+	}
+	return hypotheses
+}
@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestFiredAlertsInWindow(t *testing.T) {
+	end := time.Now().UTC()
+	start := end.Add(-time.Hour)
+	result := map[string]interface{}{
+		"alerts": []interface{}{
+			map[string]interface{}{"id": "a2", "name": "DB errors", "last_triggered_at": end.Add(-10 * time.Minute).Format(time.RFC3339)},
+			map[string]interface{}{"id": "a1", "name": "API 5xx", "last_triggered_at": end.Add(-50 * time.Minute).Format(time.RFC3339),
+				"filters": map[string]interface{}{"applications": []interface{}{map[string]interface{}{"name": "api-gateway"}}}},
+			map[string]interface{}{"id": "old", "name": "Old", "last_triggered_at": end.Add(-3 * time.Hour).Format(time.RFC3339)},
+			map[string]interface{}{"id": "never", "name": "Never fired"},
+		},
+	}
+
+	fired := FiredAlertsInWindow(result, start, end)
+	if len(fired) != 2 {
+		t.Fatalf("Expected 2 fired alerts, got %+v", fired)
+	}
+	if fired[0].ID != "a1" || fired[1].ID != "a2" {
+		t.Errorf("Expected oldest first, got %s, %s", fired[0].ID, fired[1].ID)
+	}
+	if len(fired[0].Applications) != 1 || fired[0].Applications[0] != "api-gateway" {
+		t.Errorf("Expected alert applications from filters, got %v", fired[0].Applications)
+	}
+}
+
+func TestCorrelateFiredAlerts(t *testing.T) {
+	events := []interface{}{
+		map[string]interface{}{"message": "upstream timeout", "applicationname": "api-gateway"},
+		map[string]interface{}{"message": "upstream timeout", "applicationname": "api-gateway"},
+		map[string]interface{}{"message": "deadlock detected", "applicationname": "orders"},
+	}
+	fired := []FiredAlert{
+		{Name: "API 5xx", Applications: []string{"api-gateway"}},
+		{Name: "Deadlocks", Query: "source logs | filter $d.message.contains('deadlock')"},
+		{Name: "Disk full", Query: "disk_usage > 90"},
+	}
+
+	correlations := CorrelateFiredAlerts(fired, events)
+	if len(correlations) != 3 {
+		t.Fatalf("Expected one correlation per alert, got %d", len(correlations))
+	}
+	if c := correlations[0]; c.MatchedBy != "application" || len(c.Patterns) != 1 || c.Patterns[0].Count != 2 {
+		t.Errorf("Expected the timeout pattern by application, got %+v", c)
+	}
+	if c := correlations[1]; c.MatchedBy != "query" || c.Patterns[0].Pattern != "deadlock detected" {
+		t.Errorf("Expected the deadlock pattern by query, got %+v", c)
+	}
+	if c := correlations[2]; c.MatchedBy != "" || len(c.Patterns) != 0 {
+		t.Errorf("Expected no pattern for an unrelated alert, got %+v", c)
+	}
+}
+
+func TestInvestigateIncident_CorrelatesFiredAlerts(t *testing.T) {
+	firedAt := time.Now().UTC().Add(-5 * time.Minute).Format(time.RFC3339)
+	mock := client.NewMockClient()
+	mock.DoFunc = func(_ context.Context, req *client.Request) (*client.Response, error) {
+		var body interface{}
+		if req.Path == "/v1/alerts" {
+			body = map[string]interface{}{"alerts": []interface{}{
+				map[string]interface{}{"id": "a1", "name": "Gateway errors", "severity": "critical", "last_triggered_at": firedAt,
+					"filters": map[string]interface{}{"applications": []interface{}{"api-gateway"}}},
+			}}
+		} else {
+			body = map[string]interface{}{"events": []interface{}{
+				map[string]interface{}{"message": "upstream timeout", "applicationname": "api-gateway"},
+			}}
+		}
+		data, _ := json.Marshal(body)
+		return &client.Response{StatusCode: http.StatusOK, Body: data}, nil
+	}
+	tool := NewInvestigateIncidentTool(mock, zap.NewNop())
+
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{"application": "api-gateway"})
+	if err != nil || result.IsError {
+		t.Fatalf("investigation failed: %v %+v", err, result)
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	for _, want := range []string{"Alerts Fired During Incident", "**Gateway errors** [critical]", "1x `upstream timeout`", "Alert 'Gateway errors' fired at"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in report:\n%s", want, text)
+		}
+	}
+
+	requests := mock.RequestCount()
+	result, _ = tool.Execute(testCtx(mock), map[string]interface{}{"application": "api-gateway", "correlate_alerts": false})
+	if mock.RequestCount()-requests != 1 {
+		t.Errorf("Expected only the log query with correlate_alerts=false, got %d requests", mock.RequestCount()-requests)
+	}
+	if strings.Contains(result.Content[0].(*mcp.TextContent).Text, "Alerts Fired") {
+		t.Error("Expected no alert section with correlate_alerts=false")
+	}
+}
@@ -2,7 +2,11 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -53,20 +57,26 @@ func (t *IngestLogsTool) Description() string {
 - 3: Info - Informational messages (default)
 - 4: Warning - Warning conditions
 - 5: Error - Error conditions
-- 6: Critical - Critical/fatal conditions`
+- 6: Critical - Critical/fatal conditions
+
+Entries are validated before sending (required fields, severity 1-6, parseable timestamps,
+max 256KB per entry); errors are reported per entry as logs[i]. Use dry_run=true to validate only.
+
+Large requests are split into batches (default 1000 entries / 2MB) and sent in order;
+the result lists each batch's log range and status so failures map back to the input.`
 }
 
 // InputSchema returns the JSON schema for the tool's input parameters.
 // Required fields: logs (array of log entries)
-// Each log entry must have: applicationName (or namespace), subsystemName (or component), severity, text
-// Optional fields: timestamp, json
+// Each log entry must have: applicationName (or namespace), subsystemName (or component), severity, text and/or json
+// Optional fields: timestamp
 func (t *IngestLogsTool) InputSchema() interface{} {
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
 			"logs": map[string]interface{}{
 				"type":        "array",
-				"description": "Array of log entries to ingest",
+				"description": "Array of log entries to ingest (up to 100,000; sent in batches)",
 				"examples": []interface{}{
 					[]map[string]interface{}{
 						{
@@ -102,8 +112,8 @@ func (t *IngestLogsTool) InputSchema() interface{} {
 							"description": "Alias for subsystemName - the component/resource within the application",
 						},
 						"severity": map[string]interface{}{
-							"type":        "integer",
-							"description": "Log severity level (1=Debug, 2=Verbose, 3=Info, 4=Warning, 5=Error, 6=Critical)",
+							"type":        []string{"integer", "string"},
+							"description": "Log severity level (1=Debug, 2=Verbose, 3=Info, 4=Warning, 5=Error, 6=Critical); names such as \"error\" are converted",
 							"minimum":     1,
 							"maximum":     6,
 						},
@@ -112,29 +122,98 @@ func (t *IngestLogsTool) InputSchema() interface{} {
 							"description": "The log message text",
 						},
 						"timestamp": map[string]interface{}{
-							"type":        "number",
-							"description": "Unix timestamp with nanoseconds (e.g., 1699564800.123456789). If not provided, current time will be used.",
+							"type":        []string{"number", "string"},
+							"description": "Unix timestamp with nanoseconds (e.g., 1699564800.123456789) or RFC 3339 string. If not provided, current time will be used.",
 						},
 						"json": map[string]interface{}{
 							"type":        "object",
 							"description": "Optional JSON object containing structured log data",
 						},
 					},
-					"required": []string{"severity", "text"},
+					"required": []string{"severity"},
 				},
 			},
+			"dry_run": map[string]interface{}{
+				"type":        "boolean",
+				"description": "If true, validate all entries and report per-entry errors without sending",
+				"default":     false,
+			},
 		},
 		"required": []string{"logs"},
 	}
 }
 
-// MaxIngestionBatchSize is the maximum number of log entries allowed per ingestion request.
-// This prevents DoS attacks and ensures reasonable request sizes.
-const MaxIngestionBatchSize = 1000
+// Ingestion batching limits. Requests larger than one batch are split into chunks that stay
+// under both the entry count and serialized size limits, and sent sequentially.
+const (
+	// MaxIngestionBatchSize is the default maximum number of log entries per ingestion request
+	MaxIngestionBatchSize = 1000
+	// DefaultIngestionBatchBytes is the default maximum serialized size of one ingestion request
+	DefaultIngestionBatchBytes = 2 * 1024 * 1024
+	// MaxIngestionEntries caps the entries accepted in one call. This prevents DoS
+	// and keeps the sequential upload within the tool timeout.
+	MaxIngestionEntries = 100_000
+)
+
+// Configured ingestion batch limits, initialized to the defaults above
+var (
+	ingestBatchCount atomic.Int64
+	ingestBatchBytes atomic.Int64
+)
+
+func init() {
+	ingestBatchCount.Store(MaxIngestionBatchSize)
+	ingestBatchBytes.Store(DefaultIngestionBatchBytes)
+}
+
+// SetIngestBatchLimits overrides the per-request entry count and byte limits used to chunk
+// ingest_logs batches. Non-positive values restore the defaults.
+func SetIngestBatchLimits(maxEntries, maxBytes int) {
+	if maxEntries <= 0 {
+		maxEntries = MaxIngestionBatchSize
+	}
+	if maxBytes <= 0 {
+		maxBytes = DefaultIngestionBatchBytes
+	}
+	ingestBatchCount.Store(int64(maxEntries))
+	ingestBatchBytes.Store(int64(maxBytes))
+}
+
+// ingestChunk is a contiguous range of entries [Start, End) sent in one request
+type ingestChunk struct {
+	Start, End int
+}
+
+// chunkIngestEntries splits entries into contiguous chunks of at most maxEntries entries and
+// maxBytes serialized bytes. An entry larger than maxBytes is sent on its own.
+func chunkIngestEntries(logs []map[string]interface{}, maxEntries, maxBytes int) []ingestChunk {
+	var chunks []ingestChunk
+	start, size := 0, 2 // JSON array brackets
+	for i, entry := range logs {
+		b, _ := json.Marshal(entry)
+		entrySize := len(b) + 1 // separator
+		if i > start && (i-start >= maxEntries || size+entrySize > maxBytes) {
+			chunks = append(chunks, ingestChunk{Start: start, End: i})
+			start, size = i, 2
+		}
+		size += entrySize
+	}
+	if start < len(logs) {
+		chunks = append(chunks, ingestChunk{Start: start, End: len(logs)})
+	}
+	return chunks
+}
+
+// Per-entry size limits (serialized JSON). Entries above the warning size are accepted but
+// costly to store and search; entries above the maximum are rejected before sending.
+const (
+	IngestEntryWarnSize = 32 * 1024
+	MaxIngestEntrySize  = 256 * 1024
+)
 
 // Execute ingests log entries to IBM Cloud Logs.
-// It validates input, adds timestamps where missing, and sends logs to the
-// ingestion endpoint (.ingress. subdomain).
+// It validates every entry, adds timestamps where missing, and sends logs to the
+// ingestion endpoint (.ingress. subdomain). With dry_run, entries are validated only.
 //
 // Returns an error if validation fails or the API request fails.
 func (t *IngestLogsTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
@@ -148,68 +227,62 @@ func (t *IngestLogsTool) Execute(ctx context.Context, arguments map[string]inter
 		return NewToolResultError("logs array cannot be empty"), nil
 	}
 
-	// Enforce batch size limit to prevent DoS and ensure reasonable request sizes
-	if len(logsRaw) > MaxIngestionBatchSize {
-		return NewToolResultError(fmt.Sprintf("batch size %d exceeds maximum allowed (%d). Please split into smaller batches", len(logsRaw), MaxIngestionBatchSize)), nil
+	// Enforce an overall cap to prevent DoS; larger-than-batch requests are chunked below
+	if len(logsRaw) > MaxIngestionEntries {
+		return NewToolResultError(fmt.Sprintf("%d log entries exceeds the maximum of %d per call. Please split into smaller calls", len(logsRaw), MaxIngestionEntries)), nil
 	}
 
-	// Process each log entry and add timestamp if missing
 	logs := make([]map[string]interface{}, 0, len(logsRaw))
+	var errs, warnings []string
 	for i, logRaw := range logsRaw {
 		logEntry, ok := logRaw.(map[string]interface{})
 		if !ok {
-			return NewToolResultError("each log entry must be an object"), nil
+			errs = append(errs, fmt.Sprintf("logs[%d]: entry must be an object", i))
+			continue
 		}
-
-		// Resolve applicationName aliases (namespace, app, application, service)
-		if _, exists := logEntry["applicationName"]; !exists {
-			for _, alias := range []string{"namespace", "app", "application", "service", "app_name", "application_name"} {
-				if val, exists := logEntry[alias]; exists {
-					logEntry["applicationName"] = val
-					delete(logEntry, alias) // Remove alias to avoid sending duplicate fields
-					break
-				}
-			}
+		entryErrs, entryWarnings := normalizeIngestEntry(logEntry)
+		for _, e := range entryErrs {
+			errs = append(errs, fmt.Sprintf("logs[%d]: %s", i, e))
 		}
-
-		// Resolve subsystemName aliases (component, resource, module)
-		if _, exists := logEntry["subsystemName"]; !exists {
-			for _, alias := range []string{"component", "resource", "subsystem", "module", "component_name", "subsystem_name", "resource_name"} {
-				if val, exists := logEntry[alias]; exists {
-					logEntry["subsystemName"] = val
-					delete(logEntry, alias) // Remove alias to avoid sending duplicate fields
-					break
-				}
-			}
+		for _, w := range entryWarnings {
+			warnings = append(warnings, fmt.Sprintf("logs[%d]: %s", i, w))
 		}
+		logs = append(logs, logEntry)
+	}
 
-		// Validate required fields after alias resolution
-		if _, exists := logEntry["applicationName"]; !exists {
-			return NewToolResultError("log entry missing required field: applicationName (or alias: namespace, app, application, service)"), nil
-		}
-		if _, exists := logEntry["subsystemName"]; !exists {
-			return NewToolResultError("log entry missing required field: subsystemName (or alias: component, resource, module)"), nil
+	if dryRun, _ := GetBoolParam(arguments, "dry_run", false); dryRun {
+		result := &ValidationResult{
+			Valid:    len(errs) == 0,
+			Errors:   errs,
+			Warnings: warnings,
+			Summary: map[string]interface{}{
+				"entries": len(logsRaw),
+				"invalid": countInvalidEntries(errs),
+			},
+			EstimatedImpact: &ImpactEstimate{RiskLevel: "low"},
 		}
-		if _, exists := logEntry["severity"]; !exists {
-			return NewToolResultError("log entry missing required field: severity"), nil
+		if result.Valid {
+			result.Suggestions = append(result.Suggestions, "Remove dry_run parameter to ingest the logs")
 		}
-		if _, exists := logEntry["text"]; !exists {
-			return NewToolResultError("log entry missing required field: text"), nil
+		preview := map[string]interface{}{"entries": len(logs)}
+		if len(logs) > 0 {
+			preview["first_entry"] = logs[0]
 		}
+		return FormatDryRunResult(result, "Log Batch", preview), nil
+	}
 
-		// Add current timestamp if not provided
-		if _, exists := logEntry["timestamp"]; !exists {
-			// Unix timestamp with nanoseconds
-			now := time.Now()
-			timestamp := float64(now.Unix()) + float64(now.Nanosecond())/1e9
-			logEntry["timestamp"] = timestamp
-		}
+	if len(errs) > 0 {
+		return NewToolResultErrorWithSuggestion(
+			fmt.Sprintf("%d of %d log entries are invalid; nothing was sent:\n- %s", countInvalidEntries(errs), len(logsRaw), strings.Join(errs, "\n- ")),
+			"Fix the listed entries and retry, or use dry_run=true to validate without sending."), nil
+	}
+	for _, w := range warnings {
+		t.logger.Warn("Ingest entry warning", zap.String("warning", w))
+	}
 
-		logs = append(logs, logEntry)
-		t.logger.Debug("Processing log entry",
-			zap.Int("index", i),
-			zap.String("application", logEntry["applicationName"].(string)),
-		)
+	chunks := chunkIngestEntries(logs, int(ingestBatchCount.Load()), int(ingestBatchBytes.Load()))
+	if len(chunks) > 1 {
+		return t.ingestChunks(ctx, logs, chunks), nil
 	}
 
 	// Note: The ingestion endpoint is different from the management API
@@ -220,12 +293,211 @@ func (t *IngestLogsTool) Execute(ctx context.Context, arguments map[string]inter
 		Path:           "/logs/v1/singles",
 		Body:           logs,
 		UseIngressHost: true, // Flag to use ingress endpoint instead of API endpoint
+		Compressible:   true, // Gzip large batches per LOGS_INGEST_COMPRESSION
 	}
 
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	return t.FormatResponseWithSuggestions(result, "ingest_logs")
 }
+
+// ingestChunkResult is the outcome of sending one chunk
+type ingestChunkResult struct {
+	chunk ingestChunk
+	err   error
+	sent  bool
+}
+
+// ingestChunks sends each chunk sequentially and reports per-chunk results in input order
+func (t *IngestLogsTool) ingestChunks(ctx context.Context, logs []map[string]interface{}, chunks []ingestChunk) *mcp.CallToolResult {
+	results := make([]ingestChunkResult, len(chunks))
+	sentBatches, sentEntries := 0, 0
+	for i, chunk := range chunks {
+		results[i].chunk = chunk
+		if ctx.Err() != nil {
+			results[i].err = ctx.Err()
+			continue
+		}
+		_, err := t.ExecuteRequest(ctx, &client.Request{
+			Method:         "POST",
+			Path:           "/logs/v1/singles",
+			Body:           logs[chunk.Start:chunk.End],
+			UseIngressHost: true,
+			Compressible:   true,
+		})
+		if err != nil {
+			results[i].err = err
+			t.logger.Warn("Ingest batch failed",
+				zap.Int("batch", i+1),
+				zap.Int("start", chunk.Start),
+				zap.Int("end", chunk.End),
+				zap.Error(err),
+			)
+			continue
+		}
+		results[i].sent = true
+		sentBatches++
+		sentEntries += chunk.End - chunk.Start
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Log Ingestion Summary\n\n")
+	fmt.Fprintf(&sb, "**Entries:** %d in %d batches\n", len(logs), len(chunks))
+	fmt.Fprintf(&sb, "**Succeeded:** %d/%d batches (%d entries)\n", sentBatches, len(chunks), sentEntries)
+	if failed := len(chunks) - sentBatches; failed > 0 {
+		fmt.Fprintf(&sb, "**Failed:** %d batches (%d entries)\n", failed, len(logs)-sentEntries)
+	}
+	sb.WriteString("\n| Batch | Entries | Status |\n")
+	sb.WriteString("|-------|---------|--------|\n")
+	for i, r := range results {
+		status := "✅ sent"
+		if !r.sent {
+			status = "❌ " + strings.ReplaceAll(strings.SplitN(r.err.Error(), "\n", 2)[0], "|", "\\|")
+		}
+		fmt.Fprintf(&sb, "| %d | logs[%d]–logs[%d] | %s |\n", i+1, r.chunk.Start, r.chunk.End-1, status)
+	}
+	if sentBatches < len(chunks) {
+		sb.WriteString("\n💡 Retry only the failed ranges to avoid duplicating logs that were already ingested.\n")
+	}
+
+	result := &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}},
+	}
+	if sentBatches == 0 {
+		withErrorCode(result, ClassifyError(results[0].err))
+	}
+	return result
+}
+
+// normalizeIngestEntry resolves field aliases, coerces severity and timestamp in place,
+// and returns validation errors and warnings for the entry
+func normalizeIngestEntry(logEntry map[string]interface{}) (errs []string, warnings []string) {
+	// Resolve applicationName aliases (namespace, app, application, service)
+	if _, exists := logEntry["applicationName"]; !exists {
+		for _, alias := range []string{"namespace", "app", "application", "service", "app_name", "application_name"} {
+			if val, exists := logEntry[alias]; exists {
+				logEntry["applicationName"] = val
+				delete(logEntry, alias) // Remove alias to avoid sending duplicate fields
+				break
+			}
+		}
+	}
+
+	// Resolve subsystemName aliases (component, resource, module)
+	if _, exists := logEntry["subsystemName"]; !exists {
+		for _, alias := range []string{"component", "resource", "subsystem", "module", "component_name", "subsystem_name", "resource_name"} {
+			if val, exists := logEntry[alias]; exists {
+				logEntry["subsystemName"] = val
+				delete(logEntry, alias) // Remove alias to avoid sending duplicate fields
+				break
+			}
+		}
+	}
+
+	// Validate required fields after alias resolution
+	if name, _ := logEntry["applicationName"].(string); strings.TrimSpace(name) == "" {
+		errs = append(errs, "missing required field: applicationName (or alias: namespace, app, application, service)")
+	}
+	if name, _ := logEntry["subsystemName"].(string); strings.TrimSpace(name) == "" {
+		errs = append(errs, "missing required field: subsystemName (or alias: component, resource, module)")
+	}
+	_, hasText := logEntry["text"]
+	_, hasJSON := logEntry["json"]
+	if !hasText && !hasJSON {
+		errs = append(errs, "missing required field: text or json")
+	}
+
+	if raw, exists := logEntry["severity"]; !exists {
+		errs = append(errs, "missing required field: severity")
+	} else if severity, err := coerceIngestSeverity(raw); err != nil {
+		errs = append(errs, err.Error())
+	} else {
+		logEntry["severity"] = severity
+	}
+
+	if raw, exists := logEntry["timestamp"]; !exists {
+		// Unix timestamp with nanoseconds
+		now := time.Now()
+		logEntry["timestamp"] = float64(now.Unix()) + float64(now.Nanosecond())/1e9
+	} else if ts, err := coerceIngestTimestamp(raw); err != nil {
+		errs = append(errs, err.Error())
+	} else {
+		logEntry["timestamp"] = ts
+	}
+
+	if b, err := json.Marshal(logEntry); err != nil {
+		errs = append(errs, fmt.Sprintf("entry cannot be serialized: %v", err))
+	} else if len(b) > MaxIngestEntrySize {
+		errs = append(errs, fmt.Sprintf("entry is %d bytes, exceeding the %d byte limit", len(b), MaxIngestEntrySize))
+	} else if len(b) > IngestEntryWarnSize {
+		warnings = append(warnings, fmt.Sprintf("entry is %d bytes; large entries are costly to store and search", len(b)))
+	}
+
+	return errs, warnings
+}
+
+// coerceIngestSeverity converts a numeric, numeric-string, or named severity to the 1-6 range
+func coerceIngestSeverity(raw interface{}) (int, error) {
+	var level int
+	switch v := raw.(type) {
+	case float64:
+		if v != float64(int(v)) {
+			return 0, fmt.Errorf("severity must be a whole number, got %v", v)
+		}
+		level = int(v)
+	case int:
+		level = v
+	case string:
+		if named, ok := severityByName[strings.ToLower(strings.TrimSpace(v))]; ok {
+			return named, nil
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return 0, fmt.Errorf("invalid severity '%s' (use 1-6 or debug, verbose, info, warning, error, critical)", v)
+		}
+		level = n
+	default:
+		return 0, fmt.Errorf("invalid severity %v (use 1-6)", raw)
+	}
+	if level < 1 || level > 6 {
+		return 0, fmt.Errorf("severity %d out of range (must be 1-6)", level)
+	}
+	return level, nil
+}
+
+// coerceIngestTimestamp accepts a Unix timestamp in seconds (fractional for sub-second
+// precision) or an RFC 3339 string, and returns Unix seconds
+func coerceIngestTimestamp(raw interface{}) (float64, error) {
+	switch v := raw.(type) {
+	case float64:
+		if v <= 0 {
+			return 0, fmt.Errorf("timestamp must be a positive Unix time in seconds, got %v", v)
+		}
+		return v, nil
+	case string:
+		if f, err := strconv.ParseFloat(v, 64); err == nil && f > 0 {
+			return f, nil
+		}
+		parsed, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return 0, fmt.Errorf("invalid timestamp '%s' (use Unix seconds or RFC 3339)", v)
+		}
+		return float64(parsed.Unix()) + float64(parsed.Nanosecond())/1e9, nil
+	default:
+		return 0, fmt.Errorf("invalid timestamp %v (use Unix seconds or RFC 3339)", raw)
+	}
+}
+
+// countInvalidEntries returns the number of distinct entry indices in logs[i] error messages
+func countInvalidEntries(errs []string) int {
+	seen := make(map[string]bool)
+	for _, e := range errs {
+		if i := strings.Index(e, "]"); i >= 0 {
+			seen[e[:i]] = true
+		}
+	}
+	return len(seen)
+}
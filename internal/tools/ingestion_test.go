@@ -1,9 +1,17 @@
 package tools
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
 )
 
 func TestIngestLogsTool_InputSchema(t *testing.T) {
@@ -17,3 +25,174 @@ func TestIngestLogsTool_InputSchema(t *testing.T) {
 	logsProp := props["logs"].(map[string]interface{})
 	assert.Equal(t, "array", logsProp["type"])
 }
+
+func TestCoerceIngestSeverity(t *testing.T) {
+	tests := []struct {
+		input   interface{}
+		want    int
+		wantErr bool
+	}{
+		{float64(5), 5, false},
+		{"3", 3, false},
+		{"Error", 5, false},
+		{"warn", 4, false},
+		{float64(0), 0, true},
+		{float64(7), 0, true},
+		{float64(2.5), 0, true},
+		{"loud", 0, true},
+		{true, 0, true},
+	}
+	for _, tt := range tests {
+		got, err := coerceIngestSeverity(tt.input)
+		if tt.wantErr {
+			assert.Error(t, err, "input %v", tt.input)
+			continue
+		}
+		assert.NoError(t, err, "input %v", tt.input)
+		assert.Equal(t, tt.want, got)
+	}
+}
+
+func TestCoerceIngestTimestamp(t *testing.T) {
+	ts, err := coerceIngestTimestamp("2024-01-15T10:30:00.5Z")
+	require.NoError(t, err)
+	assert.InDelta(t, 1705314600.5, ts, 0.001)
+
+	ts, err = coerceIngestTimestamp(float64(1699564800.25))
+	require.NoError(t, err)
+	assert.Equal(t, 1699564800.25, ts)
+
+	_, err = coerceIngestTimestamp("yesterday")
+	assert.Error(t, err)
+	_, err = coerceIngestTimestamp(float64(-1))
+	assert.Error(t, err)
+}
+
+func TestIngestLogsTool_Validation(t *testing.T) {
+	validEntry := func() map[string]interface{} {
+		return map[string]interface{}{"applicationName": "api", "subsystemName": "auth", "severity": "error", "text": "boom"}
+	}
+
+	t.Run("reports every invalid entry with its index", func(t *testing.T) {
+		mock := client.NewMockClient()
+		tool := NewIngestLogsTool(mock, zap.NewNop())
+		result, err := tool.Execute(testCtx(mock), map[string]interface{}{
+			"logs": []interface{}{
+				validEntry(),
+				map[string]interface{}{"subsystemName": "auth", "severity": float64(3), "text": "no app"},
+				map[string]interface{}{"applicationName": "api", "subsystemName": "auth", "severity": float64(9), "text": "x", "timestamp": "not-a-time"},
+			},
+		})
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		text := result.Content[0].(*mcp.TextContent).Text
+		assert.Contains(t, text, "2 of 3 log entries are invalid")
+		assert.Contains(t, text, "logs[1]: missing required field: applicationName")
+		assert.Contains(t, text, "logs[2]: severity 9 out of range")
+		assert.Contains(t, text, "logs[2]: invalid timestamp")
+		assert.NotContains(t, text, "logs[0]")
+		assert.Equal(t, 0, mock.RequestCount())
+	})
+
+	t.Run("rejects oversized entries", func(t *testing.T) {
+		mock := client.NewMockClient()
+		tool := NewIngestLogsTool(mock, zap.NewNop())
+		entry := validEntry()
+		entry["text"] = strings.Repeat("x", MaxIngestEntrySize)
+		result, err := tool.Execute(testCtx(mock), map[string]interface{}{"logs": []interface{}{entry}})
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "byte limit")
+	})
+
+	t.Run("accepts json without text and coerces severity", func(t *testing.T) {
+		mock := client.NewMockClient()
+		mock.RespondWith(200, map[string]interface{}{})
+		tool := NewIngestLogsTool(mock, zap.NewNop())
+		result, err := tool.Execute(testCtx(mock), map[string]interface{}{
+			"logs": []interface{}{
+				map[string]interface{}{"app": "api", "component": "auth", "severity": "critical", "json": map[string]interface{}{"k": "v"}},
+			},
+		})
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+		sent := mock.LastRequest().Body.([]map[string]interface{})
+		assert.Equal(t, 6, sent[0]["severity"])
+		assert.Equal(t, "api", sent[0]["applicationName"])
+		assert.Contains(t, sent[0], "timestamp")
+	})
+
+	t.Run("dry run validates without sending", func(t *testing.T) {
+		mock := client.NewMockClient()
+		tool := NewIngestLogsTool(mock, zap.NewNop())
+		entry := validEntry()
+		delete(entry, "text")
+		result, err := tool.Execute(testCtx(mock), map[string]interface{}{
+			"logs":    []interface{}{validEntry(), entry},
+			"dry_run": true,
+		})
+		require.NoError(t, err)
+		text := result.Content[0].(*mcp.TextContent).Text
+		assert.Contains(t, text, "Invalid")
+		assert.Contains(t, text, "logs[1]: missing required field: text or json")
+		assert.Equal(t, 0, mock.RequestCount())
+	})
+}
+
+func TestChunkIngestEntries(t *testing.T) {
+	logs := make([]map[string]interface{}, 25)
+	for i := range logs {
+		logs[i] = map[string]interface{}{"text": strings.Repeat("x", 90)}
+	}
+
+	byCount := chunkIngestEntries(logs, 10, 1<<20)
+	assert.Equal(t, []ingestChunk{{0, 10}, {10, 20}, {20, 25}}, byCount)
+
+	// ~100 bytes per entry, so a 550 byte limit fits 5 entries
+	bySize := chunkIngestEntries(logs, 1000, 550)
+	require.Len(t, bySize, 5)
+	for _, c := range bySize {
+		assert.Equal(t, 5, c.End-c.Start)
+	}
+
+	// An entry larger than the byte limit is still sent on its own
+	oversized := chunkIngestEntries(logs[:2], 1000, 10)
+	assert.Equal(t, []ingestChunk{{0, 1}, {1, 2}}, oversized)
+}
+
+func TestIngestLogsTool_Chunking(t *testing.T) {
+	SetIngestBatchLimits(2, 0)
+	defer SetIngestBatchLimits(0, 0)
+
+	var batches [][]map[string]interface{}
+	mock := client.NewMockClient()
+	mock.DoFunc = func(_ context.Context, req *client.Request) (*client.Response, error) {
+		body := req.Body.([]map[string]interface{})
+		batches = append(batches, body)
+		if body[0]["text"] == "log 2" {
+			return &client.Response{StatusCode: 400, Body: []byte(`{"message":"payload rejected"}`)}, nil
+		}
+		return &client.Response{StatusCode: 200, Body: []byte(`{}`)}, nil
+	}
+
+	logs := make([]interface{}, 5)
+	for i := range logs {
+		logs[i] = map[string]interface{}{"applicationName": "api", "subsystemName": "auth", "severity": float64(3), "text": fmt.Sprintf("log %d", i)}
+	}
+
+	tool := NewIngestLogsTool(mock, zap.NewNop())
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{"logs": logs})
+	require.NoError(t, err)
+	assert.False(t, result.IsError, "partial success should not be reported as a total failure")
+
+	require.Len(t, batches, 3)
+	assert.Equal(t, "log 0", batches[0][0]["text"])
+	assert.Equal(t, "log 4", batches[2][0]["text"])
+
+	text := result.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, "**Succeeded:** 2/3 batches (3 entries)")
+	assert.Contains(t, text, "| 1 | logs[0]–logs[1] | ✅ sent |")
+	assert.Contains(t, text, "| 2 | logs[2]–logs[3] | ❌")
+	assert.Contains(t, text, "payload rejected")
+	assert.Contains(t, text, "| 3 | logs[4]–logs[4] | ✅ sent |")
+}
@@ -0,0 +1,666 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements backup_instance and restore_instance, a disaster-recovery primitive for
+// the instance configuration.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+	"github.com/tareqmamari/cloud-logs-mcp/internal/security"
+)
+
+const (
+	// instanceBackupFormat identifies backup_instance bundles so restore_instance can recognize them
+	instanceBackupFormat = "ibm-cloud-logs-backup/v1"
+	// maxBackupItemsPerType caps the items backed up per resource type
+	maxBackupItemsPerType = 500
+	// maxRestoreItems caps the resources a single restore_instance call processes
+	maxRestoreItems = 1000
+	// maxBackupConcurrency caps in-flight list and get requests during a backup
+	maxBackupConcurrency = 3
+)
+
+// backupRef describes fields of a resource that reference another backed-up resource
+type backupRef struct {
+	Keys   []string // Fields holding the reference, matched at any depth
+	Target string   // Referenced resource type
+}
+
+// backupResource describes a resource type covered by backup_instance
+type backupResource struct {
+	Type       string   // Bundle key
+	Path       string   // API collection path, used to list and to create
+	ListKeys   []string // Response keys that may hold the items
+	FetchEach  bool     // The list holds summaries; each item is fetched from Path/<id>
+	CreateTool string   // Tool whose cache invalidation applies after creating
+	Refs       []backupRef
+}
+
+// backupResources lists the backed-up types in dependency order: a type only references
+// types before it (or itself, e.g. nested folders), so restoring in this order works
+var backupResources = []backupResource{
+	{Type: "outgoing_webhooks", Path: "/v1/outgoing_webhooks", ListKeys: []string{"outgoing_webhooks", "webhooks"}, CreateTool: "create_outgoing_webhook"},
+	{Type: "policies", Path: "/v1/policies", ListKeys: []string{"policies"}, CreateTool: "create_policy"},
+	{Type: "events2metrics", Path: "/v1/events2metrics", ListKeys: []string{"events2metrics"}, CreateTool: "create_e2m"},
+	{Type: "enrichments", Path: "/v1/enrichments", ListKeys: []string{"enrichments"}, CreateTool: "create_enrichment"},
+	{Type: "data_access_rules", Path: "/v1/data_access_rules", ListKeys: []string{"data_access_rules"}, CreateTool: "create_data_access_rule"},
+	{Type: "view_folders", Path: "/v1/view_folders", ListKeys: []string{"view_folders", "folders"}, CreateTool: "create_view_folder"},
+	{Type: "views", Path: "/v1/views", ListKeys: []string{"views"}, CreateTool: "create_view",
+		Refs: []backupRef{{Keys: []string{"folder_id"}, Target: "view_folders"}}},
+	{Type: "dashboard_folders", Path: "/v1/folders", ListKeys: []string{"folders"}, CreateTool: "create_dashboard_folder",
+		Refs: []backupRef{{Keys: []string{"parent_id"}, Target: "dashboard_folders"}}},
+	{Type: "dashboards", Path: "/v1/dashboards", ListKeys: []string{"items", "dashboards"}, FetchEach: true, CreateTool: "create_dashboard",
+		Refs: []backupRef{{Keys: []string{"folder_id"}, Target: "dashboard_folders"}}},
+	{Type: "alert_definitions", Path: "/v1/alert_definitions", ListKeys: []string{"alert_definitions", "alert_defs"}, CreateTool: "create_alert_definition",
+		Refs: []backupRef{{Keys: webhookRefKeys, Target: "outgoing_webhooks"}}},
+	{Type: "alerts", Path: "/v1/alerts", ListKeys: []string{"alerts"}, CreateTool: "create_alert",
+		Refs: []backupRef{{Keys: webhookRefKeys, Target: "outgoing_webhooks"}, {Keys: []string{"alert_definition_id"}, Target: "alert_definitions"}}},
+}
+
+// backupNameKeys are the fields that name a resource, in order of preference; enrichments
+// have no name and are identified by the field they enrich
+var backupNameKeys = []string{"name", "display_name", "field_name"}
+
+// InstanceBackup is the bundle produced by backup_instance. Server-managed fields are removed
+// and references between backed-up resources are replaced by {"$ref": type, "name", "field"}.
+type InstanceBackup struct {
+	Format               string                              `json:"format"`
+	CreatedAt            string                              `json:"created_at"`
+	Resources            map[string][]map[string]interface{} `json:"resources"`
+	Errors               []BackupItemResult                  `json:"errors,omitempty"`
+	UnresolvedReferences []DanglingReference                 `json:"unresolved_references,omitempty"`
+}
+
+// BackupItemResult is the outcome for one resource, or a whole type, in a backup or restore
+type BackupItemResult struct {
+	Type   string `json:"type"`
+	Name   string `json:"name,omitempty"`
+	Status string `json:"status,omitempty"` // created, exists, would_create or failed
+	ID     string `json:"id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// backupResourceTypesSchema is the types property shared by both tools
+func backupResourceTypesSchema(action string) map[string]interface{} {
+	names := make([]string, len(backupResources))
+	for i, r := range backupResources {
+		names[i] = r.Type
+	}
+	return map[string]interface{}{
+		"type":        "array",
+		"items":       map[string]interface{}{"type": "string", "enum": names},
+		"description": fmt.Sprintf("Resource types to %s (default: all)", action),
+	}
+}
+
+// selectBackupResources returns the resource specs named in the types argument, in dependency order
+func selectBackupResources(args map[string]interface{}) ([]backupResource, error) {
+	raw, _ := args["types"].([]interface{})
+	if len(raw) == 0 {
+		return backupResources, nil
+	}
+	wanted := make(map[string]bool, len(raw))
+	for _, v := range raw {
+		name, _ := v.(string)
+		wanted[name] = true
+	}
+	var selected []backupResource
+	for _, r := range backupResources {
+		if wanted[r.Type] {
+			selected = append(selected, r)
+			delete(wanted, r.Type)
+		}
+	}
+	if len(wanted) > 0 {
+		unknown := make([]string, 0, len(wanted))
+		for name := range wanted {
+			unknown = append(unknown, name)
+		}
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown resource types: %s", strings.Join(unknown, ", "))
+	}
+	return selected, nil
+}
+
+// backupItemName returns the name identifying a resource, or "" when it has none
+func backupItemName(item map[string]interface{}) string {
+	for _, key := range backupNameKeys {
+		if name, ok := item[key].(string); ok && name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// BackupInstanceTool exports the instance configuration into one bundle
+type BackupInstanceTool struct{ *BaseTool }
+
+// NewBackupInstanceTool creates a new tool instance
+func NewBackupInstanceTool(c client.Doer, l *zap.Logger) *BackupInstanceTool {
+	return &BackupInstanceTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *BackupInstanceTool) Name() string { return "backup_instance" }
+
+// Annotations returns tool hints for LLMs
+func (t *BackupInstanceTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Backup Instance")
+}
+
+// DefaultTimeout returns the timeout
+func (t *BackupInstanceTool) DefaultTimeout() time.Duration {
+	return DefaultSlowToolTimeout
+}
+
+// Description returns the tool description
+func (t *BackupInstanceTool) Description() string {
+	return fmt.Sprintf(`Back up the instance configuration into one JSON bundle for disaster recovery or copying to another instance.
+
+Covers outgoing webhooks, policies, E2M, enrichments, data access rules, view folders, views,
+dashboard folders, dashboards, alert definitions and alerts (up to %d of each). Server-managed
+fields (ids, timestamps) are removed and references between these resources (e.g. an alert's
+webhook, a view's folder) are recorded by name, so restore_instance can rewire them to the new IDs.
+
+The bundle is written to a file in the export directory and the path is returned with per-type
+counts; types or items that could not be read are listed under errors. The bundle itself is
+not returned: responses mask secrets such as webhook URLs, and a masked bundle cannot be restored.
+
+**Related tools:** restore_instance, diff_backup, export_alert, export_terraform`, maxBackupItemsPerType)
+}
+
+// InputSchema returns the input schema
+func (t *BackupInstanceTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"types": backupResourceTypesSchema("back up"),
+		},
+	}
+}
+
+// Execute executes the tool
+func (t *BackupInstanceTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	resources, err := selectBackupResources(args)
+	if err != nil {
+		return NewToolResultErrorWithSuggestion(err.Error(), "Omit types to back up every supported type."), nil
+	}
+
+	backup, err := t.collectBackup(ctx, resources)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+
+	path, err := writeInstanceBackup(currentExportDir(), backup)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	counts := make(map[string]int, len(backup.Resources))
+	for typ, items := range backup.Resources {
+		counts[typ] = len(items)
+	}
+	response := map[string]interface{}{
+		"file":   path,
+		"counts": counts,
+	}
+	if len(backup.Errors) > 0 {
+		response["errors"] = backup.Errors
+	}
+	if len(backup.UnresolvedReferences) > 0 {
+		response["unresolved_references"] = backup.UnresolvedReferences
+		response["note"] = "Some references point at resources that do not exist or are not backed up; they are kept as raw IDs."
+	}
+	return t.FormatResponse(response)
+}
+
+// collectBackup lists (and, for summary lists, fetches) every selected resource type, then
+// normalizes the items. It fails only when no type could be read.
+func (t *BaseTool) collectBackup(ctx context.Context, resources []backupResource) (*InstanceBackup, error) {
+	backup := &InstanceBackup{
+		Format:    instanceBackupFormat,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		Resources: make(map[string][]map[string]interface{}, len(resources)),
+	}
+
+	reqs := make([]*client.Request, len(resources))
+	for i, r := range resources {
+		reqs[i] = &client.Request{Method: "GET", Path: r.Path}
+	}
+	raw := make(map[string][]map[string]interface{}, len(resources))
+	var firstErr error
+	for i, res := range t.ExecuteConcurrent(ctx, reqs, maxBackupConcurrency) {
+		r := resources[i]
+		if res.Err != nil {
+			backup.Errors = append(backup.Errors, BackupItemResult{Type: r.Type, Status: "failed", Error: res.Err.Error()})
+			if firstErr == nil {
+				firstErr = res.Err
+			}
+			continue
+		}
+		var items []map[string]interface{}
+		for _, item := range listItems(res.Result, r.ListKeys) {
+			if m, ok := item.(map[string]interface{}); ok {
+				items = append(items, m)
+			}
+		}
+		if len(items) > maxBackupItemsPerType {
+			backup.Errors = append(backup.Errors, BackupItemResult{Type: r.Type, Status: "failed",
+				Error: fmt.Sprintf("only the first %d of %d items were backed up", maxBackupItemsPerType, len(items))})
+			items = items[:maxBackupItemsPerType]
+		}
+		if r.FetchEach {
+			items = t.fetchBackupItems(ctx, r, items, backup)
+		}
+		raw[r.Type] = items
+	}
+	if len(raw) == 0 && firstErr != nil {
+		return nil, firstErr
+	}
+
+	// Index every item by the identifiers references may use before the IDs are stripped
+	index := make(map[string]map[string]map[string]string, len(raw))
+	for typ, items := range raw {
+		index[typ] = make(map[string]map[string]string)
+		for _, item := range items {
+			name := backupItemName(item)
+			for _, key := range []string{"id", "external_id"} {
+				if v, ok := item[key]; ok && v != nil && name != "" {
+					index[typ][fmt.Sprint(v)] = map[string]string{"name": name, "field": key}
+				}
+			}
+		}
+	}
+
+	for _, r := range resources {
+		items, ok := raw[r.Type]
+		if !ok {
+			continue
+		}
+		normalized := make([]map[string]interface{}, 0, len(items))
+		for _, item := range items {
+			id := fmt.Sprint(item["id"])
+			out := stripServerManaged(item)
+			for _, ref := range r.Refs {
+				targets, backedUp := index[ref.Target]
+				rewriteReferences(out, ref.Keys, "", func(field string, value interface{}) interface{} {
+					if target, ok := targets[referenceValue(value)]; ok {
+						return map[string]interface{}{"$ref": ref.Target, "name": target["name"], "field": target["field"]}
+					}
+					if backedUp || ref.Target == r.Type {
+						backup.UnresolvedReferences = append(backup.UnresolvedReferences, DanglingReference{
+							ResourceID: id, ResourceName: backupItemName(item), Field: r.Type + "." + field, MissingID: referenceValue(value)})
+					}
+					return value
+				})
+			}
+			normalized = append(normalized, out)
+		}
+		backup.Resources[r.Type] = normalized
+	}
+	return backup, nil
+}
+
+// fetchBackupItems replaces list summaries with the full items, recording items that fail
+func (t *BaseTool) fetchBackupItems(ctx context.Context, r backupResource, summaries []map[string]interface{}, backup *InstanceBackup) []map[string]interface{} {
+	reqs := make([]*client.Request, 0, len(summaries))
+	for _, s := range summaries {
+		reqs = append(reqs, &client.Request{Method: "GET", Path: fmt.Sprintf("%s/%v", r.Path, s["id"])})
+	}
+	var items []map[string]interface{}
+	for i, res := range t.ExecuteConcurrent(ctx, reqs, maxBackupConcurrency) {
+		if res.Err != nil {
+			backup.Errors = append(backup.Errors, BackupItemResult{Type: r.Type, Name: backupItemName(summaries[i]), Status: "failed", Error: res.Err.Error()})
+			continue
+		}
+		items = append(items, res.Result)
+	}
+	return items
+}
+
+// rewriteReferences replaces every non-empty refKeys value found at any depth of value with
+// fn's result; fn receives the field path and the current value
+func rewriteReferences(value interface{}, refKeys []string, path string, fn func(field string, value interface{}) interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if isRefKey(key, refKeys) {
+				if referenceValue(child) != "" {
+					v[key] = fn(joinPath(path, key), child)
+				}
+				continue
+			}
+			rewriteReferences(child, refKeys, joinPath(path, key), fn)
+		}
+	case []interface{}:
+		for i, child := range v {
+			rewriteReferences(child, refKeys, fmt.Sprintf("%s[%d]", path, i), fn)
+		}
+	}
+}
+
+// writeInstanceBackup writes the bundle as indented JSON and returns the file path
+func writeInstanceBackup(dir string, backup *InstanceBackup) (string, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create export directory: %w", err)
+	}
+	data, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode backup: %w", err)
+	}
+	path := filepath.Join(dir, fmt.Sprintf("instance-backup-%s.json", time.Now().UTC().Format("20060102T150405Z")))
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write backup file: %w", err)
+	}
+	return path, nil
+}
+
+// RestoreInstanceTool recreates the configuration in a backup_instance bundle
+type RestoreInstanceTool struct{ *BaseTool }
+
+// NewRestoreInstanceTool creates a new tool instance
+func NewRestoreInstanceTool(c client.Doer, l *zap.Logger) *RestoreInstanceTool {
+	return &RestoreInstanceTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *RestoreInstanceTool) Name() string { return "restore_instance" }
+
+// Annotations returns tool hints for LLMs
+func (t *RestoreInstanceTool) Annotations() *mcp.ToolAnnotations {
+	return CreateAnnotations("Restore Instance")
+}
+
+// DefaultTimeout returns the timeout
+func (t *RestoreInstanceTool) DefaultTimeout() time.Duration {
+	return DefaultSlowToolTimeout
+}
+
+// Description returns the tool description
+func (t *RestoreInstanceTool) Description() string {
+	return fmt.Sprintf(`Recreate the configuration in a backup_instance bundle, e.g. after losing an instance or to seed a new one.
+
+Resources are created in dependency order (webhooks before alert definitions and alerts, folders
+before views and dashboards) and references recorded by name are rewired to the IDs of the
+created or existing resources. A resource whose name already exists is not created again; it is
+reported as "exists" and used for references. Each resource gets its own result (created, exists,
+would_create or failed with the error), so one failure does not stop the rest. At most %d
+resources are processed per call; use types to restore in parts.
+
+Always run with dry_run first to review what would be created.
+
+**Related tools:** backup_instance, restore_alert, check_references`, maxRestoreItems)
+}
+
+// InputSchema returns the input schema
+func (t *RestoreInstanceTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"file": map[string]interface{}{
+				"type":        "string",
+				"description": "Path of a bundle written by backup_instance (must be in the export directory)",
+			},
+			"backup": map[string]interface{}{
+				"type":        "object",
+				"description": "The bundle itself, e.g. the contents of a backup file copied from another server",
+			},
+			"types":   backupResourceTypesSchema("restore"),
+			"dry_run": map[string]interface{}{"type": "boolean", "description": "Resolve names and references and report what would be created, without creating anything", "default": false},
+		},
+	}
+}
+
+// Execute executes the tool
+func (t *RestoreInstanceTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	backup, err := loadInstanceBackup(args)
+	if err != nil {
+		return NewToolResultErrorWithSuggestion(err.Error(), "Pass file with the path returned by backup_instance, or backup with the bundle itself."), nil
+	}
+	resources, err := selectBackupResources(args)
+	if err != nil {
+		return NewToolResultErrorWithSuggestion(err.Error(), "Omit types to restore every type in the bundle."), nil
+	}
+	total := 0
+	for _, r := range resources {
+		total += len(backup.Resources[r.Type])
+	}
+	if total > maxRestoreItems {
+		return NewToolResultErrorWithSuggestion(
+			fmt.Sprintf("The bundle holds %d resources of the selected types (maximum %d per call)", total, maxRestoreItems),
+			"Restore in parts with the types argument, in the order the types are listed."), nil
+	}
+	dryRun, _ := GetBoolParam(args, "dry_run", false)
+
+	r := &instanceRestore{tool: t, dryRun: dryRun, resolved: make(map[string]map[string]map[string]interface{})}
+	for _, spec := range resources {
+		if items := backup.Resources[spec.Type]; len(items) > 0 {
+			r.restoreType(ctx, spec, items)
+		}
+	}
+
+	counts := map[string]int{}
+	for _, res := range r.results {
+		counts[res.Status]++
+	}
+	if !dryRun {
+		cacheHelper := GetCacheHelperFromContext(ctx)
+		for _, spec := range resources {
+			if r.createdTypes[spec.Type] {
+				cacheHelper.InvalidateRelated(spec.CreateTool)
+			}
+		}
+	}
+	response := map[string]interface{}{
+		"dry_run": dryRun,
+		"total":   len(r.results),
+		"counts":  counts,
+		"results": r.results,
+	}
+	if dryRun {
+		return MarkDryRun(t.FormatResponse(response))
+	}
+	return t.FormatResponse(response)
+}
+
+// loadInstanceBackup reads the bundle from the backup or file argument
+func loadInstanceBackup(args map[string]interface{}) (*InstanceBackup, error) {
+	var data []byte
+	if bundle, ok := args["backup"].(map[string]interface{}); ok {
+		var err error
+		if data, err = json.Marshal(bundle); err != nil {
+			return nil, fmt.Errorf("invalid backup: %w", err)
+		}
+	} else if file, _ := GetStringParam(args, "file", false); file != "" {
+		// Only bundles in the export directory can be read, so callers cannot load arbitrary files
+		dir, err := filepath.Abs(currentExportDir())
+		if err != nil {
+			return nil, err
+		}
+		path, err := filepath.Abs(file)
+		if err != nil {
+			return nil, err
+		}
+		if rel, err := filepath.Rel(dir, path); err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			return nil, fmt.Errorf("backup file must be in the export directory %s", dir)
+		}
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, fmt.Errorf("failed to read backup file: %w", err)
+		}
+	} else {
+		return nil, fmt.Errorf("either file or backup is required")
+	}
+
+	// Secrets masked in a tool response would be restored as the placeholder, e.g. broken webhook URLs
+	if strings.Contains(string(data), security.RedactedPlaceholder) {
+		return nil, fmt.Errorf("the bundle contains redacted secrets (%s) and cannot be used; pass the backup file instead", security.RedactedPlaceholder)
+	}
+
+	var backup InstanceBackup
+	if err := json.Unmarshal(data, &backup); err != nil {
+		return nil, fmt.Errorf("invalid backup: %w", err)
+	}
+	if backup.Format != instanceBackupFormat {
+		return nil, fmt.Errorf("unsupported backup format %q (expected %s)", backup.Format, instanceBackupFormat)
+	}
+	return &backup, nil
+}
+
+// instanceRestore tracks one restore_instance run
+type instanceRestore struct {
+	tool   *RestoreInstanceTool
+	dryRun bool
+	// resolved maps type -> name -> the created or existing resource, for rewiring references
+	resolved     map[string]map[string]map[string]interface{}
+	results      []BackupItemResult
+	createdTypes map[string]bool
+}
+
+// missingRef is a reference that cannot be resolved yet
+type missingRef struct {
+	Type string
+	Name string
+}
+
+// restoreType restores the items of one type. Items referencing another item of the same type
+// (nested folders) are deferred until that item is restored; items that still cannot be
+// resolved when a pass makes no progress fail.
+func (r *instanceRestore) restoreType(ctx context.Context, spec backupResource, items []map[string]interface{}) {
+	res, err := r.tool.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: spec.Path})
+	if err != nil {
+		for _, item := range items {
+			r.results = append(r.results, BackupItemResult{Type: spec.Type, Name: backupItemName(item), Status: "failed",
+				Error: "could not list existing " + spec.Type + ": " + err.Error()})
+		}
+		return
+	}
+	existing := make(map[string]map[string]interface{})
+	for _, item := range listItems(res, spec.ListKeys) {
+		if m, ok := item.(map[string]interface{}); ok && backupItemName(m) != "" {
+			existing[backupItemName(m)] = m
+		}
+	}
+	if r.resolved[spec.Type] == nil {
+		r.resolved[spec.Type] = make(map[string]map[string]interface{})
+	}
+
+	pending := make([]int, len(items))
+	for i := range items {
+		pending[i] = i
+	}
+	for progress := true; progress && len(pending) > 0; {
+		progress = false
+		waiting := make(map[string]bool, len(pending))
+		for _, i := range pending {
+			waiting[backupItemName(items[i])] = true
+		}
+		var deferred []int
+		for _, i := range pending {
+			name := backupItemName(items[i])
+			delete(waiting, name)
+			if found, ok := existing[name]; ok && name != "" {
+				r.resolved[spec.Type][name] = found
+				r.results = append(r.results, BackupItemResult{Type: spec.Type, Name: name, Status: "exists", ID: referenceValue(found["id"])})
+				progress = true
+				continue
+			}
+
+			body, missing := r.resolveRefs(deepCopyMap(items[i]))
+			if missing != nil {
+				if missing.Type == spec.Type && waiting[missing.Name] {
+					deferred = append(deferred, i)
+					waiting[name] = true
+					continue
+				}
+				r.results = append(r.results, BackupItemResult{Type: spec.Type, Name: name, Status: "failed",
+					Error: fmt.Sprintf("references %s %q, which was not restored", missing.Type, missing.Name)})
+				progress = true
+				continue
+			}
+			r.create(ctx, spec, name, body.(map[string]interface{}))
+			progress = true
+		}
+		pending = deferred
+	}
+	for _, i := range pending {
+		r.results = append(r.results, BackupItemResult{Type: spec.Type, Name: backupItemName(items[i]), Status: "failed",
+			Error: "circular reference between " + spec.Type})
+	}
+}
+
+// create creates one resource, or records that it would be created in a dry run
+func (r *instanceRestore) create(ctx context.Context, spec backupResource, name string, body map[string]interface{}) {
+	result := BackupItemResult{Type: spec.Type, Name: name}
+	if r.dryRun {
+		result.Status = "would_create"
+		r.resolved[spec.Type][name] = map[string]interface{}{}
+		r.results = append(r.results, result)
+		return
+	}
+	res, err := r.tool.ExecuteRequest(ctx, &client.Request{Method: "POST", Path: spec.Path, Body: body})
+	if err != nil {
+		result.Status = "failed"
+		result.Error = strings.TrimSpace(err.Error())
+		r.results = append(r.results, result)
+		return
+	}
+	result.Status = "created"
+	result.ID = referenceValue(res["id"])
+	if name != "" {
+		r.resolved[spec.Type][name] = res
+	}
+	if r.createdTypes == nil {
+		r.createdTypes = make(map[string]bool)
+	}
+	r.createdTypes[spec.Type] = true
+	r.results = append(r.results, result)
+}
+
+// resolveRefs replaces the {"$ref"} objects in value with the referenced resource's identifier.
+// In a dry run, references to resources that would be created get a placeholder.
+func (r *instanceRestore) resolveRefs(value interface{}) (interface{}, *missingRef) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if typ, ok := v["$ref"].(string); ok {
+			name, _ := v["name"].(string)
+			field, _ := v["field"].(string)
+			target, ok := r.resolved[typ][name]
+			if !ok {
+				return nil, &missingRef{Type: typ, Name: name}
+			}
+			if id, ok := target[field]; ok && id != nil {
+				return id, nil
+			}
+			if r.dryRun {
+				return fmt.Sprintf("<new %s %q>", typ, name), nil
+			}
+			return nil, &missingRef{Type: typ, Name: name}
+		}
+		for key, child := range v {
+			resolved, missing := r.resolveRefs(child)
+			if missing != nil {
+				return nil, missing
+			}
+			v[key] = resolved
+		}
+	case []interface{}:
+		for i, child := range v {
+			resolved, missing := r.resolveRefs(child)
+			if missing != nil {
+				return nil, missing
+			}
+			v[i] = resolved
+		}
+	}
+	return value, nil
+}
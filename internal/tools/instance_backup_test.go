@@ -0,0 +1,162 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+	"github.com/tareqmamari/cloud-logs-mcp/internal/security"
+)
+
+// instanceMock serves GETs from bodies by path and assigns IDs to created resources
+type instanceMock struct {
+	*client.MockClient
+	mu      sync.Mutex
+	created []*client.Request
+}
+
+func newInstanceMock(t *testing.T, bodies map[string]interface{}) *instanceMock {
+	m := &instanceMock{MockClient: client.NewMockClient()}
+	m.DoFunc = func(_ context.Context, req *client.Request) (*client.Response, error) {
+		if req.Method == "POST" {
+			m.mu.Lock()
+			m.created = append(m.created, req)
+			id := len(m.created)
+			m.mu.Unlock()
+			body := deepCopyMap(req.Body.(map[string]interface{}))
+			body["id"] = strings.TrimPrefix(req.Path, "/v1/") + "-new-" + string(rune('0'+id))
+			if req.Path == "/v1/outgoing_webhooks" {
+				body["external_id"] = 900.0 + float64(id)
+			}
+			data, _ := json.Marshal(body)
+			return &client.Response{StatusCode: 200, Body: data}, nil
+		}
+		body, ok := bodies[req.Path]
+		if !ok {
+			return &client.Response{StatusCode: 200, Body: []byte(`{}`)}, nil
+		}
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		return &client.Response{StatusCode: 200, Body: data}, nil
+	}
+	return m
+}
+
+func TestBackupAndRestoreInstance(t *testing.T) {
+	dir := t.TempDir()
+	SetExportDir(dir)
+	t.Cleanup(func() { SetExportDir("") })
+
+	source := newInstanceMock(t, map[string]interface{}{
+		"/v1/outgoing_webhooks": map[string]interface{}{"outgoing_webhooks": []interface{}{
+			map[string]interface{}{"id": "wh-1", "external_id": 7.0, "name": "pagerduty", "type": "pager_duty"},
+		}},
+		"/v1/folders": map[string]interface{}{"folders": []interface{}{
+			map[string]interface{}{"id": "f-child", "name": "payments", "parent_id": "f-root"},
+			map[string]interface{}{"id": "f-root", "name": "teams"},
+		}},
+		"/v1/dashboards":     map[string]interface{}{"items": []interface{}{map[string]interface{}{"id": "d-1", "name": "Checkout"}}},
+		"/v1/dashboards/d-1": map[string]interface{}{"id": "d-1", "name": "Checkout", "folder_id": "f-child", "layout": map[string]interface{}{}},
+		"/v1/alerts": map[string]interface{}{"alerts": []interface{}{
+			map[string]interface{}{"id": "a-1", "name": "High errors", "created_at": "2024-01-01T00:00:00Z",
+				"notification_groups": []interface{}{map[string]interface{}{"integration_id": 7.0}}},
+			map[string]interface{}{"id": "a-2", "name": "Orphan", "webhook_id": "wh-gone"},
+		}},
+	})
+
+	result := mustExecute(t, NewBackupInstanceTool(source, zap.NewNop()), map[string]interface{}{})
+	response := decodeFirstJSON(t, result)
+	file := response["file"].(string)
+	assert.Equal(t, dir, filepath.Dir(file))
+	counts := response["counts"].(map[string]interface{})
+	assert.Equal(t, 2.0, counts["alerts"])
+	assert.Equal(t, 1.0, counts["dashboards"])
+	assert.Len(t, response["unresolved_references"], 1, "the orphan alert's webhook is kept as a raw ID")
+
+	assert.NotContains(t, response, "backup", "the bundle is only written to the file")
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	var bundle map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &bundle))
+	alerts := bundle["resources"].(map[string]interface{})["alerts"].([]interface{})
+	alert := alerts[0].(map[string]interface{})
+	assert.NotContains(t, alert, "id")
+	assert.NotContains(t, alert, "created_at")
+	assert.Equal(t, map[string]interface{}{"$ref": "outgoing_webhooks", "name": "pagerduty", "field": "external_id"},
+		alert["notification_groups"].([]interface{})[0].(map[string]interface{})["integration_id"])
+
+	// Restore into an instance that already has the alert named "Orphan"
+	target := newInstanceMock(t, map[string]interface{}{
+		"/v1/alerts": map[string]interface{}{"alerts": []interface{}{map[string]interface{}{"id": "existing", "name": "Orphan"}}},
+	})
+	dryRun := decodeFirstJSON(t, mustExecute(t, NewRestoreInstanceTool(target, zap.NewNop()), map[string]interface{}{"file": file, "dry_run": true}))
+	assert.Equal(t, map[string]interface{}{"would_create": 5.0, "exists": 1.0}, dryRun["counts"])
+	assert.Empty(t, target.created)
+
+	restored := decodeFirstJSON(t, mustExecute(t, NewRestoreInstanceTool(target, zap.NewNop()), map[string]interface{}{"file": file}))
+	assert.Equal(t, map[string]interface{}{"created": 5.0, "exists": 1.0}, restored["counts"])
+
+	var order []string
+	for _, req := range target.created {
+		body := req.Body.(map[string]interface{})
+		order = append(order, body["name"].(string))
+		switch body["name"] {
+		case "payments":
+			assert.Equal(t, "folders-new-2", body["parent_id"], "the parent folder is created first")
+		case "Checkout":
+			assert.Equal(t, "folders-new-3", body["folder_id"])
+		case "High errors":
+			assert.Equal(t, 901.0, body["notification_groups"].([]interface{})[0].(map[string]interface{})["integration_id"])
+		}
+	}
+	assert.Equal(t, []string{"pagerduty", "teams", "payments", "Checkout", "High errors"}, order)
+}
+
+func TestRestoreInstance_Errors(t *testing.T) {
+	SetExportDir(t.TempDir())
+	t.Cleanup(func() { SetExportDir("") })
+	mock := newInstanceMock(t, nil)
+	tool := NewRestoreInstanceTool(mock, zap.NewNop())
+
+	result := mustExecute(t, tool, map[string]interface{}{"file": "/etc/passwd"})
+	require.True(t, result.IsError)
+	assert.Contains(t, resultText(result), "must be in the export directory")
+
+	result = mustExecute(t, tool, map[string]interface{}{"backup": map[string]interface{}{"format": "other"}})
+	assert.Contains(t, resultText(result), "unsupported backup format")
+
+	result = mustExecute(t, tool, map[string]interface{}{"backup": map[string]interface{}{
+		"format": instanceBackupFormat,
+		"resources": map[string]interface{}{"outgoing_webhooks": []interface{}{
+			map[string]interface{}{"name": "slack", "slack": map[string]interface{}{"url": "https://hooks.slack.com/services/" + security.RedactedPlaceholder}},
+		}},
+	}})
+	require.True(t, result.IsError)
+	assert.Contains(t, resultText(result), "redacted secrets")
+	assert.Empty(t, mock.created)
+
+	bundle := map[string]interface{}{
+		"format": instanceBackupFormat,
+		"resources": map[string]interface{}{
+			"views": []interface{}{map[string]interface{}{"name": "errors view", "folder_id": map[string]interface{}{"$ref": "view_folders", "name": "gone", "field": "id"}}},
+		},
+	}
+	response := decodeFirstJSON(t, mustExecute(t, tool, map[string]interface{}{"backup": bundle}))
+	results := response["results"].([]interface{})
+	require.Len(t, results, 1)
+	assert.Equal(t, "failed", results[0].(map[string]interface{})["status"])
+	assert.Contains(t, results[0].(map[string]interface{})["error"], `references view_folders "gone"`)
+	assert.Empty(t, mock.created)
+
+	result = mustExecute(t, tool, map[string]interface{}{"backup": bundle, "types": []interface{}{"widgets"}})
+	assert.Contains(t, resultText(result), "unknown resource types: widgets")
+}
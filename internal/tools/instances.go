@@ -0,0 +1,219 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// InstanceParam is the tool argument that selects a configured instance
+const InstanceParam = "instance"
+
+var (
+	instancesMu     sync.RWMutex
+	instanceClients map[string]client.Doer
+	primaryInstance string
+)
+
+// SetInstanceClients records the API client of each configured instance, keyed by name.
+// Calls without an instance argument use the primary instance.
+func SetInstanceClients(primary string, clients map[string]client.Doer) {
+	instancesMu.Lock()
+	defer instancesMu.Unlock()
+	primaryInstance = primary
+	instanceClients = make(map[string]client.Doer, len(clients))
+	for name, c := range clients {
+		instanceClients[name] = c
+	}
+}
+
+// instanceNames returns the configured instance names, primary first and the rest sorted
+func instanceNames() []string {
+	instancesMu.RLock()
+	defer instancesMu.RUnlock()
+	names := make([]string, 0, len(instanceClients))
+	for name := range instanceClients {
+		if name != primaryInstance {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if _, ok := instanceClients[primaryInstance]; ok {
+		names = append([]string{primaryInstance}, names...)
+	}
+	return names
+}
+
+// ResolveInstanceClient removes the instance argument from args and returns the client and
+// name of the named instance. Without an instance argument, fallback and the primary
+// instance's name are returned.
+func ResolveInstanceClient(args map[string]interface{}, fallback client.Doer) (client.Doer, string, error) {
+	raw, ok := args[InstanceParam]
+	if !ok {
+		return fallback, currentPrimaryInstance(), nil
+	}
+	delete(args, InstanceParam)
+
+	name, ok := raw.(string)
+	if !ok {
+		return nil, "", fmt.Errorf("%s must be a string", InstanceParam)
+	}
+	if name == "" {
+		return fallback, currentPrimaryInstance(), nil
+	}
+
+	c, found := lookupInstanceClient(name)
+	if !found {
+		return nil, "", fmt.Errorf("unknown instance '%s'. Configured instances: %s", name, strings.Join(instanceNames(), ", "))
+	}
+	return c, name, nil
+}
+
+// lookupInstanceClient returns the client of a configured instance
+func lookupInstanceClient(name string) (client.Doer, bool) {
+	instancesMu.RLock()
+	defer instancesMu.RUnlock()
+	c, ok := instanceClients[name]
+	return c, ok
+}
+
+// currentPrimaryInstance returns the name of the primary instance, or "" when instances
+// were never configured
+func currentPrimaryInstance() string {
+	instancesMu.RLock()
+	defer instancesMu.RUnlock()
+	return primaryInstance
+}
+
+// instanceScope returns the identifier scoping per-instance state (cache entries) of the
+// instance a call was routed to. The primary instance keeps the session's instance ID, so
+// state written without a routed context stays shared with it.
+func instanceScope(ctx context.Context, instanceID string) string {
+	name := GetInstanceNameFromContext(ctx)
+	if name == "" || name == currentPrimaryInstance() {
+		return instanceID
+	}
+	return instanceID + "@" + name
+}
+
+// WithInstanceParam adds the optional instance parameter to a tool's input schema when more
+// than one instance is configured
+func WithInstanceParam(schema interface{}) interface{} {
+	names := instanceNames()
+	m, ok := schema.(map[string]interface{})
+	if !ok || len(names) < 2 {
+		return schema
+	}
+	props, _ := m["properties"].(map[string]interface{})
+	if _, exists := props[InstanceParam]; exists {
+		return schema
+	}
+
+	newProps := make(map[string]interface{}, len(props)+1)
+	for k, v := range props {
+		newProps[k] = v
+	}
+	newProps[InstanceParam] = map[string]interface{}{
+		"type":        "string",
+		"description": fmt.Sprintf("Instance to run against (default: %s). Use list_instances to see them.", names[0]),
+		"enum":        names,
+	}
+
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	out["properties"] = newProps
+	return out
+}
+
+// ListInstancesTool lists the IBM Cloud Logs instances this server can route to
+type ListInstancesTool struct{ *BaseTool }
+
+// NewListInstancesTool creates a new tool instance
+func NewListInstancesTool(c client.Doer, l *zap.Logger) *ListInstancesTool {
+	return &ListInstancesTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *ListInstancesTool) Name() string { return "list_instances" }
+
+// Annotations returns tool hints for LLMs
+func (t *ListInstancesTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("List Instances")
+}
+
+// Description returns the tool description
+func (t *ListInstancesTool) Description() string {
+	return `List the IBM Cloud Logs instances this server is configured for.
+
+When several instances are configured, every tool accepts an optional "instance" parameter
+naming the instance to run against; calls without it use the primary instance.
+No API call is made; the information comes from the server configuration.
+
+**Related tools:** whoami, health_check`
+}
+
+// InputSchema returns the input schema
+func (t *ListInstancesTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{},
+	}
+}
+
+// Execute executes the tool
+func (t *ListInstancesTool) Execute(ctx context.Context, _ map[string]interface{}) (*mcp.CallToolResult, error) {
+	names := instanceNames()
+	if len(names) == 0 {
+		// Instances were never configured (tests, embedded use): report the current client
+		apiClient, err := t.GetClient(ctx)
+		if err != nil {
+			return NewToolResultErrorFromErr(err), nil
+		}
+		info := apiClient.GetInstanceInfo()
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: formatInstances([]string{info.InstanceName}, map[string]client.InstanceInfo{info.InstanceName: info})}},
+		}, nil
+	}
+
+	infos := make(map[string]client.InstanceInfo, len(names))
+	instancesMu.RLock()
+	for _, name := range names {
+		infos[name] = instanceClients[name].GetInstanceInfo()
+	}
+	instancesMu.RUnlock()
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: formatInstances(names, infos)}},
+	}, nil
+}
+
+// formatInstances renders the instances as a markdown table; the first name is the primary
+func formatInstances(names []string, infos map[string]client.InstanceInfo) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## Instances (%d)\n\n", len(names))
+	sb.WriteString("| Name | Region | Instance ID | Service URL |\n|------|--------|-------------|-------------|\n")
+	for i, name := range names {
+		info := infos[name]
+		label := name
+		if label == "" {
+			label = "(unnamed)"
+		}
+		if i == 0 {
+			label += " (primary)"
+		}
+		fmt.Fprintf(&sb, "| %s | %s | %s | %s |\n", label, info.Region, info.InstanceID, info.ServiceURL)
+	}
+	if len(names) > 1 {
+		sb.WriteString("\n💡 Pass `instance` to any tool to run it against a non-primary instance.\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,131 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// setTestInstances configures prod (primary) and staging instances for the test
+func setTestInstances(t *testing.T) (prod, staging *client.MockClient) {
+	t.Helper()
+	prod, staging = client.NewMockClient(), client.NewMockClient()
+	prod.Instance = client.InstanceInfo{ServiceURL: "https://prod.api.us-south.logs.cloud.ibm.com", Region: "us-south", InstanceName: "prod"}
+	staging.Instance = client.InstanceInfo{ServiceURL: "https://stage.api.eu-de.logs.cloud.ibm.com", Region: "eu-de", InstanceName: "staging"}
+	SetInstanceClients("prod", map[string]client.Doer{"prod": prod, "staging": staging})
+	t.Cleanup(func() { SetInstanceClients("", nil) })
+	return prod, staging
+}
+
+func TestResolveInstanceClient(t *testing.T) {
+	prod, staging := setTestInstances(t)
+
+	args := map[string]interface{}{"instance": "staging", "limit": 5}
+	c, name, err := ResolveInstanceClient(args, prod)
+	if err != nil || c != staging || name != "staging" {
+		t.Fatalf("expected staging client, got %v %q (err %v)", c, name, err)
+	}
+	if _, ok := args["instance"]; ok {
+		t.Error("instance argument should be removed before the tool runs")
+	}
+
+	c, name, err = ResolveInstanceClient(map[string]interface{}{}, prod)
+	if err != nil || c != prod || name != "prod" {
+		t.Errorf("expected fallback client without an instance argument, got %v %q (err %v)", c, name, err)
+	}
+
+	_, _, err = ResolveInstanceClient(map[string]interface{}{"instance": "qa"}, prod)
+	if err == nil || !strings.Contains(err.Error(), "Configured instances: prod, staging") {
+		t.Errorf("expected unknown instance error listing instances, got %v", err)
+	}
+}
+
+func TestResolveInstanceClient_RoutesRequests(t *testing.T) {
+	prod, staging := setTestInstances(t)
+
+	args := map[string]interface{}{"instance": "staging"}
+	c, _, err := ResolveInstanceClient(args, prod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tool := NewListPoliciesTool(prod, zap.NewNop())
+	if _, err := tool.Execute(WithClient(context.Background(), c), args); err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	if staging.RequestCount() != 1 || prod.RequestCount() != 0 {
+		t.Errorf("request went to the wrong instance: prod=%d staging=%d", prod.RequestCount(), staging.RequestCount())
+	}
+}
+
+func TestGetCacheHelperFromContext_PerInstance(t *testing.T) {
+	setTestInstances(t)
+	ctx := WithSession(context.Background(), NewSessionContext("user-cache", "inst-cache"))
+	prodCache := GetCacheHelperFromContext(WithInstanceName(ctx, "prod"))
+	stagingCache := GetCacheHelperFromContext(WithInstanceName(ctx, "staging"))
+	t.Cleanup(func() { prodCache.Clear(); stagingCache.Clear() })
+
+	stagingCache.Set("list_alerts", "all", "staging alerts")
+	if _, ok := prodCache.Get("list_alerts", "all"); ok {
+		t.Error("the primary instance must not see another instance's cache entries")
+	}
+	if _, ok := GetCacheHelperFromContext(ctx).Get("list_alerts", "all"); ok {
+		t.Error("an unrouted call uses the primary instance's cache")
+	}
+
+	prodCache.Set("list_alerts", "all", "prod alerts")
+	stagingCache.InvalidateRelated("create_alert")
+	if _, ok := stagingCache.Get("list_alerts", "all"); ok {
+		t.Error("invalidation should drop the staging entry")
+	}
+	if v, ok := prodCache.Get("list_alerts", "all"); !ok || v != "prod alerts" {
+		t.Errorf("invalidation on staging must keep the prod entry, got %v %v", v, ok)
+	}
+}
+
+func TestWithInstanceParam(t *testing.T) {
+	schema := map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+
+	SetInstanceClients("prod", map[string]client.Doer{"prod": client.NewMockClient()})
+	t.Cleanup(func() { SetInstanceClients("", nil) })
+	if props := WithInstanceParam(schema).(map[string]interface{})["properties"].(map[string]interface{}); len(props) != 0 {
+		t.Error("instance parameter should only be added when several instances are configured")
+	}
+
+	setTestInstances(t)
+	props := WithInstanceParam(schema).(map[string]interface{})["properties"].(map[string]interface{})
+	param, ok := props["instance"].(map[string]interface{})
+	if !ok {
+		t.Fatal("expected instance parameter")
+	}
+	if enum := param["enum"].([]string); len(enum) != 2 || enum[0] != "prod" {
+		t.Errorf("enum = %v, want primary first", enum)
+	}
+	if len(schema["properties"].(map[string]interface{})) != 0 {
+		t.Error("original schema should not be modified")
+	}
+}
+
+func TestListInstancesTool_Execute(t *testing.T) {
+	prod, _ := setTestInstances(t)
+
+	tool := NewListInstancesTool(prod, zap.NewNop())
+	result, err := tool.Execute(context.Background(), nil)
+	if err != nil || result.IsError {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	for _, want := range []string{
+		"## Instances (2)",
+		"| prod (primary) | us-south |",
+		"| staging | eu-de |",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("result missing %q:\n%s", want, text)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// listSummarySpec describes how a list tool's items are condensed for summary_only
+type listSummarySpec struct {
+	Title    string   // Heading for the summary
+	ListKeys []string // Response keys that may hold the list; the first present one is used
+	KeyField string   // Attribute shown next to each item's name and ID
+}
+
+// listSummarySpecs maps list tools to their summary_only layout
+var listSummarySpecs = map[string]listSummarySpec{
+	"list_alerts":            {Title: "Alerts", ListKeys: []string{"alerts"}, KeyField: "severity"},
+	"list_alert_definitions": {Title: "Alert Definitions", ListKeys: []string{"alert_definitions"}, KeyField: "priority"},
+	"list_outgoing_webhooks": {Title: "Outgoing Webhooks", ListKeys: []string{"outgoing_webhooks"}, KeyField: "type"},
+	"list_policies":          {Title: "Policies", ListKeys: []string{"policies"}, KeyField: "priority"},
+	"list_e2m":               {Title: "Events to Metrics", ListKeys: []string{"events2metrics"}, KeyField: "type"},
+	"list_data_access_rules": {Title: "Data Access Rules", ListKeys: []string{"data_access_rules"}, KeyField: "default_expression"},
+	"list_enrichments":       {Title: "Enrichments", ListKeys: []string{"enrichments"}, KeyField: "field_name"},
+	"list_data_sets":         {Title: "Data Sets", ListKeys: []string{"custom_enrichments", "data_sets"}, KeyField: "description"},
+	"list_views":             {Title: "Views", ListKeys: []string{"views"}, KeyField: "folder_id"},
+	"list_view_folders":      {Title: "View Folders", ListKeys: []string{"view_folders"}},
+	"list_dashboards":        {Title: "Dashboards", ListKeys: []string{"items", "dashboards"}, KeyField: "folder_id"},
+	"list_dashboard_folders": {Title: "Dashboard Folders", ListKeys: []string{"folders"}, KeyField: "parent_id"},
+	"list_rule_groups":       {Title: "Rule Groups", ListKeys: []string{"rulegroups", "rule_groups"}, KeyField: "enabled"},
+	"list_streams":           {Title: "Streams", ListKeys: []string{"streams"}, KeyField: "compression_type"},
+}
+
+// SummaryOnlyProperty returns the summary_only schema property shared by list tools
+func SummaryOnlyProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "boolean",
+		"default":     false,
+		"description": "Return only ID, name and one key attribute per item plus the total count - a cheap index for choosing which item to fetch in full",
+	}
+}
+
+// withSummaryOnly adds the summary_only property to list tool schema properties
+func withSummaryOnly(props map[string]interface{}) map[string]interface{} {
+	props["summary_only"] = SummaryOnlyProperty()
+	return props
+}
+
+// FormatListResponse formats a list tool result, condensing it to an index of
+// ID + name + key attribute when summary_only is set
+func (t *BaseTool) FormatListResponse(result map[string]interface{}, args map[string]interface{}, toolName string) (*mcp.CallToolResult, error) {
+	if summaryOnly, _ := GetBoolParam(args, "summary_only", false); summaryOnly {
+		if spec, ok := listSummarySpecs[toolName]; ok {
+			text := formatListSummary(result, spec)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: ensureResponseLimit(redactSecrets(text), t.logger)}},
+			}, nil
+		}
+	}
+	return t.FormatResponseWithSuggestions(result, toolName)
+}
+
+// formatListSummary renders one line per item plus counts and a paging hint
+func formatListSummary(result map[string]interface{}, spec listSummarySpec) string {
+	var items []interface{}
+	for _, key := range spec.ListKeys {
+		if list, ok := result[key].([]interface{}); ok {
+			items = list
+			break
+		}
+	}
+
+	total := len(items)
+	if count, ok := result["filtered_count"].(int); ok {
+		total = count
+	}
+	var nextOffset interface{}
+	if meta, ok := result["_pagination"].(map[string]interface{}); ok {
+		if count, ok := meta["total"].(int); ok {
+			total = count
+		}
+		nextOffset = meta["next_offset"]
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## %s (%d total)\n\n", spec.Title, total)
+	if len(items) == 0 {
+		sb.WriteString("No items found.\n")
+		return sb.String()
+	}
+
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		label := "(unnamed)"
+		if values := extractFieldValues([]interface{}{m}, []string{"name", "display_name"}, 1); len(values) > 0 {
+			label = values[0]
+		}
+		if id, _ := m["id"].(string); id != "" && !strings.Contains(label, "(ID: ") {
+			label += fmt.Sprintf(" (ID: %s)", id)
+		}
+		if spec.KeyField != "" {
+			if v, ok := m[spec.KeyField]; ok && v != nil && v != "" {
+				label += fmt.Sprintf(" — %s: %v", spec.KeyField, v)
+			}
+		}
+		fmt.Fprintf(&sb, "- %s\n", label)
+	}
+
+	if len(items) < total {
+		fmt.Fprintf(&sb, "\nShowing %d of %d.", len(items), total)
+		if nextOffset != nil {
+			fmt.Fprintf(&sb, " Use offset=%v for the next page.", nextOffset)
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n💡 Fetch an item in full with the matching get_* tool, or omit summary_only for complete objects.\n")
+	return sb.String()
+}
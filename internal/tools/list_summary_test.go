@@ -0,0 +1,99 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestListPoliciesTool_SummaryOnly(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.RespondWith(200, map[string]interface{}{
+		"policies": []map[string]interface{}{
+			{"id": "policy-1", "name": "Archive Policy", "priority": "type_low", "description": "long text"},
+			{"id": "policy-2", "name": "Hot Policy", "priority": "type_high"},
+			{"id": "policy-3", "name": "Debug Policy", "priority": "type_block"},
+		},
+	})
+
+	tool := NewListPoliciesTool(mock, zap.NewNop())
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{
+		"summary_only": true,
+		"limit":        float64(2),
+	})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	if result.IsError {
+		t.Fatal("Expected success result")
+	}
+
+	text := result.Content[0].(*mcp.TextContent).Text
+	for _, want := range []string{
+		"## Policies (3 total)",
+		"- Archive Policy (ID: policy-1) — priority: type_low",
+		"- Hot Policy (ID: policy-2) — priority: type_high",
+		"Showing 2 of 3. Use offset=2 for the next page.",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("summary missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "Debug Policy") || strings.Contains(text, "long text") {
+		t.Errorf("summary should only include the requested page's ID, name and key field:\n%s", text)
+	}
+}
+
+func TestFormatListSummary(t *testing.T) {
+	spec := listSummarySpecs["list_alerts"]
+
+	t.Run("uses filtered count and skips missing key field", func(t *testing.T) {
+		text := formatListSummary(map[string]interface{}{
+			"alerts": []interface{}{
+				map[string]interface{}{"id": "a1", "name": "CPU High", "severity": "critical"},
+				map[string]interface{}{"id": "a2", "name": "Disk Full"},
+			},
+			"filtered_count": 2,
+			"total_count":    10,
+		}, spec)
+		if !strings.Contains(text, "## Alerts (2 total)") {
+			t.Errorf("expected filtered count as total:\n%s", text)
+		}
+		if !strings.Contains(text, "- CPU High (ID: a1) — severity: critical") {
+			t.Errorf("missing key field line:\n%s", text)
+		}
+		if !strings.Contains(text, "- Disk Full (ID: a2)\n") {
+			t.Errorf("item without key field should have no suffix:\n%s", text)
+		}
+		if strings.Contains(text, "Showing") {
+			t.Errorf("complete list should not include a paging hint:\n%s", text)
+		}
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		text := formatListSummary(map[string]interface{}{"alerts": []interface{}{}}, spec)
+		if !strings.Contains(text, "(0 total)") || !strings.Contains(text, "No items found.") {
+			t.Errorf("unexpected empty summary:\n%s", text)
+		}
+	})
+}
+
+func TestFormatListResponse_WithoutSummaryOnly(t *testing.T) {
+	tool := NewBaseTool(client.NewMockClient(), zap.NewNop())
+	result, err := tool.FormatListResponse(map[string]interface{}{
+		"policies": []interface{}{
+			map[string]interface{}{"id": "policy-1", "name": "Archive Policy", "description": "long text"},
+		},
+	}, map[string]interface{}{}, "list_policies")
+	if err != nil {
+		t.Fatalf("FormatListResponse error: %v", err)
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(text, "long text") {
+		t.Errorf("full response should include all fields:\n%s", text)
+	}
+}
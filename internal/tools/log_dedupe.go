@@ -0,0 +1,143 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements the dedupe option that collapses repeated entries in query results.
+package tools
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dedupe modes of query_logs
+const (
+	DedupeOff         = "off"
+	DedupeConsecutive = "consecutive"
+	DedupeAll         = "all"
+)
+
+// dedupeEntryFields are the keys a collapsed entry gains; the fields projection keeps them
+var dedupeEntryFields = []string{"repeat_count", "first_time", "last_time"}
+
+// dedupeSchema is the schema of the dedupe argument of query_logs
+var dedupeSchema = map[string]interface{}{
+	"type":        "string",
+	"description": "Collapse entries with the same message, app, subsystem and severity into one entry with repeat_count, first_time and last_time. 'consecutive' only merges adjacent repeats, keeping the order of events; 'all' merges repeats anywhere in the result. Summary counts are not affected. Ignored with raw_output. Default: off.",
+	"enum":        []string{DedupeOff, DedupeConsecutive, DedupeAll},
+	"default":     DedupeOff,
+}
+
+// getDedupeArg reads and validates the optional dedupe argument
+func getDedupeArg(args map[string]interface{}) (string, error) {
+	mode, _ := GetStringParam(args, "dedupe", false)
+	switch strings.ToLower(mode) {
+	case "", DedupeOff:
+		return DedupeOff, nil
+	case DedupeConsecutive:
+		return DedupeConsecutive, nil
+	case DedupeAll:
+		return DedupeAll, nil
+	default:
+		return "", fmt.Errorf("dedupe must be one of off, consecutive, all (got %q)", mode)
+	}
+}
+
+// setResultDedupe records the dedupe mode in the result's _query_metadata so
+// CleanQueryResults collapses repeated entries
+func setResultDedupe(result map[string]interface{}, mode string) {
+	if mode == DedupeOff {
+		return
+	}
+	meta, ok := result["_query_metadata"].(map[string]interface{})
+	if !ok {
+		meta = make(map[string]interface{})
+		result["_query_metadata"] = meta
+	}
+	meta["dedupe"] = mode
+}
+
+// dedupeMode returns the dedupe mode recorded in a result's _query_metadata
+func dedupeMode(result map[string]interface{}) string {
+	if meta, ok := result["_query_metadata"].(map[string]interface{}); ok {
+		if mode, ok := meta["dedupe"].(string); ok {
+			return mode
+		}
+	}
+	return DedupeOff
+}
+
+// DedupeLogEntries collapses compacted log entries with the same message, app, subsystem
+// and severity. The first entry of each group is kept in place; when it repeats it gains
+// repeat_count and the group's first_time and last_time. It returns the entries and the
+// number of entries removed.
+func DedupeLogEntries(entries []interface{}, mode string) ([]interface{}, int) {
+	if mode != DedupeConsecutive && mode != DedupeAll {
+		return entries, 0
+	}
+
+	deduped := make([]interface{}, 0, len(entries))
+	groups := make(map[string]map[string]interface{})
+	var prevKey string
+	var prev map[string]interface{}
+	for _, e := range entries {
+		entry, ok := e.(map[string]interface{})
+		if !ok {
+			deduped = append(deduped, e)
+			prev = nil
+			continue
+		}
+		key := dedupeKey(entry)
+
+		var rep map[string]interface{}
+		if mode == DedupeAll {
+			rep = groups[key]
+		} else if prev != nil && key == prevKey {
+			rep = prev
+		}
+		if rep != nil {
+			addRepeat(rep, entry)
+			continue
+		}
+
+		rep = make(map[string]interface{}, len(entry)+3)
+		for k, v := range entry {
+			rep[k] = v
+		}
+		deduped = append(deduped, rep)
+		groups[key] = rep
+		prevKey, prev = key, rep
+	}
+
+	return deduped, len(entries) - len(deduped)
+}
+
+// dedupeKey identifies entries that dedupe treats as the same line
+func dedupeKey(entry map[string]interface{}) string {
+	var key strings.Builder
+	for _, f := range []string{"app", "subsystem", "severity", "message"} {
+		fmt.Fprintf(&key, "%v\x00", entry[f])
+	}
+	return key.String()
+}
+
+// addRepeat counts entry as a repeat of rep and widens rep's time range. ISO timestamps
+// compare chronologically as strings.
+func addRepeat(rep, entry map[string]interface{}) {
+	count, _ := rep["repeat_count"].(int)
+	if count == 0 {
+		count = 1
+		if t, ok := rep["time"].(string); ok {
+			rep["first_time"], rep["last_time"] = t, t
+		}
+	}
+	rep["repeat_count"] = count + 1
+
+	t, ok := entry["time"].(string)
+	if !ok {
+		return
+	}
+	if first, _ := rep["first_time"].(string); first == "" || t < first {
+		rep["first_time"] = t
+	}
+	if last, _ := rep["last_time"].(string); t > last {
+		rep["last_time"] = t
+	}
+}
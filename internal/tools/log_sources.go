@@ -0,0 +1,250 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+const (
+	// defaultLogSourceLimit is the number of distinct values returned by list_applications/list_subsystems
+	defaultLogSourceLimit = 50
+	// maxLogSourceLimit caps the number of distinct values a single call can return
+	maxLogSourceLimit = 500
+)
+
+// logSourceWindows maps the accepted time_range values to their durations
+var logSourceWindows = map[string]time.Duration{
+	"15m": 15 * time.Minute,
+	"1h":  time.Hour,
+	"6h":  6 * time.Hour,
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+}
+
+// LogSourceCount is one distinct application or subsystem with its event count
+type LogSourceCount struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// countsByLabel extracts label value -> count pairs from a query grouped by $l.<label>
+func countsByLabel(result map[string]interface{}, label string) map[string]float64 {
+	counts := make(map[string]float64)
+	events, _ := result["events"].([]interface{})
+	for _, event := range events {
+		eventMap, ok := event.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := eventMap["$l."+label].(string)
+		if name == "" {
+			name, _ = eventMap[label].(string)
+		}
+		if name == "" {
+			name = "unknown"
+		}
+		count, _ := eventMap["count"].(float64)
+		counts[name] += count
+	}
+	return counts
+}
+
+// logSourceSchema returns the input schema shared by list_applications and list_subsystems
+func logSourceSchema(extra map[string]interface{}) map[string]interface{} {
+	properties := map[string]interface{}{
+		"time_range": map[string]interface{}{
+			"type":        "string",
+			"description": "How far back to look for logs (default: 24h)",
+			"enum":        []string{"15m", "1h", "6h", "24h", "7d"},
+			"default":     "24h",
+		},
+		"limit": map[string]interface{}{
+			"type":        "integer",
+			"description": fmt.Sprintf("Maximum number of distinct values to return, busiest first (default: %d, max: %d)", defaultLogSourceLimit, maxLogSourceLimit),
+			"default":     defaultLogSourceLimit,
+			"minimum":     1,
+			"maximum":     maxLogSourceLimit,
+		},
+	}
+	for k, v := range extra {
+		properties[k] = v
+	}
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+}
+
+// listLogSources counts events per distinct $l.<label> value over the requested window and
+// returns the busiest values, noting when more exist than the limit allows
+func listLogSources(ctx context.Context, t *BaseTool, args map[string]interface{}, label, resultKey, filter string) (*mcp.CallToolResult, error) {
+	timeRange, _ := GetStringParam(args, "time_range", false)
+	if timeRange == "" {
+		timeRange = "24h"
+	}
+	window, ok := logSourceWindows[timeRange]
+	if !ok {
+		return NewToolResultError(fmt.Sprintf("time_range must be one of 15m, 1h, 6h, 24h, 7d (got %q)", timeRange)), nil
+	}
+	limit, err := GetIntParam(args, "limit", false)
+	if err != nil {
+		return NewToolResultError(err.Error()), nil
+	}
+	if limit <= 0 {
+		limit = defaultLogSourceLimit
+	}
+	if limit > maxLogSourceLimit {
+		limit = maxLogSourceLimit
+	}
+
+	query := "source logs"
+	if filter != "" {
+		query += " | filter " + filter
+	}
+	// Fetch one extra value so truncation can be reported
+	query += fmt.Sprintf(" | groupby $l.%s aggregate count() as count | sortby -count | limit %d", label, limit+1)
+	query, _, err = PrepareQuery(query, "archive", "dataprime")
+	if err != nil {
+		return NewToolResultError(fmt.Sprintf("Invalid query: %v", err)), nil
+	}
+
+	endDate := time.Now().UTC()
+	result, err := t.ExecuteRequest(ctx, &client.Request{
+		Method: "POST",
+		Path:   "/v1/query",
+		Body: map[string]interface{}{
+			"query":      query,
+			"tier":       "archive",
+			"syntax":     "dataprime",
+			"start_date": endDate.Add(-window).Format(time.RFC3339),
+			"end_date":   endDate.Format(time.RFC3339),
+		},
+	})
+	if err != nil {
+		return NewToolResultErrorWithCode(ClassifyError(err), fmt.Sprintf("Failed to query logs: %v", err)), nil
+	}
+
+	counts := countsByLabel(result, label)
+	values := make([]LogSourceCount, 0, len(counts))
+	for name, count := range counts {
+		values = append(values, LogSourceCount{Name: name, Count: int64(count)})
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if values[i].Count != values[j].Count {
+			return values[i].Count > values[j].Count
+		}
+		return values[i].Name < values[j].Name
+	})
+
+	response := map[string]interface{}{
+		"time_range": timeRange,
+		"truncated":  len(values) > limit,
+	}
+	if len(values) > limit {
+		values = values[:limit]
+		response["note"] = fmt.Sprintf("Only the %d busiest values are shown; more exist. Raise limit (max %d) or narrow time_range to see the rest.", limit, maxLogSourceLimit)
+	}
+	response[resultKey] = values
+	response["count"] = len(values)
+
+	return t.FormatResponse(response)
+}
+
+// ListApplicationsTool lists the application names that actually appear in recent logs
+type ListApplicationsTool struct{ *BaseTool }
+
+// NewListApplicationsTool creates a new tool instance
+func NewListApplicationsTool(c client.Doer, l *zap.Logger) *ListApplicationsTool {
+	return &ListApplicationsTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *ListApplicationsTool) Name() string { return "list_applications" }
+
+// Annotations returns tool hints for LLMs
+func (t *ListApplicationsTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("List Applications")
+}
+
+// DefaultTimeout returns the timeout for the grouped count query
+func (t *ListApplicationsTool) DefaultTimeout() time.Duration {
+	return DefaultQueryTimeout
+}
+
+// Description returns the tool description
+func (t *ListApplicationsTool) Description() string {
+	return `List the application names ($l.applicationname) found in recent logs, with per-application event counts.
+
+Use this before writing filters, alerts or policies so application names are exact rather than guessed.
+Results are sorted busiest first and capped by limit; a note is included when more applications exist.
+
+**Related tools:** list_subsystems, query_logs, discover_fields`
+}
+
+// InputSchema returns the input schema
+func (t *ListApplicationsTool) InputSchema() interface{} {
+	return logSourceSchema(nil)
+}
+
+// Execute executes the tool
+func (t *ListApplicationsTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	return listLogSources(ctx, t.BaseTool, args, "applicationname", "applications", "")
+}
+
+// ListSubsystemsTool lists the subsystem names that actually appear in recent logs
+type ListSubsystemsTool struct{ *BaseTool }
+
+// NewListSubsystemsTool creates a new tool instance
+func NewListSubsystemsTool(c client.Doer, l *zap.Logger) *ListSubsystemsTool {
+	return &ListSubsystemsTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *ListSubsystemsTool) Name() string { return "list_subsystems" }
+
+// Annotations returns tool hints for LLMs
+func (t *ListSubsystemsTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("List Subsystems")
+}
+
+// DefaultTimeout returns the timeout for the grouped count query
+func (t *ListSubsystemsTool) DefaultTimeout() time.Duration {
+	return DefaultQueryTimeout
+}
+
+// Description returns the tool description
+func (t *ListSubsystemsTool) Description() string {
+	return `List the subsystem names ($l.subsystemname) found in recent logs, with per-subsystem event counts.
+
+Optionally restrict to one application to see only its subsystems.
+Results are sorted busiest first and capped by limit; a note is included when more subsystems exist.
+
+**Related tools:** list_applications, query_logs, discover_fields`
+}
+
+// InputSchema returns the input schema
+func (t *ListSubsystemsTool) InputSchema() interface{} {
+	return logSourceSchema(map[string]interface{}{
+		"application_name": map[string]interface{}{
+			"type":        "string",
+			"description": "Only list subsystems of this application (optional)",
+		},
+	})
+}
+
+// Execute executes the tool
+func (t *ListSubsystemsTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	appName, _ := GetStringParam(args, "application_name", false)
+	filter := ""
+	if appName != "" {
+		filter = fmt.Sprintf("$l.applicationname == '%s'", escapeDataPrimeString(appName))
+	}
+	return listLogSources(ctx, t.BaseTool, args, "subsystemname", "subsystems", filter)
+}
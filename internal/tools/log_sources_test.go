@@ -0,0 +1,86 @@
+package tools
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestListApplicationsTool_Execute(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.RespondWith(http.StatusOK, map[string]interface{}{"events": []interface{}{
+		map[string]interface{}{"$l.applicationname": "checkout", "count": 1200},
+		map[string]interface{}{"$l.applicationname": "api", "count": 5400},
+		map[string]interface{}{"$l.applicationname": "worker", "count": 30},
+	}})
+
+	tool := NewListApplicationsTool(mock, zap.NewNop())
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{"time_range": "6h", "limit": 2})
+	if err != nil || result.IsError {
+		t.Fatalf("unexpected error: %v %v", err, result.Content)
+	}
+
+	var got struct {
+		Applications []LogSourceCount `json:"applications"`
+		Truncated    bool             `json:"truncated"`
+		Note         string           `json:"note"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &got); err != nil {
+		t.Fatalf("response is not JSON: %v", err)
+	}
+	if len(got.Applications) != 2 || got.Applications[0] != (LogSourceCount{Name: "api", Count: 5400}) || got.Applications[1].Name != "checkout" {
+		t.Errorf("Applications = %+v, want api and checkout busiest first", got.Applications)
+	}
+	if !got.Truncated || !strings.Contains(got.Note, "more exist") {
+		t.Errorf("expected truncation note, got truncated=%v note=%q", got.Truncated, got.Note)
+	}
+
+	query := mock.LastRequest().Body.(map[string]interface{})["query"].(string)
+	if !strings.Contains(query, "groupby $l.applicationname") || !strings.Contains(query, "limit 3") {
+		t.Errorf("unexpected query: %s", query)
+	}
+}
+
+func TestListSubsystemsTool_Execute(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.RespondWith(http.StatusOK, map[string]interface{}{"events": []interface{}{
+		map[string]interface{}{"$l.subsystemname": "payments", "count": 10},
+	}})
+
+	tool := NewListSubsystemsTool(mock, zap.NewNop())
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{"application_name": "o'brien"})
+	if err != nil || result.IsError {
+		t.Fatalf("unexpected error: %v %v", err, result.Content)
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(text, `"payments"`) || strings.Contains(text, `"note"`) {
+		t.Errorf("unexpected result: %s", text)
+	}
+	query := mock.LastRequest().Body.(map[string]interface{})["query"].(string)
+	if !strings.Contains(query, `$l.applicationname == 'o\'brien'`) || !strings.Contains(query, "groupby $l.subsystemname") {
+		t.Errorf("query not scoped to the application: %s", query)
+	}
+}
+
+func TestListApplicationsTool_InvalidTimeRange(t *testing.T) {
+	mock := client.NewMockClient()
+	result, _ := NewListApplicationsTool(mock, zap.NewNop()).Execute(testCtx(mock), map[string]interface{}{"time_range": "2y"})
+	if !result.IsError || mock.RequestCount() != 0 {
+		t.Errorf("expected a validation error without querying, got %+v", result)
+	}
+}
+
+func TestLogSourceTools_RelatedTools(t *testing.T) {
+	for _, tool := range []Tool{NewListApplicationsTool(nil, zap.NewNop()), NewListSubsystemsTool(nil, zap.NewNop())} {
+		description := tool.Description()
+		if !strings.Contains(description, "discover_fields") || strings.Contains(description, "discover_log_fields") {
+			t.Errorf("%s should point to discover_fields, got %q", tool.Name(), description)
+		}
+	}
+}
@@ -0,0 +1,635 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements convert_lucene_to_dataprime, which translates Lucene queries to DataPrime.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// ConvertLuceneToDataPrimeTool translates a Lucene query into an equivalent DataPrime pipeline
+type ConvertLuceneToDataPrimeTool struct {
+	*BaseTool
+}
+
+// NewConvertLuceneToDataPrimeTool creates a new ConvertLuceneToDataPrimeTool
+func NewConvertLuceneToDataPrimeTool(c client.Doer, l *zap.Logger) *ConvertLuceneToDataPrimeTool {
+	return &ConvertLuceneToDataPrimeTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *ConvertLuceneToDataPrimeTool) Name() string { return "convert_lucene_to_dataprime" }
+
+// Annotations returns tool hints for LLMs
+func (t *ConvertLuceneToDataPrimeTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Convert Lucene to DataPrime")
+}
+
+// Description returns the tool description
+func (t *ConvertLuceneToDataPrimeTool) Description() string {
+	return `Translate a Lucene query (the syntax of the Cloud Logs UI search bar) into an equivalent DataPrime pipeline, without executing it.
+
+Supports field:value, quoted phrases, ranges ([a TO b], {a TO b}, >=, <), AND/OR/NOT (and &&, ||, !, +, -), grouping, wildcards (* and ?), regular expressions and _exists_. Fields are mapped to their DataPrime scope: applicationName/subsystemName → $l., severity/timestamp → $m., everything else → $d.
+
+Returns the DataPrime query, the field mappings used, and notes on any construct that could not be translated exactly (e.g. fuzzy matches, boosts, free-text search).
+
+**Related tools:** query_logs, build_query, explain_query, normalize_query`
+}
+
+// InputSchema returns the input schema
+func (t *ConvertLuceneToDataPrimeTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"query": map[string]interface{}{
+				"type":        "string",
+				"description": "The Lucene query to convert",
+				"examples":    []string{`applicationName:api AND severity:error AND NOT message:"health check"`},
+			},
+			"default_operator": map[string]interface{}{
+				"type":        "string",
+				"description": "Operator joining terms that have no explicit AND/OR between them (default: AND)",
+				"enum":        []string{"AND", "OR"},
+				"default":     "AND",
+			},
+		},
+		"required": []string{"query"},
+	}
+}
+
+// LuceneConversion is the result of convert_lucene_to_dataprime
+type LuceneConversion struct {
+	LuceneQuery    string            `json:"lucene_query"`
+	DataPrimeQuery string            `json:"dataprime_query"`
+	FieldMappings  map[string]string `json:"field_mappings,omitempty"`
+	Notes          []string          `json:"notes,omitempty"`
+}
+
+// Execute converts the query
+func (t *ConvertLuceneToDataPrimeTool) Execute(_ context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	query, err := GetStringParam(args, "query", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	defaultOp, _ := GetStringParam(args, "default_operator", false)
+	defaultOp = strings.ToUpper(defaultOp)
+	if defaultOp != "OR" {
+		defaultOp = "AND"
+	}
+
+	conversion, err := ConvertLuceneToDataPrime(query, defaultOp)
+	if err != nil {
+		return NewToolResultErrorWithSuggestion(
+			fmt.Sprintf("Cannot convert Lucene query: %v", err),
+			"Check that parentheses, quotes and range brackets are balanced, or run the query as-is with query_logs syntax=lucene.",
+		), nil
+	}
+
+	data, err := json.MarshalIndent(conversion, "", "  ")
+	if err != nil {
+		return NewToolResultError(fmt.Sprintf("Failed to format conversion: %v", err)), nil
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(data)},
+		},
+	}, nil
+}
+
+// ConvertLuceneToDataPrime translates a Lucene query into a DataPrime query. defaultOp ("AND"
+// or "OR") joins terms with no explicit operator between them. The result is passed through
+// PrepareQuery so it carries the same corrections query_logs would apply.
+func ConvertLuceneToDataPrime(query, defaultOp string) (*LuceneConversion, error) {
+	p := &luceneParser{input: []rune(query), defaultOp: defaultOp, mappings: make(map[string]string)}
+
+	var expr string
+	p.skipSpace()
+	if !p.atEnd() {
+		var err error
+		if expr, err = p.parseOr(); err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if !p.atEnd() {
+			return nil, fmt.Errorf("unexpected %q at position %d", string(p.input[p.pos]), p.pos)
+		}
+	}
+
+	dataprime := "source logs"
+	if expr != "" && expr != "true" {
+		dataprime += " | filter " + expr
+	}
+
+	prepared, corrections, err := PrepareQuery(dataprime, "", "dataprime")
+	if err != nil {
+		p.note(fmt.Sprintf("The converted query does not pass validation, review it before running: %v", err))
+	} else {
+		dataprime = prepared
+		for _, c := range corrections {
+			p.note("Auto-corrected: " + c)
+		}
+	}
+
+	conversion := &LuceneConversion{
+		LuceneQuery:    query,
+		DataPrimeQuery: dataprime,
+		Notes:          p.notes,
+	}
+	if len(p.mappings) > 0 {
+		conversion.FieldMappings = p.mappings
+	}
+	return conversion, nil
+}
+
+// luceneParser is a recursive descent parser that emits DataPrime boolean expressions
+type luceneParser struct {
+	input     []rune
+	pos       int
+	defaultOp string
+	// field is the field of an enclosing field:( ... ) group
+	field    string
+	mappings map[string]string
+	notes    []string
+}
+
+func (p *luceneParser) atEnd() bool { return p.pos >= len(p.input) }
+
+func (p *luceneParser) skipSpace() {
+	for !p.atEnd() && unicode.IsSpace(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *luceneParser) note(msg string) {
+	for _, n := range p.notes {
+		if n == msg {
+			return
+		}
+	}
+	p.notes = append(p.notes, msg)
+}
+
+// peekOperator reports the boolean operator at the cursor ("AND", "OR", "NOT") and its length
+func (p *luceneParser) peekOperator() (string, int) {
+	rest := string(p.input[p.pos:])
+	for _, op := range []struct{ token, name string }{
+		{"&&", "AND"}, {"||", "OR"}, {"AND", "AND"}, {"OR", "OR"}, {"NOT", "NOT"},
+	} {
+		if !strings.HasPrefix(rest, op.token) {
+			continue
+		}
+		n := len([]rune(op.token))
+		// Word operators must stand alone: "ORDER" is a term, not OR
+		if unicode.IsLetter(rune(op.token[0])) && p.pos+n < len(p.input) && !isLuceneBoundary(p.input[p.pos+n]) {
+			continue
+		}
+		return op.name, n
+	}
+	return "", 0
+}
+
+func isLuceneBoundary(r rune) bool {
+	return unicode.IsSpace(r) || r == '(' || r == ')'
+}
+
+// termFollows reports whether another clause starts at the cursor
+func (p *luceneParser) termFollows() bool {
+	p.skipSpace()
+	if p.atEnd() || p.input[p.pos] == ')' {
+		return false
+	}
+	op, _ := p.peekOperator()
+	return op == "" || op == "NOT"
+}
+
+func (p *luceneParser) parseOr() (string, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return "", err
+	}
+	parts := []string{left}
+	for {
+		p.skipSpace()
+		if op, n := p.peekOperator(); op == "OR" {
+			p.pos += n
+		} else if p.defaultOp != "OR" || !p.termFollows() {
+			break
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, right)
+	}
+	return strings.Join(parts, " || "), nil
+}
+
+func (p *luceneParser) parseAnd() (string, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return "", err
+	}
+	parts := []string{left}
+	for {
+		p.skipSpace()
+		if op, n := p.peekOperator(); op == "AND" {
+			p.pos += n
+		} else if p.defaultOp != "AND" || !p.termFollows() {
+			break
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, right)
+	}
+	return strings.Join(parts, " && "), nil
+}
+
+func (p *luceneParser) parseUnary() (string, error) {
+	p.skipSpace()
+	if p.atEnd() {
+		return "", fmt.Errorf("query ends where a term was expected")
+	}
+	negate := false
+	if op, n := p.peekOperator(); op == "NOT" {
+		p.pos += n
+		negate = true
+	} else if c := p.input[p.pos]; c == '!' || c == '-' {
+		p.pos++
+		negate = true
+	} else if c == '+' {
+		p.pos++
+	}
+	if negate {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return "", err
+		}
+		if strings.HasPrefix(operand, "(") && strings.HasSuffix(operand, ")") {
+			return "!" + operand, nil
+		}
+		return "!(" + operand + ")", nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *luceneParser) parsePrimary() (string, error) {
+	p.skipSpace()
+	if p.atEnd() {
+		return "", fmt.Errorf("query ends where a term was expected")
+	}
+	if p.input[p.pos] == '(' {
+		return p.parseGroup()
+	}
+
+	// Read a field name or a bare term up to an unescaped ':'
+	start := p.pos
+	word := p.readWord(true)
+	if !p.atEnd() && p.input[p.pos] == ':' && word != "" {
+		p.pos++
+		return p.parseFieldValue(word)
+	}
+	p.pos = start
+	return p.parseFieldValue(p.field)
+}
+
+// parseGroup parses a parenthesized sub-expression
+func (p *luceneParser) parseGroup() (string, error) {
+	open := p.pos
+	p.pos++
+	inner, err := p.parseOr()
+	if err != nil {
+		return "", err
+	}
+	p.skipSpace()
+	if p.atEnd() || p.input[p.pos] != ')' {
+		return "", fmt.Errorf("unbalanced parenthesis at position %d", open)
+	}
+	p.pos++
+	p.skipBoost()
+	return "(" + inner + ")", nil
+}
+
+// readWord reads an unquoted term, honoring backslash escapes. With stopAtColon it stops
+// at an unescaped ':' so the caller can split field:value.
+func (p *luceneParser) readWord(stopAtColon bool) string {
+	var sb strings.Builder
+	for !p.atEnd() {
+		c := p.input[p.pos]
+		if c == '\\' && p.pos+1 < len(p.input) {
+			sb.WriteRune(p.input[p.pos+1])
+			p.pos += 2
+			continue
+		}
+		if isLuceneBoundary(c) || (stopAtColon && c == ':') || c == '^' || c == '~' {
+			break
+		}
+		sb.WriteRune(c)
+		p.pos++
+	}
+	return sb.String()
+}
+
+// readDelimited reads up to the closing delimiter, honoring backslash escapes
+func (p *luceneParser) readDelimited(closing rune) (string, error) {
+	open := p.pos
+	p.pos++
+	var sb strings.Builder
+	for !p.atEnd() {
+		c := p.input[p.pos]
+		if c == '\\' && p.pos+1 < len(p.input) {
+			if closing == '/' {
+				sb.WriteRune(c)
+			}
+			sb.WriteRune(p.input[p.pos+1])
+			p.pos += 2
+			continue
+		}
+		if c == closing {
+			p.pos++
+			return sb.String(), nil
+		}
+		sb.WriteRune(c)
+		p.pos++
+	}
+	return "", fmt.Errorf("unterminated %q at position %d", string(p.input[open]), open)
+}
+
+// skipBoost drops a ^boost suffix, which has no meaning for a filter
+func (p *luceneParser) skipBoost() {
+	if p.atEnd() || p.input[p.pos] != '^' {
+		return
+	}
+	p.pos++
+	for !p.atEnd() && (unicode.IsDigit(p.input[p.pos]) || p.input[p.pos] == '.') {
+		p.pos++
+	}
+	p.note("Boosts (^n) only affect relevance ranking and were dropped")
+}
+
+// skipFuzzy drops a ~n fuzzy or proximity suffix and reports whether one was present
+func (p *luceneParser) skipFuzzy() bool {
+	if p.atEnd() || p.input[p.pos] != '~' {
+		return false
+	}
+	p.pos++
+	for !p.atEnd() && (unicode.IsDigit(p.input[p.pos]) || p.input[p.pos] == '.') {
+		p.pos++
+	}
+	return true
+}
+
+// parseFieldValue parses the value of field (empty for free text) and converts the clause
+func (p *luceneParser) parseFieldValue(field string) (string, error) {
+	if p.atEnd() {
+		return "", fmt.Errorf("missing value for field %q", field)
+	}
+
+	switch c := p.input[p.pos]; {
+	case c == '(':
+		outer := p.field
+		p.field = field
+		expr, err := p.parseGroup()
+		p.field = outer
+		return expr, err
+	case c == '"':
+		phrase, err := p.readDelimited('"')
+		if err != nil {
+			return "", err
+		}
+		if p.skipFuzzy() {
+			p.note(fmt.Sprintf("Proximity search %q~ has no DataPrime equivalent and was matched as an exact phrase", phrase))
+		}
+		p.skipBoost()
+		return p.phraseClause(field, phrase), nil
+	case c == '/':
+		pattern, err := p.readDelimited('/')
+		if err != nil {
+			return "", err
+		}
+		p.skipBoost()
+		return p.stringMethod(field, "matches", "/"+pattern+"/"), nil
+	case c == '[' || c == '{':
+		body, closing, err := p.readRange()
+		if err != nil {
+			return "", err
+		}
+		p.skipBoost()
+		return p.rangeClause(field, c == '[', closing == ']', body)
+	}
+
+	cmp := ""
+	for _, op := range []string{">=", "<=", ">", "<"} {
+		if strings.HasPrefix(string(p.input[p.pos:]), op) {
+			cmp = op
+			p.pos += len(op)
+			break
+		}
+	}
+	value := p.readWord(false)
+	if value == "" {
+		return "", fmt.Errorf("missing value for field %q at position %d", field, p.pos)
+	}
+	if p.skipFuzzy() {
+		p.note(fmt.Sprintf("Fuzzy match %s~ has no DataPrime equivalent and was matched exactly", value))
+	}
+	p.skipBoost()
+
+	if cmp != "" {
+		if field == "" {
+			return "", fmt.Errorf("comparison %s%s needs a field", cmp, value)
+		}
+		return p.compare(field, cmp, value), nil
+	}
+	return p.termClause(field, value), nil
+}
+
+// readRange reads the body of a [a TO b] or {a TO b} range and its closing bracket
+func (p *luceneParser) readRange() (string, rune, error) {
+	open := p.pos
+	for i := open + 1; i < len(p.input); i++ {
+		if c := p.input[i]; c == ']' || c == '}' {
+			p.pos = i + 1
+			return string(p.input[open+1 : i]), c, nil
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated range at position %d", open)
+}
+
+// rangeClause converts a range. Lucene allows mixing bracket kinds, e.g. [1 TO 5}.
+func (p *luceneParser) rangeClause(field string, inclusiveLow, inclusiveHigh bool, body string) (string, error) {
+	if field == "" {
+		return "", fmt.Errorf("range [%s] needs a field", body)
+	}
+	bounds := strings.Fields(body)
+	if len(bounds) != 3 || bounds[1] != "TO" {
+		return "", fmt.Errorf("invalid range %q, expected [low TO high]", body)
+	}
+	low, high := strings.Trim(bounds[0], `"`), strings.Trim(bounds[2], `"`)
+
+	var parts []string
+	if low != "*" {
+		op := ">"
+		if inclusiveLow {
+			op = ">="
+		}
+		parts = append(parts, p.compare(field, op, low))
+	}
+	if high != "*" {
+		op := "<"
+		if inclusiveHigh {
+			op = "<="
+		}
+		parts = append(parts, p.compare(field, op, high))
+	}
+	switch len(parts) {
+	case 0:
+		return p.fieldRef(field) + " != null", nil
+	case 1:
+		return parts[0], nil
+	}
+	return "(" + strings.Join(parts, " && ") + ")", nil
+}
+
+// compare converts a comparison of field against value
+func (p *luceneParser) compare(field, op, value string) string {
+	ref := p.fieldRef(field)
+	if ref == "$m.severity" {
+		if level, ok := NormalizeSeverity(value); ok {
+			return fmt.Sprintf("%s %s %s", ref, op, strings.ToUpper(SeverityName(level)))
+		}
+	}
+	if ref == "$m.timestamp" {
+		p.note("Time ranges on timestamp are better expressed with the start_date and end_date of query_logs")
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return fmt.Sprintf("%s %s %s", ref, op, value)
+	}
+	p.note(fmt.Sprintf("Non-numeric bounds on %s are compared as strings", field))
+	return fmt.Sprintf("%s %s '%s'", ref, op, escapeDataPrimeString(value))
+}
+
+// phraseClause converts a quoted phrase. Lucene matches phrases within analyzed text, so
+// text fields use contains(); other fields are compared exactly.
+func (p *luceneParser) phraseClause(field, phrase string) string {
+	if field == "" || isLuceneTextField(p.fieldRef(field)) {
+		return p.stringMethod(field, "contains", "'"+escapeDataPrimeString(phrase)+"'")
+	}
+	return fmt.Sprintf("%s == '%s'", p.fieldRef(field), escapeDataPrimeString(phrase))
+}
+
+// termClause converts an unquoted term, which may contain wildcards
+func (p *luceneParser) termClause(field, value string) string {
+	if field == "_exists_" {
+		return p.fieldRef(value) + " != null"
+	}
+	if value == "*" {
+		if field == "" || field == "*" {
+			return "true"
+		}
+		return p.fieldRef(field) + " != null"
+	}
+
+	if strings.ContainsAny(value, "*?") {
+		core := strings.Trim(value, "*")
+		leading, trailing := strings.HasPrefix(value, "*"), strings.HasSuffix(value, "*")
+		literal := "'" + escapeDataPrimeString(core) + "'"
+		switch {
+		case strings.ContainsAny(core, "*?"):
+			return p.stringMethod(field, "matches", "/"+wildcardToRegex(value)+"/")
+		case leading && trailing:
+			return p.stringMethod(field, "contains", literal)
+		case trailing:
+			return p.stringMethod(field, "startsWith", literal)
+		default:
+			return p.stringMethod(field, "endsWith", literal)
+		}
+	}
+
+	ref := p.fieldRef(field)
+	if field == "" || isLuceneTextField(ref) {
+		return p.stringMethod(field, "contains", "'"+escapeDataPrimeString(value)+"'")
+	}
+	if ref == "$m.severity" {
+		if level, ok := NormalizeSeverity(value); ok {
+			return fmt.Sprintf("%s == %s", ref, strings.ToUpper(SeverityName(level)))
+		}
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return fmt.Sprintf("%s == %s", ref, value)
+	}
+	return fmt.Sprintf("%s == '%s'", ref, escapeDataPrimeString(value))
+}
+
+// stringMethod calls a DataPrime string method on field; free text searches the message
+func (p *luceneParser) stringMethod(field, method, arg string) string {
+	if field == "" {
+		p.note("Free-text terms were translated to searches of $d.message; Lucene free text also searches other fields")
+	}
+	ref := p.fieldRef(field)
+	if isLuceneTextField(ref) {
+		ref += ":string"
+	}
+	return fmt.Sprintf("%s.%s(%s)", ref, method, arg)
+}
+
+// fieldRef maps a Lucene field to its DataPrime reference and records the mapping
+func (p *luceneParser) fieldRef(field string) string {
+	if field == "" {
+		return "$d.message"
+	}
+	if ref, ok := p.mappings[field]; ok {
+		return ref
+	}
+	ref := luceneFieldToDataPrime(field)
+	p.mappings[field] = ref
+	return ref
+}
+
+// luceneFieldToDataPrime maps a Lucene field name, including the coralogix.metadata.* names
+// used by the UI, to a DataPrime field reference
+func luceneFieldToDataPrime(field string) string {
+	name := strings.TrimPrefix(strings.TrimPrefix(field, "coralogix.metadata."), "coralogix.")
+	ref := toDataPrimeField(name)
+	if strings.HasPrefix(ref, "$l.") || strings.HasPrefix(ref, "$m.") {
+		return strings.ToLower(ref)
+	}
+	return ref
+}
+
+// isLuceneTextField reports whether a reference is a free-text field that Lucene matches by term
+func isLuceneTextField(ref string) bool {
+	return strings.HasPrefix(ref, "$d.") && mixedTypeFields[strings.TrimPrefix(ref, "$d.")]
+}
+
+// wildcardToRegex converts a Lucene wildcard term to an anchored regular expression
+func wildcardToRegex(value string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for _, r := range value {
+		switch r {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		case '/':
+			sb.WriteString(`\/`)
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+	return sb.String()
+}
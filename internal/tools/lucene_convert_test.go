@@ -0,0 +1,133 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestConvertLuceneToDataPrime(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "field values across scopes",
+			query: `applicationName:api AND severity:error AND NOT message:"health check"`,
+			want:  `source logs | filter $l.applicationname == 'api' && $m.severity == ERROR && !($d.message:string.contains('health check'))`,
+		},
+		{
+			name:  "UI metadata field group",
+			query: `coralogix.metadata.subsystemName:(auth OR billing)`,
+			want:  `source logs | filter ($l.subsystemname == 'auth' || $l.subsystemname == 'billing')`,
+		},
+		{
+			name:  "inclusive range",
+			query: `status:[500 TO 599]`,
+			want:  `source logs | filter ($d.status >= 500 && $d.status <= 599)`,
+		},
+		{
+			name:  "open ended exclusive range",
+			query: `status:{400 TO *]`,
+			want:  `source logs | filter $d.status > 400`,
+		},
+		{
+			name:  "comparison",
+			query: `duration_ms:>=100`,
+			want:  `source logs | filter $d.duration_ms >= 100`,
+		},
+		{
+			name:  "numeric severity",
+			query: `severity:>=4`,
+			want:  `source logs | filter $m.severity >= WARNING`,
+		},
+		{
+			name:  "prefix wildcard and minus",
+			query: `host:web-* -env:staging`,
+			want:  `source logs | filter $d.host.startsWith('web-') && !($d.env == 'staging')`,
+		},
+		{
+			name:  "inner wildcard becomes regex",
+			query: `user:jo?n*`,
+			want:  `source logs | filter $d.user.matches(/^jo.n.*$/)`,
+		},
+		{
+			name:  "exists",
+			query: `_exists_:trace_id`,
+			want:  `source logs | filter $d.trace_id != null`,
+		},
+		{
+			name:  "grouping with implicit AND",
+			query: `(timeout OR refused) && kubernetes.namespace:prod`,
+			want:  `source logs | filter ($d.message:string.contains('timeout') || $d.message:string.contains('refused')) && $d.kubernetes.namespace == 'prod'`,
+		},
+		{
+			name:  "match all",
+			query: `*:*`,
+			want:  `source logs`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConvertLuceneToDataPrime(tt.query, "AND")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.DataPrimeQuery)
+			assert.Nil(t, ValidateDataPrimeQuery(got.DataPrimeQuery))
+		})
+	}
+}
+
+func TestConvertLuceneToDataPrime_DefaultOperator(t *testing.T) {
+	and, err := ConvertLuceneToDataPrime("a b", "AND")
+	require.NoError(t, err)
+	assert.Contains(t, and.DataPrimeQuery, "&&")
+
+	or, err := ConvertLuceneToDataPrime("a b AND c", "OR")
+	require.NoError(t, err)
+	assert.Equal(t, "source logs | filter $d.message:string.contains('a') || $d.message:string.contains('b') && $d.message:string.contains('c')", or.DataPrimeQuery)
+}
+
+func TestConvertLuceneToDataPrime_Notes(t *testing.T) {
+	got, err := ConvertLuceneToDataPrime(`timeout msg:foo^2 code:"E1"~2 version:[a TO b]`, "AND")
+	require.NoError(t, err)
+	require.Len(t, got.Notes, 4)
+	assert.Contains(t, got.Notes[0], "Free-text")
+	assert.Contains(t, got.Notes[1], "Boosts")
+	assert.Contains(t, got.Notes[2], "Proximity")
+	assert.Contains(t, got.Notes[3], "compared as strings")
+	assert.Equal(t, "$d.msg", got.FieldMappings["msg"])
+}
+
+func TestConvertLuceneToDataPrime_Errors(t *testing.T) {
+	for _, q := range []string{`(a OR b`, `msg:"unterminated`, `status:[1 TO`, `status:[1 5]`, `a )`} {
+		_, err := ConvertLuceneToDataPrime(q, "AND")
+		assert.Error(t, err, q)
+	}
+}
+
+func TestConvertLuceneToDataPrimeTool_Execute(t *testing.T) {
+	mock := client.NewMockClient()
+	tool := NewConvertLuceneToDataPrimeTool(mock, zap.NewNop())
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"query": "severity:critical"})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Zero(t, mock.RequestCount())
+
+	var out LuceneConversion
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &out))
+	assert.Equal(t, "source logs | filter $m.severity == CRITICAL", out.DataPrimeQuery)
+
+	result, err = tool.Execute(context.Background(), map[string]interface{}{"query": "(broken"})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
@@ -0,0 +1,13 @@
+package tools
+
+import (
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	// Tests reuse the same query and time range with different mock responses, so the
+	// query result cache is off unless a test turns it on
+	SetQueryCacheTTL(0)
+	os.Exit(m.Run())
+}
@@ -30,21 +30,31 @@ const (
 var toolNamespaceMapping = map[string]ToolNamespace{
 	// Query tools
 	"query_logs":                  NamespaceQuery,
+	"trace_logs":                  NamespaceQuery,
+	"cluster_logs":                NamespaceQuery,
+	"compare_time_windows":        NamespaceQuery,
 	"build_query":                 NamespaceQuery,
 	"explain_query":               NamespaceQuery,
 	"validate_query":              NamespaceQuery,
+	"normalize_query":             NamespaceQuery,
+	"convert_lucene_to_dataprime": NamespaceQuery,
+	"convert_dataprime_to_lucene": NamespaceQuery,
 	"query_templates":             NamespaceQuery,
 	"submit_background_query":     NamespaceQuery,
 	"get_background_query_status": NamespaceQuery,
 	"get_background_query_data":   NamespaceQuery,
 
 	// Alert tools
-	"list_alerts":   NamespaceAlert,
-	"get_alert":     NamespaceAlert,
-	"create_alert":  NamespaceAlert,
-	"update_alert":  NamespaceAlert,
-	"delete_alert":  NamespaceAlert,
-	"suggest_alert": NamespaceAlert,
+	"list_alerts":        NamespaceAlert,
+	"get_alert":          NamespaceAlert,
+	"watch_alert":        NamespaceAlert,
+	"create_alert":       NamespaceAlert,
+	"bulk_create_alerts": NamespaceAlert,
+	"update_alert":       NamespaceAlert,
+	"delete_alert":       NamespaceAlert,
+	"suggest_alert":      NamespaceAlert,
+	"export_alert":       NamespaceAlert,
+	"restore_alert":      NamespaceAlert,
 
 	// Dashboard tools
 	"list_dashboards":        NamespaceDashboard,
@@ -81,6 +91,7 @@ var toolNamespaceMapping = map[string]ToolNamespace{
 	"create_stream": NamespaceStream,
 	"update_stream": NamespaceStream,
 	"delete_stream": NamespaceStream,
+	"test_stream":   NamespaceStream,
 
 	// View tools
 	"list_views":        NamespaceView,
@@ -103,6 +114,10 @@ var toolNamespaceMapping = map[string]ToolNamespace{
 	"create_enrichment": NamespaceEnrichment,
 	"update_enrichment": NamespaceEnrichment,
 	"delete_enrichment": NamespaceEnrichment,
+	"list_data_sets":    NamespaceEnrichment,
+	"get_data_set":      NamespaceEnrichment,
+	"create_data_set":   NamespaceEnrichment,
+	"delete_data_set":   NamespaceEnrichment,
 
 	// Data access tools
 	"list_data_access_rules":  NamespaceDataAccess,
@@ -114,6 +129,10 @@ var toolNamespaceMapping = map[string]ToolNamespace{
 	// Workflow tools
 	"investigate_incident": NamespaceWorkflow,
 	"health_check":         NamespaceWorkflow,
+	"check_references":     NamespaceWorkflow,
+	"backup_instance":      NamespaceWorkflow,
+	"restore_instance":     NamespaceWorkflow,
+	"diff_backup":          NamespaceWorkflow,
 
 	// Meta tools
 	"discover_tools":       NamespaceMeta,
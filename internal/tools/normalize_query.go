@@ -0,0 +1,135 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements normalize_query, which previews the query auto-corrections.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// NormalizeQueryTool shows the query query_logs would run after auto-correction
+type NormalizeQueryTool struct {
+	*BaseTool
+}
+
+// NewNormalizeQueryTool creates a new NormalizeQueryTool
+func NewNormalizeQueryTool(c client.Doer, l *zap.Logger) *NormalizeQueryTool {
+	return &NormalizeQueryTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *NormalizeQueryTool) Name() string { return "normalize_query" }
+
+// Annotations returns tool hints for LLMs
+func (t *NormalizeQueryTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Normalize Query")
+}
+
+// Description returns the tool description
+func (t *NormalizeQueryTool) Description() string {
+	return `Preview the exact query query_logs would run, without executing it.
+
+Applies the same application/subsystem filters and DataPrime auto-corrections as query_logs (e.g. $d.level → $m.severity, $d.message.contains → $d.message:string.contains) and lists each correction, so you can learn the correct syntax. The corrections match the auto_corrections reported in query_logs metadata.
+
+**Related tools:** query_logs, validate_query, explain_query, build_query`
+}
+
+// InputSchema returns the input schema
+func (t *NormalizeQueryTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"query": map[string]interface{}{
+				"type":        "string",
+				"description": "The query to normalize",
+			},
+			"tier": map[string]interface{}{
+				"type":        "string",
+				"description": "Tier the query would run on: archive (default) or frequent_search",
+				"enum":        []string{"archive", "frequent_search"},
+			},
+			"syntax": map[string]interface{}{
+				"type":        "string",
+				"description": "Query syntax: dataprime (default) or lucene. Lucene queries are not auto-corrected.",
+				"enum":        []string{"dataprime", "lucene"},
+			},
+			"applicationName": map[string]interface{}{
+				"type":        "string",
+				"description": "Application filter folded into the query, as query_logs does",
+			},
+			"subsystemName": map[string]interface{}{
+				"type":        "string",
+				"description": "Subsystem filter folded into the query, as query_logs does",
+			},
+		},
+		"required": []string{"query"},
+	}
+}
+
+// QueryNormalization is the result of normalize_query
+type QueryNormalization struct {
+	OriginalQuery   string   `json:"original_query"`
+	NormalizedQuery string   `json:"normalized_query"`
+	Corrections     []string `json:"corrections"`
+	Changed         bool     `json:"changed"`
+	Tier            string   `json:"tier"`
+	Syntax          string   `json:"syntax"`
+	Note            string   `json:"note,omitempty"`
+}
+
+// Execute normalizes the query without running it
+func (t *NormalizeQueryTool) Execute(_ context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	original, err := GetStringParam(args, "query", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+
+	tier, _ := GetStringParam(args, "tier", false)
+	if tier == "" {
+		tier = "archive"
+	} else {
+		tier = normalizeTier(tier)
+	}
+	syntax, _ := GetStringParam(args, "syntax", false)
+	if syntax == "" {
+		syntax = "dataprime"
+	}
+
+	filtered := applyQueryFilters(original, args)
+	normalized, corrections, err := PrepareQuery(filtered, tier, syntax)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	if corrections == nil {
+		corrections = []string{}
+	}
+
+	result := QueryNormalization{
+		OriginalQuery:   original,
+		NormalizedQuery: normalized,
+		Corrections:     corrections,
+		Changed:         normalized != original,
+		Tier:            tier,
+		Syntax:          syntax,
+	}
+	if len(corrections) > 0 && !autoCorrectQueries.Load() {
+		result.Note = "Auto-correction is disabled on this server: query_logs will reject the original query unless called with auto_correct_queries=true. Run the normalized query instead."
+	}
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return NewToolResultError(fmt.Sprintf("Failed to format normalization: %v", err)), nil
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(data)},
+		},
+	}, nil
+}
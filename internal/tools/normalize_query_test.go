@@ -0,0 +1,76 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func normalizeQuery(t *testing.T, args map[string]interface{}) (*mcp.CallToolResult, QueryNormalization) {
+	t.Helper()
+	mock := client.NewMockClient()
+	result, err := NewNormalizeQueryTool(mock, zap.NewNop()).Execute(context.Background(), args)
+	require.NoError(t, err)
+	assert.Zero(t, mock.RequestCount(), "normalize_query must not execute the query")
+
+	var out QueryNormalization
+	if !result.IsError {
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &out))
+	}
+	return result, out
+}
+
+func TestNormalizeQueryTool_AppliesCorrections(t *testing.T) {
+	query := "source logs | filter $d.level == 'error'"
+	result, out := normalizeQuery(t, map[string]interface{}{"query": query})
+	require.False(t, result.IsError)
+
+	want, corrections, err := PrepareQuery(query, "archive", "dataprime")
+	require.NoError(t, err)
+	assert.Equal(t, query, out.OriginalQuery)
+	assert.Equal(t, want, out.NormalizedQuery)
+	assert.Equal(t, corrections, out.Corrections)
+	assert.Contains(t, out.NormalizedQuery, "$m.severity")
+	assert.True(t, out.Changed)
+	assert.Empty(t, out.Note)
+}
+
+func TestNormalizeQueryTool_UnchangedQuery(t *testing.T) {
+	query := "source logs | filter $m.severity >= ERROR"
+	result, out := normalizeQuery(t, map[string]interface{}{"query": query})
+	require.False(t, result.IsError)
+	assert.Equal(t, query, out.NormalizedQuery)
+	assert.NotNil(t, out.Corrections)
+	assert.Empty(t, out.Corrections)
+	assert.False(t, out.Changed)
+}
+
+func TestNormalizeQueryTool_FoldsFilters(t *testing.T) {
+	_, out := normalizeQuery(t, map[string]interface{}{
+		"query":           "source logs",
+		"applicationName": "checkout",
+	})
+	assert.Contains(t, out.NormalizedQuery, "checkout")
+	assert.True(t, out.Changed)
+}
+
+func TestNormalizeQueryTool_NotesDisabledAutoCorrection(t *testing.T) {
+	SetAutoCorrectQueries(false)
+	defer SetAutoCorrectQueries(true)
+
+	_, out := normalizeQuery(t, map[string]interface{}{"query": "source logs | filter $d.level == 'error'"})
+	assert.NotEmpty(t, out.Corrections)
+	assert.Contains(t, out.Note, "auto_correct_queries=true")
+}
+
+func TestNormalizeQueryTool_MissingQuery(t *testing.T) {
+	result, _ := normalizeQuery(t, map[string]interface{}{})
+	assert.True(t, result.IsError)
+}
@@ -292,3 +292,81 @@ func EnhanceResultWithPagination(result map[string]interface{}, events []interfa
 		}
 	}
 }
+
+// OffsetPaginationSchema returns limit/offset schema properties for list tools
+// whose APIs return the full collection and are paged client-side
+func OffsetPaginationSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"limit": map[string]interface{}{
+			"type":        "integer",
+			"description": fmt.Sprintf("Maximum number of results to return (default: %d, max: %d)", DefaultPageLimit, MaxPageLimit),
+			"default":     DefaultPageLimit,
+			"minimum":     1,
+			"maximum":     MaxPageLimit,
+		},
+		"offset": map[string]interface{}{
+			"type":        "integer",
+			"description": "Number of results to skip. Use _pagination.next_offset from a previous response to get the next page",
+			"default":     0,
+			"minimum":     0,
+		},
+	}
+}
+
+// ApplyOffsetPagination pages the list found under the first matching key in listKeys
+// and adds _pagination metadata (total, returned, offset, limit, has_more, next_offset).
+// The input result is not modified. Results without a matching list are returned as-is.
+func ApplyOffsetPagination(result map[string]interface{}, args map[string]interface{}, listKeys ...string) (map[string]interface{}, error) {
+	pagination, err := GetPaginationParams(args)
+	if err != nil {
+		return nil, err
+	}
+	limit, _ := pagination["limit"].(int)
+
+	offset := 0
+	if raw, ok := pagination["offset"]; ok {
+		switch v := raw.(type) {
+		case float64:
+			offset = int(v)
+		case int:
+			offset = v
+		default:
+			return nil, fmt.Errorf("offset must be a number")
+		}
+		if offset < 0 {
+			return nil, fmt.Errorf("offset must be non-negative")
+		}
+	}
+
+	for _, key := range listKeys {
+		items, ok := result[key].([]interface{})
+		if !ok {
+			continue
+		}
+
+		total := len(items)
+		start := min(offset, total)
+		end := min(start+limit, total)
+
+		paged := make(map[string]interface{}, len(result)+1)
+		for k, v := range result {
+			paged[k] = v
+		}
+		paged[key] = items[start:end]
+
+		meta := map[string]interface{}{
+			"total":    total,
+			"returned": end - start,
+			"offset":   offset,
+			"limit":    limit,
+			"has_more": end < total,
+		}
+		if end < total {
+			meta["next_offset"] = end
+		}
+		paged["_pagination"] = meta
+		return paged, nil
+	}
+
+	return result, nil
+}
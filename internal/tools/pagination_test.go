@@ -284,3 +284,51 @@ func TestEnhanceResultWithPagination(t *testing.T) {
 		assert.Contains(t, mcpMeta, "pagination")
 	})
 }
+
+func TestApplyOffsetPagination(t *testing.T) {
+	items := make([]interface{}, 0, 120)
+	for i := 0; i < 120; i++ {
+		items = append(items, map[string]interface{}{"id": i})
+	}
+	result := map[string]interface{}{"views": items}
+
+	t.Run("default limit", func(t *testing.T) {
+		paged, err := ApplyOffsetPagination(result, nil, "views")
+		require.NoError(t, err)
+		assert.Len(t, paged["views"], DefaultPageLimit)
+		meta := paged["_pagination"].(map[string]interface{})
+		assert.Equal(t, 120, meta["total"])
+		assert.Equal(t, DefaultPageLimit, meta["returned"])
+		assert.Equal(t, true, meta["has_more"])
+		assert.Equal(t, DefaultPageLimit, meta["next_offset"])
+		assert.Len(t, result["views"], 120, "input must not be modified")
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		paged, err := ApplyOffsetPagination(result, map[string]interface{}{"limit": float64(30), "offset": float64(100)}, "views")
+		require.NoError(t, err)
+		assert.Len(t, paged["views"], 20)
+		meta := paged["_pagination"].(map[string]interface{})
+		assert.Equal(t, false, meta["has_more"])
+		assert.NotContains(t, meta, "next_offset")
+	})
+
+	t.Run("offset past end", func(t *testing.T) {
+		paged, err := ApplyOffsetPagination(result, map[string]interface{}{"offset": float64(500)}, "views")
+		require.NoError(t, err)
+		assert.Len(t, paged["views"], 0)
+	})
+
+	t.Run("fallback list key", func(t *testing.T) {
+		paged, err := ApplyOffsetPagination(map[string]interface{}{"items": items[:3]}, nil, "dashboards", "items")
+		require.NoError(t, err)
+		assert.Equal(t, 3, paged["_pagination"].(map[string]interface{})["total"])
+	})
+
+	t.Run("invalid offset", func(t *testing.T) {
+		_, err := ApplyOffsetPagination(result, map[string]interface{}{"offset": float64(-1)}, "views")
+		assert.Error(t, err)
+		_, err = ApplyOffsetPagination(result, map[string]interface{}{"offset": "2"}, "views")
+		assert.Error(t, err)
+	})
+}
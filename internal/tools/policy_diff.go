@@ -0,0 +1,424 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// defaultHighVolumeThreshold is the daily log count above which a stream is considered high volume
+const defaultHighVolumeThreshold = 100000
+
+// policyPriorityRank orders policy priorities from dropped (block) to fully indexed (high)
+var policyPriorityRank = map[string]int{
+	"type_block":  0,
+	"type_low":    1,
+	"type_medium": 2,
+	"type_high":   3,
+}
+
+// normalizePolicyPriority accepts both "type_low" and "low" forms
+func normalizePolicyPriority(raw interface{}) string {
+	s, _ := raw.(string)
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s != "" && !strings.HasPrefix(s, "type_") {
+		s = "type_" + s
+	}
+	return s
+}
+
+// PolicyMatchCriteria is the set of logs a policy applies to
+type PolicyMatchCriteria struct {
+	Application string   `json:"application"`
+	Subsystem   string   `json:"subsystem"`
+	Severities  []string `json:"severities"`
+	Priority    string   `json:"priority"`
+	Filter      string   `json:"filter"` // DataPrime filter expression, empty when all logs match
+}
+
+// policyRuleFilter converts an application or subsystem rule into a description and a DataPrime condition
+func policyRuleFilter(rule interface{}, field string) (desc, cond string) {
+	r, ok := rule.(map[string]interface{})
+	if !ok {
+		return "(any)", ""
+	}
+	name, _ := r["name"].(string)
+	ruleType, _ := r["rule_type_id"].(string)
+	if name == "" {
+		return "(any)", ""
+	}
+	if ruleType == "" {
+		ruleType = "is"
+	}
+
+	escaped := escapeDataPrimeString(name)
+	desc = fmt.Sprintf("%s '%s'", ruleType, name)
+	switch ruleType {
+	case "is_not":
+		cond = fmt.Sprintf("%s != '%s'", field, escaped)
+	case "includes":
+		cond = fmt.Sprintf("%s.contains('%s')", field, escaped)
+	case "starts_with", "start_with":
+		cond = fmt.Sprintf("%s.startsWith('%s')", field, escaped)
+	default:
+		cond = fmt.Sprintf("%s == '%s'", field, escaped)
+	}
+	return desc, cond
+}
+
+// PolicyCriteria extracts the match criteria of a policy and builds the equivalent DataPrime filter
+func PolicyCriteria(policy map[string]interface{}) PolicyMatchCriteria {
+	var c PolicyMatchCriteria
+	var conds []string
+
+	var cond string
+	c.Application, cond = policyRuleFilter(policy["application_rule"], "$l.applicationname")
+	if cond != "" {
+		conds = append(conds, cond)
+	}
+	c.Subsystem, cond = policyRuleFilter(policy["subsystem_rule"], "$l.subsystemname")
+	if cond != "" {
+		conds = append(conds, cond)
+	}
+
+	if logRules, ok := policy["log_rules"].(map[string]interface{}); ok {
+		sevs, _ := logRules["severities"].([]interface{})
+		var sevConds []string
+		for _, s := range sevs {
+			level, ok := NormalizeSeverity(s)
+			if !ok {
+				continue
+			}
+			name := SeverityName(level)
+			c.Severities = append(c.Severities, strings.ToLower(name))
+			sevConds = append(sevConds, "$m.severity == "+strings.ToUpper(name))
+		}
+		if len(sevConds) == 1 {
+			conds = append(conds, sevConds[0])
+		} else if len(sevConds) > 1 {
+			conds = append(conds, "("+strings.Join(sevConds, " || ")+")")
+		}
+	}
+
+	c.Priority = normalizePolicyPriority(policy["priority"])
+	c.Filter = strings.Join(conds, " && ")
+	return c
+}
+
+// policyCountQuery returns the DataPrime query counting the logs matched by the criteria
+func policyCountQuery(c PolicyMatchCriteria) string {
+	if c.Filter == "" {
+		return "source logs | aggregate count() as count"
+	}
+	return "source logs | filter " + c.Filter + " | aggregate count() as count"
+}
+
+// mergePolicy overlays the proposed fields on the current policy; a null value removes the field
+func mergePolicy(current, proposed map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(current)+len(proposed))
+	for k, v := range current {
+		merged[k] = v
+	}
+	for k, v := range proposed {
+		if v == nil {
+			delete(merged, k)
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+// DiffPoliciesTool previews the effect of a policy change before it is applied
+type DiffPoliciesTool struct{ *BaseTool }
+
+// NewDiffPoliciesTool creates a new tool instance
+func NewDiffPoliciesTool(c client.Doer, l *zap.Logger) *DiffPoliciesTool {
+	return &DiffPoliciesTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *DiffPoliciesTool) Name() string { return "diff_policies" }
+
+// Annotations returns tool hints for LLMs
+func (t *DiffPoliciesTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Preview Policy Change")
+}
+
+// DefaultTimeout returns the timeout (the policy is fetched and up to two count queries run)
+func (t *DiffPoliciesTool) DefaultTimeout() time.Duration {
+	return DefaultQueryTimeout
+}
+
+// Description returns the tool description
+func (t *DiffPoliciesTool) Description() string {
+	return `Preview the cost impact of a policy change before calling update_policy.
+
+Fetches the current policy, overlays the proposed fields (omitted fields keep their current
+values, null removes a field), and shows the current vs proposed match criteria (application,
+subsystem, severities, priority) plus a field-level diff.
+
+With count_query=true, counts the logs matched by the current and proposed filters over the
+last 24 hours and warns when the change would start blocking or downgrading a high-volume stream.
+
+**Related tools:** get_policy, update_policy, export_data_usage`
+}
+
+// InputSchema returns the input schema
+func (t *DiffPoliciesTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"id": map[string]interface{}{
+				"type":        "string",
+				"description": "ID of the policy to change",
+			},
+			"policy": map[string]interface{}{
+				"type":        "object",
+				"description": "Proposed policy fields (same shape as update_policy). Omitted fields keep their current values.",
+			},
+			"count_query": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Count the logs matched by the current and proposed filters over the last 24 hours (default: false)",
+				"default":     false,
+			},
+			"high_volume_threshold": map[string]interface{}{
+				"type":        "integer",
+				"description": fmt.Sprintf("Daily log count at which a stream counts as high volume (default: %d)", defaultHighVolumeThreshold),
+				"minimum":     1,
+			},
+		},
+		"required": []string{"id", "policy"},
+		"examples": []interface{}{
+			map[string]interface{}{
+				"id": "policy-123",
+				"policy": map[string]interface{}{
+					"priority": "type_block",
+				},
+				"count_query": true,
+			},
+		},
+	}
+}
+
+// Execute executes the tool
+func (t *DiffPoliciesTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	id, err := GetStringParam(args, "id", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	proposed, err := GetObjectParam(args, "policy", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	countQuery, err := GetBoolParam(args, "count_query", false)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	threshold, err := GetIntParam(args, "high_volume_threshold", false)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	if threshold <= 0 {
+		threshold = defaultHighVolumeThreshold
+	}
+
+	current, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/policies/" + id})
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.IsNotFound() {
+			return NewToolResultErrorWithSuggestion(fmt.Sprintf("Policy not found with ID: %s", id), "Use 'list_policies' to see available policies and their IDs."), nil
+		}
+		return NewToolResultErrorWithCode(ClassifyError(err), fmt.Sprintf("Failed to fetch policy %s: %v", id, err)), nil
+	}
+
+	merged := mergePolicy(current, proposed)
+	before := PolicyCriteria(current)
+	after := PolicyCriteria(merged)
+
+	var counts []float64
+	var countErr error
+	if countQuery {
+		counts, countErr = t.countMatchedLogs(ctx, before, after)
+	}
+
+	name, _ := current["name"].(string)
+	var sb strings.Builder
+	sb.WriteString("## Policy Change Preview\n\n")
+	fmt.Fprintf(&sb, "**Policy:** %s (%s)\n\n", name, id)
+
+	sb.WriteString("### Match Criteria\n\n")
+	sb.WriteString("| Criterion | Current | Proposed |\n|-----------|---------|----------|\n")
+	writeCriteriaRow(&sb, "Application", before.Application, after.Application)
+	writeCriteriaRow(&sb, "Subsystem", before.Subsystem, after.Subsystem)
+	writeCriteriaRow(&sb, "Severities", severitiesLabel(before.Severities), severitiesLabel(after.Severities))
+	writeCriteriaRow(&sb, "Priority", priorityLabel(before.Priority), priorityLabel(after.Priority))
+
+	diffs := DiffResources(current, merged)
+	sb.WriteString("\n### Field Changes\n\n")
+	if len(diffs) == 0 {
+		sb.WriteString("The proposed configuration is identical to the current policy.\n")
+	} else {
+		sb.WriteString(FormatFieldDiffs(diffs))
+	}
+
+	if countQuery {
+		sb.WriteString("\n### Matched Volume (last 24h)\n\n")
+		if countErr != nil {
+			fmt.Fprintf(&sb, "Count query failed: %v\n", countErr)
+		} else {
+			fmt.Fprintf(&sb, "- Current filter: %.0f logs\n", counts[0])
+			fmt.Fprintf(&sb, "- Proposed filter: %.0f logs\n", counts[1])
+		}
+	}
+
+	warnings := policyChangeWarnings(before, after, counts, float64(threshold))
+	if countQuery && countErr == nil && len(warnings) == 0 && len(diffs) > 0 {
+		sb.WriteString("\n✅ No high-volume stream starts being blocked or downgraded by this change.\n")
+	}
+	if len(warnings) > 0 {
+		sb.WriteString("\n### ⚠️ Warnings\n\n")
+		for _, w := range warnings {
+			fmt.Fprintf(&sb, "- %s\n", w)
+		}
+	}
+	if !countQuery && policyPriorityLowered(before.Priority, after.Priority) {
+		sb.WriteString("\n💡 Re-run with `count_query: true` to see how many logs the proposed filter matches.\n")
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: ensureResponseLimit(sb.String(), t.logger)}},
+	}, nil
+}
+
+// countMatchedLogs counts the logs matched by the current and proposed criteria over the last day
+func (t *DiffPoliciesTool) countMatchedLogs(ctx context.Context, before, after PolicyMatchCriteria) ([]float64, error) {
+	endDate := time.Now().UTC()
+	startDate := endDate.Add(-24 * time.Hour)
+
+	queries := []string{policyCountQuery(before), policyCountQuery(after)}
+	var reqs []*client.Request
+	for _, q := range queries {
+		query, _, err := PrepareQuery(q, "archive", "dataprime")
+		if err != nil {
+			return nil, fmt.Errorf("invalid count query: %w", err)
+		}
+		reqs = append(reqs, &client.Request{
+			Method: "POST",
+			Path:   "/v1/query",
+			Body: map[string]interface{}{
+				"query":      query,
+				"tier":       "archive",
+				"syntax":     "dataprime",
+				"start_date": startDate.Format(time.RFC3339),
+				"end_date":   endDate.Format(time.RFC3339),
+			},
+		})
+	}
+	if queries[0] == queries[1] {
+		reqs = reqs[:1]
+	}
+
+	results := t.ExecuteConcurrent(ctx, reqs, len(reqs))
+	counts := make([]float64, 0, 2)
+	for _, res := range results {
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		counts = append(counts, totalCount(res.Result))
+	}
+	if len(counts) == 1 {
+		counts = append(counts, counts[0])
+	}
+	return counts, nil
+}
+
+// totalCount sums the count column of an aggregation result
+func totalCount(result map[string]interface{}) float64 {
+	var total float64
+	for _, c := range countsByApplication(result) {
+		total += c
+	}
+	return total
+}
+
+// policyPriorityLowered reports whether the priority moves to a cheaper tier (or to block)
+func policyPriorityLowered(before, after string) bool {
+	b, okB := policyPriorityRank[before]
+	a, okA := policyPriorityRank[after]
+	if !okA {
+		return false
+	}
+	if !okB {
+		// An unspecified priority keeps logs; only blocking is a downgrade from it
+		return a == 0
+	}
+	return a < b
+}
+
+// policyChangeWarnings returns warnings for changes that block or downgrade a high-volume stream.
+// counts holds the current and proposed matched volumes and is nil when no count was run.
+func policyChangeWarnings(before, after PolicyMatchCriteria, counts []float64, threshold float64) []string {
+	var warnings []string
+	lowered := policyPriorityLowered(before.Priority, after.Priority)
+	widenedBlock := after.Priority == "type_block" && before.Priority == "type_block" && before.Filter != after.Filter
+
+	if after.Priority == "type_block" && after.Filter == "" {
+		warnings = append(warnings, "The proposed policy blocks ALL logs: it has no application, subsystem, or severity rule.")
+	}
+	if counts == nil {
+		if lowered && after.Priority == "type_block" {
+			warnings = append(warnings, "This change starts blocking the matched logs; they will no longer be stored or searchable.")
+		}
+		return warnings
+	}
+
+	proposed := counts[1]
+	switch {
+	case lowered && proposed >= threshold:
+		verb := "downgrades"
+		if after.Priority == "type_block" {
+			verb = "starts blocking"
+		}
+		warnings = append(warnings, fmt.Sprintf("This change %s a high-volume stream: %.0f logs matched in the last 24h (threshold %.0f), moving from %s to %s.",
+			verb, proposed, threshold, priorityLabel(before.Priority), priorityLabel(after.Priority)))
+	case widenedBlock && proposed > counts[0] && proposed-counts[0] >= threshold:
+		warnings = append(warnings, fmt.Sprintf("The new match criteria block %.0f more logs per day than the current ones (%.0f → %.0f).",
+			proposed-counts[0], counts[0], proposed))
+	case lowered && after.Priority == "type_block":
+		warnings = append(warnings, fmt.Sprintf("This change starts blocking %.0f logs per day; they will no longer be stored or searchable.", proposed))
+	}
+	return warnings
+}
+
+// writeCriteriaRow writes one criteria table row, marking changed values
+func writeCriteriaRow(sb *strings.Builder, label, before, after string) {
+	marker := ""
+	if before != after {
+		marker = " ✏️"
+	}
+	fmt.Fprintf(sb, "| %s%s | %s | %s |\n", label, marker, before, after)
+}
+
+// severitiesLabel renders a severity list, or "(all)" when the policy has no severity rule
+func severitiesLabel(sevs []string) string {
+	if len(sevs) == 0 {
+		return "(all)"
+	}
+	return strings.Join(sevs, ", ")
+}
+
+// priorityLabel renders a priority, or "(unspecified)" when it is not set
+func priorityLabel(p string) string {
+	if p == "" {
+		return "(unspecified)"
+	}
+	return p
+}
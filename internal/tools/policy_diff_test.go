@@ -0,0 +1,128 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestPolicyCriteria(t *testing.T) {
+	c := PolicyCriteria(map[string]interface{}{
+		"priority":         "type_low",
+		"application_rule": map[string]interface{}{"name": "o'brien", "rule_type_id": "starts_with"},
+		"subsystem_rule":   map[string]interface{}{"name": "worker", "rule_type_id": "is_not"},
+		"log_rules":        map[string]interface{}{"severities": []interface{}{"debug", "verbose"}},
+	})
+
+	if c.Application != "starts_with 'o'brien'" || c.Subsystem != "is_not 'worker'" {
+		t.Errorf("unexpected rule descriptions: %+v", c)
+	}
+	if strings.Join(c.Severities, ",") != "debug,verbose" {
+		t.Errorf("Severities = %v", c.Severities)
+	}
+	want := `$l.applicationname.startsWith('o\'brien') && $l.subsystemname != 'worker' && ($m.severity == DEBUG || $m.severity == VERBOSE)`
+	if c.Filter != want {
+		t.Errorf("Filter = %s\nwant %s", c.Filter, want)
+	}
+	if _, _, err := PrepareQuery(policyCountQuery(c), "archive", "dataprime"); err != nil {
+		t.Errorf("count query is invalid: %v", err)
+	}
+
+	if all := PolicyCriteria(map[string]interface{}{"priority": "block"}); all.Filter != "" || all.Priority != "type_block" {
+		t.Errorf("expected match-all criteria with normalized priority, got %+v", all)
+	}
+}
+
+func TestPolicyChangeWarnings(t *testing.T) {
+	medium := PolicyMatchCriteria{Priority: "type_medium", Filter: "$l.applicationname == 'api'"}
+	block := PolicyMatchCriteria{Priority: "type_block", Filter: "$l.applicationname == 'api'"}
+
+	if w := policyChangeWarnings(medium, block, []float64{500000, 500000}, 100000); len(w) != 1 || !strings.Contains(w[0], "starts blocking a high-volume stream") {
+		t.Errorf("expected high-volume block warning, got %v", w)
+	}
+	if w := policyChangeWarnings(medium, block, []float64{10, 10}, 100000); len(w) != 1 || strings.Contains(w[0], "high-volume") {
+		t.Errorf("expected low-volume block notice, got %v", w)
+	}
+	if w := policyChangeWarnings(block, medium, []float64{500000, 500000}, 100000); len(w) != 0 {
+		t.Errorf("raising the priority should not warn, got %v", w)
+	}
+
+	widened := PolicyMatchCriteria{Priority: "type_block", Filter: "$l.applicationname.startsWith('a')"}
+	if w := policyChangeWarnings(block, widened, []float64{1000, 300000}, 100000); len(w) != 1 || !strings.Contains(w[0], "299000 more logs") {
+		t.Errorf("expected widened block warning, got %v", w)
+	}
+
+	if w := policyChangeWarnings(medium, PolicyMatchCriteria{Priority: "type_block"}, nil, 100000); len(w) != 2 || !strings.Contains(w[0], "blocks ALL logs") {
+		t.Errorf("expected block-all warnings, got %v", w)
+	}
+}
+
+func TestDiffPoliciesTool_Execute(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.DoFunc = func(_ context.Context, req *client.Request) (*client.Response, error) {
+		var body interface{}
+		if req.Method == "GET" {
+			body = map[string]interface{}{
+				"id":               "pol-1",
+				"name":             "API logs",
+				"priority":         "type_medium",
+				"application_rule": map[string]interface{}{"name": "api", "rule_type_id": "is"},
+			}
+		} else {
+			body = map[string]interface{}{"events": []interface{}{map[string]interface{}{"count": 250000}}}
+		}
+		data, _ := json.Marshal(body)
+		return &client.Response{StatusCode: http.StatusOK, Body: data}, nil
+	}
+
+	tool := NewDiffPoliciesTool(mock, zap.NewNop())
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{
+		"id":          "pol-1",
+		"policy":      map[string]interface{}{"priority": "type_block"},
+		"count_query": true,
+	})
+	if err != nil || result.IsError {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	for _, want := range []string{
+		"| Application | is 'api' | is 'api' |",
+		"| Priority ✏️ | type_medium | type_block |",
+		"- `priority`: \"type_medium\" → \"type_block\"",
+		"- Proposed filter: 250000 logs",
+		"starts blocking a high-volume stream",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("result missing %q:\n%s", want, text)
+		}
+	}
+
+	// The filter is unchanged, so only one count query runs after the GET
+	if mock.RequestCount() != 2 {
+		t.Errorf("RequestCount = %d, want 2", mock.RequestCount())
+	}
+	if q := mock.LastRequest().Body.(map[string]interface{})["query"].(string); !strings.Contains(q, "$l.applicationname == 'api'") {
+		t.Errorf("count query not scoped to the policy filter: %s", q)
+	}
+}
+
+func TestDiffPoliciesTool_NotFound(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.RespondWith(http.StatusNotFound, map[string]interface{}{"message": "not found"})
+
+	tool := NewDiffPoliciesTool(mock, zap.NewNop())
+	result, _ := tool.Execute(testCtx(mock), map[string]interface{}{
+		"id":     "missing",
+		"policy": map[string]interface{}{"priority": "type_low"},
+	})
+	if !result.IsError || !strings.Contains(result.Content[0].(*mcp.TextContent).Text, "list_policies") {
+		t.Errorf("expected not-found error suggesting list_policies")
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"go.uber.org/zap"
 
 	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+	mcperrors "github.com/tareqmamari/cloud-logs-mcp/internal/errors"
 )
 
 // Query timeout constants
@@ -149,9 +151,20 @@ var validQueryFields = map[string]bool{
 	"default_source":           true,
 	"strict_fields_validation": true,
 	"now_date":                 true,
+	// Named query from the local library (save_query)
+	"saved_query": true,
 	// Response format controls
-	"summary_only": true,
-	"raw_output":   true,
+	"summary_only":           true,
+	"raw_output":             true,
+	"auto_correct_queries":   true,
+	"timezone":               true,
+	"relative_time":          true,
+	"max_events":             true,
+	"fields":                 true,
+	"redact_pii":             true,
+	"dedupe":                 true,
+	"use_cache":              true,
+	"clustering_sensitivity": true,
 	// Convenience filter aliases (resolved to query filters)
 	"applicationName":  true,
 	"namespace":        true,
@@ -177,7 +190,7 @@ func (t *QueryTool) InputSchema() interface{} {
 		"properties": map[string]interface{}{
 			"query": map[string]interface{}{
 				"type":        "string",
-				"description": "The query string to execute (DataPrime or Lucene syntax). Max 4096 characters.",
+				"description": "The query string to execute (DataPrime or Lucene syntax). Max 4096 characters. Required unless saved_query is set.",
 				"minLength":   1,
 				"maxLength":   4096,
 				"examples": []string{
@@ -186,11 +199,15 @@ func (t *QueryTool) InputSchema() interface{} {
 					"source logs | filter $d.message.contains('timeout') | limit 100",
 				},
 			},
+			"saved_query": map[string]interface{}{
+				"type":        "string",
+				"description": "Run a query saved with save_query instead of passing query. Its saved syntax and tier apply unless set here.",
+			},
 			"tier": map[string]interface{}{
 				"type":        "string",
-				"description": "Log tier to query. archive (default, aliases: COS, storage, cold), frequent_search (aliases: PI, priority, insights, quick), or unspecified",
+				"description": "Log tier to query. frequent_search (default, aliases: PI, priority, insights, quick), archive (aliases: COS, storage, cold; slower, use submit_background_query for windows over 24h), or unspecified",
 				"enum":        []string{"unspecified", "archive", "frequent_search"},
-				"default":     "archive",
+				"default":     "frequent_search",
 			},
 			"syntax": map[string]interface{}{
 				"type":        "string",
@@ -233,11 +250,31 @@ func (t *QueryTool) InputSchema() interface{} {
 				"description": "If true, return only statistical summary (severity distribution, top apps, counts) without raw events. Reduces response tokens by ~90%. Default: false.",
 				"default":     false,
 			},
+			"clustering_sensitivity": clusteringSensitivitySchema,
+			"auto_correct_queries": map[string]interface{}{
+				"type":        "boolean",
+				"description": "If false, return a validation error describing the correction instead of silently rewriting the query. Defaults to the server setting (LOGS_AUTO_CORRECT_QUERIES, true).",
+			},
 			"raw_output": map[string]interface{}{
 				"type":        "boolean",
 				"description": "If true, return the full uncompacted log entries including the complete user_data JSON payload. Use when log messages contain structured JSON that you need to inspect. Default: false.",
 				"default":     false,
 			},
+			"timezone":      timezoneSchema,
+			"relative_time": relativeTimeSchema,
+			"max_events": map[string]interface{}{
+				"type":        "integer",
+				"description": "Maximum log entries to keep from the response before formatting. Defaults to the server setting (LOGS_MAX_QUERY_EVENTS, 2000) and is bounded by LOGS_MAX_QUERY_EVENTS_LIMIT (20000). Raise it only when your client can handle large results; response size limits still apply.",
+				"minimum":     1,
+			},
+			"fields":    fieldsSchema,
+			"use_cache": useCacheSchema,
+			"redact_pii": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Mask personal data (emails, IP addresses, card numbers, phone numbers, plus LOGS_PII_PATTERNS) in every string of the returned events, e.g. for screen sharing. Default: false.",
+				"default":     false,
+			},
+			"dedupe": dedupeSchema,
 			// Application filter with aliases
 			"applicationName": map[string]interface{}{
 				"type":        "string",
@@ -257,7 +294,7 @@ func (t *QueryTool) InputSchema() interface{} {
 				"description": "Alias for subsystemName - filter by component/resource name",
 			},
 		},
-		"required":             []string{"query", "start_date", "end_date"},
+		"required":             []string{"start_date", "end_date"},
 		"additionalProperties": false,
 	}
 }
@@ -271,7 +308,7 @@ func validateQueryFields(arguments map[string]interface{}) error {
 		}
 	}
 	if len(unknownFields) > 0 {
-		return fmt.Errorf("unknown field(s): %s (valid fields: query, tier, syntax, start_date, end_date, limit, default_source, strict_fields_validation, now_date, applicationName, subsystemName)",
+		return fmt.Errorf("unknown field(s): %s (valid fields: query, saved_query, tier, syntax, start_date, end_date, limit, default_source, strict_fields_validation, now_date, applicationName, subsystemName)",
 			strings.Join(unknownFields, ", "))
 	}
 	return nil
@@ -307,7 +344,7 @@ func buildQueryMetadata(arguments map[string]interface{}) (map[string]interface{
 	// Tier with default and normalization
 	tier, _ := GetStringParam(arguments, "tier", false)
 	if tier == "" {
-		tier = "archive"
+		tier = "frequent_search"
 	} else {
 		tier = normalizeTier(tier)
 	}
@@ -369,6 +406,76 @@ func buildQueryMetadata(arguments map[string]interface{}) (map[string]interface{
 	return metadata, tier, syntax, nil
 }
 
+// autoCorrectQueries is the server-wide default for applying query auto-corrections
+var autoCorrectQueries atomic.Bool
+
+func init() {
+	autoCorrectQueries.Store(true)
+}
+
+// SetAutoCorrectQueries sets whether queries are auto-corrected by default
+func SetAutoCorrectQueries(enabled bool) {
+	autoCorrectQueries.Store(enabled)
+}
+
+// autoCorrectEnabled resolves the auto_correct_queries argument against the server default
+func autoCorrectEnabled(args map[string]interface{}) bool {
+	if v, ok := args["auto_correct_queries"].(bool); ok {
+		return v
+	}
+	return autoCorrectQueries.Load()
+}
+
+// NewAutoCorrectionDisabledError reports the corrections that would have been applied
+// to a query when auto-correction is turned off
+func NewAutoCorrectionDisabledError(original, corrected string, corrections []string) *mcp.CallToolResult {
+	var sb strings.Builder
+	sb.WriteString("Query validation failed (auto-correction disabled). The following corrections are needed:\n")
+	for _, c := range corrections {
+		fmt.Fprintf(&sb, "- %s\n", c)
+	}
+	fmt.Fprintf(&sb, "\nOriginal query:\n%s\n\nCorrected query:\n%s", original, corrected)
+	return NewToolResultErrorWithSuggestion(sb.String(),
+		"Apply the corrections and re-run, or set auto_correct_queries=true to have them applied automatically.")
+}
+
+// archiveBackgroundThreshold is the archive query window above which submit_background_query is suggested
+const archiveBackgroundThreshold = 24 * time.Hour
+
+// getMaxEventsArg reads the optional max_events argument, defaulting to the configured
+// event cap and rejecting values above the server maximum
+func getMaxEventsArg(args map[string]interface{}) (int, error) {
+	maxEvents, err := GetIntParam(args, "max_events", false)
+	if err != nil {
+		return 0, err
+	}
+	if maxEvents == 0 {
+		return currentMaxSSEEvents(), nil
+	}
+	if maxEvents < 0 {
+		return 0, fmt.Errorf("max_events must be positive, got %d", maxEvents)
+	}
+	if limit := currentQueryEventsLimit(); maxEvents > limit {
+		return 0, fmt.Errorf("max_events %d exceeds the server maximum of %d (LOGS_MAX_QUERY_EVENTS_LIMIT)", maxEvents, limit)
+	}
+	return maxEvents, nil
+}
+
+// queryWindow returns the duration between the query's start_date and end_date
+func queryWindow(metadata map[string]interface{}) (time.Duration, bool) {
+	startStr, _ := metadata["start_date"].(string)
+	endStr, _ := metadata["end_date"].(string)
+	start, err := time.Parse(time.RFC3339, startStr)
+	if err != nil {
+		return 0, false
+	}
+	end, err := time.Parse(time.RFC3339, endStr)
+	if err != nil {
+		return 0, false
+	}
+	return end.Sub(start), true
+}
+
 // addQueryMetadataToResult adds query execution metadata to the result
 func addQueryMetadataToResult(result map[string]interface{}, metadata map[string]interface{}, tier, syntax, query string, corrections []string, instanceInfo *client.InstanceInfo) {
 	queryMeta := map[string]interface{}{
@@ -378,8 +485,20 @@ func addQueryMetadataToResult(result map[string]interface{}, metadata map[string
 		"end_date":   metadata["end_date"],
 		"limit":      metadata["limit"],
 	}
+	if tier == "archive" {
+		queryMeta["tier_note"] = "Archive (COS) queries are slower than frequent_search; use tier=frequent_search for recent indexed data."
+		if window, ok := queryWindow(metadata); ok && window > archiveBackgroundThreshold {
+			queryMeta["suggestion"] = fmt.Sprintf(
+				"This archive query spans %s. Consider submit_background_query for large archive windows to avoid timeouts and truncated results.",
+				window.Round(time.Hour))
+		}
+	}
+	// Always surface the corrections list so callers can tell "none applied" from "not reported"
+	if corrections == nil {
+		corrections = []string{}
+	}
+	queryMeta["auto_corrections"] = corrections
 	if len(corrections) > 0 {
-		queryMeta["auto_corrections"] = corrections
 		queryMeta["corrected_query"] = query
 	}
 	// Add instance info so users know which IBM Cloud Logs instance was queried
@@ -396,18 +515,53 @@ func addQueryMetadataToResult(result map[string]interface{}, metadata map[string
 	result["_query_metadata"] = queryMeta
 }
 
+// withMaxEvents returns the request metadata with the event cap added, since results
+// capped at different sizes must not share a cache entry
+func withMaxEvents(metadata map[string]interface{}, maxEvents int) map[string]interface{} {
+	keyed := make(map[string]interface{}, len(metadata)+1)
+	for k, v := range metadata {
+		keyed[k] = v
+	}
+	keyed["max_events"] = maxEvents
+	return keyed
+}
+
 // Execute executes the tool
 func (t *QueryTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	session := GetSessionFromContext(ctx)
 
 	// Validate fields
 	if err := validateQueryFields(arguments); err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
+	}
+	if errResult := resolveSavedQuery(session, arguments); errResult != nil {
+		return errResult, nil
 	}
 
 	query, err := GetStringParam(arguments, "query", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
+	}
+	timezone, err := getTimezoneArg(arguments)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	maxEvents, err := getMaxEventsArg(arguments)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	fields, err := GetStringArrayParam(arguments, "fields", false)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	sensitivityArg, _ := GetStringParam(arguments, "clustering_sensitivity", false)
+	sensitivity, err := ParseClusteringSensitivity(sensitivityArg)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	dedupe, err := getDedupeArg(arguments)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	// Apply session filters if not explicitly specified
@@ -423,20 +577,30 @@ func (t *QueryTool) Execute(ctx context.Context, arguments map[string]interface{
 		return NewToolResultError(fmt.Sprintf("Query too long: %d characters (max 4096)", len(query))), nil
 	}
 
+	// Keep the arguments as given (before filters are folded into the query) for replay_query
+	replayArgs := make(map[string]interface{}, len(arguments))
+	for k, v := range arguments {
+		replayArgs[k] = v
+	}
+
 	// Apply filters to query
 	query = applyQueryFilters(query, arguments)
 
 	// Build metadata
 	metadata, tier, syntax, err := buildQueryMetadata(arguments)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	// Prepare query (auto-correct and validate) using central validator
+	originalQuery := query
 	var queryCorrections []string
 	query, queryCorrections, err = PrepareQuery(query, tier, syntax)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
+	}
+	if len(queryCorrections) > 0 && !autoCorrectEnabled(arguments) {
+		return NewAutoCorrectionDisabledError(originalQuery, query, queryCorrections), nil
 	}
 
 	// Execute request
@@ -453,13 +617,32 @@ func (t *QueryTool) Execute(ctx context.Context, arguments map[string]interface{
 		Timeout:   DefaultQueryTimeout,
 	}
 
-	result, err := t.ExecuteRequest(ctx, req)
-	if err != nil {
-		session.RecordToolUse(t.Name(), false, map[string]interface{}{
-			"query": query,
-			"error": err.Error(),
-		})
-		return NewToolResultError(FormatQueryError(query, err.Error())), nil
+	// Identical requests over the same absolute range are answered from the cache
+	requestHash, cacheable := queryRequestHash(instanceScope(ctx, session.InstanceID), query, withMaxEvents(metadata, maxEvents), time.Now())
+	useCache := true
+	if v, ok := arguments["use_cache"].(bool); ok {
+		useCache = v
+	}
+	var result map[string]interface{}
+	cacheHit := false
+	if cacheable && useCache {
+		result, cacheHit = getCachedQueryResult(ctx, requestHash)
+	}
+	if !cacheHit {
+		result, err = t.ExecuteRequestWithMaxEvents(ctx, req, maxEvents)
+		if err != nil {
+			session.RecordToolUse(t.Name(), false, map[string]interface{}{
+				"query": query,
+				"error": err.Error(),
+			})
+			if ClassifyError(err) == mcperrors.CodeForbidden {
+				return NewToolResultErrorFromErr(err), nil
+			}
+			return NewToolResultError(FormatQueryError(query, err.Error())), nil
+		}
+		if cacheable {
+			cacheQueryResult(ctx, requestHash, result)
+		}
 	}
 
 	// Record success and update session
@@ -470,6 +653,7 @@ func (t *QueryTool) Execute(ctx context.Context, arguments map[string]interface{
 		"tier":       tier,
 	})
 	session.SetLastQuery(query)
+	session.SetLastQueryArgs(replayArgs)
 
 	if limit, _ := GetIntParam(arguments, "limit", false); limit > 0 {
 		session.GetPreferences().PreferredLimit = limit
@@ -486,10 +670,27 @@ func (t *QueryTool) Execute(ctx context.Context, arguments map[string]interface{
 		instanceInfo = &info
 	}
 	addQueryMetadataToResult(result, metadata, tier, syntax, query, queryCorrections, instanceInfo)
+	setResultCacheInfo(result, requestHash, cacheHit)
+	setResultTimezone(result, timezone)
+	setResultRelativeTime(result, getRelativeTimeArg(arguments))
+	if meta, ok := result["_query_metadata"].(map[string]interface{}); ok {
+		meta["max_events"] = maxEvents
+	}
+	setResultFields(result, fields)
+	setResultDedupe(result, dedupe)
+	if redactPII, _ := GetBoolParam(arguments, "redact_pii", false); redactPII {
+		redacted := redactPIIInEvents(result)
+		if meta, ok := result["_query_metadata"].(map[string]interface{}); ok {
+			meta["pii_redacted"] = redacted
+		}
+	}
 
 	// Return response
 	summaryOnly, _ := GetBoolParam(arguments, "summary_only", false)
 	if summaryOnly {
+		if meta, ok := result["_query_metadata"].(map[string]interface{}); ok {
+			meta["clustering_sensitivity"] = string(sensitivity)
+		}
 		return t.FormatCompactSummary(result, "query_logs")
 	}
 
@@ -569,19 +770,19 @@ func (t *SubmitBackgroundQueryTool) InputSchema() interface{} {
 func (t *SubmitBackgroundQueryTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	query, err := GetStringParam(arguments, "query", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	syntax, err := GetStringParam(arguments, "syntax", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	// Prepare query (auto-correct and validate) using central validator
 	// Background queries default to archive tier
 	query, _, err = PrepareQuery(query, "archive", syntax)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	// Build request body with required fields
@@ -606,7 +807,12 @@ func (t *SubmitBackgroundQueryTool) Execute(ctx context.Context, arguments map[s
 
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
+	}
+
+	// Track the query so it can be listed or cancelled later
+	if queryID := backgroundQueryID(result); queryID != "" {
+		GetSessionFromContext(ctx).TrackBackgroundQuery(queryID, query)
 	}
 
 	return t.FormatResponseWithSuggestions(result, "submit_background_query")
@@ -657,7 +863,7 @@ func (t *GetBackgroundQueryStatusTool) InputSchema() interface{} {
 func (t *GetBackgroundQueryStatusTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	queryID, err := GetStringParam(arguments, "query_id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	req := &client.Request{
@@ -697,7 +903,11 @@ func (t *GetBackgroundQueryDataTool) Annotations() *mcp.ToolAnnotations {
 
 // Description returns the tool description
 func (t *GetBackgroundQueryDataTool) Description() string {
-	return "Retrieve the results of a completed background query"
+	return `Retrieve the results of a completed background query.
+
+Set output_mode to "file" for large result sets: the full decoded result is written to a local
+JSONL file (LOGS_EXPORT_DIR, default ~/.logs-mcp/exports) and only the file path plus a summary
+(event count, severity distribution, time range) is returned, avoiding response truncation.`
 }
 
 // InputSchema returns the input schema
@@ -709,6 +919,14 @@ func (t *GetBackgroundQueryDataTool) InputSchema() interface{} {
 				"type":        "string",
 				"description": "The unique identifier of the background query",
 			},
+			"output_mode": map[string]interface{}{
+				"type":        "string",
+				"description": "inline (default) returns events in the response; file writes all events to a JSONL file and returns its path with a summary",
+				"enum":        []string{"inline", "file"},
+				"default":     "inline",
+			},
+			"timezone":      timezoneSchema,
+			"relative_time": relativeTimeSchema,
 		},
 		"required": []string{"query_id"},
 	}
@@ -718,7 +936,16 @@ func (t *GetBackgroundQueryDataTool) InputSchema() interface{} {
 func (t *GetBackgroundQueryDataTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	queryID, err := GetStringParam(arguments, "query_id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
+	}
+
+	outputMode, _ := GetStringParam(arguments, "output_mode", false)
+	if outputMode != "" && outputMode != "inline" && outputMode != "file" {
+		return NewToolResultError(fmt.Sprintf("invalid output_mode '%s' (valid: inline, file)", outputMode)), nil
+	}
+	timezone, err := getTimezoneArg(arguments)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	req := &client.Request{
@@ -726,12 +953,34 @@ func (t *GetBackgroundQueryDataTool) Execute(ctx context.Context, arguments map[
 		Path:   "/v1/background_query/" + queryID + "/data",
 	}
 
-	result, err := t.ExecuteRequest(ctx, req)
+	if outputMode != "file" {
+		result, err := t.ExecuteRequest(ctx, req)
+		if err != nil {
+			return HandleGetError(err, "Background query data", queryID, "get_background_query_status"), nil
+		}
+		if result == nil {
+			result = make(map[string]interface{})
+		}
+		setResultTimezone(result, timezone)
+		setResultRelativeTime(result, getRelativeTimeArg(arguments))
+		return t.FormatResponseWithSummary(result, "query results")
+	}
+
+	result, err := t.ExecuteRequestWithMaxEvents(ctx, req, MaxExportEvents)
 	if err != nil {
 		return HandleGetError(err, "Background query data", queryID, "get_background_query_status"), nil
 	}
 
-	return t.FormatResponseWithSummary(result, "query results")
+	events, _ := result["events"].([]interface{})
+	path, err := writeEventsJSONL(currentExportDir(), queryID, events)
+	if err != nil {
+		return NewToolResultErrorWithSuggestion(err.Error(), "Check that LOGS_EXPORT_DIR is writable, or use output_mode=inline."), nil
+	}
+	truncated, _ := result["_truncated"].(bool)
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: formatExportSummary(path, events, truncated)}},
+	}, nil
 }
 
 // CancelBackgroundQueryTool cancels a running background query
@@ -779,7 +1028,7 @@ func (t *CancelBackgroundQueryTool) InputSchema() interface{} {
 func (t *CancelBackgroundQueryTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	queryID, err := GetStringParam(arguments, "query_id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	req := &client.Request{
@@ -789,8 +1038,9 @@ func (t *CancelBackgroundQueryTool) Execute(ctx context.Context, arguments map[s
 
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
+	GetSessionFromContext(ctx).SetBackgroundQueryStatus(queryID, "cancelled")
 
 	return t.FormatResponseWithSuggestions(result, "cancel_background_query")
 }
@@ -1,9 +1,16 @@
 package tools
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
 )
 
 // TestQueryTool_InputSchema verifies the schema matches API spec
@@ -14,7 +21,7 @@ func TestQueryTool_InputSchema(t *testing.T) {
 
 	// Verify schema structure
 	assert.Equal(t, "object", schema["type"])
-	assert.Equal(t, []string{"query", "start_date", "end_date"}, schema["required"])
+	assert.Equal(t, []string{"start_date", "end_date"}, schema["required"])
 
 	props := schema["properties"].(map[string]interface{})
 
@@ -27,7 +34,7 @@ func TestQueryTool_InputSchema(t *testing.T) {
 	tierProp, ok := props["tier"].(map[string]interface{})
 	assert.True(t, ok, "tier property should exist")
 	assert.Equal(t, "string", tierProp["type"])
-	assert.Equal(t, "archive", tierProp["default"], "tier should default to 'archive'")
+	assert.Equal(t, "frequent_search", tierProp["default"], "tier should default to 'frequent_search'")
 
 	enum, ok := tierProp["enum"].([]string)
 	assert.True(t, ok, "tier enum should be []string")
@@ -77,9 +84,9 @@ func TestQueryTool_DefaultParameterHandling(t *testing.T) {
 			args: map[string]interface{}{
 				"query": "source logs",
 			},
-			wantTier:   "archive",
+			wantTier:   "frequent_search",
 			wantSyntax: "dataprime",
-			wantLimit:  2000,
+			wantLimit:  200,
 		},
 		{
 			name: "custom tier and syntax",
@@ -90,7 +97,7 @@ func TestQueryTool_DefaultParameterHandling(t *testing.T) {
 			},
 			wantTier:   "archive",
 			wantSyntax: "lucene",
-			wantLimit:  2000,
+			wantLimit:  200,
 		},
 		{
 			name: "custom limit",
@@ -98,7 +105,7 @@ func TestQueryTool_DefaultParameterHandling(t *testing.T) {
 				"query": "source logs",
 				"limit": float64(100), // JSON numbers are float64
 			},
-			wantTier:   "archive",
+			wantTier:   "frequent_search",
 			wantSyntax: "dataprime",
 			wantLimit:  100,
 		},
@@ -106,30 +113,36 @@ func TestQueryTool_DefaultParameterHandling(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Extract tier with default (simulating Execute logic)
-			tier, _ := GetStringParam(tt.args, "tier", false)
-			if tier == "" {
-				tier = "archive"
-			}
+			tt.args["start_date"] = "2024-01-01T00:00:00Z"
+			tt.args["end_date"] = "2024-01-02T00:00:00Z"
+			metadata, tier, syntax, err := buildQueryMetadata(tt.args)
+			require.NoError(t, err)
 			assert.Equal(t, tt.wantTier, tier)
-
-			// Extract syntax with default (simulating Execute logic)
-			syntax, _ := GetStringParam(tt.args, "syntax", false)
-			if syntax == "" {
-				syntax = "dataprime"
-			}
+			assert.Equal(t, tt.wantTier, metadata["tier"])
 			assert.Equal(t, tt.wantSyntax, syntax)
-
-			// Extract limit with default (simulating Execute logic)
-			limit, _ := GetIntParam(tt.args, "limit", false)
-			if limit == 0 {
-				limit = 2000
-			}
-			assert.Equal(t, tt.wantLimit, limit)
+			assert.Equal(t, tt.wantLimit, metadata["limit"])
 		})
 	}
 }
 
+// TestQueryTool_DefaultTier verifies queries without a tier run on frequent_search
+func TestQueryTool_DefaultTier(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.DefaultResponse = &client.Response{StatusCode: 200, Body: []byte("data: {\"result\":{\"message\":\"test log\"}}\n")}
+	tool := NewQueryTool(mock, zap.NewNop())
+
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{
+		"query":      "source logs",
+		"start_date": "2024-01-01T00:00:00Z",
+		"end_date":   "2024-01-02T00:00:00Z",
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	metadata := mock.LastRequest().Body.(map[string]interface{})["metadata"].(map[string]interface{})
+	assert.Equal(t, "frequent_search", metadata["tier"])
+}
+
 // TestQueryTool_MissingRequiredQuery verifies proper error handling for missing required param
 func TestQueryTool_MissingRequiredQuery(t *testing.T) {
 	args := map[string]interface{}{
@@ -216,3 +229,194 @@ func TestQueryTool_LimitValidation(t *testing.T) {
 		})
 	}
 }
+
+// TestAddQueryMetadataToResult_ArchiveTier verifies tier notes and background query suggestions
+func TestAddQueryMetadataToResult_ArchiveTier(t *testing.T) {
+	tests := []struct {
+		name          string
+		tier          string
+		endDate       string
+		expectNote    bool
+		expectSuggest bool
+	}{
+		{"archive short window", "archive", "2024-01-01T06:00:00Z", true, false},
+		{"archive long window", "archive", "2024-01-05T00:00:00Z", true, true},
+		{"frequent search long window", "frequent_search", "2024-01-05T00:00:00Z", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata := map[string]interface{}{
+				"start_date": "2024-01-01T00:00:00Z",
+				"end_date":   tt.endDate,
+				"limit":      200,
+			}
+			result := map[string]interface{}{}
+			addQueryMetadataToResult(result, metadata, tt.tier, "dataprime", "source logs", nil, nil)
+
+			queryMeta := result["_query_metadata"].(map[string]interface{})
+			assert.Equal(t, tt.tier, queryMeta["tier"])
+			_, hasNote := queryMeta["tier_note"]
+			assert.Equal(t, tt.expectNote, hasNote)
+			suggestion, hasSuggestion := queryMeta["suggestion"].(string)
+			assert.Equal(t, tt.expectSuggest, hasSuggestion)
+			if tt.expectSuggest {
+				assert.Contains(t, suggestion, "submit_background_query")
+			}
+		})
+	}
+}
+
+// TestAddQueryMetadataToResult_CorrectionsAlwaysPresent verifies auto_corrections is reported even when empty
+func TestAddQueryMetadataToResult_CorrectionsAlwaysPresent(t *testing.T) {
+	result := map[string]interface{}{}
+	addQueryMetadataToResult(result, map[string]interface{}{}, "frequent_search", "dataprime", "source logs", nil, nil)
+
+	queryMeta := result["_query_metadata"].(map[string]interface{})
+	assert.Equal(t, []string{}, queryMeta["auto_corrections"])
+	assert.NotContains(t, queryMeta, "corrected_query")
+}
+
+// TestQueryTool_MaxEvents verifies the per-call event cap, its server bound and the pagination note
+func TestQueryTool_MaxEvents(t *testing.T) {
+	SetQueryEventLimits(3, 10)
+	defer SetQueryEventLimits(0, 0)
+
+	var sse strings.Builder
+	for i := 0; i < 5; i++ {
+		fmt.Fprintf(&sse, "data: {\"result\":{\"results\":[{\"metadata\":[{\"key\":\"timestamp\",\"value\":\"2024-05-01T20:4%d:00Z\"}],\"labels\":[],\"user_data\":\"{\\\"message\\\":\\\"m%d\\\"}\"}]}}\n", i, i)
+	}
+	mock := client.NewMockClient()
+	mock.DefaultResponse = &client.Response{StatusCode: 200, Body: []byte(sse.String())}
+	tool := NewQueryTool(mock, zap.NewNop())
+	args := map[string]interface{}{
+		"query":      "source logs",
+		"start_date": "2024-05-01T20:00:00Z",
+		"end_date":   "2024-05-01T21:00:00Z",
+	}
+
+	result, err := tool.Execute(testCtx(mock), args)
+	assert.NoError(t, err)
+	text := result.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, "Results returned: 3")
+	assert.Contains(t, text, "Event cap: 3 (raise with `max_events`, server maximum 10)")
+
+	args["max_events"] = 4
+	result, _ = tool.Execute(testCtx(mock), args)
+	text = result.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, "Results returned: 4")
+	assert.Contains(t, text, "Event cap: 4")
+
+	args["max_events"] = 11
+	result, _ = tool.Execute(testCtx(mock), args)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "exceeds the server maximum of 10")
+}
+
+// TestQueryTool_FieldsProjection verifies fields trims entries and reports absent keys once
+func TestQueryTool_FieldsProjection(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.DefaultResponse = &client.Response{
+		StatusCode: 200,
+		Body:       []byte("data: {\"result\":{\"results\":[{\"metadata\":[{\"key\":\"timestamp\",\"value\":\"2024-05-01T20:47:12Z\"},{\"key\":\"severity\",\"value\":\"5\"}],\"labels\":[{\"key\":\"applicationname\",\"value\":\"api\"}],\"user_data\":\"{\\\"message\\\":\\\"boom\\\",\\\"trace_id\\\":\\\"abc123\\\"}\"}]}}\n"),
+	}
+	tool := NewQueryTool(mock, zap.NewNop())
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{
+		"query":         "source logs",
+		"start_date":    "2024-05-01T20:00:00Z",
+		"end_date":      "2024-05-01T21:00:00Z",
+		"relative_time": false,
+		"fields":        []interface{}{"message", "trace_id", "nope", "nope"},
+	})
+	assert.NoError(t, err)
+	text := result.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, "> boom")
+	assert.Contains(t, text, "  - trace_id: abc123")
+	assert.NotContains(t, text, "2024-05-01T20:47:12Z")
+	assert.NotContains(t, text, "**api**")
+	assert.Contains(t, text, "- **Requested fields not present:** nope\n")
+}
+
+func TestProjectLogFields(t *testing.T) {
+	entries := []interface{}{
+		map[string]interface{}{"time": "t1", "message": "a", "app": "api"},
+		map[string]interface{}{"time": "t2", "level": "warn"},
+	}
+	projected, missing := ProjectLogFields(entries, []string{"time", "level", "sql"})
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"time": "t1"},
+		map[string]interface{}{"time": "t2", "level": "warn"},
+	}, projected)
+	assert.Equal(t, []string{"sql"}, missing)
+}
+
+func TestDedupeLogEntries(t *testing.T) {
+	entry := func(ts, msg string) map[string]interface{} {
+		return map[string]interface{}{"time": ts, "app": "api", "severity": "Error", "message": msg}
+	}
+	entries := []interface{}{
+		entry("2024-05-01T20:00:03Z", "retrying"),
+		entry("2024-05-01T20:00:01Z", "retrying"),
+		entry("2024-05-01T20:00:02Z", "connected"),
+		entry("2024-05-01T20:00:04Z", "retrying"),
+	}
+
+	consecutive, collapsed := DedupeLogEntries(entries, DedupeConsecutive)
+	assert.Equal(t, 1, collapsed)
+	require.Len(t, consecutive, 3)
+	first := consecutive[0].(map[string]interface{})
+	assert.Equal(t, 2, first["repeat_count"])
+	assert.Equal(t, "2024-05-01T20:00:01Z", first["first_time"])
+	assert.Equal(t, "2024-05-01T20:00:03Z", first["last_time"])
+	assert.NotContains(t, consecutive[2], "repeat_count")
+
+	all, collapsed := DedupeLogEntries(entries, DedupeAll)
+	assert.Equal(t, 2, collapsed)
+	require.Len(t, all, 2)
+	first = all[0].(map[string]interface{})
+	assert.Equal(t, 3, first["repeat_count"])
+	assert.Equal(t, "2024-05-01T20:00:04Z", first["last_time"])
+	assert.Equal(t, "connected", all[1].(map[string]interface{})["message"])
+
+	assert.NotContains(t, entries[0], "repeat_count", "input entries are not modified")
+	other := entry("2024-05-01T20:00:05Z", "retrying")
+	other["app"] = "worker"
+	_, collapsed = DedupeLogEntries([]interface{}{entries[0], other}, DedupeAll)
+	assert.Zero(t, collapsed, "entries from different apps are kept apart")
+}
+
+// TestQueryTool_Dedupe verifies dedupe collapses repeats in the rendered output
+func TestQueryTool_Dedupe(t *testing.T) {
+	event := func(ts, msg string) string {
+		return `{"metadata":[{"key":"timestamp","value":"` + ts + `"},{"key":"severity","value":"5"}],"labels":[{"key":"applicationname","value":"api"}],"user_data":"{\"message\":\"` + msg + `\"}"}`
+	}
+	mock := client.NewMockClient()
+	mock.DefaultResponse = &client.Response{
+		StatusCode: 200,
+		Body: []byte(`data: {"result":{"results":[` + strings.Join([]string{
+			event("2024-05-01T20:47:12Z", "pool exhausted"),
+			event("2024-05-01T20:47:13Z", "pool exhausted"),
+			event("2024-05-01T20:47:14Z", "pool exhausted"),
+		}, ",") + "]}}\n"),
+	}
+	tool := NewQueryTool(mock, zap.NewNop())
+	args := map[string]interface{}{
+		"query":         "source logs",
+		"start_date":    "2024-05-01T20:00:00Z",
+		"end_date":      "2024-05-01T21:00:00Z",
+		"relative_time": false,
+		"dedupe":        "consecutive",
+		"fields":        []interface{}{"message"},
+	}
+	result, err := tool.Execute(testCtx(mock), args)
+	require.NoError(t, err)
+	text := result.Content[0].(*mcp.TextContent).Text
+	assert.Equal(t, 1, strings.Count(text, "> pool exhausted"))
+	assert.Contains(t, text, "  - Repeated 3 times from `2024-05-01T20:47:12Z` to `2024-05-01T20:47:14Z`")
+	assert.Contains(t, text, "- **Deduplicated (consecutive):** 2 repeated entries collapsed")
+
+	args["dedupe"] = "sometimes"
+	result, err = tool.Execute(testCtx(mock), args)
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
@@ -462,24 +462,10 @@ func getFieldFilters(arguments map[string]interface{}) []fieldFilter {
 	return filters
 }
 
-// severityToInt converts severity name to integer level
+// severityToInt converts severity name to integer level, or 0 if unrecognized
 func severityToInt(severity string) int {
-	switch strings.ToLower(severity) {
-	case "debug":
-		return 1
-	case "verbose":
-		return 2
-	case "info":
-		return 3
-	case "warning", "warn":
-		return 4
-	case "error":
-		return 5
-	case "critical", "fatal":
-		return 6
-	default:
-		return 0
-	}
+	level, _ := NormalizeSeverity(severity)
+	return level
 }
 
 // fieldToLucene converts a field filter to Lucene syntax
@@ -0,0 +1,143 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements the content-addressed result cache of query_logs.
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/cache"
+)
+
+// DefaultQueryCacheTTL is how long identical query_logs calls are answered from the cache
+const DefaultQueryCacheTTL = 30 * time.Second
+
+// queryCacheTTL is the configured query cache TTL in nanoseconds; 0 disables the cache
+var queryCacheTTL atomic.Int64
+
+func init() {
+	queryCacheTTL.Store(int64(DefaultQueryCacheTTL))
+}
+
+// SetQueryCacheTTL sets how long query_logs results are cached. Zero disables query caching.
+func SetQueryCacheTTL(ttl time.Duration) {
+	if ttl < 0 {
+		ttl = 0
+	}
+	queryCacheTTL.Store(int64(ttl))
+	if ttl > 0 {
+		cache.GetManager().SetToolTTL("query_logs", ttl)
+	}
+}
+
+// useCacheSchema is the schema of the use_cache argument of query_logs
+var useCacheSchema = map[string]interface{}{
+	"type":        "boolean",
+	"description": "Answer from the short-lived result cache when the same query over the same absolute time range ran moments ago (default: true). Set false to force a fresh scan.",
+	"default":     true,
+}
+
+// queryRequestHash returns the content address of a query_logs request: a hash of the
+// normalized query, the instance it runs on and the request metadata with its time range
+// resolved to absolute UTC instants. It returns false when the request must not be cached: its range is relative
+// (e.g. "now-1h") or it is still open, tailing logs that have not arrived yet.
+func queryRequestHash(instance, query string, metadata map[string]interface{}, now time.Time) (string, bool) {
+	start, okStart := parseQueryInstant(metadata["start_date"])
+	end, okEnd := parseQueryInstant(metadata["end_date"])
+	if !okStart || !okEnd || end.After(now) {
+		return "", false
+	}
+
+	key := map[string]interface{}{
+		"instance": instance,
+		"query":    strings.Join(strings.Fields(query), " "),
+		"start":    start.Format(time.RFC3339Nano),
+		"end":      end.Format(time.RFC3339Nano),
+	}
+	for k, v := range metadata {
+		if k != "start_date" && k != "end_date" {
+			key[k] = v
+		}
+	}
+	// json.Marshal sorts map keys, so equal requests always serialize identically
+	data, err := json.Marshal(key)
+	if err != nil {
+		return "", false
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:16]), true
+}
+
+// parseQueryInstant parses an absolute query time into UTC
+func parseQueryInstant(v interface{}) (time.Time, bool) {
+	s, _ := v.(string)
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t.UTC(), true
+}
+
+// getCachedQueryResult returns a private copy of the cached result for hash
+func getCachedQueryResult(ctx context.Context, hash string) (map[string]interface{}, bool) {
+	if queryCacheTTL.Load() == 0 {
+		return nil, false
+	}
+	cached, ok := GetCacheHelperFromContext(ctx).Get("query_logs", hash)
+	if !ok {
+		return nil, false
+	}
+	result, ok := cached.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	return deepCopyMap(result), true
+}
+
+// cacheQueryResult stores a copy of a raw query result, taken before per-call formatting
+// state such as timezone, field projection or PII redaction is applied to it
+func cacheQueryResult(ctx context.Context, hash string, result map[string]interface{}) {
+	if queryCacheTTL.Load() == 0 || result == nil {
+		return
+	}
+	GetCacheHelperFromContext(ctx).Set("query_logs", hash, deepCopyMap(result))
+}
+
+// setResultCacheInfo records the request hash and whether the result came from the cache
+func setResultCacheInfo(result map[string]interface{}, hash string, hit bool) {
+	meta, ok := result["_query_metadata"].(map[string]interface{})
+	if !ok || hash == "" {
+		return
+	}
+	meta["request_hash"] = hash
+	meta["cache_hit"] = hit
+}
+
+// deepCopyMap copies a decoded JSON map so callers can mutate the copy freely
+func deepCopyMap(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = deepCopyValue(v)
+	}
+	return out
+}
+
+func deepCopyValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return deepCopyMap(val)
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = deepCopyValue(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,81 @@
+package tools
+
+import (
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestQueryRequestHash(t *testing.T) {
+	now := time.Date(2024, 5, 1, 22, 0, 0, 0, time.UTC)
+	meta := func(start, end string) map[string]interface{} {
+		return map[string]interface{}{"tier": "archive", "syntax": "dataprime", "limit": 200, "start_date": start, "end_date": end}
+	}
+
+	hash, ok := queryRequestHash("inst-1", "source logs | filter $m.severity == ERROR", meta("2024-05-01T20:00:00Z", "2024-05-01T21:00:00Z"), now)
+	require.True(t, ok)
+
+	// Whitespace and equivalent instant spellings address the same entry
+	same, _ := queryRequestHash("inst-1", "source logs  |\n filter $m.severity == ERROR", meta("2024-05-01T22:00:00+02:00", "2024-05-01T21:00:00.000Z"), now)
+	assert.Equal(t, hash, same)
+
+	other, _ := queryRequestHash("inst-1", "source logs | filter $m.severity == ERROR", meta("2024-05-01T20:01:00Z", "2024-05-01T21:01:00Z"), now)
+	assert.NotEqual(t, hash, other, "a shifted window must not collide")
+
+	other, _ = queryRequestHash("inst-1@staging", "source logs | filter $m.severity == ERROR", meta("2024-05-01T20:00:00Z", "2024-05-01T21:00:00Z"), now)
+	assert.NotEqual(t, hash, other, "the same query on another instance must not collide")
+
+	_, ok = queryRequestHash("inst-1", "source logs", meta("now-1h", "now"), now)
+	assert.False(t, ok, "relative ranges are not cached")
+
+	_, ok = queryRequestHash("inst-1", "source logs", meta("2024-05-01T21:00:00Z", "2024-05-01T23:00:00Z"), now)
+	assert.False(t, ok, "ranges still open (tailing) are not cached")
+}
+
+func TestQueryTool_CachesIdenticalQueries(t *testing.T) {
+	SetQueryCacheTTL(time.Minute)
+	defer SetQueryCacheTTL(0)
+
+	mock := client.NewMockClient()
+	mock.DefaultResponse = &client.Response{
+		StatusCode: 200,
+		Body:       []byte("data: {\"result\":{\"results\":[{\"metadata\":[{\"key\":\"timestamp\",\"value\":\"2024-05-02T10:15:00Z\"}],\"labels\":[{\"key\":\"applicationname\",\"value\":\"billing\"}],\"user_data\":\"{\\\"message\\\":\\\"invoice job failed for bob@corp.io\\\"}\"}]}}\n"),
+	}
+	tool := NewQueryTool(mock, zap.NewNop())
+	args := func() map[string]interface{} {
+		return map[string]interface{}{
+			"query":      "source logs | filter $l.applicationname == 'billing'",
+			"start_date": "2024-05-02T10:00:00Z",
+			"end_date":   "2024-05-02T11:00:00Z",
+		}
+	}
+
+	result, err := tool.Execute(testCtx(mock), args())
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.NotContains(t, result.Content[0].(*mcp.TextContent).Text, "Cached result")
+	require.Equal(t, 1, mock.RequestCount())
+
+	// The cached copy is not changed by per-call formatting such as PII redaction
+	redacted := args()
+	redacted["redact_pii"] = true
+	result, _ = tool.Execute(testCtx(mock), redacted)
+	assert.Equal(t, 1, mock.RequestCount(), "identical query should be served from the cache")
+	text := result.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, "**Cached result:** yes")
+	assert.Contains(t, text, "[email]")
+
+	result, _ = tool.Execute(testCtx(mock), args())
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "bob@corp.io")
+
+	bypass := args()
+	bypass["use_cache"] = false
+	_, _ = tool.Execute(testCtx(mock), bypass)
+	assert.Equal(t, 2, mock.RequestCount(), "use_cache=false should rescan")
+}
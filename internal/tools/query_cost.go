@@ -50,6 +50,8 @@ func (t *QueryCostEstimateTool) Description() string {
 - Time range scope
 - Filter presence and specificity
 - Sorting and limit usage
+- Storage tier: archive (COS) scans are slower and costlier than frequent_search, and
+  long archive windows are flagged loudly
 
 **Returns:**
 - Relative cost score (low, medium, high, very_high)
@@ -68,7 +70,7 @@ func (t *QueryCostEstimateTool) Description() string {
 func (t *QueryCostEstimateTool) Metadata() *ToolMetadata {
 	return &ToolMetadata{
 		Categories:    []ToolCategory{CategoryQuery, CategoryAIHelper},
-		Keywords:      []string{"cost", "estimate", "performance", "optimize", "query", "expensive", "slow", "analyze"},
+		Keywords:      []string{"cost", "estimate", "cost estimate", "tier", "archive", "performance", "optimize", "query", "expensive", "slow", "analyze"},
 		Complexity:    ComplexitySimple,
 		UseCases:      []string{"Estimate query cost", "Optimize queries", "Predict performance", "Analyze query complexity"},
 		RelatedTools:  []string{"query_logs", "build_query", "validate_query", "submit_background_query"},
@@ -95,6 +97,12 @@ func (t *QueryCostEstimateTool) InputSchema() interface{} {
 				"description": "Result limit for the query",
 				"default":     100,
 			},
+			"tier": map[string]interface{}{
+				"type":        "string",
+				"description": "Tier the query will run against (same default as query_logs)",
+				"enum":        []string{"archive", "frequent_search"},
+				"default":     "archive",
+			},
 		},
 		"required": []string{"query"},
 	}
@@ -102,7 +110,9 @@ func (t *QueryCostEstimateTool) InputSchema() interface{} {
 
 // CostEstimate represents the estimated cost of a query
 type CostEstimate struct {
-	CostLevel         string         `json:"cost_level"`          // low, medium, high, very_high
+	CostLevel         string         `json:"cost_level"`     // low, medium, high, very_high
+	Tier              string         `json:"tier,omitempty"` // archive or frequent_search
+	RecommendedTool   string         `json:"recommended_tool,omitempty"`
 	CostScore         int            `json:"cost_score"`          // 1-100
 	EstimatedTime     string         `json:"estimated_time"`      // e.g., "1-5 seconds"
 	EstimatedDataScan string         `json:"estimated_data_scan"` // e.g., "~100MB"
@@ -141,8 +151,14 @@ func (t *QueryCostEstimateTool) Execute(_ context.Context, params map[string]int
 		limit = int(l)
 	}
 
+	tier := "archive"
+	if tr, ok := params["tier"].(string); ok && tr != "" {
+		tier = normalizeTier(tr)
+	}
+
 	// Analyze the query
 	estimate := t.analyzeQuery(query, timeRange, limit)
+	t.applyTierCost(estimate, tier, timeRange)
 
 	// Format the response
 	var builder strings.Builder
@@ -160,9 +176,14 @@ func (t *QueryCostEstimateTool) Execute(_ context.Context, params map[string]int
 	}
 
 	fmt.Fprintf(&builder, "**Cost Level:** %s %s (Score: %d/100)\n\n", costEmoji, estimate.CostLevel, estimate.CostScore)
+	fmt.Fprintf(&builder, "**Tier:** %s\n", estimate.Tier)
 	fmt.Fprintf(&builder, "**Complexity:** %s\n", estimate.Complexity)
 	fmt.Fprintf(&builder, "**Estimated Execution Time:** %s\n", estimate.EstimatedTime)
-	fmt.Fprintf(&builder, "**Estimated Data Scan:** %s\n\n", estimate.EstimatedDataScan)
+	fmt.Fprintf(&builder, "**Estimated Data Scan:** %s\n", estimate.EstimatedDataScan)
+	if hints := GetCostHints(estimate.RecommendedTool); hints != nil {
+		fmt.Fprintf(&builder, "**Recommended Tool:** %s (%s execution)\n", estimate.RecommendedTool, hints.ExecutionSpeed)
+	}
+	builder.WriteString("\n")
 
 	// Breakdown
 	builder.WriteString("### Cost Breakdown\n\n")
@@ -196,7 +217,7 @@ func (t *QueryCostEstimateTool) Execute(_ context.Context, params map[string]int
 	builder.WriteString("```\n")
 	builder.WriteString(query)
 	builder.WriteString("\n```\n")
-	fmt.Fprintf(&builder, "Time Range: %s | Limit: %d\n", timeRange, limit)
+	fmt.Fprintf(&builder, "Time Range: %s | Tier: %s | Limit: %d\n", timeRange, tier, limit)
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
@@ -279,14 +300,45 @@ func (t *QueryCostEstimateTool) analyzeQuery(query, timeRange string, limit int)
 	return estimate
 }
 
-// analyzeTimeRange scores the time range cost
-func (t *QueryCostEstimateTool) analyzeTimeRange(timeRange string) (int, string) {
-	timeRange = strings.ToLower(timeRange)
+// archiveWarningHours is the window above which archive-tier queries are flagged
+const archiveWarningHours = 24
+
+// applyTierCost adjusts an estimate for the storage tier. Archive scans read from
+// object storage, so they are slower and long windows are escalated with a warning.
+func (t *QueryCostEstimateTool) applyTierCost(estimate *CostEstimate, tier, timeRange string) {
+	estimate.Tier = tier
+	estimate.RecommendedTool = "query_logs"
+
+	hours := parseTimeRangeHours(timeRange)
+	if tier == "archive" {
+		estimate.EstimatedTime = t.estimateExecutionTime(min(estimate.CostScore+20, 100))
+
+		if hours > archiveWarningHours {
+			estimate.Warnings = append([]string{fmt.Sprintf(
+				"🚨 ARCHIVE TIER OVER A LONG WINDOW (%s): this scans object storage for every hour in range and can be very slow and costly. "+
+					"Narrow the time range, add application/subsystem filters, or query frequent_search if the data is indexed there.",
+				timeRange)}, estimate.Warnings...)
+			if estimate.CostLevel == "low" || estimate.CostLevel == "medium" {
+				estimate.CostLevel = "high"
+			}
+			if hours > 7*24 {
+				estimate.CostLevel = "very_high"
+			}
+		}
+	}
 
-	// Parse duration
+	// Expensive scans should run asynchronously rather than block query_logs
+	if estimate.CostLevel == "high" || estimate.CostLevel == "very_high" {
+		estimate.RecommendedTool = "submit_background_query"
+	}
+}
+
+// parseTimeRangeHours converts a time range such as "30m", "6h" or "7d" to hours
+func parseTimeRangeHours(timeRange string) float64 {
 	hours := 1.0
+	timeRange = strings.ToLower(timeRange)
 	if strings.HasSuffix(timeRange, "m") {
-		hours = 0.016 // ~1 minute
+		hours = 0.016
 	} else if strings.HasSuffix(timeRange, "h") {
 		_, _ = fmt.Sscanf(timeRange, "%fh", &hours)
 	} else if strings.HasSuffix(timeRange, "d") {
@@ -294,6 +346,12 @@ func (t *QueryCostEstimateTool) analyzeTimeRange(timeRange string) (int, string)
 		_, _ = fmt.Sscanf(timeRange, "%fd", &days)
 		hours = days * 24
 	}
+	return hours
+}
+
+// analyzeTimeRange scores the time range cost
+func (t *QueryCostEstimateTool) analyzeTimeRange(timeRange string) (int, string) {
+	hours := parseTimeRangeHours(timeRange)
 
 	switch {
 	case hours <= 1:
@@ -445,18 +503,7 @@ func (t *QueryCostEstimateTool) estimateExecutionTime(costScore int) string {
 
 // estimateDataScan estimates data volume scanned
 func (t *QueryCostEstimateTool) estimateDataScan(timeRange string, filterCost int) string {
-	// Parse time range to hours
-	hours := 1.0
-	timeRange = strings.ToLower(timeRange)
-	if strings.HasSuffix(timeRange, "m") {
-		hours = 0.016
-	} else if strings.HasSuffix(timeRange, "h") {
-		_, _ = fmt.Sscanf(timeRange, "%fh", &hours)
-	} else if strings.HasSuffix(timeRange, "d") {
-		var days float64
-		_, _ = fmt.Sscanf(timeRange, "%fd", &days)
-		hours = days * 24
-	}
+	hours := parseTimeRangeHours(timeRange)
 
 	// Estimate based on typical log volumes
 	// Assume ~100MB/hour of logs for a medium-sized deployment
@@ -501,3 +501,49 @@ func TestCostEstimateAnnotations(t *testing.T) {
 		t.Error("Expected cost estimation tool to be idempotent")
 	}
 }
+
+func TestApplyTierCost(t *testing.T) {
+	tool := NewQueryCostEstimateTool(nil, zap.NewNop())
+	query := "source logs | filter $l.applicationname == 'api'"
+
+	tests := []struct {
+		name        string
+		tier        string
+		timeRange   string
+		expectLevel string
+		expectWarn  bool
+		expectTool  string
+	}{
+		{"frequent search long window", "frequent_search", "3d", "", false, ""},
+		{"archive short window", "archive", "1h", "", false, "query_logs"},
+		{"archive over a day", "archive", "3d", "high", true, "submit_background_query"},
+		{"archive over a week", "archive", "30d", "very_high", true, "submit_background_query"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			estimate := tool.analyzeQuery(query, tt.timeRange, 100)
+			tool.applyTierCost(estimate, tt.tier, tt.timeRange)
+
+			if estimate.Tier != tt.tier {
+				t.Errorf("Expected tier %s, got %s", tt.tier, estimate.Tier)
+			}
+			if tt.expectLevel != "" && estimate.CostLevel != tt.expectLevel {
+				t.Errorf("Expected cost level %s, got %s", tt.expectLevel, estimate.CostLevel)
+			}
+			if tt.expectTool != "" && estimate.RecommendedTool != tt.expectTool {
+				t.Errorf("Expected recommended tool %s, got %s", tt.expectTool, estimate.RecommendedTool)
+			}
+
+			hasArchiveWarning := false
+			for _, w := range estimate.Warnings {
+				if strings.Contains(w, "ARCHIVE TIER") {
+					hasArchiveWarning = true
+				}
+			}
+			if hasArchiveWarning != tt.expectWarn {
+				t.Errorf("Expected archive warning = %v, got %v (warnings: %v)", tt.expectWarn, hasArchiveWarning, estimate.Warnings)
+			}
+		})
+	}
+}
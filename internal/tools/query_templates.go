@@ -6,6 +6,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -18,6 +19,7 @@ import (
 type QueryTemplate struct {
 	Name        string              `json:"name"`
 	Category    string              `json:"category"`
+	Tags        []string            `json:"tags,omitempty"`
 	Description string              `json:"description"`
 	Query       string              `json:"query"`
 	Parameters  []TemplateParameter `json:"parameters,omitempty"`
@@ -47,6 +49,11 @@ func NewQueryTemplatesTool(c client.Doer, l *zap.Logger) *QueryTemplatesTool {
 // Name returns the tool name
 func (t *QueryTemplatesTool) Name() string { return "get_query_templates" }
 
+// Annotations returns tool hints for LLMs
+func (t *QueryTemplatesTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Get Query Templates")
+}
+
 // Description returns the tool description
 func (t *QueryTemplatesTool) Description() string {
 	return `Get pre-built DataPrime query templates for common log analysis scenarios.
@@ -61,10 +68,11 @@ func (t *QueryTemplatesTool) Description() string {
 - health: Application health monitoring
 - usage: Resource utilization tracking
 - audit: User activity and compliance
+- kubernetes: Pod errors, restarts, and OOM kills
 
 **Usage:**
-1. Call without parameters to list all templates
-2. Specify category to filter templates
+1. Call without parameters to list all templates and the available categories
+2. Specify category and/or search to filter templates
 3. Specify name for a specific template with full details
 
 **Related tools:** query_logs, build_query, explain_query`
@@ -77,8 +85,12 @@ func (t *QueryTemplatesTool) InputSchema() interface{} {
 		"properties": map[string]interface{}{
 			"category": map[string]interface{}{
 				"type":        "string",
-				"description": "Filter templates by category",
-				"enum":        []string{"discovery", "error", "performance", "security", "health", "usage", "audit"},
+				"description": "Filter templates by category: discovery, error (or errors), performance, security, health, usage, audit, kubernetes (or k8s)",
+			},
+			"search": map[string]interface{}{
+				"type":        "string",
+				"description": "Keywords to match against template names, descriptions, and tags (case-insensitive; every word must match)",
+				"examples":    []string{"latency", "oom", "auth"},
 			},
 			"name": map[string]interface{}{
 				"type":        "string",
@@ -102,6 +114,7 @@ func (t *QueryTemplatesTool) Execute(_ context.Context, args map[string]interfac
 	name, _ := GetStringParam(args, "name", false)
 	application, _ := GetStringParam(args, "application", false)
 	timeRange, _ := GetStringParam(args, "time_range", false)
+	search, _ := GetStringParam(args, "search", false)
 
 	templates := getQueryTemplates()
 
@@ -117,22 +130,74 @@ func (t *QueryTemplatesTool) Execute(_ context.Context, args map[string]interfac
 		return NewToolResultError(fmt.Sprintf("Template '%s' not found. Use get_query_templates without name to list all available templates.", name)), nil
 	}
 
-	// Filter by category if specified
-	if category != "" {
-		var filtered []QueryTemplate
-		for _, tmpl := range templates {
-			if strings.EqualFold(tmpl.Category, category) {
-				filtered = append(filtered, tmpl)
+	filtered, err := filterQueryTemplates(templates, category, search)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+
+	// Only show the category overview when browsing, so filtered results stay small
+	var categories map[string]int
+	if (category == "" || strings.EqualFold(category, "all")) && search == "" {
+		categories = templateCategoryCounts(templates)
+	}
+	return formatTemplateList(filtered, categories)
+}
+
+// templateCategoryAliases maps alternative category spellings to their canonical name
+var templateCategoryAliases = map[string]string{
+	"errors": "error",
+	"k8s":    "kubernetes",
+}
+
+// filterQueryTemplates returns the templates in category whose name, description, or tags
+// contain every word of search. An empty category or "all" matches every category.
+func filterQueryTemplates(templates []QueryTemplate, category, search string) ([]QueryTemplate, error) {
+	category = strings.ToLower(strings.TrimSpace(category))
+	if alias, ok := templateCategoryAliases[category]; ok {
+		category = alias
+	}
+	if category == "all" {
+		category = ""
+	}
+
+	counts := templateCategoryCounts(templates)
+	if category != "" && counts[category] == 0 {
+		names := make([]string, 0, len(counts))
+		for name := range counts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown template category '%s'. Available categories: %s", category, strings.Join(names, ", "))
+	}
+
+	terms := strings.Fields(strings.ToLower(search))
+	filtered := []QueryTemplate{}
+	for _, tmpl := range templates {
+		if category != "" && tmpl.Category != category {
+			continue
+		}
+		haystack := strings.ToLower(tmpl.Name + " " + tmpl.Description + " " + strings.Join(tmpl.Tags, " "))
+		matched := true
+		for _, term := range terms {
+			if !strings.Contains(haystack, term) {
+				matched = false
+				break
 			}
 		}
-		if len(filtered) == 0 {
-			return NewToolResultError(fmt.Sprintf("No templates found for category '%s'", category)), nil
+		if matched {
+			filtered = append(filtered, tmpl)
 		}
-		templates = filtered
 	}
+	return filtered, nil
+}
 
-	// Return template summaries
-	return formatTemplateList(templates)
+// templateCategoryCounts returns the number of templates in each category
+func templateCategoryCounts(templates []QueryTemplate) map[string]int {
+	counts := make(map[string]int)
+	for _, tmpl := range templates {
+		counts[tmpl.Category]++
+	}
+	return counts
 }
 
 // substituteTemplateParams replaces placeholders in a template
@@ -165,21 +230,28 @@ func formatTemplateResult(tmpl QueryTemplate) (*mcp.CallToolResult, error) {
 	}, nil
 }
 
-// formatTemplateList formats a list of templates with summaries
-func formatTemplateList(templates []QueryTemplate) (*mcp.CallToolResult, error) {
-	var summaries []map[string]interface{}
+// formatTemplateList formats a list of templates with summaries. categories, when non-nil,
+// is included so callers can discover what is available.
+func formatTemplateList(templates []QueryTemplate, categories map[string]int) (*mcp.CallToolResult, error) {
+	summaries := []map[string]interface{}{}
 	for _, tmpl := range templates {
 		summaries = append(summaries, map[string]interface{}{
 			"name":        tmpl.Name,
 			"category":    tmpl.Category,
+			"tags":        tmpl.Tags,
 			"description": tmpl.Description,
 		})
 	}
 
-	result, err := json.MarshalIndent(map[string]interface{}{
+	response := map[string]interface{}{
+		"count":     len(summaries),
 		"templates": summaries,
 		"usage":     "Use get_query_templates with name='<template_name>' to get full template details including the query",
-	}, "", "  ")
+	}
+	if categories != nil {
+		response["categories"] = categories
+	}
+	result, err := json.MarshalIndent(response, "", "  ")
 	if err != nil {
 		return NewToolResultError(fmt.Sprintf("Failed to format templates: %v", err)), nil
 	}
@@ -201,6 +273,7 @@ func getQueryTemplates() []QueryTemplate {
 		{
 			Name:        "error_hotspots",
 			Category:    "discovery",
+			Tags:        []string{"errors", "triage", "services"},
 			Description: "Find which services have the most errors - start investigations here",
 			Query:       "source logs | filter $m.severity >= 5 | groupby $l.applicationname, $l.subsystemname | aggregate count() as error_count | sortby -error_count | limit 20",
 			UseCases: []string{
@@ -217,6 +290,7 @@ func getQueryTemplates() []QueryTemplate {
 		{
 			Name:        "anomaly_detection",
 			Category:    "discovery",
+			Tags:        []string{"errors", "anomaly", "services"},
 			Description: "Find services with unusually high error rates",
 			Query:       "source logs | filter $m.severity >= 5 | groupby $l.applicationname | aggregate count() as error_count | filter error_count > 10 | sortby -error_count | limit 20",
 			UseCases: []string{
@@ -233,6 +307,7 @@ func getQueryTemplates() []QueryTemplate {
 		{
 			Name:        "top_error_messages",
 			Category:    "discovery",
+			Tags:        []string{"errors", "messages", "patterns"},
 			Description: "Group similar errors to find the most common problems",
 			Query:       "source logs | filter $m.severity >= 5 | groupby $d.message:string | aggregate count() as occurrences, min($m.timestamp) as first_seen, max($m.timestamp) as last_seen | filter occurrences >= 3 | sortby -occurrences | limit 30",
 			UseCases: []string{
@@ -249,6 +324,7 @@ func getQueryTemplates() []QueryTemplate {
 		{
 			Name:        "noise_filtered_errors",
 			Category:    "discovery",
+			Tags:        []string{"errors", "noise", "triage"},
 			Description: "Error analysis excluding health checks and known noise",
 			Query:       "source logs | filter $m.severity >= 5 && !$d.message:string.toLowerCase().contains('health') && !$d.message:string.toLowerCase().contains('ping') && !$d.message:string.toLowerCase().contains('heartbeat') && !$d.message:string.toLowerCase().contains('metrics') | groupby $l.applicationname | aggregate count() as errors | sortby -errors | limit 20",
 			UseCases: []string{
@@ -265,6 +341,7 @@ func getQueryTemplates() []QueryTemplate {
 		{
 			Name:        "traffic_overview",
 			Category:    "discovery",
+			Tags:        []string{"volume", "services", "triage"},
 			Description: "See overall traffic patterns by service",
 			Query:       "source logs | groupby $l.applicationname | aggregate count() as volume, approx_count_distinct($l.subsystemname) as components | sortby -volume | limit 20",
 			UseCases: []string{
@@ -281,6 +358,7 @@ func getQueryTemplates() []QueryTemplate {
 		{
 			Name:        "recent_changes",
 			Category:    "discovery",
+			Tags:        []string{"deployment", "changes", "startup"},
 			Description: "Detect recent deployments or restarts that might explain issues",
 			Query:       "source logs | filter $d.message:string.contains('start') || $d.message:string.contains('deploy') || $d.message:string.contains('version') || $d.message:string.contains('initializ') || $d.message:string.contains('shutdown') | groupby $l.applicationname | aggregate count() as events, min($m.timestamp) as earliest, max($m.timestamp) as latest | filter events >= 2 | sortby -latest | limit 20",
 			UseCases: []string{
@@ -299,6 +377,7 @@ func getQueryTemplates() []QueryTemplate {
 		{
 			Name:        "error_spike",
 			Category:    "error",
+			Tags:        []string{"errors", "spike", "incident"},
 			Description: "Find error spikes in the last hour grouped by application",
 			Query:       "source logs | filter $m.severity >= 5 | groupby $l.applicationname | aggregate count() as error_count | sortby -error_count | limit 20",
 			UseCases: []string{
@@ -315,6 +394,7 @@ func getQueryTemplates() []QueryTemplate {
 		{
 			Name:        "error_details",
 			Category:    "error",
+			Tags:        []string{"errors", "stack-trace", "debugging"},
 			Description: "Get detailed error logs for a specific application",
 			Query:       "source logs | filter $l.applicationname == '{APPLICATION}' && $m.severity >= 5 | select $m.timestamp, $m.severity, $l.subsystemname, $d.message, $d.error, $d.stack_trace | sortby -$m.timestamp | limit 100",
 			Parameters: []TemplateParameter{
@@ -334,6 +414,7 @@ func getQueryTemplates() []QueryTemplate {
 		{
 			Name:        "error_timeline",
 			Category:    "error",
+			Tags:        []string{"errors", "timeline", "trend"},
 			Description: "Error rate over time for trend analysis",
 			Query:       "source logs | filter $m.severity >= 5 | groupby roundTime($m.timestamp, 1m) as time_bucket | aggregate count() as errors | sortby time_bucket",
 			UseCases: []string{
@@ -352,6 +433,7 @@ func getQueryTemplates() []QueryTemplate {
 		{
 			Name:        "slow_requests",
 			Category:    "performance",
+			Tags:        []string{"latency", "requests", "endpoints"},
 			Description: "Find slow requests with response time above threshold",
 			Query:       "source logs | filter $d.response_time_ms > 1000 | select $m.timestamp, $l.applicationname, $d.endpoint, $d.response_time_ms, $d.status_code | sortby -$d.response_time_ms | limit 50",
 			Parameters: []TemplateParameter{
@@ -371,6 +453,7 @@ func getQueryTemplates() []QueryTemplate {
 		{
 			Name:        "latency_percentiles",
 			Category:    "performance",
+			Tags:        []string{"latency", "percentiles", "endpoints"},
 			Description: "Calculate latency percentiles by endpoint",
 			Query:       "source logs | filter $d.response_time_ms > 0 | groupby $d.endpoint | aggregate percentile($d.response_time_ms, 50) as p50, percentile($d.response_time_ms, 95) as p95, percentile($d.response_time_ms, 99) as p99, count() as requests | sortby -requests | limit 20",
 			UseCases: []string{
@@ -387,6 +470,7 @@ func getQueryTemplates() []QueryTemplate {
 		{
 			Name:        "throughput_analysis",
 			Category:    "performance",
+			Tags:        []string{"throughput", "requests", "trend"},
 			Description: "Request throughput over time by application",
 			Query:       "source logs | filter $d.request_id != '' | groupby roundTime($m.timestamp, 1m) as time_bucket, $l.applicationname | aggregate count() as requests | sortby time_bucket",
 			UseCases: []string{
@@ -405,6 +489,7 @@ func getQueryTemplates() []QueryTemplate {
 		{
 			Name:        "auth_failures",
 			Category:    "security",
+			Tags:        []string{"authentication", "brute-force", "ip"},
 			Description: "Find authentication failures grouped by source",
 			Query:       "source logs | filter $d.event_type == 'auth_failure' || $d.message.contains('authentication failed') || $d.message.contains('invalid credentials') | groupby $d.source_ip, $d.username | aggregate count() as failures | filter failures > 3 | sortby -failures",
 			UseCases: []string{
@@ -421,6 +506,7 @@ func getQueryTemplates() []QueryTemplate {
 		{
 			Name:        "privilege_escalation",
 			Category:    "security",
+			Tags:        []string{"privilege", "admin", "users"},
 			Description: "Detect privilege escalation attempts",
 			Query:       "source logs | filter $d.event_type.contains('privilege') || $d.message.contains('sudo') || $d.message.contains('root access') || $d.message.contains('admin') | select $m.timestamp, $l.applicationname, $d.username, $d.action, $d.message | sortby -$m.timestamp | limit 100",
 			UseCases: []string{
@@ -437,6 +523,7 @@ func getQueryTemplates() []QueryTemplate {
 		{
 			Name:        "sensitive_data_access",
 			Category:    "security",
+			Tags:        []string{"pii", "secrets", "access"},
 			Description: "Track access to sensitive resources",
 			Query:       "source logs | filter $d.resource.contains('pii') || $d.resource.contains('secrets') || $d.resource.contains('credentials') || $d.endpoint.contains('/admin') | select $m.timestamp, $d.username, $d.resource, $d.action, $d.source_ip | sortby -$m.timestamp | limit 100",
 			UseCases: []string{
@@ -455,6 +542,7 @@ func getQueryTemplates() []QueryTemplate {
 		{
 			Name:        "service_health",
 			Category:    "health",
+			Tags:        []string{"errors", "services", "status"},
 			Description: "Overall health summary by service",
 			Query:       "source logs | filter $m.severity >= 5 | groupby $l.applicationname | aggregate count() as error_count | sortby -error_count | limit 20",
 			UseCases: []string{
@@ -471,6 +559,7 @@ func getQueryTemplates() []QueryTemplate {
 		{
 			Name:        "heartbeat_check",
 			Category:    "health",
+			Tags:        []string{"heartbeat", "availability", "services"},
 			Description: "Verify services are logging (heartbeat)",
 			Query:       "source logs | groupby $l.applicationname | aggregate max($m.timestamp) as last_seen, count() as log_count | sortby last_seen",
 			UseCases: []string{
@@ -487,6 +576,7 @@ func getQueryTemplates() []QueryTemplate {
 		{
 			Name:        "restart_detection",
 			Category:    "health",
+			Tags:        []string{"restarts", "crash", "oom"},
 			Description: "Detect service restarts and crashes",
 			Query:       "source logs | filter $d.message.contains('starting') || $d.message.contains('started') || $d.message.contains('shutdown') || $d.message.contains('terminated') || $d.message.contains('OOMKilled') | select $m.timestamp, $l.applicationname, $l.subsystemname, $d.message | sortby -$m.timestamp | limit 50",
 			UseCases: []string{
@@ -505,6 +595,7 @@ func getQueryTemplates() []QueryTemplate {
 		{
 			Name:        "top_endpoints",
 			Category:    "usage",
+			Tags:        []string{"endpoints", "traffic", "capacity"},
 			Description: "Most frequently called endpoints",
 			Query:       "source logs | filter $d.endpoint != '' | groupby $d.endpoint | aggregate count() as calls, avg($d.response_time_ms) as avg_latency | sortby -calls | limit 20",
 			UseCases: []string{
@@ -521,6 +612,7 @@ func getQueryTemplates() []QueryTemplate {
 		{
 			Name:        "user_activity",
 			Category:    "usage",
+			Tags:        []string{"users", "activity", "behavior"},
 			Description: "User activity summary",
 			Query:       "source logs | filter $d.user_id != '' | groupby $d.user_id | aggregate count() as actions, approx_count_distinct($d.endpoint) as unique_endpoints | sortby -actions | limit 50",
 			UseCases: []string{
@@ -537,6 +629,7 @@ func getQueryTemplates() []QueryTemplate {
 		{
 			Name:        "data_volume",
 			Category:    "usage",
+			Tags:        []string{"volume", "cost", "trend"},
 			Description: "Log volume by application over time",
 			Query:       "source logs | groupby roundTime($m.timestamp, 1h) as time_bucket, $l.applicationname | aggregate count() as logs | sortby time_bucket",
 			UseCases: []string{
@@ -555,6 +648,7 @@ func getQueryTemplates() []QueryTemplate {
 		{
 			Name:        "config_changes",
 			Category:    "audit",
+			Tags:        []string{"configuration", "changes", "compliance"},
 			Description: "Track configuration changes",
 			Query:       "source logs | filter $d.event_type.contains('config') || $d.message.contains('configuration changed') || $d.message.contains('settings updated') | select $m.timestamp, $d.username, $d.resource, $d.old_value, $d.new_value, $d.message | sortby -$m.timestamp | limit 100",
 			UseCases: []string{
@@ -571,6 +665,7 @@ func getQueryTemplates() []QueryTemplate {
 		{
 			Name:        "data_exports",
 			Category:    "audit",
+			Tags:        []string{"export", "exfiltration", "compliance"},
 			Description: "Track data export activities",
 			Query:       "source logs | filter $d.action.contains('export') || $d.action.contains('download') || $d.message.contains('exported') | select $m.timestamp, $d.username, $d.resource, $d.record_count, $d.destination | sortby -$m.timestamp | limit 100",
 			UseCases: []string{
@@ -587,6 +682,7 @@ func getQueryTemplates() []QueryTemplate {
 		{
 			Name:        "api_key_usage",
 			Category:    "audit",
+			Tags:        []string{"api-key", "credentials", "compliance"},
 			Description: "Track API key usage patterns",
 			Query:       "source logs | filter $d.api_key_id != '' || $d.auth_type == 'api_key' | groupby $d.api_key_id, $l.applicationname | aggregate count() as calls, approx_count_distinct($d.source_ip) as unique_ips | sortby -calls | limit 50",
 			UseCases: []string{
@@ -600,5 +696,37 @@ func getQueryTemplates() []QueryTemplate {
 				"Monitor for unusual patterns",
 			},
 		},
+
+		// Kubernetes Templates
+		{
+			Name:        "pod_errors",
+			Category:    "kubernetes",
+			Tags:        []string{"errors", "pods", "namespaces"},
+			Description: "Errors grouped by Kubernetes namespace and pod",
+			Query:       "source logs | filter $m.severity >= 5 && $d.kubernetes.namespace_name != '' | groupby $d.kubernetes.namespace_name, $d.kubernetes.pod_name | aggregate count() as error_count | sortby -error_count | limit 20",
+			UseCases: []string{
+				"Find failing pods in a cluster",
+				"Compare error load across namespaces",
+			},
+			Tips: []string{
+				"Errors concentrated in one pod suggest a bad node or replica",
+				"Errors spread across all pods suggest a code or config issue",
+			},
+		},
+		{
+			Name:        "pod_restarts",
+			Category:    "kubernetes",
+			Tags:        []string{"restarts", "oom", "crash", "pods"},
+			Description: "Pods reporting OOMKilled, CrashLoopBackOff, or back-off restarts",
+			Query:       "source logs | filter $d.message.contains('OOMKilled') || $d.message.contains('CrashLoopBackOff') || $d.message.contains('Back-off restarting') | groupby $d.kubernetes.namespace_name, $d.kubernetes.pod_name | aggregate count() as events, max($m.timestamp) as last_seen | sortby -events | limit 20",
+			UseCases: []string{
+				"Detect crash-looping pods",
+				"Find pods hitting memory limits",
+			},
+			Tips: []string{
+				"OOMKilled means the container exceeded its memory limit",
+				"Check the pod's previous container logs for the crash cause",
+			},
+		},
 	}
 }
@@ -1,7 +1,14 @@
 package tools
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
 )
 
 // TestValidateAllQueryTemplates validates all predefined query templates
@@ -74,6 +81,7 @@ func TestQueryTemplateCategories(t *testing.T) {
 		"health":      true,
 		"starter":     true,
 		"discovery":   true,
+		"kubernetes":  true,
 	}
 
 	for _, tmpl := range templates {
@@ -97,3 +105,74 @@ func TestQueryTemplatesHaveUseCases(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterQueryTemplates(t *testing.T) {
+	templates := getQueryTemplates()
+
+	tests := []struct {
+		name     string
+		category string
+		search   string
+		want     []string
+	}{
+		{"category alias", "k8s", "", []string{"pod_errors", "pod_restarts"}},
+		{"search tags", "", "oom", []string{"restart_detection", "pod_restarts"}},
+		{"category and search", "errors", "timeline", []string{"error_timeline"}},
+		{"all words must match", "", "latency percentiles", []string{"latency_percentiles"}},
+		{"no matches", "security", "latency", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := filterQueryTemplates(templates, tt.category, tt.search)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d templates, want %v", len(got), tt.want)
+			}
+			for i, tmpl := range got {
+				if tmpl.Name != tt.want[i] {
+					t.Errorf("template %d = %s, want %s", i, tmpl.Name, tt.want[i])
+				}
+			}
+		})
+	}
+
+	if _, err := filterQueryTemplates(templates, "networking", ""); err == nil {
+		t.Error("expected error for unknown category")
+	}
+}
+
+func TestQueryTemplatesTool_ListCounts(t *testing.T) {
+	tool := NewQueryTemplatesTool(client.NewMockClient(), zap.NewNop())
+
+	decode := func(args map[string]interface{}) map[string]interface{} {
+		t.Helper()
+		result, _ := tool.Execute(context.Background(), args)
+		if result.IsError {
+			t.Fatalf("unexpected error result for %v", args)
+		}
+		var out map[string]interface{}
+		if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &out); err != nil {
+			t.Fatalf("response is not JSON: %v", err)
+		}
+		return out
+	}
+
+	all := decode(map[string]interface{}{})
+	if int(all["count"].(float64)) != len(getQueryTemplates()) {
+		t.Errorf("count = %v, want %d", all["count"], len(getQueryTemplates()))
+	}
+	categories, ok := all["categories"].(map[string]interface{})
+	if !ok || categories["kubernetes"] != float64(2) {
+		t.Errorf("categories = %v, want kubernetes: 2", all["categories"])
+	}
+
+	filtered := decode(map[string]interface{}{"search": "auth"})
+	if _, ok := filtered["categories"]; ok {
+		t.Error("filtered results should not include the category overview")
+	}
+	if filtered["count"] != float64(len(filtered["templates"].([]interface{}))) {
+		t.Errorf("count %v does not match templates returned", filtered["count"])
+	}
+}
@@ -0,0 +1,86 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestFormatResponse_RedactsSecrets(t *testing.T) {
+	// Matches the slack example in create_outgoing_webhook's schema
+	webhook := map[string]interface{}{
+		"id":   "wh-1",
+		"type": "slack",
+		"url":  "https://hooks.slack.com/services/XXX/YYY/ZZZ",
+		"pager_duty": map[string]interface{}{
+			"service_key": "pd-service-key-123",
+		},
+	}
+	bt := NewBaseTool(nil, zap.NewNop())
+
+	formatters := map[string]func() (*mcp.CallToolResult, error){
+		"FormatResponse":            func() (*mcp.CallToolResult, error) { return bt.FormatResponse(webhook) },
+		"FormatResponseWithSummary": func() (*mcp.CallToolResult, error) { return bt.FormatResponseWithSummary(webhook, "webhook") },
+		"FormatResponseWithSuggestions": func() (*mcp.CallToolResult, error) {
+			return bt.FormatResponseWithSuggestions(webhook, "get_outgoing_webhook")
+		},
+	}
+	for name, format := range formatters {
+		t.Run(name, func(t *testing.T) {
+			result, err := format()
+			require.NoError(t, err)
+			text := result.Content[0].(*mcp.TextContent).Text
+			assert.NotContains(t, text, "XXX/YYY/ZZZ")
+			assert.NotContains(t, text, "pd-service-key-123")
+			assert.Contains(t, text, "hooks.slack.com/services/***redacted***")
+		})
+	}
+}
+
+func TestSetSecretRedaction(t *testing.T) {
+	defer func() { _ = SetSecretRedaction(true, nil) }()
+
+	require.NoError(t, SetSecretRedaction(true, []string{`(tenant=)[a-z0-9]+`}))
+	assert.Equal(t, "tenant=***redacted***", redactSecrets("tenant=acme42"))
+
+	require.NoError(t, SetSecretRedaction(false, nil))
+	assert.Equal(t, "https://hooks.slack.com/services/XXX/YYY/ZZZ", redactSecrets("https://hooks.slack.com/services/XXX/YYY/ZZZ"))
+
+	assert.Error(t, SetSecretRedaction(true, []string{"("}))
+}
+
+func TestQueryTool_RedactPII(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.DefaultResponse = &client.Response{
+		StatusCode: 200,
+		Body:       []byte("data: {\"result\":{\"results\":[{\"metadata\":[{\"key\":\"timestamp\",\"value\":\"2024-05-01T20:47:12Z\"}],\"labels\":[{\"key\":\"applicationname\",\"value\":\"auth\"}],\"user_data\":\"{\\\"message\\\":\\\"login failed for jane@corp.io from 203.0.113.7\\\",\\\"client\\\":{\\\"phone\\\":\\\"+1 415-555-0132\\\"}}\"}]}}\n"),
+	}
+	tool := NewQueryTool(mock, zap.NewNop())
+	args := map[string]interface{}{
+		"query":      "source logs",
+		"start_date": "2024-05-01T20:00:00Z",
+		"end_date":   "2024-05-01T21:00:00Z",
+		"redact_pii": true,
+	}
+
+	result, err := tool.Execute(testCtx(mock), args)
+	require.NoError(t, err)
+	text := result.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, "login failed for [email] from [ip]")
+	assert.Contains(t, text, "**PII redacted:** 3 values")
+
+	args["raw_output"] = true
+	result, _ = tool.Execute(testCtx(mock), args)
+	text = result.Content[0].(*mcp.TextContent).Text
+	assert.NotContains(t, text, "415-555-0132")
+	assert.NotContains(t, text, "jane@corp.io")
+
+	delete(args, "redact_pii")
+	result, _ = tool.Execute(testCtx(mock), args)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "jane@corp.io")
+}
@@ -14,10 +14,17 @@ func GetAllTools(c client.Doer, logger *zap.Logger) []Tool {
 	return []Tool{
 		// Alert tools
 		NewGetAlertTool(c, logger),
+		NewWatchAlertTool(c, logger),
 		NewListAlertsTool(c, logger),
 		NewCreateAlertTool(c, logger),
+		NewBulkCreateAlertsTool(c, logger),
 		NewUpdateAlertTool(c, logger),
 		NewDeleteAlertTool(c, logger),
+		NewCreateSLOBurnAlertTool(c, logger),
+		NewCompareAlertsTool(c, logger),
+		NewExportAlertTool(c, logger),
+		NewRestoreAlertTool(c, logger),
+		NewValidateAlertConditionTool(c, logger),
 
 		// Alert Definition tools
 		NewGetAlertDefinitionTool(c, logger),
@@ -25,6 +32,7 @@ func GetAllTools(c client.Doer, logger *zap.Logger) []Tool {
 		NewCreateAlertDefinitionTool(c, logger),
 		NewUpdateAlertDefinitionTool(c, logger),
 		NewDeleteAlertDefinitionTool(c, logger),
+		NewCreateAlertFromQueryTool(c, logger),
 
 		// Rule Group tools
 		NewGetRuleGroupTool(c, logger),
@@ -32,10 +40,11 @@ func GetAllTools(c client.Doer, logger *zap.Logger) []Tool {
 		NewCreateRuleGroupTool(c, logger),
 		NewUpdateRuleGroupTool(c, logger),
 		NewDeleteRuleGroupTool(c, logger),
+		NewTestRuleGroupTool(c, logger),
 
 		// Rule Helper tools
 		NewDiscoverLogFieldsTool(c, logger),
-		NewTestRuleGroupTool(c, logger),
+		NewTestRulePatternTool(c, logger),
 
 		// Outgoing Webhook tools
 		NewGetOutgoingWebhookTool(c, logger),
@@ -43,6 +52,7 @@ func GetAllTools(c client.Doer, logger *zap.Logger) []Tool {
 		NewCreateOutgoingWebhookTool(c, logger),
 		NewUpdateOutgoingWebhookTool(c, logger),
 		NewDeleteOutgoingWebhookTool(c, logger),
+		NewTestOutgoingWebhookTool(c, logger),
 
 		// Policy tools
 		NewGetPolicyTool(c, logger),
@@ -50,6 +60,7 @@ func GetAllTools(c client.Doer, logger *zap.Logger) []Tool {
 		NewCreatePolicyTool(c, logger),
 		NewUpdatePolicyTool(c, logger),
 		NewDeletePolicyTool(c, logger),
+		NewDiffPoliciesTool(c, logger),
 
 		// Events to Metrics (E2M) tools
 		NewGetE2MTool(c, logger),
@@ -60,12 +71,29 @@ func GetAllTools(c client.Doer, logger *zap.Logger) []Tool {
 
 		// Query tools
 		NewQueryTool(c, logger),
+		NewReplayQueryTool(c, logger),
+		NewTraceLogsTool(c, logger),
+		NewClusterLogsTool(c, logger),
+		NewCompareTimeWindowsTool(c, logger),
+		NewSaveQueryTool(c, logger),
+		NewListSavedQueriesTool(c, logger),
+		NewGetSavedQueryTool(c, logger),
+		NewDeleteSavedQueryTool(c, logger),
+		NewScheduleQueryTool(c, logger),
+		NewListScheduledQueriesTool(c, logger),
+		NewDeleteScheduledQueryTool(c, logger),
 		NewBuildQueryTool(c, logger),
+		NewBuildAggregationQueryTool(c, logger),
 		NewDataPrimeReferenceTool(c, logger),
 		NewSubmitBackgroundQueryTool(c, logger),
 		NewGetBackgroundQueryStatusTool(c, logger),
 		NewGetBackgroundQueryDataTool(c, logger),
 		NewCancelBackgroundQueryTool(c, logger),
+		NewListBackgroundQueriesTool(c, logger),
+		NewCancelAllBackgroundQueriesTool(c, logger),
+		NewListApplicationsTool(c, logger),
+		NewListSubsystemsTool(c, logger),
+		NewDiscoverFieldsTool(c, logger),
 
 		// Log Ingestion tools
 		NewIngestLogsTool(c, logger),
@@ -84,6 +112,12 @@ func GetAllTools(c client.Doer, logger *zap.Logger) []Tool {
 		NewUpdateEnrichmentTool(c, logger),
 		NewDeleteEnrichmentTool(c, logger),
 
+		// Data set (custom enrichment lookup table) tools
+		NewListDataSetsTool(c, logger),
+		NewGetDataSetTool(c, logger),
+		NewCreateDataSetTool(c, logger),
+		NewDeleteDataSetTool(c, logger),
+
 		// View tools
 		NewListViewsTool(c, logger),
 		NewCreateViewTool(c, logger),
@@ -97,6 +131,7 @@ func GetAllTools(c client.Doer, logger *zap.Logger) []Tool {
 		NewGetViewFolderTool(c, logger),
 		NewReplaceViewFolderTool(c, logger),
 		NewDeleteViewFolderTool(c, logger),
+		NewMoveViewToFolderTool(c, logger),
 
 		// Data Usage tools
 		NewExportDataUsageTool(c, logger),
@@ -112,6 +147,7 @@ func GetAllTools(c client.Doer, logger *zap.Logger) []Tool {
 		NewListDashboardsTool(c, logger),
 		NewGetDashboardTool(c, logger),
 		NewCreateDashboardTool(c, logger),
+		NewCreateDashboardFromTemplateTool(c, logger),
 		NewUpdateDashboardTool(c, logger),
 		NewDeleteDashboardTool(c, logger),
 
@@ -132,24 +168,40 @@ func GetAllTools(c client.Doer, logger *zap.Logger) []Tool {
 		NewCreateStreamTool(c, logger),
 		NewUpdateStreamTool(c, logger),
 		NewDeleteStreamTool(c, logger),
+		NewTestStreamTool(c, logger),
 
 		// AI Helper tools
 		NewExplainQueryTool(c, logger),
+		NewParseErrorTool(c, logger),
 		NewAdvancedSuggestAlertTool(c, logger), // SRE-grade alert recommendations
 		NewGetAuditLogTool(c, logger),
 
+		// Infrastructure as Code tools
+		NewExportTerraformTool(c, logger),
+
 		// Query Intelligence tools
 		NewQueryTemplatesTool(c, logger),
 		NewValidateQueryTool(c, logger),
+		NewNormalizeQueryTool(c, logger),
+		NewConvertLuceneToDataPrimeTool(c, logger),
+		NewConvertDataPrimeToLuceneTool(c, logger),
 		NewQueryCostEstimateTool(c, logger),
 
 		// Workflow Automation tools
 		NewInvestigateIncidentTool(c, logger),
 		NewHealthCheckTool(c, logger),
+		NewCheckReferencesTool(c, logger),
+		NewBackupInstanceTool(c, logger),
+		NewRestoreInstanceTool(c, logger),
+		NewDiffBackupTool(c, logger),
 
 		// Meta tools (discovery and session management)
 		NewDiscoverToolsTool(c, logger),
 		NewSessionContextTool(c, logger),
+		NewRecordFeedbackTool(c, logger),
+		NewWhoAmITool(c, logger),
+		NewSearchAllResourcesTool(c, logger),
+		NewListInstancesTool(c, logger),
 
 		// Dynamic toolset meta-tools (token-efficient discovery pattern)
 		// These enable: search_tools → describe_tools → execute workflow
@@ -162,5 +214,5 @@ func GetAllTools(c client.Doer, logger *zap.Logger) []Tool {
 // GetToolCount returns the total number of registered tools.
 // Useful for metrics and logging.
 func GetToolCount() int {
-	return 87 // Update this when adding new tools
+	return 135 // Update this when adding new tools
 }
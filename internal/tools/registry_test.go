@@ -1,6 +1,7 @@
 package tools
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -41,6 +42,35 @@ func TestGetAllTools(t *testing.T) {
 		assert.GreaterOrEqual(t, len(tools), expectedMin,
 			"Expected at least %d tools, got %d", expectedMin, len(tools))
 	})
+
+	t.Run("create and update tools have schema examples", func(t *testing.T) {
+		for _, tool := range tools {
+			name := tool.Name()
+			if !strings.HasPrefix(name, "create_") && !strings.HasPrefix(name, "update_") && !strings.HasPrefix(name, "replace_") {
+				continue
+			}
+			schema, ok := tool.InputSchema().(map[string]interface{})
+			require.True(t, ok, "Tool %s should have a map input schema", name)
+			assert.True(t, schemaHasExample(schema), "Tool %s should include an example in its input schema", name)
+		}
+	})
+}
+
+// schemaHasExample reports whether a schema or any of its properties carries an example
+func schemaHasExample(schema map[string]interface{}) bool {
+	if _, ok := schema["examples"]; ok {
+		return true
+	}
+	if _, ok := schema["example"]; ok {
+		return true
+	}
+	props, _ := schema["properties"].(map[string]interface{})
+	for _, prop := range props {
+		if propMap, ok := prop.(map[string]interface{}); ok && schemaHasExample(propMap) {
+			return true
+		}
+	}
+	return false
 }
 
 func TestToolTimeouts(t *testing.T) {
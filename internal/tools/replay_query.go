@@ -0,0 +1,156 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// defaultReplayWindow is the window used when only the query text of the last query is known
+const defaultReplayWindow = time.Hour
+
+// parseLookback parses a lookback such as "15m", "24h" or "7d" (time.ParseDuration plus a day unit)
+func parseLookback(s string) (time.Duration, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid time_range %q (use e.g. 15m, 1h, 24h, 7d)", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid time_range %q (use e.g. 15m, 1h, 24h, 7d)", s)
+	}
+	return d, nil
+}
+
+// ReplayQueryTool reruns the session's last query_logs call with optional overrides
+type ReplayQueryTool struct{ *BaseTool }
+
+// NewReplayQueryTool creates a new tool instance
+func NewReplayQueryTool(c client.Doer, l *zap.Logger) *ReplayQueryTool {
+	return &ReplayQueryTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *ReplayQueryTool) Name() string { return "replay_query" }
+
+// Annotations returns tool hints for LLMs
+func (t *ReplayQueryTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Replay Last Query")
+}
+
+// DefaultTimeout returns the timeout (same as query_logs)
+func (t *ReplayQueryTool) DefaultTimeout() time.Duration {
+	return DefaultQueryTimeout
+}
+
+// Description returns the tool description
+func (t *ReplayQueryTool) Description() string {
+	return `Rerun the last query_logs query of this session without restating it.
+
+Optionally override the time range (time_range relative to now, or start_date/end_date) or the
+application/subsystem filter. Everything else (query text, tier, syntax, limit) is reused.
+
+**Best for:** "Show me the same thing for the last 24h" or "now for the payments app".
+
+**Related tools:** query_logs, session_context`
+}
+
+// InputSchema returns the input schema
+func (t *ReplayQueryTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"time_range": map[string]interface{}{
+				"type":        "string",
+				"description": "New window ending now, e.g. '15m', '1h', '24h', '7d'",
+			},
+			"start_date": map[string]interface{}{
+				"type":        "string",
+				"format":      "date-time",
+				"description": "New start date (RFC3339). Use with end_date instead of time_range.",
+			},
+			"end_date": map[string]interface{}{
+				"type":        "string",
+				"format":      "date-time",
+				"description": "New end date (RFC3339). Use with start_date instead of time_range.",
+			},
+			"applicationName": map[string]interface{}{
+				"type":        "string",
+				"description": "Replace the application filter",
+			},
+			"subsystemName": map[string]interface{}{
+				"type":        "string",
+				"description": "Replace the subsystem filter",
+			},
+		},
+	}
+}
+
+// Execute executes the tool
+func (t *ReplayQueryTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	session := GetSessionFromContext(ctx)
+
+	queryArgs := session.GetLastQueryArgs()
+	if queryArgs == nil {
+		lastQuery := session.GetLastQuery()
+		if lastQuery == "" {
+			return NewToolResultErrorWithSuggestion("No previous query to replay in this session.",
+				"Run query_logs first; replay_query then reruns it with a different time range or filter."), nil
+		}
+		// Only the query text is known, so start from a default window
+		end := time.Now().UTC()
+		queryArgs = map[string]interface{}{
+			"query":      lastQuery,
+			"start_date": end.Add(-defaultReplayWindow).Format(time.RFC3339),
+			"end_date":   end.Format(time.RFC3339),
+		}
+	}
+
+	timeRange, _ := GetStringParam(args, "time_range", false)
+	startDate, _ := GetStringParam(args, "start_date", false)
+	endDate, _ := GetStringParam(args, "end_date", false)
+	switch {
+	case timeRange != "" && (startDate != "" || endDate != ""):
+		return NewToolResultError("Use either time_range or start_date/end_date, not both"), nil
+	case timeRange != "":
+		window, err := parseLookback(timeRange)
+		if err != nil {
+			return NewToolResultError(err.Error()), nil
+		}
+		end := time.Now().UTC()
+		queryArgs["start_date"] = end.Add(-window).Format(time.RFC3339)
+		queryArgs["end_date"] = end.Format(time.RFC3339)
+	case startDate != "" || endDate != "":
+		if startDate == "" || endDate == "" {
+			return NewToolResultError("start_date and end_date must be given together"), nil
+		}
+		queryArgs["start_date"] = startDate
+		queryArgs["end_date"] = endDate
+	}
+
+	if app, _ := GetStringParam(args, "applicationName", false); app != "" {
+		for _, alias := range applicationAliases {
+			delete(queryArgs, alias)
+		}
+		queryArgs["applicationName"] = app
+	}
+	if subsystem, _ := GetStringParam(args, "subsystemName", false); subsystem != "" {
+		for _, alias := range subsystemAliases {
+			delete(queryArgs, alias)
+		}
+		queryArgs["subsystemName"] = subsystem
+	}
+
+	return (&QueryTool{t.BaseTool}).Execute(ctx, queryArgs)
+}
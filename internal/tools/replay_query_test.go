@@ -0,0 +1,90 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestParseLookback(t *testing.T) {
+	tests := map[string]time.Duration{
+		"15m": 15 * time.Minute,
+		"24h": 24 * time.Hour,
+		"7d":  7 * 24 * time.Hour,
+	}
+	for in, want := range tests {
+		if got, err := parseLookback(in); err != nil || got != want {
+			t.Errorf("parseLookback(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "-1h", "xd", "week"} {
+		if _, err := parseLookback(bad); err == nil {
+			t.Errorf("parseLookback(%q) should fail", bad)
+		}
+	}
+}
+
+func TestReplayQueryTool_NoPreviousQuery(t *testing.T) {
+	mock := client.NewMockClient()
+	result, err := NewReplayQueryTool(mock, zap.NewNop()).Execute(testCtx(mock), map[string]interface{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].(*mcp.TextContent).Text, "No previous query") {
+		t.Errorf("expected a no-previous-query message, got %+v", result.Content)
+	}
+	if mock.RequestCount() != 0 {
+		t.Errorf("no request should be sent, got %d", mock.RequestCount())
+	}
+}
+
+func TestReplayQueryTool_Overrides(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.DefaultResponse = &client.Response{
+		StatusCode: 200,
+		Body:       []byte("data: {\"result\":{\"message\":\"test log\"}}\n"),
+	}
+	ctx := testCtx(mock)
+
+	_, err := NewQueryTool(mock, zap.NewNop()).Execute(ctx, map[string]interface{}{
+		"query":      "source logs | filter $m.severity >= ERROR",
+		"start_date": "2024-01-01T00:00:00Z",
+		"end_date":   "2024-01-01T01:00:00Z",
+		"tier":       "frequent_search",
+		"app":        "checkout",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replay := NewReplayQueryTool(mock, zap.NewNop())
+	result, err := replay.Execute(ctx, map[string]interface{}{"time_range": "24h", "applicationName": "payments"})
+	if err != nil || result.IsError {
+		t.Fatalf("replay failed: %v %+v", err, result.Content)
+	}
+
+	body := mock.LastRequest().Body.(map[string]interface{})
+	query := body["query"].(string)
+	if !strings.Contains(query, "$m.severity >= ERROR") || !strings.Contains(query, "$l.applicationname == 'payments'") || strings.Contains(query, "checkout") {
+		t.Errorf("replayed query should reuse the text with the new application filter: %s", query)
+	}
+	metadata := body["metadata"].(map[string]interface{})
+	if metadata["tier"] != "frequent_search" {
+		t.Errorf("tier should be reused, got %v", metadata["tier"])
+	}
+	start, _ := time.Parse(time.RFC3339, metadata["start_date"].(string))
+	end, _ := time.Parse(time.RFC3339, metadata["end_date"].(string))
+	if end.Sub(start) != 24*time.Hour || time.Since(end) > time.Minute {
+		t.Errorf("expected a 24h window ending now, got %v - %v", start, end)
+	}
+
+	result, _ = replay.Execute(ctx, map[string]interface{}{"time_range": "1h", "start_date": "2024-01-01T00:00:00Z"})
+	if !result.IsError {
+		t.Error("time_range with start_date should be rejected")
+	}
+}
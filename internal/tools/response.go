@@ -5,27 +5,39 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"sync/atomic"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+	mcperrors "github.com/tareqmamari/cloud-logs-mcp/internal/errors"
+	"github.com/tareqmamari/cloud-logs-mcp/internal/security"
 )
 
 // Response size limits
 const (
-	// MaxResultSize is the maximum size of tool results in bytes (100KB for Claude Desktop compatibility)
+	// MaxResultSize is the default maximum size of tool results in bytes (100KB for Claude Desktop compatibility)
 	// Claude Desktop's context compaction can fail with very large tool results, so we use a conservative limit
 	// This is much lower than MCP's 1MB limit to ensure reliable operation
+	// Override with LOGS_MAX_RESULT_SIZE (see SetResponseLimits)
 	MaxResultSize = 100 * 1024
 
-	// FinalResponseLimit is the absolute maximum size for the final response text before sending to MCP
+	// FinalResponseLimit is the default absolute maximum size for the final response text before sending to MCP
 	// This ensures we never exceed limits that could cause "compaction failed" errors in Claude Desktop
+	// Override with LOGS_FINAL_RESPONSE_LIMIT (see SetResponseLimits)
 	FinalResponseLimit = 150 * 1024
 
-	// MaxSSEEvents is the maximum number of log entries to retain from SSE parsing.
+	// MaxSSEEvents is the default maximum number of log entries to retain from SSE parsing.
 	// Response-level truncation (MaxResultSize) handles size limits for the final output,
 	// so this cap only prevents excessive memory use during parsing.
+	// Override with LOGS_MAX_QUERY_EVENTS, or per call with max_events (see SetQueryEventLimits)
 	MaxSSEEvents = 2000
 
+	// MaxQueryEventsLimit is the default upper bound for the max_events query argument.
+	// Override with LOGS_MAX_QUERY_EVENTS_LIMIT (see SetQueryEventLimits)
+	MaxQueryEventsLimit = 20000
+
 	// TruncationBufferSize is the buffer size reserved for warning messages when truncating results
 	TruncationBufferSize = 500
 
@@ -89,14 +101,34 @@ func CleanQueryResults(result map[string]interface{}) map[string]interface{} {
 		}
 	}
 
+	// Collapse repeated lines when query_logs was given dedupe. This runs before the
+	// fields projection so entries are compared on their full compact form.
+	if mode := dedupeMode(result); mode != DedupeOff {
+		var collapsed int
+		cleanedEvents, collapsed = DedupeLogEntries(cleanedEvents, mode)
+		result["_query_metadata"].(map[string]interface{})["dedupe_collapsed"] = collapsed
+	}
+
+	// Keep only the requested keys when query_logs was given fields, and report
+	// requested keys that no entry had
+	if fields := projectedFields(result); len(fields) > 0 {
+		var missing []string
+		cleanedEvents, missing = ProjectLogFields(cleanedEvents, fields)
+		if len(missing) > 0 {
+			result["_query_metadata"].(map[string]interface{})["missing_fields"] = missing
+		}
+	}
+
 	// Create compact result
 	cleaned := map[string]interface{}{
 		"logs": cleanedEvents,
 	}
 
-	// Preserve query metadata
-	if meta, ok := result["_query_metadata"]; ok {
-		cleaned["_query_metadata"] = meta
+	// Preserve query metadata and SSE truncation markers
+	for _, key := range []string{"_query_metadata", "_truncated", "_total_events", "_shown_events"} {
+		if v, ok := result[key]; ok {
+			cleaned[key] = v
+		}
 	}
 
 	return cleaned
@@ -152,7 +184,7 @@ func transformLogEntry(entry map[string]interface{}) map[string]interface{} {
 				case "timestamp":
 					compact["time"] = value
 				case "severity":
-					compact["severity"] = value
+					compact["severity"] = normalizedSeverityValue(value)
 				}
 			}
 		}
@@ -161,8 +193,8 @@ func transformLogEntry(entry map[string]interface{}) map[string]interface{} {
 		if ts, ok := entry["timestamp"].(string); ok && ts != "" {
 			compact["time"] = ts
 		}
-		if sev, ok := entry["severity"].(string); ok {
-			compact["severity"] = sev
+		if sev, ok := entry["severity"]; ok && sev != nil {
+			compact["severity"] = normalizedSeverityValue(sev)
 		}
 	}
 
@@ -231,8 +263,136 @@ func extractUserData(ud map[string]interface{}, compact map[string]interface{})
 	}
 }
 
+// Configured response size and event limits, initialized to the defaults above
+var (
+	maxResultSizeLimit     atomic.Int64
+	finalResponseSizeLimit atomic.Int64
+	defaultQueryEvents     atomic.Int64
+	queryEventsLimit       atomic.Int64
+)
+
+func init() {
+	maxResultSizeLimit.Store(MaxResultSize)
+	finalResponseSizeLimit.Store(FinalResponseLimit)
+	defaultQueryEvents.Store(MaxSSEEvents)
+	queryEventsLimit.Store(MaxQueryEventsLimit)
+}
+
+// SetQueryEventLimits overrides how many log entries query responses keep by default and
+// the most a max_events argument may request. Non-positive values restore the defaults.
+// The limit is raised to at least the default so the default is always allowed.
+func SetQueryEventLimits(defaultEvents, limit int) {
+	if defaultEvents <= 0 {
+		defaultEvents = MaxSSEEvents
+	}
+	if limit <= 0 {
+		limit = MaxQueryEventsLimit
+	}
+	if limit < defaultEvents {
+		limit = defaultEvents
+	}
+	defaultQueryEvents.Store(int64(defaultEvents))
+	queryEventsLimit.Store(int64(limit))
+}
+
+// currentMaxSSEEvents returns the configured default number of log entries kept from a query
+func currentMaxSSEEvents() int {
+	return int(defaultQueryEvents.Load())
+}
+
+// currentQueryEventsLimit returns the most log entries a max_events argument may request
+func currentQueryEventsLimit() int {
+	return int(queryEventsLimit.Load())
+}
+
+// SetResponseLimits overrides the result size and final response limits.
+// Non-positive values restore the defaults. The final limit is raised to at
+// least the result size so truncated results always fit.
+func SetResponseLimits(maxResult, finalLimit int) {
+	if maxResult <= 0 {
+		maxResult = MaxResultSize
+	}
+	if finalLimit <= 0 {
+		finalLimit = FinalResponseLimit
+	}
+	if finalLimit < maxResult {
+		finalLimit = maxResult
+	}
+	maxResultSizeLimit.Store(int64(maxResult))
+	finalResponseSizeLimit.Store(int64(finalLimit))
+}
+
+// responseRedactor masks secrets (tokens, webhook keys, basic-auth URLs) in formatted responses
+var responseRedactor = security.NewRedactor()
+
+// SetSecretRedaction enables or disables secret redaction in tool responses and adds
+// extra regular expressions to the default pattern set
+func SetSecretRedaction(enabled bool, extraPatterns []string) error {
+	return responseRedactor.Configure(enabled, extraPatterns)
+}
+
+// redactSecrets masks secrets in response text using the configured pattern set
+func redactSecrets(text string) string {
+	return responseRedactor.Redact(text)
+}
+
+// piiRedactor masks personal data in query events when query_logs is called with redact_pii
+var piiRedactor = security.NewPIIRedactor()
+
+// SetPIIPatterns adds extra regular expressions to the default PII detectors
+// (emails, IP addresses, card numbers, phone numbers)
+func SetPIIPatterns(extraPatterns []string) error {
+	return piiRedactor.Configure(extraPatterns)
+}
+
+// redactPIIInEvents masks personal data in every string value of the result's events,
+// including nested user_data, and returns the number of values masked
+func redactPIIInEvents(result map[string]interface{}) int {
+	events, ok := result["events"].([]interface{})
+	if !ok {
+		return 0
+	}
+	count := 0
+	for i, event := range events {
+		events[i] = redactPIIValue(event, &count)
+	}
+	return count
+}
+
+// redactPIIValue returns v with personal data masked in its strings, recursing into maps and slices
+func redactPIIValue(v interface{}, count *int) interface{} {
+	switch val := v.(type) {
+	case string:
+		redacted, n := piiRedactor.Redact(val)
+		*count += n
+		return redacted
+	case map[string]interface{}:
+		for k, item := range val {
+			val[k] = redactPIIValue(item, count)
+		}
+		return val
+	case []interface{}:
+		for i, item := range val {
+			val[i] = redactPIIValue(item, count)
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+// currentMaxResultSize returns the configured maximum tool result size in bytes
+func currentMaxResultSize() int {
+	return int(maxResultSizeLimit.Load())
+}
+
+// currentFinalResponseLimit returns the configured final response limit in bytes
+func currentFinalResponseLimit() int {
+	return int(finalResponseSizeLimit.Load())
+}
+
 // FormatResponse formats the response as a text/content for MCP
-// If the result exceeds MaxResultSize, it will be truncated with pagination hints
+// If the result exceeds the configured result size limit, it will be truncated with pagination hints
 func (t *BaseTool) FormatResponse(result map[string]interface{}) (*mcp.CallToolResult, error) {
 	// Handle empty result - len(nil map) is 0, so this covers both nil and empty
 	if len(result) == 0 {
@@ -250,27 +410,22 @@ func (t *BaseTool) FormatResponse(result map[string]interface{}) (*mcp.CallToolR
 	if err != nil {
 		// Return a valid CallToolResult with error message instead of nil
 		// This prevents "compaction failed" errors in Claude Desktop
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{
-					Text: fmt.Sprintf("Error formatting response: %v\n\nRaw data keys: %v", err, getMapKeys(result)),
-				},
-			},
-			IsError: true,
-		}, nil
+		return NewToolResultErrorWithCode(mcperrors.CodeUpstreamError,
+			fmt.Sprintf("Error formatting response: %v\n\nRaw data keys: %v", err, getMapKeys(result))), nil
 	}
 
 	responseText := string(jsonBytes)
+	maxSize := currentMaxResultSize()
 
 	// Check if response exceeds size limit
-	if len(jsonBytes) > MaxResultSize {
+	if len(jsonBytes) > maxSize {
 		// Try to truncate intelligently by reducing the data
-		_, truncatedBytes := truncateResult(result, MaxResultSize)
+		_, truncatedBytes := truncateResult(result, maxSize)
 		if truncatedBytes != nil {
 			responseText = string(truncatedBytes)
 		} else {
 			// Fallback: hard truncate the JSON string
-			responseText = string(jsonBytes[:MaxResultSize-TruncationBufferSize])
+			responseText = string(jsonBytes[:maxSize-TruncationBufferSize])
 		}
 
 		totalItems := countItems(result)
@@ -285,7 +440,7 @@ func (t *BaseTool) FormatResponse(result map[string]interface{}) (*mcp.CallToolR
 			"   - `filter $m.severity >= ERROR`\n"+
 			"3. **Use smaller limits** - `limit 20` instead of large numbers\n"+
 			"4. **Split time range** - Query smaller time windows",
-			shownItems, totalItems, len(jsonBytes), MaxResultSize)
+			shownItems, totalItems, len(jsonBytes), maxSize)
 		responseText += warningMsg
 
 		t.logger.Warn("Result truncated due to size limit - pagination recommended",
@@ -297,7 +452,7 @@ func (t *BaseTool) FormatResponse(result map[string]interface{}) (*mcp.CallToolR
 	}
 
 	// Final safety check
-	responseText = ensureResponseLimit(responseText, t.logger)
+	responseText = ensureResponseLimit(redactSecrets(responseText), t.logger)
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
@@ -454,7 +609,7 @@ func GenerateResultSummary(result map[string]interface{}, resultType string) str
 			}
 
 			// Time range
-			timeRange := extractTimeRange(events)
+			timeRange := extractTimeRange(events, timeDisplayFromResult(result))
 			if timeRange != "" {
 				fmt.Fprintf(&summary, "### Time Range\n%s\n\n", timeRange)
 			}
@@ -508,14 +663,27 @@ type LogCluster struct {
 	Pattern  string        `json:"pattern"`
 	Count    int           `json:"count"`
 	Severity string        `json:"severity"`
+	Traces   int           `json:"traces,omitempty"` // unique trace IDs among the events
 	Events   []interface{} `json:"events"`
 }
 
-// ClusterLogs groups log events by their message content.
-// Events with the same message are placed in the same cluster.
+// ClusterLogs groups log events by their message template at the default sensitivity.
 func ClusterLogs(events []interface{}) []LogCluster {
-	clusterMap := make(map[string]*LogCluster)
-	var order []string
+	return ClusterLogsWithSensitivity(events, DefaultClusteringSensitivity)
+}
+
+// ClusterLogsWithSensitivity groups log events whose messages share a template. Variable
+// tokens such as numbers and IDs are masked as <*>, and the sensitivity sets how many other
+// words may differ before two messages form separate patterns.
+func ClusterLogsWithSensitivity(events []interface{}, sensitivity ClusteringSensitivity) []LogCluster {
+	type clusterState struct {
+		template []string
+		cluster  *LogCluster
+		traces   map[string]bool
+	}
+	maxDiffs := clusteringMaxDiffs[sensitivity]
+	byLength := make(map[int][]*clusterState)
+	var order []*clusterState
 
 	for _, event := range events {
 		eventMap, ok := event.(map[string]interface{})
@@ -527,24 +695,45 @@ func ClusterLogs(events []interface{}) []LogCluster {
 		if message == "" {
 			message = "(empty)"
 		}
+		tokens := messageTemplate(message, sensitivity)
 
-		if cluster, exists := clusterMap[message]; exists {
-			cluster.Count++
-			cluster.Events = append(cluster.Events, event)
+		var state *clusterState
+		for _, candidate := range byLength[len(tokens)] {
+			if templateMatches(candidate.template, tokens, maxDiffs) {
+				state = candidate
+				break
+			}
+		}
+		if state != nil {
+			mergeTemplate(state.template, tokens)
+			state.cluster.Count++
+			state.cluster.Events = append(state.cluster.Events, event)
 		} else {
-			order = append(order, message)
-			clusterMap[message] = &LogCluster{
-				Pattern:  message,
-				Count:    1,
-				Severity: extractSeverityName(eventMap),
-				Events:   []interface{}{event},
+			state = &clusterState{
+				template: tokens,
+				cluster: &LogCluster{
+					Count:    1,
+					Severity: extractSeverityName(eventMap),
+					Events:   []interface{}{event},
+				},
+				traces: make(map[string]bool),
 			}
+			byLength[len(tokens)] = append(byLength[len(tokens)], state)
+			order = append(order, state)
+		}
+		if id := eventTraceID(eventMap); id != "" && !state.traces[id] {
+			state.traces[id] = true
+			state.cluster.Traces++
 		}
 	}
 
-	clusters := make([]LogCluster, 0, len(clusterMap))
-	for _, msg := range order {
-		clusters = append(clusters, *clusterMap[msg])
+	clusters := make([]LogCluster, 0, len(order))
+	for _, state := range order {
+		state.cluster.Pattern = strings.Join(state.template, " ")
+		if state.cluster.Pattern == "" {
+			state.cluster.Pattern = "(empty)"
+		}
+		clusters = append(clusters, *state.cluster)
 	}
 
 	// Sort by count descending
@@ -558,7 +747,12 @@ func ClusterLogs(events []interface{}) []LogCluster {
 // FormatClusteredSummary clusters log events and returns a formatted summary string.
 // The limit parameter controls the maximum number of clusters shown.
 func FormatClusteredSummary(events []interface{}, limit int) string {
-	clusters := ClusterLogs(events)
+	return FormatClusteredSummaryWithSensitivity(events, limit, DefaultClusteringSensitivity)
+}
+
+// FormatClusteredSummaryWithSensitivity is FormatClusteredSummary at the given clustering sensitivity
+func FormatClusteredSummaryWithSensitivity(events []interface{}, limit int, sensitivity ClusteringSensitivity) string {
+	clusters := ClusterLogsWithSensitivity(events, sensitivity)
 	if len(clusters) == 0 {
 		return "No log events to summarize."
 	}
@@ -573,7 +767,11 @@ func FormatClusteredSummary(events []interface{}, limit int) string {
 
 	for i := 0; i < shown; i++ {
 		c := clusters[i]
-		fmt.Fprintf(&sb, "**[%s] %dx:** %s\n", c.Severity, c.Count, c.Pattern)
+		if c.Traces > 0 {
+			fmt.Fprintf(&sb, "**[%s] %dx in %d traces:** %s\n", c.Severity, c.Count, c.Traces, c.Pattern)
+		} else {
+			fmt.Fprintf(&sb, "**[%s] %dx:** %s\n", c.Severity, c.Count, c.Pattern)
+		}
 	}
 
 	if len(clusters) > shown {
@@ -591,30 +789,19 @@ func extractMessage(eventMap map[string]interface{}) string {
 	if ud, ok := eventMap["user_data"].(string); ok {
 		return ud
 	}
+	// Query results carry the message inside the parsed user_data
+	if ud, ok := eventMap["user_data"].(map[string]interface{}); ok {
+		if msg, ok := ud["message"].(string); ok {
+			return msg
+		}
+	}
 	return ""
 }
 
 // extractSeverityName returns a human-readable severity from an event map.
 func extractSeverityName(eventMap map[string]interface{}) string {
-	severityNames := map[int]string{
-		1: "Debug", 2: "Verbose", 3: "Info",
-		4: "Warning", 5: "Error", 6: "Critical",
-	}
-
-	var sev float64
-	if s, ok := eventMap["severity"].(float64); ok {
-		sev = s
-	} else if meta, ok := eventMap["metadata"].(map[string]interface{}); ok {
-		if s, ok := meta["severity"].(float64); ok {
-			sev = s
-		}
-	}
-
-	if name, ok := severityNames[int(sev)]; ok {
-		return name
-	}
-	if sev > 0 {
-		return fmt.Sprintf("Level %d", int(sev))
+	if level, ok := eventSeverity(eventMap); ok {
+		return SeverityName(level)
 	}
 	return "Unknown"
 }
@@ -627,38 +814,11 @@ type ValueCount struct {
 
 // analyzeSeverityDistribution counts log entries by severity level
 func analyzeSeverityDistribution(events []interface{}) map[string]int {
-	severityNames := map[int]string{
-		1: "Debug",
-		2: "Verbose",
-		3: "Info",
-		4: "Warning",
-		5: "Error",
-		6: "Critical",
-	}
-
 	dist := make(map[string]int)
 	for _, event := range events {
 		if eventMap, ok := event.(map[string]interface{}); ok {
-			// Try different severity field locations
-			var severity int
-			if sev, ok := eventMap["severity"].(float64); ok {
-				severity = int(sev)
-			} else if labels, ok := eventMap["labels"].(map[string]interface{}); ok {
-				if sev, ok := labels["severity"].(float64); ok {
-					severity = int(sev)
-				}
-			} else if metadata, ok := eventMap["metadata"].(map[string]interface{}); ok {
-				if sev, ok := metadata["severity"].(float64); ok {
-					severity = int(sev)
-				}
-			}
-
-			if severity > 0 {
-				name := severityNames[severity]
-				if name == "" {
-					name = fmt.Sprintf("Level %d", severity)
-				}
-				dist[name]++
+			if level, ok := eventSeverity(eventMap); ok {
+				dist[SeverityName(level)]++
 			}
 		}
 	}
@@ -734,8 +894,8 @@ func findFieldValue(data map[string]interface{}, fieldName string) string {
 	return ""
 }
 
-// extractTimeRange extracts the time range from events
-func extractTimeRange(events []interface{}) string {
+// extractTimeRange extracts the time range from events, rendered with the given time display
+func extractTimeRange(events []interface{}, display TimeDisplay) string {
 	if len(events) == 0 {
 		return ""
 	}
@@ -767,7 +927,7 @@ func extractTimeRange(events []interface{}) string {
 	}
 
 	if earliest != "" && latest != "" {
-		return fmt.Sprintf("From: %s\nTo: %s", earliest, latest)
+		return fmt.Sprintf("From: %s\nTo: %s", display.FormatWithRaw(earliest), display.FormatWithRaw(latest))
 	}
 	return ""
 }
@@ -786,6 +946,8 @@ func extractLastTimestamp(events []interface{}) string {
 			timestamp := ""
 			if ts, ok := eventMap["timestamp"].(string); ok {
 				timestamp = ts
+			} else if ts, ok := eventMap["time"].(string); ok {
+				timestamp = ts
 			} else if ts, ok := eventMap["@timestamp"].(string); ok {
 				timestamp = ts
 			} else if metadata, ok := eventMap["metadata"].(map[string]interface{}); ok {
@@ -811,11 +973,32 @@ type PaginationInfo struct {
 	NextStartDate string `json:"next_start_date,omitempty"`
 }
 
+// resultEventCap returns the number of log entries a query result was capped at: the
+// max_events recorded in its _query_metadata or the configured default
+func resultEventCap(result map[string]interface{}) int {
+	if meta, ok := result["_query_metadata"].(map[string]interface{}); ok {
+		switch n := meta["max_events"].(type) {
+		case int:
+			if n > 0 {
+				return n
+			}
+		case float64:
+			if n > 0 {
+				return int(n)
+			}
+		}
+	}
+	return currentMaxSSEEvents()
+}
+
 // extractPaginationInfo extracts pagination metadata from query results
 func extractPaginationInfo(result map[string]interface{}, limit int, wasTruncated bool) *PaginationInfo {
 	events, ok := result["events"].([]interface{})
 	if !ok {
-		return nil
+		// Cleaned results carry their entries under logs
+		if events, ok = result["logs"].([]interface{}); !ok {
+			return nil
+		}
 	}
 
 	info := &PaginationInfo{
@@ -878,25 +1061,20 @@ func (t *BaseTool) FormatResponseWithSuggestions(result map[string]interface{},
 	if err != nil {
 		// Return a valid CallToolResult with error message instead of nil
 		// This prevents "compaction failed" errors in Claude Desktop
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{
-					Text: fmt.Sprintf("Error formatting response: %v\n\nRaw data keys: %v", err, getMapKeys(result)),
-				},
-			},
-			IsError: true,
-		}, nil
+		return NewToolResultErrorWithCode(mcperrors.CodeUpstreamError,
+			fmt.Sprintf("Error formatting response: %v\n\nRaw data keys: %v", err, getMapKeys(result))), nil
 	}
 
 	responseText := string(jsonBytes)
+	maxSize := currentMaxResultSize()
 
 	// Check if response exceeds size limit
-	if len(jsonBytes) > MaxResultSize {
-		_, truncatedBytes := truncateResult(result, MaxResultSize)
+	if len(jsonBytes) > maxSize {
+		_, truncatedBytes := truncateResult(result, maxSize)
 		if truncatedBytes != nil {
 			responseText = string(truncatedBytes)
 		} else {
-			responseText = string(jsonBytes[:MaxResultSize-TruncationBufferSize])
+			responseText = string(jsonBytes[:maxSize-TruncationBufferSize])
 		}
 
 		totalItems := countItems(result)
@@ -932,7 +1110,7 @@ func (t *BaseTool) FormatResponseWithSuggestions(result map[string]interface{},
 	}
 
 	// Final safety check
-	responseText = ensureResponseLimit(responseText, t.logger)
+	responseText = ensureResponseLimit(redactSecrets(responseText), t.logger)
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
@@ -990,14 +1168,8 @@ func (t *BaseTool) FormatResponseWithSummaryAndSuggestions(result map[string]int
 	default:
 		jsonBytes, err := json.MarshalIndent(result, "", "  ")
 		if err != nil {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					&mcp.TextContent{
-						Text: fmt.Sprintf("Error formatting response: %v\n\nRaw data keys: %v", err, getMapKeys(result)),
-					},
-				},
-				IsError: true,
-			}, nil
+			return NewToolResultErrorWithCode(mcperrors.CodeUpstreamError,
+				fmt.Sprintf("Error formatting response: %v\n\nRaw data keys: %v", err, getMapKeys(result))), nil
 		}
 		if summary != "" {
 			responseText = summary + "---\n\n### Raw Data\n\n" + string(jsonBytes)
@@ -1008,17 +1180,18 @@ func (t *BaseTool) FormatResponseWithSummaryAndSuggestions(result map[string]int
 
 	// Check if response exceeds size limit
 	truncatedBySize := false
-	if len(responseText) > MaxResultSize {
+	maxSize := currentMaxResultSize()
+	if len(responseText) > maxSize {
 		truncatedBySize = true
 		// Regenerate with fewer entries
 		switch resultType {
 		case "query results":
-			responseText = formatLogsAsMarkdownTruncated(result, summary, MaxResultSize-TruncationBufferSize)
+			responseText = formatLogsAsMarkdownTruncated(result, summary, maxSize-TruncationBufferSize)
 		case "raw query results":
-			responseText = formatRawLogsAsMarkdownTruncated(result, summary, MaxResultSize-TruncationBufferSize)
+			responseText = formatRawLogsAsMarkdownTruncated(result, summary, maxSize-TruncationBufferSize)
 		default:
 			jsonBytes, _ := json.MarshalIndent(result, "", "  ")
-			_, truncatedBytes := truncateResult(result, MaxResultSize-len(summary)-TruncationBufferSize)
+			_, truncatedBytes := truncateResult(result, maxSize-len(summary)-TruncationBufferSize)
 			if truncatedBytes != nil {
 				if summary != "" {
 					responseText = summary + "---\n\n### Raw Data (truncated)\n\n" + string(truncatedBytes)
@@ -1033,12 +1206,17 @@ func (t *BaseTool) FormatResponseWithSummaryAndSuggestions(result map[string]int
 
 	// Add pagination info for query results
 	if isQueryResult {
-		paginationInfo := extractPaginationInfo(result, MaxSSEEvents, wasTruncated || truncatedBySize)
+		eventCap := resultEventCap(result)
+		paginationInfo := extractPaginationInfo(result, eventCap, wasTruncated || truncatedBySize)
 		if paginationInfo != nil && paginationInfo.HasMore {
 			paginationMsg := fmt.Sprintf("\n\n---\n📄 **PAGINATION INFO:**\n"+
 				"- Results returned: %d\n"+
 				"- More results available: Yes\n",
 				paginationInfo.TotalReturned)
+			if parsedTruncated, _ := result["_truncated"].(bool); parsedTruncated {
+				paginationMsg += fmt.Sprintf("- Event cap: %d (raise with `max_events`, server maximum %d)\n",
+					eventCap, currentQueryEventsLimit())
+			}
 
 			if paginationInfo.NextStartDate != "" {
 				paginationMsg += fmt.Sprintf("- Last timestamp: `%s`\n\n"+
@@ -1068,7 +1246,7 @@ func (t *BaseTool) FormatResponseWithSummaryAndSuggestions(result map[string]int
 	}
 
 	// Final safety check: ensure response doesn't exceed absolute limit
-	responseText = ensureResponseLimit(responseText, t.logger)
+	responseText = ensureResponseLimit(redactSecrets(responseText), t.logger)
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
@@ -1081,21 +1259,48 @@ func (t *BaseTool) FormatResponseWithSummaryAndSuggestions(result map[string]int
 
 // AddRateLimitMetadata adds rate limit information to a result map.
 // This helps LLMs understand API limits and pace requests appropriately.
-func AddRateLimitMetadata(result map[string]interface{}, available float64, limit int, enabled bool) {
-	if !enabled {
+func AddRateLimitMetadata(result map[string]interface{}, info client.RateLimitInfo) {
+	if !info.Enabled || result == nil {
 		return
 	}
 
-	result["_rate_limit"] = map[string]interface{}{
-		"available":        int(available),
-		"limit_per_second": limit,
-		"status":           getRateLimitStatus(available, limit),
+	result["_rate_limit"] = rateLimitState(info)
+}
+
+// rateLimitState describes the token bucket for callers
+func rateLimitState(info client.RateLimitInfo) map[string]interface{} {
+	return map[string]interface{}{
+		"available":        int(info.Available),
+		"burst":            info.Burst,
+		"limit_per_second": info.Limit,
+		"status":           getRateLimitStatus(info.Available, info.Burst),
 	}
 }
 
-// getRateLimitStatus returns a human-readable rate limit status
-func getRateLimitStatus(available float64, limit int) string {
-	ratio := available / float64(limit)
+// addRateLimitMetaIfLow attaches the client's rate limit state to the tool result's _meta once
+// the token bucket is below half full, so callers are told to slow down before requests queue.
+// It never goes into API results, which update tools send back to the API.
+func addRateLimitMetaIfLow(result *mcp.CallToolResult, apiClient client.Doer) {
+	reporter, ok := apiClient.(client.RateLimitReporter)
+	if result == nil || !ok {
+		return
+	}
+	info := reporter.GetRateLimitInfo()
+	if info.Enabled && getRateLimitStatus(info.Available, info.Burst) != "healthy" {
+		if result.Meta == nil {
+			result.Meta = mcp.Meta{}
+		}
+		result.Meta["rate_limit"] = rateLimitState(info)
+	}
+}
+
+// getRateLimitStatus returns a human-readable rate limit status from the tokens left in a
+// bucket of the given capacity
+func getRateLimitStatus(available float64, capacity int) string {
+	if capacity <= 0 {
+		return "critical"
+	}
+	ratio := available / float64(capacity)
 	switch {
 	case ratio < 0.1:
 		return "critical" // Less than 10% remaining
@@ -1163,7 +1368,7 @@ func (t *BaseTool) FormatCompactSummary(result map[string]interface{}, _ string)
 		}
 
 		// Time range
-		timeRange := extractTimeRange(events)
+		timeRange := extractTimeRange(events, timeDisplayFromResult(result))
 		if timeRange != "" {
 			fmt.Fprintf(&summary, "### Time Range\n%s\n\n", timeRange)
 		}
@@ -1185,7 +1390,7 @@ func (t *BaseTool) FormatCompactSummary(result map[string]interface{}, _ string)
 		// Message patterns (clustered view) for larger result sets
 		if len(events) >= 10 {
 			summary.WriteString("### Message Patterns\n")
-			summary.WriteString(FormatClusteredSummary(events, 5))
+			summary.WriteString(FormatClusteredSummaryWithSensitivity(events, 5, clusteringSensitivityFromResult(result)))
 			summary.WriteString("\n")
 		}
 	} else {
@@ -1198,11 +1403,12 @@ func (t *BaseTool) FormatCompactSummary(result map[string]interface{}, _ string)
 		if tier, ok := meta["tier"].(string); ok {
 			fmt.Fprintf(&summary, "- Tier: %s\n", tier)
 		}
+		display := timeDisplayFromResult(result)
 		if start, ok := meta["start_date"].(string); ok {
-			fmt.Fprintf(&summary, "- Start: %s\n", start)
+			fmt.Fprintf(&summary, "- Start: %s\n", display.FormatWithRaw(start))
 		}
 		if end, ok := meta["end_date"].(string); ok {
-			fmt.Fprintf(&summary, "- End: %s\n", end)
+			fmt.Fprintf(&summary, "- End: %s\n", display.FormatWithRaw(end))
 		}
 		summary.WriteString("\n")
 	}
@@ -1249,22 +1455,23 @@ func extractSampleMessages(events []interface{}, limit int) []string {
 	return messages
 }
 
-// ensureResponseLimit ensures the response text doesn't exceed FinalResponseLimit
+// ensureResponseLimit ensures the response text doesn't exceed the configured final response limit
 // This is a safety net to prevent MCP 1MB limit errors
 func ensureResponseLimit(text string, logger *zap.Logger) string {
-	if len(text) <= FinalResponseLimit {
+	limit := currentFinalResponseLimit()
+	if len(text) <= limit {
 		return text
 	}
 
 	if logger != nil {
 		logger.Warn("Response exceeded final limit, truncating",
 			zap.Int("original_size", len(text)),
-			zap.Int("limit", FinalResponseLimit),
+			zap.Int("limit", limit),
 		)
 	}
 
 	// Hard truncate and add warning
-	truncated := text[:FinalResponseLimit-TruncationBufferSize]
+	truncated := text[:limit-TruncationBufferSize]
 	truncated += "\n\n---\n⚠️ **Response truncated** due to size limits. Use filters or pagination to get complete results."
 	return truncated
 }
@@ -1293,8 +1500,10 @@ func formatLogsAsMarkdown(result map[string]interface{}, summary string) string
 		return sb.String()
 	}
 
+	display := timeDisplayFromResult(result)
+	fields := projectedFields(result)
 	for i, log := range logs {
-		formatSingleLogEntry(&sb, log, i+1)
+		formatSingleLogEntry(&sb, log, i+1, display, fields)
 	}
 
 	// Add query metadata if present
@@ -1303,6 +1512,21 @@ func formatLogsAsMarkdown(result map[string]interface{}, summary string) string
 		if tier, ok := meta["tier"].(string); ok {
 			fmt.Fprintf(&sb, "- **Tier:** %s\n", tier)
 		}
+		if tz, ok := meta["timezone"].(string); ok {
+			fmt.Fprintf(&sb, "- **Timezone:** %s\n", tz)
+		}
+		if redacted, ok := meta["pii_redacted"].(int); ok {
+			fmt.Fprintf(&sb, "- **PII redacted:** %d values\n", redacted)
+		}
+		if hit, _ := meta["cache_hit"].(bool); hit {
+			fmt.Fprintf(&sb, "- **Cached result:** yes (request %v; pass use_cache=false to rescan)\n", meta["request_hash"])
+		}
+		if missing, ok := meta["missing_fields"].([]string); ok {
+			fmt.Fprintf(&sb, "- **Requested fields not present:** %s\n", strings.Join(missing, ", "))
+		}
+		if collapsed, ok := meta["dedupe_collapsed"].(int); ok {
+			fmt.Fprintf(&sb, "- **Deduplicated (%v):** %d repeated entries collapsed\n", meta["dedupe"], collapsed)
+		}
 		if inst, ok := meta["instance"].(map[string]interface{}); ok {
 			if name, ok := inst["instance_name"].(string); ok && name != "" {
 				fmt.Fprintf(&sb, "- **Instance:** %s\n", name)
@@ -1336,6 +1560,7 @@ func formatRawLogsAsMarkdown(result map[string]interface{}, summary string) stri
 		sb.WriteString("No log entries found.\n")
 		return sb.String()
 	}
+	display := timeDisplayFromResult(result)
 
 	for i, event := range events {
 		eventMap, ok := event.(map[string]interface{})
@@ -1346,9 +1571,11 @@ func formatRawLogsAsMarkdown(result map[string]interface{}, summary string) stri
 		// Header line with index and key identifiers
 		fmt.Fprintf(&sb, "**[%d]** ", i+1)
 		if ts, ok := eventMap["timestamp"].(string); ok {
-			fmt.Fprintf(&sb, "`%s` ", ts)
+			writeDisplayTimestamp(&sb, ts, display)
 		}
-		if sev, ok := eventMap["severity"].(string); ok {
+		if sev, ok := eventSeverity(eventMap); ok {
+			fmt.Fprintf(&sb, "[%s] ", SeverityName(sev))
+		} else if sev, ok := eventMap["severity"].(string); ok {
 			fmt.Fprintf(&sb, "[%s] ", sev)
 		}
 		if app, ok := eventMap["applicationname"].(string); ok {
@@ -1375,6 +1602,15 @@ func formatRawLogsAsMarkdown(result map[string]interface{}, summary string) stri
 		if tier, ok := meta["tier"].(string); ok {
 			fmt.Fprintf(&sb, "- **Tier:** %s\n", tier)
 		}
+		if tz, ok := meta["timezone"].(string); ok {
+			fmt.Fprintf(&sb, "- **Timezone:** %s\n", tz)
+		}
+		if redacted, ok := meta["pii_redacted"].(int); ok {
+			fmt.Fprintf(&sb, "- **PII redacted:** %d values\n", redacted)
+		}
+		if hit, _ := meta["cache_hit"].(bool); hit {
+			fmt.Fprintf(&sb, "- **Cached result:** yes (request %v; pass use_cache=false to rescan)\n", meta["request_hash"])
+		}
 		if inst, ok := meta["instance"].(map[string]interface{}); ok {
 			if name, ok := inst["instance_name"].(string); ok && name != "" {
 				fmt.Fprintf(&sb, "- **Instance:** %s\n", name)
@@ -1405,6 +1641,7 @@ func formatRawLogsAsMarkdownTruncated(result map[string]interface{}, summary str
 		sb.WriteString("No log entries found.\n")
 		return sb.String()
 	}
+	display := timeDisplayFromResult(result)
 
 	totalEvents := len(events)
 	shownEvents := 0
@@ -1421,9 +1658,11 @@ func formatRawLogsAsMarkdownTruncated(result map[string]interface{}, summary str
 
 		fmt.Fprintf(&sb, "**[%d]** ", i+1)
 		if ts, ok := eventMap["timestamp"].(string); ok {
-			fmt.Fprintf(&sb, "`%s` ", ts)
+			writeDisplayTimestamp(&sb, ts, display)
 		}
-		if sev, ok := eventMap["severity"].(string); ok {
+		if sev, ok := eventSeverity(eventMap); ok {
+			fmt.Fprintf(&sb, "[%s] ", SeverityName(sev))
+		} else if sev, ok := eventMap["severity"].(string); ok {
 			fmt.Fprintf(&sb, "[%s] ", sev)
 		}
 		if app, ok := eventMap["applicationname"].(string); ok {
@@ -1474,13 +1713,15 @@ func formatLogsAsMarkdownTruncated(result map[string]interface{}, summary string
 
 	totalLogs := len(logs)
 	shownLogs := 0
+	display := timeDisplayFromResult(result)
+	fields := projectedFields(result)
 
 	for i, log := range logs {
 		// Check if we're approaching the limit
 		if sb.Len() > maxSize-1000 {
 			break
 		}
-		formatSingleLogEntry(&sb, log, i+1)
+		formatSingleLogEntry(&sb, log, i+1, display, fields)
 		shownLogs++
 	}
 
@@ -1494,7 +1735,7 @@ func formatLogsAsMarkdownTruncated(result map[string]interface{}, summary string
 }
 
 // formatSingleLogEntry formats a single log entry as markdown
-func formatSingleLogEntry(sb *strings.Builder, log interface{}, index int) {
+func formatSingleLogEntry(sb *strings.Builder, log interface{}, index int, display TimeDisplay, fields []string) {
 	logMap, ok := log.(map[string]interface{})
 	if !ok {
 		return
@@ -1502,9 +1743,13 @@ func formatSingleLogEntry(sb *strings.Builder, log interface{}, index int) {
 
 	fmt.Fprintf(sb, "**[%d]** ", index)
 
-	// Time
+	// Time, in the display timezone with the raw ISO value alongside when they differ,
+	// and how long before the query end (or now) the entry was logged
 	if t, ok := logMap["time"].(string); ok {
-		fmt.Fprintf(sb, "`%s` ", t)
+		writeDisplayTimestamp(sb, t, display)
+		if rel := display.Relative(t); rel != "" {
+			sb.WriteString(rel + " ")
+		}
 	}
 
 	// Severity
@@ -1544,6 +1789,37 @@ func formatSingleLogEntry(sb *strings.Builder, log interface{}, index int) {
 	if execMs, ok := logMap["exec_ms"].(float64); ok {
 		fmt.Fprintf(sb, "  - Execution time: %.0fms\n", execMs)
 	}
+	if count, ok := logMap["repeat_count"].(int); ok {
+		fmt.Fprintf(sb, "  - Repeated %d times", count)
+		first, _ := logMap["first_time"].(string)
+		last, _ := logMap["last_time"].(string)
+		if first != "" && last != "" {
+			sb.WriteString(" from ")
+			writeDisplayTimestamp(sb, first, display)
+			sb.WriteString("to ")
+			writeDisplayTimestamp(sb, last, display)
+		}
+		sb.WriteString("\n")
+	}
+	writeProjectedFields(sb, logMap, fields)
 
 	sb.WriteString("\n")
 }
+
+// writeDisplayTimestamp writes a log timestamp in the display timezone, followed by the raw
+// ISO value when the two differ
+func writeDisplayTimestamp(sb *strings.Builder, raw string, display TimeDisplay) {
+	if formatted := display.Format(raw); formatted != raw {
+		fmt.Fprintf(sb, "`%s` (%s) ", formatted, raw)
+		return
+	}
+	fmt.Fprintf(sb, "`%s` ", raw)
+}
+
+// normalizedSeverityValue returns the display name for a recognizable severity, or the raw value
+func normalizedSeverityValue(raw interface{}) interface{} {
+	if level, ok := NormalizeSeverity(raw); ok {
+		return SeverityName(level)
+	}
+	return raw
+}
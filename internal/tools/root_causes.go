@@ -0,0 +1,123 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements the heuristics that label log patterns with a likely root cause.
+package tools
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// RootCauseRule labels log messages matching Pattern with a likely root cause and,
+// optionally, a suggested remediation
+type RootCauseRule struct {
+	Pattern     *regexp.Regexp
+	Label       string
+	Remediation string
+}
+
+// builtinRootCauseRules are checked in order after any custom rules; the first matching
+// rule labels a pattern
+var builtinRootCauseRules = []RootCauseRule{
+	{regexp.MustCompile(`(?i)out of memory|\boom\b|oomkilled|heap space|memory limit`), "Memory exhaustion",
+		"Check for memory leaks or raise the container memory limit"},
+	{regexp.MustCompile(`(?i)no space left|disk (?:full|quota)|quota exceeded`), "Disk exhaustion",
+		"Free disk space or expand the volume, and check log rotation"},
+	{regexp.MustCompile(`(?i)deadlock|lock wait timeout`), "Database lock contention",
+		"Review long-running transactions and the order in which rows are locked"},
+	{regexp.MustCompile(`(?i)timed? ?out|deadline exceeded`), "Timeout calling a slow or unresponsive dependency",
+		"Check the latency and health of the called service, then review timeouts and retries"},
+	{regexp.MustCompile(`(?i)connection (?:refused|reset|closed)|broken pipe|econn|unreachable`), "Connection failure to a downstream service",
+		"Verify the downstream service is running and reachable from the caller"},
+	{regexp.MustCompile(`(?i)no such host|dns|name resolution`), "DNS resolution failure",
+		"Check the hostname and the cluster DNS configuration"},
+	{regexp.MustCompile(`(?i)certificate|x509|tls handshake|ssl`), "TLS or certificate problem",
+		"Check certificate expiry, the trust chain and hostname"},
+	{regexp.MustCompile(`(?i)unauthori[sz]ed|forbidden|permission denied|access denied|invalid token|\b40[13]\b`), "Authentication or authorization failure",
+		"Check credentials, token expiry and the caller's IAM permissions"},
+	{regexp.MustCompile(`(?i)rate limit|too many requests|throttl|\b429\b`), "Rate limiting",
+		"Reduce the request rate, add backoff or raise the quota"},
+	{regexp.MustCompile(`(?i)service unavailable|bad gateway|\b50[234]\b`), "Upstream service unavailable",
+		"Check the health and recent deployments of the upstream service"},
+	{regexp.MustCompile(`(?i)null pointer|nil pointer|nullpointerexception|undefined is not|cannot read propert`), "Null or missing data",
+		"Find the input that lacks the expected field and add validation"},
+	{regexp.MustCompile(`(?i)panic|segmentation fault|uncaught exception|stack ?trace`), "Application crash",
+		"Inspect the stack trace of a sample event and the most recent deployment"},
+}
+
+// customRootCauseRules are loaded from LOGS_ROOT_CAUSE_RULES_FILE and take precedence
+// over the built-in rules
+var (
+	customRootCauseRules   []RootCauseRule
+	customRootCauseRulesMu sync.RWMutex
+)
+
+// rootCauseRuleFile is one entry of a root-cause rules file
+type rootCauseRuleFile struct {
+	Pattern     string `json:"pattern"`
+	RootCause   string `json:"root_cause"`
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// LoadRootCauseRules reads a JSON array of {"pattern", "root_cause", "remediation"}
+// objects and installs them ahead of the built-in rules. It returns the number of rules
+// loaded; an empty path clears any custom rules.
+func LoadRootCauseRules(path string) (int, error) {
+	if path == "" {
+		SetRootCauseRules(nil)
+		return 0, nil
+	}
+	data, err := os.ReadFile(filepath.Clean(path)) // #nosec G304 -- operator-supplied config path
+	if err != nil {
+		return 0, fmt.Errorf("failed to read root cause rules: %w", err)
+	}
+	var entries []rootCauseRuleFile
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return 0, fmt.Errorf("invalid root cause rules file: %w", err)
+	}
+
+	rules := make([]RootCauseRule, 0, len(entries))
+	for i, e := range entries {
+		if strings.TrimSpace(e.Pattern) == "" || strings.TrimSpace(e.RootCause) == "" {
+			return 0, fmt.Errorf("root cause rule %d: pattern and root_cause are required", i+1)
+		}
+		re, err := regexp.Compile(e.Pattern)
+		if err != nil {
+			return 0, fmt.Errorf("root cause rule %d: invalid pattern %q: %w", i+1, e.Pattern, err)
+		}
+		rules = append(rules, RootCauseRule{Pattern: re, Label: e.RootCause, Remediation: e.Remediation})
+	}
+	if len(rules) == 0 {
+		return 0, errors.New("root cause rules file contains no rules")
+	}
+	SetRootCauseRules(rules)
+	return len(rules), nil
+}
+
+// SetRootCauseRules replaces the custom rules checked before the built-in set
+func SetRootCauseRules(rules []RootCauseRule) {
+	customRootCauseRulesMu.Lock()
+	defer customRootCauseRulesMu.Unlock()
+	customRootCauseRules = rules
+}
+
+// MatchRootCause returns the first rule matching message, custom rules first, or nil
+func MatchRootCause(message string) *RootCauseRule {
+	customRootCauseRulesMu.RLock()
+	custom := customRootCauseRules
+	customRootCauseRulesMu.RUnlock()
+
+	for _, rules := range [][]RootCauseRule{custom, builtinRootCauseRules} {
+		for i := range rules {
+			if rules[i].Pattern.MatchString(message) {
+				return &rules[i]
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,599 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// regexRuleTypes are the rule types whose parameters carry a regex in "rule"
+var regexRuleTypes = map[string]bool{
+	"parse":   true,
+	"extract": true,
+	"replace": true,
+	"allow":   true,
+	"block":   true,
+}
+
+// RuleSimulationStep reports what one rule did to the sample log
+type RuleSimulationStep struct {
+	Subgroup    int                    `json:"subgroup"`
+	Rule        string                 `json:"rule"`
+	Type        string                 `json:"type"`
+	SourceField string                 `json:"source_field,omitempty"`
+	Matched     bool                   `json:"matched"`
+	Detail      string                 `json:"detail,omitempty"`
+	Fields      map[string]interface{} `json:"fields,omitempty"`
+}
+
+// simulatedRule is a rule with its type, parameters and compiled regex resolved
+type simulatedRule struct {
+	name        string
+	ruleType    string
+	sourceField string
+	enabled     bool
+	params      map[string]interface{}
+	re          *regexp.Regexp
+}
+
+// simulatedSubgroup is an ordered list of rules; the first matching rule applies
+type simulatedSubgroup struct {
+	enabled bool
+	rules   []simulatedRule
+}
+
+// ruleGroupSimulator applies rule group rules to a single sample log
+type ruleGroupSimulator struct {
+	// doc holds the log body under "text"; json.<field> paths are aliases of text.<field>
+	doc      map[string]interface{}
+	metadata map[string]interface{}
+	// wrapped is set once a plain-text body became an object; "text" then still
+	// refers to the original message, kept under text.text
+	wrapped bool
+	blocked bool
+	kept    bool
+}
+
+// newRuleGroupSimulator wraps a sample log; JSON objects become structured bodies
+func newRuleGroupSimulator(sample string) *ruleGroupSimulator {
+	var body interface{} = sample
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(sample)), &parsed); err == nil && parsed != nil {
+		body = parsed
+	}
+	return &ruleGroupSimulator{
+		doc:      map[string]interface{}{"text": body},
+		metadata: make(map[string]interface{}),
+	}
+}
+
+// path splits a source/destination field into segments rooted at "text"
+func (s *ruleGroupSimulator) path(field string) []string {
+	parts := strings.Split(field, ".")
+	if parts[0] == "json" {
+		parts[0] = "text"
+	}
+	if s.wrapped && len(parts) == 1 && parts[0] == "text" {
+		return []string{"text", "text"}
+	}
+	return parts
+}
+
+// get returns the value at field, if present
+func (s *ruleGroupSimulator) get(field string) (interface{}, bool) {
+	var current interface{} = s.doc
+	for _, part := range s.path(field) {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[part]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// set writes value at field, creating intermediate objects. A plain-text body is
+// kept under text.text when a nested field has to be created inside it.
+func (s *ruleGroupSimulator) set(field string, value interface{}) {
+	parts := s.path(field)
+	current := s.doc
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := current[part].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			if existing, had := current[part]; had && part == "text" && len(current) == 1 {
+				next["text"] = existing
+				s.wrapped = true
+			}
+			current[part] = next
+		}
+		current = next
+	}
+	current[parts[len(parts)-1]] = value
+}
+
+// remove deletes field and reports whether it existed
+func (s *ruleGroupSimulator) remove(field string) bool {
+	parts := s.path(field)
+	current := s.doc
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := current[part].(map[string]interface{})
+		if !ok {
+			return false
+		}
+		current = next
+	}
+	if _, ok := current[parts[len(parts)-1]]; !ok {
+		return false
+	}
+	delete(current, parts[len(parts)-1])
+	return true
+}
+
+// namedCaptures returns the named groups of a regex match
+func namedCaptures(re *regexp.Regexp, match []string) map[string]interface{} {
+	fields := make(map[string]interface{})
+	for i, name := range re.SubexpNames() {
+		if name != "" && i < len(match) {
+			fields[name] = match[i]
+		}
+	}
+	return fields
+}
+
+// apply runs one rule against the current log and reports whether it matched
+func (s *ruleGroupSimulator) apply(r simulatedRule, step *RuleSimulationStep) {
+	value, exists := s.get(r.sourceField)
+	text, isText := value.(string)
+	destination, _ := r.params["destination_field"].(string)
+	if destination == "" {
+		destination = r.sourceField
+	}
+
+	if r.re != nil && !isText {
+		if exists {
+			step.Detail = "source field is not a string"
+		} else {
+			step.Detail = "source field not present in the sample"
+		}
+		if r.ruleType == "allow" {
+			s.block(r)
+			step.Detail += "; log blocked"
+		}
+		return
+	}
+
+	switch r.ruleType {
+	case "parse":
+		match := r.re.FindStringSubmatch(text)
+		if match == nil {
+			step.Detail = "regex did not match"
+			return
+		}
+		step.Matched = true
+		step.Fields = namedCaptures(r.re, match)
+		s.set(destination, step.Fields)
+		step.Detail = fmt.Sprintf("parsed %d field(s) into %s", len(step.Fields), destination)
+	case "extract":
+		match := r.re.FindStringSubmatch(text)
+		if match == nil {
+			step.Detail = "regex did not match"
+			return
+		}
+		step.Matched = true
+		step.Fields = namedCaptures(r.re, match)
+		for name, v := range step.Fields {
+			s.set("text."+name, v)
+		}
+		step.Detail = fmt.Sprintf("extracted %d field(s); original text kept", len(step.Fields))
+	case "replace":
+		if !r.re.MatchString(text) {
+			step.Detail = "regex did not match"
+			return
+		}
+		step.Matched = true
+		replacement, _ := r.params["replace_new_val"].(string)
+		s.set(destination, r.re.ReplaceAllString(text, replacement))
+		step.Detail = "replaced matching text in " + destination
+	case "allow":
+		if r.re.MatchString(text) {
+			step.Matched = true
+			step.Detail = "regex matched; log allowed"
+			return
+		}
+		s.block(r)
+		step.Detail = "regex did not match; log blocked"
+	case "block":
+		if !r.re.MatchString(text) {
+			step.Detail = "regex did not match; log not blocked"
+			return
+		}
+		step.Matched = true
+		s.block(r)
+		step.Detail = "regex matched; log blocked"
+	case "json_extract":
+		if !exists {
+			step.Detail = "source field not present in the sample"
+			return
+		}
+		step.Matched = true
+		s.metadata[destination] = value
+		step.Detail = fmt.Sprintf("set metadata %s", destination)
+	case "json_stringify":
+		if !exists {
+			step.Detail = "source field not present in the sample"
+			return
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			step.Detail = fmt.Sprintf("could not stringify source field: %v", err)
+			return
+		}
+		step.Matched = true
+		if deleteSource, _ := r.params["delete_source"].(bool); deleteSource {
+			s.remove(r.sourceField)
+		}
+		s.set(destination, string(encoded))
+		step.Detail = "stringified into " + destination
+	case "json_parse":
+		if !isText {
+			step.Detail = "source field is not a JSON string"
+			return
+		}
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(text), &parsed); err != nil {
+			step.Detail = fmt.Sprintf("source field is not valid JSON: %v", err)
+			return
+		}
+		step.Matched = true
+		if deleteSource, _ := r.params["delete_source"].(bool); deleteSource {
+			s.remove(r.sourceField)
+		}
+		s.set(destination, parsed)
+		step.Detail = "parsed JSON into " + destination
+	case "remove_fields":
+		fields, _ := r.params["fields"].([]interface{})
+		var removed []string
+		for _, f := range fields {
+			if name, ok := f.(string); ok && s.remove(name) {
+				removed = append(removed, name)
+			}
+		}
+		step.Matched = len(removed) > 0
+		step.Detail = "no listed field present in the sample"
+		if step.Matched {
+			step.Detail = "removed " + strings.Join(removed, ", ")
+		}
+	case "extract_timestamp":
+		step.Matched = exists
+		step.Detail = "timestamp extraction is not simulated locally; the log timestamp is unchanged"
+	}
+}
+
+// block marks the log as blocked by r
+func (s *ruleGroupSimulator) block(r simulatedRule) {
+	s.blocked = true
+	s.kept, _ = r.params["keep_blocked_logs"].(bool)
+}
+
+// ruleOrder reads the numeric order of a rule or subgroup
+func ruleOrder(m map[string]interface{}) float64 {
+	order, _ := m["order"].(float64)
+	return order
+}
+
+// ruleEnabled reads the enabled flag, which defaults to true
+func ruleEnabled(m map[string]interface{}) bool {
+	enabled, ok := m["enabled"].(bool)
+	return !ok || enabled
+}
+
+// compileRuleGroup orders subgroups and rules and compiles their regexes.
+// All invalid regexes and unsupported rule types are reported together.
+func compileRuleGroup(rg map[string]interface{}) ([]simulatedSubgroup, error) {
+	rawSubgroups, _ := rg["rule_subgroups"].([]interface{})
+	if len(rawSubgroups) == 0 {
+		return nil, fmt.Errorf("rule group has no rule_subgroups")
+	}
+	subgroupMaps := make([]map[string]interface{}, 0, len(rawSubgroups))
+	for _, sg := range rawSubgroups {
+		if m, ok := sg.(map[string]interface{}); ok {
+			subgroupMaps = append(subgroupMaps, m)
+		}
+	}
+	sort.SliceStable(subgroupMaps, func(i, j int) bool { return ruleOrder(subgroupMaps[i]) < ruleOrder(subgroupMaps[j]) })
+
+	var problems []string
+	subgroups := make([]simulatedSubgroup, 0, len(subgroupMaps))
+	for i, sg := range subgroupMaps {
+		rawRules, _ := sg["rules"].([]interface{})
+		ruleMaps := make([]map[string]interface{}, 0, len(rawRules))
+		for _, r := range rawRules {
+			if m, ok := r.(map[string]interface{}); ok {
+				ruleMaps = append(ruleMaps, m)
+			}
+		}
+		sort.SliceStable(ruleMaps, func(a, b int) bool { return ruleOrder(ruleMaps[a]) < ruleOrder(ruleMaps[b]) })
+
+		subgroup := simulatedSubgroup{enabled: ruleEnabled(sg)}
+		for j, r := range ruleMaps {
+			rule := simulatedRule{enabled: ruleEnabled(r)}
+			rule.name, _ = r["name"].(string)
+			if rule.name == "" {
+				rule.name = fmt.Sprintf("rule %d", j+1)
+			}
+			rule.sourceField, _ = r["source_field"].(string)
+			if rule.sourceField == "" {
+				rule.sourceField = "text"
+			}
+			params, _ := r["parameters"].(map[string]interface{})
+			for key, value := range params {
+				if ruleType, ok := strings.CutSuffix(key, "_parameters"); ok {
+					rule.ruleType = ruleType
+					rule.params, _ = value.(map[string]interface{})
+				}
+			}
+			switch {
+			case rule.ruleType == "":
+				problems = append(problems, fmt.Sprintf("subgroup %d, rule '%s': parameters must contain one of the *_parameters objects", i+1, rule.name))
+				continue
+			case regexRuleTypes[rule.ruleType]:
+				pattern, _ := rule.params["rule"].(string)
+				if pattern == "" {
+					problems = append(problems, fmt.Sprintf("subgroup %d, rule '%s': %s_parameters.rule (regex) is required", i+1, rule.name, rule.ruleType))
+					continue
+				}
+				re, err := regexp.Compile(pattern)
+				if err != nil {
+					problems = append(problems, fmt.Sprintf("subgroup %d, rule '%s': invalid regex: %v", i+1, rule.name, err))
+					continue
+				}
+				rule.re = re
+			case rule.ruleType != "json_extract" && rule.ruleType != "json_stringify" && rule.ruleType != "json_parse" &&
+				rule.ruleType != "remove_fields" && rule.ruleType != "extract_timestamp":
+				problems = append(problems, fmt.Sprintf("subgroup %d, rule '%s': unsupported rule type '%s_parameters'", i+1, rule.name, rule.ruleType))
+				continue
+			}
+			if rule.params == nil {
+				rule.params = map[string]interface{}{}
+			}
+			subgroup.rules = append(subgroup.rules, rule)
+		}
+		subgroups = append(subgroups, subgroup)
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("%s", strings.Join(problems, "\n"))
+	}
+	return subgroups, nil
+}
+
+// ruleMatchersApply checks the rule group's matchers against the given labels.
+// Matchers of the same kind are ORed and different kinds are ANDed; kinds without
+// a given label are not checked.
+func ruleMatchersApply(rg map[string]interface{}, labels map[string]string) bool {
+	allowed := make(map[string][]string)
+	matchers, _ := rg["rule_matchers"].([]interface{})
+	for _, m := range matchers {
+		matcher, ok := m.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for kind, v := range matcher {
+			if value, ok := v.(map[string]interface{})["value"].(string); ok {
+				allowed[kind] = append(allowed[kind], value)
+			}
+		}
+	}
+	for kind, values := range allowed {
+		label := labels[kind]
+		if label == "" {
+			continue
+		}
+		found := false
+		for _, v := range values {
+			if strings.EqualFold(v, label) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// SimulateRuleGroup applies a rule group to a sample log. Within a subgroup the first
+// matching rule applies; subgroups run in order until the log is blocked.
+func SimulateRuleGroup(rg map[string]interface{}, sample string) (map[string]interface{}, error) {
+	subgroups, err := compileRuleGroup(rg)
+	if err != nil {
+		return nil, err
+	}
+
+	sim := newRuleGroupSimulator(sample)
+	steps := make([]RuleSimulationStep, 0)
+	for i, sg := range subgroups {
+		if !sg.enabled {
+			continue
+		}
+		for _, r := range sg.rules {
+			if !r.enabled {
+				continue
+			}
+			step := RuleSimulationStep{Subgroup: i + 1, Rule: r.name, Type: r.ruleType, SourceField: r.sourceField}
+			sim.apply(r, &step)
+			steps = append(steps, step)
+			if step.Matched || sim.blocked {
+				break
+			}
+		}
+		if sim.blocked {
+			break
+		}
+	}
+
+	result := map[string]interface{}{
+		"steps":   steps,
+		"blocked": sim.blocked,
+		"output":  sim.doc["text"],
+	}
+	if sim.blocked && sim.kept {
+		result["kept_blocked_log"] = true
+	}
+	if len(sim.metadata) > 0 {
+		result["metadata"] = sim.metadata
+	}
+	return result, nil
+}
+
+// TestRuleGroupTool simulates a rule group against a sample log line
+type TestRuleGroupTool struct{ *BaseTool }
+
+// NewTestRuleGroupTool creates a new tool instance
+func NewTestRuleGroupTool(c client.Doer, l *zap.Logger) *TestRuleGroupTool {
+	return &TestRuleGroupTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *TestRuleGroupTool) Name() string { return "test_rule_group" }
+
+// Annotations returns tool hints for LLMs
+func (t *TestRuleGroupTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Test Rule Group")
+}
+
+// Description returns the tool description
+func (t *TestRuleGroupTool) Description() string {
+	return `Test a rule group against a sample log line before creating or updating it.
+
+Applies the rule group's parsing rules locally and returns the transformed log, which rule in each
+subgroup matched, the fields it produced, and whether the log would be blocked. Pass either a
+rule_group config (same structure as create_rule_group) or the id of an existing rule group.
+Nothing is sent to or changed in IBM Cloud Logs except the lookup of an existing rule group.
+
+A sample that is a JSON object is treated as a structured log (text.<field> / json.<field>);
+anything else is plain text in the 'text' field. Regexes use RE2 syntax with (?P<name>...) groups.
+Timestamp extraction is not simulated.
+
+**Related tools:** create_rule_group, update_rule_group, get_rule_group, test_rule_pattern`
+}
+
+// InputSchema returns the input schema
+func (t *TestRuleGroupTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"rule_group": map[string]interface{}{
+				"type":        "object",
+				"description": "Rule group configuration to test (same structure as create_rule_group). Use this or id.",
+			},
+			"id": map[string]interface{}{
+				"type":        "string",
+				"description": "ID of an existing rule group to test. Use this or rule_group.",
+			},
+			"sample": map[string]interface{}{
+				"type":        "string",
+				"description": "Sample log line: plain text or a JSON object",
+			},
+			"application_name": map[string]interface{}{
+				"type":        "string",
+				"description": "Application of the sample log, checked against the rule group's rule_matchers (optional)",
+			},
+			"subsystem_name": map[string]interface{}{
+				"type":        "string",
+				"description": "Subsystem of the sample log, checked against the rule group's rule_matchers (optional)",
+			},
+		},
+		"required": []string{"sample"},
+		"examples": []interface{}{
+			map[string]interface{}{
+				"sample": `{"log": "2026/02/16 09:38:09 [error] 278689#278689: connect() failed"}`,
+				"rule_group": map[string]interface{}{
+					"name": "Nginx Error Log Parser",
+					"rule_subgroups": []interface{}{
+						map[string]interface{}{
+							"order": 1,
+							"rules": []interface{}{
+								map[string]interface{}{
+									"name":         "Parse nginx error",
+									"source_field": "text.log",
+									"enabled":      true,
+									"order":        1,
+									"parameters": map[string]interface{}{
+										"parse_parameters": map[string]interface{}{
+											"destination_field": "text.log",
+											"rule":              `(?P<timestamp>\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2}) \[(?P<level>\w+)\] (?P<message>.*)`,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			map[string]interface{}{
+				"id":     "rule-group-uuid",
+				"sample": "user=alice action=login status=failed",
+			},
+		},
+	}
+}
+
+// Execute executes the tool
+func (t *TestRuleGroupTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	sample, err := GetStringParam(args, "sample", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	rg, err := GetObjectParam(args, "rule_group", false)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	id, _ := GetStringParam(args, "id", false)
+	switch {
+	case rg != nil && id != "":
+		return NewToolResultError("Provide either rule_group or id, not both"), nil
+	case rg == nil && id == "":
+		return NewToolResultError("Provide a rule_group config or the id of an existing rule group"), nil
+	case id != "":
+		rg, err = t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/rule_groups/" + id})
+		if err != nil {
+			return HandleGetError(err, "Rule group", id, "list_rule_groups"), nil
+		}
+	}
+
+	result, err := SimulateRuleGroup(rg, sample)
+	if err != nil {
+		return NewToolResultErrorWithSuggestion(fmt.Sprintf("Cannot test rule group:\n%v", err),
+			"Fix the listed rules; regexes use RE2 syntax (no lookarounds or backreferences) with (?P<name>...) named groups."), nil
+	}
+
+	if name, ok := rg["name"].(string); ok && name != "" {
+		result["rule_group"] = name
+	}
+	if enabled, ok := rg["enabled"].(bool); ok && !enabled {
+		result["warning"] = "This rule group is disabled; it does not process logs until enabled."
+	}
+	appName, _ := GetStringParam(args, "application_name", false)
+	subsystem, _ := GetStringParam(args, "subsystem_name", false)
+	if appName != "" || subsystem != "" {
+		applies := ruleMatchersApply(rg, map[string]string{"application_name": appName, "subsystem_name": subsystem})
+		result["rule_matchers_apply"] = applies
+		if !applies {
+			result["warning"] = "The rule group's rule_matchers exclude this application/subsystem, so these rules would not run on this log."
+		}
+	}
+	result["note"] = "Local simulation of the rule group; server-side processing may differ in edge cases."
+	return t.FormatResponse(result)
+}
@@ -0,0 +1,162 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// testRule builds a rule group rule for simulation tests
+func testRule(name, sourceField, ruleType string, order int, params map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"name":         name,
+		"source_field": sourceField,
+		"enabled":      true,
+		"order":        float64(order),
+		"parameters":   map[string]interface{}{ruleType + "_parameters": params},
+	}
+}
+
+// testRuleGroup builds a rule group with one subgroup per rule list
+func testRuleGroup(subgroups ...[]interface{}) map[string]interface{} {
+	sgs := make([]interface{}, 0, len(subgroups))
+	for i, rules := range subgroups {
+		sgs = append(sgs, map[string]interface{}{"enabled": true, "order": float64(i + 1), "rules": rules})
+	}
+	return map[string]interface{}{"name": "test group", "rule_subgroups": sgs}
+}
+
+func TestSimulateRuleGroup_ParseJSONField(t *testing.T) {
+	rg := testRuleGroup([]interface{}{
+		testRule("parse nginx", "text.log", "parse", 1, map[string]interface{}{
+			"destination_field": "text.log",
+			"rule":              `\[(?P<level>\w+)\] (?P<message>.*)`,
+		}),
+	})
+
+	result, err := SimulateRuleGroup(rg, `{"log": "[error] connect() failed", "pod": "web-1"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := result["output"].(map[string]interface{})
+	parsed, ok := output["log"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("text.log should be replaced by parsed fields, got %v", output)
+	}
+	if parsed["level"] != "error" || parsed["message"] != "connect() failed" {
+		t.Errorf("unexpected parsed fields: %v", parsed)
+	}
+	if output["pod"] != "web-1" {
+		t.Errorf("other fields should be kept, got %v", output)
+	}
+	steps := result["steps"].([]RuleSimulationStep)
+	if len(steps) != 1 || !steps[0].Matched || steps[0].Type != "parse" {
+		t.Errorf("unexpected steps: %+v", steps)
+	}
+}
+
+func TestSimulateRuleGroup_FirstMatchInSubgroup(t *testing.T) {
+	rg := testRuleGroup(
+		[]interface{}{
+			testRule("second", "text", "extract", 2, map[string]interface{}{"rule": `user=(?P<user>\w+)`}),
+			testRule("first", "text", "extract", 1, map[string]interface{}{"rule": `nomatch=(?P<x>\w+)`}),
+		},
+		[]interface{}{
+			testRule("mask", "text", "replace", 1, map[string]interface{}{"rule": `password=\S+`, "replace_new_val": "password=***"}),
+		},
+	)
+
+	result, err := SimulateRuleGroup(rg, "user=alice password=secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	steps := result["steps"].([]RuleSimulationStep)
+	if len(steps) != 3 {
+		t.Fatalf("expected 3 steps, got %+v", steps)
+	}
+	if steps[0].Rule != "first" || steps[0].Matched || steps[1].Rule != "second" || !steps[1].Matched {
+		t.Errorf("rules should run by order until one matches: %+v", steps)
+	}
+	output := result["output"].(map[string]interface{})
+	if output["user"] != "alice" || output["text"] != "user=alice password=***" {
+		t.Errorf("unexpected output: %v", output)
+	}
+}
+
+func TestSimulateRuleGroup_Block(t *testing.T) {
+	rg := testRuleGroup(
+		[]interface{}{testRule("drop health checks", "text", "block", 1, map[string]interface{}{"rule": `GET /health`})},
+		[]interface{}{testRule("never runs", "text", "extract", 1, map[string]interface{}{"rule": `(?P<path>/\w+)`})},
+	)
+
+	result, err := SimulateRuleGroup(rg, "GET /health 200")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result["blocked"] != true {
+		t.Errorf("log should be blocked: %v", result)
+	}
+	if steps := result["steps"].([]RuleSimulationStep); len(steps) != 1 {
+		t.Errorf("processing should stop after the log is blocked: %+v", steps)
+	}
+}
+
+func TestSimulateRuleGroup_InvalidRegex(t *testing.T) {
+	rg := testRuleGroup([]interface{}{
+		testRule("bad", "text", "parse", 1, map[string]interface{}{"rule": `(?P<level>\w+`}),
+		testRule("lookahead", "text", "allow", 2, map[string]interface{}{"rule": `foo(?=bar)`}),
+	})
+
+	_, err := SimulateRuleGroup(rg, "anything")
+	if err == nil {
+		t.Fatal("expected an error for invalid regexes")
+	}
+	if !strings.Contains(err.Error(), "rule 'bad': invalid regex") || !strings.Contains(err.Error(), "rule 'lookahead': invalid regex") {
+		t.Errorf("every invalid regex should be reported by rule name, got %v", err)
+	}
+}
+
+func TestTestRuleGroupTool_ByID(t *testing.T) {
+	rg := testRuleGroup([]interface{}{
+		testRule("parse", "text", "parse", 1, map[string]interface{}{"destination_field": "text", "rule": `user=(?P<user>\w+)`}),
+	})
+	rg["rule_matchers"] = []interface{}{
+		map[string]interface{}{"application_name": map[string]interface{}{"value": "auth"}},
+	}
+
+	mock := client.NewMockClient()
+	mock.RespondWith(200, rg)
+	result, err := NewTestRuleGroupTool(mock, zap.NewNop()).Execute(testCtx(mock), map[string]interface{}{
+		"id":               "rg-1",
+		"sample":           "user=alice",
+		"application_name": "billing",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error: %+v", result.Content)
+	}
+	if req := mock.LastRequest(); req.Method != "GET" || req.Path != "/v1/rule_groups/rg-1" {
+		t.Errorf("unexpected request %s %s", req.Method, req.Path)
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(text, `"user": "alice"`) || !strings.Contains(text, `"rule_matchers_apply": false`) {
+		t.Errorf("unexpected result: %s", text)
+	}
+}
+
+func TestTestRuleGroupTool_RequiresConfigOrID(t *testing.T) {
+	mock := client.NewMockClient()
+	result, err := NewTestRuleGroupTool(mock, zap.NewNop()).Execute(testCtx(mock), map[string]interface{}{"sample": "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.IsError || mock.RequestCount() != 0 {
+		t.Errorf("expected an error without a request, got %+v", result.Content)
+	}
+}
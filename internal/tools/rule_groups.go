@@ -43,7 +43,7 @@ func (t *GetRuleGroupTool) InputSchema() interface{} {
 func (t *GetRuleGroupTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	id, err := GetStringParam(args, "id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/rule_groups/" + id})
 	if err != nil {
@@ -72,19 +72,20 @@ func (t *ListRuleGroupsTool) Description() string {
 
 // InputSchema returns the input schema
 func (t *ListRuleGroupsTool) InputSchema() interface{} {
-	return map[string]interface{}{
-		"type":       "object",
-		"properties": map[string]interface{}{},
-	}
+	return map[string]interface{}{"type": "object", "properties": withSummaryOnly(OffsetPaginationSchema())}
 }
 
 // Execute executes the tool
-func (t *ListRuleGroupsTool) Execute(ctx context.Context, _ map[string]interface{}) (*mcp.CallToolResult, error) {
+func (t *ListRuleGroupsTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/rule_groups"})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
+	}
+	paged, err := ApplyOffsetPagination(res, args, "rulegroups", "rule_groups")
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
 	}
-	return t.FormatResponseWithSuggestions(res, "list_rule_groups")
+	return t.FormatListResponse(paged, args, "list_rule_groups")
 }
 
 // CreateRuleGroupTool creates a new rule group.
@@ -383,7 +384,7 @@ func validateSourceFields(rg map[string]interface{}) error {
 func (t *CreateRuleGroupTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	rg, err := GetObjectParam(args, "rule_group", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	// Validate source fields before sending to API
@@ -393,7 +394,7 @@ func (t *CreateRuleGroupTool) Execute(ctx context.Context, args map[string]inter
 
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "POST", Path: "/v1/rule_groups", Body: rg})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	return t.FormatResponseWithSuggestions(res, "create_rule_group")
 }
@@ -429,6 +430,36 @@ func (t *UpdateRuleGroupTool) InputSchema() interface{} {
 			},
 		},
 		"required": []string{"id", "rule_group"},
+		"examples": []interface{}{
+			map[string]interface{}{
+				"id": "rule-group-uuid",
+				"rule_group": map[string]interface{}{
+					"name":    "Extract JSON from text.log",
+					"enabled": true,
+					"order":   1,
+					"rule_subgroups": []interface{}{
+						map[string]interface{}{
+							"enabled": true,
+							"order":   1,
+							"rules": []interface{}{
+								map[string]interface{}{
+									"name":         "Parse level and message",
+									"source_field": "text.log",
+									"enabled":      true,
+									"order":        1,
+									"parameters": map[string]interface{}{
+										"parse_parameters": map[string]interface{}{
+											"destination_field": "text.log",
+											"rule":              `(?P<level>\w+): (?P<message>.*)`,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -436,11 +467,11 @@ func (t *UpdateRuleGroupTool) InputSchema() interface{} {
 func (t *UpdateRuleGroupTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	id, err := GetStringParam(args, "id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	rg, err := GetObjectParam(args, "rule_group", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	// Validate source fields before sending to API
@@ -450,7 +481,7 @@ func (t *UpdateRuleGroupTool) Execute(ctx context.Context, args map[string]inter
 
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "PUT", Path: "/v1/rule_groups/" + id, Body: rg})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	return t.FormatResponseWithSuggestions(res, "update_rule_group")
 }
@@ -494,11 +525,11 @@ func (t *DeleteRuleGroupTool) InputSchema() interface{} {
 func (t *DeleteRuleGroupTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	id, err := GetStringParam(args, "id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "DELETE", Path: "/v1/rule_groups/" + id})
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 	return t.FormatResponseWithSuggestions(res, "delete_rule_group")
 }
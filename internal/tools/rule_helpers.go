@@ -21,6 +21,11 @@ func NewDiscoverLogFieldsTool(c client.Doer, l *zap.Logger) *DiscoverLogFieldsTo
 // Name returns the tool name
 func (t *DiscoverLogFieldsTool) Name() string { return "discover_log_fields" }
 
+// Annotations returns tool hints for LLMs
+func (t *DiscoverLogFieldsTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Discover Log Fields")
+}
+
 // Description returns the tool description
 func (t *DiscoverLogFieldsTool) Description() string {
 	return `Discover available fields in your logs by analyzing recent log entries.
@@ -118,7 +123,7 @@ func (t *DiscoverLogFieldsTool) Execute(ctx context.Context, args map[string]int
 
 	res, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
-		return NewToolResultError(fmt.Sprintf("Failed to query logs: %v", err)), nil
+		return NewToolResultErrorWithCode(ClassifyError(err), fmt.Sprintf("Failed to query logs: %v", err)), nil
 	}
 
 	// Parse response to extract field structure
@@ -270,19 +275,24 @@ func formatFieldDiscovery(fields map[string]bool, appName, subsysName, timeRange
 	return result.String()
 }
 
-// TestRuleGroupTool tests a parsing rule against sample data
-type TestRuleGroupTool struct{ *BaseTool }
+// TestRulePatternTool checks a regex pattern against sample data
+type TestRulePatternTool struct{ *BaseTool }
 
-// NewTestRuleGroupTool creates a new tool instance
-func NewTestRuleGroupTool(c client.Doer, l *zap.Logger) *TestRuleGroupTool {
-	return &TestRuleGroupTool{NewBaseTool(c, l)}
+// NewTestRulePatternTool creates a new tool instance
+func NewTestRulePatternTool(c client.Doer, l *zap.Logger) *TestRulePatternTool {
+	return &TestRulePatternTool{NewBaseTool(c, l)}
 }
 
 // Name returns the tool name
-func (t *TestRuleGroupTool) Name() string { return "test_rule_pattern" }
+func (t *TestRulePatternTool) Name() string { return "test_rule_pattern" }
+
+// Annotations returns tool hints for LLMs
+func (t *TestRulePatternTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Test Rule Pattern")
+}
 
 // Description returns the tool description
-func (t *TestRuleGroupTool) Description() string {
+func (t *TestRulePatternTool) Description() string {
 	return `Test a regex pattern against sample log data before creating a rule group.
 
 This tool helps you:
@@ -291,11 +301,11 @@ This tool helps you:
 - Test with your actual log data
 - Avoid creating broken parsing rules
 
-**Related tools:** create_rule_group, discover_log_fields`
+**Related tools:** test_rule_group, create_rule_group, discover_log_fields`
 }
 
 // InputSchema returns the input schema
-func (t *TestRuleGroupTool) InputSchema() interface{} {
+func (t *TestRulePatternTool) InputSchema() interface{} {
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
@@ -319,19 +329,19 @@ func (t *TestRuleGroupTool) InputSchema() interface{} {
 }
 
 // Execute executes the tool
-func (t *TestRuleGroupTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+func (t *TestRulePatternTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 
 	sampleText, err := GetStringParam(args, "sample_text", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	pattern, err := GetStringParam(args, "regex_pattern", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	// Test the pattern (simplified - in production would use actual regex engine)
@@ -0,0 +1,335 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// savedQueryNamePattern restricts saved query names to short identifiers
+var savedQueryNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,63}$`)
+
+// savedQueryNameSchema is the schema of the name argument shared by the saved query tools
+var savedQueryNameSchema = map[string]interface{}{
+	"type":        "string",
+	"description": "Saved query name (letters, digits, '_', '-', '.'; max 64 characters)",
+	"pattern":     savedQueryNamePattern.String(),
+}
+
+// getSavedQueryName reads and validates the name argument
+func getSavedQueryName(args map[string]interface{}) (string, error) {
+	name, err := GetStringParam(args, "name", true)
+	if err != nil {
+		return "", err
+	}
+	if !savedQueryNamePattern.MatchString(name) {
+		return "", fmt.Errorf("invalid saved query name %q: use letters, digits, '_', '-' or '.' (max 64 characters)", name)
+	}
+	return name, nil
+}
+
+// savedQueryNotFound reports a missing saved query
+func savedQueryNotFound(name string) *mcp.CallToolResult {
+	return NewToolResultErrorWithSuggestion(fmt.Sprintf("No saved query named '%s'.", name),
+		"Use list_saved_queries to see saved query names.")
+}
+
+// SaveQueryTool stores a named query in the user's local query library
+type SaveQueryTool struct{ *BaseTool }
+
+// NewSaveQueryTool creates a new tool instance
+func NewSaveQueryTool(c client.Doer, l *zap.Logger) *SaveQueryTool {
+	return &SaveQueryTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *SaveQueryTool) Name() string { return "save_query" }
+
+// Annotations returns tool hints for LLMs
+func (t *SaveQueryTool) Annotations() *mcp.ToolAnnotations {
+	return UpdateAnnotations("Save Query")
+}
+
+// Description returns the tool description
+func (t *SaveQueryTool) Description() string {
+	return `Save a query under a name in your personal query library.
+
+Saved queries are stored by this MCP server with your session (persisted across restarts when
+session persistence is enabled), not in IBM Cloud Logs, so they are separate from views.
+Run one with query_logs saved_query=<name>. Saving an existing name replaces it.
+
+**Related tools:** list_saved_queries, get_saved_query, delete_saved_query, query_logs`
+}
+
+// InputSchema returns the input schema
+func (t *SaveQueryTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name": savedQueryNameSchema,
+			"query": map[string]interface{}{
+				"type":        "string",
+				"description": "Query text (DataPrime or Lucene). Max 4096 characters.",
+				"minLength":   1,
+				"maxLength":   4096,
+			},
+			"syntax": map[string]interface{}{
+				"type":        "string",
+				"description": "Query syntax used when the saved query runs (default: dataprime)",
+				"enum":        []string{"dataprime", "lucene"},
+			},
+			"tier": map[string]interface{}{
+				"type":        "string",
+				"description": "Tier used when the saved query runs, unless query_logs sets one (default: query_logs default)",
+				"enum":        []string{"archive", "frequent_search"},
+			},
+			"description": map[string]interface{}{
+				"type":        "string",
+				"description": "What the query finds (optional)",
+			},
+		},
+		"required": []string{"name", "query"},
+	}
+}
+
+// Execute executes the tool
+func (t *SaveQueryTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	name, err := getSavedQueryName(args)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	query, err := GetStringParam(args, "query", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	if len(query) > 4096 {
+		return NewToolResultError(fmt.Sprintf("Query too long: %d characters (max 4096)", len(query))), nil
+	}
+	syntax, _ := GetStringParam(args, "syntax", false)
+	if syntax != "" && syntax != "dataprime" && syntax != "lucene" {
+		return NewToolResultError(fmt.Sprintf("invalid syntax '%s' (valid: dataprime, lucene)", syntax)), nil
+	}
+	tier, _ := GetStringParam(args, "tier", false)
+	if tier != "" {
+		tier = normalizeTier(tier)
+	}
+	description, _ := GetStringParam(args, "description", false)
+
+	replaced, err := GetSessionFromContext(ctx).SaveQuery(SavedQuery{
+		Name:        name,
+		Query:       query,
+		Syntax:      syntax,
+		Tier:        tier,
+		Description: description,
+	})
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+
+	verb := "Saved"
+	if replaced {
+		verb = "Updated"
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf(
+			"%s query '%s'. Run it with query_logs saved_query=\"%s\" plus start_date and end_date.", verb, name, name)}},
+	}, nil
+}
+
+// ListSavedQueriesTool lists the user's saved queries
+type ListSavedQueriesTool struct{ *BaseTool }
+
+// NewListSavedQueriesTool creates a new tool instance
+func NewListSavedQueriesTool(c client.Doer, l *zap.Logger) *ListSavedQueriesTool {
+	return &ListSavedQueriesTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *ListSavedQueriesTool) Name() string { return "list_saved_queries" }
+
+// Annotations returns tool hints for LLMs
+func (t *ListSavedQueriesTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("List Saved Queries")
+}
+
+// Description returns the tool description
+func (t *ListSavedQueriesTool) Description() string {
+	return `List the queries saved with save_query, sorted by name.
+
+**Related tools:** save_query, get_saved_query, query_logs`
+}
+
+// InputSchema returns the input schema
+func (t *ListSavedQueriesTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{},
+	}
+}
+
+// Execute executes the tool
+func (t *ListSavedQueriesTool) Execute(ctx context.Context, _ map[string]interface{}) (*mcp.CallToolResult, error) {
+	queries := GetSessionFromContext(ctx).ListSavedQueries()
+	if len(queries) == 0 {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: "No saved queries.\n\nUse `save_query` to add one."}},
+		}, nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## Saved Queries (%d)\n\n", len(queries))
+	sb.WriteString("| Name | Description | Query |\n")
+	sb.WriteString("|------|-------------|-------|\n")
+	for _, q := range queries {
+		query := q.Query
+		if len(query) > 60 {
+			query = query[:57] + "..."
+		}
+		fmt.Fprintf(&sb, "| %s | %s | `%s` |\n", q.Name, strings.ReplaceAll(q.Description, "|", "\\|"), strings.ReplaceAll(query, "|", "\\|"))
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}},
+	}, nil
+}
+
+// GetSavedQueryTool returns one saved query
+type GetSavedQueryTool struct{ *BaseTool }
+
+// NewGetSavedQueryTool creates a new tool instance
+func NewGetSavedQueryTool(c client.Doer, l *zap.Logger) *GetSavedQueryTool {
+	return &GetSavedQueryTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *GetSavedQueryTool) Name() string { return "get_saved_query" }
+
+// Annotations returns tool hints for LLMs
+func (t *GetSavedQueryTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Get Saved Query")
+}
+
+// Description returns the tool description
+func (t *GetSavedQueryTool) Description() string {
+	return `Get the full text and settings of a query saved with save_query.
+
+**Related tools:** list_saved_queries, save_query, query_logs`
+}
+
+// InputSchema returns the input schema
+func (t *GetSavedQueryTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name": savedQueryNameSchema,
+		},
+		"required": []string{"name"},
+	}
+}
+
+// Execute executes the tool
+func (t *GetSavedQueryTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	name, err := getSavedQueryName(args)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	q, ok := GetSessionFromContext(ctx).GetSavedQuery(name)
+	if !ok {
+		return savedQueryNotFound(name), nil
+	}
+	result := map[string]interface{}{
+		"name":       q.Name,
+		"query":      q.Query,
+		"created_at": q.CreatedAt.UTC().Format(time.RFC3339),
+		"updated_at": q.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+	if q.Syntax != "" {
+		result["syntax"] = q.Syntax
+	}
+	if q.Tier != "" {
+		result["tier"] = q.Tier
+	}
+	if q.Description != "" {
+		result["description"] = q.Description
+	}
+	return t.FormatResponse(result)
+}
+
+// DeleteSavedQueryTool removes a saved query
+type DeleteSavedQueryTool struct{ *BaseTool }
+
+// NewDeleteSavedQueryTool creates a new tool instance
+func NewDeleteSavedQueryTool(c client.Doer, l *zap.Logger) *DeleteSavedQueryTool {
+	return &DeleteSavedQueryTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *DeleteSavedQueryTool) Name() string { return "delete_saved_query" }
+
+// Annotations returns tool hints for LLMs
+func (t *DeleteSavedQueryTool) Annotations() *mcp.ToolAnnotations {
+	return DeleteAnnotations("Delete Saved Query")
+}
+
+// Description returns the tool description
+func (t *DeleteSavedQueryTool) Description() string {
+	return `Delete a query saved with save_query. This only affects the local query library.
+
+**Related tools:** list_saved_queries, save_query`
+}
+
+// InputSchema returns the input schema
+func (t *DeleteSavedQueryTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name": savedQueryNameSchema,
+		},
+		"required": []string{"name"},
+	}
+}
+
+// Execute executes the tool
+func (t *DeleteSavedQueryTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	name, err := getSavedQueryName(args)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	if !GetSessionFromContext(ctx).DeleteSavedQuery(name) {
+		return savedQueryNotFound(name), nil
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Deleted saved query '%s'.", name)}},
+	}, nil
+}
+
+// resolveSavedQuery replaces a saved_query argument with the stored query text, syntax and tier.
+// Explicit syntax and tier arguments take precedence over the saved ones.
+func resolveSavedQuery(session *SessionContext, arguments map[string]interface{}) *mcp.CallToolResult {
+	name, _ := GetStringParam(arguments, "saved_query", false)
+	if name == "" {
+		return nil
+	}
+	if query, _ := GetStringParam(arguments, "query", false); query != "" {
+		return NewToolResultError("Provide either query or saved_query, not both")
+	}
+	q, ok := session.GetSavedQuery(name)
+	if !ok {
+		return savedQueryNotFound(name)
+	}
+	delete(arguments, "saved_query")
+	arguments["query"] = q.Query
+	if _, set := arguments["syntax"]; !set && q.Syntax != "" {
+		arguments["syntax"] = q.Syntax
+	}
+	if _, set := arguments["tier"]; !set && q.Tier != "" {
+		arguments["tier"] = q.Tier
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestSavedQueries_Lifecycle(t *testing.T) {
+	mock := client.NewMockClient()
+	ctx := testCtx(mock)
+	logger := zap.NewNop()
+
+	result, _ := NewSaveQueryTool(mock, logger).Execute(ctx, map[string]interface{}{
+		"name":        "checkout-errors",
+		"query":       "source logs | filter $l.applicationname == 'checkout' && $m.severity >= ERROR",
+		"tier":        "PI",
+		"description": "Checkout errors",
+	})
+	if result.IsError || !strings.Contains(result.Content[0].(*mcp.TextContent).Text, "Saved query 'checkout-errors'") {
+		t.Fatalf("save failed: %+v", result.Content)
+	}
+	result, _ = NewSaveQueryTool(mock, logger).Execute(ctx, map[string]interface{}{"name": "checkout-errors", "query": "source logs"})
+	if !strings.Contains(result.Content[0].(*mcp.TextContent).Text, "Updated") {
+		t.Errorf("saving an existing name should replace it: %+v", result.Content)
+	}
+	_, _ = NewSaveQueryTool(mock, logger).Execute(ctx, map[string]interface{}{"name": "api-slow", "query": "source logs | filter $d.duration > 1000", "tier": "frequent_search"})
+
+	list, _ := NewListSavedQueriesTool(mock, logger).Execute(ctx, nil)
+	text := list.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(text, "Saved Queries (2)") || strings.Index(text, "api-slow") > strings.Index(text, "checkout-errors") {
+		t.Errorf("expected 2 queries sorted by name:\n%s", text)
+	}
+
+	got, _ := NewGetSavedQueryTool(mock, logger).Execute(ctx, map[string]interface{}{"name": "api-slow"})
+	if got.IsError || !strings.Contains(got.Content[0].(*mcp.TextContent).Text, `"tier": "frequent_search"`) {
+		t.Errorf("unexpected get result: %+v", got.Content)
+	}
+
+	if del, _ := NewDeleteSavedQueryTool(mock, logger).Execute(ctx, map[string]interface{}{"name": "api-slow"}); del.IsError {
+		t.Errorf("delete failed: %+v", del.Content)
+	}
+	if got, _ := NewGetSavedQueryTool(mock, logger).Execute(ctx, map[string]interface{}{"name": "api-slow"}); !got.IsError {
+		t.Error("deleted query should not be found")
+	}
+	if mock.RequestCount() != 0 {
+		t.Errorf("saved queries must not call the API, got %d requests", mock.RequestCount())
+	}
+
+	// Saved queries survive clearing the session
+	GetSessionFromContext(ctx).ClearSession()
+	if _, ok := GetSessionFromContext(ctx).GetSavedQuery("checkout-errors"); !ok {
+		t.Error("saved queries should be kept when the session is cleared")
+	}
+}
+
+func TestSaveQueryTool_InvalidName(t *testing.T) {
+	mock := client.NewMockClient()
+	result, _ := NewSaveQueryTool(mock, zap.NewNop()).Execute(testCtx(mock), map[string]interface{}{"name": "../etc", "query": "source logs"})
+	if !result.IsError {
+		t.Error("expected invalid name to be rejected")
+	}
+}
+
+func TestQueryTool_SavedQuery(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.DefaultResponse = &client.Response{
+		StatusCode: 200,
+		Body:       []byte("data: {\"result\":{\"message\":\"test log\"}}\n"),
+	}
+	ctx := testCtx(mock)
+	if _, err := GetSessionFromContext(ctx).SaveQuery(SavedQuery{Name: "errors", Query: "source logs | filter $m.severity >= ERROR", Tier: "frequent_search"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tool := NewQueryTool(mock, zap.NewNop())
+	dates := func(extra map[string]interface{}) map[string]interface{} {
+		args := map[string]interface{}{"start_date": "2024-01-01T00:00:00Z", "end_date": "2024-01-01T01:00:00Z"}
+		for k, v := range extra {
+			args[k] = v
+		}
+		return args
+	}
+
+	result, err := tool.Execute(ctx, dates(map[string]interface{}{"saved_query": "errors"}))
+	if err != nil || result.IsError {
+		t.Fatalf("saved query run failed: %v %+v", err, result.Content)
+	}
+	body := mock.LastRequest().Body.(map[string]interface{})
+	if body["query"] != "source logs | filter $m.severity >= ERROR" || body["metadata"].(map[string]interface{})["tier"] != "frequent_search" {
+		t.Errorf("saved query text and tier not applied: %v", body)
+	}
+
+	if result, _ := tool.Execute(ctx, dates(map[string]interface{}{"saved_query": "missing"})); !result.IsError {
+		t.Error("unknown saved query should fail")
+	}
+	if result, _ := tool.Execute(ctx, dates(map[string]interface{}{"saved_query": "errors", "query": "source logs"})); !result.IsError {
+		t.Error("query and saved_query together should fail")
+	}
+}
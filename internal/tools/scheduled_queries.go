@@ -0,0 +1,601 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements scheduled queries: recurring reports run by the server and delivered
+// to an outgoing webhook or written to a file.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+	"github.com/tareqmamari/cloud-logs-mcp/internal/security"
+)
+
+const (
+	// scheduledQueryTick is how often the scheduler looks for due queries
+	scheduledQueryTick = 30 * time.Second
+	// defaultScheduledQueryLimit is the result limit of a scheduled query unless set
+	defaultScheduledQueryLimit = 100
+	// maxScheduledQueryLimit caps the results of one scheduled run
+	maxScheduledQueryLimit = 1000
+	// maxScheduledQueryLookback caps the time window of one scheduled run
+	maxScheduledQueryLookback = 7 * 24 * time.Hour
+	// maxScheduledReportChars caps the report text sent to a webhook
+	maxScheduledReportChars = 8000
+)
+
+// scheduledQueryNameSchema is the schema of the name argument shared by the scheduled query tools
+var scheduledQueryNameSchema = map[string]interface{}{
+	"type":        "string",
+	"description": "Schedule name (letters, digits, '_', '-', '.'; max 64 characters)",
+	"pattern":     savedQueryNamePattern.String(),
+}
+
+// getScheduledQueryName reads and validates the name argument
+func getScheduledQueryName(args map[string]interface{}) (string, error) {
+	name, err := GetStringParam(args, "name", true)
+	if err != nil {
+		return "", err
+	}
+	if !savedQueryNamePattern.MatchString(name) {
+		return "", fmt.Errorf("invalid schedule name %q: use letters, digits, '_', '-' or '.' (max 64 characters)", name)
+	}
+	return name, nil
+}
+
+// ScheduleQueryTool registers a query to run on a schedule
+type ScheduleQueryTool struct{ *BaseTool }
+
+// NewScheduleQueryTool creates a new tool instance
+func NewScheduleQueryTool(c client.Doer, l *zap.Logger) *ScheduleQueryTool {
+	return &ScheduleQueryTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *ScheduleQueryTool) Name() string { return "schedule_query" }
+
+// Annotations returns tool hints for LLMs
+func (t *ScheduleQueryTool) Annotations() *mcp.ToolAnnotations {
+	return UpdateAnnotations("Schedule Query")
+}
+
+// Description returns the tool description
+func (t *ScheduleQueryTool) Description() string {
+	return fmt.Sprintf(`Run a query on a recurring schedule and deliver the results, e.g. a daily "top errors" report.
+
+Each run queries the window that just ended (lookback, default: the time between runs) and
+sends the formatted results to an existing outgoing webhook (slack, generic or pagerduty) and/or
+writes them to a Markdown file in the export directory. Schedules are kept by this MCP server
+in its schedule file, so they survive restarts, and only run while the server is running; a run
+missed while it was down happens once at startup.
+Scheduling an existing name replaces it. At most %d schedules.
+
+**Schedule:** five cron fields in UTC (minute hour day-of-month month day-of-week), e.g.
+"0 8 * * 1-5" for 08:00 on weekdays, a macro (@hourly, @daily, @weekly, @monthly), or
+"@every <duration>" (at least 1m).
+
+**Related tools:** list_scheduled_queries, delete_scheduled_query, save_query, list_outgoing_webhooks, test_outgoing_webhook`, MaxScheduledQueries)
+}
+
+// InputSchema returns the input schema
+func (t *ScheduleQueryTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name": scheduledQueryNameSchema,
+			"query": map[string]interface{}{
+				"type":        "string",
+				"description": "Query text (DataPrime or Lucene). Max 4096 characters.",
+				"maxLength":   4096,
+			},
+			"saved_query": map[string]interface{}{
+				"type":        "string",
+				"description": "Name of a query saved with save_query to schedule instead of query",
+			},
+			"syntax": map[string]interface{}{
+				"type":        "string",
+				"description": "Query syntax (default: dataprime)",
+				"enum":        []string{"dataprime", "lucene"},
+			},
+			"tier": map[string]interface{}{
+				"type":        "string",
+				"description": "Tier to query (default: query_logs default)",
+				"enum":        []string{"archive", "frequent_search"},
+			},
+			"schedule": map[string]interface{}{
+				"type":        "string",
+				"description": "Cron expression in UTC, macro (@daily) or @every <duration>",
+				"examples":    []string{"0 8 * * *", "@hourly", "@every 6h"},
+			},
+			"lookback": map[string]interface{}{
+				"type":        "string",
+				"description": "How far back each run queries, as a duration like 24h (default: time between runs, max 168h)",
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"description": fmt.Sprintf("Maximum results per run (default: %d)", defaultScheduledQueryLimit),
+				"minimum":     1,
+				"maximum":     maxScheduledQueryLimit,
+			},
+			"webhook_id": map[string]interface{}{
+				"type":        "string",
+				"description": "ID of an outgoing webhook to send results to (slack, generic or pagerduty)",
+			},
+			"write_file": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Write each run's results to a Markdown file in the export directory",
+			},
+		},
+		"required": []string{"name", "schedule"},
+	}
+}
+
+// Execute executes the tool
+func (t *ScheduleQueryTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	name, err := getScheduledQueryName(args)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+
+	session := GetSessionFromContext(ctx)
+	if errResult := resolveSavedQuery(session, args); errResult != nil {
+		return errResult, nil
+	}
+	query, err := GetStringParam(args, "query", true)
+	if err != nil {
+		return NewToolResultError("query or saved_query is required"), nil
+	}
+	if len(query) > 4096 {
+		return NewToolResultError(fmt.Sprintf("Query too long: %d characters (max 4096)", len(query))), nil
+	}
+	syntax, _ := GetStringParam(args, "syntax", false)
+	if syntax != "" && syntax != "dataprime" && syntax != "lucene" {
+		return NewToolResultError(fmt.Sprintf("invalid syntax '%s' (valid: dataprime, lucene)", syntax)), nil
+	}
+	tier, _ := GetStringParam(args, "tier", false)
+	if tier != "" {
+		tier = normalizeTier(tier)
+	}
+	if syntax != "lucene" {
+		if _, _, err := PrepareQuery(query, tier, "dataprime"); err != nil {
+			return NewToolResultErrorFromErr(err), nil
+		}
+	}
+
+	specText, err := GetStringParam(args, "schedule", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	spec, err := ParseCronSchedule(specText)
+	if err != nil {
+		return NewToolResultErrorWithSuggestion(err.Error(),
+			`Use five cron fields in UTC such as "0 8 * * *", a macro such as @daily, or "@every 6h".`), nil
+	}
+
+	now := time.Now().UTC()
+	next := spec.Next(now)
+	lookback := min(spec.Next(next).Sub(next), maxScheduledQueryLookback)
+	if s, _ := GetStringParam(args, "lookback", false); s != "" {
+		if lookback, err = time.ParseDuration(s); err != nil || lookback <= 0 {
+			return NewToolResultError(fmt.Sprintf("invalid lookback %q: use a positive duration such as 1h or 24h", s)), nil
+		}
+	}
+	if lookback > maxScheduledQueryLookback {
+		return NewToolResultError(fmt.Sprintf("lookback %s exceeds the maximum of %s; schedule a shorter window or use submit_background_query",
+			lookback, maxScheduledQueryLookback)), nil
+	}
+
+	limit, err := GetIntParam(args, "limit", false)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	if limit == 0 {
+		limit = defaultScheduledQueryLimit
+	}
+	if limit < 0 || limit > maxScheduledQueryLimit {
+		return NewToolResultError(fmt.Sprintf("limit must be between 1 and %d", maxScheduledQueryLimit)), nil
+	}
+
+	webhookID, _ := GetStringParam(args, "webhook_id", false)
+	writeFile, _ := GetBoolParam(args, "write_file", false)
+	if webhookID == "" && !writeFile {
+		return NewToolResultErrorWithSuggestion("a destination is required: set webhook_id, write_file=true, or both",
+			"Use list_outgoing_webhooks to find a webhook ID."), nil
+	}
+	if webhookID != "" {
+		wh, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/outgoing_webhooks/" + url.PathEscape(webhookID)})
+		if err != nil {
+			return HandleGetError(err, "Outgoing webhook", webhookID, "list_outgoing_webhooks"), nil
+		}
+		if whType, _ := wh["type"].(string); !scheduledReportWebhookTypes[whType] {
+			return NewToolResultError(fmt.Sprintf("webhook %s has type '%s'; scheduled results can be sent to slack, generic or pagerduty webhooks", webhookID, whType)), nil
+		}
+	}
+
+	replaced, err := currentScheduledQueryStore().Schedule(session.UserID, session.InstanceID, ScheduledQuery{
+		Name:      name,
+		Query:     query,
+		Syntax:    syntax,
+		Tier:      tier,
+		Schedule:  spec.String(),
+		Lookback:  lookback,
+		Limit:     limit,
+		WebhookID: webhookID,
+		WriteFile: writeFile,
+		Instance:  GetInstanceNameFromContext(ctx),
+		NextRunAt: next,
+	})
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+
+	verb := "Scheduled"
+	if replaced {
+		verb = "Rescheduled"
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s query '%s' (%s).\n\n", verb, name, spec)
+	fmt.Fprintf(&sb, "- **Next run:** %s\n", next.Format(time.RFC3339))
+	fmt.Fprintf(&sb, "- **Window per run:** last %s, up to %d results\n", lookback, limit)
+	fmt.Fprintf(&sb, "- **Delivery:** %s\n", scheduledQueryDestination(ScheduledQuery{WebhookID: webhookID, WriteFile: writeFile}))
+	if instance := GetInstanceNameFromContext(ctx); instance != "" {
+		fmt.Fprintf(&sb, "- **Instance:** %s\n", instance)
+	}
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}}}, nil
+}
+
+// scheduledQueryDestination describes where a schedule delivers its results
+func scheduledQueryDestination(q ScheduledQuery) string {
+	var parts []string
+	if q.WebhookID != "" {
+		parts = append(parts, "webhook "+q.WebhookID)
+	}
+	if q.WriteFile {
+		parts = append(parts, "file in "+currentExportDir())
+	}
+	return strings.Join(parts, " and ")
+}
+
+// ListScheduledQueriesTool lists the user's scheduled queries
+type ListScheduledQueriesTool struct{ *BaseTool }
+
+// NewListScheduledQueriesTool creates a new tool instance
+func NewListScheduledQueriesTool(c client.Doer, l *zap.Logger) *ListScheduledQueriesTool {
+	return &ListScheduledQueriesTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *ListScheduledQueriesTool) Name() string { return "list_scheduled_queries" }
+
+// Annotations returns tool hints for LLMs
+func (t *ListScheduledQueriesTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("List Scheduled Queries")
+}
+
+// Description returns the tool description
+func (t *ListScheduledQueriesTool) Description() string {
+	return `List the queries scheduled with schedule_query, with their next run and the outcome of their last run.
+
+**Related tools:** schedule_query, delete_scheduled_query`
+}
+
+// InputSchema returns the input schema
+func (t *ListScheduledQueriesTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{},
+	}
+}
+
+// Execute executes the tool
+func (t *ListScheduledQueriesTool) Execute(ctx context.Context, _ map[string]interface{}) (*mcp.CallToolResult, error) {
+	queries := currentScheduledQueryStore().List(GetSessionFromContext(ctx).UserID)
+	if len(queries) == 0 {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: "No scheduled queries.\n\nUse `schedule_query` to add one."}},
+		}, nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## Scheduled Queries (%d/%d)\n\n", len(queries), MaxScheduledQueries)
+	sb.WriteString("| Name | Schedule | Window | Next run | Last run | Delivery | Query |\n")
+	sb.WriteString("|------|----------|--------|----------|----------|----------|-------|\n")
+	for _, q := range queries {
+		lastRun := "never"
+		if !q.LastRunAt.IsZero() {
+			lastRun = fmt.Sprintf("%s %s", q.LastRunAt.UTC().Format(time.RFC3339), q.LastStatus)
+			if q.LastError != "" {
+				lastRun += ": " + q.LastError
+			}
+		}
+		query := q.Query
+		if len(query) > 60 {
+			query = query[:57] + "..."
+		}
+		fmt.Fprintf(&sb, "| %s | `%s` | %s | %s | %s | %s | `%s` |\n",
+			q.Name, q.Schedule, q.Lookback, q.NextRunAt.UTC().Format(time.RFC3339),
+			strings.ReplaceAll(lastRun, "|", "\\|"), scheduledQueryDestination(q), strings.ReplaceAll(query, "|", "\\|"))
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}},
+	}, nil
+}
+
+// DeleteScheduledQueryTool removes a scheduled query
+type DeleteScheduledQueryTool struct{ *BaseTool }
+
+// NewDeleteScheduledQueryTool creates a new tool instance
+func NewDeleteScheduledQueryTool(c client.Doer, l *zap.Logger) *DeleteScheduledQueryTool {
+	return &DeleteScheduledQueryTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *DeleteScheduledQueryTool) Name() string { return "delete_scheduled_query" }
+
+// Annotations returns tool hints for LLMs
+func (t *DeleteScheduledQueryTool) Annotations() *mcp.ToolAnnotations {
+	return DeleteAnnotations("Delete Scheduled Query")
+}
+
+// Description returns the tool description
+func (t *DeleteScheduledQueryTool) Description() string {
+	return `Stop and delete a query scheduled with schedule_query. Files already written are kept.
+
+**Related tools:** list_scheduled_queries, schedule_query`
+}
+
+// InputSchema returns the input schema
+func (t *DeleteScheduledQueryTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name": scheduledQueryNameSchema,
+		},
+		"required": []string{"name"},
+	}
+}
+
+// Execute executes the tool
+func (t *DeleteScheduledQueryTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	name, err := getScheduledQueryName(args)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	if !currentScheduledQueryStore().Delete(GetSessionFromContext(ctx).UserID, name) {
+		return NewToolResultErrorWithSuggestion(fmt.Sprintf("No scheduled query named '%s'.", name),
+			"Use list_scheduled_queries to see schedule names."), nil
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Deleted scheduled query '%s'.", name)}},
+	}, nil
+}
+
+// scheduledReportWebhookTypes are the outgoing webhook types scheduled results can be sent to
+var scheduledReportWebhookTypes = map[string]bool{"slack": true, "generic": true, "pagerduty": true}
+
+// QueryScheduler runs due scheduled queries of every user
+type QueryScheduler struct {
+	client client.Doer
+	logger *zap.Logger
+	now    func() time.Time
+	store  *scheduledQueryStore
+}
+
+// NewQueryScheduler creates a scheduler that runs the queries of the global schedule store
+// with the given client
+func NewQueryScheduler(c client.Doer, l *zap.Logger) *QueryScheduler {
+	return &QueryScheduler{client: c, logger: l, now: time.Now, store: currentScheduledQueryStore()}
+}
+
+// Run checks for due queries until ctx is cancelled
+func (s *QueryScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(scheduledQueryTick)
+	defer ticker.Stop()
+	for {
+		s.RunDue(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue runs every scheduled query whose next run time has passed and returns how many ran.
+// Queries run one at a time so a slow report never overlaps its own next run.
+func (s *QueryScheduler) RunDue(ctx context.Context) int {
+	ran := 0
+	for _, due := range s.store.Due(s.now()) {
+		if ctx.Err() != nil {
+			return ran
+		}
+		s.runOne(ctx, due)
+		ran++
+	}
+	return ran
+}
+
+// runOne runs a scheduled query, delivers its results and records the outcome
+func (s *QueryScheduler) runOne(ctx context.Context, due dueScheduledQuery) {
+	q := due.Query
+	start := s.now().UTC()
+	run := q
+	run.LastRunAt = start
+	run.LastStatus = "ok"
+	run.LastError = ""
+	run.LastOutput = ""
+	if spec, err := ParseCronSchedule(q.Schedule); err == nil {
+		run.NextRunAt = spec.Next(start)
+	} else {
+		run.NextRunAt = start.Add(24 * time.Hour)
+	}
+
+	if err := s.execute(ctx, due, start, &run); err != nil {
+		run.LastStatus = "failed"
+		run.LastError = security.SanitizeError(err)
+		s.logger.Warn("Scheduled query failed", zap.String("name", q.Name), zap.Error(err))
+	} else {
+		s.logger.Info("Scheduled query ran", zap.String("name", q.Name), zap.String("output", run.LastOutput))
+	}
+	s.store.RecordRun(due.UserID, q.Name, q.CreatedAt, run)
+}
+
+func (s *QueryScheduler) execute(ctx context.Context, due dueScheduledQuery, end time.Time, run *ScheduledQuery) error {
+	q := due.Query
+	windowStart := end.Add(-q.Lookback)
+	args := map[string]interface{}{
+		"query":      q.Query,
+		"start_date": windowStart.Format(time.RFC3339),
+		"end_date":   end.Format(time.RFC3339),
+		"limit":      float64(q.Limit),
+	}
+	if q.Syntax != "" {
+		args["syntax"] = q.Syntax
+	}
+	if q.Tier != "" {
+		args["tier"] = q.Tier
+	}
+
+	// Run on the instance the schedule was created on, not the primary one
+	apiClient := s.client
+	if q.Instance != "" && q.Instance != currentPrimaryInstance() {
+		c, ok := lookupInstanceClient(q.Instance)
+		if !ok {
+			return fmt.Errorf("instance '%s' is no longer configured", q.Instance)
+		}
+		apiClient = c
+	}
+
+	// A scratch session keeps scheduled runs out of the user's query history and replay state
+	ctx = WithSession(WithClient(ctx, apiClient), NewSessionContext(due.UserID, due.InstanceID))
+	ctx = WithInstanceName(ctx, q.Instance)
+	result, err := ExecuteWithTimeout(ctx, NewQueryTool(apiClient, s.logger), args)
+	if err != nil {
+		return err
+	}
+	body := resultText(result)
+	if result.IsError {
+		return fmt.Errorf("query failed: %s", truncateString(body, 200))
+	}
+
+	report := fmt.Sprintf("## Scheduled query: %s\n\n**Schedule:** %s\n**Window:** %s to %s\n**Query:** `%s`\n\n%s",
+		q.Name, q.Schedule, windowStart.Format(time.RFC3339), end.Format(time.RFC3339), q.Query, body)
+
+	var errs []string
+	if q.WriteFile {
+		path, err := writeScheduledReport(currentExportDir(), q.Name, end, report)
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+		run.LastOutput = path
+	}
+	if q.WebhookID != "" {
+		if err := s.deliver(ctx, apiClient, q, report); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// deliver sends a report to the schedule's outgoing webhook, read from the schedule's instance
+func (s *QueryScheduler) deliver(ctx context.Context, apiClient client.Doer, q ScheduledQuery, report string) error {
+	wh, err := NewBaseTool(apiClient, s.logger).ExecuteRequest(ctx, &client.Request{
+		Method: "GET",
+		Path:   "/v1/outgoing_webhooks/" + url.PathEscape(q.WebhookID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load webhook %s: %w", q.WebhookID, err)
+	}
+	whType, _ := wh["type"].(string)
+	rawURL, _ := wh["url"].(string)
+	target, err := validateWebhookURL(rawURL)
+	if err != nil {
+		return fmt.Errorf("webhook %s: %w", q.WebhookID, err)
+	}
+	routingKey := ""
+	if pd, ok := wh["pager_duty"].(map[string]interface{}); ok {
+		routingKey, _ = pd["service_key"].(string)
+	}
+
+	endpoint, payload, err := scheduledReportRequest(whType, target, routingKey, q.Name, truncateString(report, maxScheduledReportChars))
+	if err != nil {
+		return err
+	}
+	status, _, respBody, err := sendWebhookTest(ctx, endpoint, payload)
+	if err != nil {
+		return fmt.Errorf("failed to send results to webhook %s: %w", q.WebhookID, err)
+	}
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("webhook %s rejected the results with HTTP %d: %s", q.WebhookID, status, security.MaskSensitiveData(respBody))
+	}
+	return nil
+}
+
+// scheduledReportRequest returns the endpoint and payload delivering a report to a webhook type
+func scheduledReportRequest(whType string, target *url.URL, routingKey, name, report string) (string, map[string]interface{}, error) {
+	switch whType {
+	case "slack":
+		return target.String(), map[string]interface{}{"text": report}, nil
+	case "pagerduty":
+		if routingKey == "" {
+			return "", nil, fmt.Errorf("pagerduty webhook has no pager_duty.service_key")
+		}
+		// Change events are informational and never open an incident
+		changeURL := url.URL{Scheme: target.Scheme, Host: target.Host, Path: "/v2/change/enqueue"}
+		return changeURL.String(), map[string]interface{}{
+			"routing_key": routingKey,
+			"payload": map[string]interface{}{
+				"summary":        truncateString("Scheduled query report: "+name, 1024),
+				"source":         "ibm-cloud-logs-mcp",
+				"custom_details": map[string]interface{}{"report": report},
+			},
+		}, nil
+	case "generic":
+		return target.String(), map[string]interface{}{
+			"source":          "ibm-cloud-logs-mcp",
+			"scheduled_query": name,
+			"timestamp":       time.Now().UTC().Format(time.RFC3339),
+			"report":          report,
+		}, nil
+	default:
+		return "", nil, fmt.Errorf("unsupported webhook type '%s' (valid: slack, generic, pagerduty)", whType)
+	}
+}
+
+// writeScheduledReport writes a report to a timestamped Markdown file and returns its path
+func writeScheduledReport(dir, name string, at time.Time, report string) (string, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create export directory: %w", err)
+	}
+	path := filepath.Join(dir, fmt.Sprintf("scheduled-%s-%s.md",
+		unsafeFileChars.ReplaceAllString(name, "_"), at.UTC().Format("20060102T150405Z")))
+	if err := os.WriteFile(path, []byte(report), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write report: %w", err)
+	}
+	return path, nil
+}
+
+// resultText joins the text content of a tool result
+func resultText(result *mcp.CallToolResult) string {
+	if result == nil {
+		return ""
+	}
+	var parts []string
+	for _, c := range result.Content {
+		if text, ok := c.(*mcp.TextContent); ok {
+			parts = append(parts, text.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
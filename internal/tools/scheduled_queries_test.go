@@ -0,0 +1,318 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// useScheduledQueryStore replaces the global schedule store with an empty in-memory one for the test
+func useScheduledQueryStore(t *testing.T) *scheduledQueryStore {
+	t.Helper()
+	store := newScheduledQueryStore("", nil)
+	scheduledQueriesMu.Lock()
+	previous := scheduledQueries
+	scheduledQueries = store
+	scheduledQueriesMu.Unlock()
+	t.Cleanup(func() {
+		scheduledQueriesMu.Lock()
+		scheduledQueries = previous
+		scheduledQueriesMu.Unlock()
+	})
+	return store
+}
+
+func TestScheduledQueries_Lifecycle(t *testing.T) {
+	store := useScheduledQueryStore(t)
+	mock := client.NewMockClient()
+	mock.DoFunc = func(_ context.Context, req *client.Request) (*client.Response, error) {
+		return &client.Response{StatusCode: 200, Body: []byte(`{"id":"wh-1","type":"slack","url":"https://hooks.slack.com/services/T/B/X"}`)}, nil
+	}
+	ctx := testCtx(mock)
+	logger := zap.NewNop()
+
+	result, _ := NewScheduleQueryTool(mock, logger).Execute(ctx, map[string]interface{}{
+		"name":       "daily-errors",
+		"query":      "source logs | filter $m.severity >= ERROR",
+		"schedule":   "0 8 * * *",
+		"webhook_id": "wh-1",
+	})
+	require.False(t, result.IsError, "schedule failed: %+v", result.Content)
+	text := result.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, "Scheduled query 'daily-errors'")
+	assert.Contains(t, text, "last 24h0m0s")
+	assert.Equal(t, "/v1/outgoing_webhooks/wh-1", mock.LastRequest().Path)
+
+	// Monthly schedules default to the maximum window
+	result, _ = NewScheduleQueryTool(mock, logger).Execute(ctx, map[string]interface{}{
+		"name": "monthly", "query": "source logs", "schedule": "@monthly", "write_file": true,
+	})
+	require.False(t, result.IsError, "schedule failed: %+v", result.Content)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "last 168h0m0s")
+
+	list, _ := NewListScheduledQueriesTool(mock, logger).Execute(ctx, nil)
+	text = list.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, "Scheduled Queries (2/20)")
+	assert.Less(t, strings.Index(text, "daily-errors"), strings.Index(text, "monthly"))
+
+	// Schedules survive clearing the session
+	GetSessionFromContext(ctx).ClearSession()
+	assert.Len(t, store.List("test-user"), 2)
+
+	del, _ := NewDeleteScheduledQueryTool(mock, logger).Execute(ctx, map[string]interface{}{"name": "monthly"})
+	assert.False(t, del.IsError)
+	del, _ = NewDeleteScheduledQueryTool(mock, logger).Execute(ctx, map[string]interface{}{"name": "monthly"})
+	assert.True(t, del.IsError)
+	assert.Len(t, store.List("test-user"), 1)
+}
+
+func TestScheduleQueryTool_Validation(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.DoFunc = func(_ context.Context, req *client.Request) (*client.Response, error) {
+		if strings.HasSuffix(req.Path, "/wh-en") {
+			return &client.Response{StatusCode: 200, Body: []byte(`{"id":"wh-en","type":"ibm_event_notifications"}`)}, nil
+		}
+		return &client.Response{StatusCode: 404, Body: []byte(`{"message":"not found"}`)}, nil
+	}
+	tool := NewScheduleQueryTool(mock, zap.NewNop())
+	base := func(extra map[string]interface{}) map[string]interface{} {
+		args := map[string]interface{}{"name": "report", "query": "source logs", "schedule": "@daily", "write_file": true}
+		for k, v := range extra {
+			args[k] = v
+		}
+		return args
+	}
+
+	tests := []struct {
+		name string
+		args map[string]interface{}
+	}{
+		{"invalid cron", base(map[string]interface{}{"schedule": "61 * * * *"})},
+		{"interval too short", base(map[string]interface{}{"schedule": "@every 10s"})},
+		{"no destination", base(map[string]interface{}{"write_file": false})},
+		{"lookback too long", base(map[string]interface{}{"lookback": "720h"})},
+		{"invalid lookback", base(map[string]interface{}{"lookback": "yesterday"})},
+		{"limit too high", base(map[string]interface{}{"limit": float64(5000)})},
+		{"missing query", map[string]interface{}{"name": "report", "schedule": "@daily", "write_file": true}},
+		{"invalid name", base(map[string]interface{}{"name": "../report"})},
+		{"unknown webhook", base(map[string]interface{}{"webhook_id": "missing"})},
+		{"unsupported webhook type", base(map[string]interface{}{"webhook_id": "wh-en"})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tool.Execute(testCtx(mock), tt.args)
+			require.NoError(t, err)
+			assert.True(t, result.IsError, "expected an error, got %+v", result.Content)
+		})
+	}
+}
+
+func TestScheduleQueryTool_Limit(t *testing.T) {
+	useScheduledQueryStore(t)
+	mock := client.NewMockClient()
+	ctx := testCtx(mock)
+	tool := NewScheduleQueryTool(mock, zap.NewNop())
+	schedule := func(name string) *mcp.CallToolResult {
+		result, _ := tool.Execute(ctx, map[string]interface{}{"name": name, "query": "source logs", "schedule": "@hourly", "write_file": true})
+		return result
+	}
+
+	for i := 0; i < MaxScheduledQueries; i++ {
+		require.False(t, schedule(fmt.Sprintf("report-%d", i)).IsError)
+	}
+	assert.True(t, schedule("one-too-many").IsError)
+	// Replacing an existing schedule is allowed at the limit
+	assert.False(t, schedule("report-0").IsError)
+}
+
+func TestQueryScheduler_RunDue(t *testing.T) {
+	allowLocalWebhooks(t)
+	var delivered map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &delivered)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	SetExportDir(dir)
+	defer SetExportDir("")
+
+	mock := client.NewMockClient()
+	var queryBody map[string]interface{}
+	mock.DoFunc = func(_ context.Context, req *client.Request) (*client.Response, error) {
+		if strings.HasPrefix(req.Path, "/v1/outgoing_webhooks/") {
+			return &client.Response{StatusCode: 200, Body: []byte(`{"id":"wh-1","type":"generic","url":"` + srv.URL + `"}`)}, nil
+		}
+		queryBody, _ = req.Body.(map[string]interface{})
+		return &client.Response{StatusCode: 200, Body: []byte("data: {\"result\":{\"results\":[{\"user_data\":\"{\\\"message\\\":\\\"payment failed\\\"}\"}]}}\n")}, nil
+	}
+
+	now := time.Date(2026, 3, 2, 8, 0, 30, 0, time.UTC)
+	store := newScheduledQueryStore("", nil)
+	_, err := store.Schedule("user", "instance", ScheduledQuery{
+		Name:      "daily-errors",
+		Query:     "source logs | filter $m.severity >= ERROR",
+		Schedule:  "0 8 * * *",
+		Lookback:  24 * time.Hour,
+		Limit:     50,
+		WebhookID: "wh-1",
+		WriteFile: true,
+		NextRunAt: now.Add(-30 * time.Second),
+	})
+	require.NoError(t, err)
+	_, err = store.Schedule("user", "instance", ScheduledQuery{Name: "later", Query: "source logs", Schedule: "@hourly", WriteFile: true, NextRunAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+
+	scheduler := NewQueryScheduler(mock, zap.NewNop())
+	scheduler.now = func() time.Time { return now }
+	scheduler.store = store
+
+	assert.Equal(t, 1, scheduler.RunDue(context.Background()))
+
+	require.NotNil(t, queryBody)
+	assert.Equal(t, "source logs | filter $m.severity >= ERROR", queryBody["query"])
+	metadata := queryBody["metadata"].(map[string]interface{})
+	assert.Equal(t, "2026-03-01T08:00:30Z", metadata["start_date"])
+	assert.Equal(t, "2026-03-02T08:00:30Z", metadata["end_date"])
+
+	require.NotNil(t, delivered, "report was not delivered to the webhook")
+	assert.Equal(t, "daily-errors", delivered["scheduled_query"])
+	assert.Contains(t, delivered["report"], "payment failed")
+
+	q := store.List("user")[0]
+	assert.Equal(t, "ok", q.LastStatus, q.LastError)
+	assert.Equal(t, now, q.LastRunAt)
+	assert.Equal(t, time.Date(2026, 3, 3, 8, 0, 0, 0, time.UTC), q.NextRunAt)
+	report, err := os.ReadFile(q.LastOutput)
+	require.NoError(t, err)
+	assert.Contains(t, string(report), "## Scheduled query: daily-errors")
+
+	// Nothing is due until the next run
+	assert.Equal(t, 0, scheduler.RunDue(context.Background()))
+}
+
+func TestQueryScheduler_RecordsFailures(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.DoFunc = func(_ context.Context, req *client.Request) (*client.Response, error) {
+		if strings.HasPrefix(req.Path, "/v1/outgoing_webhooks/") {
+			return &client.Response{StatusCode: 404, Body: []byte(`{"message":"not found"}`)}, nil
+		}
+		return &client.Response{StatusCode: 200, Body: []byte("data: {\"result\":{\"results\":[{\"user_data\":\"{}\"}]}}\n")}, nil
+	}
+
+	now := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	store := newScheduledQueryStore("", nil)
+	_, err := store.Schedule("user", "instance", ScheduledQuery{Name: "gone", Query: "source logs", Schedule: "@every 1h", Lookback: time.Hour, Limit: 10, WebhookID: "deleted", NextRunAt: now})
+	require.NoError(t, err)
+
+	scheduler := NewQueryScheduler(mock, zap.NewNop())
+	scheduler.now = func() time.Time { return now }
+	scheduler.store = store
+	assert.Equal(t, 1, scheduler.RunDue(context.Background()))
+
+	q := store.List("user")[0]
+	assert.Equal(t, "failed", q.LastStatus)
+	assert.Contains(t, q.LastError, "deleted")
+	assert.Equal(t, now.Add(time.Hour), q.NextRunAt, "a failed run must still advance the schedule")
+}
+
+func TestQueryScheduler_RunsOnScheduleInstance(t *testing.T) {
+	prod, staging := setTestInstances(t)
+	staging.DoFunc = func(_ context.Context, req *client.Request) (*client.Response, error) {
+		if strings.HasPrefix(req.Path, "/v1/outgoing_webhooks/") {
+			return &client.Response{StatusCode: 404, Body: []byte(`{"message":"not found"}`)}, nil
+		}
+		return &client.Response{StatusCode: 200, Body: []byte("data: {\"result\":{\"results\":[{\"user_data\":\"{}\"}]}}\n")}, nil
+	}
+
+	now := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	store := newScheduledQueryStore("", nil)
+	_, err := store.Schedule("user", "instance", ScheduledQuery{Name: "staging-errors", Query: "source logs", Schedule: "@every 1h", Lookback: time.Hour, Limit: 10,
+		WebhookID: "wh-staging", Instance: "staging", NextRunAt: now})
+	require.NoError(t, err)
+
+	scheduler := NewQueryScheduler(prod, zap.NewNop())
+	scheduler.now = func() time.Time { return now }
+	scheduler.store = store
+	assert.Equal(t, 1, scheduler.RunDue(context.Background()))
+
+	assert.Zero(t, prod.RequestCount(), "nothing runs on the primary instance")
+	require.Equal(t, 2, staging.RequestCount())
+	assert.Equal(t, "/v1/outgoing_webhooks/wh-staging", staging.LastRequest().Path)
+}
+
+func TestQueryScheduler_DeliverRefusesNonPublicWebhooks(t *testing.T) {
+	mock := client.NewMockClient()
+	scheduler := NewQueryScheduler(mock, zap.NewNop())
+	q := ScheduledQuery{Name: "daily-errors", WebhookID: "wh-1"}
+
+	mock.RespondWith(200, map[string]interface{}{"id": "wh-1", "type": "generic", "url": "http://example.com/hook"})
+	err := scheduler.deliver(context.Background(), mock, q, "report")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only https is allowed")
+
+	mock.RespondWith(200, map[string]interface{}{"id": "wh-1", "type": "generic", "url": "https://127.0.0.1/hook"})
+	err = scheduler.deliver(context.Background(), mock, q, "report")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not a public address")
+}
+
+func TestScheduledQueryStore_Persists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedules.json")
+	now := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+
+	store := newScheduledQueryStore(path, nil)
+	_, err := store.Schedule("alice", "instance", ScheduledQuery{Name: "daily", Query: "source logs", Schedule: "@daily", WriteFile: true, NextRunAt: now})
+	require.NoError(t, err)
+	_, err = store.Schedule("bob", "instance", ScheduledQuery{Name: "hourly", Query: "source logs", Schedule: "@hourly", WriteFile: true, NextRunAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+	q := store.List("alice")[0]
+	run := q
+	run.LastStatus = "ok"
+	run.NextRunAt = now.Add(24 * time.Hour)
+	store.RecordRun("alice", q.Name, q.CreatedAt, run)
+
+	// A restarted server sees every user's schedules, not only the sessions it has loaded
+	reloaded := newScheduledQueryStore(path, nil)
+	reloaded.load()
+	require.Len(t, reloaded.List("alice"), 1)
+	assert.Equal(t, "ok", reloaded.List("alice")[0].LastStatus)
+	assert.Equal(t, now.Add(24*time.Hour), reloaded.List("alice")[0].NextRunAt)
+	due := reloaded.Due(now.Add(time.Hour))
+	require.Len(t, due, 1)
+	assert.Equal(t, "bob", due[0].UserID)
+	assert.Equal(t, "instance", due[0].InstanceID)
+
+	assert.True(t, reloaded.Delete("bob", "hourly"))
+	reloaded = newScheduledQueryStore(path, nil)
+	reloaded.load()
+	assert.Empty(t, reloaded.List("bob"))
+}
+
+func TestScheduledQueryStore_CorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedules.json")
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0o600))
+
+	store := newScheduledQueryStore(path, nil)
+	store.load()
+	assert.Empty(t, store.Due(time.Now()))
+	_, err := os.Stat(path + ".corrupt")
+	assert.NoError(t, err, "the corrupt file should be kept for inspection")
+}
@@ -0,0 +1,277 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements the scheduled query store. Schedules are kept apart from sessions
+// and persisted to their own file, so they survive restarts without session persistence
+// and run for every user who created one, not only the sessions loaded at the time.
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// MaxScheduledQueries caps how many recurring queries a user can schedule
+const MaxScheduledQueries = 20
+
+// ScheduledQuery is a query run on a schedule by the server, with its results delivered to
+// an outgoing webhook or written to a file
+type ScheduledQuery struct {
+	Name      string        `json:"name"`
+	Query     string        `json:"query"`
+	Syntax    string        `json:"syntax,omitempty"`
+	Tier      string        `json:"tier,omitempty"`
+	Schedule  string        `json:"schedule"`
+	Lookback  time.Duration `json:"lookback"`
+	Limit     int           `json:"limit,omitempty"`
+	WebhookID string        `json:"webhook_id,omitempty"`
+	WriteFile bool          `json:"write_file,omitempty"`
+	Instance  string        `json:"instance,omitempty"` // Instance the schedule was created on
+	CreatedAt time.Time     `json:"created_at"`
+	NextRunAt time.Time     `json:"next_run_at"`
+
+	// Outcome of the most recent run
+	LastRunAt  time.Time `json:"last_run_at,omitempty"`
+	LastStatus string    `json:"last_status,omitempty"` // ok or failed
+	LastError  string    `json:"last_error,omitempty"`
+	LastOutput string    `json:"last_output,omitempty"` // file path written, if any
+}
+
+// userSchedules are the scheduled queries of one user
+type userSchedules struct {
+	InstanceID string                    `json:"instance_id,omitempty"` // Session instance ID of the user
+	Queries    map[string]ScheduledQuery `json:"queries"`
+}
+
+// dueScheduledQuery is a scheduled query whose next run time has passed, with its owner
+type dueScheduledQuery struct {
+	UserID     string
+	InstanceID string
+	Query      ScheduledQuery
+}
+
+// scheduledQueryStore holds the scheduled queries of every user, keyed by user ID
+type scheduledQueryStore struct {
+	mu     sync.Mutex
+	path   string // file the schedules are saved to; empty keeps them in memory only
+	logger *zap.Logger
+	users  map[string]*userSchedules
+}
+
+// newScheduledQueryStore creates an empty store saved to path, or kept in memory if path is empty
+func newScheduledQueryStore(path string, logger *zap.Logger) *scheduledQueryStore {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &scheduledQueryStore{path: path, logger: logger, users: make(map[string]*userSchedules)}
+}
+
+// Global scheduled query store; in memory only until EnableScheduledQueryStore is called
+var (
+	scheduledQueriesMu sync.RWMutex
+	scheduledQueries   = newScheduledQueryStore("", nil)
+)
+
+// EnableScheduledQueryStore loads the scheduled queries from path (default:
+// ~/.logs-mcp/schedules.json) and saves every later change to it.
+// Must be called before the query scheduler starts.
+func EnableScheduledQueryStore(path string, logger *zap.Logger) {
+	if path == "" {
+		path = defaultScheduledQueryFile()
+	}
+	store := newScheduledQueryStore(path, logger)
+	store.load()
+
+	scheduledQueriesMu.Lock()
+	scheduledQueries = store
+	scheduledQueriesMu.Unlock()
+}
+
+// currentScheduledQueryStore returns the global scheduled query store
+func currentScheduledQueryStore() *scheduledQueryStore {
+	scheduledQueriesMu.RLock()
+	defer scheduledQueriesMu.RUnlock()
+	return scheduledQueries
+}
+
+// defaultScheduledQueryFile returns the default scheduled query file
+func defaultScheduledQueryFile() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".logs-mcp", "schedules.json")
+}
+
+// load reads the schedules from disk. A missing file starts an empty store; a corrupt one is
+// kept for inspection.
+func (st *scheduledQueryStore) load() {
+	if st.path == "" {
+		return
+	}
+	data, err := os.ReadFile(st.path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			st.logger.Warn("Failed to read scheduled queries", zap.String("path", st.path), zap.Error(err))
+		}
+		return
+	}
+
+	var users map[string]*userSchedules
+	if err := json.Unmarshal(data, &users); err != nil {
+		backupPath := st.path + ".corrupt"
+		renameErr := os.Rename(st.path, backupPath)
+		st.logger.Warn("Scheduled query file is corrupt, starting without schedules",
+			zap.String("path", st.path),
+			zap.String("backup_path", backupPath),
+			zap.Error(err),
+			zap.NamedError("backup_error", renameErr),
+		)
+		return
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	for userID, u := range users {
+		if u == nil || len(u.Queries) == 0 {
+			continue
+		}
+		st.users[userID] = u
+	}
+	st.logger.Info("Loaded scheduled queries", zap.String("path", st.path), zap.Int("users", len(st.users)))
+}
+
+// saveLocked writes the schedules to disk. Failures are logged: the schedules keep running
+// from memory until the next successful save. Caller must hold st.mu.
+func (st *scheduledQueryStore) saveLocked() {
+	if st.path == "" {
+		return
+	}
+	if err := st.writeLocked(); err != nil {
+		st.logger.Warn("Failed to save scheduled queries", zap.String("path", st.path), zap.Error(err))
+	}
+}
+
+// writeLocked writes the schedules to a temp file and renames it over the store file
+func (st *scheduledQueryStore) writeLocked() error {
+	if err := os.MkdirAll(filepath.Dir(st.path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(st.users, "", "  ")
+	if err != nil {
+		return err
+	}
+	// A crash mid-write leaves the temp file, never a corrupt store file
+	tmpPath := st.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, st.path)
+}
+
+// Schedule stores q under its name for the user, replacing any schedule of the same name.
+// It reports whether an existing schedule was replaced.
+func (st *scheduledQueryStore) Schedule(userID, instanceID string, q ScheduledQuery) (bool, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	u := st.users[userID]
+	if u == nil {
+		u = &userSchedules{Queries: make(map[string]ScheduledQuery)}
+		st.users[userID] = u
+	}
+	existing, replaced := u.Queries[q.Name]
+	if !replaced && len(u.Queries) >= MaxScheduledQueries {
+		return false, fmt.Errorf("scheduled query limit reached (%d); delete unused schedules first", MaxScheduledQueries)
+	}
+	q.CreatedAt = time.Now()
+	if replaced {
+		q.CreatedAt = existing.CreatedAt
+	}
+	u.InstanceID = instanceID
+	u.Queries[q.Name] = q
+	st.saveLocked()
+	return replaced, nil
+}
+
+// List returns the user's scheduled queries sorted by name
+func (st *scheduledQueryStore) List(userID string) []ScheduledQuery {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	u := st.users[userID]
+	if u == nil {
+		return []ScheduledQuery{}
+	}
+	queries := make([]ScheduledQuery, 0, len(u.Queries))
+	for _, q := range u.Queries {
+		queries = append(queries, q)
+	}
+	sort.Slice(queries, func(i, j int) bool { return queries[i].Name < queries[j].Name })
+	return queries
+}
+
+// Due returns the scheduled queries of every user whose next run time is not after now
+func (st *scheduledQueryStore) Due(now time.Time) []dueScheduledQuery {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	var due []dueScheduledQuery
+	for userID, u := range st.users {
+		for _, q := range u.Queries {
+			if !now.Before(q.NextRunAt) {
+				due = append(due, dueScheduledQuery{UserID: userID, InstanceID: u.InstanceID, Query: q})
+			}
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if due[i].UserID != due[j].UserID {
+			return due[i].UserID < due[j].UserID
+		}
+		return due[i].Query.Name < due[j].Query.Name
+	})
+	return due
+}
+
+// RecordRun stores the outcome of a run and the next run time. It is a no-op when the
+// schedule was deleted or replaced while it ran.
+func (st *scheduledQueryStore) RecordRun(userID, name string, createdAt time.Time, run ScheduledQuery) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	u := st.users[userID]
+	if u == nil {
+		return
+	}
+	q, ok := u.Queries[name]
+	if !ok || !q.CreatedAt.Equal(createdAt) || q.Schedule != run.Schedule || q.Query != run.Query {
+		return
+	}
+	q.LastRunAt = run.LastRunAt
+	q.LastStatus = run.LastStatus
+	q.LastError = run.LastError
+	q.LastOutput = run.LastOutput
+	q.NextRunAt = run.NextRunAt
+	u.Queries[name] = q
+	st.saveLocked()
+}
+
+// Delete removes one of the user's scheduled queries and reports whether it existed
+func (st *scheduledQueryStore) Delete(userID, name string) bool {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	u := st.users[userID]
+	if u == nil {
+		return false
+	}
+	if _, ok := u.Queries[name]; !ok {
+		return false
+	}
+	delete(u.Queries, name)
+	if len(u.Queries) == 0 {
+		delete(st.users, userID)
+	}
+	st.saveLocked()
+	return true
+}
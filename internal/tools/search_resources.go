@@ -0,0 +1,232 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// maxSearchConcurrency caps how many list requests search_all_resources runs at once
+const maxSearchConcurrency = 3
+
+// searchableResource describes a resource type covered by search_all_resources
+type searchableResource struct {
+	Type     string   // Resource type name used in the types filter
+	Title    string   // Heading for the result group
+	Path     string   // API collection path
+	ListKeys []string // Response keys that may hold the items; the first present one is used
+	GetTool  string   // Tool that fetches a single item
+}
+
+// searchableResources lists the resource types searched, in result order
+var searchableResources = []searchableResource{
+	{Type: "alert", Title: "Alerts", Path: "/v1/alerts", ListKeys: []string{"alerts"}, GetTool: "get_alert"},
+	{Type: "dashboard", Title: "Dashboards", Path: "/v1/dashboards", ListKeys: []string{"items", "dashboards"}, GetTool: "get_dashboard"},
+	{Type: "view", Title: "Views", Path: "/v1/views", ListKeys: []string{"views"}, GetTool: "get_view"},
+	{Type: "policy", Title: "Policies", Path: "/v1/policies", ListKeys: []string{"policies"}, GetTool: "get_policy"},
+	{Type: "e2m", Title: "Events to Metrics", Path: "/v1/events2metrics", ListKeys: []string{"events2metrics"}, GetTool: "get_e2m"},
+}
+
+// resourceSearchResult holds the matches, or the error, for one resource type
+type resourceSearchResult struct {
+	Resource searchableResource
+	Matches  []map[string]interface{}
+	Err      error
+}
+
+// SearchAllResourcesTool finds resources of several types whose name or description matches a keyword
+type SearchAllResourcesTool struct{ *BaseTool }
+
+// NewSearchAllResourcesTool creates a new tool instance
+func NewSearchAllResourcesTool(c client.Doer, l *zap.Logger) *SearchAllResourcesTool {
+	return &SearchAllResourcesTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *SearchAllResourcesTool) Name() string { return "search_all_resources" }
+
+// Annotations returns tool hints for LLMs
+func (t *SearchAllResourcesTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Search All Resources")
+}
+
+// DefaultTimeout returns the timeout
+func (t *SearchAllResourcesTool) DefaultTimeout() time.Duration {
+	return DefaultListTimeout
+}
+
+// Description returns the tool description
+func (t *SearchAllResourcesTool) Description() string {
+	return `Find alerts, dashboards, views, policies, and E2M definitions whose name or description contains a keyword.
+
+Lists the resource types concurrently and returns the matches grouped by type with their IDs,
+instead of calling each list tool separately. If a type fails to list, the other types are still
+returned with a note about the failure.
+
+**When to use:**
+- Onboarding to an unfamiliar instance ("what do we have for checkout?")
+- Finding a resource when you only remember part of its name
+
+**Related tools:** list_alerts, list_dashboards, list_views, list_policies, list_e2m`
+}
+
+// InputSchema returns the input schema
+func (t *SearchAllResourcesTool) InputSchema() interface{} {
+	types := make([]string, 0, len(searchableResources))
+	for _, r := range searchableResources {
+		types = append(types, r.Type)
+	}
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"query": map[string]interface{}{
+				"type":        "string",
+				"description": "Keyword to match against resource names and descriptions (case-insensitive)",
+			},
+			"types": map[string]interface{}{
+				"type":        "array",
+				"description": "Resource types to search (default: all)",
+				"items": map[string]interface{}{
+					"type": "string",
+					"enum": types,
+				},
+			},
+		},
+		"required": []string{"query"},
+	}
+}
+
+// Execute executes the tool
+func (t *SearchAllResourcesTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	query, err := GetStringParam(args, "query", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return NewToolResultError("query must not be empty"), nil
+	}
+
+	types, err := GetStringArrayParam(args, "types", false)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	resources, err := selectSearchableResources(types)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+
+	reqs := make([]*client.Request, len(resources))
+	for i, resource := range resources {
+		reqs[i] = &client.Request{Method: "GET", Path: resource.Path}
+	}
+	results := make([]resourceSearchResult, len(resources))
+	for i, res := range t.ExecuteConcurrent(ctx, reqs, maxSearchConcurrency) {
+		results[i] = resourceSearchResult{Resource: resources[i], Err: res.Err}
+		if res.Err == nil {
+			results[i].Matches = matchResources(listItems(res.Result, resources[i].ListKeys), query)
+		}
+	}
+
+	result := &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: formatResourceSearch(query, results)}},
+	}
+	// Only a total failure is an error; partial failures are noted in the text
+	var firstErr error
+	for _, r := range results {
+		if r.Err == nil {
+			return result, nil
+		}
+		if firstErr == nil {
+			firstErr = r.Err
+		}
+	}
+	return withErrorCode(result, ClassifyError(firstErr)), nil
+}
+
+// selectSearchableResources returns the resources named in types, or all of them when types is empty
+func selectSearchableResources(types []string) ([]searchableResource, error) {
+	if len(types) == 0 {
+		return searchableResources, nil
+	}
+	var selected []searchableResource
+	for _, name := range types {
+		found := false
+		for _, r := range searchableResources {
+			if r.Type == name {
+				selected = append(selected, r)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unsupported resource type '%s'. Valid types: alert, dashboard, view, policy, e2m", name)
+		}
+	}
+	return selected, nil
+}
+
+// listItems returns the items under the first present list key
+func listItems(res map[string]interface{}, keys []string) []interface{} {
+	for _, key := range keys {
+		if items, ok := res[key].([]interface{}); ok {
+			return items
+		}
+	}
+	return nil
+}
+
+// matchResources returns the items whose name or description contains query, case-insensitively
+func matchResources(items []interface{}, query string) []map[string]interface{} {
+	query = strings.ToLower(query)
+	var matches []map[string]interface{}
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, field := range []string{"name", "description"} {
+			if v, ok := m[field].(string); ok && strings.Contains(strings.ToLower(v), query) {
+				matches = append(matches, m)
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// formatResourceSearch renders the matches grouped by resource type
+func formatResourceSearch(query string, results []resourceSearchResult) string {
+	total := 0
+	for _, r := range results {
+		total += len(r.Matches)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## Resources matching %q (%d found)\n", query, total)
+	for _, r := range results {
+		fmt.Fprintf(&sb, "\n### %s", r.Resource.Title)
+		if r.Err != nil {
+			fmt.Fprintf(&sb, "\n\n⚠️ Could not list %s: %v\n", strings.ToLower(r.Resource.Title), r.Err)
+			continue
+		}
+		fmt.Fprintf(&sb, " (%d)\n\n", len(r.Matches))
+		if len(r.Matches) == 0 {
+			sb.WriteString("No matches.\n")
+			continue
+		}
+		for _, m := range r.Matches {
+			name, _ := m["name"].(string)
+			id, _ := m["id"].(string)
+			fmt.Fprintf(&sb, "- %s (ID: %s)\n", name, id)
+		}
+		fmt.Fprintf(&sb, "\n💡 Use `%s` for full details.\n", r.Resource.GetTool)
+	}
+	return sb.String()
+}
@@ -0,0 +1,113 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// searchMock returns a mock client that serves list responses by path; paths without a
+// body fail with a 500
+func searchMock(bodies map[string]interface{}) *client.MockClient {
+	mock := client.NewMockClient()
+	mock.DoFunc = func(_ context.Context, req *client.Request) (*client.Response, error) {
+		body, ok := bodies[req.Path]
+		if !ok {
+			return &client.Response{StatusCode: 500, Body: []byte(`{"message":"boom"}`)}, nil
+		}
+		data, _ := json.Marshal(body)
+		return &client.Response{StatusCode: 200, Body: data}, nil
+	}
+	return mock
+}
+
+func TestSearchAllResourcesTool_Execute(t *testing.T) {
+	mock := searchMock(map[string]interface{}{
+		"/v1/alerts": map[string]interface{}{"alerts": []interface{}{
+			map[string]interface{}{"id": "a1", "name": "Checkout errors"},
+			map[string]interface{}{"id": "a2", "name": "CPU high"},
+		}},
+		"/v1/dashboards": map[string]interface{}{"items": []interface{}{
+			map[string]interface{}{"id": "d1", "name": "Payments", "description": "Checkout funnel"},
+		}},
+		"/v1/views":          map[string]interface{}{"views": []interface{}{}},
+		"/v1/events2metrics": map[string]interface{}{"events2metrics": []interface{}{}},
+		// /v1/policies is left out so it fails
+	})
+
+	tool := NewSearchAllResourcesTool(mock, zap.NewNop())
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{"query": "CHECKOUT"})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	if result.IsError {
+		t.Fatal("partial failure should not be an error result")
+	}
+
+	text := result.Content[0].(*mcp.TextContent).Text
+	for _, want := range []string{
+		`Resources matching "CHECKOUT" (2 found)`,
+		"- Checkout errors (ID: a1)",
+		"- Payments (ID: d1)",
+		"### Views (0)",
+		"⚠️ Could not list policies",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("result missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "CPU high") {
+		t.Errorf("non-matching alert should not be listed:\n%s", text)
+	}
+	if got := mock.RequestCount(); got != len(searchableResources) {
+		t.Errorf("RequestCount = %d, want %d", got, len(searchableResources))
+	}
+}
+
+func TestSearchAllResourcesTool_TypesFilter(t *testing.T) {
+	mock := searchMock(map[string]interface{}{
+		"/v1/views": map[string]interface{}{"views": []interface{}{
+			map[string]interface{}{"id": "v1", "name": "checkout logs"},
+		}},
+	})
+
+	tool := NewSearchAllResourcesTool(mock, zap.NewNop())
+	result, _ := tool.Execute(testCtx(mock), map[string]interface{}{
+		"query": "checkout",
+		"types": []interface{}{"view"},
+	})
+	text := result.Content[0].(*mcp.TextContent).Text
+	if result.IsError || !strings.Contains(text, "- checkout logs (ID: v1)") {
+		t.Errorf("unexpected result:\n%s", text)
+	}
+	if mock.RequestCount() != 1 || mock.LastRequest().Path != "/v1/views" {
+		t.Errorf("expected a single request to /v1/views, got %d", mock.RequestCount())
+	}
+
+	result, _ = tool.Execute(testCtx(mock), map[string]interface{}{
+		"query": "checkout",
+		"types": []interface{}{"widget"},
+	})
+	if !result.IsError {
+		t.Error("expected error for unsupported type")
+	}
+}
+
+func TestSearchAllResourcesTool_AllFail(t *testing.T) {
+	mock := searchMock(nil)
+
+	tool := NewSearchAllResourcesTool(mock, zap.NewNop())
+	result, _ := tool.Execute(testCtx(mock), map[string]interface{}{"query": "checkout"})
+	if !result.IsError {
+		t.Fatal("expected error result when every type fails")
+	}
+	if result.Meta["error_code"] == nil {
+		t.Error("expected error_code metadata")
+	}
+}
@@ -189,6 +189,15 @@ func (t *ServiceAwareTool) executeViaClient(
 
 // ExecuteQuery executes a log query using the service layer
 func (t *ServiceAwareTool) ExecuteQuery(ctx context.Context, req *service.QueryRequest) (*service.QueryResponse, error) {
+	// The service layer calls the client directly, so apply the application scope first
+	scoped, err := currentApplicationScope().ScopeQuery(req.Query, req.Syntax)
+	if err != nil {
+		return nil, err
+	}
+	scopedReq := *req
+	scopedReq.Query = scoped
+	req = &scopedReq
+
 	svc := t.GetService()
 	if svc == nil {
 		// Fall back to direct client execution
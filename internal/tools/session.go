@@ -6,11 +6,16 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"go.uber.org/zap"
 )
 
 // validUserIDPattern matches valid user IDs (16 hex characters from SHA256 hash)
@@ -39,6 +44,9 @@ type SessionContext struct {
 	// LastQueryTime when the last query was executed
 	LastQueryTime time.Time `json:"last_query_time,omitempty"`
 
+	// LastQueryArgs stores the query_logs arguments of the most recent query so it can be replayed
+	LastQueryArgs map[string]interface{} `json:"last_query_args,omitempty"`
+
 	// LastResults caches recent tool results (limited to prevent memory bloat)
 	LastResults map[string]interface{} `json:"last_results,omitempty"`
 
@@ -59,6 +67,15 @@ type SessionContext struct {
 
 	// TCOConfig holds TCO policy configuration discovered at session start
 	TCOConfig *TCOConfig `json:"tco_config,omitempty"`
+
+	// BackgroundQueries tracks background queries submitted in this session, oldest first
+	BackgroundQueries []BackgroundQueryRecord `json:"background_queries,omitempty"`
+
+	// SavedQueries is the user's named query library (save_query), kept when the session is cleared
+	SavedQueries map[string]SavedQuery `json:"saved_queries,omitempty"`
+
+	// onChange is invoked (with mu held) after each mutation when persistence is enabled
+	onChange func()
 }
 
 // LearnedPatterns stores patterns that persist across sessions
@@ -72,6 +89,12 @@ type LearnedPatterns struct {
 	// CommonFilters are filters the user applies repeatedly
 	CommonFilters map[string]string `json:"common_filters,omitempty"`
 
+	// ToolFeedback tallies record_feedback votes per tool
+	ToolFeedback map[string]FeedbackTally `json:"tool_feedback,omitempty"`
+
+	// Feedback are the most recent record_feedback entries, oldest first
+	Feedback []FeedbackEntry `json:"feedback,omitempty"`
+
 	// TotalToolCalls lifetime tool call count
 	TotalToolCalls int `json:"total_tool_calls"`
 
@@ -85,6 +108,9 @@ type PatternSequence struct {
 	Count       int       `json:"count"`
 	SuccessRate float64   `json:"success_rate"`
 	LastUsed    time.Time `json:"last_used"`
+
+	// Weight is the time-decayed observation count as of LastUsed (see DecayedWeight)
+	Weight float64 `json:"weight,omitempty"`
 }
 
 // TCOConfig holds Total Cost of Ownership policy configuration.
@@ -197,11 +223,20 @@ type UserPreferences struct {
 	PreferredLimit int `json:"preferred_limit,omitempty"`
 }
 
+// DefaultSessionSaveDebounce is how long a session must be idle after a mutation before it is saved
+const DefaultSessionSaveDebounce = 2 * time.Second
+
 // SessionManager manages user-specific sessions with persistence
 type SessionManager struct {
 	mu       sync.RWMutex
 	sessions map[string]*SessionContext // keyed by userID
 	dataDir  string                     // directory for persistence
+	logger   *zap.Logger
+
+	// debounce enables saving on mutation when > 0; pending saves are keyed by userID
+	debounce     time.Duration
+	saveTimersMu sync.Mutex
+	saveTimers   map[string]*time.Timer
 }
 
 // Global session manager (singleton for MCP server lifecycle)
@@ -209,28 +244,120 @@ var (
 	globalSessionManager     *SessionManager
 	globalSessionManagerOnce sync.Once
 	currentUserID            string // set during initialization
+	statelessMode            atomic.Bool
 )
 
-// GetSessionManager returns the global session manager
+// SetStatelessMode turns stateless operation on or off. In stateless mode GetSession returns
+// a fresh, unregistered session on every call, so nothing carries over between requests
+// and nothing is persisted.
+func SetStatelessMode(enabled bool) {
+	statelessMode.Store(enabled)
+}
+
+// IsStatelessMode reports whether the server runs without session state
+func IsStatelessMode() bool {
+	return statelessMode.Load()
+}
+
+// GetSessionManager returns the global session manager.
+// Persistence is disabled until EnableSessionPersistence is called.
 func GetSessionManager() *SessionManager {
 	globalSessionManagerOnce.Do(func() {
-		globalSessionManager = NewSessionManager("")
+		globalSessionManager = &SessionManager{
+			sessions:   make(map[string]*SessionContext),
+			logger:     zap.NewNop(),
+			saveTimers: make(map[string]*time.Timer),
+		}
 	})
 	return globalSessionManager
 }
 
+// EnableSessionPersistence turns on disk persistence for the global session manager.
+// Sessions are loaded from dataDir (default: ~/.logs-mcp/sessions) and saved after each
+// mutation once the session has been idle for the debounce interval.
+// Must be called before the current user is set so the existing session is reloaded.
+func EnableSessionPersistence(dataDir string, debounce time.Duration, logger *zap.Logger) {
+	m := GetSessionManager()
+	if dataDir == "" {
+		dataDir = defaultSessionDir()
+	}
+	if debounce <= 0 {
+		debounce = DefaultSessionSaveDebounce
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.dataDir = dataDir
+	m.debounce = debounce
+	if logger != nil {
+		m.logger = logger
+	}
+	for userID, session := range m.sessions {
+		m.watchSession(userID, session)
+	}
+}
+
+// defaultSessionDir returns the default session persistence directory
+func defaultSessionDir() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".logs-mcp", "sessions")
+}
+
 // NewSessionManager creates a new session manager
 func NewSessionManager(dataDir string) *SessionManager {
 	if dataDir == "" {
 		// Default to user's config directory
-		homeDir, err := os.UserHomeDir()
-		if err == nil {
-			dataDir = filepath.Join(homeDir, ".logs-mcp", "sessions")
-		}
+		dataDir = defaultSessionDir()
 	}
 	return &SessionManager{
-		sessions: make(map[string]*SessionContext),
-		dataDir:  dataDir,
+		sessions:   make(map[string]*SessionContext),
+		dataDir:    dataDir,
+		logger:     zap.NewNop(),
+		saveTimers: make(map[string]*time.Timer),
+	}
+}
+
+// watchSession registers a debounced save for every mutation of the session.
+// Caller must hold m.mu.
+func (m *SessionManager) watchSession(userID string, session *SessionContext) {
+	if m.debounce <= 0 || m.dataDir == "" {
+		return
+	}
+	session.mu.Lock()
+	session.onChange = func() { m.scheduleSave(userID) }
+	session.mu.Unlock()
+}
+
+// scheduleSave saves the session once no further mutations arrive within the debounce interval
+func (m *SessionManager) scheduleSave(userID string) {
+	m.saveTimersMu.Lock()
+	defer m.saveTimersMu.Unlock()
+
+	if timer, ok := m.saveTimers[userID]; ok {
+		timer.Reset(m.debounce)
+		return
+	}
+	m.saveTimers[userID] = time.AfterFunc(m.debounce, func() {
+		m.saveTimersMu.Lock()
+		delete(m.saveTimers, userID)
+		m.saveTimersMu.Unlock()
+
+		if err := m.SaveSession(userID); err != nil {
+			m.logger.Warn("Failed to save session", zap.String("user_id", userID), zap.Error(err))
+		}
+	})
+}
+
+// stopPendingSave cancels a scheduled save for the user, if any
+func (m *SessionManager) stopPendingSave(userID string) {
+	m.saveTimersMu.Lock()
+	defer m.saveTimersMu.Unlock()
+	if timer, ok := m.saveTimers[userID]; ok {
+		timer.Stop()
+		delete(m.saveTimers, userID)
 	}
 }
 
@@ -267,18 +394,33 @@ func GenerateUserID(apiKey, instanceID string) string {
 	return hex.EncodeToString(h.Sum(nil))[:16]
 }
 
-// GetSession returns the session for the current user (backward compatible)
+// GetSession returns the session for the current user (backward compatible).
+// It never returns nil: in stateless mode, or if no session can be resolved, it returns
+// an empty ephemeral session so callers degrade to "no context" instead of crashing.
 func GetSession() *SessionContext {
+	if IsStatelessMode() {
+		return NewSessionContext("", "stateless")
+	}
+	var session *SessionContext
 	if currentUserID != "" {
-		return GetSessionManager().GetSessionByID(currentUserID)
+		session = GetSessionManager().GetSessionByID(currentUserID)
+	} else {
+		// Fallback: return a default session if no user set
+		session = GetSessionManager().GetOrCreateSession("", "default")
+	}
+	if session == nil {
+		return NewSessionContext(currentUserID, "")
 	}
-	// Fallback: return a default session if no user set
-	return GetSessionManager().GetOrCreateSession("", "default")
+	return session
 }
 
 // GetSession implements SessionProvider by returning the session for the
 // current user, or the first available session if no current user is set.
+// It returns nil when no session is loaded or the server runs stateless.
 func (m *SessionManager) GetSession() *SessionContext {
+	if IsStatelessMode() {
+		return nil
+	}
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
@@ -313,6 +455,7 @@ func (m *SessionManager) GetOrCreateSessionByID(userID, instanceID string) *Sess
 		session = NewSessionContext(userID, instanceID)
 	}
 
+	m.watchSession(userID, session)
 	m.sessions[userID] = session
 	return session
 }
@@ -353,6 +496,7 @@ func (m *SessionManager) GetSessionByID(userID string) *SessionContext {
 		}
 	}
 
+	m.watchSession(userID, session)
 	m.sessions[userID] = session
 	return session
 }
@@ -383,6 +527,15 @@ func (m *SessionManager) loadSession(userID string) *SessionContext {
 
 	var session SessionContext
 	if err := json.Unmarshal(data, &session); err != nil {
+		// Keep the corrupt file for inspection and start fresh
+		backupPath := filePath + ".corrupt"
+		renameErr := os.Rename(filePath, backupPath)
+		m.logger.Warn("Session file is corrupt, starting a fresh session",
+			zap.String("path", filePath),
+			zap.String("backup_path", backupPath),
+			zap.Error(err),
+			zap.NamedError("backup_error", renameErr),
+		)
 		return nil
 	}
 
@@ -404,6 +557,8 @@ func (m *SessionManager) loadSession(userID string) *SessionContext {
 			CommonFilters: make(map[string]string),
 		}
 	}
+	// Sequences unused while the server was down may have decayed away
+	session.pruneLearnedSequences(time.Now())
 
 	return &session
 }
@@ -427,6 +582,9 @@ func (m *SessionManager) SaveSession(userID string) error {
 		return nil // Persistence disabled
 	}
 
+	// An explicit save supersedes any pending debounced save
+	m.stopPendingSave(userID)
+
 	// Ensure directory exists
 	if err := os.MkdirAll(m.dataDir, 0700); err != nil {
 		return err
@@ -441,13 +599,18 @@ func (m *SessionManager) SaveSession(userID string) error {
 		return err
 	}
 
+	// Write to a temp file and rename so a crash mid-write never leaves a corrupt session
 	filePath := filepath.Join(m.dataDir, userID+".json")
-	return os.WriteFile(filePath, data, 0600)
+	tmpPath := filePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, filePath)
 }
 
 // SaveCurrentSession saves the current user's session
 func SaveCurrentSession() error {
-	if currentUserID == "" {
+	if currentUserID == "" || IsStatelessMode() {
 		return nil
 	}
 	return GetSessionManager().SaveSession(currentUserID)
@@ -483,12 +646,69 @@ func NewSessionContext(userID, instanceID string) *SessionContext {
 	}
 }
 
+// notifyChange signals a mutation to the persistence layer. Caller must hold s.mu.
+func (s *SessionContext) notifyChange() {
+	if s.onChange != nil {
+		s.onChange()
+	}
+}
+
+// MaxTrackedBackgroundQueries caps how many background queries a session remembers
+const MaxTrackedBackgroundQueries = 50
+
+// BackgroundQueryRecord describes a background query submitted through this server
+type BackgroundQueryRecord struct {
+	QueryID     string    `json:"query_id"`
+	Query       string    `json:"query"`
+	SubmittedAt time.Time `json:"submitted_at"`
+	Status      string    `json:"status,omitempty"` // last known status: running, completed, failed, cancelled
+}
+
+// TrackBackgroundQuery records a submitted background query
+func (s *SessionContext) TrackBackgroundQuery(queryID, query string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.BackgroundQueries = append(s.BackgroundQueries, BackgroundQueryRecord{
+		QueryID:     queryID,
+		Query:       query,
+		SubmittedAt: time.Now(),
+		Status:      "running",
+	})
+	if len(s.BackgroundQueries) > MaxTrackedBackgroundQueries {
+		s.BackgroundQueries = s.BackgroundQueries[len(s.BackgroundQueries)-MaxTrackedBackgroundQueries:]
+	}
+	s.UpdatedAt = time.Now()
+	s.notifyChange()
+}
+
+// GetBackgroundQueries returns a copy of the tracked background queries, oldest first
+func (s *SessionContext) GetBackgroundQueries() []BackgroundQueryRecord {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]BackgroundQueryRecord(nil), s.BackgroundQueries...)
+}
+
+// SetBackgroundQueryStatus updates the last known status of a tracked background query
+func (s *SessionContext) SetBackgroundQueryStatus(queryID, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.BackgroundQueries {
+		if s.BackgroundQueries[i].QueryID == queryID && s.BackgroundQueries[i].Status != status {
+			s.BackgroundQueries[i].Status = status
+			s.UpdatedAt = time.Now()
+			s.notifyChange()
+			return
+		}
+	}
+}
+
 // SetLastQuery records the last executed query
 func (s *SessionContext) SetLastQuery(query string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.LastQuery = query
 	s.LastQueryTime = time.Now()
+	s.notifyChange()
 }
 
 // GetLastQuery returns the last executed query
@@ -498,11 +718,106 @@ func (s *SessionContext) GetLastQuery() string {
 	return s.LastQuery
 }
 
+// SetLastQueryArgs records the query_logs arguments of the last executed query
+func (s *SessionContext) SetLastQueryArgs(args map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.LastQueryArgs = make(map[string]interface{}, len(args))
+	for k, v := range args {
+		s.LastQueryArgs[k] = v
+	}
+	s.notifyChange()
+}
+
+// GetLastQueryArgs returns a copy of the last query_logs arguments, or nil if none were recorded
+func (s *SessionContext) GetLastQueryArgs() map[string]interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.LastQueryArgs == nil {
+		return nil
+	}
+	args := make(map[string]interface{}, len(s.LastQueryArgs))
+	for k, v := range s.LastQueryArgs {
+		args[k] = v
+	}
+	return args
+}
+
+// MaxSavedQueries caps how many named queries a user can save
+const MaxSavedQueries = 200
+
+// SavedQuery is a named query snippet stored locally by save_query (not a Cloud Logs view)
+type SavedQuery struct {
+	Name        string    `json:"name"`
+	Query       string    `json:"query"`
+	Syntax      string    `json:"syntax,omitempty"`
+	Tier        string    `json:"tier,omitempty"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// SaveQuery stores q under its name, replacing any query of the same name.
+// It reports whether an existing query was replaced.
+func (s *SessionContext) SaveQuery(q SavedQuery) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.SavedQueries == nil {
+		s.SavedQueries = make(map[string]SavedQuery)
+	}
+	now := time.Now()
+	existing, replaced := s.SavedQueries[q.Name]
+	if !replaced && len(s.SavedQueries) >= MaxSavedQueries {
+		return false, fmt.Errorf("saved query limit reached (%d); delete unused queries first", MaxSavedQueries)
+	}
+	q.CreatedAt = now
+	if replaced {
+		q.CreatedAt = existing.CreatedAt
+	}
+	q.UpdatedAt = now
+	s.SavedQueries[q.Name] = q
+	s.notifyChange()
+	return replaced, nil
+}
+
+// GetSavedQuery returns the saved query with the given name
+func (s *SessionContext) GetSavedQuery(name string) (SavedQuery, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	q, ok := s.SavedQueries[name]
+	return q, ok
+}
+
+// ListSavedQueries returns the saved queries sorted by name
+func (s *SessionContext) ListSavedQueries() []SavedQuery {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	queries := make([]SavedQuery, 0, len(s.SavedQueries))
+	for _, q := range s.SavedQueries {
+		queries = append(queries, q)
+	}
+	sort.Slice(queries, func(i, j int) bool { return queries[i].Name < queries[j].Name })
+	return queries
+}
+
+// DeleteSavedQuery removes a saved query and reports whether it existed
+func (s *SessionContext) DeleteSavedQuery(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.SavedQueries[name]; !ok {
+		return false
+	}
+	delete(s.SavedQueries, name)
+	s.notifyChange()
+	return true
+}
+
 // SetFilter sets a persistent filter
 func (s *SessionContext) SetFilter(key, value string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.ActiveFilters[key] = value
+	s.notifyChange()
 }
 
 // GetFilter retrieves a persistent filter
@@ -528,6 +843,7 @@ func (s *SessionContext) ClearFilters() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.ActiveFilters = make(map[string]string)
+	s.notifyChange()
 }
 
 // ClearSession resets all session state while preserving user identity
@@ -543,6 +859,7 @@ func (s *SessionContext) ClearSession() {
 	// Reset all state
 	s.LastQuery = ""
 	s.LastQueryTime = time.Time{}
+	s.LastQueryArgs = nil
 	s.LastResults = make(map[string]interface{})
 	s.ActiveFilters = make(map[string]string)
 	s.InvestigationContext = nil
@@ -557,6 +874,7 @@ func (s *SessionContext) ClearSession() {
 	s.InstanceID = instanceID
 	s.CreatedAt = createdAt
 	s.UpdatedAt = time.Now()
+	s.notifyChange()
 }
 
 // CacheResult stores a tool result for later reference
@@ -618,6 +936,7 @@ func (s *SessionContext) RecordToolUse(toolName string, success bool, args map[s
 
 	// Mark session as updated
 	s.UpdatedAt = time.Now()
+	s.notifyChange()
 }
 
 // updateLearnedPatterns updates persistent learned patterns
@@ -659,58 +978,39 @@ func (s *SessionContext) updateLearnedPatterns(_ string, success bool) {
 
 // recordSequence records or updates a tool sequence pattern
 func (s *SessionContext) recordSequence(tools []string, success bool) {
+	now := time.Now()
+	halfLife, _ := chainLearningSettings()
+	successVal := 0.0
+	if success {
+		successVal = 100.0
+	}
+
 	// Find existing sequence
 	for i, seq := range s.LearnedPatterns.FrequentSequences {
-		if len(seq.Tools) == len(tools) {
-			match := true
-			for j, t := range tools {
-				if seq.Tools[j] != t {
-					match = false
-					break
-				}
-			}
-			if match {
-				// Update existing sequence
-				s.LearnedPatterns.FrequentSequences[i].Count++
-				s.LearnedPatterns.FrequentSequences[i].LastUsed = time.Now()
-				// Update success rate (rolling average)
-				oldRate := s.LearnedPatterns.FrequentSequences[i].SuccessRate
-				count := float64(s.LearnedPatterns.FrequentSequences[i].Count)
-				successVal := 0.0
-				if success {
-					successVal = 100.0
-				}
-				s.LearnedPatterns.FrequentSequences[i].SuccessRate = ((oldRate * (count - 1)) + successVal) / count
-				return
-			}
+		if sameToolSequence(seq.Tools, tools) {
+			// Update existing sequence, decaying its weight up to now before adding this use
+			existing := &s.LearnedPatterns.FrequentSequences[i]
+			existing.Weight = existing.DecayedWeight(now, halfLife) + 1
+			existing.Count++
+			existing.LastUsed = now
+			// Update success rate (rolling average)
+			count := float64(existing.Count)
+			existing.SuccessRate = ((existing.SuccessRate * (count - 1)) + successVal) / count
+			return
 		}
 	}
 
 	// Add new sequence
-	successRate := 0.0
-	if success {
-		successRate = 100.0
-	}
 	s.LearnedPatterns.FrequentSequences = append(s.LearnedPatterns.FrequentSequences, PatternSequence{
 		Tools:       tools,
 		Count:       1,
-		SuccessRate: successRate,
-		LastUsed:    time.Now(),
+		SuccessRate: successVal,
+		LastUsed:    now,
+		Weight:      1,
 	})
 
-	// Keep only top 20 sequences by count
-	if len(s.LearnedPatterns.FrequentSequences) > 20 {
-		// Sort by count descending
-		seqs := s.LearnedPatterns.FrequentSequences
-		for i := 0; i < len(seqs); i++ {
-			for j := i + 1; j < len(seqs); j++ {
-				if seqs[j].Count > seqs[i].Count {
-					seqs[i], seqs[j] = seqs[j], seqs[i]
-				}
-			}
-		}
-		s.LearnedPatterns.FrequentSequences = seqs[:20]
-	}
+	// Forget stale sequences and keep the heaviest
+	s.pruneLearnedSequences(now)
 }
 
 // learnPreferences updates user preferences based on tool usage
@@ -780,6 +1080,7 @@ func (s *SessionContext) StartInvestigation(application, timeRange string) strin
 		Findings:    []Finding{},
 		ToolsUsed:   []string{},
 	}
+	s.notifyChange()
 
 	return id
 }
@@ -803,6 +1104,7 @@ func (s *SessionContext) AddFinding(tool, description, severity, evidence string
 
 	// Track tool usage
 	s.InvestigationContext.ToolsUsed = append(s.InvestigationContext.ToolsUsed, tool)
+	s.notifyChange()
 }
 
 // SetHypothesis sets the current working hypothesis
@@ -813,6 +1115,7 @@ func (s *SessionContext) SetHypothesis(hypothesis string) {
 	if s.InvestigationContext != nil {
 		s.InvestigationContext.Hypothesis = hypothesis
 	}
+	s.notifyChange()
 }
 
 // GetInvestigation returns the current investigation context
@@ -829,6 +1132,7 @@ func (s *SessionContext) EndInvestigation() *InvestigationContext {
 
 	inv := s.InvestigationContext
 	s.InvestigationContext = nil
+	s.notifyChange()
 	return inv
 }
 
@@ -1208,6 +1512,7 @@ func (s *SessionContext) SetTCOConfig(config *TCOConfig) {
 	defer s.mu.Unlock()
 	s.TCOConfig = config
 	s.UpdatedAt = time.Now()
+	s.notifyChange()
 }
 
 // GetDefaultTier returns the recommended default tier based on TCO policies.
@@ -490,3 +490,51 @@ func TestListSessions(t *testing.T) {
 		t.Errorf("Expected 3 sessions, got %d", len(sessions))
 	}
 }
+
+func TestSessionManager_DebouncedSave(t *testing.T) {
+	tmpDir := t.TempDir()
+	manager := NewSessionManager(tmpDir)
+	manager.debounce = 20 * time.Millisecond
+
+	userID := GenerateUserID("debounce-key", "instance")
+	session := manager.GetOrCreateSession("debounce-key", "instance")
+	session.SetFilter("app", "checkout")
+	session.StartInvestigation("checkout", "1h")
+
+	filePath := filepath.Join(tmpDir, userID+".json")
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, err := os.Stat(filePath); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Session was not saved after mutation")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	reloaded := NewSessionManager(tmpDir).GetOrCreateSession("debounce-key", "instance")
+	if reloaded.GetFilter("app") != "checkout" {
+		t.Errorf("Filter not persisted: %q", reloaded.GetFilter("app"))
+	}
+	if reloaded.GetInvestigation() == nil {
+		t.Error("Investigation not persisted")
+	}
+}
+
+func TestSessionManager_CorruptFileStartsFresh(t *testing.T) {
+	tmpDir := t.TempDir()
+	userID := GenerateUserID("corrupt-key", "instance")
+	filePath := filepath.Join(tmpDir, userID+".json")
+	if err := os.WriteFile(filePath, []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	session := NewSessionManager(tmpDir).GetOrCreateSession("corrupt-key", "instance")
+	if session == nil || session.GetLastQuery() != "" {
+		t.Fatal("Expected a fresh session when the file is corrupt")
+	}
+	if _, err := os.Stat(filePath + ".corrupt"); err != nil {
+		t.Errorf("Corrupt file should be kept as a backup: %v", err)
+	}
+}
@@ -118,7 +118,7 @@ func (t *SessionContextTool) Annotations() *mcp.ToolAnnotations {
 func (t *SessionContextTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	action, err := GetStringParam(args, "action", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	session := GetSessionFromContext(ctx)
@@ -229,7 +229,7 @@ func (t *SessionContextTool) Execute(ctx context.Context, args map[string]interf
 		session.ClearSession()
 		return t.formatResult(map[string]interface{}{
 			"status":  "session_cleared",
-			"message": "Session has been cleared. All filters, context, and learned preferences reset.",
+			"message": "Session has been cleared. All filters, context, and learned preferences reset. Saved queries are kept.",
 		})
 
 	default:
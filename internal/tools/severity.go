@@ -0,0 +1,114 @@
+package tools
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Log severity levels as used by IBM Cloud Logs
+const (
+	LogSeverityDebug    = 1
+	LogSeverityVerbose  = 2
+	LogSeverityInfo     = 3
+	LogSeverityWarning  = 4
+	LogSeverityError    = 5
+	LogSeverityCritical = 6
+)
+
+// severityNames maps log severity levels to their display names
+var severityNames = map[int]string{
+	LogSeverityDebug:    "Debug",
+	LogSeverityVerbose:  "Verbose",
+	LogSeverityInfo:     "Info",
+	LogSeverityWarning:  "Warning",
+	LogSeverityError:    "Error",
+	LogSeverityCritical: "Critical",
+}
+
+// severityByName maps lowercase severity names and common synonyms to levels
+var severityByName = map[string]int{
+	"debug":         LogSeverityDebug,
+	"trace":         LogSeverityDebug,
+	"verbose":       LogSeverityVerbose,
+	"info":          LogSeverityInfo,
+	"information":   LogSeverityInfo,
+	"informational": LogSeverityInfo,
+	"notice":        LogSeverityInfo,
+	"warning":       LogSeverityWarning,
+	"warn":          LogSeverityWarning,
+	"error":         LogSeverityError,
+	"err":           LogSeverityError,
+	"critical":      LogSeverityCritical,
+	"crit":          LogSeverityCritical,
+	"fatal":         LogSeverityCritical,
+	"emergency":     LogSeverityCritical,
+	"alert":         LogSeverityCritical,
+}
+
+// NormalizeSeverity converts a severity given as a number (1-6), a numeric string, or a name in
+// any case (including synonyms such as "warn" and "err") to its level. ok is false when the value
+// is not a recognizable severity.
+func NormalizeSeverity(raw interface{}) (level int, ok bool) {
+	switch v := raw.(type) {
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, false
+		}
+		level = int(v)
+	case int:
+		level = v
+	case int64:
+		level = int(v)
+	case string:
+		s := strings.ToLower(strings.TrimSpace(v))
+		if named, found := severityByName[s]; found {
+			return named, true
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, false
+		}
+		level = n
+	default:
+		return 0, false
+	}
+	if level < LogSeverityDebug || level > LogSeverityCritical {
+		return 0, false
+	}
+	return level, true
+}
+
+// SeverityName returns the display name for a severity level
+func SeverityName(level int) string {
+	if name, ok := severityNames[level]; ok {
+		return name
+	}
+	return fmt.Sprintf("Level %d", level)
+}
+
+// eventSeverity reads and normalizes a log event's severity. It checks the top-level field
+// (flattened entries), then labels and metadata, which may be maps or key/value arrays.
+func eventSeverity(eventMap map[string]interface{}) (int, bool) {
+	if level, ok := NormalizeSeverity(eventMap["severity"]); ok {
+		return level, true
+	}
+	for _, section := range []string{"metadata", "labels"} {
+		switch v := eventMap[section].(type) {
+		case map[string]interface{}:
+			if level, ok := NormalizeSeverity(v["severity"]); ok {
+				return level, true
+			}
+		case []interface{}:
+			for _, item := range v {
+				if kv, ok := item.(map[string]interface{}); ok && kv["key"] == "severity" {
+					if level, ok := NormalizeSeverity(kv["value"]); ok {
+						return level, true
+					}
+				}
+			}
+		}
+	}
+	return 0, false
+}
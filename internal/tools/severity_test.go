@@ -0,0 +1,87 @@
+package tools
+
+import "testing"
+
+func TestNormalizeSeverity(t *testing.T) {
+	tests := []struct {
+		input interface{}
+		want  int
+		ok    bool
+	}{
+		{float64(5), LogSeverityError, true},
+		{4, LogSeverityWarning, true},
+		{int64(6), LogSeverityCritical, true},
+		{"3", LogSeverityInfo, true},
+		{" 2 ", LogSeverityVerbose, true},
+		{"ERROR", LogSeverityError, true},
+		{"Error", LogSeverityError, true},
+		{"err", LogSeverityError, true},
+		{"WARN", LogSeverityWarning, true},
+		{"warning", LogSeverityWarning, true},
+		{"fatal", LogSeverityCritical, true},
+		{"debug", LogSeverityDebug, true},
+		{float64(0), 0, false},
+		{float64(7), 0, false},
+		{float64(4.5), 0, false},
+		{"loud", 0, false},
+		{nil, 0, false},
+		{true, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := NormalizeSeverity(tt.input)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("NormalizeSeverity(%#v) = %d, %v; want %d, %v", tt.input, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestAnalyzeSeverityDistribution_MixedFormats(t *testing.T) {
+	// The shapes seen across raw, SSE-flattened, and legacy entries
+	events := []interface{}{
+		map[string]interface{}{"severity": float64(5)},
+		map[string]interface{}{"severity": "5"},
+		map[string]interface{}{"severity": "ERROR"},
+		map[string]interface{}{"severity": "error"},
+		map[string]interface{}{"severity": "Warning"},
+		map[string]interface{}{"severity": "warn"},
+		map[string]interface{}{"metadata": map[string]interface{}{"severity": float64(6)}},
+		map[string]interface{}{"labels": map[string]interface{}{"app": "x"}, "metadata": map[string]interface{}{"severity": "critical"}},
+		map[string]interface{}{"metadata": []interface{}{
+			map[string]interface{}{"key": "severity", "value": "3"},
+		}},
+		map[string]interface{}{"message": "no severity"},
+	}
+
+	dist := analyzeSeverityDistribution(events)
+	want := map[string]int{"Error": 4, "Warning": 2, "Critical": 2, "Info": 1}
+	if len(dist) != len(want) {
+		t.Errorf("distribution = %v, want %v", dist, want)
+	}
+	for name, count := range want {
+		if dist[name] != count {
+			t.Errorf("dist[%s] = %d, want %d (full: %v)", name, dist[name], count, dist)
+		}
+	}
+
+	if got := countSeverityAbove(events, LogSeverityError); got != 6 {
+		t.Errorf("countSeverityAbove(error) = %d, want 6", got)
+	}
+}
+
+func TestTransformLogEntry_SeverityFormats(t *testing.T) {
+	tests := []struct {
+		severity interface{}
+		want     interface{}
+	}{
+		{"5", "Error"},
+		{float64(4), "Warning"},
+		{"CRITICAL", "Critical"},
+		{"custom", "custom"}, // unrecognized values pass through
+	}
+	for _, tt := range tests {
+		compact := transformLogEntry(map[string]interface{}{"severity": tt.severity})
+		if compact["severity"] != tt.want {
+			t.Errorf("transformLogEntry severity %#v = %v, want %v", tt.severity, compact["severity"], tt.want)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// CreateSLOBurnAlertTool creates multi-window burn rate alerts from an SLO specification
+type CreateSLOBurnAlertTool struct{ *BaseTool }
+
+// NewCreateSLOBurnAlertTool creates a new tool instance
+func NewCreateSLOBurnAlertTool(c client.Doer, l *zap.Logger) *CreateSLOBurnAlertTool {
+	return &CreateSLOBurnAlertTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *CreateSLOBurnAlertTool) Name() string { return "create_slo_burn_alert" }
+
+// Annotations returns tool hints for LLMs
+func (t *CreateSLOBurnAlertTool) Annotations() *mcp.ToolAnnotations {
+	return CreateAnnotations("Create SLO Burn Rate Alerts")
+}
+
+// DefaultTimeout returns the timeout (three alerts are created)
+func (t *CreateSLOBurnAlertTool) DefaultTimeout() time.Duration {
+	return 3 * DefaultCreateTimeout
+}
+
+// Description returns the tool description
+func (t *CreateSLOBurnAlertTool) Description() string {
+	return `Create SLO error-budget burn rate alerts from an SLO specification.
+
+Builds ratio alerts (bad events / good events) following the multi-window, multi-burn-rate
+strategy from the Google SRE Workbook. For a 30d window:
+- **Fast burn** (critical): 14.4x burn rate over 1h - 2% of the budget consumed in an hour (page)
+- **Fast burn** (critical): 6x burn rate over 6h - 5% of the budget consumed in 6 hours (page)
+- **Slow burn** (warning): 3x burn rate over 24h - 10% of the budget consumed in a day (ticket)
+
+Burn rates are scaled to the SLO window so each alert still fires on the same share of the
+budget (e.g. 3.36x over 1h for a 7d window). Targets too loose for a burn rate (the error rate
+it implies would reach 100%) are rejected.
+
+Returns the created alert IDs and the computed error budget.
+
+**Related tools:** suggest_alert, list_alerts, list_outgoing_webhooks, query_logs`
+}
+
+// InputSchema returns the input schema
+func (t *CreateSLOBurnAlertTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name": map[string]interface{}{
+				"type":        "string",
+				"description": "SLO name used as a prefix for the alert names (e.g., 'Checkout Availability')",
+			},
+			"slo_target": map[string]interface{}{
+				"type":             "number",
+				"description":      "SLO target as a fraction (e.g., 0.999 for 99.9%)",
+				"exclusiveMinimum": 0,
+				"exclusiveMaximum": 1,
+				"examples":         []float64{0.99, 0.999, 0.9999},
+			},
+			"window": map[string]interface{}{
+				"type":        "string",
+				"description": "SLO window (e.g., '30d', '7d', '720h'). Default: 30d",
+				"default":     "30d",
+			},
+			"good_events_query": map[string]interface{}{
+				"type":        "string",
+				"description": "Lucene query matching successful events (e.g., 'service:checkout AND status_code:<500')",
+			},
+			"bad_events_query": map[string]interface{}{
+				"type":        "string",
+				"description": "Lucene query matching failed events (e.g., 'service:checkout AND status_code:>=500')",
+			},
+			"notification_group_id": map[string]interface{}{
+				"type":        "string",
+				"description": "Optional notification group to attach to every alert",
+			},
+		},
+		"required": []string{"name", "slo_target", "good_events_query", "bad_events_query"},
+	}
+}
+
+// Execute executes the tool
+func (t *CreateSLOBurnAlertTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	session := GetSessionFromContext(ctx)
+	cacheHelper := GetCacheHelperFromContext(ctx)
+
+	name, err := GetStringParam(args, "name", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	target, ok := args["slo_target"].(float64)
+	if !ok {
+		return NewToolResultError("missing required argument: slo_target"), nil
+	}
+	if target <= 0 || target >= 1 {
+		return NewToolResultError("slo_target must be between 0 and 1 (exclusive), e.g. 0.999 for 99.9%"), nil
+	}
+	windowStr, _ := GetStringParam(args, "window", false)
+	if windowStr == "" {
+		windowStr = "30d"
+	}
+	windowDays, err := parseSLOWindowDays(windowStr)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	goodQuery, err := GetStringParam(args, "good_events_query", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	badQuery, err := GetStringParam(args, "bad_events_query", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	notificationGroupID, _ := GetStringParam(args, "notification_group_id", false)
+
+	windows, err := sloBurnWindows(target, windowDays)
+	if err != nil {
+		return NewToolResultErrorWithSuggestion(err.Error(),
+			"Use a stricter slo_target or a shorter window."), nil
+	}
+
+	created := make([]map[string]interface{}, 0, len(windows))
+	var fastIDs, slowIDs []interface{}
+	for _, w := range windows {
+		alert := BuildBurnRateAlert(name, target, windowDays, w, goodQuery, badQuery, notificationGroupID)
+
+		res, err := t.ExecuteRequest(ctx, &client.Request{Method: "POST", Path: "/v1/alerts", Body: alert})
+		if err != nil {
+			session.RecordToolUse(t.Name(), false, args)
+			if len(created) > 0 {
+				cacheHelper.InvalidateRelated(t.Name())
+				ids := make([]string, len(created))
+				for i, c := range created {
+					ids[i] = fmt.Sprintf("%v", c["id"])
+				}
+				return NewToolResultErrorWithSuggestion(
+					fmt.Sprintf("Created %d of %d alerts (ids: %s) but failed to create %q: %v",
+						len(created), len(windows), strings.Join(ids, ", "), alert["name"], err),
+					"Fix the error and create the remaining alerts with create_alert, or delete the partial alerts with delete_alert",
+				), nil
+			}
+			return NewToolResultErrorFromErr(err), nil
+		}
+		if w.AlertType == "fast_burn" {
+			fastIDs = append(fastIDs, res["id"])
+		} else {
+			slowIDs = append(slowIDs, res["id"])
+		}
+		created = append(created, map[string]interface{}{
+			"id":          res["id"],
+			"name":        alert["name"],
+			"alert_type":  w.AlertType,
+			"burn_rate":   w.BurnRate,
+			"window":      formatDuration(w.Duration),
+			"severity":    alert["severity"],
+			"error_ratio": GetBurnRateThreshold(target, w.BurnRate),
+		})
+	}
+
+	cacheHelper.InvalidateRelated(t.Name())
+	session.RecordToolUse(t.Name(), true, map[string]interface{}{"slo_name": name})
+
+	errorBudget := 1 - target
+	result := map[string]interface{}{
+		"fast_burn_alert_ids": fastIDs,
+		"slow_burn_alert_ids": slowIDs,
+		"alerts":              created,
+		"slo": map[string]interface{}{
+			"target":               target,
+			"window_days":          windowDays,
+			"error_budget":         errorBudget,
+			"error_budget_minutes": errorBudget * float64(windowDays) * 24 * 60,
+		},
+	}
+
+	return t.FormatResponseWithSuggestions(result, t.Name())
+}
+
+// sloBurnWindows returns the burn rate windows to alert on, with burn rates scaled from the
+// 30-day reference to the SLO window so each window still fires on the same share of the budget.
+// The 3-day slow burn window is left out: no alert timeframe is longer than 36h.
+func sloBurnWindows(target float64, windowDays int) ([]BurnRateWindow, error) {
+	burnConfig := CalculateBurnRate(target, windowDays)
+	windows := []BurnRateWindow{burnConfig.FastBurnWindows[0], burnConfig.FastBurnWindows[1], burnConfig.SlowBurnWindows[0]}
+
+	scale := float64(windowDays) / 30
+	for i := range windows {
+		windows[i].BurnRate *= scale
+		// At an error rate of 100% or more the bad/good threshold is infinite or negative;
+		// the margin absorbs rounding for targets such as exactly 1-1/14.4
+		if GetBurnRateThreshold(target, windows[i].BurnRate) >= 1-1e-9 {
+			return nil, fmt.Errorf("slo_target %s is too loose for a %.1fx burn rate over %s with a %d day window: the error rate threshold would reach 100%%",
+				strconv.FormatFloat(target, 'f', -1, 64), windows[i].BurnRate, formatDuration(windows[i].Duration), windowDays)
+		}
+	}
+	return windows, nil
+}
+
+// BuildBurnRateAlert constructs a ratio alert that fires when the bad/good event
+// ratio implies the error budget is burning at the window's burn rate.
+func BuildBurnRateAlert(sloName string, target float64, windowDays int, w BurnRateWindow, goodQuery, badQuery, notificationGroupID string) map[string]interface{} {
+	// The alert compares bad/good, so convert the error-rate threshold r
+	// (bad/total) into the equivalent bad/good ratio r/(1-r).
+	errorRate := GetBurnRateThreshold(target, w.BurnRate)
+	ratioThreshold := errorRate / (1 - errorRate)
+
+	severity := "critical"
+	if w.AlertType == "slow_burn" {
+		severity = "warning"
+	}
+
+	alert := map[string]interface{}{
+		"name": fmt.Sprintf("%s - SLO %s (%s)", sloName, strings.ReplaceAll(w.AlertType, "_", " "), formatDuration(w.Duration)),
+		"description": fmt.Sprintf("%.1fx error budget burn rate over %s for a %s SLO over %d days",
+			w.BurnRate, formatDuration(w.Duration), strconv.FormatFloat(target*100, 'f', -1, 64)+"%", windowDays),
+		"is_active": true,
+		"severity":  severity,
+		"condition": map[string]interface{}{
+			"more_than": map[string]interface{}{
+				"parameters": map[string]interface{}{
+					"threshold":          ratioThreshold,
+					"timeframe":          burnRateTimeframe(w.Duration),
+					"relative_timeframe": "hour_or_unspecified",
+					"ignore_infinity":    true,
+				},
+			},
+		},
+		"filters": map[string]interface{}{
+			"filter_type": "ratio",
+			"alias":       "bad_events",
+			"text":        badQuery,
+			"ratio_alerts": []interface{}{
+				map[string]interface{}{
+					"alias": "good_events",
+					"text":  goodQuery,
+				},
+			},
+		},
+		"meta_labels": []interface{}{
+			map[string]interface{}{"key": "slo", "value": sloName},
+			map[string]interface{}{"key": "burn_rate", "value": w.AlertType},
+		},
+	}
+
+	if notificationGroupID != "" {
+		alert["notification_group_id"] = notificationGroupID
+	}
+
+	return alert
+}
+
+// burnRateTimeframe maps a burn rate window to an alert API timeframe
+func burnRateTimeframe(d time.Duration) string {
+	switch {
+	case d <= time.Hour:
+		return "timeframe_1h"
+	case d <= 6*time.Hour:
+		return "timeframe_6h"
+	case d <= 12*time.Hour:
+		return "timeframe_12h"
+	case d <= 24*time.Hour:
+		return "timeframe_24h"
+	default:
+		return "timeframe_36h"
+	}
+}
+
+// parseSLOWindowDays parses an SLO window such as "30d" or "720h" into whole days
+func parseSLOWindowDays(window string) (int, error) {
+	window = strings.TrimSpace(strings.ToLower(window))
+	if strings.HasSuffix(window, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(window, "d"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid window %q: expected a value like '30d'", window)
+		}
+		if days <= 0 {
+			return 0, fmt.Errorf("window must be positive, got %q", window)
+		}
+		return days, nil
+	}
+
+	d, err := time.ParseDuration(window)
+	if err != nil {
+		return 0, fmt.Errorf("invalid window %q: expected a value like '30d' or '720h'", window)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("window must be positive, got %q", window)
+	}
+	days := int(d.Hours() / 24)
+	if days < 1 {
+		return 0, fmt.Errorf("window must be at least 1 day, got %q", window)
+	}
+	return days, nil
+}
@@ -0,0 +1,175 @@
+package tools
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestParseSLOWindowDays(t *testing.T) {
+	tests := []struct {
+		window  string
+		want    int
+		wantErr bool
+	}{
+		{"30d", 30, false},
+		{"7D", 7, false},
+		{"720h", 30, false},
+		{"0d", 0, true},
+		{"-5d", 0, true},
+		{"12h", 0, true},
+		{"abc", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.window, func(t *testing.T) {
+			got, err := parseSLOWindowDays(tt.window)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSLOWindowDays(%q) error = %v, wantErr %v", tt.window, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseSLOWindowDays(%q) = %d, want %d", tt.window, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildBurnRateAlert(t *testing.T) {
+	w := BurnRateWindow{Duration: time.Hour, BurnRate: 14.4, AlertType: "fast_burn"}
+	alert := BuildBurnRateAlert("Checkout", 0.999, 30, w, "status:<500", "status:>=500", "ng-1")
+
+	if alert["severity"] != "critical" {
+		t.Errorf("severity = %v, want critical", alert["severity"])
+	}
+	if alert["notification_group_id"] != "ng-1" {
+		t.Errorf("notification_group_id = %v, want ng-1", alert["notification_group_id"])
+	}
+
+	params := alert["condition"].(map[string]interface{})["more_than"].(map[string]interface{})["parameters"].(map[string]interface{})
+	// error rate 0.0144 => bad/good ratio 0.0144/0.9856
+	want := 0.0144 / 0.9856
+	if got := params["threshold"].(float64); math.Abs(got-want) > 1e-9 {
+		t.Errorf("threshold = %v, want %v", got, want)
+	}
+	if params["timeframe"] != "timeframe_1h" {
+		t.Errorf("timeframe = %v, want timeframe_1h", params["timeframe"])
+	}
+
+	filters := alert["filters"].(map[string]interface{})
+	if filters["filter_type"] != "ratio" || filters["text"] != "status:>=500" {
+		t.Errorf("unexpected filters: %v", filters)
+	}
+}
+
+func TestSLOBurnWindows(t *testing.T) {
+	windows, err := sloBurnWindows(0.999, 30)
+	if err != nil {
+		t.Fatalf("sloBurnWindows error: %v", err)
+	}
+	want := []struct {
+		duration time.Duration
+		burnRate float64
+		kind     string
+	}{
+		{time.Hour, 14.4, "fast_burn"},
+		{6 * time.Hour, 6, "fast_burn"},
+		{24 * time.Hour, 3, "slow_burn"},
+	}
+	if len(windows) != len(want) {
+		t.Fatalf("got %d windows, want %d", len(windows), len(want))
+	}
+	for i, w := range want {
+		if windows[i].Duration != w.duration || math.Abs(windows[i].BurnRate-w.burnRate) > 1e-9 || windows[i].AlertType != w.kind {
+			t.Errorf("window %d = %+v, want %v", i, windows[i], w)
+		}
+	}
+
+	// A 7d window keeps the share of budget per alert: 14.4 * 7/30 over 1h
+	windows, err = sloBurnWindows(0.999, 7)
+	if err != nil {
+		t.Fatalf("sloBurnWindows error: %v", err)
+	}
+	if math.Abs(windows[0].BurnRate-3.36) > 1e-9 {
+		t.Errorf("7d fast burn rate = %v, want 3.36", windows[0].BurnRate)
+	}
+
+	if _, err := sloBurnWindows(0.9, 30); err == nil {
+		t.Error("expected an error when the error rate threshold exceeds 100%")
+	}
+}
+
+func TestCreateSLOBurnAlertTool_Execute_Success(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.RespondWith(200, map[string]interface{}{"id": "fast-1"})
+	mock.RespondWith(200, map[string]interface{}{"id": "fast-2"})
+	mock.RespondWith(200, map[string]interface{}{"id": "slow-1"})
+
+	tool := NewCreateSLOBurnAlertTool(mock, zap.NewNop())
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{
+		"name":              "Checkout",
+		"slo_target":        0.999,
+		"window":            "30d",
+		"good_events_query": "status:<500",
+		"bad_events_query":  "status:>=500",
+	})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Expected success, got error result")
+	}
+	if mock.RequestCount() != 3 {
+		t.Fatalf("Expected 3 alerts to be created, got %d requests", mock.RequestCount())
+	}
+	for _, req := range mock.Requests {
+		if req.Method != "POST" || req.Path != "/v1/alerts" {
+			t.Errorf("Unexpected request %s %s", req.Method, req.Path)
+		}
+	}
+}
+
+func TestCreateSLOBurnAlertTool_Execute_Validation(t *testing.T) {
+	base := map[string]interface{}{
+		"name":              "Checkout",
+		"good_events_query": "status:<500",
+		"bad_events_query":  "status:>=500",
+	}
+
+	tests := map[string]map[string]interface{}{
+		"target above 1":  {"slo_target": 1.5},
+		"target zero":     {"slo_target": 0.0},
+		"bad window":      {"slo_target": 0.99, "window": "-1d"},
+		"target loose":    {"slo_target": 0.9},
+		"target at inf":   {"slo_target": 1 - 1/14.4},
+		"window too long": {"slo_target": 0.99, "window": "365d"},
+	}
+
+	for name, extra := range tests {
+		t.Run(name, func(t *testing.T) {
+			args := map[string]interface{}{}
+			for k, v := range base {
+				args[k] = v
+			}
+			for k, v := range extra {
+				args[k] = v
+			}
+
+			mock := client.NewMockClient()
+			tool := NewCreateSLOBurnAlertTool(mock, zap.NewNop())
+			result, err := tool.Execute(testCtx(mock), args)
+			if err != nil {
+				t.Fatalf("Execute error: %v", err)
+			}
+			if !result.IsError {
+				t.Error("Expected validation error")
+			}
+			if mock.RequestCount() != 0 {
+				t.Errorf("No API request should be made, got %d", mock.RequestCount())
+			}
+		})
+	}
+}
@@ -441,8 +441,9 @@ func TestTransformLogEntry_FlattenedFormat(t *testing.T) {
 	if compact["time"] != "2026-03-09T10:15:30.123Z" {
 		t.Errorf("time = %v", compact["time"])
 	}
-	if compact["severity"] != "5" {
-		t.Errorf("severity = %v", compact["severity"])
+	// Numeric severities are normalized to their names
+	if compact["severity"] != "Error" {
+		t.Errorf("severity = %v, want Error", compact["severity"])
 	}
 	if compact["message"] != "Connection timeout to upstream service" {
 		t.Errorf("message = %v", compact["message"])
@@ -477,6 +478,9 @@ func TestTransformLogEntry_LegacyFormat(t *testing.T) {
 	if compact["time"] != "2026-03-09T10:00:00Z" {
 		t.Errorf("time = %v", compact["time"])
 	}
+	if compact["severity"] != "Info" {
+		t.Errorf("severity = %v, want Info", compact["severity"])
+	}
 	if compact["message"] != "Processing complete" {
 		t.Errorf("message = %v", compact["message"])
 	}
@@ -0,0 +1,76 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestDiscoverToolsForSession_NilSession(t *testing.T) {
+	result := NewToolRegistry().DiscoverToolsForSession(nil, "query logs for errors", "", "")
+	require.NotNil(t, result)
+	assert.NotEmpty(t, result.MatchedTools)
+	assert.Nil(t, result.SessionContext)
+}
+
+func TestDiscoverToolsTool_NilSessionInContext(t *testing.T) {
+	tool := NewDiscoverToolsTool(client.NewMockClient(), zap.NewNop())
+	ctx := WithSession(context.Background(), nil)
+
+	result, err := tool.Execute(ctx, map[string]interface{}{"intent": "investigate errors"})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var resp DiscoveryResult
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &resp))
+	assert.NotEmpty(t, resp.MatchedTools)
+}
+
+func TestStatelessMode(t *testing.T) {
+	SetStatelessMode(true)
+	t.Cleanup(func() { SetStatelessMode(false) })
+
+	first := GetSession()
+	first.SetLastQuery("source logs | filter $m.severity >= ERROR")
+	first.RecordToolUse("query_logs", true, nil)
+
+	second := GetSession()
+	require.NotNil(t, second)
+	assert.NotSame(t, first, second)
+	assert.Empty(t, second.GetLastQuery(), "nothing carries over between requests")
+	assert.Nil(t, GetSessionManager().GetSession())
+	assert.NoError(t, SaveCurrentSession())
+
+	tool := NewDiscoverToolsTool(client.NewMockClient(), zap.NewNop())
+	result, err := tool.Execute(WithSession(context.Background(), GetSession()), map[string]interface{}{"intent": "query logs"})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.NotContains(t, result.Content[0].(*mcp.TextContent).Text, "Previous query context available")
+}
+
+func TestStatelessMode_SessionToolsUnavailable(t *testing.T) {
+	SetStatelessMode(true)
+	t.Cleanup(func() { SetStatelessMode(false) })
+
+	mock := client.NewMockClient()
+	result, err := ExecuteWithTimeout(WithSession(context.Background(), GetSession()),
+		NewSaveQueryTool(mock, zap.NewNop()), map[string]interface{}{"name": "errors", "query": "source logs"})
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Equal(t, "FORBIDDEN", result.Meta["error_code"])
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "stateless mode")
+
+	assert.Error(t, CheckToolAccess(NewSessionContextTool(mock, zap.NewNop())))
+	assert.NoError(t, CheckToolAccess(NewQueryTool(mock, zap.NewNop())))
+	assert.False(t, isToolNameAllowed("record_feedback"))
+
+	SetStatelessMode(false)
+	assert.NoError(t, CheckToolAccess(NewSaveQueryTool(mock, zap.NewNop())))
+}
@@ -0,0 +1,66 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements the compression and output format options of the stream tools.
+package tools
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// streamCompressionTypes are the compression_type values the streams API accepts
+var streamCompressionTypes = []string{"gzip", "snappy", "lz4", "zstd", "unspecified"}
+
+// streamCompressionSchema describes compression_type for create_stream and update_stream;
+// "none" is accepted as a spelling of the API's "unspecified"
+var streamCompressionSchema = map[string]interface{}{
+	"type":        "string",
+	"description": "Compression of the streamed records: gzip, snappy, lz4, zstd, or none (sent as unspecified). gzip or zstd cut egress for high-volume streams.",
+	"enum":        []string{"gzip", "snappy", "lz4", "zstd", "none", "unspecified"},
+}
+
+// streamFormatSchema describes the output format argument of create_stream and update_stream
+var streamFormatSchema = map[string]interface{}{
+	"type":        "string",
+	"description": "Record format (default: json). parquet needs an object storage destination, which streams do not support; use the instance's archive bucket for Parquet.",
+	"enum":        []string{"json", "parquet"},
+	"default":     "json",
+}
+
+// resolveStreamOutput validates the compression_type and format arguments, sets the API's
+// compression_type on body, and returns the effective output configuration of the stream.
+// Streams deliver to IBM Event Streams (Kafka) only, which takes JSON records.
+func resolveStreamOutput(args, body map[string]interface{}) (map[string]interface{}, error) {
+	compression, _ := GetStringParam(args, "compression_type", false)
+	compression = strings.ToLower(compression)
+	if compression == "none" {
+		compression = "unspecified"
+	}
+	if compression != "" {
+		if !slices.Contains(streamCompressionTypes, compression) {
+			return nil, fmt.Errorf("compression_type %q is not supported (valid: gzip, snappy, lz4, zstd, none)", compression)
+		}
+		body["compression_type"] = compression
+	}
+
+	format, _ := GetStringParam(args, "format", false)
+	switch strings.ToLower(format) {
+	case "", "json":
+		format = "json"
+	case "parquet":
+		return nil, fmt.Errorf("format parquet requires an object storage destination, but streams only deliver to IBM Event Streams (Kafka), which takes JSON records; " +
+			"for Parquet files in object storage, use the instance's archive bucket")
+	default:
+		return nil, fmt.Errorf("format %q is not supported (valid: json, parquet)", format)
+	}
+
+	effective := map[string]interface{}{
+		"destination":      "ibm_event_streams",
+		"format":           format,
+		"compression_type": "unspecified",
+	}
+	if compression != "" {
+		effective["compression_type"] = compression
+	}
+	return effective, nil
+}
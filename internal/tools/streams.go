@@ -37,12 +37,12 @@ func (t *ListStreamsTool) Description() string {
 func (t *ListStreamsTool) InputSchema() interface{} {
 	return map[string]interface{}{
 		"type":       "object",
-		"properties": map[string]interface{}{},
+		"properties": withSummaryOnly(map[string]interface{}{}),
 	}
 }
 
 // Execute executes the tool
-func (t *ListStreamsTool) Execute(ctx context.Context, _ map[string]interface{}) (*mcp.CallToolResult, error) {
+func (t *ListStreamsTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	session := GetSessionFromContext(ctx)
 	cacheHelper := GetCacheHelperFromContext(ctx)
 
@@ -51,7 +51,7 @@ func (t *ListStreamsTool) Execute(ctx context.Context, _ map[string]interface{})
 		if cachedResult, ok := cached.(map[string]interface{}); ok {
 			session.RecordToolUse(t.Name(), true, nil)
 			cachedResult["_cached"] = true
-			return t.FormatResponseWithSuggestions(cachedResult, "list_streams")
+			return t.FormatListResponse(cachedResult, args, "list_streams")
 		}
 	}
 
@@ -63,14 +63,14 @@ func (t *ListStreamsTool) Execute(ctx context.Context, _ map[string]interface{})
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
 		session.RecordToolUse(t.Name(), false, nil)
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	// Cache the result
 	cacheHelper.Set(t.Name(), "all", result)
 	session.RecordToolUse(t.Name(), true, nil)
 
-	return t.FormatResponseWithSuggestions(result, "list_streams")
+	return t.FormatListResponse(result, args, "list_streams")
 }
 
 // GetStreamTool gets a specific stream by ID
@@ -114,10 +114,20 @@ func (t *GetStreamTool) InputSchema() interface{} {
 func (t *GetStreamTool) Execute(ctx context.Context, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
 	streamID, err := GetStringParam(arguments, "stream_id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
-	// List all streams and filter for the requested ID
+	stream, errResult := t.findStream(ctx, streamID)
+	if errResult != nil {
+		return errResult, nil
+	}
+	return t.FormatResponseWithSuggestions(stream, "get_stream")
+}
+
+// findStream returns the stream with the given ID. The API doesn't support GET for
+// individual streams, so all streams are listed and filtered; a non-nil result reports
+// the failure.
+func (t *BaseTool) findStream(ctx context.Context, streamID string) (map[string]interface{}, *mcp.CallToolResult) {
 	req := &client.Request{
 		Method: "GET",
 		Path:   "/v1/streams",
@@ -125,7 +135,7 @@ func (t *GetStreamTool) Execute(ctx context.Context, arguments map[string]interf
 
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return nil, NewToolResultErrorFromErr(err)
 	}
 
 	// Parse the response to filter by ID
@@ -144,13 +154,13 @@ func (t *GetStreamTool) Execute(ctx context.Context, arguments map[string]interf
 				}
 
 				if id == streamID {
-					return t.FormatResponseWithSuggestions(streamMap, "get_stream")
+					return streamMap, nil
 				}
 			}
 		}
 	}
 
-	return NewResourceNotFoundError("Stream", streamID, "list_streams"), nil
+	return nil, NewResourceNotFoundError("Stream", streamID, "list_streams")
 }
 
 // CreateStreamTool creates a new stream
@@ -172,7 +182,7 @@ func (t *CreateStreamTool) Name() string {
 
 // Description returns the tool description
 func (t *CreateStreamTool) Description() string {
-	return "Create a new stream for streaming logs to IBM Event Streams (Kafka)"
+	return "Create a new stream for streaming logs to IBM Event Streams (Kafka). The DPXL filter is validated before the API call; use dry_run to check the whole configuration."
 }
 
 // InputSchema returns the input schema
@@ -198,11 +208,8 @@ func (t *CreateStreamTool) InputSchema() interface{} {
 				"type":        "boolean",
 				"description": "Whether the stream is active (default: true)",
 			},
-			"compression_type": map[string]interface{}{
-				"type":        "string",
-				"description": "Compression type: gzip, snappy, lz4, zstd, or unspecified",
-				"enum":        []string{"gzip", "snappy", "lz4", "zstd", "unspecified"},
-			},
+			"compression_type": streamCompressionSchema,
+			"format":           streamFormatSchema,
 			"ibm_event_streams": map[string]interface{}{
 				"type":        "object",
 				"description": "IBM Event Streams (Kafka) configuration",
@@ -237,12 +244,12 @@ func (t *CreateStreamTool) Execute(ctx context.Context, arguments map[string]int
 
 	name, err := GetStringParam(arguments, "name", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	dpxlExpression, err := GetStringParam(arguments, "dpxl_expression", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	body := map[string]interface{}{
@@ -255,9 +262,9 @@ func (t *CreateStreamTool) Execute(ctx context.Context, arguments map[string]int
 		body["is_active"] = isActive
 	}
 
-	compressionType, _ := GetStringParam(arguments, "compression_type", false)
-	if compressionType != "" {
-		body["compression_type"] = compressionType
+	effective, err := resolveStreamOutput(arguments, body)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	if eventStreams, ok := arguments["ibm_event_streams"].(map[string]interface{}); ok {
@@ -267,7 +274,10 @@ func (t *CreateStreamTool) Execute(ctx context.Context, arguments map[string]int
 	// Check for dry-run mode
 	dryRun, _ := GetBoolParam(arguments, "dry_run", false)
 	if dryRun {
-		return t.validateStream(body)
+		return t.validateStream(body, effective)
+	}
+	if err := ValidateDPXLExpression(dpxlExpression); err != nil {
+		return dpxlErrorResult(err), nil
 	}
 
 	req := &client.Request{
@@ -278,8 +288,9 @@ func (t *CreateStreamTool) Execute(ctx context.Context, arguments map[string]int
 
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
+	result["effective_config"] = effective
 
 	// Invalidate related caches
 	cacheHelper.InvalidateRelated(t.Name())
@@ -287,60 +298,37 @@ func (t *CreateStreamTool) Execute(ctx context.Context, arguments map[string]int
 	return t.FormatResponseWithSuggestions(result, "create_stream")
 }
 
+// streamDryRunSpec defines dry-run validation for streams
+var streamDryRunSpec = DryRunSpec{
+	ResourceType:   "Stream",
+	RequiredFields: []string{"name", "dpxl_expression"},
+	EnumFields: map[string][]string{
+		"compression_type": streamCompressionTypes,
+	},
+	SummaryFields: []string{"name", "dpxl_expression", "compression_type"},
+}
+
 // validateStream performs dry-run validation for stream creation
-func (t *CreateStreamTool) validateStream(stream map[string]interface{}) (*mcp.CallToolResult, error) {
-	result := &ValidationResult{
-		Valid:   true,
-		Summary: make(map[string]interface{}),
-	}
+func (t *CreateStreamTool) validateStream(stream, effective map[string]interface{}) (*mcp.CallToolResult, error) {
+	result := t.ValidateDryRun(stream, streamDryRunSpec)
+	result.Summary["effective_config"] = effective
 
-	// Validate required fields
-	if name, ok := stream["name"].(string); ok {
-		if len(name) < 1 {
-			result.Errors = append(result.Errors, "Stream name must not be empty")
-			result.Valid = false
-		}
-		if len(name) > 4096 {
-			result.Errors = append(result.Errors, "Stream name must be at most 4096 characters")
-			result.Valid = false
-		}
-		result.Summary["name"] = name
-	} else {
-		result.Errors = append(result.Errors, "Missing required field: name")
+	// Validate name length
+	if errMsg := ValidateStringLength(stream, "name", 1, 4096); errMsg != "" {
+		result.Errors = append(result.Errors, errMsg)
 		result.Valid = false
 	}
 
 	// Validate DPXL expression
 	if dpxl, ok := stream["dpxl_expression"].(string); ok {
-		if len(dpxl) < 1 {
-			result.Errors = append(result.Errors, "DPXL expression must not be empty")
+		if err := ValidateDPXLExpression(dpxl); err != nil {
+			result.Errors = append(result.Errors, err.Error())
 			result.Valid = false
 		}
 		// Basic DPXL syntax check
 		if !strings.HasPrefix(dpxl, "<v1>") {
 			result.Warnings = append(result.Warnings, "DPXL expression should start with '<v1>' version prefix")
 		}
-		result.Summary["dpxl_expression"] = dpxl
-	} else {
-		result.Errors = append(result.Errors, "Missing required field: dpxl_expression")
-		result.Valid = false
-	}
-
-	// Validate compression type
-	if compression, ok := stream["compression_type"].(string); ok {
-		validCompressions := []string{"gzip", "snappy", "lz4", "zstd", "unspecified"}
-		isValid := false
-		for _, valid := range validCompressions {
-			if compression == valid {
-				isValid = true
-				break
-			}
-		}
-		if !isValid {
-			result.Errors = append(result.Errors, fmt.Sprintf("Invalid compression_type: '%s'. Must be one of: %v", compression, validCompressions))
-			result.Valid = false
-		}
-		result.Summary["compression_type"] = compression
 	}
 
 	// Validate IBM Event Streams config
@@ -366,21 +354,13 @@ func (t *CreateStreamTool) validateStream(stream map[string]interface{}) (*mcp.C
 		result.Summary["is_active"] = true // default
 	}
 
-	// Add suggestions
-	if result.Valid {
-		result.Suggestions = append(result.Suggestions, "Stream configuration is valid")
-		result.Suggestions = append(result.Suggestions, "Remove dry_run parameter to create the stream")
-	} else {
-		result.Suggestions = append(result.Suggestions, "Fix the errors above before creating the stream")
-	}
-
 	// Estimate impact
 	result.EstimatedImpact = &ImpactEstimate{
 		EstimatedCost: "Data egress charges may apply based on stream volume",
 		RiskLevel:     "medium", // Streams can have cost implications
 	}
 
-	return FormatDryRunResult(result, "Stream", stream), nil
+	return t.FormatDryRun(result, streamDryRunSpec, stream), nil
 }
 
 // UpdateStreamTool updates an existing stream
@@ -423,11 +403,8 @@ func (t *UpdateStreamTool) InputSchema() interface{} {
 				"type":        "string",
 				"description": "DPXL expression to filter logs",
 			},
-			"compression_type": map[string]interface{}{
-				"type":        "string",
-				"description": "Compression type: gzip, snappy, lz4, zstd, or unspecified",
-				"enum":        []string{"gzip", "snappy", "lz4", "zstd", "unspecified"},
-			},
+			"compression_type": streamCompressionSchema,
+			"format":           streamFormatSchema,
 			"ibm_event_streams": map[string]interface{}{
 				"type":        "object",
 				"description": "IBM Event Streams (Kafka) configuration",
@@ -445,6 +422,18 @@ func (t *UpdateStreamTool) InputSchema() interface{} {
 			},
 		},
 		"required": []string{"stream_id", "name", "dpxl_expression", "compression_type", "ibm_event_streams"},
+		"examples": []interface{}{
+			map[string]interface{}{
+				"stream_id":        "stream-id",
+				"name":             "frontend-errors",
+				"dpxl_expression":  "<v1>contains(kubernetes.labels.app, \"frontend\")",
+				"compression_type": "gzip",
+				"ibm_event_streams": map[string]interface{}{
+					"brokers": "broker-0.kafka.example.com:9093,broker-1.kafka.example.com:9093",
+					"topic":   "cloud-logs-errors",
+				},
+			},
+		},
 	}
 }
 
@@ -454,35 +443,40 @@ func (t *UpdateStreamTool) Execute(ctx context.Context, arguments map[string]int
 
 	streamID, err := GetStringParam(arguments, "stream_id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	name, err := GetStringParam(arguments, "name", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	dpxlExpression, err := GetStringParam(arguments, "dpxl_expression", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
+	}
+	if err := ValidateDPXLExpression(dpxlExpression); err != nil {
+		return dpxlErrorResult(err), nil
 	}
 
-	compressionType, err := GetStringParam(arguments, "compression_type", true)
-	if err != nil {
-		return NewToolResultError(err.Error()), nil
+	if _, err := GetStringParam(arguments, "compression_type", true); err != nil {
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	eventStreams, err := GetObjectParam(arguments, "ibm_event_streams", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	body := map[string]interface{}{
 		"name":              name,
 		"dpxl_expression":   dpxlExpression,
-		"compression_type":  compressionType,
 		"ibm_event_streams": eventStreams,
 	}
+	effective, err := resolveStreamOutput(arguments, body)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
 
 	req := &client.Request{
 		Method: "PUT",
@@ -492,8 +486,9 @@ func (t *UpdateStreamTool) Execute(ctx context.Context, arguments map[string]int
 
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
+	result["effective_config"] = effective
 
 	// Invalidate related caches
 	cacheHelper.InvalidateRelated(t.Name())
@@ -548,7 +543,7 @@ func (t *DeleteStreamTool) Execute(ctx context.Context, arguments map[string]int
 
 	streamID, err := GetStringParam(arguments, "stream_id", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	req := &client.Request{
@@ -558,7 +553,7 @@ func (t *DeleteStreamTool) Execute(ctx context.Context, arguments map[string]int
 
 	result, err := t.ExecuteRequest(ctx, req)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	// Invalidate related caches
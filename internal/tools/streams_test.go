@@ -3,7 +3,12 @@ package tools
 import (
 	"testing"
 
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
 )
 
 func TestListStreamsTool_InputSchema(t *testing.T) {
@@ -66,3 +71,43 @@ func TestDeleteStreamTool_InputSchema(t *testing.T) {
 	idProp := props["stream_id"].(map[string]interface{})
 	assert.Equal(t, "string", idProp["type"])
 }
+
+func TestCreateStreamTool_CompressionAndFormat(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.RespondWith(200, map[string]interface{}{"id": 1, "name": "s"})
+	tool := NewCreateStreamTool(mock, zap.NewNop())
+
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{
+		"name": "s", "dpxl_expression": "<v1>severity >= 5", "compression_type": "none", "format": "json",
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError, "%+v", result.Content)
+	assert.Equal(t, "unspecified", mock.LastRequest().Body.(map[string]interface{})["compression_type"])
+	text := result.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, `"effective_config"`)
+	assert.Contains(t, text, `"destination": "ibm_event_streams"`)
+	assert.Contains(t, text, `"format": "json"`)
+}
+
+func TestStreamTools_RejectUnsupportedOutput(t *testing.T) {
+	mock := client.NewMockClient()
+	events := map[string]interface{}{"brokers": "b:9093", "topic": "t"}
+	for _, tc := range []struct {
+		tool    Tool
+		args    map[string]interface{}
+		wantErr string
+	}{
+		{NewCreateStreamTool(mock, zap.NewNop()), map[string]interface{}{"name": "s", "dpxl_expression": "<v1>a == 1", "format": "parquet"},
+			"parquet requires an object storage destination"},
+		{NewCreateStreamTool(mock, zap.NewNop()), map[string]interface{}{"name": "s", "dpxl_expression": "<v1>a == 1", "compression_type": "brotli"},
+			"compression_type \"brotli\" is not supported"},
+		{NewUpdateStreamTool(mock, zap.NewNop()), map[string]interface{}{"stream_id": "1", "name": "s", "dpxl_expression": "<v1>a == 1",
+			"compression_type": "gzip", "ibm_event_streams": events, "format": "parquet"}, "parquet requires an object storage destination"},
+	} {
+		result, err := tc.tool.Execute(testCtx(mock), tc.args)
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, tc.wantErr)
+	}
+	assert.Zero(t, mock.RequestCount())
+}
@@ -40,6 +40,7 @@ func (t *AdvancedSuggestAlertTool) Description() string {
 - Multi-window burn rate alerting for SLO-based monitoring
 - Dynamic baseline suggestions for seasonal metrics
 - Severity classification based on user impact (P1/P2/P3)
+- Optional live evidence: with use_live_data, count-based thresholds are derived from the last 24h of logs
 
 **Methodologies:**
 - **RED Method** (for services): Rate, Errors, Duration
@@ -69,6 +70,21 @@ func (t *AdvancedSuggestAlertTool) InputSchema() interface{} {
 					"monolith", "custom",
 				},
 			},
+			"component_type": map[string]interface{}{
+				"type":        "string",
+				"description": "Alias for service_type. When neither is set, the component is inferred from service_name (e.g., names containing \"db\", \"cache\", \"gateway\"), then from query/use_case.",
+				"enum": []string{
+					"web_service", "api_gateway", "database", "cache",
+					"message_queue", "worker", "kubernetes", "serverless",
+					"storage", "network", "load_balancer", "microservice",
+					"monolith", "custom",
+				},
+			},
+			"methodology": map[string]interface{}{
+				"type":        "string",
+				"description": "Override the alerting methodology. RED (Rate, Errors, Duration) suits request-driven services; USE (Utilization, Saturation, Errors) suits resources such as databases and caches. Defaults to the methodology recommended for the component type.",
+				"enum":        []string{"RED", "USE", "GOLDEN_SIGNALS"},
+			},
 			"slo_target": map[string]interface{}{
 				"type":        "number",
 				"description": "Service Level Objective target (e.g., 0.999 for 99.9%). Enables burn rate alerting.",
@@ -112,7 +128,7 @@ func (t *AdvancedSuggestAlertTool) InputSchema() interface{} {
 			},
 			"service_name": map[string]interface{}{
 				"type":        "string",
-				"description": "Name of the service being monitored",
+				"description": "Name of the service or application being monitored. Used to infer component_type when not provided.",
 			},
 			"environment": map[string]interface{}{
 				"type":        "string",
@@ -134,6 +150,11 @@ func (t *AdvancedSuggestAlertTool) InputSchema() interface{} {
 				"description": "Suggest dynamic baseline queries for metrics with seasonality",
 				"default":     false,
 			},
+			"use_live_data": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Run a count query over the last 24h for each count-based suggestion and derive its threshold from the observed rate. Costs one extra query per suggestion.",
+				"default":     false,
+			},
 		},
 		"required": []string{},
 	}
@@ -142,6 +163,7 @@ func (t *AdvancedSuggestAlertTool) InputSchema() interface{} {
 // SuggestAlertInput represents the parsed input parameters
 type SuggestAlertInput struct {
 	ServiceType            ComponentType
+	Methodology            AlertingMethodology
 	SLOTarget              float64
 	SLOWindowDays          int
 	CriticalityTier        string
@@ -154,24 +176,27 @@ type SuggestAlertInput struct {
 	RunbookURL             string
 	EnableBurnRate         bool
 	EnableDynamicBaselines bool
+	UseLiveData            bool
 }
 
 // SuggestAlertOutput represents the complete response
 type SuggestAlertOutput struct {
-	Suggestions    []AdvancedAlertSuggestion `json:"suggestions"`
-	Methodology    AlertingMethodology       `json:"methodology"`
-	StrategyMatrix *AlertStrategyConfig      `json:"strategy_matrix,omitempty"`
-	BurnRateConfig *BurnRateConfig           `json:"burn_rate_config,omitempty"`
-	Warnings       []string                  `json:"warnings,omitempty"`
-	NextSteps      []string                  `json:"next_steps"`
-	References     []string                  `json:"references"`
+	Suggestions       []AdvancedAlertSuggestion `json:"suggestions"`
+	ComponentType     ComponentType             `json:"component_type"`
+	Methodology       AlertingMethodology       `json:"methodology"`
+	MethodologyReason string                    `json:"methodology_reason"`
+	StrategyMatrix    *AlertStrategyConfig      `json:"strategy_matrix,omitempty"`
+	BurnRateConfig    *BurnRateConfig           `json:"burn_rate_config,omitempty"`
+	Warnings          []string                  `json:"warnings,omitempty"`
+	NextSteps         []string                  `json:"next_steps"`
+	References        []string                  `json:"references"`
 }
 
 // Execute executes the tool
-func (t *AdvancedSuggestAlertTool) Execute(_ context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+func (t *AdvancedSuggestAlertTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	input, err := parseAdvancedAlertInput(args)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	// Validate at least one of query or use_case is provided
@@ -188,16 +213,17 @@ func (t *AdvancedSuggestAlertTool) Execute(_ context.Context, args map[string]in
 		},
 	}
 
-	// Detect component type if not specified
-	if input.ServiceType == "" || input.ServiceType == ComponentCustom {
-		input.ServiceType = DetectComponentType(input.Query, input.UseCase)
-	}
+	// Resolve component type and methodology, explaining the choice
+	output.MethodologyReason = resolveMethodology(input)
+	output.ComponentType = input.ServiceType
+	output.Methodology = input.Methodology
 
-	// Get methodology based on component type
-	output.Methodology = GetMethodologyForComponent(input.ServiceType)
-
-	// Get strategy matrix for this component type
+	// Get strategy matrix for this component type, narrowed to the chosen methodology
 	output.StrategyMatrix = GetStrategyForComponent(input.ServiceType)
+	if output.StrategyMatrix != nil && output.StrategyMatrix.Methodology != output.Methodology {
+		output.StrategyMatrix.Methodology = output.Methodology
+		output.StrategyMatrix.RecommendedMetrics = FilterMetricsByMethodology(output.StrategyMatrix.RecommendedMetrics, output.Methodology)
+	}
 
 	// Calculate burn rate config if SLO is provided
 	if input.SLOTarget > 0 && input.EnableBurnRate {
@@ -207,6 +233,11 @@ func (t *AdvancedSuggestAlertTool) Execute(_ context.Context, args map[string]in
 	// Generate suggestions based on methodology and inputs
 	output.Suggestions = t.generateSuggestions(input, output)
 
+	// Ground count-based thresholds in the last day of data
+	if input.UseLiveData {
+		t.applyLiveEvidence(ctx, output.Suggestions)
+	}
+
 	// Add warnings for missing recommended fields
 	output.Warnings = t.generateWarnings(input)
 
@@ -237,10 +268,22 @@ func parseAdvancedAlertInput(args map[string]interface{}) (*SuggestAlertInput, e
 		EnableBurnRate:  true,
 	}
 
-	// Parse service_type
+	// Parse service_type (component_type is an alias and takes precedence)
 	if st, ok := args["service_type"].(string); ok && st != "" {
 		input.ServiceType = ComponentType(st)
 	}
+	if ct, ok := args["component_type"].(string); ok && ct != "" {
+		input.ServiceType = ComponentType(ct)
+	}
+
+	// Parse methodology override
+	if m, ok := args["methodology"].(string); ok && m != "" {
+		methodology := AlertingMethodology(strings.ToUpper(m))
+		if MethodologySignals(methodology) == nil {
+			return nil, fmt.Errorf("methodology must be one of: RED, USE, GOLDEN_SIGNALS")
+		}
+		input.Methodology = methodology
+	}
 
 	// Parse SLO target
 	if slo, ok := args["slo_target"].(float64); ok {
@@ -292,10 +335,47 @@ func parseAdvancedAlertInput(args map[string]interface{}) (*SuggestAlertInput, e
 	if edb, ok := args["enable_dynamic_baselines"].(bool); ok {
 		input.EnableDynamicBaselines = edb
 	}
+	if live, ok := args["use_live_data"].(bool); ok {
+		input.UseLiveData = live
+	}
 
 	return input, nil
 }
 
+// resolveMethodology fills in the component type and methodology on the input
+// when they were not provided, and returns a human-readable reason for the choice.
+func resolveMethodology(input *SuggestAlertInput) string {
+	var componentReason string
+	switch {
+	case input.ServiceType != "" && input.ServiceType != ComponentCustom:
+		componentReason = fmt.Sprintf("component type '%s' was provided", input.ServiceType)
+	case InferComponentFromName(input.ServiceName) != ComponentCustom:
+		input.ServiceType = InferComponentFromName(input.ServiceName)
+		componentReason = fmt.Sprintf("component type '%s' was inferred from the name '%s'", input.ServiceType, input.ServiceName)
+	default:
+		input.ServiceType = DetectComponentType(input.Query, input.UseCase)
+		if input.ServiceType == ComponentCustom {
+			componentReason = "component type could not be inferred"
+		} else {
+			componentReason = fmt.Sprintf("component type '%s' was inferred from the query/use case", input.ServiceType)
+		}
+	}
+
+	if input.Methodology != "" {
+		return fmt.Sprintf("%s methodology was explicitly requested (%s)", input.Methodology, componentReason)
+	}
+
+	input.Methodology = GetMethodologyForComponent(input.ServiceType)
+	switch input.Methodology {
+	case MethodologyUSE:
+		return fmt.Sprintf("USE (Utilization, Saturation, Errors) selected because %s; resources are best monitored for capacity and saturation", componentReason)
+	case MethodologyGoldenSignals:
+		return fmt.Sprintf("Golden Signals (Latency, Traffic, Errors, Saturation) selected because %s", componentReason)
+	default:
+		return fmt.Sprintf("RED (Rate, Errors, Duration) selected because %s; request-driven services are best monitored by user-facing symptoms", componentReason)
+	}
+}
+
 // generateSuggestions creates alert suggestions based on input and methodology
 func (t *AdvancedSuggestAlertTool) generateSuggestions(input *SuggestAlertInput, output *SuggestAlertOutput) []AdvancedAlertSuggestion {
 	suggestions := []AdvancedAlertSuggestion{}
@@ -307,9 +387,10 @@ func (t *AdvancedSuggestAlertTool) generateSuggestions(input *SuggestAlertInput,
 		strategy = GetStrategyForComponent(ComponentWebService)
 	}
 
-	// Generate use-case based suggestions
+	// Generate use-case based suggestions, keeping only the chosen methodology's signals
 	if input.UseCase != "" {
-		suggestions = append(suggestions, t.generateUseCaseSuggestions(input, strategy, output)...)
+		useCase := t.generateUseCaseSuggestions(input, strategy, output)
+		suggestions = append(suggestions, FilterSuggestionsByMethodology(useCase, output.Methodology)...)
 	}
 
 	// Generate query-based suggestions
@@ -345,7 +426,7 @@ func (t *AdvancedSuggestAlertTool) generateUseCaseSuggestions(input *SuggestAler
 			Severity:    severity,
 			Methodology: output.Methodology,
 			Signal:      "errors",
-			Query:       `source logs | filter $m.severity >= 5 OR $d.status_code >= 500 | stats count() as error_count by bin(5m)`,
+			Query:       `source logs | filter $m.severity >= 5 || $d.status_code >= 500 | stats count() as error_count by bin(5m)`,
 			Condition: AlertCondition{
 				Type:       "threshold",
 				Threshold:  10,
@@ -454,7 +535,7 @@ SLO-based approach: "99.9% of requests complete in under 200ms"`,
 			query = `source logs | filter $d.component == 'database' | stats avg($d.connections_active / $d.connections_max * 100) as utilization by bin(1m)`
 			signal = "utilization"
 		default:
-			query = `source logs | filter $d.queue_depth exists OR $d.pending_count exists | stats max(coalesce($d.queue_depth, $d.pending_count)) as saturation by bin(1m)`
+			query = `source logs | filter $d.queue_depth exists || $d.pending_count exists | stats max(coalesce($d.queue_depth, $d.pending_count)) as saturation by bin(1m)`
 			signal = "saturation"
 		}
 
@@ -514,7 +595,7 @@ Saturation alerts are valuable because they predict problems BEFORE they cause e
 			Severity:    SeverityP2Warning,
 			Methodology: MethodologyGoldenSignals,
 			Signal:      "rate",
-			Query:       `source logs | filter $d.type == 'request' OR $d.http_method exists | stats count() as request_rate by bin(5m)`,
+			Query:       `source logs | filter $d.type == 'request' || $d.http_method exists | stats count() as request_rate by bin(5m)`,
 			Condition: AlertCondition{
 				Type:       "threshold",
 				Threshold:  0, // Should use dynamic baseline
@@ -0,0 +1,135 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// liveEvidenceWindow is how far back use_live_data looks when grounding thresholds
+const liveEvidenceWindow = 24 * time.Hour
+
+// liveThresholdMultiplier is how far above the observed average a more_than threshold is set
+const liveThresholdMultiplier = 2
+
+// evidenceCountQuery returns a query counting the logs matched by an alert query, or "" when the
+// alert is not count-based. The source and filter stages are kept and the aggregation replaced.
+func evidenceCountQuery(s AdvancedAlertSuggestion) string {
+	if s.Signal != "errors" && s.Signal != "rate" {
+		return ""
+	}
+	if !strings.Contains(strings.ToLower(s.Query), "count()") {
+		return ""
+	}
+	var stages []string
+	for _, stage := range strings.Split(s.Query, "|") {
+		stage = strings.TrimSpace(stage)
+		lower := strings.ToLower(stage)
+		if !strings.HasPrefix(lower, "source ") && !strings.HasPrefix(lower, "filter ") {
+			break
+		}
+		stages = append(stages, stage)
+	}
+	if len(stages) == 0 {
+		return ""
+	}
+	return strings.Join(stages, " | ") + " | aggregate count() as count"
+}
+
+// deriveLiveThreshold derives a threshold for the alert window from the number of logs matched
+// over liveEvidenceWindow and returns it with the evidence explaining it. ok is false when no
+// threshold can be derived and the default should be kept.
+func deriveLiveThreshold(total float64, cond AlertCondition) (threshold int, evidence []string, ok bool) {
+	window, err := time.ParseDuration(cond.TimeWindow)
+	if err != nil || window <= 0 {
+		window = 5 * time.Minute
+	}
+	windows := float64(liveEvidenceWindow / window)
+	perWindow := total / windows
+	perHour := total / liveEvidenceWindow.Hours()
+
+	evidence = append(evidence, fmt.Sprintf("%.0f matching logs in the last 24h: %.1f/hour on average, %.1f per %s window",
+		total, perHour, perWindow, cond.TimeWindow))
+
+	if cond.Operator == "less_than" {
+		if total == 0 {
+			evidence = append(evidence, "No matching logs in the last 24h, so a drop threshold cannot be derived; check the query with query_logs")
+			return 0, evidence, false
+		}
+		threshold = int(math.Floor(perWindow / liveThresholdMultiplier))
+		evidence = append(evidence, fmt.Sprintf("Recommended threshold: %d (half the average per %s window)", threshold, cond.TimeWindow))
+		return threshold, evidence, true
+	}
+
+	if total == 0 {
+		evidence = append(evidence, "No matching logs in the last 24h; threshold set to 1 so any occurrence fires")
+		return 1, evidence, true
+	}
+	threshold = int(math.Ceil(perWindow * liveThresholdMultiplier))
+	if threshold < 1 {
+		threshold = 1
+	}
+	evidence = append(evidence, fmt.Sprintf("Recommended threshold: %d (%dx the average per %s window)", threshold, liveThresholdMultiplier, cond.TimeWindow))
+	return threshold, evidence, true
+}
+
+// applyLiveEvidence runs one count query per count-based suggestion over the last day and
+// replaces the default threshold with one derived from the observed rate. Suggestions that
+// are not count-based, or whose query fails, keep their default threshold with a note why.
+func (t *AdvancedSuggestAlertTool) applyLiveEvidence(ctx context.Context, suggestions []AdvancedAlertSuggestion) {
+	endDate := time.Now().UTC()
+	startDate := endDate.Add(-liveEvidenceWindow)
+
+	var reqs []*client.Request
+	var indexes []int
+	for i := range suggestions {
+		if suggestions[i].BurnRateCondition != nil {
+			suggestions[i].Evidence = append(suggestions[i].Evidence,
+				"Not checked against live data: burn-rate thresholds are derived from the SLO target")
+			continue
+		}
+		q := evidenceCountQuery(suggestions[i])
+		if q == "" {
+			suggestions[i].Evidence = append(suggestions[i].Evidence,
+				fmt.Sprintf("Not checked against live data: %s thresholds are not derived from log counts", suggestions[i].Signal))
+			continue
+		}
+		query, _, err := PrepareQuery(q, "archive", "dataprime")
+		if err != nil {
+			suggestions[i].Evidence = append(suggestions[i].Evidence, fmt.Sprintf("Live data unavailable: invalid count query: %v", err))
+			continue
+		}
+		reqs = append(reqs, &client.Request{
+			Method: "POST",
+			Path:   "/v1/query",
+			Body: map[string]interface{}{
+				"query":      query,
+				"tier":       "archive",
+				"syntax":     "dataprime",
+				"start_date": startDate.Format(time.RFC3339),
+				"end_date":   endDate.Format(time.RFC3339),
+			},
+		})
+		indexes = append(indexes, i)
+	}
+	if len(reqs) == 0 {
+		return
+	}
+
+	for n, res := range t.ExecuteConcurrent(ctx, reqs, len(reqs)) {
+		s := &suggestions[indexes[n]]
+		if res.Err != nil {
+			s.Evidence = append(s.Evidence, fmt.Sprintf("Live data unavailable, default threshold kept: %v", res.Err))
+			continue
+		}
+		threshold, evidence, ok := deriveLiveThreshold(totalCount(res.Result), s.Condition)
+		s.Evidence = append(s.Evidence, evidence...)
+		if ok {
+			s.Condition.Threshold = threshold
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package tools
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestEvidenceCountQuery(t *testing.T) {
+	errors := AdvancedAlertSuggestion{
+		Signal: "errors",
+		Query:  `source logs | filter $m.severity >= 5 | stats count() as error_count by bin(5m)`,
+	}
+	if q := evidenceCountQuery(errors); q != "source logs | filter $m.severity >= 5 | aggregate count() as count" {
+		t.Errorf("unexpected count query: %s", q)
+	}
+
+	latency := AdvancedAlertSuggestion{
+		Signal: "duration",
+		Query:  `source logs | stats percentile($d.response_time_ms, 99) as p99 by bin(5m)`,
+	}
+	if q := evidenceCountQuery(latency); q != "" {
+		t.Errorf("latency alerts are not count-based, got %s", q)
+	}
+}
+
+func TestDeriveLiveThreshold(t *testing.T) {
+	// 2880 logs a day is 10 per 5m window
+	threshold, evidence, ok := deriveLiveThreshold(2880, AlertCondition{Operator: "more_than", TimeWindow: "5m"})
+	if !ok || threshold != 20 {
+		t.Errorf("threshold = %d, want 20", threshold)
+	}
+	if !strings.Contains(evidence[0], "2880 matching logs") || !strings.Contains(evidence[0], "120.0/hour") {
+		t.Errorf("unexpected evidence: %v", evidence)
+	}
+
+	if threshold, _, ok := deriveLiveThreshold(0, AlertCondition{Operator: "more_than", TimeWindow: "1m"}); !ok || threshold != 1 {
+		t.Errorf("no logs should give a threshold of 1, got %d", threshold)
+	}
+
+	if threshold, _, ok := deriveLiveThreshold(14400, AlertCondition{Operator: "less_than", TimeWindow: "10m"}); !ok || threshold != 50 {
+		t.Errorf("traffic drop threshold = %d, want 50", threshold)
+	}
+	if _, _, ok := deriveLiveThreshold(0, AlertCondition{Operator: "less_than", TimeWindow: "10m"}); ok {
+		t.Error("a drop threshold cannot be derived without traffic")
+	}
+}
+
+func TestAdvancedSuggestAlertTool_UseLiveData(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.RespondWith(200, map[string]interface{}{"events": []interface{}{map[string]interface{}{"count": 576}}})
+
+	result, err := NewAdvancedSuggestAlertTool(mock, zap.NewNop()).Execute(testCtx(mock), map[string]interface{}{
+		"use_case":      "exceptions and slow responses",
+		"service_name":  "checkout",
+		"use_live_data": true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error: %+v", result.Content)
+	}
+	var output SuggestAlertOutput
+	if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &output); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the error alert is count-based
+	if mock.RequestCount() != 1 {
+		t.Errorf("RequestCount = %d, want 1", mock.RequestCount())
+	}
+	for _, s := range output.Suggestions {
+		switch s.Signal {
+		case "errors":
+			// 576 logs a day is 2 per 5m window
+			if s.Condition.Threshold != 4 || len(s.Evidence) != 2 {
+				t.Errorf("unexpected error alert grounding: threshold %d, evidence %v", s.Condition.Threshold, s.Evidence)
+			}
+		case "duration":
+			if s.Condition.Threshold != 500 || len(s.Evidence) != 1 || !strings.Contains(s.Evidence[0], "Not checked") {
+				t.Errorf("latency alert should keep its default: threshold %d, evidence %v", s.Condition.Threshold, s.Evidence)
+			}
+		}
+	}
+}
+
+func TestAdvancedSuggestAlertTool_NoLiveDataByDefault(t *testing.T) {
+	mock := client.NewMockClient()
+	if _, err := NewAdvancedSuggestAlertTool(mock, zap.NewNop()).Execute(testCtx(mock), map[string]interface{}{
+		"use_case": "high error rate",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if mock.RequestCount() != 0 {
+		t.Errorf("suggest_alert should not query logs without use_live_data, got %d requests", mock.RequestCount())
+	}
+}
+
+func TestAdvancedSuggestAlertTool_UseCaseFollowsMethodology(t *testing.T) {
+	mock := client.NewMockClient()
+	result, err := NewAdvancedSuggestAlertTool(mock, zap.NewNop()).Execute(testCtx(mock), map[string]interface{}{
+		"use_case":     "errors, slow responses and connection pool saturation",
+		"service_name": "checkout",
+		"methodology":  "RED",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var output SuggestAlertOutput
+	if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &output); err != nil {
+		t.Fatal(err)
+	}
+	if len(output.Suggestions) == 0 {
+		t.Fatal("expected suggestions")
+	}
+	for _, s := range output.Suggestions {
+		if !slices.Contains(MethodologySignals(MethodologyRED), s.Signal) {
+			t.Errorf("suggestion %q has signal %q outside RED", s.Name, s.Signal)
+		}
+	}
+}
@@ -67,10 +67,10 @@ func getQueryResultSuggestions(result map[string]interface{}) []SmartSuggestion
 	for _, event := range events {
 		if eventMap, ok := event.(map[string]interface{}); ok {
 			// Count severities
-			if sev, ok := eventMap["severity"].(float64); ok {
-				if int(sev) == 5 {
+			if sev, ok := eventSeverity(eventMap); ok {
+				if sev == LogSeverityError {
 					errorCount++
-				} else if int(sev) == 6 {
+				} else if sev == LogSeverityCritical {
 					criticalCount++
 				}
 			}
@@ -131,7 +131,7 @@ func getQueryResultSuggestions(result map[string]interface{}) []SmartSuggestion
 	}
 
 	// Large result set - suggest dashboard
-	if len(events) >= MaxSSEEvents {
+	if maxEvents := currentMaxSSEEvents(); len(events) >= maxEvents {
 		confidence := 0.75 // Moderate confidence for dashboards
 		suggestions = append(suggestions, SmartSuggestion{
 			Tool:        "create_dashboard",
@@ -143,7 +143,7 @@ func getQueryResultSuggestions(result map[string]interface{}) []SmartSuggestion
 			Reason:     "Large result sets are better analyzed through dashboards",
 			Confidence: confidence,
 			Evidence: []string{
-				fmt.Sprintf("Result set hit maximum limit (%d events)", MaxSSEEvents),
+				fmt.Sprintf("Result set hit maximum limit (%d events)", maxEvents),
 				"Visual analysis recommended for patterns",
 			},
 		})
@@ -502,11 +502,12 @@ var staticSuggestions = map[string][]ProactiveSuggestion{
 	"move_dashboard_to_folder": {{Tool: "get_dashboard", Description: "View the moved dashboard"}, {Tool: "list_dashboard_folders", Description: "View all folders"}},
 
 	// Alert tools
-	"list_alerts":  {{Tool: "get_alert", Description: "Get details of a specific alert"}, {Tool: "create_alert", Description: "Create a new alert"}},
-	"get_alert":    {{Tool: "update_alert", Description: "Modify this alert configuration"}, {Tool: "activate_alert", Description: "Activate/deactivate this alert"}},
-	"create_alert": {{Tool: "list_alerts", Description: "View all alerts including the new one"}, {Tool: "query_logs", Description: "Test the alert condition with a query"}},
-	"update_alert": {{Tool: "get_alert", Description: "View the updated alert"}, {Tool: "list_alerts", Description: "View all alerts"}},
-	"delete_alert": {{Tool: "list_alerts", Description: "View remaining alerts"}},
+	"list_alerts":        {{Tool: "get_alert", Description: "Get details of a specific alert"}, {Tool: "create_alert", Description: "Create a new alert"}},
+	"get_alert":          {{Tool: "update_alert", Description: "Modify this alert configuration"}, {Tool: "activate_alert", Description: "Activate/deactivate this alert"}},
+	"create_alert":       {{Tool: "list_alerts", Description: "View all alerts including the new one"}, {Tool: "query_logs", Description: "Test the alert condition with a query"}},
+	"bulk_create_alerts": {{Tool: "list_alerts", Description: "View all alerts including the new ones"}},
+	"update_alert":       {{Tool: "get_alert", Description: "View the updated alert"}, {Tool: "list_alerts", Description: "View all alerts"}},
+	"delete_alert":       {{Tool: "list_alerts", Description: "View remaining alerts"}},
 
 	// Alert definition tools
 	"list_alert_definitions":  {{Tool: "get_alert_definition", Description: "Get details of a specific alert definition"}, {Tool: "create_alert_definition", Description: "Create a new alert definition"}},
@@ -573,6 +574,12 @@ var staticSuggestions = map[string][]ProactiveSuggestion{
 	"update_enrichment": {{Tool: "list_enrichments", Description: "View all enrichments"}},
 	"delete_enrichment": {{Tool: "list_enrichments", Description: "View remaining enrichments"}},
 
+	// Data set tools
+	"list_data_sets":  {{Tool: "get_data_set", Description: "Get details of a specific data set"}, {Tool: "create_data_set", Description: "Upload a new data set"}},
+	"get_data_set":    {{Tool: "create_enrichment", Description: "Use this data set as the lookup table of a custom enrichment"}},
+	"create_data_set": {{Tool: "create_enrichment", Description: "Create a custom_enrichment with this lookup_table_id"}},
+	"delete_data_set": {{Tool: "list_data_sets", Description: "View remaining data sets"}},
+
 	// View tools
 	"list_views":   {{Tool: "get_view", Description: "Get details of a specific view"}, {Tool: "create_view", Description: "Create a new view"}},
 	"get_view":     {{Tool: "replace_view", Description: "Replace this view"}, {Tool: "delete_view", Description: "Remove this view"}},
@@ -0,0 +1,308 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// terraformResourceSpec describes how an API resource maps to a Terraform resource
+type terraformResourceSpec struct {
+	TFType  string // Terraform resource type (e.g., ibm_logs_alert)
+	Path    string // API collection path
+	ListKey string // Key holding the items in the list response
+}
+
+// terraformResourceSpecs maps export_terraform resource types to their API and provider details
+var terraformResourceSpecs = map[string]terraformResourceSpec{
+	"alert":            {TFType: "ibm_logs_alert", Path: "/v1/alerts", ListKey: "alerts"},
+	"policy":           {TFType: "ibm_logs_policy", Path: "/v1/policies", ListKey: "policies"},
+	"e2m":              {TFType: "ibm_logs_e2m", Path: "/v1/events2metrics", ListKey: "events2metrics"},
+	"outgoing_webhook": {TFType: "ibm_logs_outgoing_webhook", Path: "/v1/outgoing_webhooks", ListKey: "outgoing_webhooks"},
+	"view":             {TFType: "ibm_logs_view", Path: "/v1/views", ListKey: "views"},
+}
+
+// serverManagedFields are computed by the service and must not appear in configuration or diffs
+var serverManagedFields = map[string]bool{
+	"id":                true,
+	"created_at":        true,
+	"updated_at":        true,
+	"created_by":        true,
+	"updated_by":        true,
+	"company_id":        true,
+	"unique_identifier": true,
+	"external_id":       true,
+	"last_triggered_at": true,
+}
+
+// ExportTerraformTool renders existing resources as Terraform configuration
+type ExportTerraformTool struct{ *BaseTool }
+
+// NewExportTerraformTool creates a new tool instance
+func NewExportTerraformTool(c client.Doer, l *zap.Logger) *ExportTerraformTool {
+	return &ExportTerraformTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *ExportTerraformTool) Name() string { return "export_terraform" }
+
+// Annotations returns tool hints for LLMs
+func (t *ExportTerraformTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Export Terraform")
+}
+
+// DefaultTimeout returns the timeout
+func (t *ExportTerraformTool) DefaultTimeout() time.Duration {
+	return DefaultListTimeout
+}
+
+// Description returns the tool description
+func (t *ExportTerraformTool) Description() string {
+	return `Export existing resources as Terraform configuration using the IBM Cloud provider (ibm_logs_* resources).
+
+Fetches the resource(s) and renders HCL resource blocks with server-managed fields (id, timestamps) omitted,
+so the output can be used to bring manually created resources under infrastructure-as-code.
+
+**Supported resource types:** alert, policy, e2m, outgoing_webhook, view
+
+Use id "all" to export every resource of the given type.
+
+**Related tools:** get_alert, get_policy, get_e2m, get_outgoing_webhook, get_view`
+}
+
+// InputSchema returns the input schema
+func (t *ExportTerraformTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"resource_type": map[string]interface{}{
+				"type":        "string",
+				"description": "Type of resource to export",
+				"enum":        []string{"alert", "policy", "e2m", "outgoing_webhook", "view"},
+			},
+			"id": map[string]interface{}{
+				"type":        "string",
+				"description": "ID of the resource to export, or \"all\" to export every resource of the type",
+			},
+		},
+		"required": []string{"resource_type", "id"},
+	}
+}
+
+// Execute executes the tool
+func (t *ExportTerraformTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	resourceType, err := GetStringParam(args, "resource_type", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	spec, ok := terraformResourceSpecs[resourceType]
+	if !ok {
+		return NewToolResultError(fmt.Sprintf("Unsupported resource_type '%s'. Valid types: alert, policy, e2m, outgoing_webhook, view", resourceType)), nil
+	}
+	id, err := GetStringParam(args, "id", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+
+	var resources []map[string]interface{}
+	if id == "all" {
+		res, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: spec.Path})
+		if err != nil {
+			return NewToolResultErrorFromErr(err), nil
+		}
+		items, _ := res[spec.ListKey].([]interface{})
+		for _, item := range items {
+			if m, ok := item.(map[string]interface{}); ok {
+				resources = append(resources, m)
+			}
+		}
+	} else {
+		res, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: spec.Path + "/" + id})
+		if err != nil {
+			return HandleGetError(err, resourceType, id, "export_terraform"), nil
+		}
+		resources = append(resources, res)
+	}
+
+	if len(resources) == 0 {
+		return NewToolResultError(fmt.Sprintf("No %s resources found to export", resourceType)), nil
+	}
+
+	hcl := RenderTerraform(spec.TFType, resources)
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: fmt.Sprintf("Exported %d %s resource(s) as Terraform:\n\n```hcl\n%s```", len(resources), resourceType, hcl),
+			},
+		},
+	}, nil
+}
+
+// RenderTerraform renders API resources as Terraform resource blocks of the given type.
+// Server-managed fields are omitted and instance_id/region are bound to variables.
+func RenderTerraform(tfType string, resources []map[string]interface{}) string {
+	var sb strings.Builder
+
+	sb.WriteString("variable \"logs_instance_id\" {\n  description = \"GUID of the IBM Cloud Logs instance\"\n  type        = string\n}\n\n")
+	sb.WriteString("variable \"logs_region\" {\n  description = \"Region of the IBM Cloud Logs instance\"\n  type        = string\n}\n")
+
+	usedNames := make(map[string]int)
+	for i, res := range resources {
+		name, _ := res["name"].(string)
+		resourceName := terraformIdentifier(name)
+		if resourceName == "" {
+			resourceName = fmt.Sprintf("%s_%d", strings.TrimPrefix(tfType, "ibm_logs_"), i+1)
+		}
+		usedNames[resourceName]++
+		if n := usedNames[resourceName]; n > 1 {
+			resourceName = fmt.Sprintf("%s_%d", resourceName, n)
+		}
+
+		if id, ok := res["id"]; ok {
+			fmt.Fprintf(&sb, "\n# Imported from existing resource: %v\n", id)
+		} else {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "resource \"%s\" \"%s\" {\n", tfType, resourceName)
+		sb.WriteString("  instance_id = var.logs_instance_id\n")
+		sb.WriteString("  region      = var.logs_region\n")
+		writeHCLBody(&sb, res, 1, true)
+		sb.WriteString("}\n")
+	}
+
+	return sb.String()
+}
+
+// writeHCLBody writes attributes (aligned) followed by nested blocks
+func writeHCLBody(sb *strings.Builder, obj map[string]interface{}, depth int, topLevel bool) {
+	indent := strings.Repeat("  ", depth)
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		if topLevel && serverManagedFields[k] {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var attrs, blocks []string
+	width := 0
+	for _, k := range keys {
+		switch v := obj[k].(type) {
+		case nil:
+			continue
+		case map[string]interface{}:
+			if len(v) > 0 {
+				blocks = append(blocks, k)
+			}
+		case []interface{}:
+			if len(v) > 0 && isListOfObjects(v) {
+				blocks = append(blocks, k)
+			} else {
+				attrs = append(attrs, k)
+			}
+		default:
+			attrs = append(attrs, k)
+		}
+	}
+	for _, k := range attrs {
+		if len(k) > width {
+			width = len(k)
+		}
+	}
+
+	for _, k := range attrs {
+		fmt.Fprintf(sb, "%s%-*s = %s\n", indent, width, k, hclValue(obj[k]))
+	}
+
+	for _, k := range blocks {
+		switch v := obj[k].(type) {
+		case map[string]interface{}:
+			fmt.Fprintf(sb, "%s%s {\n", indent, k)
+			writeHCLBody(sb, v, depth+1, false)
+			fmt.Fprintf(sb, "%s}\n", indent)
+		case []interface{}:
+			for _, item := range v {
+				fmt.Fprintf(sb, "%s%s {\n", indent, k)
+				writeHCLBody(sb, item.(map[string]interface{}), depth+1, false)
+				fmt.Fprintf(sb, "%s}\n", indent)
+			}
+		}
+	}
+}
+
+// isListOfObjects reports whether every element of the list is an object
+func isListOfObjects(list []interface{}) bool {
+	for _, item := range list {
+		if _, ok := item.(map[string]interface{}); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// hclValue renders a scalar or list of scalars as an HCL expression
+func hclValue(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return hclString(val)
+	case bool:
+		return strconv.FormatBool(val)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(val)
+	case []interface{}:
+		parts := make([]string, 0, len(val))
+		for _, item := range val {
+			parts = append(parts, hclValue(item))
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	default:
+		return hclString(fmt.Sprintf("%v", val))
+	}
+}
+
+// hclString quotes a string for HCL, escaping quotes, control characters and
+// template sequences so the value is taken literally.
+func hclString(s string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"\n", `\n`,
+		"\r", `\r`,
+		"\t", `\t`,
+		"${", "$${",
+		"%{", "%%{",
+	)
+	return `"` + replacer.Replace(s) + `"`
+}
+
+// terraformIdentifier converts a resource name into a valid Terraform identifier
+func terraformIdentifier(name string) string {
+	var sb strings.Builder
+	lastUnderscore := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+			lastUnderscore = false
+		} else if !lastUnderscore && sb.Len() > 0 {
+			sb.WriteRune('_')
+			lastUnderscore = true
+		}
+	}
+	id := strings.TrimSuffix(sb.String(), "_")
+	if id != "" && id[0] >= '0' && id[0] <= '9' {
+		id = "r_" + id
+	}
+	return id
+}
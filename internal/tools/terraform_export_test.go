@@ -0,0 +1,134 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestRenderTerraform(t *testing.T) {
+	resources := []map[string]interface{}{
+		{
+			"id":          "abc-123",
+			"created_at":  "2024-01-01T00:00:00Z",
+			"name":        "High \"Error\" Rate",
+			"description": "Fires on ${var}\nsecond line",
+			"is_active":   true,
+			"priority":    float64(2),
+			"tags":        []interface{}{"prod", "api"},
+			"condition": map[string]interface{}{
+				"threshold": float64(5.5),
+			},
+			"notifications": []interface{}{
+				map[string]interface{}{"integration_id": float64(7)},
+				map[string]interface{}{"integration_id": float64(8)},
+			},
+		},
+	}
+
+	hcl := RenderTerraform("ibm_logs_alert", resources)
+
+	checks := []string{
+		`resource "ibm_logs_alert" "high_error_rate" {`,
+		`  instance_id = var.logs_instance_id`,
+		`  name        = "High \"Error\" Rate"`,
+		`  description = "Fires on $${var}\nsecond line"`,
+		`  is_active   = true`,
+		`  priority    = 2`,
+		`  tags        = ["prod", "api"]`,
+		"  condition {\n    threshold = 5.5\n  }",
+		"  notifications {\n    integration_id = 7\n  }\n  notifications {\n    integration_id = 8\n  }",
+	}
+	for _, want := range checks {
+		if !strings.Contains(hcl, want) {
+			t.Errorf("Rendered HCL missing %q\n%s", want, hcl)
+		}
+	}
+
+	if strings.Contains(hcl, "created_at") || strings.Contains(hcl, "  id ") {
+		t.Errorf("Server-managed fields should be omitted\n%s", hcl)
+	}
+}
+
+func TestRenderTerraform_DuplicateNames(t *testing.T) {
+	hcl := RenderTerraform("ibm_logs_view", []map[string]interface{}{
+		{"name": "errors"},
+		{"name": "errors"},
+		{"name": ""},
+	})
+
+	for _, want := range []string{`"errors" {`, `"errors_2" {`, `"view_3" {`} {
+		if !strings.Contains(hcl, want) {
+			t.Errorf("Rendered HCL missing %q\n%s", want, hcl)
+		}
+	}
+}
+
+func TestTerraformIdentifier(t *testing.T) {
+	tests := map[string]string{
+		"High Error Rate": "high_error_rate",
+		"api--5xx!!":      "api_5xx",
+		"5xx errors":      "r_5xx_errors",
+		"***":             "",
+	}
+	for in, want := range tests {
+		if got := terraformIdentifier(in); got != want {
+			t.Errorf("terraformIdentifier(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestExportTerraformTool_Execute_All(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.RespondWith(200, map[string]interface{}{
+		"policies": []interface{}{
+			map[string]interface{}{"id": "p1", "name": "keep errors", "priority": "type_high"},
+			map[string]interface{}{"id": "p2", "name": "drop debug", "priority": "type_block"},
+		},
+	})
+
+	tool := NewExportTerraformTool(mock, zap.NewNop())
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{
+		"resource_type": "policy",
+		"id":            "all",
+	})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Expected success, got error result")
+	}
+
+	if req := mock.LastRequest(); req.Path != "/v1/policies" {
+		t.Errorf("Path = %q, want /v1/policies", req.Path)
+	}
+
+	text := result.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(text, `resource "ibm_logs_policy" "keep_errors"`) ||
+		!strings.Contains(text, `resource "ibm_logs_policy" "drop_debug"`) {
+		t.Errorf("Expected both policies in output, got:\n%s", text)
+	}
+}
+
+func TestExportTerraformTool_Execute_InvalidType(t *testing.T) {
+	mock := client.NewMockClient()
+	tool := NewExportTerraformTool(mock, zap.NewNop())
+
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{
+		"resource_type": "dashboard",
+		"id":            "x",
+	})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	if !result.IsError {
+		t.Error("Expected error result for unsupported resource type")
+	}
+	if mock.RequestCount() != 0 {
+		t.Errorf("No API request should be made, got %d", mock.RequestCount())
+	}
+}
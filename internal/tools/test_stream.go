@@ -0,0 +1,207 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements test_stream, which previews the logs a stream's DPXL filter would export.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+const (
+	// defaultTestStreamSamples is the number of sample records returned unless set
+	defaultTestStreamSamples = 5
+	// maxTestStreamSamples caps the sample records returned
+	maxTestStreamSamples = 50
+)
+
+// TestStreamTool runs a stream's DPXL filter over a recent window and returns sample records
+type TestStreamTool struct{ *BaseTool }
+
+// NewTestStreamTool creates a new tool instance
+func NewTestStreamTool(c client.Doer, l *zap.Logger) *TestStreamTool {
+	return &TestStreamTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *TestStreamTool) Name() string { return "test_stream" }
+
+// Annotations returns tool hints for LLMs
+func (t *TestStreamTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Test Stream")
+}
+
+// DefaultTimeout returns the timeout for the sample query
+func (t *TestStreamTool) DefaultTimeout() time.Duration {
+	return DefaultQueryTimeout
+}
+
+// Description returns the tool description
+func (t *TestStreamTool) Description() string {
+	return fmt.Sprintf(`Preview what a stream would export before creating or changing it.
+
+Takes an existing stream id or a stream config, translates its DPXL filter into a DataPrime query,
+runs it over a recent window (default: last 15m) and returns up to sample_size records (default %d,
+max %d) as they would be sent, with the effective format and compression. When the filter matches
+nothing in the window, the result says so explicitly, since such a stream would export nothing.
+
+**Related tools:** create_stream, update_stream, get_stream`, defaultTestStreamSamples, maxTestStreamSamples)
+}
+
+// InputSchema returns the input schema
+func (t *TestStreamTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"stream_id": map[string]interface{}{
+				"type":        "string",
+				"description": "ID of an existing stream to test",
+			},
+			"stream": map[string]interface{}{
+				"type":        "object",
+				"description": "Stream config to test before creating it (same fields as create_stream)",
+				"properties": map[string]interface{}{
+					"dpxl_expression":  map[string]interface{}{"type": "string"},
+					"compression_type": streamCompressionSchema,
+					"format":           streamFormatSchema,
+				},
+				"required": []string{"dpxl_expression"},
+			},
+			"time_range": map[string]interface{}{
+				"type":        "string",
+				"description": "Recent window to sample (default: 15m)",
+				"enum":        []string{"15m", "1h", "6h", "24h"},
+				"default":     "15m",
+			},
+			"sample_size": map[string]interface{}{
+				"type":        "integer",
+				"description": fmt.Sprintf("Maximum records to return (default: %d, max: %d)", defaultTestStreamSamples, maxTestStreamSamples),
+				"minimum":     1,
+				"maximum":     maxTestStreamSamples,
+			},
+		},
+		"examples": []interface{}{
+			map[string]interface{}{"stream_id": "42"},
+			map[string]interface{}{
+				"stream": map[string]interface{}{
+					"dpxl_expression":  "<v1>applicationname == \"api-gateway\" && severity >= 5",
+					"compression_type": "gzip",
+				},
+			},
+		},
+	}
+}
+
+// Execute executes the tool
+func (t *TestStreamTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	streamID, _ := GetStringParam(args, "stream_id", false)
+	stream, _ := GetObjectParam(args, "stream", false)
+	switch {
+	case streamID != "" && stream != nil:
+		return NewToolResultError("provide either stream_id or stream, not both"), nil
+	case streamID != "":
+		found, errResult := t.findStream(ctx, streamID)
+		if errResult != nil {
+			return errResult, nil
+		}
+		stream = found
+	case stream == nil:
+		return NewToolResultError("either stream_id or stream is required"), nil
+	}
+
+	dpxl, _ := stream["dpxl_expression"].(string)
+	filter, err := DPXLToDataPrime(dpxl)
+	if err != nil {
+		return dpxlErrorResult(err), nil
+	}
+	var effective map[string]interface{}
+	if streamID != "" {
+		// An existing stream's settings were accepted by the API; report them as stored
+		compression, _ := stream["compression_type"].(string)
+		if compression == "" {
+			compression = "unspecified"
+		}
+		effective = map[string]interface{}{"destination": "ibm_event_streams", "format": "json", "compression_type": compression}
+	} else if effective, err = resolveStreamOutput(stream, map[string]interface{}{}); err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+
+	window := map[string]interface{}{"time_range": "15m"}
+	if tr, _ := GetStringParam(args, "time_range", false); tr != "" {
+		if tr == "7d" {
+			return NewToolResultError("time_range must be one of 15m, 1h, 6h, 24h"), nil
+		}
+		window["time_range"] = tr
+	}
+	start, end, err := resolveQueryWindow(window)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	sampleSize, err := GetIntParam(args, "sample_size", false)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	if sampleSize <= 0 {
+		sampleSize = defaultTestStreamSamples
+	}
+	if sampleSize > maxTestStreamSamples {
+		sampleSize = maxTestStreamSamples
+	}
+
+	query, _, err := PrepareQuery("source logs | filter "+filter, "frequent_search", "dataprime")
+	if err != nil {
+		return NewToolResultError(fmt.Sprintf("The DPXL filter could not be run as a query: %v", err)), nil
+	}
+	result, err := t.ExecuteRequestWithMaxEvents(ctx, &client.Request{
+		Method: "POST",
+		Path:   "/v1/query",
+		Body: map[string]interface{}{
+			"query": query,
+			"metadata": map[string]interface{}{
+				"tier":       "frequent_search",
+				"syntax":     "dataprime",
+				"start_date": start.Format(time.RFC3339),
+				"end_date":   end.Format(time.RFC3339),
+				"limit":      sampleSize,
+			},
+		},
+		AcceptSSE: true,
+		Timeout:   DefaultQueryTimeout,
+	}, sampleSize)
+	if err != nil {
+		return NewToolResultError(FormatQueryError(query, err.Error())), nil
+	}
+
+	records, _ := result["events"].([]interface{})
+	if records == nil {
+		records = []interface{}{}
+	}
+	response := map[string]interface{}{
+		"dpxl_expression":  dpxl,
+		"query":            query,
+		"window":           map[string]interface{}{"start_date": start.Format(time.RFC3339), "end_date": end.Format(time.RFC3339)},
+		"effective_config": effective,
+		"sample_count":     len(records),
+		"records":          records,
+	}
+	if streamID != "" {
+		response["stream_id"] = streamID
+	}
+	if len(records) == 0 {
+		response["matched"] = false
+		response["message"] = fmt.Sprintf("The filter matched no logs between %s and %s, so this stream would export nothing. "+
+			"Check field names and values against recent logs with query_logs, or widen time_range.",
+			start.Format(time.RFC3339), end.Format(time.RFC3339))
+	} else {
+		response["matched"] = true
+		if c := effective["compression_type"]; c != "unspecified" {
+			response["note"] = fmt.Sprintf("Records are shown uncompressed; the stream sends them %s-compressed.", c)
+		}
+	}
+	return t.FormatResponse(response)
+}
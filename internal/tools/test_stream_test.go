@@ -0,0 +1,96 @@
+package tools
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestDPXLToDataPrime(t *testing.T) {
+	tests := map[string]string{
+		`<v1>contains(kubernetes.labels.app, "frontend")`:             `contains($d.kubernetes.labels.app, 'frontend')`,
+		`<v1>applicationname == "api" && severity >= 5`:               `$l.applicationname == 'api' && $m.severity >= ERROR`,
+		`<v1>!(subsystemname == "probe" || message == "it's \"ok\"")`: `!($l.subsystemname == 'probe' || $d.message == 'it\'s "ok"')`,
+		`<v1>$d.status_code != 200`:                                   `$d.status_code != 200`,
+		`<v1>enabled == true`:                                         `$d.enabled == true`,
+	}
+	for dpxl, want := range tests {
+		got, err := DPXLToDataPrime(dpxl)
+		require.NoError(t, err, dpxl)
+		assert.Equal(t, want, got, dpxl)
+	}
+
+	_, err := DPXLToDataPrime(`<v1>severity = 5`)
+	assert.Error(t, err)
+}
+
+func TestTestStreamTool_SampleForConfig(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.Responses = append(mock.Responses, sseQueryResponse([][2]string{{"api", "5"}, {"api", "5"}}))
+	tool := NewTestStreamTool(mock, zap.NewNop())
+
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{
+		"stream": map[string]interface{}{"dpxl_expression": `<v1>applicationname == "api"`, "compression_type": "gzip"},
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError, "%+v", result.Content)
+
+	var resp struct {
+		Matched         bool                   `json:"matched"`
+		SampleCount     int                    `json:"sample_count"`
+		Records         []interface{}          `json:"records"`
+		EffectiveConfig map[string]interface{} `json:"effective_config"`
+		Note            string                 `json:"note"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &resp))
+	assert.True(t, resp.Matched)
+	assert.Equal(t, 2, resp.SampleCount)
+	assert.Len(t, resp.Records, 2)
+	assert.Equal(t, "gzip", resp.EffectiveConfig["compression_type"])
+	assert.Contains(t, resp.Note, "gzip-compressed")
+
+	query := mock.LastRequest().Body.(map[string]interface{})["query"].(string)
+	assert.Contains(t, query, "filter $l.applicationname == 'api'")
+	assert.Equal(t, "frequent_search", mock.LastRequest().Body.(map[string]interface{})["metadata"].(map[string]interface{})["tier"])
+}
+
+func TestTestStreamTool_NoMatchesByID(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.RespondWith(200, map[string]interface{}{"streams": []interface{}{
+		map[string]interface{}{"id": 42, "name": "siem", "dpxl_expression": `<v1>severity >= 5`, "compression_type": "gzip"},
+	}})
+	mock.Responses = append(mock.Responses, &client.Response{StatusCode: 200, Body: []byte(`data: {"query_id":{"query_id":"q1"}}` + "\n")})
+	tool := NewTestStreamTool(mock, zap.NewNop())
+
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{"stream_id": "42", "time_range": "1h"})
+	require.NoError(t, err)
+	require.False(t, result.IsError, "%s", result.Content[0].(*mcp.TextContent).Text)
+	text := result.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, `"matched": false`)
+	assert.Contains(t, text, "would export nothing")
+	assert.Equal(t, 2, mock.RequestCount())
+	assert.Contains(t, mock.LastRequest().Body.(map[string]interface{})["query"], "$m.severity >= ERROR")
+}
+
+func TestTestStreamTool_InvalidArguments(t *testing.T) {
+	mock := client.NewMockClient()
+	tool := NewTestStreamTool(mock, zap.NewNop())
+	for _, args := range []map[string]interface{}{
+		{},
+		{"stream_id": "1", "stream": map[string]interface{}{"dpxl_expression": "<v1>a == 1"}},
+		{"stream": map[string]interface{}{"dpxl_expression": "<v1>a = 1"}},
+		{"stream": map[string]interface{}{"dpxl_expression": "<v1>a == 1", "format": "parquet"}},
+		{"stream": map[string]interface{}{"dpxl_expression": "<v1>a == 1"}, "time_range": "7d"},
+	} {
+		result, err := tool.Execute(testCtx(mock), args)
+		require.NoError(t, err)
+		assert.True(t, result.IsError, "%v", args)
+	}
+	assert.Zero(t, mock.RequestCount())
+}
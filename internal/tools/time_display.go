@@ -0,0 +1,241 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements the display timezone and format of log timestamps.
+package tools
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Named display time formats accepted by LOGS_DISPLAY_TIME_FORMAT; any other value is used
+// as a Go reference-time layout
+var displayTimeFormats = map[string]string{
+	"rfc3339":  time.RFC3339,
+	"datetime": "2006-01-02 15:04:05 MST",
+	"time":     "15:04:05 MST",
+}
+
+// TimeDisplay controls how log timestamps are rendered in query output
+type TimeDisplay struct {
+	Location *time.Location
+	Layout   string
+
+	// RelativeTo is the reference for "(3m ago)" annotations on log entries; zero disables them
+	RelativeTo time.Time
+	// RelativeToEnd is true when RelativeTo is the query's end time rather than now
+	RelativeToEnd bool
+}
+
+// defaultTimeDisplay is the server-wide display setting from configuration
+var (
+	timeDisplayMu      sync.RWMutex
+	defaultTimeDisplay = TimeDisplay{Location: time.UTC, Layout: time.RFC3339}
+)
+
+// ParseDisplayTimeFormat resolves a named format (rfc3339, datetime, time) or a Go layout.
+// Empty selects rfc3339.
+func ParseDisplayTimeFormat(format string) (string, error) {
+	if format == "" {
+		return time.RFC3339, nil
+	}
+	if layout, ok := displayTimeFormats[strings.ToLower(format)]; ok {
+		return layout, nil
+	}
+	if !strings.Contains(format, "15") && !strings.Contains(format, "2006") && !strings.Contains(format, "03") {
+		return "", fmt.Errorf("invalid time format %q: use rfc3339, datetime, time, or a Go layout such as \"2006-01-02 15:04:05\"", format)
+	}
+	return format, nil
+}
+
+// loadDisplayLocation resolves an IANA timezone name such as "Europe/Berlin". Empty selects UTC.
+func loadDisplayLocation(name string) (*time.Location, error) {
+	if name == "" || strings.EqualFold(name, "utc") {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q: use an IANA name such as \"America/New_York\" or \"UTC\"", name)
+	}
+	return loc, nil
+}
+
+// SetTimeDisplay sets the default timezone and format for timestamps in query output
+func SetTimeDisplay(timezone, format string) error {
+	loc, err := loadDisplayLocation(timezone)
+	if err != nil {
+		return err
+	}
+	layout, err := ParseDisplayTimeFormat(format)
+	if err != nil {
+		return err
+	}
+	timeDisplayMu.Lock()
+	defaultTimeDisplay = TimeDisplay{Location: loc, Layout: layout}
+	timeDisplayMu.Unlock()
+	return nil
+}
+
+// currentTimeDisplay returns the configured default time display
+func currentTimeDisplay() TimeDisplay {
+	timeDisplayMu.RLock()
+	defer timeDisplayMu.RUnlock()
+	return defaultTimeDisplay
+}
+
+// timezoneSchema is the schema of the timezone argument of the query tools
+var timezoneSchema = map[string]interface{}{
+	"type":        "string",
+	"description": "IANA timezone for displayed timestamps, e.g. 'America/New_York' or 'Asia/Kolkata'. The raw ISO timestamp is shown alongside. Defaults to the server setting (LOGS_DISPLAY_TIMEZONE, UTC).",
+	"examples":    []string{"UTC", "Europe/Berlin", "America/Los_Angeles"},
+}
+
+// relativeTimeSchema is the schema of the relative_time argument of the query tools
+var relativeTimeSchema = map[string]interface{}{
+	"type":        "boolean",
+	"description": "Annotate each log entry with its age, e.g. '(3m ago)', or '(3m before end)' when the query ended in the past. Set false for machine-readable output. Default: true.",
+	"default":     true,
+}
+
+// getTimezoneArg reads and validates the optional timezone argument
+func getTimezoneArg(args map[string]interface{}) (string, error) {
+	tz, _ := GetStringParam(args, "timezone", false)
+	if tz == "" {
+		return "", nil
+	}
+	if _, err := loadDisplayLocation(tz); err != nil {
+		return "", err
+	}
+	return tz, nil
+}
+
+// setResultTimezone records a display timezone in the result's _query_metadata so the
+// formatters render timestamps in that zone. An empty tz keeps the configured default.
+func setResultTimezone(result map[string]interface{}, tz string) {
+	if tz == "" {
+		return
+	}
+	meta, ok := result["_query_metadata"].(map[string]interface{})
+	if !ok {
+		meta = make(map[string]interface{})
+		result["_query_metadata"] = meta
+	}
+	meta["timezone"] = tz
+}
+
+// getRelativeTimeArg reads the optional relative_time argument, which defaults to true
+func getRelativeTimeArg(args map[string]interface{}) bool {
+	if _, ok := args["relative_time"]; !ok {
+		return true
+	}
+	enabled, err := GetBoolParam(args, "relative_time", false)
+	return err != nil || enabled
+}
+
+// setResultRelativeTime records in the result's _query_metadata that relative-time
+// annotations were turned off with relative_time=false
+func setResultRelativeTime(result map[string]interface{}, enabled bool) {
+	if enabled {
+		return
+	}
+	meta, ok := result["_query_metadata"].(map[string]interface{})
+	if !ok {
+		meta = make(map[string]interface{})
+		result["_query_metadata"] = meta
+	}
+	meta["relative_time"] = false
+}
+
+// timeDisplayFromResult returns the time display for a query result: the timezone recorded in
+// its _query_metadata (from the timezone argument) or the configured default. Relative
+// annotations are computed against the query's end time when it is in the past, otherwise now.
+func timeDisplayFromResult(result map[string]interface{}) TimeDisplay {
+	display := currentTimeDisplay()
+	meta, _ := result["_query_metadata"].(map[string]interface{})
+	if tz, ok := meta["timezone"].(string); ok && tz != "" {
+		if loc, err := loadDisplayLocation(tz); err == nil {
+			display.Location = loc
+		}
+	}
+	if enabled, ok := meta["relative_time"].(bool); ok && !enabled {
+		return display
+	}
+	display.RelativeTo = time.Now()
+	if endStr, ok := meta["end_date"].(string); ok {
+		if end, err := time.Parse(time.RFC3339Nano, endStr); err == nil && end.Before(display.RelativeTo) {
+			display.RelativeTo, display.RelativeToEnd = end, true
+		}
+	}
+	return display
+}
+
+// isRaw reports whether timestamps are shown exactly as returned by the API (UTC, RFC 3339)
+func (d TimeDisplay) isRaw() bool {
+	return (d.Location == nil || d.Location == time.UTC) && (d.Layout == "" || d.Layout == time.RFC3339)
+}
+
+// Format renders an ISO 8601 timestamp in the display timezone and layout. Timestamps are
+// returned unchanged with the default UTC/RFC 3339 setting or when they cannot be parsed.
+func (d TimeDisplay) Format(raw string) string {
+	if d.isRaw() {
+		return raw
+	}
+	t, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return raw
+	}
+	return t.In(d.Location).Format(d.Layout)
+}
+
+// Relative returns an annotation such as "(3m ago)" or "(2h before end)" for a timestamp,
+// "(in the future)" for timestamps after the reference (clock skew), or "" when relative
+// annotations are off or the timestamp cannot be parsed
+func (d TimeDisplay) Relative(raw string) string {
+	if d.RelativeTo.IsZero() {
+		return ""
+	}
+	t, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return ""
+	}
+	age := d.RelativeTo.Sub(t)
+	if age < 0 {
+		if d.RelativeToEnd {
+			return "(after end)"
+		}
+		return "(in the future)"
+	}
+	if d.RelativeToEnd {
+		return "(" + shortDuration(age) + " before end)"
+	}
+	return "(" + shortDuration(age) + " ago)"
+}
+
+// shortDuration renders a duration compactly: 45s, 3m, 2h15m, 5d
+func shortDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		h, m := int(d.Hours()), int(d.Minutes())%60
+		if m == 0 {
+			return fmt.Sprintf("%dh", h)
+		}
+		return fmt.Sprintf("%dh%dm", h, m)
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+// FormatWithRaw renders a timestamp like Format and appends the original ISO value when
+// the two differ, so the exact value stays available for follow-up queries
+func (d TimeDisplay) FormatWithRaw(raw string) string {
+	formatted := d.Format(raw)
+	if formatted == raw {
+		return raw
+	}
+	return fmt.Sprintf("%s (%s)", formatted, raw)
+}
@@ -0,0 +1,140 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestTimeDisplayFormat(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+	raw := "2024-05-01T20:47:12.940Z"
+
+	if got := (TimeDisplay{Location: time.UTC, Layout: time.RFC3339}).Format(raw); got != raw {
+		t.Errorf("Default display should keep the raw value, got %q", got)
+	}
+	if got := (TimeDisplay{Location: berlin, Layout: time.RFC3339}).Format(raw); got != "2024-05-01T22:47:12+02:00" {
+		t.Errorf("Unexpected Berlin time: %q", got)
+	}
+	display := TimeDisplay{Location: berlin, Layout: displayTimeFormats["datetime"]}
+	if got := display.FormatWithRaw(raw); got != "2024-05-01 22:47:12 CEST ("+raw+")" {
+		t.Errorf("Unexpected formatted value with raw: %q", got)
+	}
+	if got := display.Format("not a time"); got != "not a time" {
+		t.Errorf("Unparseable values should pass through, got %q", got)
+	}
+}
+
+func TestParseDisplayTimeFormat(t *testing.T) {
+	if layout, err := ParseDisplayTimeFormat("DateTime"); err != nil || layout != "2006-01-02 15:04:05 MST" {
+		t.Errorf("Expected named format, got %q %v", layout, err)
+	}
+	if layout, err := ParseDisplayTimeFormat("02 Jan 15:04"); err != nil || layout != "02 Jan 15:04" {
+		t.Errorf("Expected Go layout to be accepted, got %q %v", layout, err)
+	}
+	if _, err := ParseDisplayTimeFormat("yyyy-mm-dd"); err == nil {
+		t.Error("Expected non-layout format to be rejected")
+	}
+	if err := SetTimeDisplay("Mars/Olympus", ""); err == nil {
+		t.Error("Expected unknown timezone to be rejected")
+	}
+}
+
+func TestQueryTool_TimezoneParameter(t *testing.T) {
+	if _, err := time.LoadLocation("Asia/Tokyo"); err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+	mock := client.NewMockClient()
+	mock.DefaultResponse = &client.Response{
+		StatusCode: 200,
+		Body:       []byte("data: {\"result\":{\"results\":[{\"metadata\":[{\"key\":\"timestamp\",\"value\":\"2024-05-01T20:47:12Z\"}],\"labels\":[{\"key\":\"applicationname\",\"value\":\"api\"}],\"user_data\":\"{\\\"message\\\":\\\"boom\\\"}\"}]}}\n"),
+	}
+	tool := NewQueryTool(mock, zap.NewNop())
+	args := map[string]interface{}{
+		"query":      "source logs",
+		"start_date": "2024-05-01T20:00:00Z",
+		"end_date":   "2024-05-01T21:00:00Z",
+		"timezone":   "Asia/Tokyo",
+	}
+
+	result, err := tool.Execute(testCtx(mock), args)
+	if err != nil || result.IsError {
+		t.Fatalf("query failed: %v %+v", err, result)
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(text, "`2024-05-02T05:47:12+09:00` (2024-05-01T20:47:12Z)") {
+		t.Errorf("Expected Tokyo time with the raw value, got:\n%s", text)
+	}
+	if !strings.Contains(text, "**Timezone:** Asia/Tokyo") {
+		t.Errorf("Expected timezone in query metadata, got:\n%s", text)
+	}
+
+	args["timezone"] = "Nowhere/Land"
+	result, _ = tool.Execute(testCtx(mock), args)
+	if !result.IsError {
+		t.Error("Expected an unknown timezone to be rejected")
+	}
+}
+
+func TestTimeDisplayRelative(t *testing.T) {
+	now := time.Date(2024, 5, 1, 21, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		display TimeDisplay
+		raw     string
+		want    string
+	}{
+		{"seconds", TimeDisplay{RelativeTo: now}, "2024-05-01T20:59:15Z", "(45s ago)"},
+		{"minutes", TimeDisplay{RelativeTo: now}, "2024-05-01T20:57:00Z", "(3m ago)"},
+		{"hours", TimeDisplay{RelativeTo: now}, "2024-05-01T18:45:00Z", "(2h15m ago)"},
+		{"days", TimeDisplay{RelativeTo: now}, "2024-04-26T21:00:00Z", "(5d ago)"},
+		{"future", TimeDisplay{RelativeTo: now}, "2024-05-01T21:00:05Z", "(in the future)"},
+		{"before end", TimeDisplay{RelativeTo: now, RelativeToEnd: true}, "2024-05-01T20:00:00Z", "(1h before end)"},
+		{"after end", TimeDisplay{RelativeTo: now, RelativeToEnd: true}, "2024-05-01T21:01:00Z", "(after end)"},
+		{"disabled", TimeDisplay{}, "2024-05-01T20:57:00Z", ""},
+		{"unparseable", TimeDisplay{RelativeTo: now}, "yesterday", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.display.Relative(tt.raw); got != tt.want {
+				t.Errorf("Relative(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryTool_RelativeTimeParameter(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.DefaultResponse = &client.Response{
+		StatusCode: 200,
+		Body:       []byte("data: {\"result\":{\"results\":[{\"metadata\":[{\"key\":\"timestamp\",\"value\":\"2024-05-01T20:47:12Z\"}],\"labels\":[{\"key\":\"applicationname\",\"value\":\"api\"}],\"user_data\":\"{\\\"message\\\":\\\"boom\\\"}\"}]}}\n"),
+	}
+	tool := NewQueryTool(mock, zap.NewNop())
+	args := map[string]interface{}{
+		"query":      "source logs",
+		"start_date": "2024-05-01T20:00:00Z",
+		"end_date":   "2024-05-01T21:00:00Z",
+	}
+
+	result, err := tool.Execute(testCtx(mock), args)
+	if err != nil || result.IsError {
+		t.Fatalf("query failed: %v %+v", err, result)
+	}
+	if text := result.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, "(12m before end)") {
+		t.Errorf("Expected a relative annotation against the query end, got:\n%s", text)
+	}
+
+	args["relative_time"] = false
+	result, _ = tool.Execute(testCtx(mock), args)
+	if text := result.Content[0].(*mcp.TextContent).Text; strings.Contains(text, "before end)") {
+		t.Errorf("Expected no relative annotation with relative_time=false, got:\n%s", text)
+	}
+}
@@ -0,0 +1,360 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements compare_time_windows, which compares log volume between two time ranges.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+const (
+	// defaultCompareWindowLimit is the number of events fetched per window unless set
+	defaultCompareWindowLimit = 5000
+	// maxCompareWindowLimit caps the events fetched per window
+	maxCompareWindowLimit = 10000
+	// compareTopApps is the number of applications compared
+	compareTopApps = 10
+)
+
+// RateDelta compares the hourly rate of one value between the baseline and current windows.
+// PercentChange is nil when the baseline rate is zero.
+type RateDelta struct {
+	Value           string   `json:"value"`
+	BaselineCount   int      `json:"baseline_count"`
+	CurrentCount    int      `json:"current_count"`
+	BaselinePerHour float64  `json:"baseline_per_hour"`
+	CurrentPerHour  float64  `json:"current_per_hour"`
+	PercentChange   *float64 `json:"percent_change"`
+}
+
+// windowStats summarizes the events returned for one window
+type windowStats struct {
+	StartDate string         `json:"start_date"`
+	EndDate   string         `json:"end_date"`
+	Hours     float64        `json:"hours"`
+	Events    int            `json:"events"`
+	PerHour   float64        `json:"events_per_hour"`
+	Truncated bool           `json:"truncated"`
+	Severity  map[string]int `json:"severity"`
+
+	events []interface{}
+}
+
+// CompareTimeWindowsTool runs one query over two windows and compares volume, severity and applications
+type CompareTimeWindowsTool struct{ *BaseTool }
+
+// NewCompareTimeWindowsTool creates a new tool instance
+func NewCompareTimeWindowsTool(c client.Doer, l *zap.Logger) *CompareTimeWindowsTool {
+	return &CompareTimeWindowsTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *CompareTimeWindowsTool) Name() string { return "compare_time_windows" }
+
+// Annotations returns tool hints for LLMs
+func (t *CompareTimeWindowsTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Compare Time Windows")
+}
+
+// DefaultTimeout allows one query per window
+func (t *CompareTimeWindowsTool) DefaultTimeout() time.Duration {
+	return 2 * DefaultQueryTimeout
+}
+
+// Description returns the tool description
+func (t *CompareTimeWindowsTool) Description() string {
+	return fmt.Sprintf(`Compare log volume, severity distribution and top applications between two time windows, e.g. this week vs last week.
+
+The same query runs over a baseline and a current window. Counts are normalized to events per hour
+so windows of different length compare fairly, and each severity and application is reported with
+its percentage change. A window is given by start_date/end_date or time_range, and may be shifted
+back with offset (e.g. {"time_range": "7d", "offset": "168h"} is the week before last 7 days).
+Up to limit events are fetched per window (default %d, max %d); rates from truncated windows are lower bounds.
+
+**Related tools:** query_logs, cluster_logs, investigate_incident`, defaultCompareWindowLimit, maxCompareWindowLimit)
+}
+
+// InputSchema returns the input schema
+func (t *CompareTimeWindowsTool) InputSchema() interface{} {
+	window := func(description string) map[string]interface{} {
+		return map[string]interface{}{
+			"type":        "object",
+			"description": description,
+			"properties": map[string]interface{}{
+				"start_date": map[string]interface{}{"type": "string", "description": "Window start (RFC3339). Overrides time_range."},
+				"end_date":   map[string]interface{}{"type": "string", "description": "Window end (RFC3339, default: now)"},
+				"time_range": map[string]interface{}{
+					"type":        "string",
+					"description": "Window length ending at end_date when start_date is not set (default: 24h)",
+					"enum":        []string{"15m", "1h", "6h", "24h", "7d"},
+				},
+				"offset": map[string]interface{}{"type": "string", "description": "Shift the whole window back by this duration, e.g. 24h or 168h"},
+			},
+		}
+	}
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"query": map[string]interface{}{
+				"type":        "string",
+				"description": "DataPrime query selecting the logs to compare (default: source logs)",
+			},
+			"applicationName": map[string]interface{}{
+				"type":        "string",
+				"description": "Only compare logs from this application",
+			},
+			"subsystemName": map[string]interface{}{
+				"type":        "string",
+				"description": "Only compare logs from this subsystem",
+			},
+			"baseline": window("Earlier window to compare against"),
+			"current":  window("Window being evaluated (default: last 24h)"),
+			"tier": map[string]interface{}{
+				"type":        "string",
+				"description": "Tier to search (default: archive)",
+				"enum":        []string{"archive", "frequent_search"},
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"description": fmt.Sprintf("Maximum events fetched per window (default: %d, max: %d)", defaultCompareWindowLimit, maxCompareWindowLimit),
+				"minimum":     1,
+				"maximum":     maxCompareWindowLimit,
+			},
+		},
+		"required": []string{"baseline"},
+	}
+}
+
+// Execute executes the tool
+func (t *CompareTimeWindowsTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	query, _ := GetStringParam(args, "query", false)
+	if query == "" {
+		query = "source logs"
+	}
+	query = applyQueryFilters(query, args)
+
+	baselineArgs, err := GetObjectParam(args, "baseline", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	currentArgs, err := GetObjectParam(args, "current", false)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	baseStart, baseEnd, err := resolveComparisonWindow(baselineArgs)
+	if err != nil {
+		return NewToolResultError("baseline: " + err.Error()), nil
+	}
+	curStart, curEnd, err := resolveComparisonWindow(currentArgs)
+	if err != nil {
+		return NewToolResultError("current: " + err.Error()), nil
+	}
+
+	tier, _ := GetStringParam(args, "tier", false)
+	if tier == "" {
+		tier = "archive"
+	} else {
+		tier = normalizeTier(tier)
+	}
+	limit, err := GetIntParam(args, "limit", false)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	if limit <= 0 {
+		limit = defaultCompareWindowLimit
+	}
+	if limit > maxCompareWindowLimit {
+		limit = maxCompareWindowLimit
+	}
+
+	query, _, err = PrepareQuery(query, tier, "dataprime")
+	if err != nil {
+		return NewToolResultError(fmt.Sprintf("Invalid query: %v", err)), nil
+	}
+
+	baseline, err := t.fetchWindow(ctx, query, tier, baseStart, baseEnd, limit)
+	if err != nil {
+		return NewToolResultError(FormatQueryError(query, err.Error())), nil
+	}
+	current, err := t.fetchWindow(ctx, query, tier, curStart, curEnd, limit)
+	if err != nil {
+		return NewToolResultError(FormatQueryError(query, err.Error())), nil
+	}
+
+	volume := newRateDelta("events", baseline.Events, current.Events, baseline.Hours, current.Hours)
+	response := map[string]interface{}{
+		"query":            query,
+		"baseline":         baseline,
+		"current":          current,
+		"volume":           volume,
+		"severity":         compareSeverities(baseline, current),
+		"top_applications": compareTopApplications(baseline, current, compareTopApps),
+	}
+	if baseline.Truncated || current.Truncated {
+		response["note"] = fmt.Sprintf("At least one window hit the %d event limit, so its counts and rates are lower bounds. Narrow the query or raise limit (max %d).", limit, maxCompareWindowLimit)
+	}
+	return t.FormatResponse(response)
+}
+
+// fetchWindow runs the query over one window and summarizes the returned events
+func (t *CompareTimeWindowsTool) fetchWindow(ctx context.Context, query, tier string, start, end time.Time, limit int) (*windowStats, error) {
+	result, err := t.ExecuteRequestWithMaxEvents(ctx, &client.Request{
+		Method: "POST",
+		Path:   "/v1/query",
+		Body: map[string]interface{}{
+			"query": query,
+			"metadata": map[string]interface{}{
+				"tier":       tier,
+				"syntax":     "dataprime",
+				"start_date": start.Format(time.RFC3339),
+				"end_date":   end.Format(time.RFC3339),
+				"limit":      limit,
+			},
+		},
+		AcceptSSE: true,
+		Timeout:   DefaultQueryTimeout,
+	}, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	events, _ := result["events"].([]interface{})
+	hours := end.Sub(start).Hours()
+	return &windowStats{
+		StartDate: start.Format(time.RFC3339),
+		EndDate:   end.Format(time.RFC3339),
+		Hours:     math.Round(hours*100) / 100,
+		Events:    len(events),
+		PerHour:   perHour(len(events), hours),
+		Truncated: len(events) >= limit,
+		Severity:  analyzeSeverityDistribution(events),
+		events:    events,
+	}, nil
+}
+
+// resolveComparisonWindow resolves a window spec, then shifts it back by its offset
+func resolveComparisonWindow(spec map[string]interface{}) (time.Time, time.Time, error) {
+	if spec == nil {
+		spec = map[string]interface{}{}
+	}
+	start, end, err := resolveQueryWindow(spec)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if s, _ := GetStringParam(spec, "offset", false); s != "" {
+		offset, err := time.ParseDuration(s)
+		if err != nil || offset < 0 {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid offset %q: use a positive duration such as 24h or 168h", s)
+		}
+		start, end = start.Add(-offset), end.Add(-offset)
+	}
+	return start, end, nil
+}
+
+// compareSeverities returns the per-hour change of every severity seen in either window,
+// from most to least severe
+func compareSeverities(baseline, current *windowStats) []RateDelta {
+	seen := make(map[string]bool)
+	var names []string
+	for _, dist := range []map[string]int{baseline.Severity, current.Severity} {
+		for name := range dist {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		li, _ := NormalizeSeverity(names[i])
+		lj, _ := NormalizeSeverity(names[j])
+		return li > lj
+	})
+
+	deltas := make([]RateDelta, 0, len(names))
+	for _, name := range names {
+		deltas = append(deltas, newRateDelta(name, baseline.Severity[name], current.Severity[name], baseline.Hours, current.Hours))
+	}
+	return deltas
+}
+
+// compareTopApplications returns the per-hour change of the applications that are among the
+// top n of either window, ordered by current count
+func compareTopApplications(baseline, current *windowStats, n int) []RateDelta {
+	baseTop := extractTopValues(baseline.events, "applicationname", n)
+	curTop := extractTopValues(current.events, "applicationname", n)
+	counts := func(top []ValueCount) map[string]int {
+		m := make(map[string]int, len(top))
+		for _, vc := range top {
+			m[vc.Value] = vc.Count
+		}
+		return m
+	}
+	baseCounts, curCounts := counts(baseTop), counts(curTop)
+
+	// Apps outside one window's top n still need that window's real count
+	var apps []string
+	seen := make(map[string]bool)
+	for _, vc := range append(curTop, baseTop...) {
+		if !seen[vc.Value] {
+			seen[vc.Value] = true
+			apps = append(apps, vc.Value)
+		}
+	}
+	fullCount := func(events []interface{}, app string) int {
+		count := 0
+		for _, event := range events {
+			if eventMap, ok := event.(map[string]interface{}); ok && findFieldValue(eventMap, "applicationname") == app {
+				count++
+			}
+		}
+		return count
+	}
+
+	deltas := make([]RateDelta, 0, len(apps))
+	for _, app := range apps {
+		b, ok := baseCounts[app]
+		if !ok {
+			b = fullCount(baseline.events, app)
+		}
+		c, ok := curCounts[app]
+		if !ok {
+			c = fullCount(current.events, app)
+		}
+		deltas = append(deltas, newRateDelta(app, b, c, baseline.Hours, current.Hours))
+	}
+	sort.SliceStable(deltas, func(i, j int) bool { return deltas[i].CurrentCount > deltas[j].CurrentCount })
+	return deltas
+}
+
+// newRateDelta normalizes both counts to events per hour and computes the percentage change
+func newRateDelta(value string, baseCount, curCount int, baseHours, curHours float64) RateDelta {
+	d := RateDelta{
+		Value:           value,
+		BaselineCount:   baseCount,
+		CurrentCount:    curCount,
+		BaselinePerHour: perHour(baseCount, baseHours),
+		CurrentPerHour:  perHour(curCount, curHours),
+	}
+	if baseCount > 0 && baseHours > 0 && curHours > 0 {
+		baseRate, curRate := float64(baseCount)/baseHours, float64(curCount)/curHours
+		change := math.Round((curRate-baseRate)*1000/baseRate) / 10
+		d.PercentChange = &change
+	}
+	return d
+}
+
+// perHour returns count per hour rounded to two decimals
+func perHour(count int, hours float64) float64 {
+	if hours <= 0 {
+		return 0
+	}
+	return math.Round(float64(count)/hours*100) / 100
+}
@@ -0,0 +1,111 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// sseQueryResponse builds a query SSE response with one event per (app, severity) pair
+func sseQueryResponse(entries [][2]string) *client.Response {
+	var body strings.Builder
+	body.WriteString(`data: {"result":{"results":[`)
+	for i, e := range entries {
+		if i > 0 {
+			body.WriteString(",")
+		}
+		fmt.Fprintf(&body, `{"metadata":[{"key":"severity","value":"%s"}],"labels":[{"key":"applicationname","value":"%s"}],"user_data":"{\"message\":\"m%d\"}"}`, e[1], e[0], i)
+	}
+	body.WriteString("]}}\n")
+	return &client.Response{StatusCode: 200, Body: []byte(body.String())}
+}
+
+func TestCompareTimeWindowsTool(t *testing.T) {
+	mock := client.NewMockClient()
+	// Baseline: 24h with 4 api errors and 2 web infos
+	mock.Responses = append(mock.Responses, sseQueryResponse([][2]string{
+		{"api", "5"}, {"api", "5"}, {"api", "5"}, {"api", "5"}, {"web", "3"}, {"web", "3"},
+	}))
+	// Current: 12h with 4 api errors and 1 worker warning
+	mock.Responses = append(mock.Responses, sseQueryResponse([][2]string{
+		{"api", "5"}, {"api", "5"}, {"api", "5"}, {"api", "5"}, {"worker", "4"},
+	}))
+	tool := NewCompareTimeWindowsTool(mock, zap.NewNop())
+
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{
+		"baseline": map[string]interface{}{"start_date": "2024-01-01T00:00:00Z", "end_date": "2024-01-02T00:00:00Z"},
+		"current":  map[string]interface{}{"start_date": "2024-01-08T00:00:00Z", "end_date": "2024-01-08T12:00:00Z"},
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError, "%+v", result.Content)
+
+	var response struct {
+		Baseline        windowStats `json:"baseline"`
+		Current         windowStats `json:"current"`
+		Volume          RateDelta   `json:"volume"`
+		Severity        []RateDelta `json:"severity"`
+		TopApplications []RateDelta `json:"top_applications"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &response))
+	assert.Equal(t, 24.0, response.Baseline.Hours)
+	assert.Equal(t, 12.0, response.Current.Hours)
+	assert.Equal(t, 0.25, response.Volume.BaselinePerHour)
+	assert.Equal(t, 0.42, response.Volume.CurrentPerHour)
+	require.NotNil(t, response.Volume.PercentChange)
+	assert.Equal(t, 66.7, *response.Volume.PercentChange)
+
+	require.Len(t, response.Severity, 3)
+	assert.Equal(t, "Error", response.Severity[0].Value)
+	assert.Equal(t, 100.0, *response.Severity[0].PercentChange, "same count in half the time doubles the rate")
+	assert.Equal(t, "Warning", response.Severity[1].Value)
+	assert.Nil(t, response.Severity[1].PercentChange, "new severities have no baseline rate")
+	assert.Equal(t, -100.0, *response.Severity[2].PercentChange)
+
+	require.Len(t, response.TopApplications, 3)
+	assert.Equal(t, "api", response.TopApplications[0].Value)
+	assert.Equal(t, 4, response.TopApplications[0].BaselineCount)
+	assert.Equal(t, 4, response.TopApplications[0].CurrentCount)
+
+	require.Len(t, mock.Requests, 2)
+	metadata := mock.Requests[0].Body.(map[string]interface{})["metadata"].(map[string]interface{})
+	assert.Equal(t, "2024-01-01T00:00:00Z", metadata["start_date"])
+	assert.Equal(t, defaultCompareWindowLimit, metadata["limit"])
+}
+
+func TestResolveComparisonWindow(t *testing.T) {
+	start, end, err := resolveComparisonWindow(map[string]interface{}{
+		"start_date": "2024-01-08T00:00:00Z", "end_date": "2024-01-15T00:00:00Z", "offset": "168h",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "2024-01-01T00:00:00Z", start.Format("2006-01-02T15:04:05Z07:00"))
+	assert.Equal(t, "2024-01-08T00:00:00Z", end.Format("2006-01-02T15:04:05Z07:00"))
+
+	start, end, err = resolveComparisonWindow(nil)
+	require.NoError(t, err)
+	assert.Equal(t, 24.0, end.Sub(start).Hours())
+
+	for _, spec := range []map[string]interface{}{
+		{"offset": "a week"},
+		{"offset": "-24h"},
+		{"time_range": "30d"},
+	} {
+		_, _, err := resolveComparisonWindow(spec)
+		assert.Error(t, err, "%v", spec)
+	}
+}
+
+func TestCompareTimeWindowsTool_RequiresBaseline(t *testing.T) {
+	mock := client.NewMockClient()
+	result, err := NewCompareTimeWindowsTool(mock, zap.NewNop()).Execute(testCtx(mock), map[string]interface{}{})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Zero(t, mock.RequestCount())
+}
@@ -0,0 +1,189 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	mcperrors "github.com/tareqmamari/cloud-logs-mcp/internal/errors"
+)
+
+// Timeout bounds for per-tool execution
+const (
+	// DefaultSlowToolTimeout applies to tools known to run long (background queries, exports, ingestion)
+	DefaultSlowToolTimeout = 3 * time.Minute
+
+	// MaxToolTimeout caps both configured and per-call (timeout_seconds) timeouts
+	MaxToolTimeout = 10 * time.Minute
+)
+
+// slowTools lists tools that routinely exceed the standard API timeouts
+var slowTools = map[string]bool{
+	"submit_background_query":   true,
+	"get_background_query_data": true,
+	"export_data_usage":         true,
+	"ingest_logs":               true,
+	"export_terraform":          true,
+}
+
+// toolTimeoutOverrides holds per-tool timeouts from configuration
+var (
+	toolTimeoutOverridesMu sync.RWMutex
+	toolTimeoutOverrides   = map[string]time.Duration{}
+)
+
+// SetToolTimeoutOverrides replaces the configured per-tool timeouts (tool name -> timeout).
+// Values are capped at MaxToolTimeout; non-positive values are ignored.
+func SetToolTimeoutOverrides(overrides map[string]time.Duration) {
+	cleaned := make(map[string]time.Duration, len(overrides))
+	for name, d := range overrides {
+		if d > 0 {
+			cleaned[name] = min(d, MaxToolTimeout)
+		}
+	}
+	toolTimeoutOverridesMu.Lock()
+	toolTimeoutOverrides = cleaned
+	toolTimeoutOverridesMu.Unlock()
+}
+
+// ResolveToolTimeout returns the execution timeout for a tool call. Precedence:
+// the timeout_seconds argument, a configured override, the tool's DefaultTimeout,
+// then a default based on whether the tool is slow, read-only, or mutating.
+func ResolveToolTimeout(tool Tool, args map[string]interface{}) (time.Duration, error) {
+	if raw, ok := args["timeout_seconds"]; ok {
+		seconds, ok := raw.(float64)
+		if !ok {
+			return 0, fmt.Errorf("timeout_seconds must be a number")
+		}
+		if seconds <= 0 {
+			return 0, fmt.Errorf("timeout_seconds must be positive")
+		}
+		return min(time.Duration(seconds*float64(time.Second)), MaxToolTimeout), nil
+	}
+
+	toolTimeoutOverridesMu.RLock()
+	override, ok := toolTimeoutOverrides[tool.Name()]
+	toolTimeoutOverridesMu.RUnlock()
+	if ok {
+		return override, nil
+	}
+
+	if d := tool.DefaultTimeout(); d > 0 {
+		return d, nil
+	}
+	return defaultTimeoutFor(tool), nil
+}
+
+// defaultTimeoutFor picks a timeout for tools that don't declare one
+func defaultTimeoutFor(tool Tool) time.Duration {
+	if slowTools[tool.Name()] {
+		return DefaultSlowToolTimeout
+	}
+	if capability := GetToolCapability(tool.Name()); capability != nil {
+		switch capability.Category {
+		case "read":
+			return DefaultGetTimeout
+		case "list":
+			return DefaultListTimeout
+		case "delete":
+			return DefaultDeleteTimeout
+		case "create", "update":
+			return DefaultCreateTimeout
+		case "query":
+			return DefaultQueryTimeout
+		}
+	}
+	if ann := tool.Annotations(); ann != nil && ann.ReadOnlyHint {
+		return DefaultListTimeout
+	}
+	return DefaultWorkflowTimeout
+}
+
+// isRetrySafe reports whether re-running the tool after a timeout cannot duplicate side effects
+func isRetrySafe(tool Tool) bool {
+	ann := tool.Annotations()
+	return ann != nil && (ann.ReadOnlyHint || ann.IdempotentHint)
+}
+
+// ExecuteWithTimeout runs the tool under its resolved timeout. If the deadline is hit,
+// a structured timeout error is returned with error_code and retry_allowed in _meta.
+// Tools the access policy disables are refused with a FORBIDDEN error.
+func ExecuteWithTimeout(ctx context.Context, tool Tool, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	if err := CheckToolAccess(tool); err != nil {
+		return NewToolDisabledError(err), nil
+	}
+	StripUnsupportedDryRun(tool, args)
+	if pending := CheckConfirmation(ctx, tool, args); pending != nil {
+		return pending, nil
+	}
+
+	timeout, err := ResolveToolTimeout(tool, args)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+
+	toolCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, err := tool.Execute(toolCtx, args)
+	if apiClient, clientErr := GetClientFromContext(ctx); clientErr == nil {
+		addRateLimitMetaIfLow(result, apiClient)
+	}
+
+	// Only report our own deadline; a cancelled parent context is the caller's decision
+	timedOut := errors.Is(toolCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil
+	if timedOut && (err != nil || result == nil || result.IsError) {
+		return NewToolTimeoutError(tool.Name(), timeout, isRetrySafe(tool)), nil
+	}
+	return result, err
+}
+
+// NewToolTimeoutError creates a timeout error result with machine-readable metadata
+func NewToolTimeoutError(toolName string, timeout time.Duration, retryAllowed bool) *mcp.CallToolResult {
+	suggestion := "Narrow the request (smaller time range, filters, or limit) or retry with a larger timeout_seconds."
+	if !retryAllowed {
+		suggestion = "This operation may have partially completed. Verify the resource state before retrying."
+	}
+	result := newToolError(mcperrors.CodeTimeout,
+		fmt.Sprintf("Tool '%s' timed out after %s", toolName, timeout),
+		suggestion,
+	)
+	result.Meta["retryable"] = retryAllowed
+	result.Meta["retry_allowed"] = retryAllowed
+	result.Meta["timeout_seconds"] = timeout.Seconds()
+	return result
+}
+
+// WithTimeoutParam returns a copy of an object input schema with the timeout_seconds property added
+func WithTimeoutParam(schema interface{}) interface{} {
+	m, ok := schema.(map[string]interface{})
+	if !ok {
+		return schema
+	}
+	props, _ := m["properties"].(map[string]interface{})
+	if _, exists := props["timeout_seconds"]; exists {
+		return schema
+	}
+
+	newProps := make(map[string]interface{}, len(props)+1)
+	for k, v := range props {
+		newProps[k] = v
+	}
+	newProps["timeout_seconds"] = map[string]interface{}{
+		"type":        "number",
+		"description": fmt.Sprintf("Optional execution timeout override in seconds (max %d)", int(MaxToolTimeout.Seconds())),
+		"minimum":     1,
+		"maximum":     int(MaxToolTimeout.Seconds()),
+	}
+
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	out["properties"] = newProps
+	return out
+}
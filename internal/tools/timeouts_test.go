@@ -0,0 +1,84 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// slowTool blocks until its context is done
+type slowTool struct {
+	*BaseTool
+	readOnly bool
+}
+
+func (t *slowTool) Name() string                  { return "slow_tool" }
+func (t *slowTool) Description() string           { return "Blocks until cancelled" }
+func (t *slowTool) InputSchema() interface{}      { return map[string]interface{}{"type": "object"} }
+func (t *slowTool) DefaultTimeout() time.Duration { return 20 * time.Millisecond }
+func (t *slowTool) Annotations() *mcp.ToolAnnotations {
+	if t.readOnly {
+		return ReadOnlyAnnotations("Slow")
+	}
+	return CreateAnnotations("Slow")
+}
+func (t *slowTool) Execute(ctx context.Context, _ map[string]interface{}) (*mcp.CallToolResult, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestResolveToolTimeout(t *testing.T) {
+	defer SetToolTimeoutOverrides(nil)
+
+	listAlerts := NewListAlertsTool(nil, zap.NewNop())
+	d, err := ResolveToolTimeout(listAlerts, nil)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultListTimeout, d, "list tools fall back to the list timeout")
+
+	d, _ = ResolveToolTimeout(NewGetAlertTool(nil, zap.NewNop()), nil)
+	assert.Equal(t, DefaultGetTimeout, d)
+
+	d, _ = ResolveToolTimeout(NewSubmitBackgroundQueryTool(nil, zap.NewNop()), nil)
+	assert.Equal(t, DefaultSlowToolTimeout, d)
+
+	d, _ = ResolveToolTimeout(NewQueryTool(nil, zap.NewNop()), nil)
+	assert.Equal(t, DefaultQueryTimeout, d, "declared DefaultTimeout wins over fallbacks")
+
+	SetToolTimeoutOverrides(map[string]time.Duration{"list_alerts": 5 * time.Second})
+	d, _ = ResolveToolTimeout(listAlerts, nil)
+	assert.Equal(t, 5*time.Second, d)
+
+	d, _ = ResolveToolTimeout(listAlerts, map[string]interface{}{"timeout_seconds": float64(2)})
+	assert.Equal(t, 2*time.Second, d, "timeout_seconds overrides configuration")
+
+	d, _ = ResolveToolTimeout(listAlerts, map[string]interface{}{"timeout_seconds": float64(100000)})
+	assert.Equal(t, MaxToolTimeout, d)
+
+	_, err = ResolveToolTimeout(listAlerts, map[string]interface{}{"timeout_seconds": float64(-1)})
+	assert.Error(t, err)
+}
+
+func TestExecuteWithTimeout(t *testing.T) {
+	result, err := ExecuteWithTimeout(context.Background(), &slowTool{BaseTool: NewBaseTool(nil, zap.NewNop()), readOnly: true}, nil)
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Equal(t, "TIMEOUT", result.Meta["error_code"])
+	assert.Equal(t, true, result.Meta["retry_allowed"])
+
+	result, _ = ExecuteWithTimeout(context.Background(), &slowTool{BaseTool: NewBaseTool(nil, zap.NewNop())}, nil)
+	assert.Equal(t, false, result.Meta["retry_allowed"], "non-idempotent tools must not be retried blindly")
+}
+
+func TestWithTimeoutParam(t *testing.T) {
+	schema := NewListAlertsTool(nil, zap.NewNop()).InputSchema()
+	withTimeout := WithTimeoutParam(schema).(map[string]interface{})
+
+	props := withTimeout["properties"].(map[string]interface{})
+	assert.Contains(t, props, "timeout_seconds")
+	assert.NotContains(t, schema.(map[string]interface{})["properties"], "timeout_seconds", "original schema must not be modified")
+}
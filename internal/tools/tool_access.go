@@ -0,0 +1,166 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements the tool access policy that limits which tools a deployment exposes.
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	mcperrors "github.com/tareqmamari/cloud-logs-mcp/internal/errors"
+)
+
+// ToolAccessPolicy decides which tools are exposed. A tool is allowed when it is in Enabled
+// (or Enabled is empty), is not in Disabled, and, in read-only mode, does not mutate state.
+type ToolAccessPolicy struct {
+	ReadOnly bool
+	Enabled  map[string]bool
+	Disabled map[string]bool
+}
+
+// toolAccessPolicy holds the policy applied by the server; nil allows every tool
+var (
+	toolAccessPolicyMu sync.RWMutex
+	toolAccessPolicy   *ToolAccessPolicy
+)
+
+// NewToolAccessPolicy builds a policy from configuration. It returns nil when nothing is
+// restricted, and an error for tool names that do not exist so typos do not silently
+// leave a tool exposed.
+func NewToolAccessPolicy(readOnly bool, enabled, disabled []string) (*ToolAccessPolicy, error) {
+	if !readOnly && len(enabled) == 0 && len(disabled) == 0 {
+		return nil, nil
+	}
+
+	known := make(map[string]bool)
+	for _, t := range GetAllTools(nil, zap.NewNop()) {
+		known[t.Name()] = true
+	}
+	toSet := func(setting string, names []string) (map[string]bool, error) {
+		set := make(map[string]bool, len(names))
+		var unknown []string
+		for _, name := range names {
+			if !known[name] {
+				unknown = append(unknown, name)
+				continue
+			}
+			set[name] = true
+		}
+		if len(unknown) > 0 {
+			sort.Strings(unknown)
+			return nil, fmt.Errorf("%s lists unknown tools: %s", setting, strings.Join(unknown, ", "))
+		}
+		return set, nil
+	}
+
+	p := &ToolAccessPolicy{ReadOnly: readOnly}
+	var err error
+	if p.Enabled, err = toSet("enabled_tools", enabled); err != nil {
+		return nil, err
+	}
+	if p.Disabled, err = toSet("disabled_tools", disabled); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Check returns nil if the policy allows t, or an error explaining why it is disabled
+func (p *ToolAccessPolicy) Check(t Tool) error {
+	if p == nil {
+		return nil
+	}
+	return p.check(t.Name(), IsReadOnlyTool(t))
+}
+
+// check applies the policy to a tool name and its read-only classification
+func (p *ToolAccessPolicy) check(name string, readOnly bool) error {
+	switch {
+	case p.Disabled[name]:
+		return fmt.Errorf("tool '%s' is disabled by the server's disabled_tools setting", name)
+	case len(p.Enabled) > 0 && !p.Enabled[name]:
+		return fmt.Errorf("tool '%s' is not in the server's enabled_tools list", name)
+	case p.ReadOnly && !readOnly:
+		return fmt.Errorf("tool '%s' modifies state and is disabled because the server runs in read-only mode", name)
+	}
+	return nil
+}
+
+// IsReadOnlyTool reports whether t only reads data. The capability annotation decides when
+// the tool has one; otherwise the tool's own read-only hint does. Unknown tools count as mutating.
+func IsReadOnlyTool(t Tool) bool {
+	if capability := GetToolCapability(t.Name()); capability != nil {
+		return capability.IsReadOnly
+	}
+	annotations := t.Annotations()
+	return annotations != nil && annotations.ReadOnlyHint
+}
+
+// sessionStateTools keep their data in the session, which stateless mode drops after every
+// request, so they would report success while losing everything they stored
+var sessionStateTools = map[string]bool{
+	"save_query":             true,
+	"list_saved_queries":     true,
+	"get_saved_query":        true,
+	"delete_saved_query":     true,
+	"schedule_query":         true,
+	"list_scheduled_queries": true,
+	"delete_scheduled_query": true,
+	"replay_query":           true,
+	"session_context":        true,
+	"record_feedback":        true,
+}
+
+// checkStateless returns an error if the named tool needs session state the server does not keep
+func checkStateless(name string) error {
+	if sessionStateTools[name] && IsStatelessMode() {
+		return fmt.Errorf("tool '%s' needs session state and is unavailable because the server runs in stateless mode", name)
+	}
+	return nil
+}
+
+// SetToolAccessPolicy replaces the policy applied to tool execution; nil allows every tool
+func SetToolAccessPolicy(p *ToolAccessPolicy) {
+	toolAccessPolicyMu.Lock()
+	toolAccessPolicy = p
+	toolAccessPolicyMu.Unlock()
+}
+
+// CheckToolAccess returns nil if the configured policy and the session mode allow t
+func CheckToolAccess(t Tool) error {
+	if err := checkStateless(t.Name()); err != nil {
+		return err
+	}
+	toolAccessPolicyMu.RLock()
+	p := toolAccessPolicy
+	toolAccessPolicyMu.RUnlock()
+	return p.Check(t)
+}
+
+// isToolNameAllowed reports whether the configured policy and session mode allow the named
+// tool, for filtering suggestions. Names that are not registered are judged by capability alone.
+func isToolNameAllowed(name string) bool {
+	if checkStateless(name) != nil {
+		return false
+	}
+	toolAccessPolicyMu.RLock()
+	p := toolAccessPolicy
+	toolAccessPolicyMu.RUnlock()
+	if p == nil {
+		return true
+	}
+	if t := GetRegisteredTool(name); t != nil {
+		return p.Check(t) == nil
+	}
+	capability := GetToolCapability(name)
+	return p.check(name, capability != nil && capability.IsReadOnly) == nil
+}
+
+// NewToolDisabledError reports a call to a tool the access policy does not allow
+func NewToolDisabledError(err error) *mcp.CallToolResult {
+	return newToolError(mcperrors.CodeForbidden, err.Error(),
+		"Use search_tools to see the tools this server exposes, or ask the operator to change read_only_mode, enabled_tools, disabled_tools, or stateless.")
+}
@@ -0,0 +1,71 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestNewToolAccessPolicy(t *testing.T) {
+	p, err := NewToolAccessPolicy(false, nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, p, "an unrestricted config needs no policy")
+
+	_, err = NewToolAccessPolicy(false, nil, []string{"delete_alert", "drop_everything"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "disabled_tools lists unknown tools: drop_everything")
+
+	_, err = NewToolAccessPolicy(false, []string{"query_log"}, nil)
+	assert.ErrorContains(t, err, "enabled_tools")
+}
+
+func TestToolAccessPolicy_Check(t *testing.T) {
+	mock := client.NewMockClient()
+	logger := zap.NewNop()
+
+	readOnly, err := NewToolAccessPolicy(true, nil, []string{"query_logs"})
+	require.NoError(t, err)
+	for _, tool := range GetAllTools(mock, logger) {
+		err := readOnly.Check(tool)
+		switch tool.Name() {
+		case "list_alerts", "get_alert", "discover_log_fields", "health_check", "search_tools":
+			assert.NoError(t, err, tool.Name())
+		case "create_alert", "update_alert", "delete_alert", "ingest_logs", "create_slo_burn_alert":
+			assert.ErrorContains(t, err, "read-only mode", tool.Name())
+		case "query_logs":
+			assert.ErrorContains(t, err, "disabled_tools", tool.Name())
+		}
+	}
+
+	allowList, err := NewToolAccessPolicy(false, []string{"list_alerts", "create_alert"}, []string{"create_alert"})
+	require.NoError(t, err)
+	assert.NoError(t, allowList.Check(NewListAlertsTool(mock, logger)))
+	assert.ErrorContains(t, allowList.Check(NewCreateAlertTool(mock, logger)), "disabled_tools")
+	assert.ErrorContains(t, allowList.Check(NewGetAlertTool(mock, logger)), "enabled_tools")
+}
+
+func TestExecuteWithTimeout_DisabledTool(t *testing.T) {
+	policy, err := NewToolAccessPolicy(true, nil, nil)
+	require.NoError(t, err)
+	SetToolAccessPolicy(policy)
+	t.Cleanup(func() { SetToolAccessPolicy(nil) })
+
+	mock := client.NewMockClient()
+	result, err := ExecuteWithTimeout(context.Background(), NewDeleteAlertTool(mock, zap.NewNop()), map[string]interface{}{"id": "a1"})
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Equal(t, "FORBIDDEN", result.Meta["error_code"])
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "read-only mode")
+	assert.Zero(t, mock.RequestCount())
+
+	discovered := NewToolRegistry().DiscoverToolsForSession(nil, "delete alert", "", "")
+	for _, m := range discovered.MatchedTools {
+		assert.NotEqual(t, "delete_alert", m.Name, "disabled tools are not suggested")
+	}
+}
@@ -31,12 +31,85 @@ var ToolCapabilities = map[string]ToolCapability{
 		CanPaginate:  true,
 		RelatedTools: []string{"build_query", "create_dashboard", "create_alert"},
 	},
+	"trace_logs": {
+		Category:     "query",
+		ResourceType: "logs",
+		IsReadOnly:   true,
+		RelatedTools: []string{"query_logs", "investigate_incident"},
+	},
+	"cluster_logs": {
+		Category:     "query",
+		ResourceType: "logs",
+		IsReadOnly:   true,
+		RelatedTools: []string{"query_logs", "investigate_incident", "trace_logs"},
+	},
+	"compare_time_windows": {
+		Category:     "query",
+		ResourceType: "logs",
+		IsReadOnly:   true,
+		RelatedTools: []string{"query_logs", "cluster_logs"},
+	},
+	"replay_query": {
+		Category:      "query",
+		ResourceType:  "logs",
+		IsReadOnly:    true,
+		CanPaginate:   true,
+		Prerequisites: []string{"query_logs"},
+		RelatedTools:  []string{"query_logs"},
+	},
+	"save_query": {
+		Category:     "update",
+		ResourceType: "saved_query",
+		RelatedTools: []string{"list_saved_queries", "query_logs"},
+	},
+	"list_saved_queries": {
+		Category:     "list",
+		ResourceType: "saved_query",
+		IsReadOnly:   true,
+		RelatedTools: []string{"get_saved_query", "query_logs"},
+	},
+	"get_saved_query": {
+		Category:     "read",
+		ResourceType: "saved_query",
+		IsReadOnly:   true,
+		RequiresID:   true,
+		RelatedTools: []string{"query_logs", "save_query"},
+	},
+	"delete_saved_query": {
+		Category:      "delete",
+		ResourceType:  "saved_query",
+		RequiresID:    true,
+		Prerequisites: []string{"list_saved_queries"},
+	},
+	"schedule_query": {
+		Category:     "update",
+		ResourceType: "scheduled_query",
+		RelatedTools: []string{"list_scheduled_queries", "list_outgoing_webhooks"},
+	},
+	"list_scheduled_queries": {
+		Category:     "list",
+		ResourceType: "scheduled_query",
+		IsReadOnly:   true,
+		RelatedTools: []string{"schedule_query", "delete_scheduled_query"},
+	},
+	"delete_scheduled_query": {
+		Category:      "delete",
+		ResourceType:  "scheduled_query",
+		RequiresID:    true,
+		Prerequisites: []string{"list_scheduled_queries"},
+	},
 	"build_query": {
 		Category:     "query",
 		ResourceType: "query",
 		IsReadOnly:   true,
 		RelatedTools: []string{"query_logs"},
 	},
+	"build_aggregation_query": {
+		Category:     "query",
+		ResourceType: "query",
+		IsReadOnly:   true,
+		RelatedTools: []string{"query_logs", "build_query"},
+	},
 	"submit_background_query": {
 		Category:     "query",
 		ResourceType: "logs",
@@ -61,6 +134,36 @@ var ToolCapabilities = map[string]ToolCapability{
 		ResourceType: "background_query",
 		RequiresID:   true,
 	},
+	"list_background_queries": {
+		Category:     "list",
+		ResourceType: "background_query",
+		IsReadOnly:   true,
+		RelatedTools: []string{"get_background_query_data", "cancel_all_background_queries"},
+	},
+	"cancel_all_background_queries": {
+		Category:      "delete",
+		ResourceType:  "background_query",
+		Prerequisites: []string{"list_background_queries"},
+	},
+	"list_applications": {
+		Category:     "list",
+		ResourceType: "logs",
+		IsReadOnly:   true,
+		RelatedTools: []string{"list_subsystems", "query_logs"},
+	},
+	"list_subsystems": {
+		Category:     "list",
+		ResourceType: "logs",
+		IsReadOnly:   true,
+		RelatedTools: []string{"list_applications", "query_logs"},
+	},
+	"discover_fields": {
+		Category:      "query",
+		ResourceType:  "logs",
+		IsReadOnly:    true,
+		Prerequisites: []string{"list_applications"},
+		RelatedTools:  []string{"build_query", "query_logs"},
+	},
 
 	// Dashboard tools
 	"list_dashboards": {
@@ -82,6 +185,12 @@ var ToolCapabilities = map[string]ToolCapability{
 		SupportsDryRun: true,
 		RelatedTools:   []string{"list_dashboards", "get_dashboard", "query_logs"},
 	},
+	"create_dashboard_from_template": {
+		Category:       "create",
+		ResourceType:   "dashboard",
+		SupportsDryRun: true,
+		RelatedTools:   []string{"get_dashboard", "update_dashboard", "create_dashboard"},
+	},
 	"update_dashboard": {
 		Category:      "update",
 		ResourceType:  "dashboard",
@@ -161,11 +270,52 @@ var ToolCapabilities = map[string]ToolCapability{
 		RequiresID:   true,
 		RelatedTools: []string{"update_alert", "delete_alert"},
 	},
+	"watch_alert": {
+		Category:     "read",
+		ResourceType: "alert",
+		IsReadOnly:   true,
+		RequiresID:   true,
+		RelatedTools: []string{"get_alert", "create_alert", "list_alerts"},
+	},
+	"compare_alerts": {
+		Category:     "read",
+		ResourceType: "alert",
+		IsReadOnly:   true,
+		RelatedTools: []string{"get_alert", "list_alerts", "update_alert"},
+	},
+	"export_alert": {
+		Category:     "read",
+		ResourceType: "alert",
+		IsReadOnly:   true,
+		RequiresID:   true,
+		RelatedTools: []string{"restore_alert", "get_alert"},
+	},
+	"restore_alert": {
+		Category:       "create",
+		ResourceType:   "alert",
+		SupportsDryRun: true,
+		Prerequisites:  []string{"export_alert"},
+		RelatedTools:   []string{"list_outgoing_webhooks", "create_outgoing_webhook"},
+	},
+	"validate_alert_condition": {
+		Category:     "read",
+		ResourceType: "alert",
+		IsReadOnly:   true,
+		RelatedTools: []string{"create_alert", "update_alert"},
+	},
 	"create_alert": {
-		Category:      "create",
-		ResourceType:  "alert",
-		Prerequisites: []string{"list_alert_definitions", "list_outgoing_webhooks"},
-		RelatedTools:  []string{"create_alert_def", "create_outgoing_webhook"},
+		Category:       "create",
+		ResourceType:   "alert",
+		SupportsDryRun: true,
+		Prerequisites:  []string{"list_alert_definitions", "list_outgoing_webhooks"},
+		RelatedTools:   []string{"create_alert_def", "create_outgoing_webhook"},
+	},
+	"bulk_create_alerts": {
+		Category:       "create",
+		ResourceType:   "alert",
+		SupportsDryRun: true,
+		Prerequisites:  []string{"list_alert_definitions", "list_outgoing_webhooks"},
+		RelatedTools:   []string{"create_alert", "validate_alert_condition"},
 	},
 	"update_alert": {
 		Category:      "update",
@@ -196,9 +346,17 @@ var ToolCapabilities = map[string]ToolCapability{
 		RelatedTools: []string{"update_alert_definition", "delete_alert_definition"},
 	},
 	"create_alert_definition": {
-		Category:     "create",
-		ResourceType: "alert_definition",
-		RelatedTools: []string{"create_alert", "query_logs"},
+		Category:       "create",
+		ResourceType:   "alert_definition",
+		SupportsDryRun: true,
+		RelatedTools:   []string{"create_alert", "query_logs"},
+	},
+	"create_alert_from_query": {
+		Category:       "create",
+		ResourceType:   "alert_definition",
+		SupportsDryRun: true,
+		Prerequisites:  []string{"query_logs"},
+		RelatedTools:   []string{"create_alert_definition", "list_outgoing_webhooks"},
 	},
 	"update_alert_definition": {
 		Category:      "update",
@@ -248,9 +406,10 @@ var ToolCapabilities = map[string]ToolCapability{
 		RelatedTools: []string{"update_stream", "delete_stream"},
 	},
 	"create_stream": {
-		Category:     "create",
-		ResourceType: "stream",
-		RelatedTools: []string{"list_streams"},
+		Category:       "create",
+		ResourceType:   "stream",
+		SupportsDryRun: true,
+		RelatedTools:   []string{"list_streams"},
 	},
 	"update_stream": {
 		Category:      "update",
@@ -264,6 +423,12 @@ var ToolCapabilities = map[string]ToolCapability{
 		RequiresID:    true,
 		Prerequisites: []string{"get_stream"},
 	},
+	"test_stream": {
+		Category:     "read",
+		ResourceType: "stream",
+		IsReadOnly:   true,
+		RelatedTools: []string{"create_stream", "update_stream"},
+	},
 
 	// Event stream target tools (alternative API for streams)
 	"get_event_stream_targets": {
@@ -319,6 +484,12 @@ var ToolCapabilities = map[string]ToolCapability{
 		RequiresID:    true,
 		Prerequisites: []string{"get_rule_group"},
 	},
+	"test_rule_group": {
+		Category:     "read",
+		ResourceType: "rule_group",
+		IsReadOnly:   true,
+		RelatedTools: []string{"create_rule_group", "update_rule_group"},
+	},
 
 	// Outgoing webhook tools (for alert notifications)
 	"list_outgoing_webhooks": {
@@ -335,9 +506,10 @@ var ToolCapabilities = map[string]ToolCapability{
 		RelatedTools: []string{"update_outgoing_webhook", "delete_outgoing_webhook"},
 	},
 	"create_outgoing_webhook": {
-		Category:     "create",
-		ResourceType: "outgoing_webhook",
-		RelatedTools: []string{"list_outgoing_webhooks", "create_alert"},
+		Category:       "create",
+		ResourceType:   "outgoing_webhook",
+		SupportsDryRun: true,
+		RelatedTools:   []string{"list_outgoing_webhooks", "create_alert"},
 	},
 	"update_outgoing_webhook": {
 		Category:      "update",
@@ -346,10 +518,16 @@ var ToolCapabilities = map[string]ToolCapability{
 		Prerequisites: []string{"get_outgoing_webhook"},
 	},
 	"delete_outgoing_webhook": {
-		Category:      "delete",
-		ResourceType:  "outgoing_webhook",
-		RequiresID:    true,
-		Prerequisites: []string{"get_outgoing_webhook"},
+		Category:       "delete",
+		ResourceType:   "outgoing_webhook",
+		RequiresID:     true,
+		SupportsDryRun: true,
+		Prerequisites:  []string{"get_outgoing_webhook"},
+	},
+	"test_outgoing_webhook": {
+		Category:     "read",
+		ResourceType: "outgoing_webhook",
+		RelatedTools: []string{"create_outgoing_webhook", "create_alert"},
 	},
 
 	// Policy tools (TCO policies for log management)
@@ -367,9 +545,10 @@ var ToolCapabilities = map[string]ToolCapability{
 		RelatedTools: []string{"update_policy", "delete_policy"},
 	},
 	"create_policy": {
-		Category:     "create",
-		ResourceType: "policy",
-		RelatedTools: []string{"list_policies"},
+		Category:       "create",
+		ResourceType:   "policy",
+		SupportsDryRun: true,
+		RelatedTools:   []string{"list_policies"},
 	},
 	"update_policy": {
 		Category:      "update",
@@ -378,10 +557,18 @@ var ToolCapabilities = map[string]ToolCapability{
 		Prerequisites: []string{"get_policy"},
 	},
 	"delete_policy": {
-		Category:      "delete",
-		ResourceType:  "policy",
-		RequiresID:    true,
-		Prerequisites: []string{"get_policy"},
+		Category:       "delete",
+		ResourceType:   "policy",
+		RequiresID:     true,
+		SupportsDryRun: true,
+		Prerequisites:  []string{"get_policy"},
+	},
+	"diff_policies": {
+		Category:     "query",
+		ResourceType: "policy",
+		IsReadOnly:   true,
+		RequiresID:   true,
+		RelatedTools: []string{"get_policy", "update_policy"},
 	},
 
 	// E2M tools (Events to Metrics conversion)
@@ -399,9 +586,10 @@ var ToolCapabilities = map[string]ToolCapability{
 		RelatedTools: []string{"replace_e2m", "delete_e2m"},
 	},
 	"create_e2m": {
-		Category:     "create",
-		ResourceType: "e2m",
-		RelatedTools: []string{"list_e2m", "query_logs"},
+		Category:       "create",
+		ResourceType:   "e2m",
+		SupportsDryRun: true,
+		RelatedTools:   []string{"list_e2m", "query_logs"},
 	},
 	"replace_e2m": {
 		Category:      "update",
@@ -431,9 +619,10 @@ var ToolCapabilities = map[string]ToolCapability{
 		RelatedTools: []string{"update_data_access_rule", "delete_data_access_rule"},
 	},
 	"create_data_access_rule": {
-		Category:     "create",
-		ResourceType: "data_access_rule",
-		RelatedTools: []string{"list_data_access_rules"},
+		Category:       "create",
+		ResourceType:   "data_access_rule",
+		SupportsDryRun: true,
+		RelatedTools:   []string{"list_data_access_rules"},
 	},
 	"update_data_access_rule": {
 		Category:      "update",
@@ -462,9 +651,10 @@ var ToolCapabilities = map[string]ToolCapability{
 		RelatedTools: []string{"update_enrichment", "delete_enrichment"},
 	},
 	"create_enrichment": {
-		Category:     "create",
-		ResourceType: "enrichment",
-		RelatedTools: []string{"list_enrichments"},
+		Category:       "create",
+		ResourceType:   "enrichment",
+		SupportsDryRun: true,
+		RelatedTools:   []string{"list_enrichments"},
 	},
 	"update_enrichment": {
 		Category:      "update",
@@ -479,6 +669,32 @@ var ToolCapabilities = map[string]ToolCapability{
 		Prerequisites: []string{"get_enrichments"},
 	},
 
+	// Data set tools (lookup tables for custom enrichments)
+	"list_data_sets": {
+		Category:     "list",
+		ResourceType: "data_set",
+		IsReadOnly:   true,
+		RelatedTools: []string{"get_data_set", "create_data_set"},
+	},
+	"get_data_set": {
+		Category:     "read",
+		ResourceType: "data_set",
+		IsReadOnly:   true,
+		RequiresID:   true,
+		RelatedTools: []string{"delete_data_set", "create_enrichment"},
+	},
+	"create_data_set": {
+		Category:     "create",
+		ResourceType: "data_set",
+		RelatedTools: []string{"create_enrichment", "list_data_sets"},
+	},
+	"delete_data_set": {
+		Category:      "delete",
+		ResourceType:  "data_set",
+		RequiresID:    true,
+		Prerequisites: []string{"get_data_set"},
+	},
+
 	// View tools (saved log views)
 	"list_views": {
 		Category:     "list",
@@ -494,9 +710,10 @@ var ToolCapabilities = map[string]ToolCapability{
 		RelatedTools: []string{"replace_view", "delete_view"},
 	},
 	"create_view": {
-		Category:     "create",
-		ResourceType: "view",
-		RelatedTools: []string{"list_views", "list_view_folders"},
+		Category:       "create",
+		ResourceType:   "view",
+		SupportsDryRun: true,
+		RelatedTools:   []string{"list_views", "list_view_folders"},
 	},
 	"replace_view": {
 		Category:      "update",
@@ -542,6 +759,12 @@ var ToolCapabilities = map[string]ToolCapability{
 		RequiresID:    true,
 		Prerequisites: []string{"get_view_folder"},
 	},
+	"move_view_to_folder": {
+		Category:      "update",
+		ResourceType:  "view",
+		RequiresID:    true,
+		Prerequisites: []string{"get_view", "list_view_folders"},
+	},
 
 	// Data access policy tools
 	"list_data_access_policies": {
@@ -582,6 +805,30 @@ var ToolCapabilities = map[string]ToolCapability{
 		IsReadOnly:   true,
 		RelatedTools: []string{"query_logs", "build_query"},
 	},
+	"normalize_query": {
+		Category:     "query",
+		ResourceType: "query",
+		IsReadOnly:   true,
+		RelatedTools: []string{"query_logs", "validate_query"},
+	},
+	"convert_lucene_to_dataprime": {
+		Category:     "query",
+		ResourceType: "query",
+		IsReadOnly:   true,
+		RelatedTools: []string{"query_logs", "build_query", "explain_query"},
+	},
+	"convert_dataprime_to_lucene": {
+		Category:     "query",
+		ResourceType: "query",
+		IsReadOnly:   true,
+		RelatedTools: []string{"create_view", "convert_lucene_to_dataprime"},
+	},
+	"parse_and_explain_error": {
+		Category:     "query",
+		ResourceType: "query",
+		IsReadOnly:   true,
+		RelatedTools: []string{"query_logs", "investigate_incident"},
+	},
 	"suggest_alert": {
 		Category:     "query",
 		ResourceType: "alert",
@@ -593,6 +840,55 @@ var ToolCapabilities = map[string]ToolCapability{
 		ResourceType: "audit",
 		IsReadOnly:   true,
 	},
+	"record_feedback": {
+		Category:     "update",
+		ResourceType: "feedback",
+		RelatedTools: []string{"discover_tools", "session_context"},
+	},
+	"whoami": {
+		Category:     "read",
+		ResourceType: "instance",
+		IsReadOnly:   true,
+		RelatedTools: []string{"session_context", "health_check"},
+	},
+	"list_instances": {
+		Category:     "list",
+		ResourceType: "instance",
+		IsReadOnly:   true,
+		RelatedTools: []string{"whoami"},
+	},
+	"search_all_resources": {
+		Category:     "read",
+		ResourceType: "resource",
+		IsReadOnly:   true,
+		RelatedTools: []string{"list_alerts", "list_dashboards", "list_views", "list_policies", "list_e2m"},
+	},
+	"check_references": {
+		Category:     "read",
+		ResourceType: "resource",
+		IsReadOnly:   true,
+		RelatedTools: []string{"delete_outgoing_webhook", "list_alerts", "list_views", "list_dashboard_folders"},
+	},
+	"backup_instance": {
+		Category:     "read",
+		ResourceType: "resource",
+		IsReadOnly:   true,
+		RelatedTools: []string{"restore_instance", "diff_backup", "export_alert", "export_terraform"},
+	},
+	"restore_instance": {
+		Category:       "create",
+		ResourceType:   "resource",
+		SupportsDryRun: true,
+		Prerequisites:  []string{"backup_instance"},
+		RelatedTools:   []string{"check_references"},
+	},
+	"diff_backup": {
+		Category:      "read",
+		ResourceType:  "resource",
+		IsReadOnly:    true,
+		Prerequisites: []string{"backup_instance"},
+		RelatedTools:  []string{"restore_instance", "compare_alerts"},
+	},
 }
 
 // GetToolCapability returns the capability annotation for a tool, or nil if not found
@@ -0,0 +1,318 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements trace_logs, which reconstructs a request's journey from its trace ID.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+const (
+	// defaultTraceLogLimit is the number of trace entries returned unless set
+	defaultTraceLogLimit = 500
+	// maxTraceLogLimit caps the entries of one trace
+	maxTraceLogLimit = 5000
+)
+
+// traceIDPattern accepts W3C, Zipkin, Jaeger and UUID style trace IDs
+var traceIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// TraceLogsTool fetches every log entry of one distributed trace in time order
+type TraceLogsTool struct{ *BaseTool }
+
+// NewTraceLogsTool creates a new tool instance
+func NewTraceLogsTool(c client.Doer, l *zap.Logger) *TraceLogsTool {
+	return &TraceLogsTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *TraceLogsTool) Name() string { return "trace_logs" }
+
+// Annotations returns tool hints for LLMs
+func (t *TraceLogsTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Trace Logs")
+}
+
+// DefaultTimeout returns the timeout for the trace query
+func (t *TraceLogsTool) DefaultTimeout() time.Duration {
+	return DefaultQueryTimeout
+}
+
+// Description returns the tool description
+func (t *TraceLogsTool) Description() string {
+	return fmt.Sprintf(`Reconstruct a request's journey across services from its trace ID.
+
+Fetches every log entry whose trace ID field matches, across all applications and subsystems,
+ordered oldest first. Each entry shows its offset from the start of the trace, and the result
+lists the services the trace touched in the order it reached them. Chatty traces are capped at
+limit entries (default %d, max %d) with a note when more exist.
+
+**Related tools:** query_logs, investigate_incident, smart_investigate`, defaultTraceLogLimit, maxTraceLogLimit)
+}
+
+// InputSchema returns the input schema
+func (t *TraceLogsTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"trace_id": map[string]interface{}{
+				"type":        "string",
+				"description": "Trace ID to follow, e.g. 4bf92f3577b34da6a3ce929d0e0e4736",
+				"pattern":     traceIDPattern.String(),
+			},
+			"trace_field": map[string]interface{}{
+				"type":        "string",
+				"description": "Log field holding the trace ID (default: trace_id). Use e.g. traceId or trace.id for other conventions.",
+			},
+			"time_range": map[string]interface{}{
+				"type":        "string",
+				"description": "How far back to search when start_date is not set (default: 24h)",
+				"enum":        []string{"15m", "1h", "6h", "24h", "7d"},
+				"default":     "24h",
+			},
+			"start_date": map[string]interface{}{
+				"type":        "string",
+				"description": "Start of the search window (RFC3339). Overrides time_range.",
+			},
+			"end_date": map[string]interface{}{
+				"type":        "string",
+				"description": "End of the search window (RFC3339, default: now)",
+			},
+			"tier": map[string]interface{}{
+				"type":        "string",
+				"description": "Tier to search (default: archive)",
+				"enum":        []string{"archive", "frequent_search"},
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"description": fmt.Sprintf("Maximum entries to return (default: %d, max: %d)", defaultTraceLogLimit, maxTraceLogLimit),
+				"minimum":     1,
+				"maximum":     maxTraceLogLimit,
+			},
+		},
+		"required": []string{"trace_id"},
+	}
+}
+
+// TraceService is one application/subsystem pair a trace passed through
+type TraceService struct {
+	Application string `json:"application"`
+	Subsystem   string `json:"subsystem"`
+	Entries     int    `json:"entries"`
+	FirstSeen   string `json:"first_seen,omitempty"`
+	LastSeen    string `json:"last_seen,omitempty"`
+}
+
+// Execute executes the tool
+func (t *TraceLogsTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	traceID, err := GetStringParam(args, "trace_id", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	if !traceIDPattern.MatchString(traceID) {
+		return NewToolResultError(fmt.Sprintf("invalid trace_id %q: use letters, digits, '.', '_', ':' or '-' (max 128 characters)", traceID)), nil
+	}
+	field, _ := GetStringParam(args, "trace_field", false)
+	if field == "" {
+		field = "trace_id"
+	}
+	field = toDataPrimeField(field)
+
+	start, end, err := resolveQueryWindow(args)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+
+	tier, _ := GetStringParam(args, "tier", false)
+	if tier == "" {
+		tier = "archive"
+	} else {
+		tier = normalizeTier(tier)
+	}
+	limit, err := GetIntParam(args, "limit", false)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	if limit <= 0 {
+		limit = defaultTraceLogLimit
+	}
+	if limit > maxTraceLogLimit {
+		limit = maxTraceLogLimit
+	}
+
+	// Fetch one extra entry so truncation can be reported
+	query := fmt.Sprintf("source logs | filter %s == '%s' | sortby $m.timestamp asc | limit %d", field, traceID, limit+1)
+	query, _, err = PrepareQuery(query, tier, "dataprime")
+	if err != nil {
+		return NewToolResultError(fmt.Sprintf("Invalid trace query: %v", err)), nil
+	}
+
+	result, err := t.ExecuteRequestWithMaxEvents(ctx, &client.Request{
+		Method: "POST",
+		Path:   "/v1/query",
+		Body: map[string]interface{}{
+			"query": query,
+			"metadata": map[string]interface{}{
+				"tier":       tier,
+				"syntax":     "dataprime",
+				"start_date": start.Format(time.RFC3339),
+				"end_date":   end.Format(time.RFC3339),
+				"limit":      limit + 1,
+			},
+		},
+		AcceptSSE: true,
+		Timeout:   DefaultQueryTimeout,
+	}, limit+1)
+	if err != nil {
+		return NewToolResultError(FormatQueryError(query, err.Error())), nil
+	}
+
+	events, _ := result["events"].([]interface{})
+	entries := make([]map[string]interface{}, 0, len(events))
+	for _, event := range events {
+		if eventMap, ok := event.(map[string]interface{}); ok {
+			entries = append(entries, transformLogEntry(eventMap))
+		}
+	}
+	truncated := len(entries) > limit
+	if truncated {
+		entries = entries[:limit]
+	}
+
+	response := map[string]interface{}{
+		"trace_id": traceID,
+		"query":    query,
+		"window": map[string]interface{}{
+			"start_date": start.Format(time.RFC3339),
+			"end_date":   end.Format(time.RFC3339),
+		},
+		"count":     len(entries),
+		"truncated": truncated,
+	}
+	if len(entries) == 0 {
+		response["note"] = fmt.Sprintf("No logs with %s == '%s' in this window. Widen time_range or set trace_field if your services log the trace ID under another name.", field, traceID)
+		return t.FormatResponse(response)
+	}
+	if truncated {
+		response["note"] = fmt.Sprintf("The trace has more than %d entries; only the first %d are shown. Raise limit (max %d) or narrow start_date/end_date to see later entries.", limit, limit, maxTraceLogLimit)
+	}
+
+	services, duration := summarizeTrace(entries)
+	applications, subsystems := []string{}, []string{}
+	seenApp, seenSub := map[string]bool{}, map[string]bool{}
+	for _, s := range services {
+		if !seenApp[s.Application] {
+			seenApp[s.Application] = true
+			applications = append(applications, s.Application)
+		}
+		if !seenSub[s.Subsystem] {
+			seenSub[s.Subsystem] = true
+			subsystems = append(subsystems, s.Subsystem)
+		}
+	}
+	response["applications"] = applications
+	response["subsystems"] = subsystems
+	response["services"] = services
+	if duration > 0 {
+		response["duration"] = duration.String()
+	}
+	response["entries"] = entries
+
+	return t.FormatResponse(response)
+}
+
+// resolveQueryWindow resolves the search window from start_date/end_date or time_range (default 24h)
+func resolveQueryWindow(args map[string]interface{}) (time.Time, time.Time, error) {
+	end := time.Now().UTC()
+	if s, _ := GetStringParam(args, "end_date", false); s != "" {
+		parsed, ok := parseQueryInstant(s)
+		if !ok {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid end_date %q: use RFC3339 such as 2024-01-01T00:00:00Z", s)
+		}
+		end = parsed
+	}
+	if s, _ := GetStringParam(args, "start_date", false); s != "" {
+		start, ok := parseQueryInstant(s)
+		if !ok {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid start_date %q: use RFC3339 such as 2024-01-01T00:00:00Z", s)
+		}
+		if !start.Before(end) {
+			return time.Time{}, time.Time{}, fmt.Errorf("start_date must be before end_date")
+		}
+		return start, end, nil
+	}
+
+	timeRange, _ := GetStringParam(args, "time_range", false)
+	if timeRange == "" {
+		timeRange = "24h"
+	}
+	window, ok := logSourceWindows[timeRange]
+	if !ok {
+		return time.Time{}, time.Time{}, fmt.Errorf("time_range must be one of 15m, 1h, 6h, 24h, 7d (got %q)", timeRange)
+	}
+	return end.Add(-window), end, nil
+}
+
+// summarizeTrace orders the entries chronologically, annotates each with its offset from the
+// first entry, and returns the services in the order the trace reached them with its duration
+func summarizeTrace(entries []map[string]interface{}) ([]TraceService, time.Duration) {
+	entryTime := func(e map[string]interface{}) (time.Time, bool) {
+		return parseQueryInstant(e["time"])
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		ti, okI := entryTime(entries[i])
+		tj, okJ := entryTime(entries[j])
+		return okI && okJ && ti.Before(tj)
+	})
+
+	var first, last time.Time
+	byService := map[string]*TraceService{}
+	var services []*TraceService
+	for _, e := range entries {
+		app, _ := e["app"].(string)
+		sub, _ := e["subsystem"].(string)
+		if app == "" {
+			app = "unknown"
+		}
+		if sub == "" {
+			sub = "unknown"
+		}
+		key := app + "\x00" + sub
+		s, ok := byService[key]
+		if !ok {
+			s = &TraceService{Application: app, Subsystem: sub}
+			byService[key] = s
+			services = append(services, s)
+		}
+		s.Entries++
+
+		ts, ok := entryTime(e)
+		if !ok {
+			continue
+		}
+		if first.IsZero() {
+			first = ts
+		}
+		last = ts
+		e["offset_ms"] = ts.Sub(first).Milliseconds()
+		raw, _ := e["time"].(string)
+		if s.FirstSeen == "" {
+			s.FirstSeen = raw
+		}
+		s.LastSeen = raw
+	}
+
+	out := make([]TraceService, len(services))
+	for i, s := range services {
+		out[i] = *s
+	}
+	return out, last.Sub(first)
+}
@@ -0,0 +1,121 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// traceSSE builds a query response with one entry per (time, app, subsystem, message)
+func traceSSE(entries ...[4]string) []byte {
+	results := make([]map[string]interface{}, 0, len(entries))
+	for _, e := range entries {
+		userData, _ := json.Marshal(map[string]interface{}{"message": e[3], "trace_id": "abc123"})
+		results = append(results, map[string]interface{}{
+			"metadata":  []interface{}{map[string]interface{}{"key": "timestamp", "value": e[0]}},
+			"labels":    []interface{}{map[string]interface{}{"key": "applicationname", "value": e[1]}, map[string]interface{}{"key": "subsystemname", "value": e[2]}},
+			"user_data": string(userData),
+		})
+	}
+	data, _ := json.Marshal(map[string]interface{}{"result": map[string]interface{}{"results": results}})
+	return []byte("data: " + string(data) + "\n")
+}
+
+func TestTraceLogsTool(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.DefaultResponse = &client.Response{StatusCode: 200, Body: traceSSE(
+		[4]string{"2024-05-01T10:00:00.250Z", "payments", "worker", "charge card"},
+		[4]string{"2024-05-01T10:00:00.000Z", "gateway", "ingress", "request received"},
+		[4]string{"2024-05-01T10:00:00.100Z", "checkout", "api", "create order"},
+		[4]string{"2024-05-01T10:00:00.400Z", "gateway", "ingress", "response sent"},
+	)}
+
+	result, err := NewTraceLogsTool(mock, zap.NewNop()).Execute(testCtx(mock), map[string]interface{}{
+		"trace_id":   "abc123",
+		"start_date": "2024-05-01T09:00:00Z",
+		"end_date":   "2024-05-01T11:00:00Z",
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError, "%+v", result.Content)
+
+	body := mock.LastRequest().Body.(map[string]interface{})
+	assert.Contains(t, body["query"], "filter $d.trace_id == 'abc123'")
+	metadata := body["metadata"].(map[string]interface{})
+	assert.Equal(t, "2024-05-01T09:00:00Z", metadata["start_date"])
+	assert.Equal(t, defaultTraceLogLimit+1, metadata["limit"])
+
+	var out struct {
+		Count        int                      `json:"count"`
+		Truncated    bool                     `json:"truncated"`
+		Applications []string                 `json:"applications"`
+		Subsystems   []string                 `json:"subsystems"`
+		Services     []TraceService           `json:"services"`
+		Duration     string                   `json:"duration"`
+		Entries      []map[string]interface{} `json:"entries"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &out))
+	assert.Equal(t, 4, out.Count)
+	assert.False(t, out.Truncated)
+	assert.Equal(t, []string{"gateway", "checkout", "payments"}, out.Applications)
+	assert.Equal(t, []string{"ingress", "api", "worker"}, out.Subsystems)
+	assert.Equal(t, "400ms", out.Duration)
+	require.Len(t, out.Services, 3)
+	assert.Equal(t, TraceService{Application: "gateway", Subsystem: "ingress", Entries: 2,
+		FirstSeen: "2024-05-01T10:00:00.000Z", LastSeen: "2024-05-01T10:00:00.400Z"}, out.Services[0])
+
+	require.Len(t, out.Entries, 4)
+	assert.Equal(t, "request received", out.Entries[0]["message"])
+	assert.Equal(t, "charge card", out.Entries[2]["message"])
+	assert.EqualValues(t, 250, out.Entries[2]["offset_ms"])
+}
+
+func TestTraceLogsTool_Truncation(t *testing.T) {
+	var entries [][4]string
+	for i := 0; i < 4; i++ {
+		entries = append(entries, [4]string{fmt.Sprintf("2024-05-01T10:00:0%dZ", i), "app", "sub", "step"})
+	}
+	mock := client.NewMockClient()
+	mock.DefaultResponse = &client.Response{StatusCode: 200, Body: traceSSE(entries...)}
+
+	result, _ := NewTraceLogsTool(mock, zap.NewNop()).Execute(testCtx(mock), map[string]interface{}{
+		"trace_id": "abc123", "trace_field": "traceId", "limit": float64(3),
+	})
+	require.False(t, result.IsError, "%+v", result.Content)
+	assert.Contains(t, mock.LastRequest().Body.(map[string]interface{})["query"], "$d.traceId == 'abc123'")
+
+	text := result.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, `"truncated": true`)
+	assert.Contains(t, text, `"count": 3`)
+	assert.Contains(t, text, "more than 3 entries")
+}
+
+func TestTraceLogsTool_Validation(t *testing.T) {
+	mock := client.NewMockClient()
+	tool := NewTraceLogsTool(mock, zap.NewNop())
+	for _, args := range []map[string]interface{}{
+		{},
+		{"trace_id": "abc' || true"},
+		{"trace_id": "abc", "time_range": "30d"},
+		{"trace_id": "abc", "start_date": "yesterday"},
+		{"trace_id": "abc", "start_date": "2024-05-02T00:00:00Z", "end_date": "2024-05-01T00:00:00Z"},
+	} {
+		result, err := tool.Execute(testCtx(mock), args)
+		require.NoError(t, err)
+		assert.True(t, result.IsError, "expected %v to be rejected", args)
+	}
+	assert.Zero(t, mock.RequestCount())
+
+	// An empty trace explains how to widen the search
+	mock.DefaultResponse = &client.Response{StatusCode: 200, Body: []byte(`{"events":[]}`)}
+	result, _ := tool.Execute(testCtx(mock), map[string]interface{}{"trace_id": "abc"})
+	require.False(t, result.IsError)
+	assert.True(t, strings.Contains(result.Content[0].(*mcp.TextContent).Text, "Widen time_range"))
+}
@@ -305,6 +305,18 @@ func toTitleCase(s string) string {
 	return strings.Join(words, " ")
 }
 
+// MarkDryRun flags a result as a dry run that changed nothing, so it is left out of the
+// audit trail
+func MarkDryRun(result *mcp.CallToolResult, err error) (*mcp.CallToolResult, error) {
+	if result != nil {
+		if result.Meta == nil {
+			result.Meta = mcp.Meta{}
+		}
+		result.Meta["dry_run"] = true
+	}
+	return result, err
+}
+
 // FormatDryRunResult creates a formatted response for dry-run validation
 func FormatDryRunResult(result *ValidationResult, resourceType string, config map[string]interface{}) *mcp.CallToolResult {
 	var builder strings.Builder
@@ -391,5 +403,6 @@ func FormatDryRunResult(result *ValidationResult, resourceType string, config ma
 				Text: builder.String(),
 			},
 		},
+		Meta: mcp.Meta{"dry_run": true},
 	}
 }
@@ -0,0 +1,98 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// MoveViewToFolderTool moves an existing view into a folder (or back to the root).
+type MoveViewToFolderTool struct{ *BaseTool }
+
+// NewMoveViewToFolderTool creates a new tool instance
+func NewMoveViewToFolderTool(c client.Doer, l *zap.Logger) *MoveViewToFolderTool {
+	return &MoveViewToFolderTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *MoveViewToFolderTool) Name() string { return "move_view_to_folder" }
+
+// Annotations returns tool hints for LLMs
+func (t *MoveViewToFolderTool) Annotations() *mcp.ToolAnnotations {
+	return UpdateAnnotations("Move View to Folder")
+}
+
+// Description returns the tool description
+func (t *MoveViewToFolderTool) Description() string {
+	return `Move an existing view into a view folder, or back to the root when folder_id is empty.
+
+Fetches the view, sets its folder_id, and replaces it, preserving all other fields.
+
+**Related tools:** list_views, list_view_folders, create_view_folder, replace_view`
+}
+
+// InputSchema returns the input schema
+func (t *MoveViewToFolderTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"id": map[string]interface{}{
+				"type":        "string",
+				"description": "ID of the view to move",
+			},
+			"folder_id": map[string]interface{}{
+				"type":        "string",
+				"description": "Target view folder ID (empty string moves the view to the root)",
+			},
+		},
+		"required": []string{"id", "folder_id"},
+	}
+}
+
+// Execute executes the tool
+func (t *MoveViewToFolderTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	id, err := GetStringParam(args, "id", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	if _, ok := args["folder_id"].(string); !ok {
+		return NewToolResultError("folder_id is required (use an empty string to move the view to the root)"), nil
+	}
+	folderID, _ := GetStringParam(args, "folder_id", false)
+
+	if folderID != "" {
+		if _, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/view_folders/" + folderID}); err != nil {
+			var apiErr *APIError
+			if errors.As(err, &apiErr) && apiErr.IsNotFound() {
+				return NewToolResultErrorWithSuggestion(
+					fmt.Sprintf("View folder '%s' does not exist", folderID),
+					"Use list_view_folders to find the folder ID, or create_view_folder to create it."), nil
+			}
+			return NewToolResultErrorFromErr(err), nil
+		}
+	}
+
+	view, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/views/" + id})
+	if err != nil {
+		return HandleGetError(err, "View", id, "list_views"), nil
+	}
+
+	delete(view, "id")
+	if folderID == "" {
+		delete(view, "folder_id")
+	} else {
+		view["folder_id"] = folderID
+	}
+
+	res, err := t.ExecuteRequest(ctx, &client.Request{Method: "PUT", Path: "/v1/views/" + id, Body: view})
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	GetCacheHelperFromContext(ctx).InvalidateRelated(t.Name())
+	return t.FormatResponseWithSuggestions(res, "move_view_to_folder")
+}
@@ -0,0 +1,98 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestMoveViewToFolderTool_Execute(t *testing.T) {
+	view := func() map[string]interface{} {
+		return map[string]interface{}{
+			"id":           float64(42),
+			"name":         "errors",
+			"search_query": map[string]interface{}{"query": "severity:error"},
+			"folder_id":    "old-folder",
+		}
+	}
+
+	t.Run("moves view and preserves fields", func(t *testing.T) {
+		mock := client.NewMockClient()
+		var putBody map[string]interface{}
+		mock.DoFunc = func(_ context.Context, req *client.Request) (*client.Response, error) {
+			switch {
+			case req.Method == "GET" && strings.HasPrefix(req.Path, "/v1/view_folders/"):
+				return &client.Response{StatusCode: 200, Body: []byte(`{"id":"new-folder","name":"Team"}`)}, nil
+			case req.Method == "GET":
+				return jsonResponse(view()), nil
+			default:
+				putBody = req.Body.(map[string]interface{})
+				return &client.Response{StatusCode: 200, Body: []byte(`{"id":42}`)}, nil
+			}
+		}
+
+		tool := NewMoveViewToFolderTool(mock, zap.NewNop())
+		result, err := tool.Execute(testCtx(mock), map[string]interface{}{"id": "42", "folder_id": "new-folder"})
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+		require.NotNil(t, putBody)
+		assert.Equal(t, "new-folder", putBody["folder_id"])
+		assert.Equal(t, "errors", putBody["name"])
+		assert.NotNil(t, putBody["search_query"])
+		assert.Equal(t, "/v1/views/42", mock.LastRequest().Path)
+	})
+
+	t.Run("empty folder_id moves to root", func(t *testing.T) {
+		mock := client.NewMockClient()
+		var putBody map[string]interface{}
+		mock.DoFunc = func(_ context.Context, req *client.Request) (*client.Response, error) {
+			if req.Method == "GET" {
+				assert.Equal(t, "/v1/views/42", req.Path)
+				return jsonResponse(view()), nil
+			}
+			putBody = req.Body.(map[string]interface{})
+			return &client.Response{StatusCode: 200, Body: []byte(`{"id":42}`)}, nil
+		}
+
+		tool := NewMoveViewToFolderTool(mock, zap.NewNop())
+		result, err := tool.Execute(testCtx(mock), map[string]interface{}{"id": "42", "folder_id": ""})
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+		assert.NotContains(t, putBody, "folder_id")
+	})
+
+	t.Run("nonexistent folder", func(t *testing.T) {
+		mock := client.NewMockClient()
+		mock.RespondWith(404, map[string]interface{}{"message": "not found"})
+
+		tool := NewMoveViewToFolderTool(mock, zap.NewNop())
+		result, err := tool.Execute(testCtx(mock), map[string]interface{}{"id": "42", "folder_id": "missing"})
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "does not exist")
+		assert.Equal(t, 1, mock.RequestCount())
+	})
+
+	t.Run("folder_id required", func(t *testing.T) {
+		mock := client.NewMockClient()
+		tool := NewMoveViewToFolderTool(mock, zap.NewNop())
+		result, err := tool.Execute(testCtx(mock), map[string]interface{}{"id": "42"})
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Equal(t, 0, mock.RequestCount())
+	})
+}
+
+// jsonResponse wraps a map in a 200 mock response
+func jsonResponse(body map[string]interface{}) *client.Response {
+	b, _ := json.Marshal(body)
+	return &client.Response{StatusCode: 200, Body: b}
+}
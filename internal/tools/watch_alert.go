@@ -0,0 +1,225 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements watch_alert, which polls an alert until it reaches a target state.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+const (
+	// defaultWatchAlertDuration is how long an alert is watched unless set
+	defaultWatchAlertDuration = 2 * time.Minute
+	// maxWatchAlertDuration is the hard cap on one watch
+	maxWatchAlertDuration = 10 * time.Minute
+	// defaultWatchAlertInterval is the time between polls unless set
+	defaultWatchAlertInterval = 15 * time.Second
+)
+
+// watchAlertMinInterval is the shortest accepted poll interval; a variable so tests can poll quickly
+var watchAlertMinInterval = 5 * time.Second
+
+// watchAlertStates are the states watch_alert can wait for, matching list_alerts' state filter
+var watchAlertStates = []string{"active", "inactive", "triggered"}
+
+// AlertObservation is the state of an alert at one poll
+type AlertObservation struct {
+	Time            string `json:"time"`
+	State           string `json:"state"`
+	LastTriggeredAt string `json:"last_triggered_at,omitempty"`
+	Error           string `json:"error,omitempty"`
+}
+
+// WatchAlertTool polls an alert and reports the states it passes through
+type WatchAlertTool struct{ *BaseTool }
+
+// NewWatchAlertTool creates a new tool instance
+func NewWatchAlertTool(c client.Doer, l *zap.Logger) *WatchAlertTool {
+	return &WatchAlertTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *WatchAlertTool) Name() string { return "watch_alert" }
+
+// Annotations returns tool hints for LLMs
+func (t *WatchAlertTool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Watch Alert")
+}
+
+// DefaultTimeout covers the longest watch plus one final poll
+func (t *WatchAlertTool) DefaultTimeout() time.Duration {
+	return maxWatchAlertDuration + DefaultGetTimeout
+}
+
+// Description returns the tool description
+func (t *WatchAlertTool) Description() string {
+	return fmt.Sprintf(`Poll an alert's state over a bounded duration to verify it behaves as expected, e.g. that a newly created alert fires under load.
+
+The alert is fetched every interval (default %s, min %s) for up to duration (default %s, max %s),
+stopping early once it reaches target_state (default: triggered). An alert counts as triggered
+when its state says so or its last_triggered_at changes during the watch. Returns every
+observation with its timestamp, whether the target was reached, and how long it took.
+
+**Related tools:** get_alert, create_alert, list_alerts, ingest_logs`,
+		defaultWatchAlertInterval, watchAlertMinInterval, defaultWatchAlertDuration, maxWatchAlertDuration)
+}
+
+// InputSchema returns the input schema
+func (t *WatchAlertTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"id": map[string]interface{}{
+				"type":        "string",
+				"description": "The unique identifier of the alert to watch",
+			},
+			"target_state": map[string]interface{}{
+				"type":        "string",
+				"description": "Stop as soon as the alert reaches this state (default: triggered)",
+				"enum":        watchAlertStates,
+				"default":     "triggered",
+			},
+			"duration": map[string]interface{}{
+				"type":        "string",
+				"description": fmt.Sprintf("How long to watch, e.g. 90s or 5m (default: %s, max: %s)", defaultWatchAlertDuration, maxWatchAlertDuration),
+			},
+			"interval": map[string]interface{}{
+				"type":        "string",
+				"description": fmt.Sprintf("Time between polls, e.g. 10s (default: %s, min: %s)", defaultWatchAlertInterval, watchAlertMinInterval),
+			},
+		},
+		"required": []string{"id"},
+	}
+}
+
+// Execute executes the tool
+func (t *WatchAlertTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	id, err := GetStringParam(args, "id", true)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	target, _ := GetStringParam(args, "target_state", false)
+	if target == "" {
+		target = "triggered"
+	}
+	if !slices.Contains(watchAlertStates, target) {
+		return NewToolResultError(fmt.Sprintf("invalid target_state '%s'. Valid values: %s", target, strings.Join(watchAlertStates, ", "))), nil
+	}
+	duration, err := getWatchDuration(args, "duration", defaultWatchAlertDuration)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	if duration > maxWatchAlertDuration {
+		return NewToolResultError(fmt.Sprintf("duration %s exceeds the maximum of %s", duration, maxWatchAlertDuration)), nil
+	}
+	interval, err := getWatchDuration(args, "interval", defaultWatchAlertInterval)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	if interval < watchAlertMinInterval {
+		return NewToolResultError(fmt.Sprintf("interval %s is below the minimum of %s", interval, watchAlertMinInterval)), nil
+	}
+
+	// The first poll must succeed so an unknown ID fails fast instead of being watched
+	start := time.Now()
+	alert, err := t.fetchAlert(ctx, id)
+	if err != nil {
+		return HandleGetError(err, "Alert", id, "list_alerts"), nil
+	}
+	initialTrigger, _ := alert["last_triggered_at"].(string)
+	first := observeAlert(alert, initialTrigger)
+	observations := []AlertObservation{first}
+	reached := first.State == target
+
+	deadline := start.Add(duration)
+	stopReason := "duration elapsed"
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for !reached && time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+		case <-ticker.C:
+		}
+		if ctx.Err() != nil {
+			stopReason = "cancelled: " + ctx.Err().Error()
+			break
+		}
+
+		alert, err := t.fetchAlert(ctx, id)
+		if err != nil {
+			observations = append(observations, AlertObservation{
+				Time: time.Now().UTC().Format(time.RFC3339), State: "unknown", Error: err.Error(),
+			})
+			continue
+		}
+		obs := observeAlert(alert, initialTrigger)
+		observations = append(observations, obs)
+		reached = obs.State == target
+	}
+
+	name, _ := alert["name"].(string)
+	response := map[string]interface{}{
+		"alert_id":       id,
+		"alert_name":     name,
+		"target_state":   target,
+		"reached_target": reached,
+		"polls":          len(observations),
+		"watched_for":    time.Since(start).Round(time.Second).String(),
+		"observations":   observations,
+	}
+	if reached {
+		response["stopped"] = "target state reached"
+	} else {
+		response["stopped"] = stopReason
+		response["hint"] = fmt.Sprintf("The alert did not reach %s. Check its condition with get_alert, confirm matching logs arrive with query_logs, or watch longer.", target)
+	}
+	return t.FormatResponse(response)
+}
+
+// fetchAlert gets the current alert, bounding each poll by the get timeout
+func (t *WatchAlertTool) fetchAlert(ctx context.Context, id string) (map[string]interface{}, error) {
+	return t.ExecuteRequest(ctx, &client.Request{
+		Method:  "GET",
+		Path:    "/v1/alerts/" + id,
+		Timeout: DefaultGetTimeout,
+	})
+}
+
+// observeAlert derives an alert's state: triggered when the API says so or its
+// last_triggered_at moved past initialTrigger, otherwise active or inactive
+func observeAlert(alert map[string]interface{}, initialTrigger string) AlertObservation {
+	obs := AlertObservation{Time: time.Now().UTC().Format(time.RFC3339)}
+	obs.LastTriggeredAt, _ = alert["last_triggered_at"].(string)
+
+	state, _ := alert["state"].(string)
+	switch {
+	case strings.EqualFold(state, "triggered"), obs.LastTriggeredAt != "" && obs.LastTriggeredAt != initialTrigger:
+		obs.State = "triggered"
+	case alert["is_active"] == false:
+		obs.State = "inactive"
+	default:
+		obs.State = "active"
+	}
+	return obs
+}
+
+// getWatchDuration reads an optional positive Go duration argument
+func getWatchDuration(args map[string]interface{}, key string, def time.Duration) (time.Duration, error) {
+	s, _ := GetStringParam(args, key, false)
+	if s == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid %s %q: use a positive duration such as 30s or 2m", key, s)
+	}
+	return d, nil
+}
@@ -0,0 +1,112 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+type watchAlertResponse struct {
+	ReachedTarget bool               `json:"reached_target"`
+	Polls         int                `json:"polls"`
+	Stopped       string             `json:"stopped"`
+	Observations  []AlertObservation `json:"observations"`
+}
+
+func fastWatchAlertPolls(t *testing.T) {
+	prev := watchAlertMinInterval
+	watchAlertMinInterval = time.Millisecond
+	t.Cleanup(func() { watchAlertMinInterval = prev })
+}
+
+func decodeWatchAlert(t *testing.T, result *mcp.CallToolResult) watchAlertResponse {
+	t.Helper()
+	require.False(t, result.IsError, "%+v", result.Content)
+	var resp watchAlertResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &resp))
+	return resp
+}
+
+func TestWatchAlertTool_StopsAtTargetState(t *testing.T) {
+	fastWatchAlertPolls(t)
+	mock := client.NewMockClient()
+	mock.RespondWith(200, map[string]interface{}{"id": "a1", "name": "High errors", "is_active": true, "last_triggered_at": "2024-01-01T00:00:00Z"})
+	mock.RespondWithError(errors.New("connection reset"))
+	mock.RespondWith(200, map[string]interface{}{"id": "a1", "is_active": true, "last_triggered_at": "2024-01-01T00:00:00Z"})
+	mock.RespondWith(200, map[string]interface{}{"id": "a1", "is_active": true, "last_triggered_at": "2024-01-01T00:05:00Z"})
+	tool := NewWatchAlertTool(mock, zap.NewNop())
+
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{"id": "a1", "interval": "1ms", "duration": "5s"})
+	require.NoError(t, err)
+	resp := decodeWatchAlert(t, result)
+	assert.True(t, resp.ReachedTarget)
+	assert.Equal(t, "target state reached", resp.Stopped)
+	require.Equal(t, 4, resp.Polls)
+	assert.Equal(t, "active", resp.Observations[0].State, "a trigger before the watch does not count")
+	assert.Equal(t, "unknown", resp.Observations[1].State)
+	assert.Contains(t, resp.Observations[1].Error, "connection reset")
+	assert.Equal(t, "triggered", resp.Observations[3].State)
+	assert.Equal(t, 4, mock.RequestCount(), "no polls after the target is reached")
+}
+
+func TestWatchAlertTool_DurationElapsed(t *testing.T) {
+	fastWatchAlertPolls(t)
+	mock := client.NewMockClient()
+	mock.DefaultResponse = &client.Response{StatusCode: 200, Body: []byte(`{"id":"a1","is_active":false}`)}
+	tool := NewWatchAlertTool(mock, zap.NewNop())
+
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{"id": "a1", "interval": "5ms", "duration": "30ms"})
+	require.NoError(t, err)
+	resp := decodeWatchAlert(t, result)
+	assert.False(t, resp.ReachedTarget)
+	assert.Equal(t, "duration elapsed", resp.Stopped)
+	assert.Greater(t, resp.Polls, 1)
+	assert.Equal(t, "inactive", resp.Observations[0].State)
+
+	result, err = tool.Execute(testCtx(mock), map[string]interface{}{"id": "a1", "interval": "5ms", "target_state": "inactive"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, decodeWatchAlert(t, result).Polls)
+}
+
+func TestWatchAlertTool_Cancelled(t *testing.T) {
+	fastWatchAlertPolls(t)
+	mock := client.NewMockClient()
+	mock.DefaultResponse = &client.Response{StatusCode: 200, Body: []byte(`{"id":"a1","is_active":true}`)}
+	tool := NewWatchAlertTool(mock, zap.NewNop())
+
+	ctx, cancel := context.WithTimeout(testCtx(mock), 30*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	result, err := tool.Execute(ctx, map[string]interface{}{"id": "a1", "interval": "5ms", "duration": "10m"})
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	resp := decodeWatchAlert(t, result)
+	assert.False(t, resp.ReachedTarget)
+	assert.Contains(t, resp.Stopped, "cancelled")
+}
+
+func TestWatchAlertTool_InvalidArguments(t *testing.T) {
+	mock := client.NewMockClient()
+	tool := NewWatchAlertTool(mock, zap.NewNop())
+	for _, args := range []map[string]interface{}{
+		{},
+		{"id": "a1", "target_state": "firing"},
+		{"id": "a1", "duration": "11m"},
+		{"id": "a1", "duration": "soon"},
+		{"id": "a1", "interval": "1s"},
+	} {
+		result, err := tool.Execute(testCtx(mock), args)
+		require.NoError(t, err)
+		assert.True(t, result.IsError, "%v", args)
+	}
+	assert.Zero(t, mock.RequestCount())
+}
@@ -0,0 +1,318 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+	"github.com/tareqmamari/cloud-logs-mcp/internal/security"
+)
+
+// webhookTestTimeout bounds how long a webhook connectivity test may take
+const webhookTestTimeout = 10 * time.Second
+
+// webhookHTTPClient sends webhook test payloads and scheduled reports. The URLs come from
+// callers, so it only connects to public addresses (checked on the resolved IP, which also
+// covers DNS rebinding), does not use a proxy, and does not follow redirects.
+var webhookHTTPClient = &http.Client{
+	Timeout: webhookTestTimeout,
+	Transport: &http.Transport{
+		Proxy: nil,
+		DialContext: (&net.Dialer{
+			Timeout: webhookTestTimeout,
+			Control: func(_, address string, _ syscall.RawConn) error {
+				host, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return err
+				}
+				if ip := net.ParseIP(host); ip == nil || !webhookAddressAllowed(ip) {
+					return fmt.Errorf("webhook address %s is not a public address", host)
+				}
+				return nil
+			},
+		}).DialContext,
+		TLSHandshakeTimeout: webhookTestTimeout,
+	},
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// webhookAddressAllowed decides which IPs webhook requests may connect to; replaced in tests
+var webhookAddressAllowed = isPublicIP
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), not covered by net.IP.IsPrivate
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// isPublicIP reports whether ip is a globally routable unicast address. Loopback, private,
+// link-local (including the 169.254.169.254 metadata service) and shared ranges are not.
+func isPublicIP(ip net.IP) bool {
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !sharedAddressSpace.Contains(ip)
+}
+
+// webhookAllowHTTP permits plain http webhook URLs; only https is allowed by default
+var webhookAllowHTTP atomic.Bool
+
+// SetWebhookAllowHTTP allows or forbids plain http URLs for webhook tests and scheduled reports
+func SetWebhookAllowHTTP(allow bool) {
+	webhookAllowHTTP.Store(allow)
+}
+
+// validateWebhookURL parses a webhook URL and checks that its scheme may be used
+func validateWebhookURL(rawURL string) (*url.URL, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil || target.Host == "" {
+		return nil, fmt.Errorf("invalid webhook url: %s", MaskWebhookURL(rawURL))
+	}
+	switch {
+	case target.Scheme == "https":
+	case target.Scheme == "http" && webhookAllowHTTP.Load():
+	case target.Scheme == "http":
+		return nil, fmt.Errorf("webhook url %s uses http; only https is allowed unless LOGS_WEBHOOK_ALLOW_HTTP is set", MaskWebhookURL(rawURL))
+	default:
+		return nil, fmt.Errorf("invalid webhook url: %s", MaskWebhookURL(rawURL))
+	}
+	return target, nil
+}
+
+// webhookTestMessage is the text sent in every test payload
+const webhookTestMessage = "Test notification from IBM Cloud Logs MCP server. No action required."
+
+// TestOutgoingWebhookTool sends a harmless test payload to a webhook target
+type TestOutgoingWebhookTool struct{ *BaseTool }
+
+// NewTestOutgoingWebhookTool creates a new tool instance
+func NewTestOutgoingWebhookTool(c client.Doer, l *zap.Logger) *TestOutgoingWebhookTool {
+	return &TestOutgoingWebhookTool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *TestOutgoingWebhookTool) Name() string { return "test_outgoing_webhook" }
+
+// Annotations returns tool hints for LLMs
+func (t *TestOutgoingWebhookTool) Annotations() *mcp.ToolAnnotations {
+	return &mcp.ToolAnnotations{
+		Title:           "Test Outgoing Webhook",
+		ReadOnlyHint:    false,
+		DestructiveHint: boolPtr(false), // Sends a test message only
+		IdempotentHint:  false,          // Each call delivers another message
+		OpenWorldHint:   boolPtr(true),  // Contacts an external endpoint
+	}
+}
+
+// DefaultTimeout returns the timeout (an optional lookup plus the test request)
+func (t *TestOutgoingWebhookTool) DefaultTimeout() time.Duration {
+	return DefaultGetTimeout + webhookTestTimeout
+}
+
+// Description returns the tool description
+func (t *TestOutgoingWebhookTool) Description() string {
+	return `Send a harmless test payload to a webhook target and report the HTTP status and latency.
+
+Provide either an existing webhook id or a webhook config (same shape as create_outgoing_webhook).
+Use this before wiring a webhook to alerts, so a bad URL doesn't silently swallow notifications.
+
+**Payloads by type:**
+- slack: {"text": "..."} message
+- pagerduty: a Change Event (informational, does not open an incident); requires routing_key
+- generic: small JSON body with "test": true
+
+Secrets in the URL are masked in the output. ibm_event_notifications targets cannot be tested directly.
+Only https URLs resolving to public addresses are contacted, and redirects are not followed.
+
+**Related tools:** create_outgoing_webhook, get_outgoing_webhook, create_alert`
+}
+
+// InputSchema returns the input schema
+func (t *TestOutgoingWebhookTool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"id": map[string]interface{}{
+				"type":        "string",
+				"description": "ID of an existing outgoing webhook to test",
+			},
+			"webhook": map[string]interface{}{
+				"type":        "object",
+				"description": "Webhook config to test before creating it (type and url required)",
+				"properties": map[string]interface{}{
+					"type": map[string]interface{}{
+						"type": "string",
+						"enum": []string{"generic", "slack", "pagerduty", "ibm_event_notifications"},
+					},
+					"url": map[string]interface{}{
+						"type": "string",
+					},
+				},
+			},
+			"routing_key": map[string]interface{}{
+				"type":        "string",
+				"description": "PagerDuty integration/routing key (defaults to the webhook's pager_duty.service_key)",
+			},
+		},
+	}
+}
+
+// Execute executes the tool
+func (t *TestOutgoingWebhookTool) Execute(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	id, _ := GetStringParam(args, "id", false)
+	wh, _ := GetObjectParam(args, "webhook", false)
+
+	switch {
+	case id != "" && wh != nil:
+		return NewToolResultError("provide either id or webhook, not both"), nil
+	case id != "":
+		res, err := t.ExecuteRequest(ctx, &client.Request{Method: "GET", Path: "/v1/outgoing_webhooks/" + id})
+		if err != nil {
+			return HandleGetError(err, "Outgoing webhook", id, "list_outgoing_webhooks"), nil
+		}
+		wh = res
+	case wh == nil:
+		return NewToolResultError("either id or webhook is required"), nil
+	}
+
+	whType, _ := wh["type"].(string)
+	if whType == eventNotificationsType {
+		return NewToolResultErrorWithSuggestion(
+			"ibm_event_notifications webhooks are delivered through IAM-authenticated IBM Cloud APIs and cannot be tested directly",
+			"Verify the Event Notifications instance and topic in the IBM Cloud console instead."), nil
+	}
+	rawURL, _ := wh["url"].(string)
+	if whType == "" || rawURL == "" {
+		return NewToolResultError("webhook type and url are required"), nil
+	}
+
+	target, err := validateWebhookURL(rawURL)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+
+	routingKey, _ := GetStringParam(args, "routing_key", false)
+	if routingKey == "" {
+		if pd, ok := wh["pager_duty"].(map[string]interface{}); ok {
+			routingKey, _ = pd["service_key"].(string)
+		}
+	}
+
+	endpoint, payload, err := webhookTestRequest(whType, target, routingKey)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+
+	status, latency, respBody, err := sendWebhookTest(ctx, endpoint, payload)
+
+	var sb strings.Builder
+	sb.WriteString("## Webhook Connectivity Test\n\n")
+	fmt.Fprintf(&sb, "**Type:** %s\n", whType)
+	fmt.Fprintf(&sb, "**Target:** %s\n", MaskWebhookURL(endpoint))
+	if err != nil {
+		fmt.Fprintf(&sb, "**Result:** ❌ Request failed after %s: %s\n", latency.Round(time.Millisecond), security.SanitizeError(err))
+		return NewToolResultErrorWithSuggestion(sb.String(),
+			"Check that the URL is reachable from this server, resolves to a public address, and that DNS and TLS are valid."), nil
+	}
+
+	fmt.Fprintf(&sb, "**HTTP Status:** %d\n", status)
+	fmt.Fprintf(&sb, "**Latency:** %s\n", latency.Round(time.Millisecond))
+	if status >= 200 && status < 300 {
+		sb.WriteString("**Result:** ✅ Target accepted the test payload\n")
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}}}, nil
+	}
+
+	sb.WriteString("**Result:** ❌ Target rejected the test payload\n")
+	if respBody != "" {
+		fmt.Fprintf(&sb, "\n**Response:**\n```\n%s\n```\n", security.MaskSensitiveData(respBody))
+	}
+	return NewToolResultErrorWithSuggestion(sb.String(),
+		"Check the webhook URL and credentials; alerts using this webhook would not be delivered."), nil
+}
+
+// webhookTestRequest returns the endpoint and minimal valid test payload for a webhook type
+func webhookTestRequest(whType string, target *url.URL, routingKey string) (string, map[string]interface{}, error) {
+	switch whType {
+	case "slack":
+		return target.String(), map[string]interface{}{"text": webhookTestMessage}, nil
+	case "pagerduty":
+		if routingKey == "" {
+			return "", nil, fmt.Errorf("pagerduty tests require routing_key (or a webhook with pager_duty.service_key)")
+		}
+		// Change events are informational and never open an incident
+		changeURL := url.URL{Scheme: target.Scheme, Host: target.Host, Path: "/v2/change/enqueue"}
+		return changeURL.String(), map[string]interface{}{
+			"routing_key": routingKey,
+			"payload": map[string]interface{}{
+				"summary": webhookTestMessage,
+				"source":  "ibm-cloud-logs-mcp",
+			},
+		}, nil
+	case "generic":
+		return target.String(), map[string]interface{}{
+			"test":      true,
+			"source":    "ibm-cloud-logs-mcp",
+			"message":   webhookTestMessage,
+			"timestamp": time.Now().UTC().Format(time.RFC3339),
+		}, nil
+	default:
+		return "", nil, fmt.Errorf("unsupported webhook type '%s' (valid: generic, slack, pagerduty)", whType)
+	}
+}
+
+// sendWebhookTest posts the payload and returns the status code, latency, and a truncated response body
+func sendWebhookTest(ctx context.Context, endpoint string, payload map[string]interface{}) (int, time.Duration, string, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, 0, "", err
+	}
+	ctx, cancel := context.WithTimeout(ctx, webhookTestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	start := time.Now()
+	resp, err := webhookHTTPClient.Do(req)
+	latency := time.Since(start)
+	if err != nil {
+		return 0, latency, "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return resp.StatusCode, latency, strings.TrimSpace(string(respBody)), nil
+}
+
+// MaskWebhookURL hides credentials in a webhook URL. Userinfo is dropped, secret query
+// parameters are masked, and path segments after the first are replaced, since services
+// like Slack embed the token in the path.
+func MaskWebhookURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return security.MaskURL(rawURL)
+	}
+	u.User = nil
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) > 1 {
+		for i := 1; i < len(segments); i++ {
+			segments[i] = "***"
+		}
+		u.Path = "/" + strings.Join(segments, "/")
+		u.RawPath = u.Path // keep the mask unescaped
+	}
+	return security.MaskURL(u.String())
+}
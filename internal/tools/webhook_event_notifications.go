@@ -0,0 +1,113 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements the type-specific fields and validation for ibm_event_notifications webhooks.
+package tools
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/config"
+)
+
+// eventNotificationsType is the webhook type delivered through IBM Cloud Event Notifications
+const eventNotificationsType = "ibm_event_notifications"
+
+// eventNotificationsInstanceID matches an Event Notifications instance GUID
+var eventNotificationsInstanceID = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// eventNotificationsSchema describes the ibm_event_notifications object of a webhook
+var eventNotificationsSchema = map[string]interface{}{
+	"type":        "object",
+	"description": "Event Notifications target; required when type is ibm_event_notifications, where a url alone is not enough",
+	"properties": map[string]interface{}{
+		"event_notifications_instance_id": map[string]interface{}{
+			"type":        "string",
+			"description": "Event Notifications instance GUID, or its CRN (the GUID and region are taken from the CRN)",
+		},
+		"region_id": map[string]interface{}{
+			"type":        "string",
+			"description": "Region of the Event Notifications instance, e.g. us-south",
+		},
+		"source_id": map[string]interface{}{
+			"type":        "string",
+			"description": "Event Notifications source ID for this Cloud Logs instance (created on first use if omitted)",
+		},
+		"source_name": map[string]interface{}{
+			"type":        "string",
+			"description": "Display name of the Event Notifications source",
+		},
+		"endpoint_type": map[string]interface{}{
+			"type":        "string",
+			"description": "Endpoint used to reach Event Notifications",
+			"enum":        []string{"default", "private"},
+		},
+	},
+	"required": []string{"event_notifications_instance_id", "region_id"},
+}
+
+// validateWebhookTypeFields checks the fields each webhook type requires. For
+// ibm_event_notifications it also resolves an instance CRN into the GUID and region the
+// API expects, updating wh in place.
+func validateWebhookTypeFields(wh map[string]interface{}) []string {
+	whType, _ := wh["type"].(string)
+	if whType != eventNotificationsType {
+		if urlStr, ok := wh["url"].(string); !ok || urlStr == "" {
+			return []string{"url is required"}
+		}
+		return nil
+	}
+
+	en, ok := wh[eventNotificationsType].(map[string]interface{})
+	if !ok {
+		if _, hasURL := wh["url"]; hasURL {
+			return []string{"a url alone cannot configure an ibm_event_notifications webhook; set the ibm_event_notifications object (event_notifications_instance_id and region_id)"}
+		}
+		return []string{"ibm_event_notifications object is required (event_notifications_instance_id and region_id)"}
+	}
+
+	var errs []string
+
+	instanceID, _ := en["event_notifications_instance_id"].(string)
+	region, _ := en["region_id"].(string)
+	if strings.HasPrefix(instanceID, "crn:") {
+		crnID, crnRegion, err := parseEventNotificationsCRN(instanceID)
+		if err != nil {
+			return append(errs, err.Error())
+		}
+		if region != "" && region != crnRegion {
+			errs = append(errs, fmt.Sprintf("region_id %q does not match the instance CRN region %q", region, crnRegion))
+		}
+		instanceID, region = crnID, crnRegion
+		en["event_notifications_instance_id"] = instanceID
+		en["region_id"] = region
+	}
+
+	switch {
+	case instanceID == "":
+		errs = append(errs, "ibm_event_notifications.event_notifications_instance_id is required")
+	case !eventNotificationsInstanceID.MatchString(instanceID):
+		errs = append(errs, fmt.Sprintf("ibm_event_notifications.event_notifications_instance_id %q is not an instance GUID or CRN", instanceID))
+	}
+	switch {
+	case region == "":
+		errs = append(errs, "ibm_event_notifications.region_id is required")
+	case !config.IsValidRegion(region):
+		errs = append(errs, fmt.Sprintf("ibm_event_notifications.region_id %q is not a known region (valid: %s)",
+			region, strings.Join(config.ValidRegions, ", ")))
+	}
+	if endpoint, ok := en["endpoint_type"].(string); ok && endpoint != "default" && endpoint != "private" {
+		errs = append(errs, fmt.Sprintf("ibm_event_notifications.endpoint_type %q must be default or private", endpoint))
+	}
+	return errs
+}
+
+// parseEventNotificationsCRN returns the instance GUID and region of an Event
+// Notifications CRN: crn:v1:<cname>:<ctype>:event-notifications:<region>:a/<account>:<guid>::
+func parseEventNotificationsCRN(crn string) (string, string, error) {
+	parts := strings.Split(crn, ":")
+	if len(parts) < 8 || parts[4] != "event-notifications" || parts[5] == "" || parts[7] == "" {
+		return "", "", fmt.Errorf("ibm_event_notifications.event_notifications_instance_id %q is not an Event Notifications instance CRN", crn)
+	}
+	return parts[7], parts[5], nil
+}
@@ -0,0 +1,84 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func eventNotificationsWebhook(en map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"name": "EN", "type": "ibm_event_notifications", "ibm_event_notifications": en}
+}
+
+func TestValidateWebhookTypeFields(t *testing.T) {
+	const guid = "6964e1e9-74a2-4c6c-980b-d806ff75175d"
+	tests := []struct {
+		name    string
+		webhook map[string]interface{}
+		wantErr string
+	}{
+		{"slack with url", map[string]interface{}{"type": "slack", "url": "https://hooks.slack.com/x"}, ""},
+		{"generic without url", map[string]interface{}{"type": "generic"}, "url is required"},
+		{"event notifications", eventNotificationsWebhook(map[string]interface{}{"event_notifications_instance_id": guid, "region_id": "eu-de"}), ""},
+		{"event notifications bare url", map[string]interface{}{"type": "ibm_event_notifications", "url": "https://example.com"}, "a url alone cannot configure"},
+		{"missing region", eventNotificationsWebhook(map[string]interface{}{"event_notifications_instance_id": guid}), "region_id is required"},
+		{"unknown region", eventNotificationsWebhook(map[string]interface{}{"event_notifications_instance_id": guid, "region_id": "mars-1"}), "not a known region"},
+		{"bad instance id", eventNotificationsWebhook(map[string]interface{}{"event_notifications_instance_id": "my-instance", "region_id": "us-south"}), "not an instance GUID or CRN"},
+		{"bad endpoint type", eventNotificationsWebhook(map[string]interface{}{"event_notifications_instance_id": guid, "region_id": "us-south", "endpoint_type": "public"}), "endpoint_type"},
+		{"crn region mismatch", eventNotificationsWebhook(map[string]interface{}{
+			"event_notifications_instance_id": "crn:v1:bluemix:public:event-notifications:us-south:a/abc123:" + guid + "::",
+			"region_id":                       "eu-de",
+		}), "does not match the instance CRN region"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateWebhookTypeFields(tt.webhook)
+			if tt.wantErr == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			require.NotEmpty(t, errs)
+			assert.Contains(t, errs[0], tt.wantErr)
+		})
+	}
+}
+
+func TestCreateOutgoingWebhookTool_EventNotificationsCRN(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.RespondWith(200, map[string]interface{}{"id": "wh-1"})
+	tool := NewCreateOutgoingWebhookTool(mock, zap.NewNop())
+
+	wh := eventNotificationsWebhook(map[string]interface{}{
+		"event_notifications_instance_id": "crn:v1:bluemix:public:event-notifications:jp-tok:a/abc123:6964e1e9-74a2-4c6c-980b-d806ff75175d::",
+	})
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{"webhook": wh})
+	require.NoError(t, err)
+	require.False(t, result.IsError, "%+v", result.Content)
+
+	body := mock.LastRequest().Body.(map[string]interface{})
+	en := body["ibm_event_notifications"].(map[string]interface{})
+	assert.Equal(t, "6964e1e9-74a2-4c6c-980b-d806ff75175d", en["event_notifications_instance_id"])
+	assert.Equal(t, "jp-tok", en["region_id"])
+}
+
+func TestCreateOutgoingWebhookTool_RejectsBareURLForEventNotifications(t *testing.T) {
+	mock := client.NewMockClient()
+	tool := NewCreateOutgoingWebhookTool(mock, zap.NewNop())
+	wh := map[string]interface{}{"name": "EN", "type": "ibm_event_notifications", "url": "https://example.com/hook"}
+
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{"webhook": wh})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "a url alone cannot configure")
+	assert.Zero(t, mock.RequestCount())
+
+	result, err = tool.Execute(testCtx(mock), map[string]interface{}{"webhook": wh, "dry_run": true})
+	require.NoError(t, err)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "a url alone cannot configure")
+	assert.Zero(t, mock.RequestCount())
+}
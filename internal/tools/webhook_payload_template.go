@@ -0,0 +1,101 @@
+// Package tools provides MCP tool implementations for IBM Cloud Logs.
+// This file implements payload_template, the custom message format for slack and generic webhooks.
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// webhookPlaceholders are the alert fields a payload template can reference
+var webhookPlaceholders = map[string]string{
+	"$ALERT_NAME":              "Alert name",
+	"$ALERT_DESCRIPTION":       "Alert description",
+	"$ALERT_ID":                "Alert ID",
+	"$ALERT_UNIQUE_IDENTIFIER": "Unique ID of this alert occurrence",
+	"$ALERT_ACTION":            "trigger or resolve",
+	"$ALERT_SEVERITY":          "Alert priority",
+	"$ALERT_URL":               "Link to the alert in the UI",
+	"$ALERT_GROUP_BY_VALUES":   "Group-by values of the triggering group",
+	"$EVENT_TIMESTAMP":         "Time the alert triggered",
+	"$HIT_COUNT":               "Number of matching logs",
+	"$LOG_URL":                 "Link to the matching logs",
+	"$APPLICATION_NAME":        "Application of the matching logs",
+	"$SUBSYSTEM_NAME":          "Subsystem of the matching logs",
+	"$COMPUTER_NAME":           "Host of the matching logs",
+	"$IP_ADDRESS":              "IP address of the matching logs",
+	"$META_LABELS":             "Alert labels",
+}
+
+// webhookPlaceholderPattern matches $UPPER_CASE placeholders
+var webhookPlaceholderPattern = regexp.MustCompile(`\$[A-Z][A-Z0-9_]*`)
+
+// payloadTemplateSchema describes the payload_template argument of the webhook create/update tools
+var payloadTemplateSchema = map[string]interface{}{
+	"type": "string",
+	"description": "Custom notification body for slack and generic webhooks, with alert placeholders such as " +
+		"$ALERT_NAME, $ALERT_SEVERITY, $APPLICATION_NAME and $LOG_URL. For generic webhooks it must be JSON once " +
+		"placeholders are filled in. For slack webhooks it is the message text. Omit to keep the default format.",
+}
+
+// placeholderNames returns the supported placeholders, sorted
+func placeholderNames() []string {
+	names := make([]string, 0, len(webhookPlaceholders))
+	for name := range webhookPlaceholders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyPayloadTemplate validates template and stores it in the webhook's custom payload,
+// generic_webhook.payload. The native slack integration has a fixed message format, so a
+// slack webhook with a template becomes a generic webhook posting {"text": template} to
+// its incoming-webhook URL.
+func applyPayloadTemplate(wh map[string]interface{}, template string) error {
+	whType, _ := wh["type"].(string)
+	if whType != "slack" && whType != "generic" {
+		return fmt.Errorf("payload_template is supported for slack and generic webhooks, not %q", whType)
+	}
+	if strings.TrimSpace(template) == "" {
+		return fmt.Errorf("payload_template cannot be empty")
+	}
+
+	var unknown []string
+	for _, p := range webhookPlaceholderPattern.FindAllString(template, -1) {
+		if _, ok := webhookPlaceholders[p]; !ok {
+			unknown = append(unknown, p)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown placeholder(s) in payload_template: %s (supported: %s)",
+			strings.Join(unknown, ", "), strings.Join(placeholderNames(), ", "))
+	}
+
+	payload := template
+	if whType == "slack" {
+		data, err := json.Marshal(map[string]string{"text": template})
+		if err != nil {
+			return err
+		}
+		payload = string(data)
+		wh["type"] = "generic"
+		delete(wh, "slack")
+	} else if sample := webhookPlaceholderPattern.ReplaceAllString(template, "0"); !json.Valid([]byte(sample)) {
+		return fmt.Errorf("payload_template for a generic webhook must be valid JSON once placeholders are filled in")
+	}
+
+	generic, _ := wh["generic_webhook"].(map[string]interface{})
+	if generic == nil {
+		generic = map[string]interface{}{
+			"method":  "post",
+			"headers": map[string]interface{}{"Content-Type": "application/json"},
+		}
+	}
+	generic["payload"] = payload
+	wh["generic_webhook"] = generic
+	return nil
+}
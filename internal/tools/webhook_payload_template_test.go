@@ -0,0 +1,91 @@
+package tools
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestApplyPayloadTemplate_Generic(t *testing.T) {
+	wh := map[string]interface{}{
+		"type":            "generic",
+		"url":             "https://example.com/hook",
+		"generic_webhook": map[string]interface{}{"method": "put"},
+	}
+	template := `{"alert": "$ALERT_NAME", "hits": $HIT_COUNT}`
+	require.NoError(t, applyPayloadTemplate(wh, template))
+
+	generic := wh["generic_webhook"].(map[string]interface{})
+	assert.Equal(t, template, generic["payload"])
+	assert.Equal(t, "put", generic["method"], "existing generic_webhook settings are kept")
+}
+
+func TestApplyPayloadTemplate_SlackBecomesGeneric(t *testing.T) {
+	wh := map[string]interface{}{
+		"type":  "slack",
+		"url":   "https://hooks.slack.com/services/XXX",
+		"slack": map[string]interface{}{"digests": []interface{}{}},
+	}
+	require.NoError(t, applyPayloadTemplate(wh, "*$ALERT_NAME* fired: \"$LOG_URL\""))
+
+	assert.Equal(t, "generic", wh["type"])
+	assert.NotContains(t, wh, "slack")
+	generic := wh["generic_webhook"].(map[string]interface{})
+	assert.Equal(t, "post", generic["method"])
+	var payload map[string]string
+	require.NoError(t, json.Unmarshal([]byte(generic["payload"].(string)), &payload))
+	assert.Equal(t, "*$ALERT_NAME* fired: \"$LOG_URL\"", payload["text"])
+}
+
+func TestApplyPayloadTemplate_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		webhook  map[string]interface{}
+		template string
+		wantErr  string
+	}{
+		{"unknown placeholder", map[string]interface{}{"type": "slack"}, "$ALERT_NAME on $HOSTNAME", "unknown placeholder(s) in payload_template: $HOSTNAME"},
+		{"pagerduty", map[string]interface{}{"type": "pagerduty"}, "$ALERT_NAME", "supported for slack and generic"},
+		{"generic not json", map[string]interface{}{"type": "generic"}, "alert $ALERT_NAME", "valid JSON"},
+		{"empty", map[string]interface{}{"type": "generic"}, "  ", "cannot be empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := applyPayloadTemplate(tt.webhook, tt.template)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCreateOutgoingWebhookTool_PayloadTemplate(t *testing.T) {
+	mock := client.NewMockClient()
+	mock.RespondWith(200, map[string]interface{}{"id": "wh-1"})
+	tool := NewCreateOutgoingWebhookTool(mock, zap.NewNop())
+
+	result, err := tool.Execute(testCtx(mock), map[string]interface{}{
+		"webhook":          map[string]interface{}{"name": "Slack", "type": "slack", "url": "https://hooks.slack.com/services/XXX"},
+		"payload_template": "$ALERT_NAME: $HIT_COUNT hits",
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError, "%+v", result.Content)
+	body := mock.LastRequest().Body.(map[string]interface{})
+	assert.Equal(t, "generic", body["type"])
+	assert.Equal(t, `{"text":"$ALERT_NAME: $HIT_COUNT hits"}`, body["generic_webhook"].(map[string]interface{})["payload"])
+
+	mock.Reset()
+	result, err = tool.Execute(testCtx(mock), map[string]interface{}{
+		"webhook":          map[string]interface{}{"name": "Slack", "type": "slack", "url": "https://hooks.slack.com/services/XXX"},
+		"payload_template": "$NOPE",
+	})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "$NOPE")
+	assert.Zero(t, mock.RequestCount())
+}
@@ -0,0 +1,162 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+// ServerInfo describes the server build and enabled feature flags reported by whoami.
+// It is populated at startup, so reporting it needs no API call.
+type ServerInfo struct {
+	Version  string
+	Commit   string
+	BuiltBy  string
+	Features map[string]bool
+}
+
+var (
+	serverInfoMu sync.RWMutex
+	serverInfo   = ServerInfo{Version: "dev", Commit: "unknown", BuiltBy: "manual"}
+)
+
+// SetServerInfo records the server version and feature flags reported by whoami.
+// Build fields left empty keep the values set by SetBuildInfo.
+func SetServerInfo(info ServerInfo) {
+	serverInfoMu.Lock()
+	defer serverInfoMu.Unlock()
+	if info.Version == "" {
+		info.Version = serverInfo.Version
+	}
+	if info.Commit == "" {
+		info.Commit = serverInfo.Commit
+	}
+	if info.BuiltBy == "" {
+		info.BuiltBy = serverInfo.BuiltBy
+	}
+	serverInfo = info
+}
+
+// SetBuildInfo records the build version, commit, and builder reported by whoami
+func SetBuildInfo(version, commit, builtBy string) {
+	serverInfoMu.Lock()
+	defer serverInfoMu.Unlock()
+	serverInfo.Version = version
+	serverInfo.Commit = commit
+	serverInfo.BuiltBy = builtBy
+}
+
+// GetServerInfo returns a copy of the current server info
+func GetServerInfo() ServerInfo {
+	serverInfoMu.RLock()
+	defer serverInfoMu.RUnlock()
+	info := serverInfo
+	info.Features = make(map[string]bool, len(serverInfo.Features))
+	for k, v := range serverInfo.Features {
+		info.Features[k] = v
+	}
+	return info
+}
+
+// WhoAmITool reports which instance and server build the client is talking to
+type WhoAmITool struct{ *BaseTool }
+
+// NewWhoAmITool creates a new tool instance
+func NewWhoAmITool(c client.Doer, l *zap.Logger) *WhoAmITool {
+	return &WhoAmITool{NewBaseTool(c, l)}
+}
+
+// Name returns the tool name
+func (t *WhoAmITool) Name() string { return "whoami" }
+
+// Annotations returns tool hints for LLMs
+func (t *WhoAmITool) Annotations() *mcp.ToolAnnotations {
+	return ReadOnlyAnnotations("Instance Info")
+}
+
+// Description returns the tool description
+func (t *WhoAmITool) Description() string {
+	return `Show which IBM Cloud Logs instance this server is connected to.
+
+Reports the service URL, region, instance name and ID, server version and commit,
+and the enabled feature flags (caching, rate limiting, query auto-correction, ...).
+No API call is made; the information comes from the server configuration.
+
+**When to use:**
+- Before destructive operations, to confirm you are pointed at the right environment
+- When several instances are configured and it's unclear which one is active
+
+**Related tools:** session_context, health_check`
+}
+
+// InputSchema returns the input schema
+func (t *WhoAmITool) InputSchema() interface{} {
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{},
+	}
+}
+
+// Execute executes the tool
+func (t *WhoAmITool) Execute(ctx context.Context, _ map[string]interface{}) (*mcp.CallToolResult, error) {
+	apiClient, err := t.GetClient(ctx)
+	if err != nil {
+		return NewToolResultErrorFromErr(err), nil
+	}
+	info := GetServerInfo()
+
+	// Runtime toggles are read live so the report reflects the current state
+	info.Features["caching"] = GetCacheHelperFromContext(ctx).IsEnabled()
+	info.Features["auto_correct_queries"] = autoCorrectQueries.Load()
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: formatWhoAmI(apiClient.GetInstanceInfo(), info)}},
+	}, nil
+}
+
+// formatWhoAmI renders instance and server info as markdown
+func formatWhoAmI(instance client.InstanceInfo, info ServerInfo) string {
+	orNotSet := func(s string) string {
+		if s == "" {
+			return "(not set)"
+		}
+		return s
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Instance Info\n\n")
+	sb.WriteString("| Property | Value |\n|----------|-------|\n")
+	fmt.Fprintf(&sb, "| Instance Name | %s |\n", orNotSet(instance.InstanceName))
+	fmt.Fprintf(&sb, "| Instance ID | %s |\n", orNotSet(instance.InstanceID))
+	fmt.Fprintf(&sb, "| Region | %s |\n", orNotSet(instance.Region))
+	fmt.Fprintf(&sb, "| Service URL | %s |\n", orNotSet(instance.ServiceURL))
+	fmt.Fprintf(&sb, "| Server Version | %s |\n", orNotSet(info.Version))
+	fmt.Fprintf(&sb, "| Commit | %s |\n", orNotSet(info.Commit))
+	fmt.Fprintf(&sb, "| Built By | %s |\n", orNotSet(info.BuiltBy))
+
+	if len(info.Features) > 0 {
+		names := make([]string, 0, len(info.Features))
+		for name := range info.Features {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		sb.WriteString("\n### Features\n\n")
+		for _, name := range names {
+			status := "❌ disabled"
+			if info.Features[name] {
+				status = "✅ enabled"
+			}
+			fmt.Fprintf(&sb, "- `%s`: %s\n", name, status)
+		}
+	}
+
+	return sb.String()
+}
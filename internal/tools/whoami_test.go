@@ -0,0 +1,60 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/tareqmamari/cloud-logs-mcp/internal/client"
+)
+
+func TestWhoAmITool_ReportsInstanceInfo(t *testing.T) {
+	previous := GetServerInfo()
+	t.Cleanup(func() { SetServerInfo(previous) })
+
+	SetBuildInfo("v1.2.3", "abc1234", "goreleaser")
+	SetServerInfo(ServerInfo{Features: map[string]bool{"rate_limiting": true, "tracing": false}})
+
+	mock := client.NewMockClient()
+	mock.Instance = client.InstanceInfo{
+		ServiceURL:   "https://inst-1.api.eu-de.logs.cloud.ibm.com",
+		Region:       "eu-de",
+		InstanceName: "prod-logs",
+		InstanceID:   "inst-1",
+	}
+	tool := NewWhoAmITool(mock, zap.NewNop())
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	text := result.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, "| Instance Name | prod-logs |")
+	assert.Contains(t, text, "| Instance ID | inst-1 |")
+	assert.Contains(t, text, "| Region | eu-de |")
+	assert.Contains(t, text, "| Service URL | https://inst-1.api.eu-de.logs.cloud.ibm.com |")
+	assert.Contains(t, text, "| Server Version | v1.2.3 |")
+	assert.Contains(t, text, "| Commit | abc1234 |")
+	assert.Contains(t, text, "- `rate_limiting`: ✅ enabled")
+	assert.Contains(t, text, "- `tracing`: ❌ disabled")
+	assert.Contains(t, text, "`auto_correct_queries`")
+	assert.Contains(t, text, "`caching`")
+	assert.Equal(t, 0, mock.RequestCount(), "whoami must not call the API")
+}
+
+func TestSetServerInfo_KeepsBuildInfo(t *testing.T) {
+	previous := GetServerInfo()
+	t.Cleanup(func() { SetServerInfo(previous) })
+
+	SetBuildInfo("v2.0.0", "deadbeef", "manual")
+	SetServerInfo(ServerInfo{Features: map[string]bool{"tracing": true}})
+
+	info := GetServerInfo()
+	assert.Equal(t, "v2.0.0", info.Version)
+	assert.Equal(t, "deadbeef", info.Commit)
+	assert.True(t, info.Features["tracing"])
+	assert.Contains(t, formatWhoAmI(client.InstanceInfo{}, info), "| Instance Name | (not set) |")
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -64,14 +65,17 @@ func (t *InvestigateIncidentTool) Description() string {
 1. Queries recent error logs for the specified application/time range
 2. Analyzes error patterns and trends
 3. Identifies top error sources and frequencies
-4. Provides root cause hypotheses
-5. Suggests remediation actions
+4. Lists alerts that fired in the window and correlates them with the error patterns
+5. Provides root cause hypotheses
+6. Suggests remediation actions
 
 **Input options:**
 - application: Focus on specific application (recommended)
 - time_range: How far back to look (default: 1h)
 - severity: Minimum severity to investigate (default: error)
 - keyword: Additional search term to filter results
+- correlate_alerts: Include alerts that fired during the window (default: true)
+- group_by_trace: Count errors sharing a trace_id as one failing request (default: false)
 
 **Related tools:** query_logs, list_alerts, get_query_templates, create_alert`
 }
@@ -103,6 +107,16 @@ func (t *InvestigateIncidentTool) InputSchema() interface{} {
 				"description": "Additional keyword to search for in logs",
 				"examples":    []string{"timeout", "connection refused", "500", "OOM"},
 			},
+			"correlate_alerts": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Fetch alerts that fired during the time range and correlate them with the error patterns found (default: true)",
+				"default":     true,
+			},
+			"group_by_trace": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Group errors by trace_id so one failing request that logs many lines counts once. Reports unique traces alongside event counts and bases hypotheses on traces (default: false)",
+				"default":     false,
+			},
 		},
 		"examples": []interface{}{
 			map[string]interface{}{
@@ -249,8 +263,26 @@ func (t *InvestigateIncidentTool) Execute(ctx context.Context, args map[string]i
 		return t.formatInvestigationError(err, query, application)
 	}
 
+	// Cross-reference alerts that fired in the same window; alert lookup failures
+	// degrade to a note rather than failing the investigation
+	alerts := &incidentAlerts{enabled: true}
+	if _, set := args["correlate_alerts"]; set {
+		alerts.enabled, _ = GetBoolParam(args, "correlate_alerts", false)
+	}
+	if alerts.enabled {
+		alerts.fired, alerts.err = t.fetchFiredAlerts(ctx, startDate, endDate)
+	}
+
 	// Analyze the results
-	return t.formatInvestigationResults(ctx, result, query, application, timeRange, severity)
+	groupByTrace, _ := GetBoolParam(args, "group_by_trace", false)
+	return t.formatInvestigationResults(ctx, result, query, application, timeRange, severity, alerts, groupByTrace)
+}
+
+// incidentAlerts holds the alerts that fired during an investigation window
+type incidentAlerts struct {
+	enabled bool
+	fired   []FiredAlert
+	err     error
 }
 
 // formatInvestigationError formats an error response with helpful suggestions
@@ -272,17 +304,29 @@ func (t *InvestigateIncidentTool) formatInvestigationError(err error, query, _ s
 }
 
 // formatInvestigationResults formats the investigation findings
-func (t *InvestigateIncidentTool) formatInvestigationResults(ctx context.Context, result map[string]interface{}, query, application, timeRange, severity string) (*mcp.CallToolResult, error) {
+func (t *InvestigateIncidentTool) formatInvestigationResults(ctx context.Context, result map[string]interface{}, query, application, timeRange, severity string, alerts *incidentAlerts, groupByTrace bool) (*mcp.CallToolResult, error) {
 	var response strings.Builder
 
 	response.WriteString("# 🔍 Incident Investigation Report\n\n")
 	t.writeParameters(&response, application, timeRange, severity)
 
 	events, ok := result["events"].([]interface{})
+	var correlations []AlertCorrelation
+	if alerts != nil && alerts.err == nil {
+		correlations = CorrelateFiredAlerts(alerts.fired, events)
+	}
 	if !ok || len(events) == 0 {
 		t.writeNoIssuesFound(&response)
 	} else {
-		t.writeFindings(ctx, &response, result, events, timeRange)
+		t.writeFindings(ctx, &response, result, events, timeRange, correlations, groupByTrace)
+	}
+
+	if alerts != nil && alerts.enabled {
+		if alerts.err != nil {
+			fmt.Fprintf(&response, "\n_Alert history unavailable: %s_\n", alerts.err.Error())
+		} else {
+			writeFiredAlerts(&response, correlations, currentTimeDisplay())
+		}
 	}
 
 	fmt.Fprintf(&response, "\n---\n### Query Used\n```dataprime\n%s\n```\n", query)
@@ -324,16 +368,30 @@ func (t *InvestigateIncidentTool) writeNoIssuesFound(response *strings.Builder)
 	response.WriteString("- Check `list_alerts` for any triggered alerts\n")
 }
 
-func (t *InvestigateIncidentTool) writeFindings(ctx context.Context, response *strings.Builder, result map[string]interface{}, events []interface{}, timeRange string) {
+func (t *InvestigateIncidentTool) writeFindings(ctx context.Context, response *strings.Builder, result map[string]interface{}, events []interface{}, timeRange string, correlations []AlertCorrelation, groupByTrace bool) {
 	session := GetSessionFromContext(ctx)
-	analysis := AnalyzeQueryResults(result)
+	analysis := AnalyzeQueryResultsWithOptions(result, AnalysisOptions{GroupByTrace: groupByTrace})
 
 	response.WriteString("## 📊 Findings Summary\n\n")
-	fmt.Fprintf(response, "Found **%d error logs** in the specified time range.\n\n", len(events))
+	if traces := analysis.Traces; traces != nil && traces.UniqueTraces > 0 {
+		fmt.Fprintf(response, "Found **%d error logs** from **%d unique traces**", len(events), traces.UniqueTraces)
+		if traces.UntracedEvents > 0 {
+			fmt.Fprintf(response, " (plus %d logs without a trace ID)", traces.UntracedEvents)
+		}
+		response.WriteString(" in the specified time range.\n\n")
+		// Sample errors and hypotheses weigh each failing request once
+		events = DedupeByTrace(events)
+	} else {
+		fmt.Fprintf(response, "Found **%d error logs** in the specified time range.\n\n", len(events))
+		if groupByTrace {
+			response.WriteString("_No trace IDs found in these logs; errors are counted individually._\n\n")
+		}
+	}
 
 	t.writeAnalysis(response, analysis)
+	t.writeTraces(response, analysis.Traces)
 	t.writeSampleErrors(response, events)
-	t.writeHypotheses(response, events, session)
+	t.writeHypotheses(response, events, correlations, session)
 	t.recordFindings(session, events, analysis, timeRange)
 	t.writeRecommendedActions(response)
 }
@@ -382,6 +440,42 @@ func (t *InvestigateIncidentTool) writeAnalysis(response *strings.Builder, analy
 	}
 }
 
+func (t *InvestigateIncidentTool) writeTraces(response *strings.Builder, traces *TraceSummary) {
+	if traces == nil || traces.UniqueTraces == 0 {
+		return
+	}
+	response.WriteString("\n### Traces Affected\n")
+	apps := make([]string, 0, len(traces.ByApplication))
+	for app := range traces.ByApplication {
+		apps = append(apps, app)
+	}
+	sort.Slice(apps, func(i, j int) bool {
+		if traces.ByApplication[apps[i]] != traces.ByApplication[apps[j]] {
+			return traces.ByApplication[apps[i]] > traces.ByApplication[apps[j]]
+		}
+		return apps[i] < apps[j]
+	})
+	for i, app := range apps {
+		if i >= 5 {
+			break
+		}
+		fmt.Fprintf(response, "- %s: %d traces\n", app, traces.ByApplication[app])
+	}
+
+	response.WriteString("\n### Noisiest Traces\n")
+	for _, g := range traces.TopTraces {
+		fmt.Fprintf(response, "- `%s`: %d logs", g.TraceID, g.Events)
+		if len(g.Applications) > 0 {
+			fmt.Fprintf(response, " across %s", strings.Join(g.Applications, ", "))
+		}
+		if g.Message != "" {
+			fmt.Fprintf(response, " — `%s`", g.Message)
+		}
+		response.WriteString("\n")
+	}
+	response.WriteString("\nUse `trace_logs` with a trace ID to follow one request across services.\n")
+}
+
 func (t *InvestigateIncidentTool) writeSampleErrors(response *strings.Builder, events []interface{}) {
 	response.WriteString("\n### Sample Error Messages\n")
 	shown := 0
@@ -399,9 +493,9 @@ func (t *InvestigateIncidentTool) writeSampleErrors(response *strings.Builder, e
 	}
 }
 
-func (t *InvestigateIncidentTool) writeHypotheses(response *strings.Builder, events []interface{}, session *SessionContext) {
+func (t *InvestigateIncidentTool) writeHypotheses(response *strings.Builder, events []interface{}, correlations []AlertCorrelation, session *SessionContext) {
 	response.WriteString("\n## 🎯 Root Cause Hypotheses\n")
-	hypotheses := generateHypotheses(events)
+	hypotheses := append(firedAlertHypotheses(correlations), generateHypotheses(events)...)
 	for i, h := range hypotheses {
 		fmt.Fprintf(response, "%d. %s\n", i+1, h)
 		session.AddFinding(t.Name(), h, "info", "hypothesis")
@@ -549,6 +643,8 @@ func (t *HealthCheckTool) DefaultTimeout() time.Duration {
 // Description returns the tool description
 func (t *HealthCheckTool) Description() string {
 	return `Quick system health check that summarizes recent activity, error rates, and potential issues.
+Set deep=true to run bounded count queries (concurrently), compare against the previous period, and get per-application checks.
+If the previous-period queries fail, the report omits the comparison and lists them under partial_failures.
 
 **Best for:** Morning health checks, shift handoffs, quick status overview.
 
@@ -572,6 +668,26 @@ func (t *HealthCheckTool) InputSchema() interface{} {
 				"enum":        []string{"15m", "1h", "6h"},
 				"default":     "1h",
 			},
+			"deep": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Run bounded queries for total, error and critical counts, compare against the previous period, and return per-application checks (default: false for a quick check)",
+				"default":     false,
+			},
+			"warning_error_rate": map[string]interface{}{
+				"type":        "number",
+				"description": "Deep mode: error rate percentage at or above which status is warning (default: 5)",
+				"default":     5,
+			},
+			"critical_error_rate": map[string]interface{}{
+				"type":        "number",
+				"description": "Deep mode: error rate percentage at or above which status is critical (default: 10)",
+				"default":     10,
+			},
+			"error_rate_increase": map[string]interface{}{
+				"type":        "number",
+				"description": "Deep mode: relative error rate increase (percent) versus the previous period that raises a warning (default: 100, i.e. doubled)",
+				"default":     100,
+			},
 		},
 	}
 }
@@ -608,14 +724,19 @@ func (t *HealthCheckTool) Execute(ctx context.Context, args map[string]interface
 
 	// Calculate time range
 	endDate := time.Now().UTC()
-	var startDate time.Time
+	var window time.Duration
 	switch timeRange {
 	case "15m":
-		startDate = endDate.Add(-15 * time.Minute)
+		window = 15 * time.Minute
 	case "6h":
-		startDate = endDate.Add(-6 * time.Hour)
+		window = 6 * time.Hour
 	default:
-		startDate = endDate.Add(-1 * time.Hour)
+		window = 1 * time.Hour
+	}
+	startDate := endDate.Add(-window)
+
+	if deep, _ := GetBoolParam(args, "deep", false); deep {
+		return t.executeDeep(ctx, args, timeRange, window, endDate)
 	}
 
 	var response strings.Builder
@@ -769,6 +890,301 @@ func (t *HealthCheckTool) Execute(ctx context.Context, args map[string]interface
 	}, nil
 }
 
+// HealthThresholds configures how deep health checks classify error rates
+type HealthThresholds struct {
+	WarningErrorRate  float64 // Error rate (%) at or above which status is warning
+	CriticalErrorRate float64 // Error rate (%) at or above which status is critical
+	ErrorRateIncrease float64 // Relative increase (%) versus the previous period that raises a warning
+}
+
+// healthWindowCounts holds the instance-wide counts and the per-application counts for one time window
+type healthWindowCounts struct {
+	Total     float64
+	Errors    float64
+	Critical  float64
+	AppTotal  map[string]float64 // Busiest applications
+	AppErrors map[string]float64 // Applications with the most errors
+}
+
+// healthAppLimit caps the applications returned by the per-application queries
+const healthAppLimit = 50
+
+// healthCheckQueries are the bounded count queries run for each window in deep mode. The
+// instance-wide counts are ungrouped so they cover every application; the per-application
+// queries are sorted so the limit keeps the busiest and noisiest applications.
+var healthCheckQueries = map[string]string{
+	"total":      "source logs | aggregate count() as count",
+	"errors":     "source logs | filter $m.severity >= ERROR | aggregate count() as count",
+	"critical":   "source logs | filter $m.severity == CRITICAL | aggregate count() as count",
+	"app_total":  fmt.Sprintf("source logs | groupby $l.applicationname aggregate count() as count | sortby -count | limit %d", healthAppLimit),
+	"app_errors": fmt.Sprintf("source logs | filter $m.severity >= ERROR | groupby $l.applicationname aggregate count() as count | sortby -count | limit %d", healthAppLimit),
+}
+
+// executeDeep runs the deep health check and returns a structured report
+func (t *HealthCheckTool) executeDeep(ctx context.Context, args map[string]interface{}, timeRange string, window time.Duration, endDate time.Time) (*mcp.CallToolResult, error) {
+	thresholds := HealthThresholds{WarningErrorRate: 5, CriticalErrorRate: 10, ErrorRateIncrease: 100}
+	if v, ok := args["warning_error_rate"].(float64); ok && v > 0 {
+		thresholds.WarningErrorRate = v
+	}
+	if v, ok := args["critical_error_rate"].(float64); ok && v > 0 {
+		thresholds.CriticalErrorRate = v
+	}
+	if v, ok := args["error_rate_increase"].(float64); ok && v > 0 {
+		thresholds.ErrorRateIncrease = v
+	}
+
+	current, previous, failures, err := t.queryHealthWindows(ctx, window, endDate)
+	if err != nil {
+		return NewToolResultErrorWithCode(ClassifyError(err), fmt.Sprintf("Deep health check failed: %s", strings.Join(failures, "; "))), nil
+	}
+
+	report := BuildDeepHealthReport(current, previous, thresholds)
+	report["time_range"] = timeRange
+	report["end_time"] = endDate.Format(time.RFC3339)
+	if len(failures) > 0 {
+		report["partial_failures"] = failures
+	}
+
+	if apiClient, err := t.GetClient(ctx); err == nil {
+		info := apiClient.GetInstanceInfo()
+		report["instance"] = map[string]interface{}{
+			"name":    info.InstanceName,
+			"region":  info.Region,
+			"service": info.ServiceURL,
+		}
+	}
+
+	output, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return NewToolResultError(fmt.Sprintf("Failed to format response: %v", err)), nil
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(output)},
+		},
+	}, nil
+}
+
+// healthQueryKinds are the count queries run per window, in request order
+var healthQueryKinds = []string{"total", "errors", "critical", "app_total", "app_errors"}
+
+// queryHealthWindows runs the bounded count queries for the current and previous windows
+// concurrently. A failure in the current window is returned as an error along with every failed
+// query; failures in the previous window only drop the comparison and are returned as notes.
+func (t *HealthCheckTool) queryHealthWindows(ctx context.Context, window time.Duration, endDate time.Time) (current, previous *healthWindowCounts, failures []string, err error) {
+	windows := []struct {
+		name       string
+		start, end time.Time
+	}{
+		{"current", endDate.Add(-window), endDate},
+		{"previous", endDate.Add(-2 * window), endDate.Add(-window)},
+	}
+
+	var reqs []*client.Request
+	for _, w := range windows {
+		for _, kind := range healthQueryKinds {
+			query, _, err := PrepareQuery(healthCheckQueries[kind], "archive", "dataprime")
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("invalid %s query: %w", kind, err)
+			}
+			reqs = append(reqs, &client.Request{
+				Method: "POST",
+				Path:   "/v1/query",
+				Body: map[string]interface{}{
+					"query":      query,
+					"tier":       "archive",
+					"syntax":     "dataprime",
+					"start_date": w.start.Format(time.RFC3339),
+					"end_date":   w.end.Format(time.RFC3339),
+				},
+			})
+		}
+	}
+
+	results := t.ExecuteConcurrent(ctx, reqs, len(reqs))
+	counts := make([]*healthWindowCounts, len(windows))
+	for wi, w := range windows {
+		counts[wi] = &healthWindowCounts{}
+		for ki, kind := range healthQueryKinds {
+			res := results[wi*len(healthQueryKinds)+ki]
+			if res.Err != nil {
+				failures = append(failures, fmt.Sprintf("%s window %s query: %v", w.name, kind, res.Err))
+				if wi == 0 && err == nil {
+					err = res.Err
+				}
+				continue
+			}
+			switch kind {
+			case "total":
+				counts[wi].Total = ungroupedCount(res.Result)
+			case "errors":
+				counts[wi].Errors = ungroupedCount(res.Result)
+			case "critical":
+				counts[wi].Critical = ungroupedCount(res.Result)
+			case "app_total":
+				counts[wi].AppTotal = countsByApplication(res.Result)
+			case "app_errors":
+				counts[wi].AppErrors = countsByApplication(res.Result)
+			}
+		}
+	}
+	if err != nil {
+		return nil, nil, failures, err
+	}
+	if len(failures) > 0 {
+		// An incomplete previous window would skew the comparison, so drop it entirely
+		counts[1] = &healthWindowCounts{}
+	}
+	return counts[0], counts[1], failures, nil
+}
+
+// countsByApplication extracts application -> count pairs from a grouped query result
+func countsByApplication(result map[string]interface{}) map[string]float64 {
+	return countsByLabel(result, "applicationname")
+}
+
+// ungroupedCount returns the count of an ungrouped count query result
+func ungroupedCount(result map[string]interface{}) float64 {
+	total := 0.0
+	events, _ := result["events"].([]interface{})
+	for _, event := range events {
+		if eventMap, ok := event.(map[string]interface{}); ok {
+			count, _ := eventMap["count"].(float64)
+			total += count
+		}
+	}
+	return total
+}
+
+// healthStatusRank orders statuses by severity
+var healthStatusRank = map[string]int{"healthy": 0, "warning": 1, "critical": 2}
+
+// classifyErrorRate determines a status from the current and previous error rates
+func classifyErrorRate(rate, previousRate float64, hasPrevious bool, th HealthThresholds) (string, string) {
+	switch {
+	case rate >= th.CriticalErrorRate:
+		return "critical", fmt.Sprintf("error rate %.2f%% is at or above the critical threshold (%.1f%%)", rate, th.CriticalErrorRate)
+	case rate >= th.WarningErrorRate:
+		return "warning", fmt.Sprintf("error rate %.2f%% is at or above the warning threshold (%.1f%%)", rate, th.WarningErrorRate)
+	case hasPrevious && previousRate > 0 && (rate-previousRate)/previousRate*100 >= th.ErrorRateIncrease:
+		return "warning", fmt.Sprintf("error rate rose from %.2f%% to %.2f%% versus the previous period", previousRate, rate)
+	case hasPrevious && previousRate == 0 && rate >= 1:
+		return "warning", fmt.Sprintf("error rate rose from 0%% to %.2f%% versus the previous period", rate)
+	default:
+		return "healthy", fmt.Sprintf("error rate %.2f%% is within thresholds", rate)
+	}
+}
+
+// BuildDeepHealthReport compares the current window against the previous one and
+// produces the overall status plus per-check results.
+func BuildDeepHealthReport(current, previous *healthWindowCounts, th HealthThresholds) map[string]interface{} {
+	rate := func(errors, total float64) float64 {
+		if total == 0 {
+			return 0
+		}
+		return errors * 100 / total
+	}
+
+	totalLogs, totalErrors, totalCritical := current.Total, current.Errors, current.Critical
+	prevLogs, prevErrors := previous.Total, previous.Errors
+	errorRate, prevErrorRate := rate(totalErrors, totalLogs), rate(prevErrors, prevLogs)
+
+	overall := "healthy"
+	checks := []map[string]interface{}{}
+	addCheck := func(check map[string]interface{}) {
+		checks = append(checks, check)
+		if status, _ := check["status"].(string); healthStatusRank[status] > healthStatusRank[overall] {
+			overall = status
+		}
+	}
+
+	// Ingestion check
+	switch {
+	case totalLogs == 0 && prevLogs > 0:
+		addCheck(map[string]interface{}{"name": "ingestion", "status": "critical", "detail": "no logs received in the current period but logs were received in the previous period"})
+	case totalLogs == 0:
+		addCheck(map[string]interface{}{"name": "ingestion", "status": "warning", "detail": "no logs received in the current or previous period"})
+	default:
+		addCheck(map[string]interface{}{"name": "ingestion", "status": "healthy", "detail": fmt.Sprintf("%.0f logs received (previous period: %.0f)", totalLogs, prevLogs)})
+	}
+
+	// Overall error rate check
+	status, detail := classifyErrorRate(errorRate, prevErrorRate, prevLogs > 0, th)
+	addCheck(map[string]interface{}{
+		"name":                "error_rate",
+		"status":              status,
+		"detail":              detail,
+		"error_rate":          errorRate,
+		"previous_error_rate": prevErrorRate,
+	})
+
+	// Critical logs check
+	criticalStatus := "healthy"
+	if totalCritical > 0 {
+		criticalStatus = "warning"
+	}
+	addCheck(map[string]interface{}{
+		"name":     "critical_logs",
+		"status":   criticalStatus,
+		"detail":   fmt.Sprintf("%.0f critical logs (previous period: %.0f)", totalCritical, previous.Critical),
+		"critical": totalCritical,
+	})
+
+	// Per-application error breakdown, noisiest first. The per-application queries are
+	// limited, so only applications in both the busiest and the noisiest sets have a
+	// reliable error rate.
+	apps := make([]string, 0, len(current.AppErrors))
+	for app := range current.AppErrors {
+		if _, ok := current.AppTotal[app]; ok {
+			apps = append(apps, app)
+		}
+	}
+	sort.Slice(apps, func(i, j int) bool {
+		if current.AppErrors[apps[i]] != current.AppErrors[apps[j]] {
+			return current.AppErrors[apps[i]] > current.AppErrors[apps[j]]
+		}
+		return apps[i] < apps[j]
+	})
+	for _, app := range apps {
+		appRate := rate(current.AppErrors[app], current.AppTotal[app])
+		_, hasPrevious := previous.AppTotal[app]
+		appPrevRate := 0.0
+		if _, ok := previous.AppErrors[app]; ok && hasPrevious {
+			appPrevRate = rate(previous.AppErrors[app], previous.AppTotal[app])
+		}
+		appStatus, appDetail := classifyErrorRate(appRate, appPrevRate, hasPrevious, th)
+		addCheck(map[string]interface{}{
+			"name":                "application:" + app,
+			"status":              appStatus,
+			"detail":              appDetail,
+			"total":               current.AppTotal[app],
+			"errors":              current.AppErrors[app],
+			"error_rate":          appRate,
+			"previous_error_rate": appPrevRate,
+		})
+	}
+
+	return map[string]interface{}{
+		"overall_status": overall,
+		"total_logs":     totalLogs,
+		"total_errors":   totalErrors,
+		"total_critical": totalCritical,
+		"error_rate":     errorRate,
+		"previous_period": map[string]interface{}{
+			"total_logs":   prevLogs,
+			"total_errors": prevErrors,
+			"error_rate":   prevErrorRate,
+		},
+		"thresholds": map[string]interface{}{
+			"warning_error_rate":  th.WarningErrorRate,
+			"critical_error_rate": th.CriticalErrorRate,
+			"error_rate_increase": th.ErrorRateIncrease,
+		},
+		"checks":            checks,
+		"applications_note": fmt.Sprintf("Per-application checks cover applications among both the %d busiest and the %d with the most errors", healthAppLimit, healthAppLimit),
+	}
+}
+
 // ValidateQueryTool validates a query without executing it
 type ValidateQueryTool struct {
 	*BaseTool
@@ -842,7 +1258,7 @@ func (t *ValidateQueryTool) Metadata() *ToolMetadata {
 func (t *ValidateQueryTool) Execute(_ context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	query, err := GetStringParam(args, "query", true)
 	if err != nil {
-		return NewToolResultError(err.Error()), nil
+		return NewToolResultErrorFromErr(err), nil
 	}
 
 	validation := validateDataPrimeQuery(query)
@@ -12,6 +12,8 @@
 //   - LOGS_REGION: IBM Cloud region (optional - auto-extracted from service URL)
 //   - LOGS_INSTANCE_NAME: (Optional) Friendly name for the instance
 //   - ENVIRONMENT: (Optional) Set to "production" for production logging
+//   - LOG_LEVEL: (Optional) debug, info, warn, or error; overrides the ENVIRONMENT preset level
+//   - LOG_FORMAT: (Optional) json or console; overrides the ENVIRONMENT preset encoding
 //
 // Example usage:
 //
@@ -32,8 +34,10 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/tareqmamari/cloud-logs-mcp/internal/config"
+	"github.com/tareqmamari/cloud-logs-mcp/internal/logging"
 	"github.com/tareqmamari/cloud-logs-mcp/internal/server"
 	"github.com/tareqmamari/cloud-logs-mcp/internal/skills"
+	"github.com/tareqmamari/cloud-logs-mcp/internal/tools"
 )
 
 // Build information - set at build time via ldflags
@@ -72,8 +76,9 @@ func main() {
 	// Load .env file if it exists (optional, for development)
 	_ = godotenv.Load()
 
-	// Initialize logger
-	logger, err := initLogger()
+	// Initialize logger from the environment preset; it is rebuilt once the configuration
+	// (which may set log_level and log_format) is loaded
+	logger, err := logging.New(os.Getenv("ENVIRONMENT"), "", "")
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
 		os.Exit(1)
@@ -93,6 +98,15 @@ func main() {
 		logger.Fatal("Invalid configuration", zap.Error(err))
 	}
 
+	if cfg.LogLevel != "" || cfg.LogFormat != "" {
+		configured, err := logging.New(os.Getenv("ENVIRONMENT"), cfg.LogLevel, cfg.LogFormat)
+		if err != nil {
+			logger.Fatal("Failed to configure logger", zap.Error(err))
+		}
+		_ = logger.Sync()
+		logger = configured
+	}
+
 	logFields := []zap.Field{
 		zap.String("version", version),
 		zap.String("commit", commit),
@@ -104,6 +118,8 @@ func main() {
 	}
 	logger.Info("Starting IBM Cloud Logs MCP Server", logFields...)
 
+	tools.SetBuildInfo(version, commit, builtBy)
+
 	// Create and start MCP server
 	mcpServer, err := server.New(cfg, logger, version)
 	if err != nil {
@@ -255,14 +271,3 @@ Examples:
   logs-mcp-server skills install --project
   logs-mcp-server skills remove`)
 }
-
-// initLogger initializes and returns a zap logger.
-// It creates a production logger if ENVIRONMENT=production, otherwise returns
-// a development logger with more verbose output.
-func initLogger() (*zap.Logger, error) {
-	env := os.Getenv("ENVIRONMENT")
-	if env == "production" {
-		return zap.NewProduction()
-	}
-	return zap.NewDevelopment()
-}